@@ -0,0 +1,6 @@
+// Package warehousev1 contains the gRPC contract of the warehouse service.
+//
+// Code is generated from warehouse.proto with buf, protoc-gen-go and protoc-gen-go-grpc.
+package warehousev1
+
+//go:generate buf generate --template ../../buf.gen.yaml --output ../.. ../..
@@ -0,0 +1,109 @@
+// Command migrate applies the postgres schema migrations of the service.
+//
+// Usage:
+//
+//	migrate [-dsn DSN] up|down|status|to VERSION
+//
+// If -dsn is not set, the first of pg_dsn_rw, pg_dsn_rw_1..pg_dsn_rw_9
+// environment variables is used.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/migrate"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/migrations"
+)
+
+const applicationName = "warehouse-migrate"
+
+var errUsage = errors.New("usage: migrate [-dsn DSN] [-timeout DURATION] up|down|status|to VERSION")
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	dsn := flag.String("dsn", "", "postgres DSN of the write source")
+	timeout := flag.Duration("timeout", 10*time.Minute, "timeout of the whole run")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		return errUsage
+	}
+
+	if *dsn == "" {
+		if sources := db.NewConfigMultiFromEnv().Sources; len(sources) > 0 {
+			*dsn = sources[0]
+		}
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	ctx, cancel = context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	defer log.Sync()
+
+	inst, err := db.NewInstance(*dsn, db.WithApplicationName(applicationName))
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+
+	defer inst.Close()
+
+	m, err := migrate.New(inst.WriteSQL(), migrations.FS)
+	if err != nil {
+		return fmt.Errorf("load migrations: %w", err)
+	}
+
+	switch flag.Arg(0) {
+	case "up":
+		return m.Up(ctx)
+	case "down":
+		return m.Down(ctx)
+	case "to":
+		version, err := strconv.ParseUint(flag.Arg(1), 10, 64)
+		if err != nil {
+			return errUsage
+		}
+
+		return m.To(ctx, version)
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.Applied {
+				appliedAt = s.AppliedAt.Format(time.RFC3339)
+			}
+
+			fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+
+		return w.Flush()
+	default:
+		return errUsage
+	}
+}
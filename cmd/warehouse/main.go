@@ -0,0 +1,287 @@
+// Command warehouse runs the warehouse service: REST and gRPC APIs over postgres.
+//
+// Usage:
+//
+//	warehouse [-config PATH]
+//
+// Configuration is read from the YAML file (see config.example.yaml) and
+// overridden by environment variables, see internal/config.
+//
+// On SIGINT/SIGTERM the service stops accepting requests, drains the in-flight
+// ones, flushes Sentry events and traces, closes the database pools and flushes logs last.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	googleGRPC "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/config"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/application"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/auth"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/recovery"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/ioc"
+	"github.com/smgladkovskiy/warehouse-task/internal/transport/grpc"
+	"github.com/smgladkovskiy/warehouse-task/internal/transport/rest"
+)
+
+const (
+	applicationName = "warehouse"
+
+	readHeaderTimeout  = 10 * time.Second
+	sentryFlushTimeout = 5 * time.Second
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	configPath := flag.String("config", "", "YAML config file, environment variables take precedence")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath, config.EnvLookup)
+	if err != nil {
+		return err
+	}
+
+	logger := log.GetLogger(cfg.Log.Logger())
+
+	if cfg.Mail.Memory {
+		logger.Warn(context.Background(), "mail.memory is enabled: emails are not sent and are kept in process memory")
+	}
+
+	sentryClient, err := newSentryClient(cfg.Sentry)
+	if err != nil {
+		return err
+	}
+
+	// до запуска приложения хуки остановки не выполняются: при выходе с ошибкой события Sentry
+	// и трейсы сбрасываются здесь, после запуска за них отвечает приложение
+	appOwned := false
+
+	defer func() {
+		if !appOwned {
+			_ = flushSentry(sentryClient)
+		}
+	}()
+
+	tracerProvider, err := cfg.Tracing.TracerProvider(context.Background(), applicationName)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if !appOwned {
+			_ = tracerProvider.Shutdown(context.Background())
+		}
+	}()
+
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	inst, err := db.NewInstance("",
+		db.WithConfig(db.Config{Multi: cfg.DB.Multi()}),
+		db.WithApplicationName(applicationName),
+		db.WithTracerProvider(tracerProvider),
+		db.WithMaxTracingQuerySize(cfg.DB.MaxTracingQuerySize),
+	)
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+
+	// хуки остановки выполняются в обратном порядке: транспорты, Sentry, трейсы, база данных, логи
+	app := application.New(
+		application.WithLogger(logger.Named("app")),
+		application.WithShutdownTimeout(cfg.ShutdownTimeout),
+		application.WithDB(inst),
+	)
+
+	app.OnStop("tracing", tracerProvider.Shutdown)
+	app.OnStop("sentry", func(context.Context) error {
+		return flushSentry(sentryClient)
+	})
+
+	issuer, err := cfg.Auth.Issuer()
+	if err != nil {
+		return errors.Join(fmt.Errorf("token issuer: %w", err), inst.Close())
+	}
+
+	hasher, err := cfg.Password.Hasher()
+	if err != nil {
+		return errors.Join(fmt.Errorf("password hasher: %w", err), inst.Close())
+	}
+
+	allocationPolicy, err := cfg.Rules.Allocation()
+	if err != nil {
+		return errors.Join(fmt.Errorf("allocation policy: %w", err), inst.Close())
+	}
+
+	container, err := ioc.NewContainer(
+		ioc.NewImplementations(app),
+		ioc.WithTokenIssuer(issuer),
+		ioc.WithPasswordHasher(hasher),
+		ioc.WithMailer(cfg.Mail.Mailer()),
+		ioc.WithRules(cfg.Rules.ValueObject()),
+		ioc.WithAllocationPolicy(allocationPolicy),
+		ioc.WithEmailVerificationURL(cfg.Mail.EmailVerificationURL),
+		ioc.WithPasswordResetURL(cfg.Mail.PasswordResetURL),
+	)
+	if err != nil {
+		return errors.Join(fmt.Errorf("build container: %w", err), inst.Close())
+	}
+
+	// recoverer стоит дважды: внешний перехватывает паники и в аутентификации, внутренний видит
+	// пользователя из токена и спан запроса и описывает ими паники обработчиков
+	recoverer := recovery.New(sentryClient, logger.Named("recovery"), recovery.WithUserID(auth.UserID))
+
+	serveHTTP(app, cfg.HTTP.Addr, recoverer.Middleware(
+		rest.NewHandler(container, logger.Named("rest"), rest.WithMiddleware(recoverer.Middleware)),
+	))
+
+	if err = serveGRPC(app, cfg.GRPC.Addr, recoverer, grpc.NewServer(container, logger.Named("grpc"))); err != nil {
+		return errors.Join(err, inst.Close())
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	appOwned = true
+
+	return app.Run(ctx)
+}
+
+// flushSentry отправляет накопленные события Sentry. Без клиента ничего не делает.
+func flushSentry(client *sentry.Client) error {
+	if client != nil && !client.Flush(sentryFlushTimeout) {
+		return errors.New("sentry flush timeout")
+	}
+
+	return nil
+}
+
+// newSentryClient создаёт клиент Sentry и подключает его к логгеру. Без SENTRY_DSN возвращает nil.
+func newSentryClient(cfg config.Sentry) (*sentry.Client, error) {
+	if cfg.DSN == "" {
+		return nil, nil
+	}
+
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:              cfg.DSN,
+		Environment:      cfg.Environment,
+		AttachStacktrace: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sentry: %w", err)
+	}
+
+	if err = log.SetSentry(client); err != nil {
+		return nil, fmt.Errorf("sentry logger: %w", err)
+	}
+
+	return client, nil
+}
+
+// serveHTTP регистрирует HTTP-сервер. Shutdown дожидается завершения обрабатываемых запросов.
+func serveHTTP(app *application.App, addr string, handler http.Handler) {
+	if addr == "" {
+		return
+	}
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	app.Go("http", func(context.Context) error {
+		if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+
+		return nil
+	})
+	app.OnStop("http", server.Shutdown)
+}
+
+// serveGRPC регистрирует gRPC-сервер. Паники перехватываются recoverer-ом снаружи и внутри
+// проверки токена доступа, как и в REST.
+// GracefulStop дожидается завершения обрабатываемых вызовов, по таймауту соединения закрываются принудительно.
+func serveGRPC(app *application.App, addr string, recoverer *recovery.Recoverer, service *grpc.Server) error {
+	if addr == "" {
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("grpc listen: %w", err)
+	}
+
+	server := googleGRPC.NewServer(googleGRPC.ChainUnaryInterceptor(
+		recoverInterceptor(recoverer),
+		service.UnaryAuthInterceptor(),
+		recoverInterceptor(recoverer),
+	))
+
+	service.Register(server)
+
+	app.Go("grpc", func(context.Context) error {
+		return server.Serve(listener)
+	})
+	app.OnStop("grpc", func(ctx context.Context) error {
+		stopped := make(chan struct{})
+
+		go func() {
+			server.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+			return nil
+		case <-ctx.Done():
+			server.Stop()
+
+			return ctx.Err()
+		}
+	})
+
+	return nil
+}
+
+// recoverInterceptor перехватывает панику вызова и отвечает клиенту codes.Internal.
+func recoverInterceptor(recoverer *recovery.Recoverer) googleGRPC.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *googleGRPC.UnaryServerInfo, handler googleGRPC.UnaryHandler) (any, error) {
+		var resp any
+
+		err := recoverer.Do(ctx, info.FullMethod, func(ctx context.Context) (err error) {
+			resp, err = handler(ctx, req)
+
+			return err
+		})
+		if errors.Is(err, recovery.ErrPanic) {
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+
+		return resp, err
+	}
+}
@@ -0,0 +1,499 @@
+/*
+Package config загружает конфигурацию сервиса: значения по умолчанию, затем YAML-файл,
+затем переопределения из переменных окружения. Итоговая конфигурация проверяется при старте.
+*/
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	netMail "net/mail"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/yaml.v3"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/application"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/auth"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/mail"
+	passcrypto "github.com/smgladkovskiy/warehouse-task/internal/pkg/pass_crypto"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+const (
+	// maxPasswordLength ограничение bcrypt: длиннее 72 байт пароль не хешируется (argon2id длину не ограничивает).
+	maxPasswordLength = 72
+	maxAge            = 150
+	maxPerPage        = 1000
+	defaultSMTPPort   = 587
+)
+
+var ErrInvalid = errors.New("invalid config")
+
+type Config struct {
+	HTTP            Listener      `yaml:"http"`
+	GRPC            Listener      `yaml:"grpc"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+
+	Log      Log      `yaml:"log"`
+	DB       DB       `yaml:"db"`
+	Sentry   Sentry   `yaml:"sentry"`
+	Tracing  Tracing  `yaml:"tracing"`
+	Auth     Auth     `yaml:"auth"`
+	Password Password `yaml:"password"`
+	Mail     Mail     `yaml:"mail"`
+	Rules    Rules    `yaml:"rules"`
+}
+
+// Listener адрес транспорта. Пустой адрес отключает транспорт.
+type Listener struct {
+	Addr string `yaml:"addr"`
+}
+
+type Log struct {
+	// Level DEBUG, INFO, WARN, ERROR, PANIC.
+	Level string `yaml:"level"`
+	// Encoding console или json.
+	Encoding string `yaml:"encoding"`
+}
+
+type DB struct {
+	Sources             []string `yaml:"sources"`
+	ReplicasSync        []string `yaml:"replicas_sync"`
+	ReplicasAsync       []string `yaml:"replicas_async"`
+	MaxTracingQuerySize int      `yaml:"max_tracing_query_size"`
+}
+
+type Sentry struct {
+	DSN         string `yaml:"dsn"`
+	Environment string `yaml:"environment"`
+}
+
+// Tracing экспорт трейсов в коллектор по OTLP/HTTP. Без otlp_endpoint спаны не экспортируются.
+type Tracing struct {
+	// OTLPEndpoint адрес коллектора, например http://otel-collector:4318. Схема http отключает TLS.
+	OTLPEndpoint string `yaml:"otlp_endpoint"`
+	// SampleRatio доля записываемых трейсов в [0, 1]. Решение родительского спана из запроса соблюдается.
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// Auth подпись и время жизни токенов сессии.
+type Auth struct {
+	// TokenSecret ключ подписи токенов, общий для всех экземпляров сервиса.
+	TokenSecret     string        `yaml:"token_secret"`
+	AccessTokenTTL  time.Duration `yaml:"access_token_ttl"`
+	RefreshTokenTTL time.Duration `yaml:"refresh_token_ttl"`
+}
+
+// Password хеширование паролей. Смена алгоритма или параметров не ломает вход: хеш пароля
+// пересчитывается с новыми настройками при следующем успешном входе пользователя.
+type Password struct {
+	// Algorithm argon2id или bcrypt.
+	Algorithm  string   `yaml:"algorithm"`
+	BcryptCost int      `yaml:"bcrypt_cost"`
+	Argon2id   Argon2id `yaml:"argon2id"`
+}
+
+// Argon2id параметры argon2id, Memory в КиБ.
+type Argon2id struct {
+	Memory      int `yaml:"memory"`
+	Iterations  int `yaml:"iterations"`
+	Parallelism int `yaml:"parallelism"`
+}
+
+// Mail отправка писем с токенами подтверждения email и сброса пароля. Нужен smtp.host либо явно
+// включённый режим memory для локального запуска и тестов: письма не уходят, а складываются в память процесса.
+type Mail struct {
+	// Memory хранить последние письма в памяти процесса вместо отправки, несовместим с smtp.host.
+	Memory bool `yaml:"memory"`
+	SMTP   SMTP `yaml:"smtp"`
+	// EmailVerificationURL и PasswordResetURL адреса страниц, к которым в письме дописывается токен,
+	// например https://shop.example.com/verify-email?token=. Без адреса письмо содержит только токен.
+	EmailVerificationURL string `yaml:"email_verification_url"`
+	PasswordResetURL     string `yaml:"password_reset_url"`
+}
+
+type SMTP struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+}
+
+// Rules бизнес-правила инсталляции, см. valueobjects.Rules.
+type Rules struct {
+	MinAge            int           `yaml:"min_age"`
+	MinPasswordLength int           `yaml:"min_password_length"`
+	DefaultPerPage    int           `yaml:"default_per_page"`
+	MaxFailedLogins   int           `yaml:"max_failed_logins"`
+	LoginLockout      time.Duration `yaml:"login_lockout"`
+
+	EmailVerificationTTL time.Duration `yaml:"email_verification_ttl"`
+	PasswordResetTTL     time.Duration `yaml:"password_reset_ttl"`
+
+	OrderPricePolicy string `yaml:"order_price_policy"`
+
+	// AllocationPolicy split, single_warehouse_first, priority или least_fragmentation, см. entities.NewAllocationPolicy.
+	AllocationPolicy string `yaml:"allocation_policy"`
+	// AllocationPriority идентификаторы складов в порядке приоритета для политики priority.
+	AllocationPriority []string `yaml:"allocation_priority"`
+}
+
+// Default конфигурация по умолчанию. DSN базы данных и ключ подписи токенов по умолчанию не заданы.
+func Default() Config {
+	rules := vObject.DefaultRules()
+
+	return Config{
+		HTTP:            Listener{Addr: ":8080"},
+		GRPC:            Listener{Addr: ":9090"},
+		ShutdownTimeout: application.DefaultShutdownTimeout,
+		Log:             Log{Level: "INFO", Encoding: "console"},
+		DB:              DB{MaxTracingQuerySize: db.DefaultMaxTracingQuerySize},
+		Tracing:         Tracing{SampleRatio: 1},
+		Auth: Auth{
+			AccessTokenTTL:  auth.DefaultAccessTokenTTL,
+			RefreshTokenTTL: auth.DefaultRefreshTokenTTL,
+		},
+		Password: Password{
+			Algorithm:  string(passcrypto.AlgorithmArgon2id),
+			BcryptCost: passcrypto.DefaultBcryptCost,
+			Argon2id: Argon2id{
+				Memory:      int(passcrypto.DefaultArgon2idParams.Memory),
+				Iterations:  int(passcrypto.DefaultArgon2idParams.Iterations),
+				Parallelism: int(passcrypto.DefaultArgon2idParams.Parallelism),
+			},
+		},
+		Mail: Mail{
+			SMTP: SMTP{Port: defaultSMTPPort},
+		},
+		Rules: Rules{
+			MinAge:               rules.MinAge,
+			MinPasswordLength:    rules.MinPasswordLength,
+			DefaultPerPage:       rules.DefaultPerPage,
+			MaxFailedLogins:      rules.MaxFailedLogins,
+			LoginLockout:         rules.LoginLockout,
+			EmailVerificationTTL: rules.EmailVerificationTTL,
+			PasswordResetTTL:     rules.PasswordResetTTL,
+			OrderPricePolicy:     rules.OrderPricePolicy.String(),
+			AllocationPolicy:     entities.AllocationPolicySplit,
+		},
+	}
+}
+
+// Load читает YAML-файл path (если задан) поверх значений по умолчанию, применяет переопределения
+// из lookup (см. EnvLookup) и проверяет результат.
+func Load(path string, lookup func(key string) (string, bool)) (Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		if err := cfg.readFile(path); err != nil {
+			return cfg, err
+		}
+	}
+
+	if err := cfg.applyEnv(lookup); err != nil {
+		return cfg, err
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// EnvLookup ищет переменную окружения в верхнем, затем в исходном регистре.
+func EnvLookup(key string) (string, bool) {
+	if v, ok := os.LookupEnv(strings.ToUpper(key)); ok {
+		return v, true
+	}
+
+	return os.LookupEnv(key)
+}
+
+func (c *Config) readFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("[config - read file]: %w", err)
+	}
+
+	defer f.Close()
+
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+
+	if err = decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("[config - decode %s]: %w", path, err)
+	}
+
+	return nil
+}
+
+// Validate проверяет обязательные значения и допустимые диапазоны.
+func (c Config) Validate() error {
+	var errs []error
+
+	invalid := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%w: "+format, append([]any{ErrInvalid}, args...)...))
+	}
+
+	if len(c.DB.Sources) == 0 {
+		invalid("db.sources: at least one write DSN is required (PG_DSN_RW)")
+	}
+
+	for _, group := range []struct {
+		name string
+		dsns []string
+	}{
+		{name: "db.sources", dsns: c.DB.Sources},
+		{name: "db.replicas_sync", dsns: c.DB.ReplicasSync},
+		{name: "db.replicas_async", dsns: c.DB.ReplicasAsync},
+	} {
+		for i, dsn := range group.dsns {
+			// текст ошибки разбора не выводится: он может содержать пароль
+			if _, err := pgx.ParseConfig(dsn); err != nil {
+				invalid("%s[%d] is not a valid postgres DSN", group.name, i)
+			}
+		}
+	}
+
+	if c.DB.MaxTracingQuerySize < 0 {
+		invalid("db.max_tracing_query_size must not be negative")
+	}
+
+	if u, err := url.Parse(c.Tracing.OTLPEndpoint); c.Tracing.OTLPEndpoint != "" && (err != nil || u.Host == "" ||
+		(u.Scheme != "http" && u.Scheme != "https")) {
+		invalid("tracing.otlp_endpoint must be an absolute http(s) URL")
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		invalid("tracing.sample_ratio must be in [0, 1]")
+	}
+
+	if c.HTTP.Addr == "" && c.GRPC.Addr == "" {
+		invalid("at least one of http.addr and grpc.addr must be set")
+	}
+
+	for _, l := range []struct{ name, addr string }{
+		{name: "http.addr", addr: c.HTTP.Addr},
+		{name: "grpc.addr", addr: c.GRPC.Addr},
+	} {
+		if _, _, err := net.SplitHostPort(l.addr); l.addr != "" && err != nil {
+			invalid("%s %q is not a host:port address", l.name, l.addr)
+		}
+	}
+
+	if c.ShutdownTimeout <= 0 {
+		invalid("shutdown_timeout must be positive")
+	}
+
+	if err := new(zapcore.Level).UnmarshalText([]byte(c.Log.Level)); err != nil {
+		invalid("log.level %q is unknown", c.Log.Level)
+	}
+
+	if c.Log.Encoding != "console" && c.Log.Encoding != "json" {
+		invalid("log.encoding must be console or json")
+	}
+
+	if len(c.Auth.TokenSecret) < auth.MinSecretLength {
+		invalid("auth.token_secret must be at least %d bytes (AUTH_TOKEN_SECRET)", auth.MinSecretLength)
+	}
+
+	if c.Auth.AccessTokenTTL <= 0 || c.Auth.RefreshTokenTTL <= 0 {
+		invalid("auth.access_token_ttl and auth.refresh_token_ttl must be positive")
+	} else if c.Auth.AccessTokenTTL > c.Auth.RefreshTokenTTL {
+		invalid("auth.access_token_ttl must not exceed auth.refresh_token_ttl")
+	}
+
+	if _, err := c.Password.Hasher(); err != nil {
+		invalid("password: %s", err.Error())
+	}
+
+	switch {
+	case c.Mail.Memory && c.Mail.SMTP.Host != "":
+		invalid("mail.memory and mail.smtp.host are mutually exclusive (MAIL_MEMORY, MAIL_SMTP_HOST)")
+	case !c.Mail.Memory && c.Mail.SMTP.Host == "":
+		invalid("mail.smtp.host is required, set mail.memory to keep mail in memory locally (MAIL_SMTP_HOST, MAIL_MEMORY)")
+	case c.Mail.SMTP.Host != "":
+		if c.Mail.SMTP.Port < 1 || c.Mail.SMTP.Port > math.MaxUint16 {
+			invalid("mail.smtp.port must be in [1, %d]", math.MaxUint16)
+		}
+
+		if _, err := netMail.ParseAddress(c.Mail.SMTP.From); err != nil {
+			invalid("mail.smtp.from must be an email address (MAIL_SMTP_FROM)")
+		}
+	}
+
+	for _, link := range []struct{ name, value string }{
+		{name: "mail.email_verification_url", value: c.Mail.EmailVerificationURL},
+		{name: "mail.password_reset_url", value: c.Mail.PasswordResetURL},
+	} {
+		if u, err := url.Parse(link.value); link.value != "" && (err != nil || u.Host == "" ||
+			(u.Scheme != "http" && u.Scheme != "https")) {
+			invalid("%s must be an absolute http(s) URL", link.name)
+		}
+	}
+
+	if c.Rules.MinAge < 0 || c.Rules.MinAge > maxAge {
+		invalid("rules.min_age must be in [0, %d]", maxAge)
+	}
+
+	if c.Rules.MinPasswordLength < 1 || c.Rules.MinPasswordLength > maxPasswordLength {
+		invalid("rules.min_password_length must be in [1, %d]", maxPasswordLength)
+	}
+
+	if c.Rules.DefaultPerPage < 1 || c.Rules.DefaultPerPage > maxPerPage {
+		invalid("rules.default_per_page must be in [1, %d]", maxPerPage)
+	}
+
+	if c.Rules.MaxFailedLogins < 1 {
+		invalid("rules.max_failed_logins must be positive")
+	}
+
+	if c.Rules.LoginLockout <= 0 {
+		invalid("rules.login_lockout must be positive")
+	}
+
+	if c.Rules.EmailVerificationTTL <= 0 || c.Rules.PasswordResetTTL <= 0 {
+		invalid("rules.email_verification_ttl and rules.password_reset_ttl must be positive")
+	}
+
+	if _, err := vObject.NewOrderPricePolicy(c.Rules.OrderPricePolicy); err != nil {
+		invalid("rules.order_price_policy must be snapshot or reprice")
+	}
+
+	if _, err := c.Rules.Allocation(); err != nil {
+		invalid("rules.allocation_policy: %s", err.Error())
+	}
+
+	return errors.Join(errs...)
+}
+
+// Logger конфигурация логгера.
+func (l Log) Logger() log.ConfigLogger {
+	return log.ConfigLogger{
+		Level:        l.Level,
+		EncodingType: l.Encoding,
+	}
+}
+
+// Multi источники и реплики базы данных.
+func (d DB) Multi() db.ConfigMulti {
+	return db.ConfigMulti{
+		Sources:       d.Sources,
+		ReplicasSync:  d.ReplicasSync,
+		ReplicasAsync: d.ReplicasAsync,
+	}
+}
+
+// TracerProvider провайдер трейсов сервиса serviceName. Без otlp_endpoint спаны создаются
+// (их идентификаторы попадают в логи и события Sentry), но никуда не отправляются.
+func (t Tracing) TracerProvider(ctx context.Context, serviceName string) (*sdktrace.TracerProvider, error) {
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(t.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+	}
+
+	if t.OTLPEndpoint != "" {
+		exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(t.OTLPEndpoint))
+		if err != nil {
+			return nil, fmt.Errorf("[config - otlp exporter]: %w", err)
+		}
+
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	return sdktrace.NewTracerProvider(opts...), nil
+}
+
+// Issuer выпуск и проверка токенов сессии.
+func (a Auth) Issuer() (*auth.Issuer, error) {
+	return auth.NewIssuer(
+		[]byte(a.TokenSecret),
+		auth.WithAccessTokenTTL(a.AccessTokenTTL),
+		auth.WithRefreshTokenTTL(a.RefreshTokenTTL),
+	)
+}
+
+// Hasher хеширование паролей с заданными алгоритмом и параметрами.
+func (p Password) Hasher() (*passcrypto.Hasher, error) {
+	a := p.Argon2id
+	if a.Memory < 0 || a.Iterations < 0 || a.Parallelism < 0 || a.Parallelism > math.MaxUint8 {
+		return nil, fmt.Errorf("%w: argon2id params out of range", passcrypto.ErrInvalidParams)
+	}
+
+	params := passcrypto.DefaultArgon2idParams
+	params.Memory = uint32(a.Memory)
+	params.Iterations = uint32(a.Iterations)
+	params.Parallelism = uint8(a.Parallelism)
+
+	return passcrypto.NewHasher(
+		passcrypto.WithAlgorithm(passcrypto.Algorithm(p.Algorithm)),
+		passcrypto.WithBcryptCost(p.BcryptCost),
+		passcrypto.WithArgon2idParams(params),
+	)
+}
+
+// Mailer отправка писем: через SMTP-сервер либо в память процесса в режиме memory.
+func (m Mail) Mailer() mail.Mailer {
+	if m.Memory || m.SMTP.Host == "" {
+		return mail.NewMemory()
+	}
+
+	return mail.NewSMTP(mail.ConfigSMTP{
+		Host:     m.SMTP.Host,
+		Port:     m.SMTP.Port,
+		Username: m.SMTP.Username,
+		Password: m.SMTP.Password,
+		From:     m.SMTP.From,
+	})
+}
+
+// ValueObject правила в виде, который контейнер передаёт юзкейсам, см. ioc.WithRules.
+func (r Rules) ValueObject() vObject.Rules {
+	return vObject.Rules{
+		MinAge:            r.MinAge,
+		MinPasswordLength: r.MinPasswordLength,
+		DefaultPerPage:    r.DefaultPerPage,
+		MaxFailedLogins:   r.MaxFailedLogins,
+		LoginLockout:      r.LoginLockout,
+
+		EmailVerificationTTL: r.EmailVerificationTTL,
+		PasswordResetTTL:     r.PasswordResetTTL,
+
+		OrderPricePolicy: vObject.OrderPricePolicy(r.OrderPricePolicy),
+	}
+}
+
+// Allocation политика распределения товара заказа по складам.
+func (r Rules) Allocation() (entities.AllocationPolicy, error) {
+	if len(r.AllocationPriority) > 0 && r.AllocationPolicy != entities.AllocationPolicyPriority {
+		return nil, errors.New("allocation_priority is only used by the priority policy")
+	}
+
+	priority := make([]vObject.WarehouseID, 0, len(r.AllocationPriority))
+
+	for i, raw := range r.AllocationPriority {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("allocation_priority[%d] is not a warehouse id", i)
+		}
+
+		priority = append(priority, vObject.NewWarehouseIDFromUUIDUnsafe(id))
+	}
+
+	return entities.NewAllocationPolicy(r.AllocationPolicy, priority...)
+}
@@ -0,0 +1,313 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/config"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/mail"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+const (
+	dsnRW       = "postgres://user:pass@rw:5432/db"
+	tokenSecret = "0123456789abcdef0123456789abcdef"
+)
+
+func mapLookup(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := values[key]
+
+		return v, ok
+	}
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.Load("", mapLookup(map[string]string{
+		"pg_dsn_rw": dsnRW, "AUTH_TOKEN_SECRET": tokenSecret, "MAIL_MEMORY": "true",
+	}))
+	require.NoError(t, err)
+
+	exp := config.Default()
+	exp.DB.Sources = []string{dsnRW}
+	exp.Auth.TokenSecret = tokenSecret
+	exp.Mail.Memory = true
+
+	assert.Equal(t, exp, cfg)
+	assert.Equal(t, vObject.DefaultRules(), cfg.Rules.ValueObject())
+
+	allocation, err := cfg.Rules.Allocation()
+	require.NoError(t, err)
+	assert.Equal(t, entities.SplitAllocationPolicy{}, allocation)
+}
+
+func TestLoad_FileAndEnv(t *testing.T) {
+	t.Parallel()
+
+	path := writeFile(t, `
+http:
+  addr: ":8000"
+grpc:
+  addr: ""
+shutdown_timeout: 10s
+log:
+  level: WARN
+  encoding: json
+db:
+  sources: ["postgres://file:5432/db"]
+  replicas_sync: ["postgres://sync:5432/db"]
+auth:
+  access_token_ttl: 5m
+password:
+  algorithm: bcrypt
+mail:
+  smtp:
+    host: smtp.local
+    username: shop
+    from: Магазин <noreply@shop.local>
+  password_reset_url: https://shop.local/reset?token=
+rules:
+  min_age: 21
+  min_password_length: 12
+  login_lockout: 1h
+  password_reset_ttl: 30m
+  order_price_policy: reprice
+  allocation_policy: priority
+  allocation_priority: ["0192d0c4-5c3a-7b6e-9f10-2a3b4c5d6e7f"]
+`)
+
+	cfg, err := config.Load(path, mapLookup(map[string]string{
+		"pg_dsn_rw":                    dsnRW,
+		"SHUTDOWN_TIMEOUT":             "5s",
+		"RULES_DEFAULT_PER_PAGE":       "50",
+		"RULES_MAX_FAILED_LOGINS":      "3",
+		"SENTRY_DSN":                   "https://key@sentry.local/1",
+		"AUTH_TOKEN_SECRET":            tokenSecret,
+		"PASSWORD_BCRYPT_COST":         "10",
+		"MAIL_SMTP_PASSWORD":           "smtp_secret",
+		"RULES_EMAIL_VERIFICATION_TTL": "48h",
+		"RULES_ALLOCATION_PRIORITY":    "0192d0c4-5c3a-7b6e-9f10-2a3b4c5d6e7f, 0192d0c4-5c3a-7b6e-9f10-2a3b4c5d6e80",
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, ":8000", cfg.HTTP.Addr)
+	assert.Empty(t, cfg.GRPC.Addr)
+	assert.Equal(t, 5*time.Second, cfg.ShutdownTimeout, "env overrides file")
+	assert.Equal(t, "WARN", cfg.Log.Logger().Level)
+	assert.Equal(t, "json", cfg.Log.Logger().EncodingType)
+	assert.Equal(t, []string{dsnRW}, cfg.DB.Multi().Sources, "env DSNs replace the file list")
+	assert.Equal(t, []string{"postgres://sync:5432/db"}, cfg.DB.Multi().ReplicasSync)
+	assert.Equal(t, "https://key@sentry.local/1", cfg.Sentry.DSN)
+	assert.Equal(t, vObject.Rules{
+		MinAge:            21,
+		MinPasswordLength: 12,
+		DefaultPerPage:    50,
+		MaxFailedLogins:   3,
+		LoginLockout:      time.Hour,
+
+		EmailVerificationTTL: 48 * time.Hour,
+		PasswordResetTTL:     30 * time.Minute,
+
+		OrderPricePolicy: vObject.OrderPricePolicyReprice,
+	}, cfg.Rules.ValueObject())
+	assert.Equal(t, 5*time.Minute, cfg.Auth.AccessTokenTTL)
+
+	allocation, err := cfg.Rules.Allocation()
+	require.NoError(t, err)
+	assert.Equal(t, entities.NewPriorityAllocationPolicy(
+		vObject.NewWarehouseIDFromUUIDUnsafe(uuid.MustParse("0192d0c4-5c3a-7b6e-9f10-2a3b4c5d6e7f")),
+		vObject.NewWarehouseIDFromUUIDUnsafe(uuid.MustParse("0192d0c4-5c3a-7b6e-9f10-2a3b4c5d6e80")),
+	), allocation, "env list replaces the file list")
+
+	issuer, err := cfg.Auth.Issuer()
+	require.NoError(t, err)
+	assert.Equal(t, cfg.Auth.RefreshTokenTTL, issuer.RefreshTokenTTL())
+
+	assert.Equal(t, config.Password{
+		Algorithm:  "bcrypt",
+		BcryptCost: 10,
+		Argon2id:   config.Default().Password.Argon2id,
+	}, cfg.Password)
+
+	hasher, err := cfg.Password.Hasher()
+	require.NoError(t, err)
+
+	hash, err := hasher.HashAndSalt([]byte("secret_password"))
+	require.NoError(t, err)
+	assert.Equal(t, "$2a$10$", hash[:7])
+
+	assert.Equal(t, config.Mail{
+		SMTP: config.SMTP{
+			Host:     "smtp.local",
+			Port:     587,
+			Username: "shop",
+			Password: "smtp_secret",
+			From:     "Магазин <noreply@shop.local>",
+		},
+		PasswordResetURL: "https://shop.local/reset?token=",
+	}, cfg.Mail)
+	assert.IsType(t, &mail.SMTP{}, cfg.Mail.Mailer())
+	assert.IsType(t, &mail.Memory{}, config.Mail{Memory: true}.Mailer())
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := config.Load("", mapLookup(nil))
+	require.ErrorIs(t, err, config.ErrInvalid)
+	assert.ErrorContains(t, err, "PG_DSN_RW")
+
+	_, err = config.Load("", mapLookup(map[string]string{"pg_dsn_rw": dsnRW, "RULES_MIN_AGE": "adult"}))
+	require.ErrorIs(t, err, config.ErrInvalid)
+	assert.ErrorContains(t, err, "RULES_MIN_AGE")
+
+	_, err = config.Load("", mapLookup(map[string]string{"pg_dsn_rw": dsnRW}))
+	require.ErrorIs(t, err, config.ErrInvalid)
+	assert.ErrorContains(t, err, "AUTH_TOKEN_SECRET")
+
+	_, err = config.Load("", mapLookup(map[string]string{"pg_dsn_rw": dsnRW, "AUTH_TOKEN_SECRET": tokenSecret}))
+	require.ErrorIs(t, err, config.ErrInvalid)
+	assert.ErrorContains(t, err, "MAIL_SMTP_HOST", "mail is not kept in memory silently")
+
+	_, err = config.Load("", mapLookup(map[string]string{
+		"pg_dsn_rw": dsnRW, "AUTH_TOKEN_SECRET": tokenSecret, "MAIL_MEMORY": "yes please",
+	}))
+	require.ErrorIs(t, err, config.ErrInvalid)
+	assert.ErrorContains(t, err, "MAIL_MEMORY")
+
+	_, err = config.Load(writeFile(t, "unknown_key: 1\n"), mapLookup(map[string]string{"pg_dsn_rw": dsnRW, "AUTH_TOKEN_SECRET": tokenSecret}))
+	require.Error(t, err, "unknown keys are rejected")
+
+	_, err = config.Load(filepath.Join(t.TempDir(), "missing.yaml"), mapLookup(nil))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	valid := config.Default()
+	valid.DB.Sources = []string{dsnRW}
+	valid.Auth.TokenSecret = tokenSecret
+	valid.Mail.Memory = true
+	require.NoError(t, valid.Validate())
+
+	tcs := map[string]func(c *config.Config){
+		"invalid dsn":           func(c *config.Config) { c.DB.ReplicasAsync = []string{"postgres://rw:port/db"} },
+		"no transports":         func(c *config.Config) { c.HTTP.Addr, c.GRPC.Addr = "", "" },
+		"bad address":           func(c *config.Config) { c.HTTP.Addr = "8080" },
+		"zero timeout":          func(c *config.Config) { c.ShutdownTimeout = 0 },
+		"unknown log level":     func(c *config.Config) { c.Log.Level = "TRACE" },
+		"unknown encoding":      func(c *config.Config) { c.Log.Encoding = "xml" },
+		"negative query size":   func(c *config.Config) { c.DB.MaxTracingQuerySize = -1 },
+		"negative min age":      func(c *config.Config) { c.Rules.MinAge = -1 },
+		"long min password":     func(c *config.Config) { c.Rules.MinPasswordLength = 100 },
+		"zero default per page": func(c *config.Config) { c.Rules.DefaultPerPage = 0 },
+		"short token secret":    func(c *config.Config) { c.Auth.TokenSecret = "secret" },
+		"zero access ttl":       func(c *config.Config) { c.Auth.AccessTokenTTL = 0 },
+		"access exceeds refresh": func(c *config.Config) {
+			c.Auth.AccessTokenTTL = c.Auth.RefreshTokenTTL + time.Second
+		},
+		"zero max failed logins": func(c *config.Config) { c.Rules.MaxFailedLogins = 0 },
+		"zero login lockout":     func(c *config.Config) { c.Rules.LoginLockout = 0 },
+		"unknown hash algorithm": func(c *config.Config) { c.Password.Algorithm = "md5" },
+		"low bcrypt cost":        func(c *config.Config) { c.Password.BcryptCost = 1 },
+		"zero argon2id memory":   func(c *config.Config) { c.Password.Argon2id.Memory = 0 },
+		"huge argon2id threads":  func(c *config.Config) { c.Password.Argon2id.Parallelism = 256 },
+		"no mailer":              func(c *config.Config) { c.Mail.Memory = false },
+		"memory with smtp": func(c *config.Config) {
+			c.Mail.SMTP.Host, c.Mail.SMTP.From = "smtp.local", "noreply@shop.local"
+		},
+		"smtp without sender": func(c *config.Config) { c.Mail.Memory, c.Mail.SMTP.Host = false, "smtp.local" },
+		"bad smtp port": func(c *config.Config) {
+			c.Mail.Memory = false
+			c.Mail.SMTP.Host, c.Mail.SMTP.From, c.Mail.SMTP.Port = "smtp.local", "noreply@shop.local", 70000
+		},
+		"relative reset url":          func(c *config.Config) { c.Mail.PasswordResetURL = "/reset?token=" },
+		"zero verification ttl":       func(c *config.Config) { c.Rules.EmailVerificationTTL = 0 },
+		"negative password reset ttl": func(c *config.Config) { c.Rules.PasswordResetTTL = -time.Hour },
+		"unknown order price policy":  func(c *config.Config) { c.Rules.OrderPricePolicy = "latest" },
+		"unknown allocation policy":   func(c *config.Config) { c.Rules.AllocationPolicy = "nearest" },
+		"otlp endpoint without scheme": func(c *config.Config) {
+			c.Tracing.OTLPEndpoint = "otel-collector:4318"
+		},
+		"sample ratio above one": func(c *config.Config) { c.Tracing.SampleRatio = 1.5 },
+		"bad allocation priority": func(c *config.Config) {
+			c.Rules.AllocationPolicy, c.Rules.AllocationPriority = entities.AllocationPolicyPriority, []string{"main"}
+		},
+		"priority without priority policy": func(c *config.Config) {
+			c.Rules.AllocationPriority = []string{"0192d0c4-5c3a-7b6e-9f10-2a3b4c5d6e7f"}
+		},
+	}
+
+	for name, mutate := range tcs {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := valid
+			mutate(&cfg)
+
+			require.ErrorIs(t, cfg.Validate(), config.ErrInvalid)
+		})
+	}
+}
+
+func TestTracing_TracerProvider(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.Load("", mapLookup(map[string]string{
+		"pg_dsn_rw":             dsnRW,
+		"AUTH_TOKEN_SECRET":     tokenSecret,
+		"TRACING_OTLP_ENDPOINT": "http://otel-collector:4318",
+		"TRACING_SAMPLE_RATIO":  "0.25",
+		"MAIL_MEMORY":           "true",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, config.Tracing{OTLPEndpoint: "http://otel-collector:4318", SampleRatio: 0.25}, cfg.Tracing)
+
+	for _, tracing := range []config.Tracing{cfg.Tracing, config.Default().Tracing} {
+		provider, err := tracing.TracerProvider(context.Background(), "warehouse")
+		require.NoError(t, err)
+
+		_, span := provider.Tracer("test").Start(context.Background(), "span")
+		span.End()
+
+		// коллектор недоступен: ошибка отправки не должна мешать остановке дольше таймаута контекста
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		_ = provider.Shutdown(ctx)
+
+		cancel()
+	}
+
+	_, err = config.Load("", mapLookup(map[string]string{
+		"pg_dsn_rw": dsnRW, "AUTH_TOKEN_SECRET": tokenSecret, "MAIL_MEMORY": "true", "TRACING_SAMPLE_RATIO": "half",
+	}))
+	require.ErrorIs(t, err, config.ErrInvalid)
+}
+
+func TestExampleConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := config.Load("../../cmd/warehouse/config.example.yaml", mapLookup(map[string]string{
+		"AUTH_TOKEN_SECRET": tokenSecret, "MAIL_MEMORY": "true",
+	}))
+	require.NoError(t, err)
+}
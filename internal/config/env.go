@@ -0,0 +1,161 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+)
+
+// envOverrides переменные окружения, переопределяющие значения из файла.
+var envOverrides = []struct {
+	key   string
+	apply func(c *Config, value string) error
+}{
+	{key: "HTTP_ADDR", apply: setString(func(c *Config) *string { return &c.HTTP.Addr })},
+	{key: "GRPC_ADDR", apply: setString(func(c *Config) *string { return &c.GRPC.Addr })},
+	{key: "SHUTDOWN_TIMEOUT", apply: setDuration(func(c *Config) *time.Duration { return &c.ShutdownTimeout })},
+	{key: "LOG_LEVEL", apply: setString(func(c *Config) *string { return &c.Log.Level })},
+	{key: "LOG_ENCODING", apply: setString(func(c *Config) *string { return &c.Log.Encoding })},
+	{key: "DB_MAX_TRACING_QUERY_SIZE", apply: setInt(func(c *Config) *int { return &c.DB.MaxTracingQuerySize })},
+	{key: "SENTRY_DSN", apply: setString(func(c *Config) *string { return &c.Sentry.DSN })},
+	{key: "SENTRY_ENVIRONMENT", apply: setString(func(c *Config) *string { return &c.Sentry.Environment })},
+	{key: "TRACING_OTLP_ENDPOINT", apply: setString(func(c *Config) *string { return &c.Tracing.OTLPEndpoint })},
+	{key: "TRACING_SAMPLE_RATIO", apply: setFloat(func(c *Config) *float64 { return &c.Tracing.SampleRatio })},
+	{key: "AUTH_TOKEN_SECRET", apply: setString(func(c *Config) *string { return &c.Auth.TokenSecret })},
+	{key: "AUTH_ACCESS_TOKEN_TTL", apply: setDuration(func(c *Config) *time.Duration { return &c.Auth.AccessTokenTTL })},
+	{key: "AUTH_REFRESH_TOKEN_TTL", apply: setDuration(func(c *Config) *time.Duration { return &c.Auth.RefreshTokenTTL })},
+	{key: "PASSWORD_ALGORITHM", apply: setString(func(c *Config) *string { return &c.Password.Algorithm })},
+	{key: "PASSWORD_BCRYPT_COST", apply: setInt(func(c *Config) *int { return &c.Password.BcryptCost })},
+	{key: "PASSWORD_ARGON2ID_MEMORY", apply: setInt(func(c *Config) *int { return &c.Password.Argon2id.Memory })},
+	{key: "PASSWORD_ARGON2ID_ITERATIONS", apply: setInt(func(c *Config) *int { return &c.Password.Argon2id.Iterations })},
+	{key: "PASSWORD_ARGON2ID_PARALLELISM", apply: setInt(func(c *Config) *int { return &c.Password.Argon2id.Parallelism })},
+	{key: "MAIL_MEMORY", apply: setBool(func(c *Config) *bool { return &c.Mail.Memory })},
+	{key: "MAIL_SMTP_HOST", apply: setString(func(c *Config) *string { return &c.Mail.SMTP.Host })},
+	{key: "MAIL_SMTP_PORT", apply: setInt(func(c *Config) *int { return &c.Mail.SMTP.Port })},
+	{key: "MAIL_SMTP_USERNAME", apply: setString(func(c *Config) *string { return &c.Mail.SMTP.Username })},
+	{key: "MAIL_SMTP_PASSWORD", apply: setString(func(c *Config) *string { return &c.Mail.SMTP.Password })},
+	{key: "MAIL_SMTP_FROM", apply: setString(func(c *Config) *string { return &c.Mail.SMTP.From })},
+	{key: "MAIL_EMAIL_VERIFICATION_URL", apply: setString(func(c *Config) *string { return &c.Mail.EmailVerificationURL })},
+	{key: "MAIL_PASSWORD_RESET_URL", apply: setString(func(c *Config) *string { return &c.Mail.PasswordResetURL })},
+	{key: "RULES_MIN_AGE", apply: setInt(func(c *Config) *int { return &c.Rules.MinAge })},
+	{key: "RULES_MIN_PASSWORD_LENGTH", apply: setInt(func(c *Config) *int { return &c.Rules.MinPasswordLength })},
+	{key: "RULES_DEFAULT_PER_PAGE", apply: setInt(func(c *Config) *int { return &c.Rules.DefaultPerPage })},
+	{key: "RULES_MAX_FAILED_LOGINS", apply: setInt(func(c *Config) *int { return &c.Rules.MaxFailedLogins })},
+	{key: "RULES_LOGIN_LOCKOUT", apply: setDuration(func(c *Config) *time.Duration { return &c.Rules.LoginLockout })},
+	{key: "RULES_EMAIL_VERIFICATION_TTL", apply: setDuration(func(c *Config) *time.Duration { return &c.Rules.EmailVerificationTTL })},
+	{key: "RULES_PASSWORD_RESET_TTL", apply: setDuration(func(c *Config) *time.Duration { return &c.Rules.PasswordResetTTL })},
+	{key: "RULES_ORDER_PRICE_POLICY", apply: setString(func(c *Config) *string { return &c.Rules.OrderPricePolicy })},
+	{key: "RULES_ALLOCATION_POLICY", apply: setString(func(c *Config) *string { return &c.Rules.AllocationPolicy })},
+	{key: "RULES_ALLOCATION_PRIORITY", apply: setList(func(c *Config) *[]string { return &c.Rules.AllocationPriority })},
+}
+
+// applyEnv применяет переопределения. DSN собираются по префиксам PG_DSN_RW, PG_DSN_RO_SYNC и
+// PG_DSN_RO_ASYNC (см. db.NewConfigMulti) и заменяют соответствующий список из файла целиком.
+func (c *Config) applyEnv(lookup func(key string) (string, bool)) error {
+	for _, o := range envOverrides {
+		value, ok := lookup(o.key)
+		if !ok {
+			continue
+		}
+
+		if err := o.apply(c, value); err != nil {
+			return fmt.Errorf("%w: %s: %s", ErrInvalid, o.key, err.Error())
+		}
+	}
+
+	multi := db.NewConfigMulti(lookup)
+
+	if len(multi.Sources) > 0 {
+		c.DB.Sources = multi.Sources
+	}
+
+	if len(multi.ReplicasSync) > 0 {
+		c.DB.ReplicasSync = multi.ReplicasSync
+	}
+
+	if len(multi.ReplicasAsync) > 0 {
+		c.DB.ReplicasAsync = multi.ReplicasAsync
+	}
+
+	return nil
+}
+
+func setString(field func(c *Config) *string) func(c *Config, value string) error {
+	return func(c *Config, value string) error {
+		*field(c) = value
+
+		return nil
+	}
+}
+
+// setList разбирает список значений через запятую, пустая строка очищает список.
+func setList(field func(c *Config) *[]string) func(c *Config, value string) error {
+	return func(c *Config, value string) error {
+		var list []string
+
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+
+		*field(c) = list
+
+		return nil
+	}
+}
+
+func setBool(field func(c *Config) *bool) func(c *Config, value string) error {
+	return func(c *Config, value string) error {
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+
+		*field(c) = v
+
+		return nil
+	}
+}
+
+func setInt(field func(c *Config) *int) func(c *Config, value string) error {
+	return func(c *Config, value string) error {
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+
+		*field(c) = v
+
+		return nil
+	}
+}
+
+func setFloat(field func(c *Config) *float64) func(c *Config, value string) error {
+	return func(c *Config, value string) error {
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+
+		*field(c) = v
+
+		return nil
+	}
+}
+
+func setDuration(field func(c *Config) *time.Duration) func(c *Config, value string) error {
+	return func(c *Config, value string) error {
+		v, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+
+		*field(c) = v
+
+		return nil
+	}
+}
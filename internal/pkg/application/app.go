@@ -1,14 +1,176 @@
+// Package application собирает инфраструктуру сервиса и управляет его жизненным циклом:
+// запускает сервисы (транспорты, фоновые задачи) и при остановке выполняет хуки в обратном порядке.
 package application
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
 	trmgorm "github.com/avito-tech/go-transaction-manager/gorm"
 	"github.com/avito-tech/go-transaction-manager/trm"
+	"github.com/avito-tech/go-transaction-manager/trm/manager"
 
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
 )
 
+// DefaultShutdownTimeout время на выполнение всех хуков остановки.
+const DefaultShutdownTimeout = 30 * time.Second
+
 type App struct {
 	DB        *db.Instance
 	TrxGetter *trmgorm.CtxGetter
 	TxManager trm.Manager
+
+	logger          log.Logger
+	shutdownTimeout time.Duration
+	services        []hook
+	stops           []hook
+}
+
+type hook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// Option specifies configuration options of App.
+type Option func(*App)
+
+// WithLogger задаёт логгер жизненного цикла приложения.
+func WithLogger(logger log.Logger) Option {
+	return func(a *App) {
+		a.logger = logger
+	}
+}
+
+// WithShutdownTimeout задаёт время на выполнение хуков остановки.
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(a *App) {
+		a.shutdownTimeout = timeout
+	}
+}
+
+// WithDB подключает базу данных и менеджер транзакций поверх неё.
+// Пулы соединений закрываются после остальных хуков остановки, перед сбросом логов.
+func WithDB(inst *db.Instance) Option {
+	return func(a *App) {
+		a.DB = inst
+	}
+}
+
+func New(opts ...Option) *App {
+	a := &App{
+		logger:          log.Named("app"),
+		shutdownTimeout: DefaultShutdownTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	// логи сбрасываются последним хуком, чтобы не потерять записи остальных хуков
+	a.OnStop("log", func(context.Context) error {
+		log.Sync()
+
+		return nil
+	})
+
+	if a.DB != nil {
+		a.TrxGetter = trmgorm.DefaultCtxGetter
+		a.TxManager = manager.Must(
+			trmgorm.NewDefaultFactory(a.DB.Gorm),
+			manager.WithLog(tx.NewTrxLogger(a.logger)),
+		)
+
+		a.OnStop("db", func(context.Context) error {
+			return a.DB.Close()
+		})
+	}
+
+	return a
+}
+
+// Go регистрирует сервис. Сервис работает до отмены ctx либо до ошибки,
+// остановку сервиса (например, http.Server.Shutdown) нужно зарегистрировать через OnStop.
+func (a *App) Go(name string, fn func(ctx context.Context) error) {
+	a.services = append(a.services, hook{name: name, fn: fn})
+}
+
+// OnStop регистрирует хук остановки. Хуки выполняются в порядке, обратном регистрации.
+func (a *App) OnStop(name string, fn func(ctx context.Context) error) {
+	a.stops = append(a.stops, hook{name: name, fn: fn})
+}
+
+// Run запускает сервисы и ждёт отмены ctx либо завершения любого из сервисов, после чего
+// выполняет хуки остановки и ждёт завершения остальных сервисов. Всё это ограничено shutdownTimeout.
+// Возвращает ошибки сервисов и хуков.
+func (a *App) Run(ctx context.Context) error {
+	done := make(chan error, len(a.services))
+
+	for _, s := range a.services {
+		go func() {
+			a.logger.Info(ctx, "service started", log.String("service", s.name))
+
+			if err := s.fn(ctx); err != nil {
+				done <- fmt.Errorf("[app - service %s]: %w", s.name, err)
+
+				return
+			}
+
+			done <- nil
+		}()
+	}
+
+	errs := make([]error, 0, len(a.services)+1)
+	running := len(a.services)
+
+	select {
+	case <-ctx.Done():
+		a.logger.Info(ctx, "shutdown requested")
+	case err := <-done:
+		running--
+
+		errs = append(errs, err)
+
+		a.logger.Warn(ctx, "service stopped, shutting down", log.Err(err))
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), a.shutdownTimeout)
+	defer cancel()
+
+	errs = append(errs, a.shutdown(shutdownCtx))
+
+	for ; running > 0; running-- {
+		select {
+		case err := <-done:
+			errs = append(errs, err)
+		case <-shutdownCtx.Done():
+			return errors.Join(append(errs, fmt.Errorf("[app - wait services]: %w", shutdownCtx.Err()))...)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (a *App) shutdown(ctx context.Context) error {
+	var errs []error
+
+	for i := len(a.stops) - 1; i >= 0; i-- {
+		s := a.stops[i]
+
+		if err := s.fn(ctx); err != nil {
+			a.logger.Error(ctx, "stop hook error", log.String("hook", s.name), log.Err(err))
+
+			errs = append(errs, fmt.Errorf("[app - stop %s]: %w", s.name, err))
+
+			continue
+		}
+
+		a.logger.Debug(ctx, "stop hook done", log.String("hook", s.name))
+	}
+
+	return errors.Join(errs...)
 }
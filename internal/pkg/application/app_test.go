@@ -0,0 +1,130 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/application"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+)
+
+type recorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *recorder) add(call string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, call)
+}
+
+func (r *recorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.calls...)
+}
+
+// blockingService работает до вызова stop, как http.Server до Shutdown.
+func blockingService(rec *recorder, name string) (run, stop func(context.Context) error) {
+	stopped := make(chan struct{})
+
+	run = func(context.Context) error {
+		<-stopped
+		rec.add("exit " + name)
+
+		return nil
+	}
+
+	stop = func(context.Context) error {
+		rec.add("stop " + name)
+		close(stopped)
+
+		return nil
+	}
+
+	return run, stop
+}
+
+func TestApp_Run(t *testing.T) {
+	t.Parallel()
+
+	rec := &recorder{}
+	app := application.New(application.WithLogger(log.Named("test")))
+
+	app.OnStop("sentry", func(context.Context) error {
+		rec.add("stop sentry")
+
+		return nil
+	})
+
+	run, stop := blockingService(rec, "http")
+	app.Go("http", run)
+	app.OnStop("http", stop)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	result := make(chan error)
+	go func() { result <- app.Run(ctx) }()
+
+	cancel()
+
+	require.NoError(t, <-result)
+
+	calls := rec.get()
+	require.Len(t, calls, 3)
+	assert.Equal(t, "stop http", calls[0], "stop hooks run in reverse order")
+	assert.ElementsMatch(t, []string{"exit http", "stop sentry"}, calls[1:])
+}
+
+func TestApp_RunServiceError(t *testing.T) {
+	t.Parallel()
+
+	rec := &recorder{}
+	app := application.New()
+
+	app.Go("grpc", func(context.Context) error {
+		return assert.AnError
+	})
+
+	run, stop := blockingService(rec, "http")
+	app.Go("http", run)
+	app.OnStop("http", stop)
+
+	app.OnStop("broken", func(context.Context) error {
+		return errors.New("stop failed")
+	})
+
+	err := app.Run(context.Background())
+	require.ErrorIs(t, err, assert.AnError)
+	require.ErrorContains(t, err, "stop failed")
+	assert.Contains(t, rec.get(), "exit http", "other services are stopped as well")
+}
+
+func TestApp_RunShutdownTimeout(t *testing.T) {
+	t.Parallel()
+
+	app := application.New(application.WithShutdownTimeout(10 * time.Millisecond))
+
+	stuck := make(chan struct{})
+	t.Cleanup(func() { close(stuck) })
+
+	app.Go("stuck", func(context.Context) error {
+		<-stuck
+
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.ErrorIs(t, app.Run(ctx), context.DeadlineExceeded)
+}
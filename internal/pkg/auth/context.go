@@ -0,0 +1,27 @@
+package auth
+
+import "context"
+
+type claimsKey struct{}
+
+// ContextWithClaims сохраняет в контексте данные токена доступа аутентифицированного пользователя.
+func ContextWithClaims(ctx context.Context, claims Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext возвращает данные токена доступа, если запрос аутентифицирован.
+func ClaimsFromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(Claims)
+
+	return claims, ok
+}
+
+// UserID возвращает идентификатор аутентифицированного пользователя либо пустую строку.
+// Подходит для recovery.WithUserID.
+func UserID(ctx context.Context) string {
+	if claims, ok := ClaimsFromContext(ctx); ok {
+		return claims.UserID.String()
+	}
+
+	return ""
+}
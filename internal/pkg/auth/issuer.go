@@ -0,0 +1,207 @@
+// Package auth выпускает и проверяет подписанные токены доступа и обновления
+// и передаёт аутентифицированного пользователя через контекст запроса.
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	// MinSecretLength минимальная длина ключа подписи HS256.
+	MinSecretLength = 32
+
+	DefaultAccessTokenTTL  = 15 * time.Minute
+	DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+type TokenType string
+
+const (
+	TokenTypeAccess  TokenType = "access"
+	TokenTypeRefresh TokenType = "refresh"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrShortSecret  = errors.New("token secret is too short")
+)
+
+// Claims данные проверенного токена.
+type Claims struct {
+	// ID идентификатор токена обновления, у токена доступа пустой.
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	SessionID uuid.UUID
+	Type      TokenType
+	ExpiresAt time.Time
+}
+
+// Pair пара токенов, выдаваемая при входе и при обновлении сессии.
+type Pair struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+type jwtClaims struct {
+	jwt.RegisteredClaims
+
+	SessionID string    `json:"sid"`
+	Type      TokenType `json:"typ"`
+}
+
+// Issuer подписывает токены ключом HS256.
+type Issuer struct {
+	secret     []byte
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+// Option specifies configuration options of Issuer.
+type Option func(*Issuer)
+
+// WithAccessTokenTTL задаёт время жизни токена доступа.
+func WithAccessTokenTTL(ttl time.Duration) Option {
+	return func(i *Issuer) {
+		i.accessTTL = ttl
+	}
+}
+
+// WithRefreshTokenTTL задаёт время жизни токена обновления и сессии.
+func WithRefreshTokenTTL(ttl time.Duration) Option {
+	return func(i *Issuer) {
+		i.refreshTTL = ttl
+	}
+}
+
+func NewIssuer(secret []byte, opts ...Option) (*Issuer, error) {
+	if len(secret) < MinSecretLength {
+		return nil, fmt.Errorf("%w: at least %d bytes required", ErrShortSecret, MinSecretLength)
+	}
+
+	i := &Issuer{
+		secret:     append([]byte(nil), secret...),
+		accessTTL:  DefaultAccessTokenTTL,
+		refreshTTL: DefaultRefreshTokenTTL,
+	}
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	return i, nil
+}
+
+// RefreshTokenTTL время жизни токена обновления.
+func (i *Issuer) RefreshTokenTTL() time.Duration {
+	return i.refreshTTL
+}
+
+// Issue выпускает пару токенов сессии sessionID. refreshID — идентификатор токена обновления,
+// который сессия хранит, чтобы принимать только последний выданный токен.
+func (i *Issuer) Issue(userID, sessionID, refreshID uuid.UUID, issuedAt time.Time) (Pair, error) {
+	pair := Pair{
+		AccessExpiresAt:  issuedAt.Add(i.accessTTL),
+		RefreshExpiresAt: issuedAt.Add(i.refreshTTL),
+	}
+
+	var err error
+
+	pair.AccessToken, err = i.sign(jwtClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID.String(),
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(pair.AccessExpiresAt),
+		},
+		SessionID: sessionID.String(),
+		Type:      TokenTypeAccess,
+	})
+	if err != nil {
+		return Pair{}, err
+	}
+
+	pair.RefreshToken, err = i.sign(jwtClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        refreshID.String(),
+			Subject:   userID.String(),
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(pair.RefreshExpiresAt),
+		},
+		SessionID: sessionID.String(),
+		Type:      TokenTypeRefresh,
+	})
+	if err != nil {
+		return Pair{}, err
+	}
+
+	return pair, nil
+}
+
+// Parse проверяет подпись, срок действия на момент at и тип токена.
+func (i *Issuer) Parse(raw string, typ TokenType, at time.Time) (Claims, error) {
+	var c jwtClaims
+
+	_, err := jwt.ParseWithClaims(raw, &c,
+		func(*jwt.Token) (any, error) { return i.secret, nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(func() time.Time { return at }),
+	)
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	if c.Type != typ {
+		return Claims{}, fmt.Errorf("%w: %s token expected", ErrInvalidToken, typ)
+	}
+
+	claims := Claims{
+		Type:      c.Type,
+		ExpiresAt: c.ExpiresAt.Time,
+	}
+
+	if claims.UserID, err = uuid.Parse(c.Subject); err != nil {
+		return Claims{}, fmt.Errorf("%w: subject", ErrInvalidToken)
+	}
+
+	if claims.SessionID, err = uuid.Parse(c.SessionID); err != nil {
+		return Claims{}, fmt.Errorf("%w: session", ErrInvalidToken)
+	}
+
+	if typ == TokenTypeRefresh {
+		if claims.ID, err = uuid.Parse(c.ID); err != nil {
+			return Claims{}, fmt.Errorf("%w: token id", ErrInvalidToken)
+		}
+	}
+
+	return claims, nil
+}
+
+func (i *Issuer) sign(c jwtClaims) (string, error) {
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString(i.secret)
+	if err != nil {
+		return "", fmt.Errorf("[auth - sign token]: %w", err)
+	}
+
+	return signed, nil
+}
+
+// BearerToken извлекает токен из значения заголовка Authorization.
+func BearerToken(header string) (string, bool) {
+	const prefix = "Bearer "
+
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", false
+	}
+
+	token := strings.TrimSpace(header[len(prefix):])
+
+	return token, token != ""
+}
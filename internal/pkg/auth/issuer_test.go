@@ -0,0 +1,104 @@
+package auth_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/auth"
+)
+
+var secret = []byte(strings.Repeat("s", auth.MinSecretLength))
+
+func TestNewIssuer(t *testing.T) {
+	t.Parallel()
+
+	_, err := auth.NewIssuer([]byte("short"))
+	require.ErrorIs(t, err, auth.ErrShortSecret)
+
+	issuer, err := auth.NewIssuer(secret, auth.WithRefreshTokenTTL(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, issuer.RefreshTokenTTL())
+}
+
+func TestIssuer_IssueParse(t *testing.T) {
+	t.Parallel()
+
+	issuer, err := auth.NewIssuer(secret, auth.WithAccessTokenTTL(time.Minute), auth.WithRefreshTokenTTL(time.Hour))
+	require.NoError(t, err)
+
+	userID, sessionID, refreshID := uuid.New(), uuid.New(), uuid.New()
+	tn := time.Now().Truncate(time.Second)
+
+	pair, err := issuer.Issue(userID, sessionID, refreshID, tn)
+	require.NoError(t, err)
+	assert.Equal(t, tn.Add(time.Minute), pair.AccessExpiresAt)
+	assert.Equal(t, tn.Add(time.Hour), pair.RefreshExpiresAt)
+
+	access, err := issuer.Parse(pair.AccessToken, auth.TokenTypeAccess, tn)
+	require.NoError(t, err)
+	assert.Equal(t, auth.Claims{
+		UserID:    userID,
+		SessionID: sessionID,
+		Type:      auth.TokenTypeAccess,
+		ExpiresAt: pair.AccessExpiresAt,
+	}, access)
+
+	refresh, err := issuer.Parse(pair.RefreshToken, auth.TokenTypeRefresh, tn.Add(30*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, refreshID, refresh.ID)
+	assert.Equal(t, sessionID, refresh.SessionID)
+
+	_, err = issuer.Parse(pair.AccessToken, auth.TokenTypeAccess, tn.Add(2*time.Minute))
+	require.ErrorIs(t, err, auth.ErrInvalidToken, "expired")
+
+	_, err = issuer.Parse(pair.RefreshToken, auth.TokenTypeAccess, tn)
+	require.ErrorIs(t, err, auth.ErrInvalidToken, "wrong type")
+
+	other, err := auth.NewIssuer([]byte(strings.Repeat("o", auth.MinSecretLength)))
+	require.NoError(t, err)
+
+	_, err = other.Parse(pair.AccessToken, auth.TokenTypeAccess, tn)
+	require.ErrorIs(t, err, auth.ErrInvalidToken, "foreign signature")
+
+	_, err = issuer.Parse("garbage", auth.TokenTypeAccess, tn)
+	require.ErrorIs(t, err, auth.ErrInvalidToken)
+}
+
+func TestBearerToken(t *testing.T) {
+	t.Parallel()
+
+	token, ok := auth.BearerToken("Bearer abc")
+	assert.True(t, ok)
+	assert.Equal(t, "abc", token)
+
+	token, ok = auth.BearerToken("bearer abc")
+	assert.True(t, ok)
+	assert.Equal(t, "abc", token)
+
+	_, ok = auth.BearerToken("Basic abc")
+	assert.False(t, ok)
+
+	_, ok = auth.BearerToken("Bearer ")
+	assert.False(t, ok)
+}
+
+func TestContext(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	assert.Empty(t, auth.UserID(ctx))
+
+	claims := auth.Claims{UserID: uuid.New()}
+	ctx = auth.ContextWithClaims(ctx, claims)
+
+	got, ok := auth.ClaimsFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, claims, got)
+	assert.Equal(t, claims.UserID.String(), auth.UserID(ctx))
+}
@@ -4,9 +4,15 @@ package db
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
 
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db/multisql"
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
@@ -25,7 +31,7 @@ type Instance struct {
 	sourcesSQL []*sql.DB
 	syncsSQL   []*sql.DB
 	asyncsSQL  []*sql.DB
-	gormSQL    []*sql.DB
+	gormSQL    []*sql.DB // all opened pools, closed by Close
 
 	tracerProvider       trace.TracerProvider
 	maxTracingQuerySize  int
@@ -35,6 +41,9 @@ type Instance struct {
 	preferSimpleProtocol bool
 }
 
+// ErrNoSources is returned when neither dsn nor Config.Multi.Sources are set.
+var ErrNoSources = errors.New("no database sources are configured")
+
 // Config contains information about the connection.
 type Config struct {
 	Adapter  string
@@ -51,18 +60,162 @@ type ConfigMulti struct {
 }
 
 // NewInstance initializes db connection.
+//
+// The dsn is used as the main write source. Additional sources and sync/async
+// replicas are taken from Config.Multi (see WithConfig). Reads without explicit
+// resolver go to sync replicas, AsyncDB uses async replicas falling back to the
+// sync ones, and all of them fall back to the sources if no replicas are set.
 func NewInstance(dsn string, opts ...Option) (*Instance, error) {
 	inst := &Instance{
-		log:        log.Named(DefaultLoggerName),
-		gormConfig: &gorm.Config{},
+		log:                 log.Named(DefaultLoggerName),
+		gormConfig:          &gorm.Config{},
+		maxTracingQuerySize: DefaultMaxTracingQuerySize,
 	}
 
 	for _, opt := range opts {
 		opt(inst)
 	}
 
-	// TODO database initialisation here
-	// ...
+	if err := inst.open(dsn); err != nil {
+		_ = inst.Close()
+
+		return nil, err
+	}
 
 	return inst, nil
 }
+
+func (i *Instance) open(dsn string) error {
+	sources := i.Config.Multi.Sources
+	if dsn != "" {
+		sources = append([]string{dsn}, sources...)
+	}
+
+	if len(sources) == 0 {
+		return ErrNoSources
+	}
+
+	var err error
+
+	if i.sourcesSQL, err = i.openAll(sources); err != nil {
+		return fmt.Errorf("[db.NewInstance - sources] %w", err)
+	}
+
+	if i.syncsSQL, err = i.openAll(i.Config.Multi.ReplicasSync); err != nil {
+		return fmt.Errorf("[db.NewInstance - sync replicas] %w", err)
+	}
+
+	if i.asyncsSQL, err = i.openAll(i.Config.Multi.ReplicasAsync); err != nil {
+		return fmt.Errorf("[db.NewInstance - async replicas] %w", err)
+	}
+
+	i.SQL = i.sourcesSQL[0]
+
+	gormConfig := *i.gormConfig
+	if gormConfig.Logger == nil {
+		gormConfig.Logger = newGormLogger(i.log)
+	}
+
+	if i.Gorm, err = gorm.Open(postgres.New(postgres.Config{Conn: i.SQL}), &gormConfig); err != nil {
+		return fmt.Errorf("[db.NewInstance - gorm.Open] %w", err)
+	}
+
+	asyncs := i.asyncsSQL
+	if len(asyncs) == 0 {
+		asyncs = i.syncsSQL
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Sources:  dialectors(i.sourcesSQL),
+		Replicas: dialectors(i.syncsSQL),
+		Policy:   dbresolver.RandomPolicy{},
+	}).Register(dbresolver.Config{
+		Sources:  dialectors(i.sourcesSQL),
+		Replicas: dialectors(asyncs),
+		Policy:   dbresolver.RandomPolicy{},
+	}, asyncResolverName)
+
+	if err = i.Gorm.Use(resolver); err != nil {
+		return fmt.Errorf("[db.NewInstance - dbresolver] %w", err)
+	}
+
+	if i.tracerProvider != nil {
+		if err = i.Gorm.Use(newTracingPlugin(i.tracerProvider, i.maxTracingQuerySize)); err != nil {
+			return fmt.Errorf("[db.NewInstance - tracing] %w", err)
+		}
+	}
+
+	syncs := i.syncsSQL
+	if len(syncs) == 0 {
+		syncs = i.sourcesSQL
+	}
+
+	if len(asyncs) == 0 {
+		asyncs = syncs
+	}
+
+	i.manager = multisql.NewManager(i.SQL, multisql.Resolvers{
+		sourcesResolverName: multisql.RandomResolver(i.sourcesSQL),
+		syncResolverName:    multisql.RandomResolver(syncs),
+		asyncResolverName:   multisql.RandomResolver(asyncs),
+	})
+
+	return nil
+}
+
+// openAll opens *sql.DB for every dsn.
+func (i *Instance) openAll(dsns []string) ([]*sql.DB, error) {
+	dbs := make([]*sql.DB, 0, len(dsns))
+
+	for _, dsn := range dsns {
+		cfg, err := pgx.ParseConfig(dsn)
+		if err != nil {
+			closeAll(dbs)
+
+			return nil, fmt.Errorf("parse dsn: %w", err)
+		}
+
+		if i.applicationName != "" {
+			cfg.RuntimeParams["application_name"] = i.applicationName
+		}
+
+		if i.preferSimpleProtocol {
+			cfg.DefaultQueryExecMode = pgx.QueryExecModeSimpleProtocol
+		}
+
+		dbs = append(dbs, stdlib.OpenDB(*cfg))
+	}
+
+	i.gormSQL = append(i.gormSQL, dbs...)
+
+	return dbs, nil
+}
+
+// Close closes all opened connection pools.
+func (i *Instance) Close() error {
+	err := closeAll(i.gormSQL)
+	i.gormSQL = nil
+
+	return err
+}
+
+func closeAll(dbs []*sql.DB) error {
+	var errs []error
+
+	for _, db := range dbs {
+		if err := db.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func dialectors(dbs []*sql.DB) []gorm.Dialector {
+	ds := make([]gorm.Dialector, 0, len(dbs))
+	for _, db := range dbs {
+		ds = append(ds, postgres.New(postgres.Config{Conn: db}))
+	}
+
+	return ds
+}
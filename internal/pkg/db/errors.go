@@ -0,0 +1,28 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// uniqueViolationCode is the postgres error code of the unique constraint violation.
+const uniqueViolationCode = "23505"
+
+// IsDuplicateKeyError reports whether err is caused by the unique constraint
+// violation. Both translated GORM errors and raw postgres errors are detected.
+func IsDuplicateKeyError(err error) bool {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
+// IsNotFoundError reports whether err is caused by the empty query result.
+func IsNotFoundError(err error) bool {
+	return errors.Is(err, gorm.ErrRecordNotFound)
+}
@@ -2,6 +2,9 @@ package db
 
 import (
 	"database/sql"
+	"fmt"
+	"os"
+	"strings"
 
 	"gorm.io/gorm"
 	"gorm.io/plugin/dbresolver"
@@ -25,6 +28,46 @@ var (
 	useAsyncResolver = dbresolver.Use(asyncResolverName)
 )
 
+// NewConfigMulti collects DSNs by the pg_dsn_rw, pg_dsn_ro_sync and
+// pg_dsn_ro_async prefixes. For each prefix the key itself and the keys with
+// suffixes _1.._9 are looked up, e.g. pg_dsn_ro_sync, pg_dsn_ro_sync_1.
+func NewConfigMulti(lookup func(key string) (string, bool)) ConfigMulti {
+	return ConfigMulti{
+		Sources:       lookupDSNs(lookup, sourcesDSNPrefix),
+		ReplicasSync:  lookupDSNs(lookup, replicasSyncDSNPrefix),
+		ReplicasAsync: lookupDSNs(lookup, replicasAsyncDSNPrefix),
+	}
+}
+
+// NewConfigMultiFromEnv collects DSNs from the environment. Both lower and
+// upper case variable names are accepted (PG_DSN_RW_1 or pg_dsn_rw_1).
+func NewConfigMultiFromEnv() ConfigMulti {
+	return NewConfigMulti(func(key string) (string, bool) {
+		if v, ok := os.LookupEnv(strings.ToUpper(key)); ok {
+			return v, true
+		}
+
+		return os.LookupEnv(key)
+	})
+}
+
+func lookupDSNs(lookup func(key string) (string, bool), prefix string) []string {
+	var dsns []string
+
+	for n := 0; n <= maxCountDSN; n++ {
+		key := prefix
+		if n > 0 {
+			key = fmt.Sprintf("%s_%d", prefix, n)
+		}
+
+		if dsn, ok := lookup(key); ok && dsn != "" {
+			dsns = append(dsns, dsn)
+		}
+	}
+
+	return dsns
+}
+
 // DB returns DB object.
 func (i *Instance) DB() *gorm.DB {
 	return i.Gorm
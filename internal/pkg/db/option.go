@@ -17,6 +17,22 @@ func WithLogger(logger log.Logger) Option {
 	}
 }
 
+// WithConfig sets the connection config. Config.Multi describes additional
+// sources and sync/async replicas.
+func WithConfig(cfg Config) Option {
+	return func(i *Instance) {
+		i.Config = cfg
+	}
+}
+
+// WithApplicationName sets application_name of every opened connection, so
+// the sessions can be recognized in pg_stat_activity.
+func WithApplicationName(name string) Option {
+	return func(i *Instance) {
+		i.applicationName = name
+	}
+}
+
 // WithTracerProvider specifies a tracer provider to use for creating a tracer.
 // If the option is not specified, tracing is disabled.
 func WithTracerProvider(provider trace.TracerProvider) Option {
@@ -25,6 +41,14 @@ func WithTracerProvider(provider trace.TracerProvider) Option {
 	}
 }
 
+// WithMaxTracingQuerySize limits the size of the db.statement span attribute,
+// DefaultMaxTracingQuerySize by default. Zero means no limit.
+func WithMaxTracingQuerySize(size int) Option {
+	return func(i *Instance) {
+		i.maxTracingQuerySize = size
+	}
+}
+
 func limitQuerySize(query string, limit int) string {
 	maxQuerySize := len(query)
 	if limit != 0 && maxQuerySize > limit {
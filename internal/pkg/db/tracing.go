@@ -0,0 +1,107 @@
+package db
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultMaxTracingQuerySize is a default limit of the db.statement span attribute.
+	DefaultMaxTracingQuerySize = 4096
+
+	tracerName     = "github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	tracingSpanKey = "otel:span"
+)
+
+var (
+	dbSystemKey       = attribute.Key("db.system")
+	dbStatementKey    = attribute.Key("db.statement")
+	dbTableKey        = attribute.Key("db.sql.table")
+	dbRowsAffectedKey = attribute.Key("db.rows_affected")
+)
+
+var _ gorm.Plugin = (*tracingPlugin)(nil)
+
+// tracingPlugin is a GORM plugin which starts a client span for every SQL statement.
+type tracingPlugin struct {
+	tracer       trace.Tracer
+	maxQuerySize int
+}
+
+func newTracingPlugin(provider trace.TracerProvider, maxQuerySize int) *tracingPlugin {
+	return &tracingPlugin{
+		tracer:       provider.Tracer(tracerName),
+		maxQuerySize: maxQuerySize,
+	}
+}
+
+// Name implements gorm.Plugin.
+func (p *tracingPlugin) Name() string {
+	return "otel:tracing"
+}
+
+// Initialize implements gorm.Plugin. It registers before/after callbacks around
+// every GORM processor.
+func (p *tracingPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("otel:before_create", p.before("create")),
+		cb.Create().After("gorm:create").Register("otel:after_create", p.after),
+		cb.Query().Before("gorm:query").Register("otel:before_query", p.before("query")),
+		cb.Query().After("gorm:query").Register("otel:after_query", p.after),
+		cb.Update().Before("gorm:update").Register("otel:before_update", p.before("update")),
+		cb.Update().After("gorm:update").Register("otel:after_update", p.after),
+		cb.Delete().Before("gorm:delete").Register("otel:before_delete", p.before("delete")),
+		cb.Delete().After("gorm:delete").Register("otel:after_delete", p.after),
+		cb.Row().Before("gorm:row").Register("otel:before_row", p.before("row")),
+		cb.Row().After("gorm:row").Register("otel:after_row", p.after),
+		cb.Raw().Before("gorm:raw").Register("otel:before_raw", p.before("raw")),
+		cb.Raw().After("gorm:raw").Register("otel:after_raw", p.after),
+	)
+}
+
+func (p *tracingPlugin) before(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx, span := p.tracer.Start(db.Statement.Context, "db."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(dbSystemKey.String("postgresql")),
+		)
+
+		// контекст со спаном уходит дальше в драйвер и логгер GORM
+		db.Statement.Context = ctx
+		db.InstanceSet(tracingSpanKey, span)
+	}
+}
+
+func (p *tracingPlugin) after(db *gorm.DB) {
+	v, ok := db.InstanceGet(tracingSpanKey)
+	if !ok {
+		return
+	}
+
+	span, ok := v.(trace.Span)
+	if !ok {
+		return
+	}
+
+	defer span.End()
+
+	span.SetAttributes(
+		dbStatementKey.String(limitQuerySize(db.Statement.SQL.String(), p.maxQuerySize)),
+		dbRowsAffectedKey.Int64(db.Statement.RowsAffected),
+	)
+
+	if db.Statement.Table != "" {
+		span.SetAttributes(dbTableKey.String(db.Statement.Table))
+	}
+
+	if err := db.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type tracedRow struct {
+	ID   int64
+	Name string
+}
+
+func newTracedDB(t *testing.T, maxQuerySize int) (*gorm.DB, sqlmock.Sqlmock, *tracetest.SpanRecorder) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger:                 logger.Discard,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	require.NoError(t, gdb.Use(newTracingPlugin(provider, maxQuerySize)))
+
+	return gdb, mock, recorder
+}
+
+func spanAttrs(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+
+	return attrs
+}
+
+func TestTracingPlugin(t *testing.T) {
+	t.Parallel()
+
+	gdb, mock, recorder := newTracedDB(t, 0)
+
+	parentCtx, parent := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "parent")
+	defer parent.End()
+
+	mock.ExpectQuery(`SELECT \* FROM "traced_rows"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "one"))
+	mock.ExpectExec(`UPDATE "traced_rows"`).WithArgs("two", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	var rows []tracedRow
+	require.NoError(t, gdb.WithContext(parentCtx).Find(&rows).Error)
+	require.NoError(t, gdb.WithContext(parentCtx).Model(&tracedRow{}).Where("id = ?", 1).
+		Update("name", "two").Error)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	query := spans[0]
+	assert.Equal(t, "db.query", query.Name())
+	assert.Equal(t, trace.SpanKindClient, query.SpanKind())
+	assert.Equal(t, parent.SpanContext().TraceID(), query.SpanContext().TraceID())
+	assert.Equal(t, parent.SpanContext().SpanID(), query.Parent().SpanID())
+	assert.Equal(t, codes.Unset, query.Status().Code)
+
+	attrs := spanAttrs(query)
+	assert.Equal(t, "postgresql", attrs[dbSystemKey].AsString())
+	assert.Equal(t, `SELECT * FROM "traced_rows"`, attrs[dbStatementKey].AsString())
+	assert.Equal(t, "traced_rows", attrs[dbTableKey].AsString())
+	assert.Equal(t, int64(1), attrs[dbRowsAffectedKey].AsInt64())
+
+	update := spans[1]
+	assert.Equal(t, "db.update", update.Name())
+	assert.Equal(t, int64(1), spanAttrs(update)[dbRowsAffectedKey].AsInt64())
+}
+
+func TestTracingPlugin_Errors(t *testing.T) {
+	t.Parallel()
+
+	gdb, mock, recorder := newTracedDB(t, 13)
+
+	mock.ExpectQuery(`SELECT`).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+	mock.ExpectQuery(`SELECT`).WithArgs(1).WillReturnError(assert.AnError)
+
+	var row tracedRow
+	require.ErrorIs(t, gdb.First(&row).Error, gorm.ErrRecordNotFound)
+	require.ErrorIs(t, gdb.First(&row).Error, assert.AnError)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, codes.Unset, spans[0].Status().Code, "record not found is not a span error")
+	assert.Equal(t, "SELECT * FROM", spanAttrs(spans[0])[dbStatementKey].AsString(), "statement is truncated")
+
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	require.Len(t, spans[1].Events(), 1)
+	assert.Equal(t, "exception", spans[1].Events()[0].Name)
+}
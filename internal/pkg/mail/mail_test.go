@@ -0,0 +1,173 @@
+package mail_test
+
+import (
+	"bufio"
+	"context"
+	"mime"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/mail"
+)
+
+// fakeSMTP принимает одно письмо без STARTTLS и аутентификации и возвращает конверт и данные письма.
+func fakeSMTP(t *testing.T) (int, <-chan []string) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	received := make(chan []string, 1)
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		var (
+			lines  []string
+			inData bool
+		)
+
+		r := bufio.NewReader(conn)
+		reply := func(s string) { _, _ = conn.Write([]byte(s + "\r\n")) }
+
+		reply("220 localhost ESMTP")
+
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				received <- lines
+
+				return
+			}
+
+			line = strings.TrimRight(line, "\r\n")
+
+			switch {
+			case inData && line == ".":
+				inData = false
+
+				reply("250 OK")
+			case inData:
+				lines = append(lines, line)
+			case strings.HasPrefix(line, "EHLO"):
+				reply("250 localhost")
+			case strings.HasPrefix(line, "MAIL FROM"), strings.HasPrefix(line, "RCPT TO"):
+				lines = append(lines, line)
+
+				reply("250 OK")
+			case line == "DATA":
+				inData = true
+
+				reply("354 go ahead")
+			case line == "QUIT":
+				reply("221 bye")
+				received <- lines
+
+				return
+			default:
+				reply("502 unsupported")
+			}
+		}
+	}()
+
+	return ln.Addr().(*net.TCPAddr).Port, received
+}
+
+func TestSMTP_Send(t *testing.T) {
+	t.Parallel()
+
+	port, received := fakeSMTP(t)
+
+	sender := mail.NewSMTP(mail.ConfigSMTP{Host: "127.0.0.1", Port: port, From: "shop@example.com"})
+
+	err := sender.Send(context.Background(), mail.Message{
+		To:      "user@example.com",
+		Subject: "Подтверждение email",
+		Body:    "line 1\nline 2",
+	})
+	require.NoError(t, err)
+
+	lines := <-received
+	assert.Contains(t, lines, "MAIL FROM:<shop@example.com>")
+	assert.Contains(t, lines, "RCPT TO:<user@example.com>")
+	assert.Contains(t, lines, "From: shop@example.com")
+	assert.Contains(t, lines, "To: user@example.com")
+	assert.Contains(t, lines, "Content-Type: text/plain; charset=utf-8")
+	assert.Equal(t, []string{"line 1", "line 2"}, lines[len(lines)-2:])
+
+	for _, line := range lines {
+		if subject, ok := strings.CutPrefix(line, "Subject: "); ok {
+			decoded, err := new(mime.WordDecoder).DecodeHeader(subject)
+			require.NoError(t, err)
+			assert.Equal(t, "Подтверждение email", decoded)
+		}
+	}
+}
+
+func TestSMTP_SendError(t *testing.T) {
+	t.Parallel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	require.NoError(t, ln.Close())
+
+	sender := mail.NewSMTP(mail.ConfigSMTP{Host: "127.0.0.1", Port: port, From: "shop@example.com"})
+
+	err = sender.Send(context.Background(), mail.Message{To: "user@example.com"})
+	require.ErrorIs(t, err, mail.ErrSend)
+	assert.Contains(t, err.Error(), strconv.Itoa(port))
+}
+
+func TestMemory(t *testing.T) {
+	t.Parallel()
+
+	m := mail.NewMemory()
+
+	_, ok := m.Last("user@example.com")
+	assert.False(t, ok)
+
+	require.NoError(t, m.Send(context.Background(), mail.Message{To: "user@example.com", Subject: "first"}))
+	require.NoError(t, m.Send(context.Background(), mail.Message{To: "other@example.com", Subject: "other"}))
+	require.NoError(t, m.Send(context.Background(), mail.Message{To: "user@example.com", Subject: "second"}))
+
+	msg, ok := m.Last("user@example.com")
+	require.True(t, ok)
+	assert.Equal(t, "second", msg.Subject)
+	assert.Len(t, m.Messages(), 3)
+}
+
+func TestMemory_Limit(t *testing.T) {
+	t.Parallel()
+
+	m := mail.NewMemory(mail.WithMemoryLimit(2))
+
+	for _, subject := range []string{"first", "second", "third"} {
+		require.NoError(t, m.Send(context.Background(), mail.Message{To: "user@example.com", Subject: subject}))
+	}
+
+	// старые письма отбрасываются
+	messages := m.Messages()
+	require.Len(t, messages, 2)
+	assert.Equal(t, "second", messages[0].Subject)
+	assert.Equal(t, "third", messages[1].Subject)
+
+	m = mail.NewMemory(mail.WithMemoryLimit(0))
+
+	for range mail.DefaultMemoryLimit + 1 {
+		require.NoError(t, m.Send(context.Background(), mail.Message{To: "user@example.com"}))
+	}
+
+	assert.Len(t, m.Messages(), mail.DefaultMemoryLimit)
+}
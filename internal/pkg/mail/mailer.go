@@ -0,0 +1,24 @@
+/*
+Package mail отправляет служебные письма пользователям: SMTP в рабочем окружении,
+Memory в тестах и при локальном запуске без почтового сервера.
+*/
+package mail
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrSend = errors.New("mail send error")
+
+// Message текстовое письмо одному получателю.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+//go:generate mockgen -source=mailer.go -destination=mailer_mock.go -package=mail -mock_names Mailer=MailerMock
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mailer.go
+//
+// Generated by this command:
+//
+//	mockgen -source=mailer.go -destination=mailer_mock.go -package=mail -mock_names Mailer=MailerMock
+//
+
+// Package mail is a generated GoMock package.
+package mail
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MailerMock is a mock of Mailer interface.
+type MailerMock struct {
+	ctrl     *gomock.Controller
+	recorder *MailerMockMockRecorder
+}
+
+// MailerMockMockRecorder is the mock recorder for MailerMock.
+type MailerMockMockRecorder struct {
+	mock *MailerMock
+}
+
+// NewMailerMock creates a new mock instance.
+func NewMailerMock(ctrl *gomock.Controller) *MailerMock {
+	mock := &MailerMock{ctrl: ctrl}
+	mock.recorder = &MailerMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MailerMock) EXPECT() *MailerMockMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MailerMock) Send(ctx context.Context, msg Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MailerMockMockRecorder) Send(ctx, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MailerMock)(nil).Send), ctx, msg)
+}
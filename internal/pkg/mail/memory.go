@@ -0,0 +1,76 @@
+package mail
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// DefaultMemoryLimit сколько последних писем хранит Memory по умолчанию.
+const DefaultMemoryLimit = 100
+
+// Memory сохраняет письма в памяти вместо отправки. Используется в тестах и при локальном запуске.
+// Хранятся только последние limit писем, более старые отбрасываются, чтобы память процесса не росла.
+type Memory struct {
+	mu       sync.Mutex
+	limit    int
+	messages []Message
+}
+
+var _ Mailer = (*Memory)(nil)
+
+type MemoryOption func(m *Memory)
+
+// WithMemoryLimit задаёт число хранимых писем, неположительное значение оставляет значение по умолчанию.
+func WithMemoryLimit(limit int) MemoryOption {
+	return func(m *Memory) {
+		if limit > 0 {
+			m.limit = limit
+		}
+	}
+}
+
+func NewMemory(opts ...MemoryOption) *Memory {
+	m := &Memory{limit: DefaultMemoryLimit}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+func (m *Memory) Send(_ context.Context, msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.messages) >= m.limit {
+		m.messages = slices.Delete(m.messages, 0, len(m.messages)-m.limit+1)
+	}
+
+	m.messages = append(m.messages, msg)
+
+	return nil
+}
+
+// Messages отправленные письма в порядке отправки.
+func (m *Memory) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.messages)
+}
+
+// Last последнее письмо получателю to.
+func (m *Memory) Last(to string) (Message, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := len(m.messages) - 1; i >= 0; i-- {
+		if m.messages[i].To == to {
+			return m.messages[i], true
+		}
+	}
+
+	return Message{}, false
+}
@@ -0,0 +1,123 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// ConfigSMTP параметры почтового сервера. Без Username письма отправляются без аутентификации.
+type ConfigSMTP struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// From адрес отправителя.
+	From string
+}
+
+// SMTP отправляет письма через почтовый сервер. Если сервер поддерживает STARTTLS, соединение шифруется.
+type SMTP struct {
+	cfg ConfigSMTP
+	now func() time.Time
+}
+
+var _ Mailer = (*SMTP)(nil)
+
+func NewSMTP(cfg ConfigSMTP) *SMTP {
+	return &SMTP{cfg: cfg, now: time.Now}
+}
+
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	if err := s.send(ctx, msg); err != nil {
+		return fmt.Errorf("%w: %s", ErrSend, err.Error())
+	}
+
+	return nil
+}
+
+func (s *SMTP) send(ctx context.Context, msg Message) error {
+	addr := net.JoinHostPort(s.cfg.Host, fmt.Sprint(s.cfg.Port))
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+
+		return err
+	}
+
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err = c.StartTLS(&tls.Config{ServerName: s.cfg.Host, MinVersion: tls.VersionTLS12}); err != nil {
+			return err
+		}
+	}
+
+	if s.cfg.Username != "" {
+		if err = c.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err = c.Mail(s.cfg.From); err != nil {
+		return err
+	}
+
+	if err = c.Rcpt(msg.To); err != nil {
+		return err
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err = w.Write(s.compose(msg)); err != nil {
+		return err
+	}
+
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+// compose собирает письмо в формате RFC 5322. Тема кодируется по RFC 2047, тело передаётся в UTF-8.
+func (s *SMTP) compose(msg Message) []byte {
+	var b bytes.Buffer
+
+	header := func(key, value string) {
+		// переводы строк в заголовках позволили бы внедрить свои заголовки
+		value = strings.NewReplacer("\r", "", "\n", "").Replace(value)
+		fmt.Fprintf(&b, "%s: %s\r\n", key, value)
+	}
+
+	header("From", s.cfg.From)
+	header("To", msg.To)
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", s.now().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "8bit")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+
+	return b.Bytes()
+}
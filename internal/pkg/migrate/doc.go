@@ -0,0 +1,7 @@
+// Package migrate provides versioned schema migrations for postgres.
+//
+// Migrations are read from an fs.FS (usually embed.FS) and named
+// <version>_<name>.up.sql / <version>_<name>.down.sql. Applied versions are
+// stored in the migrations table. Every run holds a postgres advisory lock, so
+// concurrently started replicas of the service apply migrations one by one.
+package migrate
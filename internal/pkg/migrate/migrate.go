@@ -0,0 +1,326 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+)
+
+const (
+	// DefaultTableName is a default name of the table with applied versions.
+	DefaultTableName = "schema_migrations"
+	// DefaultLockID is a default key of the advisory lock held during a run.
+	DefaultLockID int64 = 7_305_923_114
+	// DefaultLoggerName is a default logger name.
+	DefaultLoggerName = "migrate"
+)
+
+var (
+	ErrNoDownScript   = errors.New("migration has no down script")
+	ErrUnknownVersion = errors.New("unknown migration version")
+)
+
+// Status describes the state of a single migration.
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// Migrator applies and rolls back migrations.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	table      string
+	lockID     int64
+	log        log.Logger
+}
+
+// Option specifies configuration options of Migrator.
+type Option func(*Migrator)
+
+// WithTableName sets the name of the table with applied versions.
+func WithTableName(name string) Option {
+	return func(m *Migrator) {
+		m.table = name
+	}
+}
+
+// WithLockID sets the key of the advisory lock.
+func WithLockID(id int64) Option {
+	return func(m *Migrator) {
+		m.lockID = id
+	}
+}
+
+// WithLogger sets the logger for the Migrator.
+func WithLogger(logger log.Logger) Option {
+	return func(m *Migrator) {
+		m.log = logger
+	}
+}
+
+// New creates Migrator for migrations from fsys.
+func New(db *sql.DB, fsys fs.FS, opts ...Option) (*Migrator, error) {
+	if db == nil {
+		panic("database is nil")
+	}
+
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Migrator{
+		db:         db,
+		migrations: migrations,
+		table:      DefaultTableName,
+		lockID:     DefaultLockID,
+		log:        log.Named(DefaultLoggerName),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m, nil
+}
+
+// Latest returns the latest known version or 0 if there are no migrations.
+func (m *Migrator) Latest() uint64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies all not applied migrations.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.Latest())
+}
+
+// Down rolls back the last applied migration.
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		var last uint64
+
+		for version := range applied {
+			if version > last {
+				last = version
+			}
+		}
+
+		if last == 0 {
+			m.log.Info(ctx, "nothing to roll back")
+
+			return nil
+		}
+
+		migration, ok := m.find(last)
+		if !ok {
+			return fmt.Errorf("[migrate.Down] %w: %d", ErrUnknownVersion, last)
+		}
+
+		return m.rollback(ctx, conn, migration)
+	})
+}
+
+// To migrates the schema to the version: applies the migrations up to it or
+// rolls back the ones above it. Version 0 rolls back everything.
+func (m *Migrator) To(ctx context.Context, version uint64) error {
+	if _, ok := m.find(version); !ok && version != 0 {
+		return fmt.Errorf("[migrate.To] %w: %d", ErrUnknownVersion, version)
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for v := range applied {
+			if _, ok := m.find(v); !ok && v > version {
+				return fmt.Errorf("[migrate.To] %w: %d is applied but not found", ErrUnknownVersion, v)
+			}
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; ok && migration.Version > version {
+				if err = m.rollback(ctx, conn, migration); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; !ok && migration.Version <= version {
+				if err = m.apply(ctx, conn, migration); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// Status returns the state of every known migration.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		statuses = make([]Status, 0, len(m.migrations))
+
+		for _, migration := range m.migrations {
+			status := Status{Migration: migration}
+
+			if appliedAt, ok := applied[migration.Version]; ok {
+				status.Applied = true
+				status.AppliedAt = &appliedAt
+			}
+
+			statuses = append(statuses, status)
+		}
+
+		return nil
+	})
+
+	return statuses, err
+}
+
+func (m *Migrator) find(version uint64) (Migration, bool) {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+
+	return Migration{}, false
+}
+
+// withLock runs fn on a dedicated connection holding the advisory lock.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("[migrate] get connection: %w", err)
+	}
+
+	defer conn.Close()
+
+	if _, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", m.lockID); err != nil {
+		return fmt.Errorf("[migrate] advisory lock: %w", err)
+	}
+
+	defer func() {
+		// разблокируем даже при отменённом контексте, иначе блокировка останется на соединении в пуле
+		if _, unlockErr := conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", m.lockID); unlockErr != nil {
+			err = errors.Join(err, fmt.Errorf("[migrate] advisory unlock: %w", unlockErr))
+		}
+	}()
+
+	_, err = conn.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %q (
+	version bigint PRIMARY KEY,
+	name text NOT NULL,
+	applied_at timestamptz NOT NULL DEFAULT now()
+)`, m.table))
+	if err != nil {
+		return fmt.Errorf("[migrate] create migrations table: %w", err)
+	}
+
+	return fn(conn)
+}
+
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[uint64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf(`SELECT version, applied_at FROM %q`, m.table))
+	if err != nil {
+		return nil, fmt.Errorf("[migrate] select applied versions: %w", err)
+	}
+
+	defer rows.Close()
+
+	applied := make(map[uint64]time.Time)
+
+	for rows.Next() {
+		var (
+			version   uint64
+			appliedAt time.Time
+		)
+
+		if err = rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("[migrate] scan applied version: %w", err)
+		}
+
+		applied[version] = appliedAt
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("[migrate] select applied versions: %w", err)
+	}
+
+	return applied, nil
+}
+
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	l := m.log.With(log.Uint64("version", migration.Version), log.String("name", migration.Name))
+	l.Info(ctx, "applying migration")
+
+	err := m.inTx(ctx, conn, migration.Up,
+		fmt.Sprintf(`INSERT INTO %q (version, name) VALUES ($1, $2)`, m.table), migration.Version, migration.Name)
+	if err != nil {
+		return fmt.Errorf("[migrate] apply %d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	return nil
+}
+
+func (m *Migrator) rollback(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	if migration.Down == "" {
+		return fmt.Errorf("[migrate] rollback %d_%s: %w", migration.Version, migration.Name, ErrNoDownScript)
+	}
+
+	l := m.log.With(log.Uint64("version", migration.Version), log.String("name", migration.Name))
+	l.Info(ctx, "rolling back migration")
+
+	err := m.inTx(ctx, conn, migration.Down,
+		fmt.Sprintf(`DELETE FROM %q WHERE version = $1`, m.table), migration.Version)
+	if err != nil {
+		return fmt.Errorf("[migrate] rollback %d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	return nil
+}
+
+// inTx executes the migration script and the versions table change in one transaction.
+func (m *Migrator) inTx(ctx context.Context, conn *sql.Conn, script, versionQuery string, args ...any) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, script); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	if _, err = tx.ExecContext(ctx, versionQuery, args...); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,146 @@
+package migrate_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/migrate"
+)
+
+const lockID = 42
+
+//nolint:gochecknoglobals // сделано чисто для тестов
+var migrations = fstest.MapFS{
+	"0001_first.up.sql":    {Data: []byte("CREATE TABLE first ()")},
+	"0001_first.down.sql":  {Data: []byte("DROP TABLE first")},
+	"0002_second.up.sql":   {Data: []byte("CREATE TABLE second ()")},
+	"0002_second.down.sql": {Data: []byte("DROP TABLE second")},
+}
+
+func newMigrator(t *testing.T) (*migrate.Migrator, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	require.NoError(t, err)
+
+	m, err := migrate.New(db, migrations, migrate.WithLockID(lockID), migrate.WithTableName("versions"))
+	require.NoError(t, err)
+
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).WithArgs(lockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE IF NOT EXISTS "versions"`)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	return m, mock
+}
+
+func expectApplied(mock sqlmock.Sqlmock, versions ...int64) {
+	rows := sqlmock.NewRows([]string{"version", "applied_at"})
+	for _, v := range versions {
+		rows.AddRow(v, time.Time{})
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT version, applied_at FROM "versions"`)).WillReturnRows(rows)
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_unlock($1)")).WithArgs(lockID).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func TestMigrator_Up(t *testing.T) {
+	t.Parallel()
+
+	m, mock := newMigrator(t)
+
+	expectApplied(mock, 1)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE second ()")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "versions" (version, name) VALUES ($1, $2)`)).
+		WithArgs(2, "second").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	require.NoError(t, m.Up(context.Background()))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_UpError(t *testing.T) {
+	t.Parallel()
+
+	m, mock := newMigrator(t)
+
+	expectApplied(mock)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE first ()")).WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+	expectUnlock(mock)
+
+	require.ErrorIs(t, m.Up(context.Background()), assert.AnError)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Down(t *testing.T) {
+	t.Parallel()
+
+	m, mock := newMigrator(t)
+
+	expectApplied(mock, 1, 2)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DROP TABLE second")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "versions" WHERE version = $1`)).
+		WithArgs(2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	require.NoError(t, m.Down(context.Background()))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_To(t *testing.T) {
+	t.Parallel()
+
+	m, mock := newMigrator(t)
+
+	require.ErrorIs(t, m.To(context.Background(), 3), migrate.ErrUnknownVersion)
+
+	expectApplied(mock, 1, 2)
+
+	for _, script := range []string{"DROP TABLE second", "DROP TABLE first"} {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(script)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "versions"`)).WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+
+	expectUnlock(mock)
+
+	require.NoError(t, m.To(context.Background(), 0))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Status(t *testing.T) {
+	t.Parallel()
+
+	m, mock := newMigrator(t)
+
+	expectApplied(mock, 1)
+	expectUnlock(mock)
+
+	statuses, err := m.Status(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	require.Len(t, statuses, 2)
+	assert.True(t, statuses[0].Applied)
+	assert.NotNil(t, statuses[0].AppliedAt)
+	assert.False(t, statuses[1].Applied)
+	assert.Nil(t, statuses[1].AppliedAt)
+	assert.Equal(t, uint64(2), m.Latest())
+}
@@ -0,0 +1,106 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Migration is a single versioned schema change.
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+var (
+	ErrInvalidFileName  = errors.New("invalid migration file name")
+	ErrDuplicateVersion = errors.New("duplicate migration version")
+	ErrNoUpScript       = errors.New("migration has no up script")
+)
+
+var fileNameRe = regexp.MustCompile(`^(\d+)_([\w-]+)\.(up|down)\.sql$`)
+
+// Load reads migrations from the root of fsys and returns them sorted by version.
+// Files not ending with .sql are skipped.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("[migrate.Load] %w", err)
+	}
+
+	byVersion := make(map[uint64]*Migration)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		name := entry.Name()
+
+		m := fileNameRe.FindStringSubmatch(name)
+		if m == nil {
+			if strings.HasSuffix(name, ".sql") {
+				return nil, fmt.Errorf("[migrate.Load] %w: %s", ErrInvalidFileName, name)
+			}
+
+			continue
+		}
+
+		version, err := strconv.ParseUint(m[1], 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("[migrate.Load] %w: %s", ErrInvalidFileName, name)
+		}
+
+		script, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("[migrate.Load] %w", err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = migration
+		}
+
+		if migration.Name != m[2] {
+			return nil, fmt.Errorf("[migrate.Load] %w: %d", ErrDuplicateVersion, version)
+		}
+
+		switch m[3] {
+		case "up":
+			if migration.Up != "" {
+				return nil, fmt.Errorf("[migrate.Load] %w: %d", ErrDuplicateVersion, version)
+			}
+
+			migration.Up = string(script)
+		case "down":
+			if migration.Down != "" {
+				return nil, fmt.Errorf("[migrate.Load] %w: %d", ErrDuplicateVersion, version)
+			}
+
+			migration.Down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("[migrate.Load] %w: %d_%s", ErrNoUpScript, migration.Version, migration.Name)
+		}
+
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
@@ -0,0 +1,79 @@
+package migrate_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/migrate"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name   string
+		in     fstest.MapFS
+		exp    []migrate.Migration
+		expErr error
+	}
+
+	tcs := []testCase{
+		{
+			name: "sorted migrations",
+			in: fstest.MapFS{
+				"0002_second.up.sql":   {Data: []byte("up 2")},
+				"0001_first.up.sql":    {Data: []byte("up 1")},
+				"0001_first.down.sql":  {Data: []byte("down 1")},
+				"README.md":            {Data: []byte("skipped")},
+				"nested/0003_x.up.sql": {Data: []byte("skipped")},
+			},
+			exp: []migrate.Migration{
+				{Version: 1, Name: "first", Up: "up 1", Down: "down 1"},
+				{Version: 2, Name: "second", Up: "up 2"},
+			},
+		},
+		{
+			name:   "invalid file name",
+			in:     fstest.MapFS{"first.up.sql": {Data: []byte("up")}},
+			expErr: migrate.ErrInvalidFileName,
+		},
+		{
+			name:   "zero version",
+			in:     fstest.MapFS{"0000_zero.up.sql": {Data: []byte("up")}},
+			expErr: migrate.ErrInvalidFileName,
+		},
+		{
+			name: "duplicate version",
+			in: fstest.MapFS{
+				"0001_first.up.sql":  {Data: []byte("up")},
+				"0001_second.up.sql": {Data: []byte("up")},
+			},
+			expErr: migrate.ErrDuplicateVersion,
+		},
+		{
+			name:   "down without up",
+			in:     fstest.MapFS{"0001_first.down.sql": {Data: []byte("down")}},
+			expErr: migrate.ErrNoUpScript,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := migrate.Load(tc.in)
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.exp, out)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package passcrypto
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const argon2idPrefix = "$argon2id$"
+
+// Argon2idParams параметры argon2id. Memory задаётся в КиБ.
+type Argon2idParams struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2idParams минимальные параметры argon2id, рекомендованные OWASP: 19 МиБ, 2 прохода, 1 поток.
+var DefaultArgon2idParams = Argon2idParams{
+	Memory:      19 * 1024,
+	Iterations:  2,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// Argon2idLimits верхние границы параметров argon2id. Параметры проверяемого хеша берутся из него самого,
+// поэтому без границ испорченный или подобранный хеш заставит вход выделить гигабайты памяти или считать минутами.
+type Argon2idLimits struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	KeyLength   uint32
+}
+
+// DefaultArgon2idLimits границы по умолчанию: 256 МиБ, 16 проходов, 16 потоков, ключ до 64 байт.
+var DefaultArgon2idLimits = Argon2idLimits{
+	Memory:      256 * 1024,
+	Iterations:  16,
+	Parallelism: 16,
+	KeyLength:   64,
+}
+
+func (p Argon2idParams) validate() error {
+	if p.Memory < 8*uint32(p.Parallelism) || p.Iterations < 1 || p.Parallelism < 1 || p.SaltLength < 8 || p.KeyLength < 16 {
+		return fmt.Errorf("%w: argon2id m=%d,t=%d,p=%d", ErrInvalidParams, p.Memory, p.Iterations, p.Parallelism)
+	}
+
+	return nil
+}
+
+// within проверяет, что параметры не превышают границы.
+func (p Argon2idParams) within(l Argon2idLimits) error {
+	if p.Memory > l.Memory || p.Iterations > l.Iterations || p.Parallelism > l.Parallelism || p.KeyLength > l.KeyLength {
+		return fmt.Errorf("%w: argon2id m=%d,t=%d,p=%d exceeds limits", ErrInvalidParams, p.Memory, p.Iterations, p.Parallelism)
+	}
+
+	return nil
+}
+
+// withoutSalt параметры, которые можно восстановить из хеша: длина соли и ключа в хеше не кодируется явно.
+func (p Argon2idParams) withoutSalt() Argon2idParams {
+	return Argon2idParams{Memory: p.Memory, Iterations: p.Iterations, Parallelism: p.Parallelism, KeyLength: p.KeyLength}
+}
+
+// hashArgon2id кодирует хеш в формате PHC: $argon2id$v=19$m=19456,t=2,p=1$<соль>$<ключ>.
+func hashArgon2id(pwd []byte, p Argon2idParams) (string, error) {
+	salt := make([]byte, p.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrPasswordHashing, err.Error())
+	}
+
+	key := argon2.IDKey(pwd, salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version, p.Memory, p.Iterations, p.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// compareArgon2id сверяет пароль с хешем. Хеш с параметрами сверх границ отклоняется до вычисления ключа.
+func compareArgon2id(hashedPwd string, plainPwd []byte, limits Argon2idLimits) bool {
+	p, salt, key, err := decodeArgon2id(hashedPwd)
+	if err != nil || p.within(limits) != nil {
+		return false
+	}
+
+	other := argon2.IDKey(plainPwd, salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+
+	return subtle.ConstantTimeCompare(key, other) == 1
+}
+
+// decodeArgon2id разбирает хеш в формате PHC. В параметрах заполняются все поля, кроме SaltLength.
+func decodeArgon2id(hashedPwd string) (Argon2idParams, []byte, []byte, error) {
+	var (
+		p       Argon2idParams
+		version int
+	)
+
+	parts := strings.Split(strings.TrimPrefix(hashedPwd, argon2idPrefix), "$")
+	if len(parts) != 4 {
+		return p, nil, nil, fmt.Errorf("%w: argon2id hash format", ErrInvalidParams)
+	}
+
+	if _, err := fmt.Sscanf(parts[0], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, nil, nil, fmt.Errorf("%w: argon2id version", ErrInvalidParams)
+	}
+
+	if _, err := fmt.Sscanf(parts[1], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism); err != nil {
+		return p, nil, nil, fmt.Errorf("%w: argon2id params", ErrInvalidParams)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return p, nil, nil, fmt.Errorf("%w: argon2id salt", ErrInvalidParams)
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil || len(key) == 0 {
+		return p, nil, nil, fmt.Errorf("%w: argon2id key", ErrInvalidParams)
+	}
+
+	p.KeyLength = uint32(len(key))
+
+	return p, salt, key, nil
+}
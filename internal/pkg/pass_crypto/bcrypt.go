@@ -0,0 +1,47 @@
+package passcrypto
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// DefaultBcryptCost стоимость bcrypt по умолчанию.
+const DefaultBcryptCost = 12
+
+func validateBcryptCost(cost int) error {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return fmt.Errorf("%w: bcrypt cost must be in [%d, %d]", ErrInvalidParams, bcrypt.MinCost, bcrypt.MaxCost)
+	}
+
+	return nil
+}
+
+func hashBcrypt(pwd []byte, cost int) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword(pwd, cost)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrPasswordHashing, err.Error())
+	}
+
+	return string(hash), nil
+}
+
+func compareBcrypt(hashedPwd string, plainPwd []byte) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hashedPwd), plainPwd) == nil
+}
+
+func isBcrypt(hashedPwd string) bool {
+	_, err := bcrypt.Cost([]byte(hashedPwd))
+
+	return err == nil
+}
+
+// bcryptCost стоимость из хеша bcrypt либо 0, если это не хеш bcrypt.
+func bcryptCost(hashedPwd string) int {
+	cost, err := bcrypt.Cost([]byte(hashedPwd))
+	if err != nil {
+		return 0
+	}
+
+	return cost
+}
@@ -3,37 +3,142 @@ package passcrypto
 import (
 	"errors"
 	"fmt"
-
-	"golang.org/x/crypto/bcrypt"
+	"strings"
 )
 
 //go:generate mockgen -source=hasher.go -destination=hasher_mock.go -package=passcrypto -mock_names PasswordHashable=PasswordHashMock
 type PasswordHashable interface {
 	HashAndSalt(pwd []byte) (string, error)
 	ComparePasswords(hashedPwd string, plainPwd []byte) bool
+	// NeedsRehash сообщает, что хеш получен другим алгоритмом или с другими параметрами,
+	// чем текущие, и его стоит пересчитать при следующей проверке пароля.
+	NeedsRehash(hashedPwd string) bool
+}
+
+// Algorithm алгоритм хеширования новых паролей.
+type Algorithm string
+
+const (
+	AlgorithmArgon2id Algorithm = "argon2id"
+	AlgorithmBcrypt   Algorithm = "bcrypt"
+)
+
+var (
+	ErrPasswordHashing  = errors.New("password hashing error")
+	ErrUnknownAlgorithm = errors.New("unknown password hashing algorithm")
+	ErrInvalidParams    = errors.New("invalid password hashing params")
+)
+
+// Hasher хеширует пароли выбранным алгоритмом и проверяет хеши всех поддерживаемых алгоритмов.
+// Алгоритм и параметры хранятся в самом хеше, поэтому смена настроек не ломает вход с прежними хешами.
+type Hasher struct {
+	algorithm Algorithm
+	argon2id  Argon2idParams
+	limits    Argon2idLimits
+	bcrypt    int
+}
+
+var _ PasswordHashable = (*Hasher)(nil)
+
+// Option specifies configuration options of Hasher.
+type Option func(*Hasher)
+
+// WithAlgorithm задаёт алгоритм хеширования новых паролей.
+func WithAlgorithm(algorithm Algorithm) Option {
+	return func(h *Hasher) {
+		h.algorithm = algorithm
+	}
 }
 
-type defaultPasswordHasher struct{}
+// WithArgon2idParams задаёт параметры argon2id.
+func WithArgon2idParams(params Argon2idParams) Option {
+	return func(h *Hasher) {
+		h.argon2id = params
+	}
+}
 
-var ErrPasswordHashing = errors.New("password hashing error")
+// WithArgon2idLimits задаёт верхние границы параметров argon2id для проверяемых хешей.
+func WithArgon2idLimits(limits Argon2idLimits) Option {
+	return func(h *Hasher) {
+		h.limits = limits
+	}
+}
+
+// WithBcryptCost задаёт стоимость bcrypt.
+func WithBcryptCost(cost int) Option {
+	return func(h *Hasher) {
+		h.bcrypt = cost
+	}
+}
+
+// defaultPasswordHasher хешер по умолчанию: argon2id с параметрами DefaultArgon2idParams.
+var defaultPasswordHasher = &Hasher{
+	algorithm: AlgorithmArgon2id,
+	argon2id:  DefaultArgon2idParams,
+	limits:    DefaultArgon2idLimits,
+	bcrypt:    DefaultBcryptCost,
+}
+
+// NewHasher создаёт Hasher. По умолчанию новые пароли хешируются argon2id с параметрами DefaultArgon2idParams.
+func NewHasher(opts ...Option) (*Hasher, error) {
+	h := *defaultPasswordHasher
+
+	for _, opt := range opts {
+		opt(&h)
+	}
+
+	switch h.algorithm {
+	case AlgorithmArgon2id, AlgorithmBcrypt:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAlgorithm, h.algorithm)
+	}
+
+	if err := h.argon2id.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := h.argon2id.within(h.limits); err != nil {
+		return nil, err
+	}
+
+	if err := validateBcryptCost(h.bcrypt); err != nil {
+		return nil, err
+	}
+
+	return &h, nil
+}
 
 // HashAndSalt Hashes a given string
-func (d defaultPasswordHasher) HashAndSalt(pwd []byte) (string, error) {
+func (h *Hasher) HashAndSalt(pwd []byte) (string, error) {
+	if h.algorithm == AlgorithmBcrypt {
+		return hashBcrypt(pwd, h.bcrypt)
+	}
+
+	return hashArgon2id(pwd, h.argon2id)
+}
 
-	hash, err := bcrypt.GenerateFromPassword(pwd, bcrypt.MinCost)
-	if err != nil {
-		return "", fmt.Errorf("%w: %s", ErrPasswordHashing, err.Error())
+func (h *Hasher) ComparePasswords(hashedPwd string, plainPwd []byte) bool {
+	if isArgon2id(hashedPwd) {
+		return compareArgon2id(hashedPwd, plainPwd, h.limits)
 	}
 
-	return string(hash), nil
+	return compareBcrypt(hashedPwd, plainPwd)
 }
 
-func (d defaultPasswordHasher) ComparePasswords(hashedPwd string, plainPwd []byte) bool {
-	byteHash := []byte(hashedPwd)
-	err := bcrypt.CompareHashAndPassword(byteHash, plainPwd)
-	if err != nil {
-		return false
+func (h *Hasher) NeedsRehash(hashedPwd string) bool {
+	if h.algorithm == AlgorithmBcrypt {
+		return !isBcrypt(hashedPwd) || bcryptCost(hashedPwd) != h.bcrypt
 	}
 
-	return true
+	if !isArgon2id(hashedPwd) {
+		return true
+	}
+
+	params, _, _, err := decodeArgon2id(hashedPwd)
+
+	return err != nil || params != h.argon2id.withoutSalt()
+}
+
+func isArgon2id(hashedPwd string) bool {
+	return strings.HasPrefix(hashedPwd, argon2idPrefix)
 }
@@ -66,3 +66,17 @@ func (mr *PasswordHashMockMockRecorder) HashAndSalt(pwd any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashAndSalt", reflect.TypeOf((*PasswordHashMock)(nil).HashAndSalt), pwd)
 }
+
+// NeedsRehash mocks base method.
+func (m *PasswordHashMock) NeedsRehash(hashedPwd string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NeedsRehash", hashedPwd)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NeedsRehash indicates an expected call of NeedsRehash.
+func (mr *PasswordHashMockMockRecorder) NeedsRehash(hashedPwd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedsRehash", reflect.TypeOf((*PasswordHashMock)(nil).NeedsRehash), hashedPwd)
+}
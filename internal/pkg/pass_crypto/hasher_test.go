@@ -0,0 +1,132 @@
+package passcrypto_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	passCrypto "github.com/smgladkovskiy/warehouse-task/internal/pkg/pass_crypto"
+)
+
+// fastArgon2id параметры, при которых тесты не тратят время на честное хеширование.
+var fastArgon2id = passCrypto.Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestHasher_HashAndCompare(t *testing.T) {
+	t.Parallel()
+
+	tcs := map[string][]passCrypto.Option{
+		"argon2id": {passCrypto.WithArgon2idParams(fastArgon2id)},
+		"bcrypt":   {passCrypto.WithAlgorithm(passCrypto.AlgorithmBcrypt), passCrypto.WithBcryptCost(4)},
+	}
+
+	for name, opts := range tcs {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			h, err := passCrypto.NewHasher(opts...)
+			require.NoError(t, err)
+
+			hash, err := h.HashAndSalt([]byte("secret_password"))
+			require.NoError(t, err)
+
+			other, err := h.HashAndSalt([]byte("secret_password"))
+			require.NoError(t, err)
+			assert.NotEqual(t, hash, other, "salt is random")
+
+			assert.True(t, h.ComparePasswords(hash, []byte("secret_password")))
+			assert.False(t, h.ComparePasswords(hash, []byte("wrong_password")))
+			assert.False(t, h.NeedsRehash(hash))
+		})
+	}
+}
+
+func TestHasher_Argon2idEncoding(t *testing.T) {
+	t.Parallel()
+
+	h, err := passCrypto.NewHasher(passCrypto.WithArgon2idParams(fastArgon2id))
+	require.NoError(t, err)
+
+	hash, err := h.HashAndSalt([]byte("secret_password"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"), hash)
+
+	for _, broken := range []string{
+		"",
+		"$argon2id$v=19$m=64,t=1,p=1$",
+		"$argon2id$v=18$m=64,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=x,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=64,t=1,p=1$!!!$a2V5",
+	} {
+		assert.False(t, h.ComparePasswords(broken, []byte("secret_password")), broken)
+		assert.True(t, h.NeedsRehash(broken), broken)
+	}
+}
+
+func TestHasher_Argon2idLimits(t *testing.T) {
+	t.Parallel()
+
+	h, err := passCrypto.NewHasher(passCrypto.WithArgon2idParams(fastArgon2id))
+	require.NoError(t, err)
+
+	hash, err := h.HashAndSalt([]byte("secret_password"))
+	require.NoError(t, err)
+
+	// подменённые параметры потребовали бы 4 ТиБ памяти и 4 млрд проходов: хеш отклоняется без вычисления ключа
+	for _, params := range []string{"m=4294967295,t=1,p=1", "m=64,t=4294967295,p=1", "m=2048,t=1,p=255"} {
+		oversized := strings.Replace(hash, "m=64,t=1,p=1", params, 1)
+
+		assert.False(t, h.ComparePasswords(oversized, []byte("secret_password")), oversized)
+		assert.True(t, h.NeedsRehash(oversized), oversized)
+	}
+
+	strict, err := passCrypto.NewHasher(
+		passCrypto.WithArgon2idParams(fastArgon2id),
+		passCrypto.WithArgon2idLimits(passCrypto.Argon2idLimits{Memory: 32, Iterations: 1, Parallelism: 1, KeyLength: 32}),
+	)
+	require.ErrorIs(t, err, passCrypto.ErrInvalidParams, "own params must fit the limits")
+	assert.Nil(t, strict)
+}
+
+func TestHasher_NeedsRehash(t *testing.T) {
+	t.Parallel()
+
+	bcrypt4, err := passCrypto.NewHasher(passCrypto.WithAlgorithm(passCrypto.AlgorithmBcrypt), passCrypto.WithBcryptCost(4))
+	require.NoError(t, err)
+	bcrypt5, err := passCrypto.NewHasher(passCrypto.WithAlgorithm(passCrypto.AlgorithmBcrypt), passCrypto.WithBcryptCost(5))
+	require.NoError(t, err)
+	argon, err := passCrypto.NewHasher(passCrypto.WithArgon2idParams(fastArgon2id))
+	require.NoError(t, err)
+
+	stronger := fastArgon2id
+	stronger.Iterations = 2
+	argonStronger, err := passCrypto.NewHasher(passCrypto.WithArgon2idParams(stronger))
+	require.NoError(t, err)
+
+	bcryptHash, err := bcrypt4.HashAndSalt([]byte("secret_password"))
+	require.NoError(t, err)
+	argonHash, err := argon.HashAndSalt([]byte("secret_password"))
+	require.NoError(t, err)
+
+	assert.True(t, bcrypt5.NeedsRehash(bcryptHash), "bcrypt cost changed")
+	assert.True(t, argon.NeedsRehash(bcryptHash), "algorithm changed")
+	assert.True(t, bcrypt4.NeedsRehash(argonHash), "algorithm changed")
+	assert.True(t, argonStronger.NeedsRehash(argonHash), "argon2id params changed")
+
+	assert.True(t, argon.ComparePasswords(bcryptHash, []byte("secret_password")), "legacy hashes are still accepted")
+	assert.True(t, bcrypt4.ComparePasswords(argonHash, []byte("secret_password")))
+}
+
+func TestNewHasher_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := passCrypto.NewHasher(passCrypto.WithAlgorithm("md5"))
+	require.ErrorIs(t, err, passCrypto.ErrUnknownAlgorithm)
+
+	_, err = passCrypto.NewHasher(passCrypto.WithBcryptCost(40))
+	require.ErrorIs(t, err, passCrypto.ErrInvalidParams)
+
+	_, err = passCrypto.NewHasher(passCrypto.WithArgon2idParams(passCrypto.Argon2idParams{Memory: 64}))
+	require.ErrorIs(t, err, passCrypto.ErrInvalidParams)
+}
@@ -13,7 +13,7 @@ var _ WithPasswordHasherable = (*WithPasswordHasher)(nil)
 
 func (w *WithPasswordHasher) GetHasher() PasswordHashable {
 	if w.hasher == nil {
-		w.hasher = defaultPasswordHasher{}
+		w.hasher = defaultPasswordHasher
 	}
 
 	return w.hasher
@@ -21,7 +21,7 @@ func (w *WithPasswordHasher) GetHasher() PasswordHashable {
 
 func (w *WithPasswordHasher) SetHasher(hashFunc PasswordHashable) {
 	if hashFunc == nil {
-		hashFunc = defaultPasswordHasher{}
+		hashFunc = defaultPasswordHasher
 	}
 
 	w.hasher = hashFunc
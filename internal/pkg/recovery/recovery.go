@@ -0,0 +1,184 @@
+/*
+Package recovery перехватывает паники в HTTP-обработчиках и фоновых задачах
+и отправляет их в Sentry со стеком вызовов, данными запроса, пользователем и трассировкой.
+
+Пользователь и спан берутся из контекста, который видит перехвативший панику Middleware или Do.
+Поэтому Recoverer ставят дважды: снаружи, чтобы перехватывать паники и в аутентификации,
+и внутри аутентификации и трассировки, чтобы паника обработчика попала в Sentry с пользователем и спаном.
+Паника перехватывается ближайшим к ней Recoverer-ом.
+*/
+package recovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/getsentry/sentry-go"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+)
+
+// ErrPanic возвращается вместо паники, перехваченной в фоновой задаче.
+var ErrPanic = errors.New("panic recovered")
+
+// UserIDFunc возвращает идентификатор пользователя из контекста запроса либо пустую строку.
+type UserIDFunc func(ctx context.Context) string
+
+type Option func(*Recoverer)
+
+// WithUserID задаёт получение пользователя, который будет указан в событии Sentry.
+func WithUserID(fn UserIDFunc) Option {
+	return func(rc *Recoverer) {
+		rc.userID = fn
+	}
+}
+
+type Recoverer struct {
+	client *sentry.Client
+	logger log.Logger
+	userID UserIDFunc
+}
+
+// New создаёт Recoverer. Если client равен nil, паники только логируются.
+func New(client *sentry.Client, logger log.Logger, opts ...Option) *Recoverer {
+	if logger == nil {
+		panic("nil logger")
+	}
+
+	rc := &Recoverer{
+		client: client,
+		logger: logger,
+		userID: func(context.Context) string { return "" },
+	}
+
+	for _, opt := range opts {
+		opt(rc)
+	}
+
+	return rc
+}
+
+// Middleware перехватывает панику обработчика и отвечает клиенту 500.
+// http.ErrAbortHandler пробрасывается дальше: им net/http прерывает ответ намеренно.
+// Пользователь и спан события берутся из контекста запроса, с которым вызван Middleware.
+func (rc *Recoverer) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &responseWriter{ResponseWriter: w}
+
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			rc.capture(r.Context(), rec, func(scope *sentry.Scope) {
+				scope.SetRequest(r)
+				scope.SetTag("http.method", r.Method)
+			})
+
+			// заголовки уже отправлены, изменить код ответа нельзя
+			if rw.wroteHeader {
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":"Internal Server Error"}` + "\n"))
+		}()
+
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// Do выполняет задачу name, перехватывая панику. Паника возвращается ошибкой ErrPanic.
+func (rc *Recoverer) Do(ctx context.Context, name string, fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		rec := recover()
+		if rec == nil {
+			return
+		}
+
+		rc.capture(ctx, rec, func(scope *sentry.Scope) {
+			scope.SetTag("worker", name)
+		})
+
+		err = fmt.Errorf("[recovery.Do %s]: %w: %v", name, ErrPanic, rec)
+	}()
+
+	return fn(ctx)
+}
+
+// Go запускает задачу name в отдельной горутине. Паника в задаче не останавливает процесс,
+// ошибка задачи логируется.
+func (rc *Recoverer) Go(ctx context.Context, name string, fn func(ctx context.Context) error) {
+	go func() {
+		if err := rc.Do(ctx, name, fn); err != nil && !errors.Is(err, ErrPanic) {
+			rc.logger.Error(ctx, "background task error", log.String("worker", name), log.Err(err))
+		}
+	}()
+}
+
+func (rc *Recoverer) capture(ctx context.Context, rec any, configure func(scope *sentry.Scope)) {
+	err, ok := rec.(error)
+	if !ok {
+		// ошибка без стека: Sentry приложит стек текущей горутины, в котором есть место паники
+		err = fmt.Errorf("panic: %v", rec)
+	}
+
+	fields := []log.Field{log.Err(err)}
+
+	if rc.client == nil {
+		rc.logger.Error(ctx, "panic recovered", fields...)
+
+		return
+	}
+
+	hub := sentry.NewHub(rc.client, sentry.NewScope())
+	scope := hub.Scope()
+	configure(scope)
+
+	if id := rc.userID(ctx); id != "" {
+		scope.SetUser(sentry.User{ID: id})
+	}
+
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		scope.SetTag("trace_id", sc.TraceID().String())
+		scope.SetTag("span_id", sc.SpanID().String())
+	}
+
+	if eventID := hub.RecoverWithContext(ctx, err); eventID != nil {
+		fields = append(fields, log.String("sentryEventID", string(*eventID)))
+	}
+
+	// событие уже отправлено в Sentry, поэтому не Error: иначе логгер с Sentry-ядром продублирует его
+	rc.logger.Warn(ctx, "panic recovered", fields...)
+}
+
+// responseWriter запоминает, начал ли обработчик отвечать клиенту.
+type responseWriter struct {
+	http.ResponseWriter
+
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,240 @@
+package recovery_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/recovery"
+)
+
+// transportMock повторяет TransportMock из тестов sentry-go: сохраняет события вместо отправки.
+type transportMock struct {
+	mu     sync.Mutex
+	events []*sentry.Event
+}
+
+func (t *transportMock) Configure(sentry.ClientOptions) {}
+func (t *transportMock) Flush(time.Duration) bool       { return true }
+
+func (t *transportMock) SendEvent(event *sentry.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.events = append(t.events, event)
+}
+
+func (t *transportMock) Events() []*sentry.Event {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.events
+}
+
+func newRecoverer(t *testing.T, opts ...recovery.Option) (*recovery.Recoverer, *transportMock) {
+	t.Helper()
+
+	transport := &transportMock{}
+
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:       "https://public@example.com/1",
+		Transport: transport,
+	})
+	require.NoError(t, err)
+
+	return recovery.New(client, log.Named("test"), opts...), transport
+}
+
+type userIDKey struct{}
+
+func TestRecoverer_Middleware(t *testing.T) {
+	t.Parallel()
+
+	rc, transport := newRecoverer(t, recovery.WithUserID(func(ctx context.Context) string {
+		id, _ := ctx.Value(userIDKey{}).(string)
+
+		return id
+	}))
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{2},
+	})
+
+	handler := rc.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+
+	ctx := trace.ContextWithSpanContext(context.WithValue(context.Background(), userIDKey{}, "user-1"), spanContext)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.JSONEq(t, `{"error":"Internal Server Error"}`, rec.Body.String())
+
+	events := transport.Events()
+	require.Len(t, events, 1)
+
+	event := events[0]
+	assert.Equal(t, sentry.LevelFatal, event.Level)
+	assert.Equal(t, "user-1", event.User.ID)
+	assert.Equal(t, spanContext.TraceID().String(), event.Tags["trace_id"])
+	assert.Equal(t, spanContext.SpanID().String(), event.Tags["span_id"])
+	assert.Equal(t, http.MethodPost, event.Request.Method)
+	assert.True(t, strings.HasSuffix(event.Request.URL, "/api/v1/orders"))
+
+	require.NotEmpty(t, event.Exception)
+	assert.Equal(t, "panic: boom", event.Exception[0].Value)
+	require.NotNil(t, event.Exception[0].Stacktrace)
+	assert.NotEmpty(t, event.Exception[0].Stacktrace.Frames)
+}
+
+func TestRecoverer_MiddlewareRequestContext(t *testing.T) {
+	t.Parallel()
+
+	rc, transport := newRecoverer(t, recovery.WithUserID(func(ctx context.Context) string {
+		id, _ := ctx.Value(userIDKey{}).(string)
+
+		return id
+	}))
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{3},
+		SpanID:  trace.SpanID{4},
+	})
+
+	// пользователь и спан появляются в контексте внутри внешнего Middleware, как после аутентификации:
+	// панику перехватывает внутренний Middleware, который их видит
+	handler := rc.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := trace.ContextWithSpanContext(context.WithValue(r.Context(), userIDKey{}, "user-2"), spanContext)
+
+		rc.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic("boom")
+		})).ServeHTTP(w, r.WithContext(ctx))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/orders/1", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	events := transport.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, "user-2", events[0].User.ID)
+	assert.Equal(t, spanContext.TraceID().String(), events[0].Tags["trace_id"])
+	assert.Equal(t, http.MethodGet, events[0].Request.Method)
+}
+
+func TestRecoverer_MiddlewareAfterWrite(t *testing.T) {
+	t.Parallel()
+
+	rc, transport := newRecoverer(t)
+
+	handler := rc.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		panic(errors.New("late panic"))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// код ответа уже отправлен и не меняется
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	require.Len(t, transport.Events(), 1)
+	assert.Empty(t, transport.Events()[0].User)
+}
+
+func TestRecoverer_MiddlewareAbortHandler(t *testing.T) {
+	t.Parallel()
+
+	rc, transport := newRecoverer(t)
+
+	handler := rc.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+	assert.Empty(t, transport.Events())
+}
+
+func TestRecoverer_Do(t *testing.T) {
+	t.Parallel()
+
+	rc, transport := newRecoverer(t)
+
+	err := rc.Do(context.Background(), "worker", func(context.Context) error {
+		var m map[string]int
+		m["x"]++
+
+		return nil
+	})
+	require.ErrorIs(t, err, recovery.ErrPanic)
+
+	require.Len(t, transport.Events(), 1)
+	assert.Equal(t, "worker", transport.Events()[0].Tags["worker"])
+
+	require.ErrorIs(t, rc.Do(context.Background(), "worker", func(context.Context) error {
+		return assert.AnError
+	}), assert.AnError)
+	assert.Len(t, transport.Events(), 1)
+
+	rc, transport = newRecoverer(t, recovery.WithUserID(func(ctx context.Context) string {
+		id, _ := ctx.Value(userIDKey{}).(string)
+
+		return id
+	}))
+
+	require.ErrorIs(t, rc.Do(context.Background(), "grpc", func(ctx context.Context) error {
+		return rc.Do(context.WithValue(ctx, userIDKey{}, "user-3"), "grpc", func(context.Context) error {
+			panic("boom")
+		})
+	}), recovery.ErrPanic)
+
+	require.Len(t, transport.Events(), 1)
+	assert.Equal(t, "user-3", transport.Events()[0].User.ID)
+}
+
+func TestRecoverer_Go(t *testing.T) {
+	t.Parallel()
+
+	rc, transport := newRecoverer(t)
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	rc.Go(context.Background(), "worker", func(context.Context) error {
+		defer wg.Done()
+
+		panic("background boom")
+	})
+	wg.Wait()
+
+	require.Eventually(t, func() bool {
+		return len(transport.Events()) == 1
+	}, time.Second, time.Millisecond)
+}
+
+func TestRecoverer_WithoutClient(t *testing.T) {
+	t.Parallel()
+
+	rc := recovery.New(nil, log.Named("test"))
+
+	require.ErrorIs(t, rc.Do(context.Background(), "worker", func(context.Context) error {
+		panic("boom")
+	}), recovery.ErrPanic)
+}
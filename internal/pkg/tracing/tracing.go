@@ -0,0 +1,32 @@
+/*
+Package tracing запускает OpenTelemetry-спаны слоёв сервиса (юзкейсы, команды, запросы)
+через глобальный TracerProvider. Пока провайдер не установлен, спаны ничего не делают.
+*/
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName имя трейсера сервиса.
+const InstrumentationName = "github.com/smgladkovskiy/warehouse-task"
+
+// Start запускает дочерний спан name. Спан нужно завершить вызовом span.End().
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(InstrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// Error отмечает ошибку err на спане и возвращает её без изменений.
+func Error(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
@@ -0,0 +1,44 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+)
+
+// тест изменяет глобальный TracerProvider, поэтому не параллельный
+func TestStart(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctx, parent := tracing.Start(context.Background(), "usecase.test")
+
+	_, child := tracing.Start(ctx, "command.test", attribute.String("key", "value"))
+	require.ErrorIs(t, tracing.Error(child, assert.AnError), assert.AnError)
+	child.End()
+
+	require.NoError(t, tracing.Error(parent, nil))
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "command.test", spans[0].Name())
+	assert.Equal(t, trace.SpanContextFromContext(ctx).SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Contains(t, spans[0].Attributes(), attribute.String("key", "value"))
+	require.Len(t, spans[0].Events(), 1)
+
+	assert.Equal(t, "usecase.test", spans[1].Name())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
@@ -53,7 +53,11 @@ func (t *WithTransactionDB) GetQueryDB(ctx context.Context, qos queryOptions.Que
 
 	if forUpdate := qos.IsForUpdate(); forUpdate {
 		return t.WriteDBTrx(ctx).Clauses(clause.Locking{Strength: "UPDATE"})
-	} else {
-		return t.AsyncDB().WithContext(ctx)
 	}
+
+	if qos.IsFromSync() {
+		return t.SyncDB().WithContext(ctx)
+	}
+
+	return t.AsyncDB().WithContext(ctx)
 }
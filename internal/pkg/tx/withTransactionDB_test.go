@@ -81,6 +81,15 @@ func TestWithTransactionDB_GetQueryDB(t *testing.T) {
 				return dbi.AsyncDB().WithContext(ctx)
 			},
 		},
+		{
+			name: "sync db",
+			in:   queryOptions.NewBasicQueryOptions(queryOptions.WithFromSync[*queryOptions.BasicQueryOptions]()),
+			exp: func(ctx context.Context, t *testing.T, in queryOptions.QueryOptionable, dbi *db.Instance, trg *trmgorm.CtxGetter) *gorm.DB {
+				t.Helper()
+
+				return dbi.SyncDB().WithContext(ctx)
+			},
+		},
 		{
 			name: "write db for update",
 			in:   queryOptions.NewBasicQueryOptions(queryOptions.WithForUpdate[*queryOptions.BasicQueryOptions]()),
@@ -3,6 +3,7 @@ package upsertorder
 import (
 	"context"
 
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
 	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
 )
 
@@ -24,5 +25,8 @@ func NewCommandHandler(repo OrderUpserter) *CommandHandler {
 }
 
 func (h *CommandHandler) Handle(ctx context.Context, cmd Command) error {
-	return h.repo.UpsertOrder(ctx, cmd.order)
+	ctx, span := tracing.Start(ctx, "command.upsertOrder")
+	defer span.End()
+
+	return tracing.Error(span, h.repo.UpsertOrder(ctx, cmd.order))
 }
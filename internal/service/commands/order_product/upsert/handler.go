@@ -3,6 +3,7 @@ package upsertorderproduct
 import (
 	"context"
 
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
 	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
 )
 
@@ -24,5 +25,8 @@ func NewCommandHandler(repo OrderProductUpserter) *CommandHandler {
 }
 
 func (h *CommandHandler) Handle(ctx context.Context, cmd Command) error {
-	return h.repo.UpsertOrderProduct(ctx, cmd.orderProduct)
+	ctx, span := tracing.Start(ctx, "command.upsertOrderProduct")
+	defer span.End()
+
+	return tracing.Error(span, h.repo.UpsertOrderProduct(ctx, cmd.orderProduct))
 }
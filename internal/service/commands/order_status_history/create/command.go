@@ -0,0 +1,15 @@
+package createorderstatushistory
+
+import "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+
+type Command struct {
+	history *entities.OrderStatusHistory
+}
+
+func NewCommandUnsafe(history *entities.OrderStatusHistory) Command {
+	return Command{history: history}
+}
+
+func (c Command) GetHistory() *entities.OrderStatusHistory {
+	return c.history
+}
@@ -0,0 +1,32 @@
+package createorderstatushistory
+
+import (
+	"context"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+)
+
+//go:generate mockgen -source=handler.go -destination=order_status_history_creator_mock.go -package=createorderstatushistory -mock_names OrderStatusHistoryCreator=CreateOrderStatusHistoryMock
+type OrderStatusHistoryCreator interface {
+	CreateOrderStatusHistory(ctx context.Context, history *entities.OrderStatusHistory) error
+}
+
+type CommandHandler struct {
+	repo OrderStatusHistoryCreator
+}
+
+func NewCommandHandler(repo OrderStatusHistoryCreator) *CommandHandler {
+	if repo == nil {
+		panic("OrderStatusHistoryCreator repo is nil")
+	}
+
+	return &CommandHandler{repo: repo}
+}
+
+func (h *CommandHandler) Handle(ctx context.Context, cmd Command) error {
+	ctx, span := tracing.Start(ctx, "command.createOrderStatusHistory")
+	defer span.End()
+
+	return tracing.Error(span, h.repo.CreateOrderStatusHistory(ctx, cmd.history))
+}
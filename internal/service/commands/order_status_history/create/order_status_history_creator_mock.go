@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handler.go -destination=order_status_history_creator_mock.go -package=createorderstatushistory -mock_names OrderStatusHistoryCreator=CreateOrderStatusHistoryMock
+//
+
+// Package createorderstatushistory is a generated GoMock package.
+package createorderstatushistory
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// CreateOrderStatusHistoryMock is a mock of OrderStatusHistoryCreator interface.
+type CreateOrderStatusHistoryMock struct {
+	ctrl     *gomock.Controller
+	recorder *CreateOrderStatusHistoryMockMockRecorder
+}
+
+// CreateOrderStatusHistoryMockMockRecorder is the mock recorder for CreateOrderStatusHistoryMock.
+type CreateOrderStatusHistoryMockMockRecorder struct {
+	mock *CreateOrderStatusHistoryMock
+}
+
+// NewCreateOrderStatusHistoryMock creates a new mock instance.
+func NewCreateOrderStatusHistoryMock(ctrl *gomock.Controller) *CreateOrderStatusHistoryMock {
+	mock := &CreateOrderStatusHistoryMock{ctrl: ctrl}
+	mock.recorder = &CreateOrderStatusHistoryMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *CreateOrderStatusHistoryMock) EXPECT() *CreateOrderStatusHistoryMockMockRecorder {
+	return m.recorder
+}
+
+// CreateOrderStatusHistory mocks base method.
+func (m *CreateOrderStatusHistoryMock) CreateOrderStatusHistory(ctx context.Context, history *entities.OrderStatusHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderStatusHistory", ctx, history)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrderStatusHistory indicates an expected call of CreateOrderStatusHistory.
+func (mr *CreateOrderStatusHistoryMockMockRecorder) CreateOrderStatusHistory(ctx, history any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderStatusHistory", reflect.TypeOf((*CreateOrderStatusHistoryMock)(nil).CreateOrderStatusHistory), ctx, history)
+}
@@ -0,0 +1,22 @@
+package createproduct
+
+import (
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+)
+
+type Command struct {
+	product *entities.Product
+}
+
+func NewCommand(title, description string, tags []string, price int64, opts ...entities.Option[*entities.Product]) (*Command, error) {
+	product, err := entities.NewProduct(title, description, tags, price, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Command{product: product}, nil
+}
+
+func (c Command) GetProduct() *entities.Product {
+	return c.product
+}
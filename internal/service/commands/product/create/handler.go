@@ -0,0 +1,32 @@
+package createproduct
+
+import (
+	"context"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+)
+
+//go:generate mockgen -source=handler.go -destination=product_creator_mock.go -package=createproduct -mock_names ProductCreator=CreateProductMock
+type ProductCreator interface {
+	CreateProduct(ctx context.Context, product *entities.Product) error
+}
+
+type CommandHandler struct {
+	repo ProductCreator
+}
+
+func NewCommandHandler(repo ProductCreator) *CommandHandler {
+	if repo == nil {
+		panic("ProductCreator repo is nil")
+	}
+
+	return &CommandHandler{repo: repo}
+}
+
+func (h *CommandHandler) Handle(ctx context.Context, cmd Command) error {
+	ctx, span := tracing.Start(ctx, "command.createProduct")
+	defer span.End()
+
+	return tracing.Error(span, h.repo.CreateProduct(ctx, cmd.product))
+}
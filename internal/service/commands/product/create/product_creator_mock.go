@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handler.go -destination=product_creator_mock.go -package=createproduct -mock_names ProductCreator=CreateProductMock
+//
+
+// Package createproduct is a generated GoMock package.
+package createproduct
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// CreateProductMock is a mock of ProductCreator interface.
+type CreateProductMock struct {
+	ctrl     *gomock.Controller
+	recorder *CreateProductMockMockRecorder
+}
+
+// CreateProductMockMockRecorder is the mock recorder for CreateProductMock.
+type CreateProductMockMockRecorder struct {
+	mock *CreateProductMock
+}
+
+// NewCreateProductMock creates a new mock instance.
+func NewCreateProductMock(ctrl *gomock.Controller) *CreateProductMock {
+	mock := &CreateProductMock{ctrl: ctrl}
+	mock.recorder = &CreateProductMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *CreateProductMock) EXPECT() *CreateProductMockMockRecorder {
+	return m.recorder
+}
+
+// CreateProduct mocks base method.
+func (m *CreateProductMock) CreateProduct(ctx context.Context, product *entities.Product) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProduct", ctx, product)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateProduct indicates an expected call of CreateProduct.
+func (mr *CreateProductMockMockRecorder) CreateProduct(ctx, product any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*CreateProductMock)(nil).CreateProduct), ctx, product)
+}
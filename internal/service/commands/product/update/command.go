@@ -0,0 +1,11 @@
+package updateproduct
+
+import "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+
+type Command struct {
+	product *entities.Product
+}
+
+func NewCommandUnsafe(product *entities.Product) Command {
+	return Command{product: product}
+}
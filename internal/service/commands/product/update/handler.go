@@ -0,0 +1,32 @@
+package updateproduct
+
+import (
+	"context"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+)
+
+//go:generate mockgen -source=handler.go -destination=product_updater_mock.go -package=updateproduct -mock_names ProductUpdater=UpdateProductMock
+type ProductUpdater interface {
+	UpdateProduct(ctx context.Context, product *entities.Product) error
+}
+
+type CommandHandler struct {
+	repo ProductUpdater
+}
+
+func NewCommandHandler(repo ProductUpdater) *CommandHandler {
+	if repo == nil {
+		panic("ProductUpdater repo is nil")
+	}
+
+	return &CommandHandler{repo: repo}
+}
+
+func (h *CommandHandler) Handle(ctx context.Context, cmd Command) error {
+	ctx, span := tracing.Start(ctx, "command.updateProduct")
+	defer span.End()
+
+	return tracing.Error(span, h.repo.UpdateProduct(ctx, cmd.product))
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handler.go -destination=product_updater_mock.go -package=updateproduct -mock_names ProductUpdater=UpdateProductMock
+//
+
+// Package updateproduct is a generated GoMock package.
+package updateproduct
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// UpdateProductMock is a mock of ProductUpdater interface.
+type UpdateProductMock struct {
+	ctrl     *gomock.Controller
+	recorder *UpdateProductMockMockRecorder
+}
+
+// UpdateProductMockMockRecorder is the mock recorder for UpdateProductMock.
+type UpdateProductMockMockRecorder struct {
+	mock *UpdateProductMock
+}
+
+// NewUpdateProductMock creates a new mock instance.
+func NewUpdateProductMock(ctrl *gomock.Controller) *UpdateProductMock {
+	mock := &UpdateProductMock{ctrl: ctrl}
+	mock.recorder = &UpdateProductMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *UpdateProductMock) EXPECT() *UpdateProductMockMockRecorder {
+	return m.recorder
+}
+
+// UpdateProduct mocks base method.
+func (m *UpdateProductMock) UpdateProduct(ctx context.Context, product *entities.Product) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProduct", ctx, product)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProduct indicates an expected call of UpdateProduct.
+func (mr *UpdateProductMockMockRecorder) UpdateProduct(ctx, product any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*UpdateProductMock)(nil).UpdateProduct), ctx, product)
+}
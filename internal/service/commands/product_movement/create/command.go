@@ -0,0 +1,11 @@
+package createproductmovements
+
+import "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+
+type Command struct {
+	movements entities.ProductMovements
+}
+
+func NewCommandUnsafe(movements entities.ProductMovements) Command {
+	return Command{movements: movements}
+}
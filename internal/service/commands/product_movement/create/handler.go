@@ -0,0 +1,37 @@
+package createproductmovements
+
+import (
+	"context"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+)
+
+//go:generate mockgen -source=handler.go -destination=product_movements_creator_mock.go -package=createproductmovements -mock_names ProductMovementsCreator=CreateProductMovementsMock
+type ProductMovementsCreator interface {
+	CreateProductMovements(ctx context.Context, movements entities.ProductMovements) error
+}
+
+type CommandHandler struct {
+	repo ProductMovementsCreator
+}
+
+func NewCommandHandler(repo ProductMovementsCreator) *CommandHandler {
+	if repo == nil {
+		panic("ProductMovementsCreator repo is nil")
+	}
+
+	return &CommandHandler{repo: repo}
+}
+
+// Handle сохраняет движения товара. Пустой список ничего не делает.
+func (h *CommandHandler) Handle(ctx context.Context, cmd Command) error {
+	ctx, span := tracing.Start(ctx, "command.createProductMovements")
+	defer span.End()
+
+	if len(cmd.movements) == 0 {
+		return nil
+	}
+
+	return tracing.Error(span, h.repo.CreateProductMovements(ctx, cmd.movements))
+}
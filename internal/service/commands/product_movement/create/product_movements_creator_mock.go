@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handler.go -destination=product_movements_creator_mock.go -package=createproductmovements -mock_names ProductMovementsCreator=CreateProductMovementsMock
+//
+
+// Package createproductmovements is a generated GoMock package.
+package createproductmovements
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// CreateProductMovementsMock is a mock of ProductMovementsCreator interface.
+type CreateProductMovementsMock struct {
+	ctrl     *gomock.Controller
+	recorder *CreateProductMovementsMockMockRecorder
+}
+
+// CreateProductMovementsMockMockRecorder is the mock recorder for CreateProductMovementsMock.
+type CreateProductMovementsMockMockRecorder struct {
+	mock *CreateProductMovementsMock
+}
+
+// NewCreateProductMovementsMock creates a new mock instance.
+func NewCreateProductMovementsMock(ctrl *gomock.Controller) *CreateProductMovementsMock {
+	mock := &CreateProductMovementsMock{ctrl: ctrl}
+	mock.recorder = &CreateProductMovementsMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *CreateProductMovementsMock) EXPECT() *CreateProductMovementsMockMockRecorder {
+	return m.recorder
+}
+
+// CreateProductMovements mocks base method.
+func (m *CreateProductMovementsMock) CreateProductMovements(ctx context.Context, movements entities.ProductMovements) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductMovements", ctx, movements)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateProductMovements indicates an expected call of CreateProductMovements.
+func (mr *CreateProductMovementsMockMockRecorder) CreateProductMovements(ctx, movements any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductMovements", reflect.TypeOf((*CreateProductMovementsMock)(nil).CreateProductMovements), ctx, movements)
+}
@@ -0,0 +1,15 @@
+package createproductprice
+
+import "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+
+type Command struct {
+	price *entities.ProductPrice
+}
+
+func NewCommandUnsafe(price *entities.ProductPrice) Command {
+	return Command{price: price}
+}
+
+func (c Command) GetPrice() *entities.ProductPrice {
+	return c.price
+}
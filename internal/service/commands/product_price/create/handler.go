@@ -0,0 +1,32 @@
+package createproductprice
+
+import (
+	"context"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+)
+
+//go:generate mockgen -source=handler.go -destination=product_price_creator_mock.go -package=createproductprice -mock_names ProductPriceCreator=CreateProductPriceMock
+type ProductPriceCreator interface {
+	CreateProductPrice(ctx context.Context, price *entities.ProductPrice) error
+}
+
+type CommandHandler struct {
+	repo ProductPriceCreator
+}
+
+func NewCommandHandler(repo ProductPriceCreator) *CommandHandler {
+	if repo == nil {
+		panic("ProductPriceCreator repo is nil")
+	}
+
+	return &CommandHandler{repo: repo}
+}
+
+func (h *CommandHandler) Handle(ctx context.Context, cmd Command) error {
+	ctx, span := tracing.Start(ctx, "command.createProductPrice")
+	defer span.End()
+
+	return tracing.Error(span, h.repo.CreateProductPrice(ctx, cmd.price))
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handler.go -destination=product_price_creator_mock.go -package=createproductprice -mock_names ProductPriceCreator=CreateProductPriceMock
+//
+
+// Package createproductprice is a generated GoMock package.
+package createproductprice
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// CreateProductPriceMock is a mock of ProductPriceCreator interface.
+type CreateProductPriceMock struct {
+	ctrl     *gomock.Controller
+	recorder *CreateProductPriceMockMockRecorder
+}
+
+// CreateProductPriceMockMockRecorder is the mock recorder for CreateProductPriceMock.
+type CreateProductPriceMockMockRecorder struct {
+	mock *CreateProductPriceMock
+}
+
+// NewCreateProductPriceMock creates a new mock instance.
+func NewCreateProductPriceMock(ctrl *gomock.Controller) *CreateProductPriceMock {
+	mock := &CreateProductPriceMock{ctrl: ctrl}
+	mock.recorder = &CreateProductPriceMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *CreateProductPriceMock) EXPECT() *CreateProductPriceMockMockRecorder {
+	return m.recorder
+}
+
+// CreateProductPrice mocks base method.
+func (m *CreateProductPriceMock) CreateProductPrice(ctx context.Context, price *entities.ProductPrice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductPrice", ctx, price)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateProductPrice indicates an expected call of CreateProductPrice.
+func (mr *CreateProductPriceMockMockRecorder) CreateProductPrice(ctx, price any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductPrice", reflect.TypeOf((*CreateProductPriceMock)(nil).CreateProductPrice), ctx, price)
+}
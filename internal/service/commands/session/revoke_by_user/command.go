@@ -0,0 +1,16 @@
+package revokeusersessions
+
+import (
+	"time"
+
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+type Command struct {
+	userID    vObject.UserID
+	revokedAt time.Time
+}
+
+func NewCommandUnsafe(userID vObject.UserID, revokedAt time.Time) Command {
+	return Command{userID: userID, revokedAt: revokedAt}
+}
@@ -0,0 +1,35 @@
+package revokeusersessions
+
+import (
+	"context"
+	"time"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+//go:generate mockgen -source=handler.go -destination=user_sessions_revoker_mock.go -package=revokeusersessions -mock_names UserSessionsRevoker=RevokeUserSessionsMock
+
+// UserSessionsRevoker отзывает все активные сессии пользователя. Уже отозванные сессии не изменяются.
+type UserSessionsRevoker interface {
+	RevokeUserSessions(ctx context.Context, userID vObject.UserID, revokedAt time.Time) error
+}
+
+type CommandHandler struct {
+	repo UserSessionsRevoker
+}
+
+func NewCommandHandler(repo UserSessionsRevoker) *CommandHandler {
+	if repo == nil {
+		panic("UserSessionsRevoker repo is nil")
+	}
+
+	return &CommandHandler{repo: repo}
+}
+
+func (h *CommandHandler) Handle(ctx context.Context, cmd Command) error {
+	ctx, span := tracing.Start(ctx, "command.revokeUserSessions")
+	defer span.End()
+
+	return tracing.Error(span, h.repo.RevokeUserSessions(ctx, cmd.userID, cmd.revokedAt))
+}
@@ -0,0 +1,11 @@
+package upsertsession
+
+import "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+
+type Command struct {
+	session *entities.Session
+}
+
+func NewCommandUnsafe(session *entities.Session) Command {
+	return Command{session: session}
+}
@@ -0,0 +1,32 @@
+package upsertsession
+
+import (
+	"context"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+)
+
+//go:generate mockgen -source=handler.go -destination=session_upserter_mock.go -package=upsertsession -mock_names SessionUpserter=UpsertSessionMock
+type SessionUpserter interface {
+	UpsertSession(ctx context.Context, session *entities.Session) error
+}
+
+type CommandHandler struct {
+	repo SessionUpserter
+}
+
+func NewCommandHandler(repo SessionUpserter) *CommandHandler {
+	if repo == nil {
+		panic("SessionUpserter repo is nil")
+	}
+
+	return &CommandHandler{repo: repo}
+}
+
+func (h *CommandHandler) Handle(ctx context.Context, cmd Command) error {
+	ctx, span := tracing.Start(ctx, "command.upsertSession")
+	defer span.End()
+
+	return tracing.Error(span, h.repo.UpsertSession(ctx, cmd.session))
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handler.go -destination=session_upserter_mock.go -package=upsertsession -mock_names SessionUpserter=UpsertSessionMock
+//
+
+// Package upsertsession is a generated GoMock package.
+package upsertsession
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// UpsertSessionMock is a mock of SessionUpserter interface.
+type UpsertSessionMock struct {
+	ctrl     *gomock.Controller
+	recorder *UpsertSessionMockMockRecorder
+}
+
+// UpsertSessionMockMockRecorder is the mock recorder for UpsertSessionMock.
+type UpsertSessionMockMockRecorder struct {
+	mock *UpsertSessionMock
+}
+
+// NewUpsertSessionMock creates a new mock instance.
+func NewUpsertSessionMock(ctrl *gomock.Controller) *UpsertSessionMock {
+	mock := &UpsertSessionMock{ctrl: ctrl}
+	mock.recorder = &UpsertSessionMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *UpsertSessionMock) EXPECT() *UpsertSessionMockMockRecorder {
+	return m.recorder
+}
+
+// UpsertSession mocks base method.
+func (m *UpsertSessionMock) UpsertSession(ctx context.Context, session *entities.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertSession indicates an expected call of UpsertSession.
+func (mr *UpsertSessionMockMockRecorder) UpsertSession(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSession", reflect.TypeOf((*UpsertSessionMock)(nil).UpsertSession), ctx, session)
+}
@@ -0,0 +1,35 @@
+package createstock
+
+import (
+	"context"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+)
+
+//go:generate mockgen -source=handler.go -destination=stock_creator_mock.go -package=createstock -mock_names StockCreator=CreateStockMock
+
+// StockCreator создаёт строку остатка товара на складе, если её ещё нет. Существующая строка не изменяется:
+// после создания остаток нужно заново получить с блокировкой и только затем менять.
+type StockCreator interface {
+	CreateStock(ctx context.Context, stock *entities.Stock) error
+}
+
+type CommandHandler struct {
+	repo StockCreator
+}
+
+func NewCommandHandler(repo StockCreator) *CommandHandler {
+	if repo == nil {
+		panic("StockCreator repo is nil")
+	}
+
+	return &CommandHandler{repo: repo}
+}
+
+func (h *CommandHandler) Handle(ctx context.Context, cmd Command) error {
+	ctx, span := tracing.Start(ctx, "command.createStock")
+	defer span.End()
+
+	return tracing.Error(span, h.repo.CreateStock(ctx, cmd.stock))
+}
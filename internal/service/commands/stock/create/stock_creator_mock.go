@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handler.go -destination=stock_creator_mock.go -package=createstock -mock_names StockCreator=CreateStockMock
+//
+
+// Package createstock is a generated GoMock package.
+package createstock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// CreateStockMock is a mock of StockCreator interface.
+type CreateStockMock struct {
+	ctrl     *gomock.Controller
+	recorder *CreateStockMockMockRecorder
+}
+
+// CreateStockMockMockRecorder is the mock recorder for CreateStockMock.
+type CreateStockMockMockRecorder struct {
+	mock *CreateStockMock
+}
+
+// NewCreateStockMock creates a new mock instance.
+func NewCreateStockMock(ctrl *gomock.Controller) *CreateStockMock {
+	mock := &CreateStockMock{ctrl: ctrl}
+	mock.recorder = &CreateStockMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *CreateStockMock) EXPECT() *CreateStockMockMockRecorder {
+	return m.recorder
+}
+
+// CreateStock mocks base method.
+func (m *CreateStockMock) CreateStock(ctx context.Context, stock *entities.Stock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStock", ctx, stock)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateStock indicates an expected call of CreateStock.
+func (mr *CreateStockMockMockRecorder) CreateStock(ctx, stock any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStock", reflect.TypeOf((*CreateStockMock)(nil).CreateStock), ctx, stock)
+}
@@ -0,0 +1,11 @@
+package updatestock
+
+import "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+
+type Command struct {
+	stock *entities.Stock
+}
+
+func NewCommandUnsafe(stock *entities.Stock) Command {
+	return Command{stock: stock}
+}
@@ -0,0 +1,32 @@
+package updatestock
+
+import (
+	"context"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+)
+
+//go:generate mockgen -source=handler.go -destination=stock_updater_mock.go -package=updatestock -mock_names StockUpdater=UpdateStockMock
+type StockUpdater interface {
+	UpdateStock(ctx context.Context, stock *entities.Stock) error
+}
+
+type CommandHandler struct {
+	repo StockUpdater
+}
+
+func NewCommandHandler(repo StockUpdater) *CommandHandler {
+	if repo == nil {
+		panic("StockUpdater repo is nil")
+	}
+
+	return &CommandHandler{repo: repo}
+}
+
+func (h *CommandHandler) Handle(ctx context.Context, cmd Command) error {
+	ctx, span := tracing.Start(ctx, "command.updateStock")
+	defer span.End()
+
+	return tracing.Error(span, h.repo.UpdateStock(ctx, cmd.stock))
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handler.go -destination=stock_updater_mock.go -package=updatestock -mock_names StockUpdater=UpdateStockMock
+//
+
+// Package updatestock is a generated GoMock package.
+package updatestock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// UpdateStockMock is a mock of StockUpdater interface.
+type UpdateStockMock struct {
+	ctrl     *gomock.Controller
+	recorder *UpdateStockMockMockRecorder
+}
+
+// UpdateStockMockMockRecorder is the mock recorder for UpdateStockMock.
+type UpdateStockMockMockRecorder struct {
+	mock *UpdateStockMock
+}
+
+// NewUpdateStockMock creates a new mock instance.
+func NewUpdateStockMock(ctrl *gomock.Controller) *UpdateStockMock {
+	mock := &UpdateStockMock{ctrl: ctrl}
+	mock.recorder = &UpdateStockMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *UpdateStockMock) EXPECT() *UpdateStockMockMockRecorder {
+	return m.recorder
+}
+
+// UpdateStock mocks base method.
+func (m *UpdateStockMock) UpdateStock(ctx context.Context, stock *entities.Stock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStock", ctx, stock)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStock indicates an expected call of UpdateStock.
+func (mr *UpdateStockMockMockRecorder) UpdateStock(ctx, stock any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStock", reflect.TypeOf((*UpdateStockMock)(nil).UpdateStock), ctx, stock)
+}
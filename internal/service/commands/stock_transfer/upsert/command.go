@@ -0,0 +1,11 @@
+package upsertstocktransfer
+
+import "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+
+type Command struct {
+	transfer *entities.StockTransfer
+}
+
+func NewCommandUnsafe(transfer *entities.StockTransfer) Command {
+	return Command{transfer: transfer}
+}
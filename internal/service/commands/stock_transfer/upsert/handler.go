@@ -0,0 +1,32 @@
+package upsertstocktransfer
+
+import (
+	"context"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+)
+
+//go:generate mockgen -source=handler.go -destination=stock_transfer_upserter_mock.go -package=upsertstocktransfer -mock_names StockTransferUpserter=UpsertStockTransferMock
+type StockTransferUpserter interface {
+	UpsertStockTransfer(ctx context.Context, transfer *entities.StockTransfer) error
+}
+
+type CommandHandler struct {
+	repo StockTransferUpserter
+}
+
+func NewCommandHandler(repo StockTransferUpserter) *CommandHandler {
+	if repo == nil {
+		panic("StockTransferUpserter repo is nil")
+	}
+
+	return &CommandHandler{repo: repo}
+}
+
+func (h *CommandHandler) Handle(ctx context.Context, cmd Command) error {
+	ctx, span := tracing.Start(ctx, "command.upsertStockTransfer")
+	defer span.End()
+
+	return tracing.Error(span, h.repo.UpsertStockTransfer(ctx, cmd.transfer))
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handler.go -destination=stock_transfer_upserter_mock.go -package=upsertstocktransfer -mock_names StockTransferUpserter=UpsertStockTransferMock
+//
+
+// Package upsertstocktransfer is a generated GoMock package.
+package upsertstocktransfer
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// UpsertStockTransferMock is a mock of StockTransferUpserter interface.
+type UpsertStockTransferMock struct {
+	ctrl     *gomock.Controller
+	recorder *UpsertStockTransferMockMockRecorder
+}
+
+// UpsertStockTransferMockMockRecorder is the mock recorder for UpsertStockTransferMock.
+type UpsertStockTransferMockMockRecorder struct {
+	mock *UpsertStockTransferMock
+}
+
+// NewUpsertStockTransferMock creates a new mock instance.
+func NewUpsertStockTransferMock(ctrl *gomock.Controller) *UpsertStockTransferMock {
+	mock := &UpsertStockTransferMock{ctrl: ctrl}
+	mock.recorder = &UpsertStockTransferMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *UpsertStockTransferMock) EXPECT() *UpsertStockTransferMockMockRecorder {
+	return m.recorder
+}
+
+// UpsertStockTransfer mocks base method.
+func (m *UpsertStockTransferMock) UpsertStockTransfer(ctx context.Context, transfer *entities.StockTransfer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertStockTransfer", ctx, transfer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertStockTransfer indicates an expected call of UpsertStockTransfer.
+func (mr *UpsertStockTransferMockMockRecorder) UpsertStockTransfer(ctx, transfer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertStockTransfer", reflect.TypeOf((*UpsertStockTransferMock)(nil).UpsertStockTransfer), ctx, transfer)
+}
@@ -10,8 +10,13 @@ type Command struct {
 	user *entities.User
 }
 
-func NewCommand(email, firstName, lastName, maritalStatus string, birthdate time.Time, opts ...entities.Option[*entities.User]) (*Command, error) {
-	user, err := entities.NewUser(email, firstName, lastName, maritalStatus, birthdate, opts...)
+func NewCommand(
+	email, firstName, lastName, maritalStatus string,
+	birthdate time.Time,
+	minAge int,
+	opts ...entities.Option[*entities.User],
+) (*Command, error) {
+	user, err := entities.NewUser(email, firstName, lastName, maritalStatus, birthdate, minAge, opts...)
 	if err != nil {
 		return nil, err
 	}
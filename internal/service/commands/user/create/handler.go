@@ -3,6 +3,7 @@ package createuser
 import (
 	"context"
 
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
 	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
 )
 
@@ -24,5 +25,8 @@ func NewCommandHandler(repo UserCreator) *CommandHandler {
 }
 
 func (h *CommandHandler) Handle(ctx context.Context, cmd Command) error {
-	return h.repo.CreateUser(ctx, cmd.user)
+	ctx, span := tracing.Start(ctx, "command.createUser")
+	defer span.End()
+
+	return tracing.Error(span, h.repo.CreateUser(ctx, cmd.user))
 }
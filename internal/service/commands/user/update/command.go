@@ -0,0 +1,11 @@
+package updateuser
+
+import "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+
+type Command struct {
+	user *entities.User
+}
+
+func NewCommandUnsafe(user *entities.User) Command {
+	return Command{user: user}
+}
@@ -0,0 +1,32 @@
+package updateuser
+
+import (
+	"context"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+)
+
+//go:generate mockgen -source=handler.go -destination=user_updater_mock.go -package=updateuser -mock_names UserUpdater=UpdateUserMock
+type UserUpdater interface {
+	UpdateUser(ctx context.Context, user *entities.User) error
+}
+
+type CommandHandler struct {
+	repo UserUpdater
+}
+
+func NewCommandHandler(repo UserUpdater) *CommandHandler {
+	if repo == nil {
+		panic("UserUpdater repo is nil")
+	}
+
+	return &CommandHandler{repo: repo}
+}
+
+func (h *CommandHandler) Handle(ctx context.Context, cmd Command) error {
+	ctx, span := tracing.Start(ctx, "command.updateUser")
+	defer span.End()
+
+	return tracing.Error(span, h.repo.UpdateUser(ctx, cmd.user))
+}
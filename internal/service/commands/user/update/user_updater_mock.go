@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handler.go -destination=user_updater_mock.go -package=updateuser -mock_names UserUpdater=UpdateUserMock
+//
+
+// Package updateuser is a generated GoMock package.
+package updateuser
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// UpdateUserMock is a mock of UserUpdater interface.
+type UpdateUserMock struct {
+	ctrl     *gomock.Controller
+	recorder *UpdateUserMockMockRecorder
+}
+
+// UpdateUserMockMockRecorder is the mock recorder for UpdateUserMock.
+type UpdateUserMockMockRecorder struct {
+	mock *UpdateUserMock
+}
+
+// NewUpdateUserMock creates a new mock instance.
+func NewUpdateUserMock(ctrl *gomock.Controller) *UpdateUserMock {
+	mock := &UpdateUserMock{ctrl: ctrl}
+	mock.recorder = &UpdateUserMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *UpdateUserMock) EXPECT() *UpdateUserMockMockRecorder {
+	return m.recorder
+}
+
+// UpdateUser mocks base method.
+func (m *UpdateUserMock) UpdateUser(ctx context.Context, user *entities.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *UpdateUserMockMockRecorder) UpdateUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*UpdateUserMock)(nil).UpdateUser), ctx, user)
+}
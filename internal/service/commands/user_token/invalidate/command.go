@@ -0,0 +1,12 @@
+package invalidateusertokens
+
+import "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+
+type Command struct {
+	token *entities.UserToken
+}
+
+// NewCommandUnsafe команда погасить остальные токены пользователя с тем же назначением, что и погашенный token.
+func NewCommandUnsafe(token *entities.UserToken) Command {
+	return Command{token: token}
+}
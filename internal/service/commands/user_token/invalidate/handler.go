@@ -0,0 +1,35 @@
+package invalidateusertokens
+
+import (
+	"context"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+)
+
+//go:generate mockgen -source=handler.go -destination=user_tokens_invalidator_mock.go -package=invalidateusertokens -mock_names UserTokensInvalidator=InvalidateUserTokensMock
+
+// UserTokensInvalidator погашает неиспользованные токены пользователя с тем же назначением, что и token,
+// кроме самого token. Отметка об использовании берётся из token.UsedAt.
+type UserTokensInvalidator interface {
+	InvalidateUserTokens(ctx context.Context, token *entities.UserToken) error
+}
+
+type CommandHandler struct {
+	repo UserTokensInvalidator
+}
+
+func NewCommandHandler(repo UserTokensInvalidator) *CommandHandler {
+	if repo == nil {
+		panic("UserTokensInvalidator repo is nil")
+	}
+
+	return &CommandHandler{repo: repo}
+}
+
+func (h *CommandHandler) Handle(ctx context.Context, cmd Command) error {
+	ctx, span := tracing.Start(ctx, "command.invalidateUserTokens")
+	defer span.End()
+
+	return tracing.Error(span, h.repo.InvalidateUserTokens(ctx, cmd.token))
+}
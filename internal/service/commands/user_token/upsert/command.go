@@ -0,0 +1,11 @@
+package upsertusertoken
+
+import "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+
+type Command struct {
+	token *entities.UserToken
+}
+
+func NewCommandUnsafe(token *entities.UserToken) Command {
+	return Command{token: token}
+}
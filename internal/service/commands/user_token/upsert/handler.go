@@ -0,0 +1,32 @@
+package upsertusertoken
+
+import (
+	"context"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+)
+
+//go:generate mockgen -source=handler.go -destination=user_token_upserter_mock.go -package=upsertusertoken -mock_names UserTokenUpserter=UpsertUserTokenMock
+type UserTokenUpserter interface {
+	UpsertUserToken(ctx context.Context, token *entities.UserToken) error
+}
+
+type CommandHandler struct {
+	repo UserTokenUpserter
+}
+
+func NewCommandHandler(repo UserTokenUpserter) *CommandHandler {
+	if repo == nil {
+		panic("UserTokenUpserter repo is nil")
+	}
+
+	return &CommandHandler{repo: repo}
+}
+
+func (h *CommandHandler) Handle(ctx context.Context, cmd Command) error {
+	ctx, span := tracing.Start(ctx, "command.upsertUserToken")
+	defer span.End()
+
+	return tracing.Error(span, h.repo.UpsertUserToken(ctx, cmd.token))
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handler.go -destination=user_token_upserter_mock.go -package=upsertusertoken -mock_names UserTokenUpserter=UpsertUserTokenMock
+//
+
+// Package upsertusertoken is a generated GoMock package.
+package upsertusertoken
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// UpsertUserTokenMock is a mock of UserTokenUpserter interface.
+type UpsertUserTokenMock struct {
+	ctrl     *gomock.Controller
+	recorder *UpsertUserTokenMockMockRecorder
+}
+
+// UpsertUserTokenMockMockRecorder is the mock recorder for UpsertUserTokenMock.
+type UpsertUserTokenMockMockRecorder struct {
+	mock *UpsertUserTokenMock
+}
+
+// NewUpsertUserTokenMock creates a new mock instance.
+func NewUpsertUserTokenMock(ctrl *gomock.Controller) *UpsertUserTokenMock {
+	mock := &UpsertUserTokenMock{ctrl: ctrl}
+	mock.recorder = &UpsertUserTokenMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *UpsertUserTokenMock) EXPECT() *UpsertUserTokenMockMockRecorder {
+	return m.recorder
+}
+
+// UpsertUserToken mocks base method.
+func (m *UpsertUserTokenMock) UpsertUserToken(ctx context.Context, token *entities.UserToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertUserToken indicates an expected call of UpsertUserToken.
+func (mr *UpsertUserTokenMockMockRecorder) UpsertUserToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserToken", reflect.TypeOf((*UpsertUserTokenMock)(nil).UpsertUserToken), ctx, token)
+}
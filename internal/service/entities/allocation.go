@@ -0,0 +1,192 @@
+package entities
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+// OrderProductAllocation количество товара позиции заказа, выделенное с одного склада.
+type OrderProductAllocation struct {
+	WarehouseID vObject.WarehouseID
+	Quantity    vObject.Quantity
+}
+
+type OrderProductAllocations []OrderProductAllocation
+
+func (a OrderProductAllocations) Total() vObject.Quantity {
+	var total vObject.Quantity
+
+	for _, allocation := range a {
+		total += allocation.Quantity
+	}
+
+	return total
+}
+
+// Apply возвращает распределение после применения движений резерва и снятия резерва.
+// Порядок складов сохраняется, склады с нулевым количеством удаляются.
+func (a OrderProductAllocations) Apply(movements ProductMovements) OrderProductAllocations {
+	result := slices.Clone(a)
+
+	for _, movement := range movements {
+		i := slices.IndexFunc(result, func(allocation OrderProductAllocation) bool {
+			return allocation.WarehouseID == movement.WarehouseID
+		})
+
+		switch movement.OperationType {
+		case vObject.OperationTypeReserve:
+			if i < 0 {
+				result = append(result, OrderProductAllocation{WarehouseID: movement.WarehouseID})
+				i = len(result) - 1
+			}
+
+			result[i].Quantity += movement.Quantity
+		case vObject.OperationTypeUnreserve:
+			if i < 0 {
+				continue
+			}
+
+			result[i].Quantity -= min(result[i].Quantity, movement.Quantity)
+		}
+	}
+
+	return slices.DeleteFunc(result, func(allocation OrderProductAllocation) bool {
+		return allocation.Quantity == vObject.QuantityZero
+	})
+}
+
+// Названия политик распределения товара для конфигурации, см. NewAllocationPolicy.
+const (
+	AllocationPolicySplit                = "split"
+	AllocationPolicySingleWarehouseFirst = "single_warehouse_first"
+	AllocationPolicyPriority             = "priority"
+	AllocationPolicyLeastFragmentation   = "least_fragmentation"
+)
+
+var ErrUnknownAllocationPolicy = errors.New("unknown allocation policy")
+
+// NewAllocationPolicy возвращает политику распределения по названию. Список priority используется
+// только политикой priority.
+func NewAllocationPolicy(name string, priority ...vObject.WarehouseID) (AllocationPolicy, error) {
+	switch name {
+	case AllocationPolicySplit:
+		return SplitAllocationPolicy{}, nil
+	case AllocationPolicySingleWarehouseFirst:
+		return SingleWarehouseFirstPolicy{}, nil
+	case AllocationPolicyPriority:
+		return NewPriorityAllocationPolicy(priority...), nil
+	case AllocationPolicyLeastFragmentation:
+		return LeastFragmentationPolicy{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAllocationPolicy, name)
+	}
+}
+
+// AllocationPolicy решает, с каких складов выделить quantity товара.
+// Политика не изменяет остатки, а только возвращает распределение по складам.
+type AllocationPolicy interface {
+	Allocate(stocks Stocks, quantity vObject.Quantity) (OrderProductAllocations, error)
+}
+
+// SplitAllocationPolicy выделяет товар со складов в порядке следования остатков,
+// переходя к следующему складу, когда свободный остаток текущего исчерпан.
+type SplitAllocationPolicy struct{}
+
+func (SplitAllocationPolicy) Allocate(stocks Stocks, quantity vObject.Quantity) (OrderProductAllocations, error) {
+	return allocateInOrder(stocks, quantity)
+}
+
+// SingleWarehouseFirstPolicy выделяет весь товар с первого склада, на котором его достаточно.
+// Если такого склада нет, товар делится между складами в порядке следования остатков.
+type SingleWarehouseFirstPolicy struct{}
+
+func (SingleWarehouseFirstPolicy) Allocate(stocks Stocks, quantity vObject.Quantity) (OrderProductAllocations, error) {
+	for _, stock := range stocks {
+		if quantity > vObject.QuantityZero && stock.FreeQuantity() >= quantity {
+			return OrderProductAllocations{{WarehouseID: stock.WarehouseID, Quantity: quantity}}, nil
+		}
+	}
+
+	return allocateInOrder(stocks, quantity)
+}
+
+// PriorityAllocationPolicy выделяет товар со складов в порядке приоритета (например, по удалённости от покупателя).
+// Склады, отсутствующие в списке приоритета, используются последними.
+type PriorityAllocationPolicy struct {
+	priority []vObject.WarehouseID
+}
+
+func NewPriorityAllocationPolicy(priority ...vObject.WarehouseID) PriorityAllocationPolicy {
+	return PriorityAllocationPolicy{priority: priority}
+}
+
+func (p PriorityAllocationPolicy) Allocate(stocks Stocks, quantity vObject.Quantity) (OrderProductAllocations, error) {
+	rank := func(stock Stock) int {
+		if i := slices.Index(p.priority, stock.WarehouseID); i >= 0 {
+			return i
+		}
+
+		return len(p.priority)
+	}
+
+	ordered := slices.Clone(stocks)
+	slices.SortStableFunc(ordered, func(a, b Stock) int {
+		return cmp.Compare(rank(a), rank(b))
+	})
+
+	return allocateInOrder(ordered, quantity)
+}
+
+// LeastFragmentationPolicy минимизирует дробление остатков: если товар помещается на одном складе,
+// выбирается склад с наименьшим достаточным свободным остатком, иначе склады заполняются от большего
+// свободного остатка к меньшему, чтобы задействовать как можно меньше складов.
+type LeastFragmentationPolicy struct{}
+
+func (LeastFragmentationPolicy) Allocate(stocks Stocks, quantity vObject.Quantity) (OrderProductAllocations, error) {
+	ordered := slices.Clone(stocks)
+	slices.SortStableFunc(ordered, func(a, b Stock) int {
+		return cmp.Compare(b.FreeQuantity(), a.FreeQuantity())
+	})
+
+	for i := len(ordered) - 1; i >= 0; i-- {
+		if quantity > vObject.QuantityZero && ordered[i].FreeQuantity() >= quantity {
+			return OrderProductAllocations{{WarehouseID: ordered[i].WarehouseID, Quantity: quantity}}, nil
+		}
+	}
+
+	return allocateInOrder(ordered, quantity)
+}
+
+func allocateInOrder(stocks Stocks, quantity vObject.Quantity) (OrderProductAllocations, error) {
+	var free vObject.Quantity
+	for _, stock := range stocks {
+		free += stock.FreeQuantity()
+	}
+
+	if free < quantity {
+		return nil, fmt.Errorf("[allocate error]: %w", ErrNotEnoughProductIntStocks)
+	}
+
+	allocations := make(OrderProductAllocations, 0, 1)
+
+	for _, stock := range stocks {
+		if quantity == vObject.QuantityZero {
+			break
+		}
+
+		take := min(stock.FreeQuantity(), quantity)
+		if take == vObject.QuantityZero {
+			continue
+		}
+
+		quantity -= take
+
+		allocations = append(allocations, OrderProductAllocation{WarehouseID: stock.WarehouseID, Quantity: take})
+	}
+
+	return allocations, nil
+}
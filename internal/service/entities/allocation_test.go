@@ -0,0 +1,139 @@
+//go:build unit
+
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+func TestAllocationPolicies(t *testing.T) {
+	t.Parallel()
+
+	productID := vObject.NewProductIDFromUUIDUnsafe(uuid.New())
+	small := entities.NewStockUnsafe(productID, vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New()), 0, 3)
+	middle := entities.NewStockUnsafe(productID, vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New()), 2, 8)
+	large := entities.NewStockUnsafe(productID, vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New()), 0, 20)
+	stocks := entities.Stocks{small, middle, large}
+
+	tcs := []struct {
+		name     string
+		policy   entities.AllocationPolicy
+		quantity vObject.Quantity
+		exp      entities.OrderProductAllocations
+	}{
+		{
+			name:     "split",
+			policy:   entities.SplitAllocationPolicy{},
+			quantity: 5,
+			exp:      entities.OrderProductAllocations{{WarehouseID: small.WarehouseID, Quantity: 3}, {WarehouseID: middle.WarehouseID, Quantity: 2}},
+		},
+		{
+			name:     "single warehouse first",
+			policy:   entities.SingleWarehouseFirstPolicy{},
+			quantity: 5,
+			exp:      entities.OrderProductAllocations{{WarehouseID: middle.WarehouseID, Quantity: 5}},
+		},
+		{
+			name:     "single warehouse first falls back to split",
+			policy:   entities.SingleWarehouseFirstPolicy{},
+			quantity: 25,
+			exp: entities.OrderProductAllocations{
+				{WarehouseID: small.WarehouseID, Quantity: 3},
+				{WarehouseID: middle.WarehouseID, Quantity: 6},
+				{WarehouseID: large.WarehouseID, Quantity: 16},
+			},
+		},
+		{
+			name:     "priority ordered",
+			policy:   entities.NewPriorityAllocationPolicy(large.WarehouseID, small.WarehouseID),
+			quantity: 22,
+			exp: entities.OrderProductAllocations{
+				{WarehouseID: large.WarehouseID, Quantity: 20},
+				{WarehouseID: small.WarehouseID, Quantity: 2},
+			},
+		},
+		{
+			name:     "least fragmentation picks best fit",
+			policy:   entities.LeastFragmentationPolicy{},
+			quantity: 3,
+			exp:      entities.OrderProductAllocations{{WarehouseID: small.WarehouseID, Quantity: 3}},
+		},
+		{
+			name:     "least fragmentation uses largest stocks",
+			policy:   entities.LeastFragmentationPolicy{},
+			quantity: 24,
+			exp: entities.OrderProductAllocations{
+				{WarehouseID: large.WarehouseID, Quantity: 20},
+				{WarehouseID: middle.WarehouseID, Quantity: 4},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			allocations, err := tc.policy.Allocate(stocks, tc.quantity)
+			require.NoError(t, err)
+			assert.Equal(t, tc.exp, allocations)
+			assert.Equal(t, tc.quantity, allocations.Total())
+
+			_, err = tc.policy.Allocate(stocks, 30)
+			require.ErrorIs(t, err, entities.ErrNotEnoughProductIntStocks)
+
+			// политика не изменяет остатки
+			assert.Equal(t, entities.Stocks{small, middle, large}, stocks)
+		})
+	}
+}
+
+func TestNewAllocationPolicy(t *testing.T) {
+	t.Parallel()
+
+	warehouseID := vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New())
+
+	tcs := map[string]entities.AllocationPolicy{
+		entities.AllocationPolicySplit:                entities.SplitAllocationPolicy{},
+		entities.AllocationPolicySingleWarehouseFirst: entities.SingleWarehouseFirstPolicy{},
+		entities.AllocationPolicyPriority:             entities.NewPriorityAllocationPolicy(warehouseID),
+		entities.AllocationPolicyLeastFragmentation:   entities.LeastFragmentationPolicy{},
+	}
+
+	for name, exp := range tcs {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			policy, err := entities.NewAllocationPolicy(name, warehouseID)
+			require.NoError(t, err)
+			assert.Equal(t, exp, policy)
+		})
+	}
+
+	_, err := entities.NewAllocationPolicy("nearest")
+	require.ErrorIs(t, err, entities.ErrUnknownAllocationPolicy)
+}
+
+func TestOrderProductAllocations_Apply(t *testing.T) {
+	t.Parallel()
+
+	productID := vObject.NewProductIDFromUUIDUnsafe(uuid.New())
+	first := vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New())
+	second := vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New())
+
+	allocations := entities.OrderProductAllocations{{WarehouseID: first, Quantity: 2}}
+
+	applied := allocations.Apply(entities.ProductMovements{
+		entities.NewProductMovementUnsafe(productID, second, vObject.OperationTypeReserve, 3, 100),
+		entities.NewProductMovementUnsafe(productID, first, vObject.OperationTypeUnreserve, 2, 100),
+	})
+
+	assert.Equal(t, entities.OrderProductAllocations{{WarehouseID: second, Quantity: 3}}, applied)
+	assert.Equal(t, entities.OrderProductAllocations{{WarehouseID: first, Quantity: 2}}, allocations)
+}
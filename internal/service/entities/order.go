@@ -28,7 +28,14 @@ type Order struct {
 	Products OrderProducts
 }
 
-var ErrOrderRecNotFound = errors.New("order record not found")
+type Orders []Order
+
+var (
+	ErrOrderRecNotFound      = errors.New("order record not found")
+	ErrOrderStatusTransition = errors.New("order status transition is not allowed")
+	// ErrOrderNotEditable состав заказа меняется только до оплаты.
+	ErrOrderNotEditable = errors.New("order products can not be changed in this status")
+)
 
 func NewOrder(userUUID baseUUID.UUID, opts ...Option[*Order]) (*Order, error) {
 	userID, err := vObject.NewUserIDFromUUID(userUUID)
@@ -61,29 +68,80 @@ func NewOrderUnsafe(userID vObject.UserID, opts ...Option[*Order]) Order {
 	return *o
 }
 
-func (o *Order) ChangeOrderProducts(stocks Stocks, product Product, quantity uint64) error {
-	if stocks.GetAvailableQuantity().IsLessThan(quantity) {
-		return fmt.Errorf("[Order.ChangeOrderProducts error]: %w", ErrNotEnoughProductIntStocks)
+// CanTransitionTo проверяет, можно ли перевести заказ в указанный статус.
+func (o *Order) CanTransitionTo(status vObject.OrderStatus) bool {
+	return o.Status.CanTransitionTo(status)
+}
+
+// TransitionTo переводит заказ в указанный статус, если переход разрешён.
+func (o *Order) TransitionTo(status vObject.OrderStatus) error {
+	if !o.CanTransitionTo(status) {
+		return fmt.Errorf("[Order.TransitionTo error]: %w: %s -> %s", ErrOrderStatusTransition, o.Status, status)
 	}
 
-	orderProduct := o.GetOrCreateOrderProductByProduct(product)
+	o.Status = status
+	o.UpdatedAt = o.Now()
+
+	return nil
+}
+
+// ChangeOrderProducts изменяет количество товара в заказе. Уже заказанное количество
+// зарезервировано на складах, поэтому проверяется только доступность прироста.
+// Цена позиции определяется политикой policy: snapshot сохраняет цену первого добавления товара,
+// reprice обновляет её до текущей цены, пока заказ не оплачен.
+// Состав заказа меняется только в статусе created.
+// Возвращает изменённую позицию, при нулевом количестве удалённую. Если удаляется товар, которого нет в заказе,
+// ничего не меняется и возвращается nil.
+func (o *Order) ChangeOrderProducts(
+	stocks Stocks,
+	product Product,
+	quantity uint64,
+	policy vObject.OrderPricePolicy,
+) (*OrderProduct, error) {
+	if o.Status != vObject.OrderStatusCreated {
+		return nil, fmt.Errorf("[Order.ChangeOrderProducts error]: %w: %s", ErrOrderNotEditable, o.Status)
+	}
+
+	var ordered uint64
+	if orderProduct := o.GetOrderProductByProductIDUnsafe(product.ID); orderProduct != nil {
+		ordered = orderProduct.Quantity.Uint64()
+	}
+
+	if quantity > ordered && stocks.GetAvailableQuantity().IsLessThan(quantity-ordered) {
+		return nil, fmt.Errorf("[Order.ChangeOrderProducts error]: %w", ErrNotEnoughProductIntStocks)
+	}
 
 	if quantity == 0 {
-		o.Products.Delete(orderProduct)
-		o.TotalPrice.Subtract(orderProduct.TotalPrice())
-	} else {
+		orderProduct := o.GetOrderProductByProductIDUnsafe(product.ID)
+		if orderProduct == nil {
+			return nil, nil
+		}
+
 		o.TotalPrice.Subtract(orderProduct.TotalPrice())
-		orderProduct.ChangeQuantity(quantity)
-		o.TotalPrice.Add(orderProduct.TotalPrice())
+		orderProduct.Delete()
 		o.Products.Replace(*orderProduct)
+
+		return orderProduct, nil
 	}
 
-	return nil
+	orderProduct := o.GetOrCreateOrderProductByProduct(product)
+	o.TotalPrice.Subtract(orderProduct.TotalPrice())
+
+	if policy.RepriceOnChange(o.Status) {
+		orderProduct.Reprice(product.Price)
+	}
+
+	orderProduct.ChangeQuantity(quantity)
+	o.TotalPrice.Add(orderProduct.TotalPrice())
+	o.Products.Replace(*orderProduct)
+
+	return orderProduct, nil
 }
 
+// GetOrderProductByProductIDUnsafe позиция товара в заказе. Удалённые позиции не учитываются.
 func (o *Order) GetOrderProductByProductIDUnsafe(productID vObject.ProductID) *OrderProduct {
 	for _, orderProduct := range o.Products {
-		if orderProduct.ProductID == productID {
+		if orderProduct.ProductID == productID && !orderProduct.IsDeleted() {
 			return &orderProduct
 		}
 	}
@@ -91,11 +149,10 @@ func (o *Order) GetOrderProductByProductIDUnsafe(productID vObject.ProductID) *O
 	return nil
 }
 
+// GetOrCreateOrderProductByProduct позиция товара в заказе либо новая, если товара в заказе нет или его позиция удалена.
 func (o *Order) GetOrCreateOrderProductByProduct(product Product) *OrderProduct {
-	for _, orderProduct := range o.Products {
-		if orderProduct.ProductID == product.ID {
-			return &orderProduct
-		}
+	if orderProduct := o.GetOrderProductByProductIDUnsafe(product.ID); orderProduct != nil {
+		return orderProduct
 	}
 
 	op := NewOrderProductUnsafe(
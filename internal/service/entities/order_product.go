@@ -18,6 +18,9 @@ type OrderProduct struct {
 	UpdatedAt time.Time
 	DeletedAt *time.Time
 
+	// Allocations распределение количества товара позиции по складам, с которых он зарезервирован и будет отгружен
+	Allocations OrderProductAllocations
+
 	Order   *Order
 	Product *Product
 }
@@ -27,31 +30,56 @@ func (p *OrderProduct) ChangeQuantity(quantity uint64) {
 	p.UpdatedAt = p.Now()
 }
 
+// Reprice заменяет цену позиции текущей ценой товара.
+func (p *OrderProduct) Reprice(price vObject.Price) {
+	p.Price = price
+	p.UpdatedAt = p.Now()
+}
+
+// ApplyMovements обновляет распределение товара по складам движениями резерва и снятия резерва.
+func (p *OrderProduct) ApplyMovements(movements ProductMovements) {
+	p.Allocations = p.Allocations.Apply(movements)
+}
+
+// Delete удаляет позицию из заказа. Количество обнуляется, чтобы удалённая позиция не учитывалась
+// ни в сумме заказа, ни в резерве.
 func (p *OrderProduct) Delete() {
 	tn := p.Now()
+	p.Quantity = vObject.QuantityZero
 	p.UpdatedAt = tn
 	p.DeletedAt = &tn
 }
 
+// IsDeleted сообщает, удалена ли позиция из заказа.
+func (p *OrderProduct) IsDeleted() bool {
+	return p.DeletedAt != nil
+}
+
 func (p *OrderProduct) TotalPrice() vObject.Price {
 	return p.Price.Multiply(p.Quantity)
 }
 
+// OrderProducts позиции заказа. Удалённая позиция остаётся в списке до сохранения заказа,
+// поэтому после повторного добавления товара у него две позиции: удалённая и следующая за ней новая.
 type OrderProducts []OrderProduct
 
+// Delete удаляет позицию товара, уже удалённые позиции не меняются.
 func (p OrderProducts) Delete(product *OrderProduct) {
 	for i, orderProduct := range p {
-		if orderProduct.ProductID == product.ProductID {
+		if orderProduct.ProductID == product.ProductID && !orderProduct.IsDeleted() {
 			orderProduct.Delete()
 			p[i] = orderProduct
 		}
 	}
 }
 
+// Replace заменяет последнюю позицию товара.
 func (p OrderProducts) Replace(orderProduct OrderProduct) {
-	for i, op := range p {
-		if op.ProductID == orderProduct.ProductID {
+	for i := len(p) - 1; i >= 0; i-- {
+		if p[i].ProductID == orderProduct.ProductID {
 			p[i] = orderProduct
+
+			return
 		}
 	}
 }
@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+// OrderStatusHistory запись о смене статуса заказа.
+type OrderStatusHistory struct {
+	now.WithNowGenerator
+	uuid.WithUUIDGenerator
+
+	ID      vObject.OrderStatusHistoryID
+	OrderID vObject.OrderID
+	// From пустой у записи о создании заказа.
+	From vObject.OrderStatus
+	To   vObject.OrderStatus
+	// ActorID пользователь, сменивший статус. Пустой, если статус сменила система.
+	ActorID   *vObject.UserID
+	Reason    vObject.OrderStatusReason
+	CreatedAt time.Time
+}
+
+// OrderStatusHistories история статусов заказа в хронологическом порядке.
+type OrderStatusHistories []OrderStatusHistory
+
+func NewOrderStatusHistoryUnsafe(
+	orderID vObject.OrderID,
+	from, to vObject.OrderStatus,
+	actorID *vObject.UserID,
+	reason vObject.OrderStatusReason,
+	opts ...Option[*OrderStatusHistory],
+) OrderStatusHistory {
+	h := OrderStatusHistory{
+		OrderID: orderID,
+		From:    from,
+		To:      to,
+		ActorID: actorID,
+		Reason:  reason,
+	}
+
+	for _, opt := range opts {
+		_ = opt(&h)
+	}
+
+	h.ID = vObject.NewOrderStatusHistoryIDFromUUIDUnsafe(h.UUID())
+	h.CreatedAt = h.Now()
+
+	return h
+}
@@ -0,0 +1,143 @@
+//go:build unit
+
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+func TestOrder_TransitionTo(t *testing.T) {
+	t.Parallel()
+
+	created := time.Now().Add(-time.Hour)
+	changed := time.Now()
+
+	nowFunc := now.NewMock(gomock.NewController(t))
+	nowFunc.EXPECT().Now().Return(created)
+
+	order := entities.NewOrderUnsafe(
+		vObject.NewUserIDFromUUIDUnsafe(uuid.New()),
+		entities.WithNowFunc[*entities.Order](nowFunc),
+	)
+	require.Equal(t, vObject.OrderStatusCreated, order.Status)
+
+	err := order.TransitionTo(vObject.OrderStatusShipped)
+	require.ErrorIs(t, err, entities.ErrOrderStatusTransition)
+	assert.Equal(t, vObject.OrderStatusCreated, order.Status)
+	assert.Equal(t, created, order.UpdatedAt)
+
+	nowFunc.EXPECT().Now().Return(changed)
+
+	require.True(t, order.CanTransitionTo(vObject.OrderStatusPaid))
+	require.NoError(t, order.TransitionTo(vObject.OrderStatusPaid))
+	assert.Equal(t, vObject.OrderStatusPaid, order.Status)
+	assert.Equal(t, created, order.CreatedAt)
+	assert.Equal(t, changed, order.UpdatedAt)
+}
+
+func TestOrder_ChangeOrderProducts_PricePolicy(t *testing.T) {
+	t.Parallel()
+
+	stocks := entities.Stocks{{AvailableQuantity: 10}}
+	product := entities.Product{ID: vObject.NewProductIDFromUUIDUnsafe(uuid.New()), Price: vObject.NewPriceUnsafe(1000)}
+	order := entities.NewOrderUnsafe(vObject.NewUserIDFromUUIDUnsafe(uuid.New()))
+
+	_, err := order.ChangeOrderProducts(stocks, product, 2, vObject.OrderPricePolicySnapshot)
+	require.NoError(t, err)
+	assert.Equal(t, vObject.NewPriceUnsafe(2000), order.TotalPrice)
+
+	product.Price = vObject.NewPriceUnsafe(1500)
+
+	_, err = order.ChangeOrderProducts(stocks, product, 3, vObject.OrderPricePolicySnapshot)
+	require.NoError(t, err)
+	assert.Equal(t, vObject.NewPriceUnsafe(1000), order.GetOrderProductByProductIDUnsafe(product.ID).Price, "snapshot keeps the first price")
+	assert.Equal(t, vObject.NewPriceUnsafe(3000), order.TotalPrice)
+
+	_, err = order.ChangeOrderProducts(stocks, product, 3, vObject.OrderPricePolicyReprice)
+	require.NoError(t, err)
+	assert.Equal(t, vObject.NewPriceUnsafe(1500), order.GetOrderProductByProductIDUnsafe(product.ID).Price)
+	assert.Equal(t, vObject.NewPriceUnsafe(4500), order.TotalPrice)
+
+	require.NoError(t, order.TransitionTo(vObject.OrderStatusPaid))
+
+	product.Price = vObject.NewPriceUnsafe(2000)
+
+	_, err = order.ChangeOrderProducts(stocks, product, 4, vObject.OrderPricePolicyReprice)
+	require.ErrorIs(t, err, entities.ErrOrderNotEditable)
+	assert.Equal(t, vObject.NewPriceUnsafe(1500), order.GetOrderProductByProductIDUnsafe(product.ID).Price, "paid order keeps its price")
+	assert.Equal(t, vObject.NewPriceUnsafe(4500), order.TotalPrice)
+}
+
+func TestOrder_ChangeOrderProducts_Remove(t *testing.T) {
+	t.Parallel()
+
+	product := entities.Product{ID: vObject.NewProductIDFromUUIDUnsafe(uuid.New()), Price: vObject.NewPriceUnsafe(1000)}
+	order := entities.NewOrderUnsafe(vObject.NewUserIDFromUUIDUnsafe(uuid.New()))
+
+	_, err := order.ChangeOrderProducts(entities.Stocks{{AvailableQuantity: 10}}, product, 2, vObject.OrderPricePolicySnapshot)
+	require.NoError(t, err)
+
+	removed, err := order.ChangeOrderProducts(entities.Stocks{{AvailableQuantity: 8}}, product, 0, vObject.OrderPricePolicySnapshot)
+	require.NoError(t, err)
+	require.NotNil(t, removed)
+	assert.NotNil(t, removed.DeletedAt)
+	assert.Equal(t, vObject.QuantityZero, removed.Quantity)
+	assert.Equal(t, vObject.NewPriceUnsafe(0), order.TotalPrice)
+	assert.Nil(t, order.GetOrderProductByProductIDUnsafe(product.ID), "deleted line is skipped")
+
+	// повторное удаление ничего не меняет и не вычитает сумму позиции ещё раз
+	removed, err = order.ChangeOrderProducts(entities.Stocks{{AvailableQuantity: 10}}, product, 0, vObject.OrderPricePolicySnapshot)
+	require.NoError(t, err)
+	assert.Nil(t, removed)
+	assert.Len(t, order.Products, 1)
+	assert.Equal(t, vObject.NewPriceUnsafe(0), order.TotalPrice)
+
+	// после удаления прирост считается от нуля, а не от количества удалённой позиции
+	_, err = order.ChangeOrderProducts(entities.Stocks{{AvailableQuantity: 2}}, product, 3, vObject.OrderPricePolicySnapshot)
+	require.ErrorIs(t, err, entities.ErrNotEnoughProductIntStocks)
+
+	added, err := order.ChangeOrderProducts(entities.Stocks{{AvailableQuantity: 3}}, product, 3, vObject.OrderPricePolicySnapshot)
+	require.NoError(t, err)
+	assert.Nil(t, added.DeletedAt)
+	assert.Equal(t, vObject.NewQuantityUnsafe(3), added.Quantity)
+	assert.Equal(t, vObject.NewPriceUnsafe(3000), order.TotalPrice)
+	assert.Equal(t, added, order.GetOrderProductByProductIDUnsafe(product.ID))
+
+	require.Len(t, order.Products, 2)
+	assert.NotNil(t, order.Products[0].DeletedAt, "deleted line stays deleted")
+	assert.Equal(t, vObject.QuantityZero, order.Products[0].Quantity)
+}
+
+func TestOrder_ChangeOrderProducts_NotEditable(t *testing.T) {
+	t.Parallel()
+
+	stocks := entities.Stocks{{AvailableQuantity: 10}}
+	product := entities.Product{ID: vObject.NewProductIDFromUUIDUnsafe(uuid.New()), Price: vObject.NewPriceUnsafe(1000)}
+
+	for _, status := range []vObject.OrderStatus{
+		vObject.OrderStatusPaid,
+		vObject.OrderStatusOrdered,
+		vObject.OrderStatusShipped,
+		vObject.OrderStatusReceived,
+		vObject.OrderStatusReturned,
+		vObject.OrderStatusCanceled,
+	} {
+		order := entities.NewOrderUnsafe(vObject.NewUserIDFromUUIDUnsafe(uuid.New()))
+		order.Status = status
+
+		_, err := order.ChangeOrderProducts(stocks, product, 1, vObject.OrderPricePolicySnapshot)
+		require.ErrorIs(t, err, entities.ErrOrderNotEditable, status)
+		assert.Empty(t, order.Products, status)
+		assert.Equal(t, vObject.NewPriceUnsafe(0), order.TotalPrice, status)
+	}
+}
@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
@@ -26,6 +28,114 @@ type Product struct {
 	Orders    OrderProducts
 }
 
+type Products []Product
+
+var (
+	ErrProductRecNotFound = errors.New("product record not found")
+	ErrProductArchived    = errors.New("product is archived")
+)
+
+// productFields проверенные поля товара, которые задаёт оператор.
+type productFields struct {
+	title       vObject.ProductTitle
+	description vObject.ProductDescription
+	tags        vObject.Tags
+	price       vObject.Price
+}
+
+func newProductFields(title, description string, tags []string, price int64) (productFields, error) {
+	var (
+		f   productFields
+		err error
+	)
+
+	if f.title, err = vObject.NewProductTitle(title); err != nil {
+		return f, fmt.Errorf("[NewProductTitle] %w", err)
+	}
+
+	if f.description, err = vObject.NewProductDescription(description); err != nil {
+		return f, fmt.Errorf("[NewProductDescription] %w", err)
+	}
+
+	if f.tags, err = vObject.NewTags(tags); err != nil {
+		return f, fmt.Errorf("[NewTags] %w", err)
+	}
+
+	if f.price, err = vObject.NewPrice(price); err != nil {
+		return f, fmt.Errorf("[NewPrice] %w", err)
+	}
+
+	return f, nil
+}
+
+func (p *Product) apply(f productFields) {
+	p.Title = f.title
+	p.Description = f.description
+	p.Tags = f.tags
+	p.Price = f.price
+}
+
+// IsArchived сообщает, снят ли товар с продажи.
+func (p *Product) IsArchived() bool {
+	return p.DeletedAt != nil
+}
+
+// Update заменяет название, описание, теги и цену товара. Цена уже оформленных заказов не меняется:
+// позиции заказа хранят цену на момент заказа.
+func (p *Product) Update(title, description string, tags []string, price int64) error {
+	if p.IsArchived() {
+		return fmt.Errorf("[Product.Update] %w", ErrProductArchived)
+	}
+
+	f, err := newProductFields(title, description, tags, price)
+	if err != nil {
+		return fmt.Errorf("[Product.Update] %w", err)
+	}
+
+	p.apply(f)
+	p.UpdatedAt = p.Now()
+
+	return nil
+}
+
+// Archive снимает товар с продажи: запись остаётся для истории заказов и движений, но товар больше
+// не находится и не добавляется в заказы. Возвращает false, если товар уже в архиве.
+func (p *Product) Archive() bool {
+	if p.IsArchived() {
+		return false
+	}
+
+	tn := p.Now()
+	p.DeletedAt = &tn
+	p.UpdatedAt = tn
+
+	return true
+}
+
+func NewProduct(title, description string, tags []string, price int64, opts ...Option[*Product]) (*Product, error) {
+	f, err := newProductFields(title, description, tags, price)
+	if err != nil {
+		return nil, fmt.Errorf("[NewProduct] %w", err)
+	}
+
+	var p Product
+
+	p.apply(f)
+
+	for _, opt := range opts {
+		if err = opt(&p); err != nil {
+			return nil, err
+		}
+	}
+
+	tn := p.Now()
+	p.ID = vObject.NewProductIDFromUUIDUnsafe(p.UUID())
+	p.CreatedAt = tn
+	p.UpdatedAt = tn
+
+	return &p, nil
+}
+
 func NewProductUnsafe(title vObject.ProductTitle, description vObject.ProductDescription, price vObject.Price, opts ...Option[*Product]) Product {
 	p := Product{
 		Title:       title,
@@ -3,17 +3,75 @@ package entities
 import (
 	"time"
 
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
 	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
 )
 
 type ProductMovement struct {
+	now.WithNowGenerator
+	uuid.WithUUIDGenerator
+
 	ID            vObject.ProductMovementID
 	ProductID     vObject.ProductID
 	WarehouseID   vObject.WarehouseID
+	OrderID       *vObject.OrderID         // заказ, для которого выполнена операция
+	TransferID    *vObject.StockTransferID // перемещение между складами, в рамках которого выполнена операция
 	OperationType vObject.OperationType
 	Quantity      vObject.Quantity
 	Price         vObject.Price
+	Reason        *vObject.MovementReason    // причина поступления или списания
+	DocumentRef   *vObject.DocumentReference // документ-основание поступления или списания
 	CreatedAt     time.Time
 }
 
 type ProductMovements []ProductMovement
+
+func NewProductMovementUnsafe(
+	productID vObject.ProductID,
+	warehouseID vObject.WarehouseID,
+	operationType vObject.OperationType,
+	quantity vObject.Quantity,
+	price vObject.Price,
+	opts ...Option[*ProductMovement],
+) ProductMovement {
+	m := ProductMovement{
+		ProductID:     productID,
+		WarehouseID:   warehouseID,
+		OperationType: operationType,
+		Quantity:      quantity,
+		Price:         price,
+	}
+
+	for _, opt := range opts {
+		_ = opt(&m)
+	}
+
+	m.ID = vObject.NewProductMovementIDFromUUIDUnsafe(m.UUID())
+	m.CreatedAt = m.Now()
+
+	return m
+}
+
+// ReservedByWarehouse считает по движениям текущий резерв на каждом складе:
+// резервы за вычетом снятий резерва.
+func (m ProductMovements) ReservedByWarehouse() map[vObject.WarehouseID]vObject.Quantity {
+	reserved := make(map[vObject.WarehouseID]vObject.Quantity)
+
+	for _, movement := range m {
+		switch movement.OperationType {
+		case vObject.OperationTypeReserve:
+			reserved[movement.WarehouseID] += movement.Quantity
+		case vObject.OperationTypeUnreserve:
+			if reserved[movement.WarehouseID] < movement.Quantity {
+				reserved[movement.WarehouseID] = vObject.QuantityZero
+
+				continue
+			}
+
+			reserved[movement.WarehouseID] -= movement.Quantity
+		}
+	}
+
+	return reserved
+}
@@ -0,0 +1,29 @@
+package entities
+
+import vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+
+func WithProductMovementOrderID(orderID vObject.OrderID) func(*ProductMovement) error {
+	return func(m *ProductMovement) error {
+		m.OrderID = &orderID
+
+		return nil
+	}
+}
+
+func WithProductMovementTransferID(transferID vObject.StockTransferID) func(*ProductMovement) error {
+	return func(m *ProductMovement) error {
+		m.TransferID = &transferID
+
+		return nil
+	}
+}
+
+// WithProductMovementDocument указывает причину и документ-основание операции.
+func WithProductMovementDocument(reason vObject.MovementReason, document vObject.DocumentReference) func(*ProductMovement) error {
+	return func(m *ProductMovement) error {
+		m.Reason = &reason
+		m.DocumentRef = &document
+
+		return nil
+	}
+}
@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+var ErrProductPriceRecNotFound = errors.New("product price record not found")
+
+// ProductPrice цена товара, действующая с момента EffectiveFrom до следующей записи истории цен.
+type ProductPrice struct {
+	now.WithNowGenerator
+	uuid.WithUUIDGenerator
+
+	ID            vObject.ProductPriceID
+	ProductID     vObject.ProductID
+	Price         vObject.Price
+	EffectiveFrom time.Time
+	// ActorID пользователь, установивший цену. Пустой для цен, перенесённых из каталога при миграции.
+	ActorID *vObject.UserID
+}
+
+// ProductPrices история цен товара.
+type ProductPrices []ProductPrice
+
+// At возвращает цену, действовавшую в момент at: запись с наибольшим EffectiveFrom не позже at.
+func (p ProductPrices) At(at time.Time) (ProductPrice, bool) {
+	var (
+		found ProductPrice
+		ok    bool
+	)
+
+	for _, price := range p {
+		if price.EffectiveFrom.After(at) {
+			continue
+		}
+
+		if !ok || price.EffectiveFrom.After(found.EffectiveFrom) ||
+			// идентификаторы упорядочены по времени создания, поэтому разрешают записи с одинаковым EffectiveFrom
+			price.EffectiveFrom.Equal(found.EffectiveFrom) && price.ID.String() > found.ID.String() {
+			found, ok = price, true
+		}
+	}
+
+	return found, ok
+}
+
+// NewProductPriceUnsafe запись истории о цене товара, действующей с текущего момента.
+func NewProductPriceUnsafe(
+	productID vObject.ProductID,
+	price vObject.Price,
+	actorID *vObject.UserID,
+	opts ...Option[*ProductPrice],
+) ProductPrice {
+	p := ProductPrice{
+		ProductID: productID,
+		Price:     price,
+		ActorID:   actorID,
+	}
+
+	for _, opt := range opts {
+		_ = opt(&p)
+	}
+
+	p.ID = vObject.NewProductPriceIDFromUUIDUnsafe(p.UUID())
+	p.EffectiveFrom = p.Now()
+
+	return p
+}
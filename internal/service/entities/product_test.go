@@ -0,0 +1,112 @@
+//go:build unit
+
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+func TestNewProduct(t *testing.T) {
+	t.Parallel()
+
+	tn := time.Now().UTC().Truncate(time.Second)
+	nowFunc := now.NewMock(gomock.NewController(t))
+	nowFunc.EXPECT().Now().Return(tn)
+
+	product, err := entities.NewProduct(" Молоко ", "Пастеризованное", []string{"Молочное", "молочное"}, 8900,
+		entities.WithNowFunc[*entities.Product](nowFunc),
+	)
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, product.ID.UUID())
+	assert.Equal(t, vObject.NewProductTitleUnsafe("Молоко"), product.Title)
+	assert.Equal(t, vObject.Tags{"молочное"}, product.Tags)
+	assert.Equal(t, vObject.NewPriceUnsafe(8900), product.Price)
+	assert.Equal(t, tn, product.CreatedAt)
+	assert.Equal(t, tn, product.UpdatedAt)
+	assert.False(t, product.IsArchived())
+
+	_, err = entities.NewProduct("", "", nil, 8900)
+	require.ErrorIs(t, err, vObject.ErrEmptyProductTitle)
+
+	_, err = entities.NewProduct("Молоко", "", []string{""}, 8900)
+	require.ErrorIs(t, err, vObject.ErrEmptyTag)
+
+	_, err = entities.NewProduct("Молоко", "", nil, -1)
+	require.ErrorIs(t, err, vObject.ErrNegativePrice)
+}
+
+func TestProduct_UpdateAndArchive(t *testing.T) {
+	t.Parallel()
+
+	tn := time.Now().UTC().Truncate(time.Second)
+	nowFunc := now.NewMock(gomock.NewController(t))
+	nowFunc.EXPECT().Now().Return(tn).Times(2)
+
+	product := entities.Product{
+		Title: vObject.NewProductTitleUnsafe("Молоко"),
+		Price: vObject.NewPriceUnsafe(8900),
+	}
+	product.SetNowGen(nowFunc)
+
+	require.ErrorIs(t, product.Update("Молоко", "", nil, -1), vObject.ErrNegativePrice)
+	assert.Equal(t, vObject.NewPriceUnsafe(8900), product.Price, "invalid update changes nothing")
+
+	require.NoError(t, product.Update("Кефир", "1%", []string{"напитки"}, 7500))
+	assert.Equal(t, vObject.NewProductTitleUnsafe("Кефир"), product.Title)
+	assert.Equal(t, vObject.NewProductDescriptionUnsafe("1%"), product.Description)
+	assert.Equal(t, vObject.Tags{"напитки"}, product.Tags)
+	assert.Equal(t, vObject.NewPriceUnsafe(7500), product.Price)
+	assert.Equal(t, tn, product.UpdatedAt)
+
+	assert.True(t, product.Archive())
+	require.NotNil(t, product.DeletedAt)
+	assert.Equal(t, tn, *product.DeletedAt)
+	assert.True(t, product.IsArchived())
+	assert.False(t, product.Archive(), "already archived")
+
+	require.ErrorIs(t, product.Update("Кефир", "", nil, 7500), entities.ErrProductArchived)
+}
+
+func TestProductPrices_At(t *testing.T) {
+	t.Parallel()
+
+	tn := time.Now().UTC().Truncate(time.Second)
+	productID := vObject.NewProductIDFromUUIDUnsafe(uuid.New())
+	price := func(cents int, effectiveFrom time.Time) entities.ProductPrice {
+		nowFunc := now.NewMock(gomock.NewController(t))
+		nowFunc.EXPECT().Now().Return(effectiveFrom)
+
+		return entities.NewProductPriceUnsafe(productID, vObject.NewPriceUnsafe(cents), nil,
+			entities.WithNowFunc[*entities.ProductPrice](nowFunc),
+		)
+	}
+
+	first := price(1000, tn.Add(-2*time.Hour))
+	second := price(1500, tn.Add(-time.Hour))
+	prices := entities.ProductPrices{second, first}
+
+	_, ok := prices.At(tn.Add(-3 * time.Hour))
+	assert.False(t, ok, "no price before the first record")
+
+	got, ok := prices.At(tn.Add(-90 * time.Minute))
+	require.True(t, ok)
+	assert.Equal(t, first, got)
+
+	got, ok = prices.At(second.EffectiveFrom)
+	require.True(t, ok)
+	assert.Equal(t, second, got, "price is effective from its timestamp inclusive")
+
+	got, ok = prices.At(tn)
+	require.True(t, ok)
+	assert.Equal(t, vObject.NewPriceUnsafe(1500), got.Price)
+}
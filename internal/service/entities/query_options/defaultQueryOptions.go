@@ -2,7 +2,9 @@ package queryoptions
 
 type QueryOptionable interface {
 	IsForUpdate() bool
+	IsFromSync() bool
 	setForUpdate()
+	setFromSync()
 }
 
 type BasicQueryOptions struct {
@@ -30,6 +32,14 @@ func WithForUpdate[T QueryOptionable]() QueryOption[T] {
 	}
 }
 
+// WithFromSync читает из синхронной реплики: в отличие от асинхронной, в ней видны
+// все зафиксированные изменения. Для данных, устаревание которых недопустимо.
+func WithFromSync[T QueryOptionable]() QueryOption[T] {
+	return func(options T) {
+		options.setFromSync()
+	}
+}
+
 func (s *BasicQueryOptions) setForUpdate() {
 	s.forUpdate = true
 }
@@ -37,3 +47,11 @@ func (s *BasicQueryOptions) setForUpdate() {
 func (s BasicQueryOptions) IsForUpdate() bool {
 	return s.forUpdate
 }
+
+func (s *BasicQueryOptions) setFromSync() {
+	s.fromSync = true
+}
+
+func (s BasicQueryOptions) IsFromSync() bool {
+	return s.fromSync
+}
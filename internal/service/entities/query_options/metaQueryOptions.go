@@ -1,20 +1,35 @@
 package queryoptions
 
-import vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+import (
+	"slices"
+
+	"github.com/google/uuid"
+
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
 
 type MetaQueryOptionable interface {
 	ForLimit() uint64
 	ForOffset() uint64
+	// ForCursor курсор страницы, nil — навигация по номеру страницы.
+	ForCursor() *vObject.Cursor
+	ForKeyset(desc bool) (from *uuid.UUID, ascending bool)
 	GetMeta() *vObject.Meta
 	WithMetaTotal(total int)
+	WithMetaCursors(prev, next *vObject.Cursor)
 	setPage(page int)
 	setPerPage(perPage int)
+	setCursor(cursor vObject.Cursor)
 }
 
 type MetaQueryOptions struct {
 	Page    int
 	PerPage int
 	Total   int
+
+	Cursor     *vObject.Cursor
+	PrevCursor *vObject.Cursor
+	NextCursor *vObject.Cursor
 }
 
 var _ MetaQueryOptionable = (*MetaQueryOptions)(nil)
@@ -49,6 +64,13 @@ func WithMetaPerPage[T MetaQueryOptionable](perPage int) QueryOption[T] {
 	}
 }
 
+// WithMetaCursor включает навигацию по курсору вместо номера страницы.
+func WithMetaCursor[T MetaQueryOptionable](cursor vObject.Cursor) QueryOption[T] {
+	return func(options T) {
+		options.setCursor(cursor)
+	}
+}
+
 func (m *MetaQueryOptions) ForLimit() uint64 {
 	return uint64(m.PerPage)
 }
@@ -61,11 +83,58 @@ func (m *MetaQueryOptions) ForOffset() uint64 {
 	return (uint64(m.Page) - 1) * m.ForLimit()
 }
 
+func (m *MetaQueryOptions) ForCursor() *vObject.Cursor {
+	return m.Cursor
+}
+
+// ForKeyset граница и порядок выборки страницы курсора из списка, упорядоченного по id по убыванию (desc)
+// или по возрастанию. Выбираются записи с id больше from при ascending и меньше from иначе, from == nil
+// на первой странице. Выборку из ForLimit()+1 записей в порядке ascending передают в KeysetPage.
+func (m *MetaQueryOptions) ForKeyset(desc bool) (*uuid.UUID, bool) {
+	if m.Cursor == nil {
+		return nil, !desc
+	}
+
+	// предыдущая страница выбирается в обратном порядке от записи курсора
+	ascending := !desc
+	if m.Cursor.IsPrev() {
+		ascending = desc
+	}
+
+	if m.Cursor.IsFirst() {
+		return nil, ascending
+	}
+
+	from := m.Cursor.ID
+
+	return &from, ascending
+}
+
 func (m *MetaQueryOptions) WithMetaTotal(total int) {
 	m.Total = total
 }
 
+// WithMetaCursors курсоры соседних страниц, nil — страницы нет.
+func (m *MetaQueryOptions) WithMetaCursors(prev, next *vObject.Cursor) {
+	m.PrevCursor = prev
+	m.NextCursor = next
+}
+
 func (m MetaQueryOptions) GetMeta() *vObject.Meta {
+	if m.Cursor != nil {
+		meta := vObject.Meta{PerPage: vObject.NewMetaPerPage(int(m.ForLimit()))}
+
+		if m.PrevCursor != nil {
+			meta.PrevCursor = m.PrevCursor.Token()
+		}
+
+		if m.NextCursor != nil {
+			meta.NextCursor = m.NextCursor.Token()
+		}
+
+		return &meta
+	}
+
 	return &vObject.Meta{
 		Total:    vObject.NewMetaTotal(m.Total),
 		PerPage:  vObject.NewMetaPerPage(int(m.ForLimit())),
@@ -81,3 +150,50 @@ func (m *MetaQueryOptions) setPage(page int) {
 func (m *MetaQueryOptions) setPerPage(perPage int) {
 	m.PerPage = perPage
 }
+
+func (m *MetaQueryOptions) setCursor(cursor vObject.Cursor) {
+	m.Cursor = &cursor
+}
+
+// KeysetPage обрезает выборку, полученную по ForKeyset, до страницы в порядке списка и записывает
+// в qos курсоры соседних страниц. Без курсора возвращает rows как есть.
+func KeysetPage[T any](qos MetaQueryOptionable, rows []T, id func(T) uuid.UUID) []T {
+	cursor := qos.ForCursor()
+	if cursor == nil {
+		return rows
+	}
+
+	limit := int(qos.ForLimit())
+
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	if cursor.IsPrev() {
+		slices.Reverse(rows)
+	}
+
+	if len(rows) == 0 {
+		qos.WithMetaCursors(nil, nil)
+
+		return rows
+	}
+
+	var prev, next *vObject.Cursor
+
+	// назад можно вернуться со всех страниц, кроме первой; вперёд — со всех, кроме последней
+	if (cursor.IsPrev() && hasMore) || (!cursor.IsPrev() && !cursor.IsFirst()) {
+		c := vObject.NewPrevCursor(id(rows[0]))
+		prev = &c
+	}
+
+	if cursor.IsPrev() || hasMore {
+		c := vObject.NewNextCursor(id(rows[len(rows)-1]))
+		next = &c
+	}
+
+	qos.WithMetaCursors(prev, next)
+
+	return rows
+}
@@ -3,6 +3,7 @@ package queryoptions_test
 import (
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
 	queryoptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
@@ -35,3 +36,45 @@ func TestMetaQueryOptions_ForLimit(t *testing.T) {
 		LastPage: 16,
 	}, *metaQos.GetMeta())
 }
+
+func TestKeysetPage(t *testing.T) {
+	t.Parallel()
+
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	id := func(u uuid.UUID) uuid.UUID { return u }
+
+	cursorQos := func(cursor vObject.Cursor) *queryoptions.MetaQueryOptions {
+		return queryoptions.NewMetaQueryOptions(
+			queryoptions.WithMetaCursor[*queryoptions.MetaQueryOptions](cursor),
+			queryoptions.WithMetaPerPage[*queryoptions.MetaQueryOptions](2),
+		)
+	}
+
+	// первая страница: выбрано на одну запись больше страницы
+	qos := cursorQos(vObject.Cursor{Direction: vObject.CursorNext})
+	from, ascending := qos.ForKeyset(true)
+	assert.Nil(t, from)
+	assert.False(t, ascending)
+	assert.Equal(t, ids[:2], queryoptions.KeysetPage(qos, ids, id))
+	assert.Equal(t, vObject.Meta{PerPage: 2, NextCursor: vObject.NewNextCursor(ids[1]).Token()}, *qos.GetMeta())
+
+	// последняя страница
+	qos = cursorQos(vObject.NewNextCursor(ids[1]))
+	from, ascending = qos.ForKeyset(true)
+	assert.Equal(t, ids[1], *from)
+	assert.False(t, ascending)
+	assert.Equal(t, ids[2:], queryoptions.KeysetPage(qos, ids[2:], id))
+	assert.Equal(t, vObject.Meta{PerPage: 2, PrevCursor: vObject.NewPrevCursor(ids[2]).Token()}, *qos.GetMeta())
+
+	// назад: выборка идёт в обратном порядке и разворачивается
+	qos = cursorQos(vObject.NewPrevCursor(ids[2]))
+	_, ascending = qos.ForKeyset(true)
+	assert.True(t, ascending)
+	assert.Equal(t, []uuid.UUID{ids[0], ids[1]}, queryoptions.KeysetPage(qos, []uuid.UUID{ids[1], ids[0]}, id))
+	assert.Equal(t, vObject.Meta{PerPage: 2, NextCursor: vObject.NewNextCursor(ids[1]).Token()}, *qos.GetMeta())
+
+	// без курсора выборка не меняется
+	qos = queryoptions.NewMetaQueryOptions()
+	assert.Equal(t, ids, queryoptions.KeysetPage(qos, ids, id))
+	assert.Nil(t, qos.ForCursor())
+}
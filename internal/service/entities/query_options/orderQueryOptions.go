@@ -1,25 +1,69 @@
 package queryoptions
 
-import vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+import (
+	"time"
+
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
 
 type OrderQueryOptionable interface {
 	QueryOptionable
 	MetaQueryOptionable
 
 	ForOrderID() *vObject.OrderID
+	ForUserID() *vObject.UserID
+	ForStatuses() []vObject.OrderStatus
+	ForCreatedFrom() *time.Time
+	ForCreatedTo() *time.Time
+	ForSort() vObject.OrderSort
+	IsWithProducts() bool
 }
 
 type OrderQueryOptions struct {
 	BasicQueryOptions
 	MetaQueryOptions
 
-	orderID vObject.OrderID
+	orderID      vObject.OrderID
+	userID       *vObject.UserID
+	statuses     []vObject.OrderStatus
+	createdFrom  *time.Time
+	createdTo    *time.Time
+	sort         vObject.OrderSort
+	withProducts bool
 }
 
 func (p OrderQueryOptions) ForOrderID() *vObject.OrderID {
 	return &p.orderID
 }
 
+func (p OrderQueryOptions) ForUserID() *vObject.UserID {
+	return p.userID
+}
+
+// ForStatuses статусы заказов, пустой список — любой статус.
+func (p OrderQueryOptions) ForStatuses() []vObject.OrderStatus {
+	return p.statuses
+}
+
+// ForCreatedFrom начало периода создания заказа включительно.
+func (p OrderQueryOptions) ForCreatedFrom() *time.Time {
+	return p.createdFrom
+}
+
+// ForCreatedTo конец периода создания заказа, не включая его.
+func (p OrderQueryOptions) ForCreatedTo() *time.Time {
+	return p.createdTo
+}
+
+func (p OrderQueryOptions) ForSort() vObject.OrderSort {
+	return p.sort
+}
+
+// IsWithProducts загружать ли позиции заказов вместе с товарами.
+func (p OrderQueryOptions) IsWithProducts() bool {
+	return p.withProducts
+}
+
 type OrderQueryOption func(options *OrderQueryOptions)
 
 var _ OrderQueryOptionable = (*OrderQueryOptions)(nil)
@@ -28,6 +72,7 @@ func NewOrderQueryOptions(queryOption ...QueryOption[*OrderQueryOptions]) *Order
 	qos := OrderQueryOptions{
 		BasicQueryOptions: *NewBasicQueryOptions(),
 		MetaQueryOptions:  *NewMetaQueryOptions(),
+		sort:              vObject.OrderSortNewest,
 	}
 
 	for _, opt := range queryOption {
@@ -42,3 +87,39 @@ func WithOrderID(orderID vObject.OrderID) QueryOption[*OrderQueryOptions] {
 		options.orderID = orderID
 	}
 }
+
+func WithOrderUserID(userID vObject.UserID) QueryOption[*OrderQueryOptions] {
+	return func(options *OrderQueryOptions) {
+		options.userID = &userID
+	}
+}
+
+func WithOrderStatuses(statuses ...vObject.OrderStatus) QueryOption[*OrderQueryOptions] {
+	return func(options *OrderQueryOptions) {
+		options.statuses = statuses
+	}
+}
+
+func WithOrderCreatedFrom(from time.Time) QueryOption[*OrderQueryOptions] {
+	return func(options *OrderQueryOptions) {
+		options.createdFrom = &from
+	}
+}
+
+func WithOrderCreatedTo(to time.Time) QueryOption[*OrderQueryOptions] {
+	return func(options *OrderQueryOptions) {
+		options.createdTo = &to
+	}
+}
+
+func WithOrderSort(sort vObject.OrderSort) QueryOption[*OrderQueryOptions] {
+	return func(options *OrderQueryOptions) {
+		options.sort = sort
+	}
+}
+
+func WithOrderProducts() QueryOption[*OrderQueryOptions] {
+	return func(options *OrderQueryOptions) {
+		options.withProducts = true
+	}
+}
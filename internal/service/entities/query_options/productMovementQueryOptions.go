@@ -0,0 +1,55 @@
+package queryoptions
+
+import vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+
+type ProductMovementQueryOptionable interface {
+	QueryOptionable
+	MetaQueryOptionable
+
+	ForProductID() *vObject.ProductID
+	ForOrderID() *vObject.OrderID
+}
+
+type ProductMovementQueryOptions struct {
+	BasicQueryOptions
+	MetaQueryOptions
+
+	productID vObject.ProductID
+	orderID   *vObject.OrderID
+}
+
+func (p ProductMovementQueryOptions) ForProductID() *vObject.ProductID {
+	return &p.productID
+}
+
+// ForOrderID возвращает заказ, по которому фильтруются движения, либо nil.
+func (p ProductMovementQueryOptions) ForOrderID() *vObject.OrderID {
+	return p.orderID
+}
+
+var _ ProductMovementQueryOptionable = (*ProductMovementQueryOptions)(nil)
+
+func NewProductMovementQueryOptions(queryOption ...QueryOption[*ProductMovementQueryOptions]) *ProductMovementQueryOptions {
+	qos := ProductMovementQueryOptions{
+		BasicQueryOptions: *NewBasicQueryOptions(),
+		MetaQueryOptions:  *NewMetaQueryOptions(),
+	}
+
+	for _, opt := range queryOption {
+		opt(&qos)
+	}
+
+	return &qos
+}
+
+func WithMovementProductID(productID vObject.ProductID) QueryOption[*ProductMovementQueryOptions] {
+	return func(options *ProductMovementQueryOptions) {
+		options.productID = productID
+	}
+}
+
+func WithMovementOrderID(orderID vObject.OrderID) QueryOption[*ProductMovementQueryOptions] {
+	return func(options *ProductMovementQueryOptions) {
+		options.orderID = &orderID
+	}
+}
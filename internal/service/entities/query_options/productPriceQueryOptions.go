@@ -0,0 +1,56 @@
+package queryoptions
+
+import (
+	"time"
+
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+type ProductPriceQueryOptionable interface {
+	QueryOptionable
+
+	ForProductID() *vObject.ProductID
+	At() time.Time
+}
+
+type ProductPriceQueryOptions struct {
+	BasicQueryOptions
+
+	productID vObject.ProductID
+	at        time.Time
+}
+
+func (p ProductPriceQueryOptions) ForProductID() *vObject.ProductID {
+	return &p.productID
+}
+
+// At момент времени, на который запрашивается цена.
+func (p ProductPriceQueryOptions) At() time.Time {
+	return p.at
+}
+
+var _ ProductPriceQueryOptionable = (*ProductPriceQueryOptions)(nil)
+
+func NewProductPriceQueryOptions(queryOption ...QueryOption[*ProductPriceQueryOptions]) *ProductPriceQueryOptions {
+	qos := ProductPriceQueryOptions{
+		BasicQueryOptions: *NewBasicQueryOptions(),
+	}
+
+	for _, opt := range queryOption {
+		opt(&qos)
+	}
+
+	return &qos
+}
+
+func WithPriceProductID(productID vObject.ProductID) QueryOption[*ProductPriceQueryOptions] {
+	return func(options *ProductPriceQueryOptions) {
+		options.productID = productID
+	}
+}
+
+func WithPriceAt(at time.Time) QueryOption[*ProductPriceQueryOptions] {
+	return func(options *ProductPriceQueryOptions) {
+		options.at = at
+	}
+}
@@ -7,19 +7,66 @@ type ProductQueryOptionable interface {
 	MetaQueryOptionable
 
 	ForProductID() *vObject.ProductID
+	ForTags() (vObject.Tags, vObject.TagsMatch)
+	ForTitle() string
+	ForSearch() string
+	ForMinPrice() *vObject.Price
+	ForMaxPrice() *vObject.Price
+	IsInStockOnly() bool
+	ForSort() vObject.ProductSort
 }
 
 type ProductQueryOptions struct {
 	BasicQueryOptions
 	MetaQueryOptions
 
-	productID vObject.ProductID
+	productID   vObject.ProductID
+	tags        vObject.Tags
+	tagsMatch   vObject.TagsMatch
+	title       string
+	search      string
+	minPrice    *vObject.Price
+	maxPrice    *vObject.Price
+	inStockOnly bool
+	sort        vObject.ProductSort
 }
 
 func (p ProductQueryOptions) ForProductID() *vObject.ProductID {
 	return &p.productID
 }
 
+// ForTags теги фильтра и способ их сравнения с тегами товара.
+func (p ProductQueryOptions) ForTags() (vObject.Tags, vObject.TagsMatch) {
+	return p.tags, p.tagsMatch
+}
+
+// ForTitle подстрока названия товара без учёта регистра.
+func (p ProductQueryOptions) ForTitle() string {
+	return p.title
+}
+
+// ForSearch текст полнотекстового поиска по названию и описанию товара.
+func (p ProductQueryOptions) ForSearch() string {
+	return p.search
+}
+
+func (p ProductQueryOptions) ForMinPrice() *vObject.Price {
+	return p.minPrice
+}
+
+func (p ProductQueryOptions) ForMaxPrice() *vObject.Price {
+	return p.maxPrice
+}
+
+// IsInStockOnly только товары, доступные на складах.
+func (p ProductQueryOptions) IsInStockOnly() bool {
+	return p.inStockOnly
+}
+
+func (p ProductQueryOptions) ForSort() vObject.ProductSort {
+	return p.sort
+}
+
 type ProductQueryOption func(options *ProductQueryOptions)
 
 var _ ProductQueryOptionable = (*ProductQueryOptions)(nil)
@@ -28,6 +75,8 @@ func NewProductQueryOptions(queryOption ...QueryOption[*ProductQueryOptions]) *P
 	qos := ProductQueryOptions{
 		BasicQueryOptions: *NewBasicQueryOptions(),
 		MetaQueryOptions:  *NewMetaQueryOptions(),
+		tagsMatch:         vObject.TagsMatchAny,
+		sort:              vObject.ProductSortNewest,
 	}
 
 	for _, opt := range queryOption {
@@ -42,3 +91,46 @@ func WithProductID(productID vObject.ProductID) QueryOption[*ProductQueryOptions
 		options.productID = productID
 	}
 }
+
+func WithProductTags(tags vObject.Tags, match vObject.TagsMatch) QueryOption[*ProductQueryOptions] {
+	return func(options *ProductQueryOptions) {
+		options.tags = tags
+		options.tagsMatch = match
+	}
+}
+
+func WithProductTitle(title string) QueryOption[*ProductQueryOptions] {
+	return func(options *ProductQueryOptions) {
+		options.title = title
+	}
+}
+
+func WithProductSearch(search string) QueryOption[*ProductQueryOptions] {
+	return func(options *ProductQueryOptions) {
+		options.search = search
+	}
+}
+
+func WithProductMinPrice(price vObject.Price) QueryOption[*ProductQueryOptions] {
+	return func(options *ProductQueryOptions) {
+		options.minPrice = &price
+	}
+}
+
+func WithProductMaxPrice(price vObject.Price) QueryOption[*ProductQueryOptions] {
+	return func(options *ProductQueryOptions) {
+		options.maxPrice = &price
+	}
+}
+
+func WithProductInStockOnly() QueryOption[*ProductQueryOptions] {
+	return func(options *ProductQueryOptions) {
+		options.inStockOnly = true
+	}
+}
+
+func WithProductSort(sort vObject.ProductSort) QueryOption[*ProductQueryOptions] {
+	return func(options *ProductQueryOptions) {
+		options.sort = sort
+	}
+}
@@ -0,0 +1,39 @@
+package queryoptions
+
+import vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+
+type SessionQueryOptionable interface {
+	QueryOptionable
+
+	ForSessionID() *vObject.SessionID
+}
+
+type SessionQueryOptions struct {
+	BasicQueryOptions
+
+	sessionID vObject.SessionID
+}
+
+var _ SessionQueryOptionable = (*SessionQueryOptions)(nil)
+
+func NewSessionQueryOptions(queryOption ...QueryOption[*SessionQueryOptions]) *SessionQueryOptions {
+	qos := SessionQueryOptions{
+		BasicQueryOptions: *NewBasicQueryOptions(),
+	}
+
+	for _, opt := range queryOption {
+		opt(&qos)
+	}
+
+	return &qos
+}
+
+func (q SessionQueryOptions) ForSessionID() *vObject.SessionID {
+	return &q.sessionID
+}
+
+func WithSessionID(sessionID vObject.SessionID) QueryOption[*SessionQueryOptions] {
+	return func(options *SessionQueryOptions) {
+		options.sessionID = sessionID
+	}
+}
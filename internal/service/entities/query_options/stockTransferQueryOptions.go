@@ -0,0 +1,39 @@
+package queryoptions
+
+import vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+
+type StockTransferQueryOptionable interface {
+	QueryOptionable
+
+	ForStockTransferID() *vObject.StockTransferID
+}
+
+type StockTransferQueryOptions struct {
+	BasicQueryOptions
+
+	transferID vObject.StockTransferID
+}
+
+var _ StockTransferQueryOptionable = (*StockTransferQueryOptions)(nil)
+
+func NewStockTransferQueryOptions(queryOption ...QueryOption[*StockTransferQueryOptions]) *StockTransferQueryOptions {
+	qos := StockTransferQueryOptions{
+		BasicQueryOptions: *NewBasicQueryOptions(),
+	}
+
+	for _, opt := range queryOption {
+		opt(&qos)
+	}
+
+	return &qos
+}
+
+func (q StockTransferQueryOptions) ForStockTransferID() *vObject.StockTransferID {
+	return &q.transferID
+}
+
+func WithStockTransferID(transferID vObject.StockTransferID) QueryOption[*StockTransferQueryOptions] {
+	return func(options *StockTransferQueryOptions) {
+		options.transferID = transferID
+	}
+}
@@ -0,0 +1,51 @@
+package queryoptions
+
+import vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+
+type UserQueryOptionable interface {
+	QueryOptionable
+
+	ForUserEmail() vObject.Email
+	ForUserID() *vObject.UserID
+}
+
+type UserQueryOptions struct {
+	BasicQueryOptions
+
+	email  vObject.Email
+	userID *vObject.UserID
+}
+
+var _ UserQueryOptionable = (*UserQueryOptions)(nil)
+
+func NewUserQueryOptions(queryOption ...QueryOption[*UserQueryOptions]) *UserQueryOptions {
+	qos := UserQueryOptions{
+		BasicQueryOptions: *NewBasicQueryOptions(),
+	}
+
+	for _, opt := range queryOption {
+		opt(&qos)
+	}
+
+	return &qos
+}
+
+func (q UserQueryOptions) ForUserEmail() vObject.Email {
+	return q.email
+}
+
+func (q UserQueryOptions) ForUserID() *vObject.UserID {
+	return q.userID
+}
+
+func WithUserEmail(email vObject.Email) QueryOption[*UserQueryOptions] {
+	return func(options *UserQueryOptions) {
+		options.email = email
+	}
+}
+
+func WithUserID(userID vObject.UserID) QueryOption[*UserQueryOptions] {
+	return func(options *UserQueryOptions) {
+		options.userID = &userID
+	}
+}
@@ -0,0 +1,47 @@
+package queryoptions
+
+import "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+
+type UserTokenQueryOptionable interface {
+	QueryOptionable
+
+	ForUserTokenHash() string
+	ForUserTokenPurpose() entities.UserTokenPurpose
+}
+
+type UserTokenQueryOptions struct {
+	BasicQueryOptions
+
+	hash    string
+	purpose entities.UserTokenPurpose
+}
+
+var _ UserTokenQueryOptionable = (*UserTokenQueryOptions)(nil)
+
+func NewUserTokenQueryOptions(queryOption ...QueryOption[*UserTokenQueryOptions]) *UserTokenQueryOptions {
+	qos := UserTokenQueryOptions{
+		BasicQueryOptions: *NewBasicQueryOptions(),
+	}
+
+	for _, opt := range queryOption {
+		opt(&qos)
+	}
+
+	return &qos
+}
+
+func (q UserTokenQueryOptions) ForUserTokenHash() string {
+	return q.hash
+}
+
+func (q UserTokenQueryOptions) ForUserTokenPurpose() entities.UserTokenPurpose {
+	return q.purpose
+}
+
+// WithUserToken ищет токен по хешу секрета и назначению: токен сброса пароля не подтверждает email и наоборот.
+func WithUserToken(hash string, purpose entities.UserTokenPurpose) QueryOption[*UserTokenQueryOptions] {
+	return func(options *UserTokenQueryOptions) {
+		options.hash = hash
+		options.purpose = purpose
+	}
+}
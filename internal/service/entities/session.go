@@ -0,0 +1,89 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	baseUUID "github.com/google/uuid"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+// Session сессия пользователя, открытая при входе. Токены выдаются в рамках сессии, из токенов обновления
+// действителен только последний выданный (RefreshTokenID). Отзыв сессии делает недействительными все её токены.
+type Session struct {
+	now.WithNowGenerator
+	uuid.WithUUIDGenerator
+
+	ID             vObject.SessionID
+	UserID         vObject.UserID
+	RefreshTokenID baseUUID.UUID
+	ExpiresAt      time.Time
+	RevokedAt      *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+var (
+	ErrSessionRecNotFound = errors.New("session not found")
+	ErrSessionInactive    = errors.New("session is revoked or expired")
+	ErrRefreshTokenReused = errors.New("refresh token has already been used")
+)
+
+// NewSession открывает сессию пользователя сроком на ttl.
+func NewSession(userID vObject.UserID, ttl time.Duration, opts ...Option[*Session]) Session {
+	s := Session{UserID: userID}
+
+	for _, opt := range opts {
+		_ = opt(&s)
+	}
+
+	tn := s.Now()
+	s.ID = vObject.NewSessionIDFromUUIDUnsafe(s.UUID())
+	s.RefreshTokenID = s.UUID()
+	s.ExpiresAt = tn.Add(ttl)
+	s.CreatedAt = tn
+	s.UpdatedAt = tn
+
+	return s
+}
+
+// IsActive сообщает, что сессия не отозвана и не истекла.
+func (s *Session) IsActive() bool {
+	return s.RevokedAt == nil && s.Now().Before(s.ExpiresAt)
+}
+
+// Rotate заменяет токен обновления refreshID новым и продлевает сессию на ttl.
+// Повторное предъявление уже заменённого токена означает его утечку: сессия отзывается,
+// возвращается ErrRefreshTokenReused, и отзыв нужно сохранить.
+func (s *Session) Rotate(refreshID baseUUID.UUID, ttl time.Duration) error {
+	if !s.IsActive() {
+		return ErrSessionInactive
+	}
+
+	if refreshID != s.RefreshTokenID {
+		s.Revoke()
+
+		return ErrRefreshTokenReused
+	}
+
+	tn := s.Now()
+	s.RefreshTokenID = s.UUID()
+	s.ExpiresAt = tn.Add(ttl)
+	s.UpdatedAt = tn
+
+	return nil
+}
+
+// Revoke отзывает сессию. Повторный отзыв ничего не меняет.
+func (s *Session) Revoke() {
+	if s.RevokedAt != nil {
+		return
+	}
+
+	tn := s.Now()
+	s.RevokedAt = &tn
+	s.UpdatedAt = tn
+}
@@ -0,0 +1,84 @@
+//go:build unit
+
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	uuidGen "github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+func TestSession_Rotate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	nowFunc := now.NewMock(ctrl)
+	uuidFunc := uuidGen.NewMock(ctrl)
+
+	created := time.Now().UTC().Truncate(time.Second)
+	sessionID, refreshID, rotatedID := uuid.New(), uuid.New(), uuid.New()
+
+	nowFunc.EXPECT().Now().Return(created)
+	uuidFunc.EXPECT().UUID().Return(sessionID)
+	uuidFunc.EXPECT().UUID().Return(refreshID)
+
+	session := entities.NewSession(
+		vObject.NewUserIDFromUUIDUnsafe(uuid.New()),
+		time.Hour,
+		entities.WithNowFunc[*entities.Session](nowFunc),
+		entities.WithUUIDFunc[*entities.Session](uuidFunc),
+	)
+	assert.Equal(t, sessionID, session.ID.UUID())
+	assert.Equal(t, refreshID, session.RefreshTokenID)
+	assert.Equal(t, created.Add(time.Hour), session.ExpiresAt)
+
+	rotated := created.Add(30 * time.Minute)
+
+	nowFunc.EXPECT().Now().Return(rotated).Times(2)
+	uuidFunc.EXPECT().UUID().Return(rotatedID)
+
+	require.NoError(t, session.Rotate(refreshID, time.Hour))
+	assert.Equal(t, rotatedID, session.RefreshTokenID)
+	assert.Equal(t, rotated.Add(time.Hour), session.ExpiresAt)
+	assert.Nil(t, session.RevokedAt)
+
+	// предъявлен уже заменённый токен
+	nowFunc.EXPECT().Now().Return(rotated).Times(2)
+
+	require.ErrorIs(t, session.Rotate(refreshID, time.Hour), entities.ErrRefreshTokenReused)
+	require.NotNil(t, session.RevokedAt)
+
+	nowFunc.EXPECT().Now().Return(rotated).AnyTimes()
+
+	assert.False(t, session.IsActive())
+	require.ErrorIs(t, session.Rotate(rotatedID, time.Hour), entities.ErrSessionInactive)
+}
+
+func TestSession_IsActive(t *testing.T) {
+	t.Parallel()
+
+	nowFunc := now.NewMock(gomock.NewController(t))
+	tn := time.Now()
+
+	session := entities.Session{ExpiresAt: tn.Add(time.Minute)}
+	session.SetNowGen(nowFunc)
+
+	nowFunc.EXPECT().Now().Return(tn)
+	assert.True(t, session.IsActive())
+
+	nowFunc.EXPECT().Now().Return(tn.Add(time.Minute))
+	assert.False(t, session.IsActive(), "expired")
+
+	nowFunc.EXPECT().Now().Return(tn)
+	session.Revoke()
+	assert.False(t, session.IsActive(), "revoked")
+}
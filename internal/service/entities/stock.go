@@ -2,6 +2,7 @@ package entities
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
@@ -15,12 +16,28 @@ type Stock struct {
 	WarehouseID       vObject.WarehouseID
 	AvailableQuantity vObject.Quantity
 	ReservedQuantity  vObject.Quantity
+	InTransitQuantity vObject.Quantity // товар, отгруженный на склад другим складом и ещё не принятый
 	CreatedAt         time.Time
 }
 
 type Stocks []Stock
 
-var ErrNotEnoughProductIntStocks = errors.New("not enough products in stocks")
+var (
+	ErrNotEnoughProductIntStocks = errors.New("not enough products in stocks")
+	ErrNotEnoughReservedProduct  = errors.New("not enough reserved products in stocks")
+	ErrEmptyStockQuantity        = errors.New("stock operation quantity must be positive")
+	ErrWriteOffBelowReserved     = errors.New("write-off would drop available quantity below reserved")
+	ErrStockNotFound             = errors.New("product stock not found in warehouse")
+)
+
+// FreeQuantity количество товара на складе, доступное для резервирования.
+func (s Stock) FreeQuantity() vObject.Quantity {
+	if s.ReservedQuantity > s.AvailableQuantity {
+		return vObject.QuantityZero
+	}
+
+	return s.AvailableQuantity - s.ReservedQuantity
+}
 
 func (s Stocks) GetAvailableQuantity() vObject.Quantity {
 	var quantity vObject.Quantity
@@ -33,6 +50,17 @@ func (s Stocks) GetAvailableQuantity() vObject.Quantity {
 	return quantity
 }
 
+// GetByWarehouseIDUnsafe возвращает остаток на складе warehouseID либо nil.
+func (s Stocks) GetByWarehouseIDUnsafe(warehouseID vObject.WarehouseID) *Stock {
+	for i := range s {
+		if s[i].WarehouseID == warehouseID {
+			return &s[i]
+		}
+	}
+
+	return nil
+}
+
 func (s Stocks) GetProductID() vObject.ProductID {
 	return s[0].ProductID
 }
@@ -58,3 +86,160 @@ func NewStockUnsafe(
 
 	return s
 }
+
+// Reserve резервирует quantity товара на складах согласно распределению политики policy,
+// увеличивая ReservedQuantity, и возвращает движения резерва по каждому затронутому складу.
+func (s Stocks) Reserve(
+	policy AllocationPolicy,
+	quantity vObject.Quantity,
+	price vObject.Price,
+	opts ...Option[*ProductMovement],
+) (ProductMovements, error) {
+	allocations, err := policy.Allocate(s, quantity)
+	if err != nil {
+		return nil, fmt.Errorf("[Stocks.Reserve error]: %w", err)
+	}
+
+	movements := make(ProductMovements, 0, len(allocations))
+
+	for _, allocation := range allocations {
+		stock := s.GetByWarehouseIDUnsafe(allocation.WarehouseID)
+		if stock == nil || stock.FreeQuantity() < allocation.Quantity {
+			return nil, fmt.Errorf("[Stocks.Reserve error]: %w", ErrNotEnoughProductIntStocks)
+		}
+
+		stock.ReservedQuantity += allocation.Quantity
+
+		movements = append(movements, NewProductMovementUnsafe(stock.ProductID, stock.WarehouseID, vObject.OperationTypeReserve, allocation.Quantity, price, opts...))
+	}
+
+	return movements, nil
+}
+
+// Unreserve снимает резерв quantity товара со складов согласно reserved (резерв по складам),
+// начиная с последнего склада, и возвращает движения снятия резерва.
+func (s Stocks) Unreserve(
+	reserved map[vObject.WarehouseID]vObject.Quantity,
+	quantity vObject.Quantity,
+	price vObject.Price,
+	opts ...Option[*ProductMovement],
+) (ProductMovements, error) {
+	var total vObject.Quantity
+	for _, stock := range s {
+		total += min(reserved[stock.WarehouseID], stock.ReservedQuantity)
+	}
+
+	if total < quantity {
+		return nil, fmt.Errorf("[Stocks.Unreserve error]: %w", ErrNotEnoughReservedProduct)
+	}
+
+	movements := make(ProductMovements, 0, 1)
+
+	for i := len(s) - 1; i >= 0 && quantity > vObject.QuantityZero; i-- {
+		release := min(reserved[s[i].WarehouseID], s[i].ReservedQuantity, quantity)
+		if release == vObject.QuantityZero {
+			continue
+		}
+
+		s[i].ReservedQuantity -= release
+		quantity -= release
+
+		movements = append(movements, NewProductMovementUnsafe(s[i].ProductID, s[i].WarehouseID, vObject.OperationTypeUnreserve, release, price, opts...))
+	}
+
+	return movements, nil
+}
+
+// Ship отгружает товар позиции заказа со складов её распределения allocations: количество уменьшает
+// и остаток, и резерв склада. Возвращает движения продажи по каждому складу распределения.
+func (s Stocks) Ship(
+	allocations OrderProductAllocations,
+	price vObject.Price,
+	opts ...Option[*ProductMovement],
+) (ProductMovements, error) {
+	movements := make(ProductMovements, 0, len(allocations))
+
+	for _, allocation := range allocations {
+		stock := s.GetByWarehouseIDUnsafe(allocation.WarehouseID)
+		if stock == nil || stock.ReservedQuantity < allocation.Quantity || stock.AvailableQuantity < allocation.Quantity {
+			return nil, fmt.Errorf("[Stocks.Ship error]: %w", ErrNotEnoughReservedProduct)
+		}
+
+		stock.ReservedQuantity -= allocation.Quantity
+		stock.AvailableQuantity -= allocation.Quantity
+
+		movements = append(movements, NewProductMovementUnsafe(stock.ProductID, stock.WarehouseID, vObject.OperationTypeSale, allocation.Quantity, price, opts...))
+	}
+
+	return movements, nil
+}
+
+// Restock принимает обратно на склады распределения allocations товар, возвращённый покупателем,
+// и возвращает движения поступления с причиной customer_return и документом document.
+func (s Stocks) Restock(
+	allocations OrderProductAllocations,
+	price vObject.Price,
+	document vObject.DocumentReference,
+	opts ...Option[*ProductMovement],
+) (ProductMovements, error) {
+	movements := make(ProductMovements, 0, len(allocations))
+
+	for _, allocation := range allocations {
+		stock := s.GetByWarehouseIDUnsafe(allocation.WarehouseID)
+		if stock == nil {
+			return nil, fmt.Errorf("[Stocks.Restock error]: %w: %s", ErrStockNotFound, allocation.WarehouseID)
+		}
+
+		movement, err := stock.Income(allocation.Quantity, price, vObject.MovementReasonCustomerReturn, document, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("[Stocks.Restock error]: %w", err)
+		}
+
+		movements = append(movements, movement)
+	}
+
+	return movements, nil
+}
+
+// Income принимает на склад quantity товара по закупочной цене price
+// и возвращает движение поступления.
+func (s *Stock) Income(
+	quantity vObject.Quantity,
+	price vObject.Price,
+	reason vObject.MovementReason,
+	document vObject.DocumentReference,
+	opts ...Option[*ProductMovement],
+) (ProductMovement, error) {
+	if quantity == vObject.QuantityZero {
+		return ProductMovement{}, fmt.Errorf("[Stock.Income error]: %w", ErrEmptyStockQuantity)
+	}
+
+	s.AvailableQuantity += quantity
+
+	opts = append(opts[:len(opts):len(opts)], WithProductMovementDocument(reason, document))
+
+	return NewProductMovementUnsafe(s.ProductID, s.WarehouseID, vObject.OperationTypeIncome, quantity, price, opts...), nil
+}
+
+// WriteOff списывает со склада quantity товара и возвращает движение списания (без стоимости).
+// Зарезервированный товар списать нельзя: остаток не может стать меньше резерва.
+func (s *Stock) WriteOff(
+	quantity vObject.Quantity,
+	reason vObject.MovementReason,
+	document vObject.DocumentReference,
+	opts ...Option[*ProductMovement],
+) (ProductMovement, error) {
+	if quantity == vObject.QuantityZero {
+		return ProductMovement{}, fmt.Errorf("[Stock.WriteOff error]: %w", ErrEmptyStockQuantity)
+	}
+
+	if s.FreeQuantity() < quantity {
+		return ProductMovement{}, fmt.Errorf("[Stock.WriteOff error]: %w", ErrWriteOffBelowReserved)
+	}
+
+	s.AvailableQuantity -= quantity
+
+	opts = append(opts[:len(opts):len(opts)], WithProductMovementDocument(reason, document))
+
+	return NewProductMovementUnsafe(s.ProductID, s.WarehouseID, vObject.OperationTypeWriteOff, quantity, vObject.NewPriceUnsafe(0), opts...), nil
+}
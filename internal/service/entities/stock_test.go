@@ -0,0 +1,160 @@
+//go:build unit
+
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+func newTestStocks() entities.Stocks {
+	productID := vObject.NewProductIDFromUUIDUnsafe(uuid.New())
+
+	return entities.Stocks{
+		entities.NewStockUnsafe(productID, vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New()), 3, 5),
+		entities.NewStockUnsafe(productID, vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New()), 0, 10),
+	}
+}
+
+func TestStocks_Reserve(t *testing.T) {
+	t.Parallel()
+
+	orderID := vObject.NewOrderIDFromUUIDUnsafe(uuid.New())
+	stocks := newTestStocks()
+
+	movements, err := stocks.Reserve(entities.SplitAllocationPolicy{}, 4, 100, entities.WithProductMovementOrderID(orderID))
+	require.NoError(t, err)
+	require.Len(t, movements, 2)
+
+	assert.Equal(t, stocks[0].WarehouseID, movements[0].WarehouseID)
+	assert.Equal(t, vObject.NewQuantityUnsafe(2), movements[0].Quantity)
+	assert.Equal(t, stocks[1].WarehouseID, movements[1].WarehouseID)
+	assert.Equal(t, vObject.NewQuantityUnsafe(2), movements[1].Quantity)
+	assert.Equal(t, vObject.OperationTypeReserve, movements[0].OperationType)
+	assert.Equal(t, &orderID, movements[0].OrderID)
+
+	assert.Equal(t, vObject.NewQuantityUnsafe(5), stocks[0].ReservedQuantity)
+	assert.Equal(t, vObject.NewQuantityUnsafe(2), stocks[1].ReservedQuantity)
+
+	_, err = stocks.Reserve(entities.SplitAllocationPolicy{}, 9, 100)
+	require.ErrorIs(t, err, entities.ErrNotEnoughProductIntStocks)
+	assert.Equal(t, vObject.NewQuantityUnsafe(2), stocks[1].ReservedQuantity)
+}
+
+func TestStocks_Unreserve(t *testing.T) {
+	t.Parallel()
+
+	stocks := newTestStocks()
+
+	reserves, err := stocks.Reserve(entities.SplitAllocationPolicy{}, 4, 100)
+	require.NoError(t, err)
+
+	reserved := reserves.ReservedByWarehouse()
+	assert.Equal(t, vObject.NewQuantityUnsafe(2), reserved[stocks[1].WarehouseID])
+
+	_, err = stocks.Unreserve(reserved, 5, 100)
+	require.ErrorIs(t, err, entities.ErrNotEnoughReservedProduct)
+
+	movements, err := stocks.Unreserve(reserved, 3, 100)
+	require.NoError(t, err)
+	require.Len(t, movements, 2)
+
+	// снятие резерва начинается с последнего склада
+	assert.Equal(t, stocks[1].WarehouseID, movements[0].WarehouseID)
+	assert.Equal(t, vObject.NewQuantityUnsafe(2), movements[0].Quantity)
+	assert.Equal(t, vObject.OperationTypeUnreserve, movements[0].OperationType)
+	assert.Equal(t, vObject.NewQuantityUnsafe(1), movements[1].Quantity)
+
+	assert.Equal(t, vObject.NewQuantityUnsafe(4), stocks[0].ReservedQuantity)
+	assert.Equal(t, vObject.QuantityZero, stocks[1].ReservedQuantity)
+
+	reserved = append(reserves, movements...).ReservedByWarehouse()
+	assert.Equal(t, vObject.NewQuantityUnsafe(1), reserved[stocks[0].WarehouseID])
+	assert.Equal(t, vObject.QuantityZero, reserved[stocks[1].WarehouseID])
+}
+
+func TestStocks_Ship(t *testing.T) {
+	t.Parallel()
+
+	stocks := newTestStocks()
+
+	_, err := stocks.Ship(entities.OrderProductAllocations{{WarehouseID: stocks[1].WarehouseID, Quantity: 1}}, 100)
+	require.ErrorIs(t, err, entities.ErrNotEnoughReservedProduct)
+
+	movements, err := stocks.Ship(entities.OrderProductAllocations{{WarehouseID: stocks[0].WarehouseID, Quantity: 2}}, 100)
+	require.NoError(t, err)
+	require.Len(t, movements, 1)
+
+	assert.Equal(t, stocks[0].WarehouseID, movements[0].WarehouseID)
+	assert.Equal(t, vObject.OperationTypeSale, movements[0].OperationType)
+	assert.Equal(t, vObject.NewQuantityUnsafe(2), movements[0].Quantity)
+
+	assert.Equal(t, vObject.NewQuantityUnsafe(3), stocks[0].AvailableQuantity)
+	assert.Equal(t, vObject.NewQuantityUnsafe(1), stocks[0].ReservedQuantity)
+}
+
+func TestStocks_Restock(t *testing.T) {
+	t.Parallel()
+
+	stocks := newTestStocks()
+	document := vObject.NewDocumentReferenceUnsafe("order-1")
+
+	_, err := stocks.Restock(entities.OrderProductAllocations{{WarehouseID: vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New()), Quantity: 1}}, 100, document)
+	require.ErrorIs(t, err, entities.ErrStockNotFound)
+
+	movements, err := stocks.Restock(entities.OrderProductAllocations{{WarehouseID: stocks[1].WarehouseID, Quantity: 2}}, 100, document)
+	require.NoError(t, err)
+	require.Len(t, movements, 1)
+
+	reason := vObject.MovementReasonCustomerReturn
+	assert.Equal(t, vObject.OperationTypeIncome, movements[0].OperationType)
+	assert.Equal(t, &reason, movements[0].Reason)
+	assert.Equal(t, &document, movements[0].DocumentRef)
+
+	assert.Equal(t, vObject.NewQuantityUnsafe(12), stocks[1].AvailableQuantity)
+	assert.Equal(t, vObject.QuantityZero, stocks[1].ReservedQuantity)
+}
+
+func TestStock_Income(t *testing.T) {
+	t.Parallel()
+
+	stock := newTestStocks()[0]
+	document := vObject.NewDocumentReferenceUnsafe("INV-1")
+
+	_, err := stock.Income(0, 700, vObject.MovementReasonPurchase, document)
+	require.ErrorIs(t, err, entities.ErrEmptyStockQuantity)
+
+	movement, err := stock.Income(4, 700, vObject.MovementReasonPurchase, document)
+	require.NoError(t, err)
+
+	assert.Equal(t, vObject.NewQuantityUnsafe(9), stock.AvailableQuantity)
+	assert.Equal(t, vObject.OperationTypeIncome, movement.OperationType)
+	assert.Equal(t, vObject.NewPriceUnsafe(700), movement.Price)
+	assert.Equal(t, vObject.MovementReasonPurchase, *movement.Reason)
+	assert.Equal(t, document, *movement.DocumentRef)
+}
+
+func TestStock_WriteOff(t *testing.T) {
+	t.Parallel()
+
+	stock := newTestStocks()[0]
+	document := vObject.NewDocumentReferenceUnsafe("ACT-1")
+
+	// на складе 5 единиц, из них 3 в резерве
+	_, err := stock.WriteOff(3, vObject.MovementReasonDamage, document)
+	require.ErrorIs(t, err, entities.ErrWriteOffBelowReserved)
+	assert.Equal(t, vObject.NewQuantityUnsafe(5), stock.AvailableQuantity)
+
+	movement, err := stock.WriteOff(2, vObject.MovementReasonDamage, document)
+	require.NoError(t, err)
+
+	assert.Equal(t, vObject.NewQuantityUnsafe(3), stock.AvailableQuantity)
+	assert.Equal(t, vObject.OperationTypeWriteOff, movement.OperationType)
+	assert.Equal(t, vObject.MovementReasonDamage, *movement.Reason)
+}
@@ -0,0 +1,123 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+// StockTransfer перемещение товара между складами. Между отгрузкой и приёмкой
+// товар числится в пути (InTransitQuantity) на складе-получателе.
+type StockTransfer struct {
+	now.WithNowGenerator
+	uuid.WithUUIDGenerator
+
+	ID              vObject.StockTransferID
+	ProductID       vObject.ProductID
+	FromWarehouseID vObject.WarehouseID
+	ToWarehouseID   vObject.WarehouseID
+	Quantity        vObject.Quantity
+	Status          vObject.StockTransferStatus
+	DispatchedAt    time.Time
+	ReceivedAt      *time.Time
+}
+
+var (
+	ErrStockTransferRecNotFound   = errors.New("stock transfer not found")
+	ErrStockTransferSameWarehouse = errors.New("stock transfer source and destination warehouses are the same")
+	ErrStockTransferEmptyQuantity = errors.New("stock transfer quantity is empty")
+	ErrStockTransferNotInTransit  = errors.New("stock transfer is not in transit")
+	ErrStockTransferStockMismatch = errors.New("stock does not belong to stock transfer")
+)
+
+func NewStockTransfer(
+	productID vObject.ProductID,
+	fromWarehouseID, toWarehouseID vObject.WarehouseID,
+	quantity vObject.Quantity,
+	opts ...Option[*StockTransfer],
+) (StockTransfer, error) {
+	if fromWarehouseID == toWarehouseID {
+		return StockTransfer{}, fmt.Errorf("[NewStockTransfer error]: %w", ErrStockTransferSameWarehouse)
+	}
+
+	if quantity == vObject.QuantityZero {
+		return StockTransfer{}, fmt.Errorf("[NewStockTransfer error]: %w", ErrStockTransferEmptyQuantity)
+	}
+
+	t := StockTransfer{
+		ProductID:       productID,
+		FromWarehouseID: fromWarehouseID,
+		ToWarehouseID:   toWarehouseID,
+		Quantity:        quantity,
+		Status:          vObject.StockTransferStatusInTransit,
+	}
+
+	for _, opt := range opts {
+		_ = opt(&t)
+	}
+
+	t.ID = vObject.NewStockTransferIDFromUUIDUnsafe(t.UUID())
+	t.DispatchedAt = t.Now()
+
+	return t, nil
+}
+
+// Dispatch отгружает товар со склада source: свободный остаток источника уменьшается,
+// товар в пути на складе destination увеличивается. Возвращает движение перемещения по складу-источнику.
+func (t *StockTransfer) Dispatch(source, destination *Stock, opts ...Option[*ProductMovement]) (ProductMovement, error) {
+	if err := t.checkStock(source, t.FromWarehouseID); err != nil {
+		return ProductMovement{}, fmt.Errorf("[StockTransfer.Dispatch error]: %w", err)
+	}
+
+	if err := t.checkStock(destination, t.ToWarehouseID); err != nil {
+		return ProductMovement{}, fmt.Errorf("[StockTransfer.Dispatch error]: %w", err)
+	}
+
+	if source.FreeQuantity() < t.Quantity {
+		return ProductMovement{}, fmt.Errorf("[StockTransfer.Dispatch error]: %w", ErrNotEnoughProductIntStocks)
+	}
+
+	source.AvailableQuantity -= t.Quantity
+	destination.InTransitQuantity += t.Quantity
+
+	return t.movement(t.FromWarehouseID, opts...), nil
+}
+
+// Receive принимает товар на складе destination: товар в пути переходит в доступный остаток.
+// Возвращает парное отгрузке движение перемещения по складу-получателю.
+func (t *StockTransfer) Receive(destination *Stock, opts ...Option[*ProductMovement]) (ProductMovement, error) {
+	if t.Status != vObject.StockTransferStatusInTransit {
+		return ProductMovement{}, fmt.Errorf("[StockTransfer.Receive error]: %w", ErrStockTransferNotInTransit)
+	}
+
+	if err := t.checkStock(destination, t.ToWarehouseID); err != nil {
+		return ProductMovement{}, fmt.Errorf("[StockTransfer.Receive error]: %w", err)
+	}
+
+	destination.InTransitQuantity -= min(destination.InTransitQuantity, t.Quantity)
+	destination.AvailableQuantity += t.Quantity
+
+	tn := t.Now()
+	t.Status = vObject.StockTransferStatusReceived
+	t.ReceivedAt = &tn
+
+	return t.movement(t.ToWarehouseID, opts...), nil
+}
+
+func (t *StockTransfer) checkStock(stock *Stock, warehouseID vObject.WarehouseID) error {
+	if stock == nil || stock.ProductID != t.ProductID || stock.WarehouseID != warehouseID {
+		return ErrStockTransferStockMismatch
+	}
+
+	return nil
+}
+
+func (t *StockTransfer) movement(warehouseID vObject.WarehouseID, opts ...Option[*ProductMovement]) ProductMovement {
+	opts = append(opts[:len(opts):len(opts)], WithProductMovementTransferID(t.ID))
+
+	return NewProductMovementUnsafe(t.ProductID, warehouseID, vObject.OperationTypeTransfer, t.Quantity, vObject.NewPriceUnsafe(0), opts...)
+}
@@ -0,0 +1,89 @@
+//go:build unit
+
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+func TestNewStockTransfer(t *testing.T) {
+	t.Parallel()
+
+	productID := vObject.NewProductIDFromUUIDUnsafe(uuid.New())
+	warehouseID := vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New())
+
+	_, err := entities.NewStockTransfer(productID, warehouseID, warehouseID, 1)
+	require.ErrorIs(t, err, entities.ErrStockTransferSameWarehouse)
+
+	_, err = entities.NewStockTransfer(productID, warehouseID, vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New()), 0)
+	require.ErrorIs(t, err, entities.ErrStockTransferEmptyQuantity)
+
+	transfer, err := entities.NewStockTransfer(productID, warehouseID, vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New()), 1)
+	require.NoError(t, err)
+	assert.Equal(t, vObject.StockTransferStatusInTransit, transfer.Status)
+	assert.Nil(t, transfer.ReceivedAt)
+}
+
+func TestStockTransfer_DispatchAndReceive(t *testing.T) {
+	t.Parallel()
+
+	tn := time.Now()
+	nowFunc := now.NewMock(gomock.NewController(t))
+	nowFunc.EXPECT().Now().AnyTimes().Return(tn)
+
+	productID := vObject.NewProductIDFromUUIDUnsafe(uuid.New())
+	source := entities.NewStockUnsafe(productID, vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New()), 2, 10)
+	destination := entities.NewStockUnsafe(productID, vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New()), 0, 1)
+
+	transfer, err := entities.NewStockTransfer(
+		productID,
+		source.WarehouseID,
+		destination.WarehouseID,
+		9,
+		entities.WithNowFunc[*entities.StockTransfer](nowFunc),
+	)
+	require.NoError(t, err)
+
+	// свободно только 10 - 2 = 8, зарезервированный товар не перемещается
+	_, err = transfer.Dispatch(&source, &destination)
+	require.ErrorIs(t, err, entities.ErrNotEnoughProductIntStocks)
+
+	_, err = transfer.Dispatch(&destination, &source)
+	require.ErrorIs(t, err, entities.ErrStockTransferStockMismatch)
+
+	transfer.Quantity = 8
+
+	outgoing, err := transfer.Dispatch(&source, &destination)
+	require.NoError(t, err)
+	assert.Equal(t, vObject.NewQuantityUnsafe(2), source.AvailableQuantity)
+	assert.Equal(t, vObject.NewQuantityUnsafe(1), destination.AvailableQuantity)
+	assert.Equal(t, vObject.NewQuantityUnsafe(8), destination.InTransitQuantity)
+	assert.Equal(t, source.WarehouseID, outgoing.WarehouseID)
+	assert.Equal(t, vObject.OperationTypeTransfer, outgoing.OperationType)
+	assert.Equal(t, &transfer.ID, outgoing.TransferID)
+
+	_, err = transfer.Receive(&source)
+	require.ErrorIs(t, err, entities.ErrStockTransferStockMismatch)
+
+	incoming, err := transfer.Receive(&destination)
+	require.NoError(t, err)
+	assert.Equal(t, vObject.NewQuantityUnsafe(9), destination.AvailableQuantity)
+	assert.Equal(t, vObject.QuantityZero, destination.InTransitQuantity)
+	assert.Equal(t, destination.WarehouseID, incoming.WarehouseID)
+	assert.Equal(t, &transfer.ID, incoming.TransferID)
+	assert.Equal(t, vObject.StockTransferStatusReceived, transfer.Status)
+	assert.Equal(t, &tn, transfer.ReceivedAt)
+
+	_, err = transfer.Receive(&destination)
+	require.ErrorIs(t, err, entities.ErrStockTransferNotInTransit)
+}
@@ -24,16 +24,30 @@ type User struct {
 	BirthDate     vObject.Birthdate
 	MaritalStatus vObject.MaritalStatus
 	PasswordHash  vObject.PasswordHash
+	Role          vObject.Role
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 	DeletedAt     *time.Time
 
+	// FailedLoginAttempts число неудачных попыток входа подряд.
+	FailedLoginAttempts int
+	// LockedUntil время, до которого вход заблокирован.
+	LockedUntil *time.Time
+	// EmailVerifiedAt время подтверждения email, nil — email не подтверждён.
+	EmailVerifiedAt *time.Time
+
 	Orders []Order
 }
 
 var (
 	ErrUserRecNotFound   = errors.New("user record not found")
 	ErrUserAlreadyExists = errors.New("user already exists")
+	// ErrInvalidCredentials не раскрывает, что именно не совпало: email или пароль.
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrUserLocked         = errors.New("user is temporarily locked after failed login attempts")
+	ErrPermissionDenied   = errors.New("permission denied")
+	// ErrOwnRoleChange администратор не может изменить собственную роль и остаться без прав назначать роли.
+	ErrOwnRoleChange = errors.New("user cannot change own role")
 )
 
 func (u User) FullName() string {
@@ -50,7 +64,153 @@ func (u User) FullName() string {
 	return fullName.String()
 }
 
-func NewUser(email, firstName, lastName, maritalStatus string, birthdate time.Time, opts ...Option[*User]) (*User, error) {
+// CheckPassword сверяет пароль с хешем пароля пользователя.
+func (u *User) CheckPassword(password string) bool {
+	return u.GetHasher().ComparePasswords(string(u.PasswordHash), []byte(password))
+}
+
+// IsLocked сообщает, заблокирован ли вход пользователя в текущий момент.
+func (u *User) IsLocked() bool {
+	return u.LockedUntil != nil && u.Now().Before(*u.LockedUntil)
+}
+
+// RegisterFailedLogin учитывает неудачную попытку входа. После maxFailedLogins попыток подряд
+// вход блокируется на lockout (см. valueobjects.Rules), счётчик попыток начинается заново.
+func (u *User) RegisterFailedLogin(maxFailedLogins int, lockout time.Duration) {
+	tn := u.Now()
+
+	u.FailedLoginAttempts++
+	u.UpdatedAt = tn
+
+	if u.FailedLoginAttempts >= maxFailedLogins {
+		lockedUntil := tn.Add(lockout)
+		u.LockedUntil = &lockedUntil
+		u.FailedLoginAttempts = 0
+	}
+}
+
+// ResetFailedLogins сбрасывает счётчик неудачных попыток и блокировку после успешного входа.
+// Возвращает false, если сбрасывать нечего.
+func (u *User) ResetFailedLogins() bool {
+	if u.FailedLoginAttempts == 0 && u.LockedUntil == nil {
+		return false
+	}
+
+	u.FailedLoginAttempts = 0
+	u.LockedUntil = nil
+	u.UpdatedAt = u.Now()
+
+	return true
+}
+
+// RehashPassword пересчитывает хеш пароля, если он получен устаревшим алгоритмом или с устаревшими
+// параметрами. Вызывается только после успешной проверки пароля; правила длины пароля не применяются,
+// чтобы не заблокировать пользователей со старыми короткими паролями. Возвращает false, если хеш актуален.
+func (u *User) RehashPassword(password string) (bool, error) {
+	hasher := u.GetHasher()
+	if !hasher.NeedsRehash(string(u.PasswordHash)) {
+		return false, nil
+	}
+
+	hash, err := hasher.HashAndSalt([]byte(password))
+	if err != nil {
+		return false, fmt.Errorf("[User.RehashPassword] %w", err)
+	}
+
+	u.PasswordHash = vObject.NewPasswordHashUnsafe(hash)
+	u.UpdatedAt = u.Now()
+
+	return true, nil
+}
+
+// IsEmailVerified сообщает, подтверждён ли email пользователя.
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// VerifyEmail отмечает email подтверждённым. Возвращает false, если email уже подтверждён.
+func (u *User) VerifyEmail() bool {
+	if u.EmailVerifiedAt != nil {
+		return false
+	}
+
+	tn := u.Now()
+	u.EmailVerifiedAt = &tn
+	u.UpdatedAt = tn
+
+	return true
+}
+
+// ResetPassword задаёт новый пароль длиной не меньше minLength символов и снимает блокировку входа:
+// пользователь подтвердил владение email.
+func (u *User) ResetPassword(password string, minLength int) error {
+	hash, err := vObject.NewPasswordHash(u.GetHasher(), password, minLength)
+	if err != nil {
+		return fmt.Errorf("[User.ResetPassword] %w", err)
+	}
+
+	u.PasswordHash = hash
+	u.FailedLoginAttempts = 0
+	u.LockedUntil = nil
+	u.UpdatedAt = u.Now()
+
+	return nil
+}
+
+// Can сообщает, есть ли у пользователя разрешение permission по его роли.
+func (u *User) Can(permission vObject.Permission) bool {
+	return u.Role.Can(permission)
+}
+
+// CanManageOrder сообщает, может ли пользователь изменять заказ: свой — с разрешением на свои заказы,
+// чужой — только с разрешением на заказы любых пользователей.
+func (u *User) CanManageOrder(order *Order) bool {
+	if order.UserID == u.ID {
+		return u.Can(vObject.PermissionManageOwnOrders)
+	}
+
+	return u.Can(vObject.PermissionManageAnyOrders)
+}
+
+// CanViewOrdersOf сообщает, может ли пользователь просматривать заказы пользователя userID: свои — с разрешением
+// на свои заказы, чужие — только с разрешением на заказы любых пользователей.
+func (u *User) CanViewOrdersOf(userID vObject.UserID) bool {
+	if userID == u.ID {
+		return u.Can(vObject.PermissionManageOwnOrders)
+	}
+
+	return u.Can(vObject.PermissionManageAnyOrders)
+}
+
+// CanChangeOrderStatus сообщает, может ли пользователь перевести заказ в статус status. Покупатель может
+// только отменить свой заказ, остальные переходы выполняют пользователи с доступом к заказам всех пользователей.
+func (u *User) CanChangeOrderStatus(order *Order, status vObject.OrderStatus) bool {
+	if u.Can(vObject.PermissionManageAnyOrders) {
+		return true
+	}
+
+	return status == vObject.OrderStatusCanceled && order.UserID == u.ID && u.Can(vObject.PermissionManageOwnOrders)
+}
+
+// GrantRole назначает пользователю роль. Возвращает false, если роль уже назначена.
+func (u *User) GrantRole(role vObject.Role) bool {
+	if u.Role == role {
+		return false
+	}
+
+	u.Role = role
+	u.UpdatedAt = u.Now()
+
+	return true
+}
+
+// NewUser новый покупатель. Пользователь должен быть не моложе minAge лет, см. valueobjects.Rules.MinAge.
+func NewUser(
+	email, firstName, lastName, maritalStatus string,
+	birthdate time.Time,
+	minAge int,
+	opts ...Option[*User],
+) (*User, error) {
 	var (
 		u   User
 		err error
@@ -76,11 +236,13 @@ func NewUser(email, firstName, lastName, maritalStatus string, birthdate time.Ti
 		return nil, fmt.Errorf("[NewUser - NewMaritalStatus] %w", err)
 	}
 
-	u.BirthDate, err = vObject.NewBirthDate(birthdate)
+	u.BirthDate, err = vObject.NewBirthDate(birthdate, minAge)
 	if err != nil {
 		return nil, fmt.Errorf("[NewUser - NewAge] %w", err)
 	}
 
+	u.Role = vObject.RoleCustomer
+
 	for _, opt := range opts {
 		if err = opt(&u); err != nil {
 			return nil, err
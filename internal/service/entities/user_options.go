@@ -7,11 +7,12 @@ import (
 	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
 )
 
-func WithUserPassword(password string) func(*User) error {
+// WithUserPassword задаёт пароль длиной не меньше minLength символов, см. valueobjects.Rules.MinPasswordLength.
+func WithUserPassword(password string, minLength int) func(*User) error {
 	return func(u *User) error {
 		var err error
 
-		u.PasswordHash, err = vObject.NewPasswordHash(u.GetHasher(), password)
+		u.PasswordHash, err = vObject.NewPasswordHash(u.GetHasher(), password, minLength)
 		if err != nil {
 			return fmt.Errorf("[WithUserPassword] %w", err)
 		}
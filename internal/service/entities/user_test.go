@@ -0,0 +1,183 @@
+//go:build unit
+
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	passCrypto "github.com/smgladkovskiy/warehouse-task/internal/pkg/pass_crypto"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+func TestUser_RegisterFailedLogin(t *testing.T) {
+	t.Parallel()
+
+	tn := time.Now().UTC().Truncate(time.Second)
+	nowFunc := now.NewMock(gomock.NewController(t))
+	nowFunc.EXPECT().Now().Return(tn).AnyTimes()
+
+	var user entities.User
+	user.SetNowGen(nowFunc)
+
+	user.RegisterFailedLogin(2, time.Minute)
+	assert.Equal(t, 1, user.FailedLoginAttempts)
+	assert.False(t, user.IsLocked())
+
+	user.RegisterFailedLogin(2, time.Minute)
+	assert.Zero(t, user.FailedLoginAttempts)
+	require.NotNil(t, user.LockedUntil)
+	assert.Equal(t, tn.Add(time.Minute), *user.LockedUntil)
+	assert.True(t, user.IsLocked())
+
+	assert.True(t, user.ResetFailedLogins())
+	assert.Nil(t, user.LockedUntil)
+	assert.False(t, user.ResetFailedLogins(), "nothing to reset")
+}
+
+func TestUser_CheckPassword(t *testing.T) {
+	t.Parallel()
+
+	var hasher passCrypto.WithPasswordHasher
+
+	hash, err := vObject.NewPasswordHash(hasher.GetHasher(), "secret_password", vObject.DefaultRules().MinPasswordLength)
+	require.NoError(t, err)
+
+	user := entities.User{PasswordHash: hash}
+
+	assert.True(t, user.CheckPassword("secret_password"))
+	assert.False(t, user.CheckPassword("wrong_password"))
+}
+
+func TestUser_RehashPassword(t *testing.T) {
+	t.Parallel()
+
+	legacy, err := passCrypto.NewHasher(passCrypto.WithAlgorithm(passCrypto.AlgorithmBcrypt), passCrypto.WithBcryptCost(4))
+	require.NoError(t, err)
+
+	hash, err := legacy.HashAndSalt([]byte("short"))
+	require.NoError(t, err)
+
+	user := entities.User{PasswordHash: vObject.NewPasswordHashUnsafe(hash)}
+
+	rehashed, err := user.RehashPassword("short")
+	require.NoError(t, err, "length rules do not apply on rehash")
+	assert.True(t, rehashed)
+	assert.NotEqual(t, hash, string(user.PasswordHash))
+	assert.True(t, user.CheckPassword("short"))
+	assert.False(t, user.GetHasher().NeedsRehash(string(user.PasswordHash)))
+
+	rehashed, err = user.RehashPassword("short")
+	require.NoError(t, err)
+	assert.False(t, rehashed, "hash is up to date")
+}
+
+func TestUser_ResetPassword(t *testing.T) {
+	t.Parallel()
+
+	lockedUntil := time.Now().Add(time.Hour)
+	user := entities.User{FailedLoginAttempts: 2, LockedUntil: &lockedUntil}
+
+	require.ErrorIs(t, user.ResetPassword("short", vObject.DefaultRules().MinPasswordLength), vObject.ErrPasswordLen)
+	assert.Equal(t, vObject.PasswordHashEmpty, user.PasswordHash)
+
+	require.NoError(t, user.ResetPassword("new_secret_password", vObject.DefaultRules().MinPasswordLength))
+	assert.True(t, user.CheckPassword("new_secret_password"))
+	assert.Zero(t, user.FailedLoginAttempts)
+	assert.Nil(t, user.LockedUntil)
+}
+
+func TestUser_VerifyEmail(t *testing.T) {
+	t.Parallel()
+
+	tn := time.Now().UTC().Truncate(time.Second)
+	nowFunc := now.NewMock(gomock.NewController(t))
+	nowFunc.EXPECT().Now().Return(tn)
+
+	var user entities.User
+	user.SetNowGen(nowFunc)
+
+	assert.False(t, user.IsEmailVerified())
+	assert.True(t, user.VerifyEmail())
+	assert.True(t, user.IsEmailVerified())
+	assert.Equal(t, tn, *user.EmailVerifiedAt)
+	assert.False(t, user.VerifyEmail(), "already verified")
+}
+
+func TestUser_CanManageOrder(t *testing.T) {
+	t.Parallel()
+
+	ownerID := vObject.NewUserIDFromUUIDUnsafe(uuid.New())
+	order := entities.NewOrderUnsafe(ownerID)
+
+	type testCase struct {
+		name string
+		user entities.User
+		exp  bool
+	}
+
+	tcs := []testCase{
+		{name: "owner customer", user: entities.User{ID: ownerID, Role: vObject.RoleCustomer}, exp: true},
+		{name: "other customer", user: entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(uuid.New()), Role: vObject.RoleCustomer}},
+		{name: "operator", user: entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(uuid.New()), Role: vObject.RoleOperator}, exp: true},
+		{name: "admin", user: entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(uuid.New()), Role: vObject.RoleAdmin}, exp: true},
+		{name: "owner without role", user: entities.User{ID: ownerID}},
+	}
+
+	for _, tc := range tcs {
+		assert.Equal(t, tc.exp, tc.user.CanManageOrder(&order), tc.name)
+	}
+}
+
+func TestUser_CanViewOrdersOf(t *testing.T) {
+	t.Parallel()
+
+	owner := entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(uuid.New()), Role: vObject.RoleCustomer}
+	other := entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(uuid.New()), Role: vObject.RoleCustomer}
+	operator := entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(uuid.New()), Role: vObject.RoleOperator}
+
+	assert.True(t, owner.CanViewOrdersOf(owner.ID))
+	assert.False(t, other.CanViewOrdersOf(owner.ID))
+	assert.True(t, operator.CanViewOrdersOf(owner.ID))
+	assert.False(t, (&entities.User{ID: owner.ID}).CanViewOrdersOf(owner.ID))
+}
+
+func TestUser_CanChangeOrderStatus(t *testing.T) {
+	t.Parallel()
+
+	owner := entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(uuid.New()), Role: vObject.RoleCustomer}
+	other := entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(uuid.New()), Role: vObject.RoleCustomer}
+	operator := entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(uuid.New()), Role: vObject.RoleOperator}
+	order := entities.NewOrderUnsafe(owner.ID)
+
+	assert.True(t, owner.CanChangeOrderStatus(&order, vObject.OrderStatusCanceled))
+	assert.False(t, owner.CanChangeOrderStatus(&order, vObject.OrderStatusPaid))
+	assert.False(t, other.CanChangeOrderStatus(&order, vObject.OrderStatusCanceled))
+	assert.True(t, operator.CanChangeOrderStatus(&order, vObject.OrderStatusPaid))
+	assert.True(t, operator.CanChangeOrderStatus(&order, vObject.OrderStatusCanceled))
+}
+
+func TestUser_GrantRole(t *testing.T) {
+	t.Parallel()
+
+	tn := time.Now().UTC().Truncate(time.Second)
+	nowFunc := now.NewMock(gomock.NewController(t))
+	nowFunc.EXPECT().Now().Return(tn)
+
+	user := entities.User{Role: vObject.RoleCustomer}
+	user.SetNowGen(nowFunc)
+
+	assert.False(t, user.Can(vObject.PermissionManageStock))
+	assert.True(t, user.GrantRole(vObject.RoleOperator))
+	assert.Equal(t, vObject.RoleOperator, user.Role)
+	assert.Equal(t, tn, user.UpdatedAt)
+	assert.True(t, user.Can(vObject.PermissionManageStock))
+	assert.False(t, user.GrantRole(vObject.RoleOperator), "role already granted")
+}
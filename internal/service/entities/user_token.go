@@ -0,0 +1,93 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+// UserTokenPurpose назначение одноразового токена пользователя.
+type UserTokenPurpose string
+
+const (
+	UserTokenEmailVerification UserTokenPurpose = "email_verification"
+	UserTokenPasswordReset     UserTokenPurpose = "password_reset"
+)
+
+// userTokenSecretLength длина секрета токена в байтах.
+const userTokenSecretLength = 32
+
+// UserToken одноразовый токен подтверждения email или сброса пароля. Секрет токена пользователь
+// получает письмом, хранится только его хеш (Hash): по содержимому базы воспользоваться токеном нельзя.
+type UserToken struct {
+	now.WithNowGenerator
+
+	Hash      string
+	UserID    vObject.UserID
+	Purpose   UserTokenPurpose
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+var (
+	ErrUserTokenRecNotFound = errors.New("user token not found")
+	// ErrUserTokenInvalid не раскрывает, был ли токен выдан, использован или истёк.
+	ErrUserTokenInvalid = errors.New("token is invalid, used or expired")
+)
+
+// NewUserToken выпускает токен пользователя сроком на ttl. Возвращает токен и его секрет для письма пользователю.
+func NewUserToken(
+	userID vObject.UserID,
+	purpose UserTokenPurpose,
+	ttl time.Duration,
+	opts ...Option[*UserToken],
+) (UserToken, string, error) {
+	t := UserToken{UserID: userID, Purpose: purpose}
+
+	for _, opt := range opts {
+		_ = opt(&t)
+	}
+
+	secret := make([]byte, userTokenSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return UserToken{}, "", fmt.Errorf("[NewUserToken] %w", err)
+	}
+
+	raw := base64.RawURLEncoding.EncodeToString(secret)
+
+	tn := t.Now()
+	t.Hash = HashUserTokenSecret(raw)
+	t.ExpiresAt = tn.Add(ttl)
+	t.CreatedAt = tn
+
+	return t, raw, nil
+}
+
+// HashUserTokenSecret хеш секрета токена, по которому токен хранится и ищется.
+// Секрет случайный и длинный, поэтому медленный хеш паролей здесь не нужен.
+func HashUserTokenSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// Redeem погашает токен. Использованный или истёкший токен погасить нельзя.
+func (t *UserToken) Redeem() error {
+	tn := t.Now()
+
+	if t.UsedAt != nil || !tn.Before(t.ExpiresAt) {
+		return ErrUserTokenInvalid
+	}
+
+	t.UsedAt = &tn
+
+	return nil
+}
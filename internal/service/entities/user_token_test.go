@@ -0,0 +1,57 @@
+//go:build unit
+
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+func TestUserToken_Redeem(t *testing.T) {
+	t.Parallel()
+
+	nowFunc := now.NewMock(gomock.NewController(t))
+	created := time.Now().UTC().Truncate(time.Second)
+	userID := vObject.NewUserIDFromUUIDUnsafe(uuid.New())
+
+	nowFunc.EXPECT().Now().Return(created)
+
+	token, secret, err := entities.NewUserToken(
+		userID,
+		entities.UserTokenPasswordReset,
+		time.Hour,
+		entities.WithNowFunc[*entities.UserToken](nowFunc),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, userID, token.UserID)
+	assert.Equal(t, entities.UserTokenPasswordReset, token.Purpose)
+	assert.Equal(t, created.Add(time.Hour), token.ExpiresAt)
+	assert.Equal(t, entities.HashUserTokenSecret(secret), token.Hash)
+	assert.NotContains(t, token.Hash, secret, "secret is not stored")
+
+	_, other, err := entities.NewUserToken(userID, entities.UserTokenPasswordReset, time.Hour)
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+
+	expired := token
+
+	nowFunc.EXPECT().Now().Return(created.Add(time.Minute))
+	require.NoError(t, token.Redeem())
+	require.NotNil(t, token.UsedAt)
+	assert.Equal(t, created.Add(time.Minute), *token.UsedAt)
+
+	nowFunc.EXPECT().Now().Return(created.Add(2 * time.Minute))
+	require.ErrorIs(t, token.Redeem(), entities.ErrUserTokenInvalid, "single use")
+
+	nowFunc.EXPECT().Now().Return(created.Add(time.Hour))
+	require.ErrorIs(t, expired.Redeem(), entities.ErrUserTokenInvalid, "expired")
+}
@@ -16,7 +16,8 @@ var AgeUnknown = Birthdate(time.Time{})
 
 var ErrAgeIsTooLow = errors.New("age must be greater than")
 
-func NewBirthDate(birthDate time.Time) (Birthdate, error) {
+// NewBirthDate дата рождения пользователя, которому исполнилось не меньше minAge лет, см. Rules.MinAge.
+func NewBirthDate(birthDate time.Time, minAge int) (Birthdate, error) {
 	bd := NewAgeUnsafe(birthDate)
 
 	if bd.Age() < minAge {
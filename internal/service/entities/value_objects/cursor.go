@@ -0,0 +1,89 @@
+package valueobjects
+
+import (
+	"encoding/base64"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// CursorDirection направление перехода от записи курсора.
+type CursorDirection byte
+
+const (
+	// CursorNext следующая страница: записи после записи курсора.
+	CursorNext CursorDirection = 'n'
+	// CursorPrev предыдущая страница: записи перед записью курсора.
+	CursorPrev CursorDirection = 'p'
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrCursorSort курсор задаёт положение по id, поэтому листать курсором можно только в порядке создания записей.
+	ErrCursorSort = errors.New("cursor paging supports only creation order")
+	// ErrCursorWithPage номер страницы и курсор взаимоисключающие.
+	ErrCursorWithPage = errors.New("cursor paging does not accept page number")
+)
+
+// Cursor положение в списке, упорядоченном по id. Идентификаторы UUIDv7 растут со временем создания,
+// поэтому порядок по id совпадает с порядком создания записей и выборка страницы использует индекс по первичному ключу.
+type Cursor struct {
+	// ID запись, от которой отсчитывается страница, сама запись в страницу не входит. uuid.Nil — первая страница.
+	ID        uuid.UUID
+	Direction CursorDirection
+}
+
+// NewCursor разбирает курсор из Meta предыдущего ответа. Пустая строка — первая страница.
+func NewCursor(token string) (Cursor, error) {
+	if token == "" {
+		return Cursor{Direction: CursorNext}, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) != 1+len(uuid.UUID{}) {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	direction := CursorDirection(raw[0])
+	if direction != CursorNext && direction != CursorPrev {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	id, err := uuid.FromBytes(raw[1:])
+	if err != nil || id == uuid.Nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return Cursor{ID: id, Direction: direction}, nil
+}
+
+func NewNextCursor(id uuid.UUID) Cursor {
+	return Cursor{ID: id, Direction: CursorNext}
+}
+
+func NewPrevCursor(id uuid.UUID) Cursor {
+	return Cursor{ID: id, Direction: CursorPrev}
+}
+
+// IsFirst первая страница списка.
+func (c Cursor) IsFirst() bool {
+	return c.ID == uuid.Nil
+}
+
+// IsPrev курсор ведёт на предыдущую страницу.
+func (c Cursor) IsPrev() bool {
+	return c.Direction == CursorPrev
+}
+
+// Token непрозрачное представление курсора для ответа.
+func (c Cursor) Token() MetaCursor {
+	if c.IsFirst() {
+		return ""
+	}
+
+	raw := make([]byte, 0, 1+len(c.ID))
+	raw = append(raw, byte(c.Direction))
+	raw = append(raw, c.ID[:]...)
+
+	return MetaCursor(base64.RawURLEncoding.EncodeToString(raw))
+}
@@ -0,0 +1,36 @@
+//go:build unit
+
+package valueobjects_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+func TestNewCursor(t *testing.T) {
+	t.Parallel()
+
+	first, err := vObject.NewCursor("")
+	require.NoError(t, err)
+	assert.True(t, first.IsFirst())
+	assert.False(t, first.IsPrev())
+	assert.Empty(t, first.Token())
+
+	id := uuid.New()
+
+	for _, cursor := range []vObject.Cursor{vObject.NewNextCursor(id), vObject.NewPrevCursor(id)} {
+		got, err := vObject.NewCursor(cursor.Token().String())
+		require.NoError(t, err)
+		assert.Equal(t, cursor, got)
+	}
+
+	for _, token := range []string{"not base64!", "bg", vObject.NewNextCursor(id).Token().String() + "AA"} {
+		_, err = vObject.NewCursor(token)
+		require.ErrorIs(t, err, vObject.ErrInvalidCursor, token)
+	}
+}
@@ -0,0 +1,41 @@
+package valueobjects
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// DocumentReferenceMaxLength максимальная длина ссылки на документ-основание.
+const DocumentReferenceMaxLength = 128
+
+// DocumentReference ссылка на документ-основание операции: накладную, акт списания и т.п.
+type DocumentReference string
+
+var (
+	ErrEmptyDocumentReference   = errors.New("empty document reference")
+	ErrDocumentReferenceTooLong = errors.New("document reference is too long")
+)
+
+func NewDocumentReference(ref string) (DocumentReference, error) {
+	ref = strings.TrimSpace(ref)
+
+	if ref == "" {
+		return "", ErrEmptyDocumentReference
+	}
+
+	if utf8.RuneCountInString(ref) > DocumentReferenceMaxLength {
+		return "", fmt.Errorf("%w: max %d symbols", ErrDocumentReferenceTooLong, DocumentReferenceMaxLength)
+	}
+
+	return NewDocumentReferenceUnsafe(ref), nil
+}
+
+func NewDocumentReferenceUnsafe(ref string) DocumentReference {
+	return DocumentReference(ref)
+}
+
+func (r DocumentReference) String() string {
+	return string(r)
+}
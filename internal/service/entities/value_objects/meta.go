@@ -1,20 +1,51 @@
 package valueobjects
 
+import "errors"
+
 type (
 	MetaTotal    int64
 	MetaPerPage  int64
 	MetaPage     int64
 	MetaLastPage int64
-	Meta         struct {
-		Total    MetaTotal
-		PerPage  MetaPerPage
-		Page     MetaPage
-		LastPage MetaLastPage
+	// MetaCursor непрозрачный курсор соседней страницы, пустой, если страницы нет.
+	MetaCursor string
+	// Meta положение страницы в списке. При навигации по курсору заполняются только PerPage,
+	// NextCursor и PrevCursor: общее число записей не подсчитывается.
+	Meta struct {
+		Total      MetaTotal
+		PerPage    MetaPerPage
+		Page       MetaPage
+		LastPage   MetaLastPage
+		NextCursor MetaCursor
+		PrevCursor MetaCursor
 	}
 )
 
+// DefaultPerPage размер страницы, если он не указан ни в запросе, ни правилом Rules.DefaultPerPage.
 const DefaultPerPage = 10
 
+// MaxPerPage наибольший размер страницы, который можно запросить.
+const MaxPerPage = 100
+
+var (
+	ErrInvalidMetaPage    = errors.New("page must not be negative")
+	ErrInvalidMetaPerPage = errors.New("per page must be between 0 and MaxPerPage")
+)
+
+// ValidateMetaPaging проверяет номер и размер запрошенной страницы.
+// Нулевые значения допустимы и означают первую страницу и размер по умолчанию.
+func ValidateMetaPaging(page, perPage int) error {
+	if page < 0 {
+		return ErrInvalidMetaPage
+	}
+
+	if perPage < 0 || perPage > MaxPerPage {
+		return ErrInvalidMetaPerPage
+	}
+
+	return nil
+}
+
 func NewMetaTotal(total int) MetaTotal {
 	return MetaTotal(total)
 }
@@ -52,3 +83,7 @@ func (p MetaPage) Int64() int64 {
 func (p MetaLastPage) Int64() int64 {
 	return int64(p)
 }
+
+func (c MetaCursor) String() string {
+	return string(c)
+}
@@ -0,0 +1,56 @@
+package valueobjects
+
+import (
+	"errors"
+	"slices"
+)
+
+// MovementReason код причины поступления или списания товара.
+type MovementReason string
+
+const (
+	MovementReasonPurchase         MovementReason = "purchase"          // Закупка у поставщика
+	MovementReasonCustomerReturn   MovementReason = "customer_return"   // Возврат от покупателя
+	MovementReasonInventorySurplus MovementReason = "inventory_surplus" // Излишек по результатам инвентаризации
+
+	MovementReasonDamage            MovementReason = "damage"             // Порча или повреждение товара
+	MovementReasonExpired           MovementReason = "expired"            // Истёк срок годности
+	MovementReasonLoss              MovementReason = "loss"               // Утеря или кража
+	MovementReasonInventoryShortage MovementReason = "inventory_shortage" // Недостача по результатам инвентаризации
+)
+
+// movementReasons причины, допустимые для каждого типа операции.
+var movementReasons = map[OperationType][]MovementReason{
+	OperationTypeIncome: {MovementReasonPurchase, MovementReasonCustomerReturn, MovementReasonInventorySurplus},
+	OperationTypeWriteOff: {
+		MovementReasonDamage, MovementReasonExpired, MovementReasonLoss, MovementReasonInventoryShortage,
+	},
+}
+
+var (
+	ErrEmptyMovementReason   = errors.New("empty movement reason")
+	ErrUnknownMovementReason = errors.New("unknown movement reason for operation type")
+)
+
+// NewMovementReason проверяет, что причина reason допустима для операции operationType.
+func NewMovementReason(operationType OperationType, reason string) (MovementReason, error) {
+	if reason == "" {
+		return "", ErrEmptyMovementReason
+	}
+
+	r := NewMovementReasonUnsafe(reason)
+
+	if !slices.Contains(movementReasons[operationType], r) {
+		return "", ErrUnknownMovementReason
+	}
+
+	return r, nil
+}
+
+func NewMovementReasonUnsafe(reason string) MovementReason {
+	return MovementReason(reason)
+}
+
+func (r MovementReason) String() string {
+	return string(r)
+}
@@ -0,0 +1,49 @@
+package valueobjects
+
+import "errors"
+
+// OrderPricePolicy политика цены позиций заказа, который ещё не оплачен (статус created).
+// Оплаченные и последующие заказы всегда сохраняют цену на момент добавления товара.
+type OrderPricePolicy string
+
+const (
+	// OrderPricePolicySnapshot позиция хранит цену товара на момент первого добавления в заказ,
+	// изменение количества и повторное добавление товара цену не меняют.
+	OrderPricePolicySnapshot OrderPricePolicy = "snapshot"
+	// OrderPricePolicyReprice при изменении количества товара в заказе цена позиции
+	// обновляется до текущей цены товара.
+	OrderPricePolicyReprice OrderPricePolicy = "reprice"
+)
+
+var (
+	ErrEmptyOrderPricePolicy   = errors.New("empty order price policy")
+	ErrUnknownOrderPricePolicy = errors.New("unknown order price policy")
+)
+
+func NewOrderPricePolicy(policy string) (OrderPricePolicy, error) {
+	if policy == "" {
+		return "", ErrEmptyOrderPricePolicy
+	}
+
+	p := OrderPricePolicy(policy)
+
+	if !p.IsValid() {
+		return "", ErrUnknownOrderPricePolicy
+	}
+
+	return p, nil
+}
+
+// IsValid проверяет, что политика известна.
+func (p OrderPricePolicy) IsValid() bool {
+	return p == OrderPricePolicySnapshot || p == OrderPricePolicyReprice
+}
+
+// RepriceOnChange сообщает, нужно ли обновить цену позиции заказа в статусе status при изменении количества.
+func (p OrderPricePolicy) RepriceOnChange(status OrderStatus) bool {
+	return p == OrderPricePolicyReprice && status == OrderStatusCreated
+}
+
+func (p OrderPricePolicy) String() string {
+	return string(p)
+}
@@ -0,0 +1,34 @@
+//go:build unit
+
+package valueobjects_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+func TestNewOrderPricePolicy(t *testing.T) {
+	t.Parallel()
+
+	policy, err := vObject.NewOrderPricePolicy("reprice")
+	require.NoError(t, err)
+	assert.Equal(t, vObject.OrderPricePolicyReprice, policy)
+
+	_, err = vObject.NewOrderPricePolicy("")
+	require.ErrorIs(t, err, vObject.ErrEmptyOrderPricePolicy)
+
+	_, err = vObject.NewOrderPricePolicy("latest")
+	require.ErrorIs(t, err, vObject.ErrUnknownOrderPricePolicy)
+}
+
+func TestOrderPricePolicy_RepriceOnChange(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, vObject.OrderPricePolicyReprice.RepriceOnChange(vObject.OrderStatusCreated))
+	assert.False(t, vObject.OrderPricePolicyReprice.RepriceOnChange(vObject.OrderStatusPaid))
+	assert.False(t, vObject.OrderPricePolicySnapshot.RepriceOnChange(vObject.OrderStatusCreated))
+}
@@ -0,0 +1,39 @@
+package valueobjects
+
+import "errors"
+
+// OrderSort порядок заказов в истории заказов пользователя.
+type OrderSort string
+
+const (
+	// OrderSortNewest сначала новые заказы.
+	OrderSortNewest OrderSort = "newest"
+	// OrderSortOldest сначала старые заказы.
+	OrderSortOldest OrderSort = "oldest"
+	// OrderSortTotalAsc по возрастанию суммы заказа.
+	OrderSortTotalAsc OrderSort = "total_asc"
+	// OrderSortTotalDesc по убыванию суммы заказа.
+	OrderSortTotalDesc OrderSort = "total_desc"
+)
+
+var ErrUnknownOrderSort = errors.New("unknown order sort")
+
+// NewOrderSort порядок заказов, по умолчанию OrderSortNewest.
+func NewOrderSort(sort string) (OrderSort, error) {
+	if sort == "" {
+		return OrderSortNewest, nil
+	}
+
+	s := OrderSort(sort)
+
+	switch s {
+	case OrderSortNewest, OrderSortOldest, OrderSortTotalAsc, OrderSortTotalDesc:
+		return s, nil
+	default:
+		return "", ErrUnknownOrderSort
+	}
+}
+
+func (s OrderSort) String() string {
+	return string(s)
+}
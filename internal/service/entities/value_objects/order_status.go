@@ -1,5 +1,7 @@
 package valueobjects
 
+import "errors"
+
 type OrderStatus string
 
 const (
@@ -20,3 +22,62 @@ var orderFlow = map[OrderStatus][]OrderStatus{
 	OrderStatusShipped:  {OrderStatusReceived, OrderStatusReturned, OrderStatusCanceled},
 	OrderStatusReceived: {OrderStatusReturned},
 }
+
+var (
+	ErrEmptyOrderStatus   = errors.New("empty order status")
+	ErrUnknownOrderStatus = errors.New("unknown order status")
+)
+
+func NewOrderStatus(status string) (OrderStatus, error) {
+	if status == "" {
+		return "", ErrEmptyOrderStatus
+	}
+
+	os := NewOrderStatusUnsafe(status)
+
+	if !os.IsValid() {
+		return "", ErrUnknownOrderStatus
+	}
+
+	return os, nil
+}
+
+func NewOrderStatusUnsafe(status string) OrderStatus {
+	return OrderStatus(status)
+}
+
+// IsValid проверяет, что статус известен.
+func (s OrderStatus) IsValid() bool {
+	switch s {
+	case OrderStatusCreated, OrderStatusPaid, OrderStatusOrdered, OrderStatusShipped,
+		OrderStatusReceived, OrderStatusReturned, OrderStatusCanceled:
+		return true
+	}
+
+	return false
+}
+
+// CanTransitionTo проверяет, разрешён ли переход в статус next согласно orderFlow.
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, status := range orderFlow[s] {
+		if status == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HoldsReserve сообщает, что в статусе s товар заказа зарезервирован на складах и ещё не отгружен.
+func (s OrderStatus) HoldsReserve() bool {
+	return s == OrderStatusCreated || s == OrderStatusPaid || s == OrderStatusOrdered
+}
+
+// IsShipped сообщает, что в статусе s товар заказа отгружен со складов.
+func (s OrderStatus) IsShipped() bool {
+	return s == OrderStatusShipped || s == OrderStatusReceived
+}
+
+func (s OrderStatus) String() string {
+	return string(s)
+}
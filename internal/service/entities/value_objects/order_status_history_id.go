@@ -0,0 +1,26 @@
+package valueobjects
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type OrderStatusHistoryID struct {
+	withUUIDer
+}
+
+func NewOrderStatusHistoryIDFromUUID(id uuid.UUID) (OrderStatusHistoryID, error) {
+	if id == uuid.Nil {
+		return OrderStatusHistoryID{}, fmt.Errorf("order status history %w", ErrEmptyID)
+	}
+
+	return NewOrderStatusHistoryIDFromUUIDUnsafe(id), nil
+}
+
+func NewOrderStatusHistoryIDFromUUIDUnsafe(id uuid.UUID) OrderStatusHistoryID {
+	historyID := OrderStatusHistoryID{}
+	historyID.SetFromUUID(id)
+
+	return historyID
+}
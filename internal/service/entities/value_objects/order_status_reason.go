@@ -0,0 +1,7 @@
+package valueobjects
+
+type OrderStatusReason string
+
+func NewOrderStatusReasonUnsafe(reason string) OrderStatusReason {
+	return OrderStatusReason(reason)
+}
@@ -0,0 +1,73 @@
+//go:build unit
+
+package valueobjects_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+func TestNewOrderStatus(t *testing.T) {
+	t.Parallel()
+
+	status, err := vObject.NewOrderStatus("paid")
+	require.NoError(t, err)
+	assert.Equal(t, vObject.OrderStatusPaid, status)
+
+	_, err = vObject.NewOrderStatus("")
+	require.ErrorIs(t, err, vObject.ErrEmptyOrderStatus)
+
+	_, err = vObject.NewOrderStatus("lost")
+	require.ErrorIs(t, err, vObject.ErrUnknownOrderStatus)
+}
+
+func TestOrderStatus_CanTransitionTo(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		from vObject.OrderStatus
+		to   vObject.OrderStatus
+		exp  bool
+	}
+
+	tcs := []testCase{
+		{from: vObject.OrderStatusCreated, to: vObject.OrderStatusPaid, exp: true},
+		{from: vObject.OrderStatusCreated, to: vObject.OrderStatusCanceled, exp: true},
+		{from: vObject.OrderStatusCreated, to: vObject.OrderStatusShipped, exp: false},
+		{from: vObject.OrderStatusPaid, to: vObject.OrderStatusOrdered, exp: true},
+		{from: vObject.OrderStatusOrdered, to: vObject.OrderStatusShipped, exp: true},
+		{from: vObject.OrderStatusShipped, to: vObject.OrderStatusReturned, exp: true},
+		{from: vObject.OrderStatusShipped, to: vObject.OrderStatusPaid, exp: false},
+		{from: vObject.OrderStatusReceived, to: vObject.OrderStatusReturned, exp: true},
+		{from: vObject.OrderStatusReturned, to: vObject.OrderStatusCreated, exp: false},
+		{from: vObject.OrderStatusCanceled, to: vObject.OrderStatusPaid, exp: false},
+		{from: vObject.OrderStatusPaid, to: vObject.OrderStatusPaid, exp: false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.from.String()+"->"+tc.to.String(), func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.exp, tc.from.CanTransitionTo(tc.to))
+		})
+	}
+}
+
+func TestOrderStatus_StockState(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, vObject.OrderStatusCreated.HoldsReserve())
+	assert.True(t, vObject.OrderStatusOrdered.HoldsReserve())
+	assert.False(t, vObject.OrderStatusShipped.HoldsReserve())
+	assert.False(t, vObject.OrderStatusCanceled.HoldsReserve())
+
+	assert.True(t, vObject.OrderStatusShipped.IsShipped())
+	assert.True(t, vObject.OrderStatusReceived.IsShipped())
+	assert.False(t, vObject.OrderStatusOrdered.IsShipped())
+	assert.False(t, vObject.OrderStatusReturned.IsShipped())
+	assert.False(t, vObject.OrderStatusCanceled.IsShipped())
+}
@@ -18,9 +18,10 @@ var (
 	ErrPasswordLen = errors.New("password length must be at least")
 )
 
-func NewPasswordHash(hasher passCrypto.PasswordHashable, password string) (PasswordHash, error) {
-	if len(password) < minPasswordLength {
-		return PasswordHashEmpty, fmt.Errorf("%w %d symbols but got only %d", ErrPasswordLen, minPasswordLength, len(password))
+// NewPasswordHash хеш пароля длиной не меньше minLength символов, см. Rules.MinPasswordLength.
+func NewPasswordHash(hasher passCrypto.PasswordHashable, password string, minLength int) (PasswordHash, error) {
+	if len(password) < minLength {
+		return PasswordHashEmpty, fmt.Errorf("%w %d symbols but got only %d", ErrPasswordLen, minLength, len(password))
 	}
 
 	hash, err := hasher.HashAndSalt([]byte(password))
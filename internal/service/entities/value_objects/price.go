@@ -1,7 +1,11 @@
 package valueobjects
 
+import "errors"
+
 type Price int64
 
+var ErrNegativePrice = errors.New("price must not be negative")
+
 func (p Price) Multiply(quantity Quantity) Price {
 	return p * Price(quantity)
 }
@@ -14,6 +18,14 @@ func (p *Price) Add(price Price) {
 	*p += price
 }
 
+func NewPrice(cents int64) (Price, error) {
+	if cents < 0 {
+		return 0, ErrNegativePrice
+	}
+
+	return Price(cents), nil
+}
+
 func NewPriceUnsafe(cents int) Price {
 	return Price(cents)
 }
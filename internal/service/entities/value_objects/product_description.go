@@ -1,7 +1,29 @@
 package valueobjects
 
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
 type ProductDescription string
 
+// ProductDescriptionMaxLength максимальная длина описания товара в символах.
+const ProductDescriptionMaxLength = 4096
+
+var ErrProductDescriptionTooLong = errors.New("product description is too long")
+
+// NewProductDescription описание товара без пробелов по краям. Описание может быть пустым.
+func NewProductDescription(desc string) (ProductDescription, error) {
+	desc = strings.TrimSpace(desc)
+
+	if utf8.RuneCountInString(desc) > ProductDescriptionMaxLength {
+		return "", ErrProductDescriptionTooLong
+	}
+
+	return NewProductDescriptionUnsafe(desc), nil
+}
+
 func NewProductDescriptionUnsafe(desc string) ProductDescription {
 	return ProductDescription(desc)
 }
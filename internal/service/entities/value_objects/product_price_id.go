@@ -0,0 +1,26 @@
+package valueobjects
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type ProductPriceID struct {
+	withUUIDer
+}
+
+func NewProductPriceIDFromUUID(id uuid.UUID) (ProductPriceID, error) {
+	if id == uuid.Nil {
+		return ProductPriceID{}, fmt.Errorf("product price %w", ErrEmptyID)
+	}
+
+	return NewProductPriceIDFromUUIDUnsafe(id), nil
+}
+
+func NewProductPriceIDFromUUIDUnsafe(id uuid.UUID) ProductPriceID {
+	priceID := ProductPriceID{}
+	priceID.SetFromUUID(id)
+
+	return priceID
+}
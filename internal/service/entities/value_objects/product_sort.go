@@ -0,0 +1,41 @@
+package valueobjects
+
+import "errors"
+
+// ProductSort порядок товаров в списке каталога.
+type ProductSort string
+
+const (
+	// ProductSortNewest сначала новые товары.
+	ProductSortNewest ProductSort = "newest"
+	// ProductSortTitle по названию.
+	ProductSortTitle ProductSort = "title"
+	// ProductSortPriceAsc сначала дешёвые товары.
+	ProductSortPriceAsc ProductSort = "price_asc"
+	// ProductSortPriceDesc сначала дорогие товары.
+	ProductSortPriceDesc ProductSort = "price_desc"
+	// ProductSortRelevance по релевантности полнотекстовому поиску, доступен только вместе с поиском.
+	ProductSortRelevance ProductSort = "relevance"
+)
+
+var ErrUnknownProductSort = errors.New("unknown product sort")
+
+// NewProductSort порядок товаров, по умолчанию ProductSortNewest.
+func NewProductSort(sort string) (ProductSort, error) {
+	if sort == "" {
+		return ProductSortNewest, nil
+	}
+
+	s := ProductSort(sort)
+
+	switch s {
+	case ProductSortNewest, ProductSortTitle, ProductSortPriceAsc, ProductSortPriceDesc, ProductSortRelevance:
+		return s, nil
+	default:
+		return "", ErrUnknownProductSort
+	}
+}
+
+func (s ProductSort) String() string {
+	return string(s)
+}
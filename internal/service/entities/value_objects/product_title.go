@@ -1,7 +1,40 @@
 package valueobjects
 
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
 type ProductTitle string
 
+const (
+	ProductTitleEmpty ProductTitle = ""
+
+	// ProductTitleMaxLength максимальная длина названия товара в символах.
+	ProductTitleMaxLength = 255
+)
+
+var (
+	ErrEmptyProductTitle   = errors.New("empty product title")
+	ErrProductTitleTooLong = errors.New("product title is too long")
+)
+
+// NewProductTitle название товара без пробелов по краям.
+func NewProductTitle(title string) (ProductTitle, error) {
+	title = strings.TrimSpace(title)
+
+	if title == "" {
+		return ProductTitleEmpty, ErrEmptyProductTitle
+	}
+
+	if utf8.RuneCountInString(title) > ProductTitleMaxLength {
+		return ProductTitleEmpty, ErrProductTitleTooLong
+	}
+
+	return NewProductTitleUnsafe(title), nil
+}
+
 func NewProductTitleUnsafe(title string) ProductTitle {
 	return ProductTitle(title)
 }
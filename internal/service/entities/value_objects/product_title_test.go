@@ -0,0 +1,45 @@
+//go:build unit
+
+package valueobjects_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+func TestNewProductTitle(t *testing.T) {
+	t.Parallel()
+
+	title, err := vObject.NewProductTitle("  Молоко 3,2%  ")
+	require.NoError(t, err)
+	assert.Equal(t, vObject.NewProductTitleUnsafe("Молоко 3,2%"), title)
+
+	_, err = vObject.NewProductTitle(strings.Repeat("я", vObject.ProductTitleMaxLength))
+	require.NoError(t, err, "length is counted in runes")
+
+	_, err = vObject.NewProductTitle(" \t")
+	require.ErrorIs(t, err, vObject.ErrEmptyProductTitle)
+
+	_, err = vObject.NewProductTitle(strings.Repeat("я", vObject.ProductTitleMaxLength+1))
+	require.ErrorIs(t, err, vObject.ErrProductTitleTooLong)
+}
+
+func TestNewProductDescription(t *testing.T) {
+	t.Parallel()
+
+	desc, err := vObject.NewProductDescription("")
+	require.NoError(t, err)
+	assert.Empty(t, desc)
+
+	desc, err = vObject.NewProductDescription(" Пастеризованное \n")
+	require.NoError(t, err)
+	assert.Equal(t, vObject.NewProductDescriptionUnsafe("Пастеризованное"), desc)
+
+	_, err = vObject.NewProductDescription(strings.Repeat("я", vObject.ProductDescriptionMaxLength+1))
+	require.ErrorIs(t, err, vObject.ErrProductDescriptionTooLong)
+}
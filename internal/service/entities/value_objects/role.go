@@ -0,0 +1,68 @@
+package valueobjects
+
+import (
+	"errors"
+	"slices"
+)
+
+// Role роль пользователя. Роль определяет набор разрешений, см. Role.Can.
+type Role string
+
+const (
+	RoleCustomer Role = "customer" // Покупатель: работает только со своими заказами
+	RoleOperator Role = "operator" // Оператор склада: ведёт каталог, остатки и заказы всех покупателей
+	RoleAdmin    Role = "admin"    // Администратор: всё, что может оператор, и назначение ролей
+)
+
+// Permission действие, на которое у пользователя должно быть разрешение.
+type Permission string
+
+const (
+	PermissionManageOwnOrders Permission = "orders:manage_own" // Изменение своих заказов
+	PermissionManageAnyOrders Permission = "orders:manage_any" // Изменение заказов любых пользователей
+	PermissionManageStock     Permission = "stock:manage"      // Поступление, списание и перемещение товара
+	PermissionManageProducts  Permission = "products:manage"   // Создание, изменение и архивирование товаров
+	PermissionGrantRoles      Permission = "roles:grant"       // Назначение ролей пользователям
+)
+
+// rolePermissions разрешения каждой роли.
+var rolePermissions = map[Role][]Permission{
+	RoleCustomer: {PermissionManageOwnOrders},
+	RoleOperator: {PermissionManageOwnOrders, PermissionManageAnyOrders, PermissionManageStock, PermissionManageProducts},
+	RoleAdmin: {
+		PermissionManageOwnOrders, PermissionManageAnyOrders, PermissionManageStock, PermissionManageProducts,
+		PermissionGrantRoles,
+	},
+}
+
+var (
+	ErrEmptyRole   = errors.New("empty role")
+	ErrUnknownRole = errors.New("unknown role")
+)
+
+func NewRole(role string) (Role, error) {
+	if role == "" {
+		return "", ErrEmptyRole
+	}
+
+	r := NewRoleUnsafe(role)
+
+	if _, ok := rolePermissions[r]; !ok {
+		return "", ErrUnknownRole
+	}
+
+	return r, nil
+}
+
+func NewRoleUnsafe(role string) Role {
+	return Role(role)
+}
+
+// Can сообщает, есть ли у роли разрешение permission. У неизвестной роли разрешений нет.
+func (r Role) Can(permission Permission) bool {
+	return slices.Contains(rolePermissions[r], permission)
+}
+
+func (r Role) String() string {
+	return string(r)
+}
@@ -0,0 +1,56 @@
+//go:build unit
+
+package valueobjects_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+func TestNewRole(t *testing.T) {
+	t.Parallel()
+
+	role, err := vObject.NewRole("operator")
+	require.NoError(t, err)
+	assert.Equal(t, vObject.RoleOperator, role)
+
+	_, err = vObject.NewRole("")
+	require.ErrorIs(t, err, vObject.ErrEmptyRole)
+
+	_, err = vObject.NewRole("root")
+	require.ErrorIs(t, err, vObject.ErrUnknownRole)
+}
+
+func TestRole_Can(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		role       vObject.Role
+		permission vObject.Permission
+		exp        bool
+	}
+
+	tcs := []testCase{
+		{role: vObject.RoleCustomer, permission: vObject.PermissionManageOwnOrders, exp: true},
+		{role: vObject.RoleCustomer, permission: vObject.PermissionManageAnyOrders, exp: false},
+		{role: vObject.RoleCustomer, permission: vObject.PermissionManageStock, exp: false},
+		{role: vObject.RoleCustomer, permission: vObject.PermissionManageProducts, exp: false},
+		{role: vObject.RoleCustomer, permission: vObject.PermissionGrantRoles, exp: false},
+		{role: vObject.RoleOperator, permission: vObject.PermissionManageAnyOrders, exp: true},
+		{role: vObject.RoleOperator, permission: vObject.PermissionManageStock, exp: true},
+		{role: vObject.RoleOperator, permission: vObject.PermissionManageProducts, exp: true},
+		{role: vObject.RoleOperator, permission: vObject.PermissionGrantRoles, exp: false},
+		{role: vObject.RoleAdmin, permission: vObject.PermissionManageStock, exp: true},
+		{role: vObject.RoleAdmin, permission: vObject.PermissionManageProducts, exp: true},
+		{role: vObject.RoleAdmin, permission: vObject.PermissionGrantRoles, exp: true},
+		{role: vObject.NewRoleUnsafe("root"), permission: vObject.PermissionManageOwnOrders, exp: false},
+	}
+
+	for _, tc := range tcs {
+		assert.Equal(t, tc.exp, tc.role.Can(tc.permission), "%s can %s", tc.role, tc.permission)
+	}
+}
@@ -0,0 +1,48 @@
+package valueobjects
+
+import "time"
+
+const (
+	defaultMaxFailedLogins = 5
+	defaultLoginLockout    = 15 * time.Minute
+
+	defaultEmailVerificationTTL = 24 * time.Hour
+	defaultPasswordResetTTL     = time.Hour
+)
+
+// Rules бизнес-правила, которые задаются для инсталляции сервиса конфигурацией. Правила передаются
+// юзкейсам при сборке контейнера, а нужные значения из них — аргументами методов сущностей.
+type Rules struct {
+	// MinAge минимальный возраст пользователя при регистрации.
+	MinAge int
+	// MinPasswordLength минимальная длина пароля.
+	MinPasswordLength int
+	// DefaultPerPage размер страницы списков, если он не указан в запросе.
+	DefaultPerPage int
+	// MaxFailedLogins число неудачных попыток входа подряд, после которого учётная запись блокируется.
+	MaxFailedLogins int
+	// LoginLockout время блокировки входа.
+	LoginLockout time.Duration
+	// EmailVerificationTTL срок действия токена подтверждения email.
+	EmailVerificationTTL time.Duration
+	// PasswordResetTTL срок действия токена сброса пароля.
+	PasswordResetTTL time.Duration
+	// OrderPricePolicy цена позиций неоплаченного заказа при изменении количества товара.
+	OrderPricePolicy OrderPricePolicy
+}
+
+// DefaultRules правила, действующие, если конфигурация их не задаёт.
+func DefaultRules() Rules {
+	return Rules{
+		MinAge:            minAge,
+		MinPasswordLength: minPasswordLength,
+		DefaultPerPage:    DefaultPerPage,
+		MaxFailedLogins:   defaultMaxFailedLogins,
+		LoginLockout:      defaultLoginLockout,
+
+		EmailVerificationTTL: defaultEmailVerificationTTL,
+		PasswordResetTTL:     defaultPasswordResetTTL,
+
+		OrderPricePolicy: OrderPricePolicySnapshot,
+	}
+}
@@ -0,0 +1,38 @@
+//go:build unit
+
+package valueobjects_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	passCrypto "github.com/smgladkovskiy/warehouse-task/internal/pkg/pass_crypto"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+func TestRules(t *testing.T) {
+	t.Parallel()
+
+	var hasher passCrypto.WithPasswordHasher
+
+	rules := vObject.Rules{MinAge: 21, MinPasswordLength: 12, DefaultPerPage: 50}
+
+	_, err := vObject.NewBirthDate(time.Now().AddDate(-20, 0, 0), rules.MinAge)
+	require.ErrorIs(t, err, vObject.ErrAgeIsTooLow)
+	assert.ErrorContains(t, err, "21")
+
+	_, err = vObject.NewBirthDate(time.Now().AddDate(-20, 0, 0), vObject.DefaultRules().MinAge)
+	require.NoError(t, err)
+
+	_, err = vObject.NewPasswordHash(hasher.GetHasher(), "eleven_char", rules.MinPasswordLength)
+	require.ErrorIs(t, err, vObject.ErrPasswordLen)
+
+	_, err = vObject.NewPasswordHash(hasher.GetHasher(), "twelve_chars", rules.MinPasswordLength)
+	require.NoError(t, err)
+
+	assert.Equal(t, vObject.MetaPerPage(vObject.DefaultPerPage), vObject.NewMetaPerPage(0))
+	assert.Equal(t, vObject.DefaultPerPage, vObject.DefaultRules().DefaultPerPage)
+}
@@ -0,0 +1,26 @@
+package valueobjects
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type SessionID struct {
+	withUUIDer
+}
+
+func NewSessionIDFromUUID(id uuid.UUID) (SessionID, error) {
+	if id == uuid.Nil {
+		return SessionID{}, fmt.Errorf("session %w", ErrEmptyID)
+	}
+
+	return NewSessionIDFromUUIDUnsafe(id), nil
+}
+
+func NewSessionIDFromUUIDUnsafe(id uuid.UUID) SessionID {
+	sessionID := SessionID{}
+	sessionID.SetFromUUID(id)
+
+	return sessionID
+}
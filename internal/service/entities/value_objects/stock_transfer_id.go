@@ -0,0 +1,26 @@
+package valueobjects
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type StockTransferID struct {
+	withUUIDer
+}
+
+func NewStockTransferIDFromUUID(id uuid.UUID) (StockTransferID, error) {
+	if id == uuid.Nil {
+		return StockTransferID{}, fmt.Errorf("stock transfer %w", ErrEmptyID)
+	}
+
+	return NewStockTransferIDFromUUIDUnsafe(id), nil
+}
+
+func NewStockTransferIDFromUUIDUnsafe(id uuid.UUID) StockTransferID {
+	transferID := StockTransferID{}
+	transferID.SetFromUUID(id)
+
+	return transferID
+}
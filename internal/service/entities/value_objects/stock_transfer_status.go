@@ -0,0 +1,16 @@
+package valueobjects
+
+type StockTransferStatus string
+
+const (
+	StockTransferStatusInTransit StockTransferStatus = "in_transit" // Товар отгружен со склада-источника и находится в пути
+	StockTransferStatusReceived  StockTransferStatus = "received"   // Товар принят на складе-получателе
+)
+
+func NewStockTransferStatusUnsafe(status string) StockTransferStatus {
+	return StockTransferStatus(status)
+}
+
+func (s StockTransferStatus) String() string {
+	return string(s)
+}
@@ -1,4 +1,61 @@
 package valueobjects
 
+import (
+	"errors"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
 type Tag string
 type Tags []Tag
+
+const (
+	// TagMaxLength максимальная длина тега в символах.
+	TagMaxLength = 64
+	// TagsMaxCount максимальное количество тегов у товара.
+	TagsMaxCount = 20
+)
+
+var (
+	ErrEmptyTag    = errors.New("empty tag")
+	ErrTagTooLong  = errors.New("tag is too long")
+	ErrTooManyTags = errors.New("too many tags")
+)
+
+// NewTag тег в нижнем регистре без пробелов по краям.
+func NewTag(tag string) (Tag, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+
+	if tag == "" {
+		return "", ErrEmptyTag
+	}
+
+	if utf8.RuneCountInString(tag) > TagMaxLength {
+		return "", ErrTagTooLong
+	}
+
+	return Tag(tag), nil
+}
+
+// NewTags теги товара без повторов в порядке первого упоминания.
+func NewTags(tags []string) (Tags, error) {
+	result := make(Tags, 0, len(tags))
+
+	for _, t := range tags {
+		tag, err := NewTag(t)
+		if err != nil {
+			return nil, err
+		}
+
+		if !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+
+	if len(result) > TagsMaxCount {
+		return nil, ErrTooManyTags
+	}
+
+	return result, nil
+}
@@ -0,0 +1,59 @@
+package valueobjects
+
+import (
+	"errors"
+	"slices"
+)
+
+// TagsMatch способ сравнения тегов товара с тегами фильтра.
+type TagsMatch string
+
+const (
+	// TagsMatchAny у товара есть хотя бы один из тегов фильтра.
+	TagsMatchAny TagsMatch = "any"
+	// TagsMatchAll у товара есть все теги фильтра.
+	TagsMatchAll TagsMatch = "all"
+)
+
+var ErrUnknownTagsMatch = errors.New("unknown tags match")
+
+// NewTagsMatch способ сравнения тегов, по умолчанию TagsMatchAny.
+func NewTagsMatch(match string) (TagsMatch, error) {
+	if match == "" {
+		return TagsMatchAny, nil
+	}
+
+	m := TagsMatch(match)
+
+	if m != TagsMatchAny && m != TagsMatchAll {
+		return "", ErrUnknownTagsMatch
+	}
+
+	return m, nil
+}
+
+// Matches сообщает, подходят ли теги товара tags под теги фильтра filter.
+// Пустой фильтр подходит любому товару.
+func (m TagsMatch) Matches(tags, filter Tags) bool {
+	if len(filter) == 0 {
+		return true
+	}
+
+	for _, f := range filter {
+		found := slices.Contains(tags, f)
+
+		if found && m == TagsMatchAny {
+			return true
+		}
+
+		if !found && m == TagsMatchAll {
+			return false
+		}
+	}
+
+	return m == TagsMatchAll
+}
+
+func (m TagsMatch) String() string {
+	return string(m)
+}
@@ -0,0 +1,54 @@
+//go:build unit
+
+package valueobjects_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+func TestNewTagsMatch(t *testing.T) {
+	t.Parallel()
+
+	match, err := vObject.NewTagsMatch("")
+	require.NoError(t, err)
+	assert.Equal(t, vObject.TagsMatchAny, match)
+
+	match, err = vObject.NewTagsMatch("all")
+	require.NoError(t, err)
+	assert.Equal(t, vObject.TagsMatchAll, match)
+
+	_, err = vObject.NewTagsMatch("none")
+	require.ErrorIs(t, err, vObject.ErrUnknownTagsMatch)
+}
+
+func TestTagsMatch_Matches(t *testing.T) {
+	t.Parallel()
+
+	tags := vObject.Tags{"молочное", "напитки"}
+
+	testCases := []struct {
+		name   string
+		match  vObject.TagsMatch
+		filter vObject.Tags
+		want   bool
+	}{
+		{name: "empty filter", match: vObject.TagsMatchAll, filter: nil, want: true},
+		{name: "any one of", match: vObject.TagsMatchAny, filter: vObject.Tags{"сыры", "напитки"}, want: true},
+		{name: "any none", match: vObject.TagsMatchAny, filter: vObject.Tags{"сыры"}, want: false},
+		{name: "all present", match: vObject.TagsMatchAll, filter: vObject.Tags{"напитки", "молочное"}, want: true},
+		{name: "all missing one", match: vObject.TagsMatchAll, filter: vObject.Tags{"напитки", "сыры"}, want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, tc.match.Matches(tags, tc.filter))
+		})
+	}
+}
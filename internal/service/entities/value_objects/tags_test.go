@@ -0,0 +1,43 @@
+//go:build unit
+
+package valueobjects_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+func TestNewTags(t *testing.T) {
+	t.Parallel()
+
+	tags, err := vObject.NewTags([]string{" Молочное ", "напитки", "молочное"})
+	require.NoError(t, err)
+	assert.Equal(t, vObject.Tags{"молочное", "напитки"}, tags)
+
+	tags, err = vObject.NewTags(nil)
+	require.NoError(t, err)
+	assert.Equal(t, vObject.Tags{}, tags)
+
+	_, err = vObject.NewTags([]string{"напитки", " "})
+	require.ErrorIs(t, err, vObject.ErrEmptyTag)
+
+	_, err = vObject.NewTags([]string{strings.Repeat("я", vObject.TagMaxLength+1)})
+	require.ErrorIs(t, err, vObject.ErrTagTooLong)
+
+	many := make([]string, 0, vObject.TagsMaxCount+1)
+	for i := range vObject.TagsMaxCount + 1 {
+		many = append(many, "tag"+strconv.Itoa(i))
+	}
+
+	_, err = vObject.NewTags(many)
+	require.ErrorIs(t, err, vObject.ErrTooManyTags)
+
+	_, err = vObject.NewTags(append(many[:vObject.TagsMaxCount], "tag0"))
+	require.NoError(t, err, "duplicates are not counted")
+}
@@ -1,17 +1,66 @@
 package ioc
 
 import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/auth"
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/mail"
+	passcrypto "github.com/smgladkovskiy/warehouse-task/internal/pkg/pass_crypto"
 	upsertOrder "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order/upsert"
 	upsertOrderProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order_product/upsert"
+	createOrderStatusHistory "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order_status_history/create"
+	createProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product/create"
+	updateProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product/update"
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	createProductPrice "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_price/create"
+	revokeUserSessions "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/revoke_by_user"
+	upsertSession "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/upsert"
+	createStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
+	upsertStockTransfer "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock_transfer/upsert"
 	createUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/create"
+	updateUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/update"
+	invalidateUserTokens "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/invalidate"
+	upsertUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/upsert"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
 	getOrder "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_order"
+	getStatusHistory "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_status_history"
 	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	listOrders "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/list_orders"
+	getMovements "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_movements"
+	getPrice "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_price"
 	getProduct "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_product"
+	listProducts "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/list_products"
+	getSession "github.com/smgladkovskiy/warehouse-task/internal/service/queries/session/get_session"
+	getTransfer "github.com/smgladkovskiy/warehouse-task/internal/service/queries/stock_transfer/get_transfer"
 	getUserByEmail "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_by_email"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	getUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user_token/get_user_token"
 	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/usecases/auth/authenticate"
+	loginUser "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/auth/login_user"
+	refreshSession "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/auth/refresh_session"
+	revokeSession "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/auth/revoke_session"
 	addProductToOrder "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/order/add_product_to_order"
+	changeOrderStatus "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/order/change_order_status"
+	orderDetails "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/order/order_details"
+	orderHistory "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/order/order_history"
+	addProduct "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/product/add_product"
+	archiveProduct "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/product/archive_product"
+	editProduct "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/product/edit_product"
+	incomeStock "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/stock/income_stock"
+	receiveTransfer "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/stock/receive_transfer"
+	transferStock "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/stock/transfer_stock"
+	writeOffStock "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/stock/write_off_stock"
+	grantRole "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/user/grant_role"
 	userRegistration "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/user/registration"
+	requestEmailVerification "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/user/request_email_verification"
+	requestPasswordReset "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/user/request_password_reset"
+	resetPassword "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/user/reset_password"
+	verifyEmail "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/user/verify_email"
 )
 
 type Container struct {
@@ -22,14 +71,29 @@ type Container struct {
 
 type Queries struct {
 	// order
-	GetOrder  *getOrder.QueryHandler
-	GetStocks *getStocks.QueryHandler
+	GetOrder              *getOrder.QueryHandler
+	GetOrderStatusHistory *getStatusHistory.QueryHandler
+	GetStocks             *getStocks.QueryHandler
+	ListOrders            *listOrders.QueryHandler
 
 	// product
-	GetProduct *getProduct.QueryHandler
+	GetProduct          *getProduct.QueryHandler
+	GetProductMovements *getMovements.QueryHandler
+	GetProductPrice     *getPrice.QueryHandler
+	ListProducts        *listProducts.QueryHandler
+
+	// session
+	GetSession *getSession.QueryHandler
+
+	// stock transfer
+	GetStockTransfer *getTransfer.QueryHandler
 
 	// user
+	GetUser        *getUser.QueryHandler
 	GetUserByEmail *getUserByEmail.QueryHandler
+
+	// user token
+	GetUserToken *getUserToken.QueryHandler
 }
 
 type Commands struct {
@@ -39,30 +103,211 @@ type Commands struct {
 	// order product
 	UpsertOrderProduct *upsertOrderProduct.CommandHandler
 
+	// order status history
+	CreateOrderStatusHistory *createOrderStatusHistory.CommandHandler
+
+	// product
+	CreateProduct *createProduct.CommandHandler
+	UpdateProduct *updateProduct.CommandHandler
+
+	// product movement
+	CreateProductMovements *createProductMovements.CommandHandler
+
+	// product price
+	CreateProductPrice *createProductPrice.CommandHandler
+
+	// session
+	UpsertSession      *upsertSession.CommandHandler
+	RevokeUserSessions *revokeUserSessions.CommandHandler
+
+	// stock
+	CreateStock *createStock.CommandHandler
+	UpdateStock *updateStock.CommandHandler
+
+	// stock transfer
+	UpsertStockTransfer *upsertStockTransfer.CommandHandler
+
 	// user
 	CreateUser *createUser.CommandHandler
+	UpdateUser *updateUser.CommandHandler
+
+	// user token
+	UpsertUserToken      *upsertUserToken.CommandHandler
+	InvalidateUserTokens *invalidateUserTokens.CommandHandler
 }
 
 type UseCases struct {
+	// auth
+	LoginUser      *loginUser.UseCase
+	RefreshSession *refreshSession.UseCase
+	RevokeSession  *revokeSession.UseCase
+	Authenticate   *authenticate.UseCase
+
 	// order
 	AddProductToOrder *addProductToOrder.UseCase
+	ChangeOrderStatus *changeOrderStatus.UseCase
+	OrderDetails      *orderDetails.UseCase
+	OrderHistory      *orderHistory.UseCase
+
+	// product
+	AddProduct     *addProduct.UseCase
+	EditProduct    *editProduct.UseCase
+	ArchiveProduct *archiveProduct.UseCase
+
+	// stock
+	IncomeStock     *incomeStock.UseCase
+	WriteOffStock   *writeOffStock.UseCase
+	TransferStock   *transferStock.UseCase
+	ReceiveTransfer *receiveTransfer.UseCase
 
 	// user
-	UserRegistration *userRegistration.UseCase
+	UserRegistration         *userRegistration.UseCase
+	RequestEmailVerification *requestEmailVerification.UseCase
+	VerifyEmail              *verifyEmail.UseCase
+	RequestPasswordReset     *requestPasswordReset.UseCase
+	ResetPassword            *resetPassword.UseCase
+	GrantRole                *grantRole.UseCase
+}
+
+// Option specifies configuration options of Container.
+type Option func(*options)
+
+type options struct {
+	issuer *auth.Issuer
+	hasher passcrypto.PasswordHashable
+	mailer mail.Mailer
+
+	rules            vObject.Rules
+	allocationPolicy entities.AllocationPolicy
+
+	emailVerificationURL string
+	passwordResetURL     string
 }
 
-func NewContainer(realisations Implementationable) (*Container, error) {
+// WithTokenIssuer задаёт выпуск и проверку токенов доступа.
+// По умолчанию токены подписываются случайным ключом и не переживают перезапуск.
+func WithTokenIssuer(issuer *auth.Issuer) Option {
+	return func(o *options) {
+		o.issuer = issuer
+	}
+}
+
+// WithPasswordHasher задаёт хеширование паролей при регистрации, входе и сбросе пароля.
+// По умолчанию используется argon2id с параметрами passcrypto.DefaultArgon2idParams.
+func WithPasswordHasher(hasher passcrypto.PasswordHashable) Option {
+	return func(o *options) {
+		o.hasher = hasher
+	}
+}
+
+// WithMailer задаёт отправку писем с токенами подтверждения email и сброса пароля.
+// По умолчанию письма складываются в память процесса и никуда не уходят.
+func WithMailer(mailer mail.Mailer) Option {
+	return func(o *options) {
+		o.mailer = mailer
+	}
+}
+
+// WithRules задаёт бизнес-правила инсталляции, которые контейнер передаёт юзкейсам.
+// По умолчанию используются valueobjects.DefaultRules.
+func WithRules(rules vObject.Rules) Option {
+	return func(o *options) {
+		o.rules = rules
+	}
+}
+
+// WithAllocationPolicy задаёт политику распределения товара заказа по складам.
+// По умолчанию используется entities.SplitAllocationPolicy.
+func WithAllocationPolicy(policy entities.AllocationPolicy) Option {
+	return func(o *options) {
+		o.allocationPolicy = policy
+	}
+}
+
+// WithEmailVerificationURL задаёт адрес страницы подтверждения email, к которому в письме дописывается токен.
+func WithEmailVerificationURL(linkURL string) Option {
+	return func(o *options) {
+		o.emailVerificationURL = linkURL
+	}
+}
+
+// WithPasswordResetURL задаёт адрес страницы сброса пароля, к которому в письме дописывается токен.
+func WithPasswordResetURL(linkURL string) Option {
+	return func(o *options) {
+		o.passwordResetURL = linkURL
+	}
+}
+
+func NewContainer(realisations Implementationable, opts ...Option) (*Container, error) {
+	o := options{rules: vObject.DefaultRules()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.issuer == nil {
+		secret := make([]byte, auth.MinSecretLength)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("[ioc - token secret]: %w", err)
+		}
+
+		issuer, err := auth.NewIssuer(secret)
+		if err != nil {
+			return nil, err
+		}
+
+		o.issuer = issuer
+	}
+
+	if o.hasher == nil {
+		hasher, err := passcrypto.NewHasher()
+		if err != nil {
+			return nil, err
+		}
+
+		o.hasher = hasher
+	}
+
+	if o.mailer == nil {
+		o.mailer = mail.NewMemory()
+	}
+
+	if o.allocationPolicy == nil {
+		o.allocationPolicy = entities.SplitAllocationPolicy{}
+	}
+
 	c := Container{
 		Queries: Queries{
-			GetOrder:       getOrder.NewQueryHandler(realisations.OrderGetter()),
-			GetStocks:      getStocks.NewQueryHandler(realisations.StocksGetter()),
-			GetProduct:     getProduct.NewQueryHandler(realisations.ProductGetter()),
-			GetUserByEmail: getUserByEmail.NewQueryHandler(realisations.UserGetter()),
+			GetOrder:              getOrder.NewQueryHandler(realisations.OrderGetter()),
+			GetOrderStatusHistory: getStatusHistory.NewQueryHandler(realisations.OrderStatusHistoryGetter()),
+			GetStocks:             getStocks.NewQueryHandler(realisations.StocksGetter()),
+			ListOrders:            listOrders.NewQueryHandler(realisations.OrdersLister()),
+			GetProduct:            getProduct.NewQueryHandler(realisations.ProductGetter()),
+			GetProductMovements:   getMovements.NewQueryHandler(realisations.ProductMovementsGetter()),
+			GetProductPrice:       getPrice.NewQueryHandler(realisations.ProductPriceGetter()),
+			ListProducts:          listProducts.NewQueryHandler(realisations.ProductsLister()),
+			GetSession:            getSession.NewQueryHandler(realisations.SessionGetter()),
+			GetStockTransfer:      getTransfer.NewQueryHandler(realisations.StockTransferGetter()),
+			GetUser:               getUser.NewQueryHandler(realisations.UserByIDGetter()),
+			GetUserByEmail:        getUserByEmail.NewQueryHandler(realisations.UserGetter()),
+			GetUserToken:          getUserToken.NewQueryHandler(realisations.UserTokenGetter()),
 		},
 		Commands: Commands{
-			UpsertOrder:        upsertOrder.NewCommandHandler(realisations.OrderUpserter()),
-			UpsertOrderProduct: upsertOrderProduct.NewCommandHandler(realisations.OrderProductUpserter()),
-			CreateUser:         createUser.NewCommandHandler(realisations.UserCreator()),
+			UpsertOrder:              upsertOrder.NewCommandHandler(realisations.OrderUpserter()),
+			UpsertOrderProduct:       upsertOrderProduct.NewCommandHandler(realisations.OrderProductUpserter()),
+			CreateOrderStatusHistory: createOrderStatusHistory.NewCommandHandler(realisations.OrderStatusHistoryCreator()),
+			CreateProduct:            createProduct.NewCommandHandler(realisations.ProductCreator()),
+			UpdateProduct:            updateProduct.NewCommandHandler(realisations.ProductUpdater()),
+			CreateProductMovements:   createProductMovements.NewCommandHandler(realisations.ProductMovementsCreator()),
+			CreateProductPrice:       createProductPrice.NewCommandHandler(realisations.ProductPriceCreator()),
+			UpsertSession:            upsertSession.NewCommandHandler(realisations.SessionUpserter()),
+			RevokeUserSessions:       revokeUserSessions.NewCommandHandler(realisations.UserSessionsRevoker()),
+			CreateStock:              createStock.NewCommandHandler(realisations.StockCreator()),
+			UpdateStock:              updateStock.NewCommandHandler(realisations.StockUpdater()),
+			UpsertStockTransfer:      upsertStockTransfer.NewCommandHandler(realisations.StockTransferUpserter()),
+			CreateUser:               createUser.NewCommandHandler(realisations.UserCreator()),
+			UpdateUser:               updateUser.NewCommandHandler(realisations.UserUpdater()),
+			UpsertUserToken:          upsertUserToken.NewCommandHandler(realisations.UserTokenUpserter()),
+			InvalidateUserTokens:     invalidateUserTokens.NewCommandHandler(realisations.UserTokensInvalidator()),
 		},
 	}
 
@@ -74,15 +319,255 @@ func NewContainer(realisations Implementationable) (*Container, error) {
 		addProductToOrder.WithGetStocksQuery(c.Queries.GetStocks),
 		addProductToOrder.WithUpsertOrderCommand(c.Commands.UpsertOrder),
 		addProductToOrder.WithUpsertOrderProductCommand(c.Commands.UpsertOrderProduct),
+		addProductToOrder.WithGetProductMovementsQuery(c.Queries.GetProductMovements),
+		addProductToOrder.WithUpdateStockCommand(c.Commands.UpdateStock),
+		addProductToOrder.WithCreateProductMovementsCommand(c.Commands.CreateProductMovements),
+		addProductToOrder.WithCreateOrderStatusHistoryCommand(c.Commands.CreateOrderStatusHistory),
+		addProductToOrder.WithGetUserQuery(c.Queries.GetUser),
+		addProductToOrder.WithAllocationPolicy(o.allocationPolicy),
+		addProductToOrder.WithOrderPricePolicy(o.rules.OrderPricePolicy),
 		usecase.WithTransactionManager[*addProductToOrder.UseCase](realisations.TransactionManager()),
 		usecase.WithLogger[*addProductToOrder.UseCase](log.Named("usecase.addProductToOrder")),
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UseCases.ChangeOrderStatus, err = changeOrderStatus.NewUseCase(
+		changeOrderStatus.WithGetOrderQuery(c.Queries.GetOrder),
+		changeOrderStatus.WithUpsertOrderCommand(c.Commands.UpsertOrder),
+		changeOrderStatus.WithCreateOrderStatusHistoryCommand(c.Commands.CreateOrderStatusHistory),
+		changeOrderStatus.WithGetStocksQuery(c.Queries.GetStocks),
+		changeOrderStatus.WithGetProductMovementsQuery(c.Queries.GetProductMovements),
+		changeOrderStatus.WithUpdateStockCommand(c.Commands.UpdateStock),
+		changeOrderStatus.WithCreateProductMovementsCommand(c.Commands.CreateProductMovements),
+		changeOrderStatus.WithGetUserQuery(c.Queries.GetUser),
+		usecase.WithTransactionManager[*changeOrderStatus.UseCase](realisations.TransactionManager()),
+		usecase.WithLogger[*changeOrderStatus.UseCase](log.Named("usecase.changeOrderStatus")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UseCases.OrderDetails, err = orderDetails.NewUseCase(
+		orderDetails.WithGetUserQuery(c.Queries.GetUser),
+		orderDetails.WithGetOrderQuery(c.Queries.GetOrder),
+		usecase.WithLogger[*orderDetails.UseCase](log.Named("usecase.orderDetails")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UseCases.OrderHistory, err = orderHistory.NewUseCase(
+		orderHistory.WithRules(o.rules),
+		orderHistory.WithGetUserQuery(c.Queries.GetUser),
+		orderHistory.WithListOrdersQuery(c.Queries.ListOrders),
+		usecase.WithLogger[*orderHistory.UseCase](log.Named("usecase.orderHistory")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UseCases.AddProduct, err = addProduct.NewUseCase(
+		addProduct.WithGetUserQuery(c.Queries.GetUser),
+		addProduct.WithCreateProductCommand(c.Commands.CreateProduct),
+		addProduct.WithCreateProductPriceCommand(c.Commands.CreateProductPrice),
+		usecase.WithTransactionManager[*addProduct.UseCase](realisations.TransactionManager()),
+		usecase.WithLogger[*addProduct.UseCase](log.Named("usecase.addProduct")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UseCases.EditProduct, err = editProduct.NewUseCase(
+		editProduct.WithGetUserQuery(c.Queries.GetUser),
+		editProduct.WithGetProductQuery(c.Queries.GetProduct),
+		editProduct.WithUpdateProductCommand(c.Commands.UpdateProduct),
+		editProduct.WithCreateProductPriceCommand(c.Commands.CreateProductPrice),
+		usecase.WithTransactionManager[*editProduct.UseCase](realisations.TransactionManager()),
+		usecase.WithLogger[*editProduct.UseCase](log.Named("usecase.editProduct")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UseCases.ArchiveProduct, err = archiveProduct.NewUseCase(
+		archiveProduct.WithGetUserQuery(c.Queries.GetUser),
+		archiveProduct.WithGetProductQuery(c.Queries.GetProduct),
+		archiveProduct.WithUpdateProductCommand(c.Commands.UpdateProduct),
+		usecase.WithTransactionManager[*archiveProduct.UseCase](realisations.TransactionManager()),
+		usecase.WithLogger[*archiveProduct.UseCase](log.Named("usecase.archiveProduct")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UseCases.IncomeStock, err = incomeStock.NewUseCase(
+		incomeStock.WithGetStocksQuery(c.Queries.GetStocks),
+		incomeStock.WithCreateStockCommand(c.Commands.CreateStock),
+		incomeStock.WithUpdateStockCommand(c.Commands.UpdateStock),
+		incomeStock.WithCreateProductMovementsCommand(c.Commands.CreateProductMovements),
+		incomeStock.WithGetUserQuery(c.Queries.GetUser),
+		usecase.WithTransactionManager[*incomeStock.UseCase](realisations.TransactionManager()),
+		usecase.WithLogger[*incomeStock.UseCase](log.Named("usecase.incomeStock")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UseCases.WriteOffStock, err = writeOffStock.NewUseCase(
+		writeOffStock.WithGetStocksQuery(c.Queries.GetStocks),
+		writeOffStock.WithUpdateStockCommand(c.Commands.UpdateStock),
+		writeOffStock.WithCreateProductMovementsCommand(c.Commands.CreateProductMovements),
+		writeOffStock.WithGetUserQuery(c.Queries.GetUser),
+		usecase.WithTransactionManager[*writeOffStock.UseCase](realisations.TransactionManager()),
+		usecase.WithLogger[*writeOffStock.UseCase](log.Named("usecase.writeOffStock")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UseCases.TransferStock, err = transferStock.NewUseCase(
+		transferStock.WithGetStocksQuery(c.Queries.GetStocks),
+		transferStock.WithCreateStockCommand(c.Commands.CreateStock),
+		transferStock.WithUpdateStockCommand(c.Commands.UpdateStock),
+		transferStock.WithUpsertStockTransferCommand(c.Commands.UpsertStockTransfer),
+		transferStock.WithCreateProductMovementsCommand(c.Commands.CreateProductMovements),
+		transferStock.WithGetUserQuery(c.Queries.GetUser),
+		usecase.WithTransactionManager[*transferStock.UseCase](realisations.TransactionManager()),
+		usecase.WithLogger[*transferStock.UseCase](log.Named("usecase.transferStock")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UseCases.ReceiveTransfer, err = receiveTransfer.NewUseCase(
+		receiveTransfer.WithGetStockTransferQuery(c.Queries.GetStockTransfer),
+		receiveTransfer.WithGetStocksQuery(c.Queries.GetStocks),
+		receiveTransfer.WithUpdateStockCommand(c.Commands.UpdateStock),
+		receiveTransfer.WithUpsertStockTransferCommand(c.Commands.UpsertStockTransfer),
+		receiveTransfer.WithCreateProductMovementsCommand(c.Commands.CreateProductMovements),
+		receiveTransfer.WithGetUserQuery(c.Queries.GetUser),
+		usecase.WithTransactionManager[*receiveTransfer.UseCase](realisations.TransactionManager()),
+		usecase.WithLogger[*receiveTransfer.UseCase](log.Named("usecase.receiveTransfer")),
+	)
+	if err != nil {
+		return nil, err
+	}
 
 	c.UseCases.UserRegistration, err = userRegistration.NewUseCase(
+		userRegistration.WithRules(o.rules),
 		userRegistration.WithGetUserByEmailQuery(c.Queries.GetUserByEmail),
 		userRegistration.WithCreateUserCommand(c.Commands.CreateUser),
+		userRegistration.WithPasswordHasher(o.hasher),
 		usecase.WithLogger[*userRegistration.UseCase](log.Named("usecase.userRegistration")),
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UseCases.RequestEmailVerification, err = requestEmailVerification.NewUseCase(
+		requestEmailVerification.WithRules(o.rules),
+		requestEmailVerification.WithGetUserByEmailQuery(c.Queries.GetUserByEmail),
+		requestEmailVerification.WithUpsertUserTokenCommand(c.Commands.UpsertUserToken),
+		requestEmailVerification.WithMailer(o.mailer),
+		requestEmailVerification.WithLinkURL(o.emailVerificationURL),
+		usecase.WithLogger[*requestEmailVerification.UseCase](log.Named("usecase.requestEmailVerification")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UseCases.VerifyEmail, err = verifyEmail.NewUseCase(
+		verifyEmail.WithGetUserTokenQuery(c.Queries.GetUserToken),
+		verifyEmail.WithGetUserQuery(c.Queries.GetUser),
+		verifyEmail.WithUpdateUserCommand(c.Commands.UpdateUser),
+		verifyEmail.WithUpsertUserTokenCommand(c.Commands.UpsertUserToken),
+		usecase.WithTransactionManager[*verifyEmail.UseCase](realisations.TransactionManager()),
+		usecase.WithLogger[*verifyEmail.UseCase](log.Named("usecase.verifyEmail")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UseCases.RequestPasswordReset, err = requestPasswordReset.NewUseCase(
+		requestPasswordReset.WithRules(o.rules),
+		requestPasswordReset.WithGetUserByEmailQuery(c.Queries.GetUserByEmail),
+		requestPasswordReset.WithUpsertUserTokenCommand(c.Commands.UpsertUserToken),
+		requestPasswordReset.WithMailer(o.mailer),
+		requestPasswordReset.WithLinkURL(o.passwordResetURL),
+		usecase.WithLogger[*requestPasswordReset.UseCase](log.Named("usecase.requestPasswordReset")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UseCases.ResetPassword, err = resetPassword.NewUseCase(
+		resetPassword.WithRules(o.rules),
+		resetPassword.WithGetUserTokenQuery(c.Queries.GetUserToken),
+		resetPassword.WithGetUserQuery(c.Queries.GetUser),
+		resetPassword.WithUpdateUserCommand(c.Commands.UpdateUser),
+		resetPassword.WithUpsertUserTokenCommand(c.Commands.UpsertUserToken),
+		resetPassword.WithInvalidateUserTokensCommand(c.Commands.InvalidateUserTokens),
+		resetPassword.WithRevokeUserSessionsCommand(c.Commands.RevokeUserSessions),
+		resetPassword.WithPasswordHasher(o.hasher),
+		usecase.WithTransactionManager[*resetPassword.UseCase](realisations.TransactionManager()),
+		usecase.WithLogger[*resetPassword.UseCase](log.Named("usecase.resetPassword")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UseCases.GrantRole, err = grantRole.NewUseCase(
+		grantRole.WithGetUserQuery(c.Queries.GetUser),
+		grantRole.WithUpdateUserCommand(c.Commands.UpdateUser),
+		usecase.WithTransactionManager[*grantRole.UseCase](realisations.TransactionManager()),
+		usecase.WithLogger[*grantRole.UseCase](log.Named("usecase.grantRole")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UseCases.LoginUser, err = loginUser.NewUseCase(
+		loginUser.WithRules(o.rules),
+		loginUser.WithTokenIssuer(o.issuer),
+		loginUser.WithPasswordHasher(o.hasher),
+		loginUser.WithGetUserByEmailQuery(c.Queries.GetUserByEmail),
+		loginUser.WithUpdateUserCommand(c.Commands.UpdateUser),
+		loginUser.WithUpsertSessionCommand(c.Commands.UpsertSession),
+		usecase.WithTransactionManager[*loginUser.UseCase](realisations.TransactionManager()),
+		usecase.WithLogger[*loginUser.UseCase](log.Named("usecase.loginUser")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UseCases.RefreshSession, err = refreshSession.NewUseCase(
+		refreshSession.WithTokenIssuer(o.issuer),
+		refreshSession.WithGetSessionQuery(c.Queries.GetSession),
+		refreshSession.WithUpsertSessionCommand(c.Commands.UpsertSession),
+		usecase.WithTransactionManager[*refreshSession.UseCase](realisations.TransactionManager()),
+		usecase.WithLogger[*refreshSession.UseCase](log.Named("usecase.refreshSession")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UseCases.RevokeSession, err = revokeSession.NewUseCase(
+		revokeSession.WithGetSessionQuery(c.Queries.GetSession),
+		revokeSession.WithUpsertSessionCommand(c.Commands.UpsertSession),
+		usecase.WithTransactionManager[*revokeSession.UseCase](realisations.TransactionManager()),
+		usecase.WithLogger[*revokeSession.UseCase](log.Named("usecase.revokeSession")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UseCases.Authenticate, err = authenticate.NewUseCase(
+		authenticate.WithTokenIssuer(o.issuer),
+		authenticate.WithGetSessionQuery(c.Queries.GetSession),
+		usecase.WithLogger[*authenticate.UseCase](log.Named("usecase.authenticate")),
+	)
 
 	return &c, err
 }
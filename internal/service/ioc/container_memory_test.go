@@ -0,0 +1,939 @@
+package ioc_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/mail"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/ioc"
+	getOrder "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_order"
+	getStatusHistory "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_status_history"
+	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	getPrice "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_price"
+	listProducts "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/list_products"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/memory"
+)
+
+type registrationRequest struct{}
+
+func (registrationRequest) GetEmail() string         { return "test@test.ru" }
+func (registrationRequest) GetFirstName() string     { return "Иван" }
+func (registrationRequest) GetLastName() string      { return "Иванов" }
+func (registrationRequest) GetBirthDate() time.Time  { return time.Now().AddDate(-30, 0, 0) }
+func (registrationRequest) GetMaritalStatus() string { return string(vObject.MaritalStatusSingle) }
+func (registrationRequest) GetPassword() string      { return "secret_password" }
+
+type loginRequest struct {
+	password string
+}
+
+func (loginRequest) GetEmail() string      { return "test@test.ru" }
+func (r loginRequest) GetPassword() string { return r.password }
+
+type emailRequest string
+
+func (r emailRequest) GetEmail() string { return string(r) }
+
+type redeemRequest struct {
+	token, password string
+}
+
+func (r redeemRequest) GetToken() string    { return r.token }
+func (r redeemRequest) GetPassword() string { return r.password }
+
+type tokenRequest string
+
+func (r tokenRequest) GetRefreshToken() string { return string(r) }
+func (r tokenRequest) GetAccessToken() string  { return string(r) }
+
+type revokeRequest struct {
+	sessionID, userID uuid.UUID
+}
+
+func (r revokeRequest) GetSessionID() uuid.UUID { return r.sessionID }
+func (r revokeRequest) GetUserID() uuid.UUID    { return r.userID }
+
+type addProductRequest struct {
+	orderID   uuid.UUID
+	userID    uuid.UUID
+	productID uuid.UUID
+	quantity  uint64
+}
+
+func (r addProductRequest) GetOrderID() uuid.UUID   { return r.orderID }
+func (r addProductRequest) GetUserID() uuid.UUID    { return r.userID }
+func (r addProductRequest) GetProductID() uuid.UUID { return r.productID }
+func (r addProductRequest) GetQuantity() uint64     { return r.quantity }
+
+type changeStatusRequest struct {
+	orderID uuid.UUID
+	actorID uuid.UUID
+	status  string
+}
+
+func (r changeStatusRequest) GetOrderID() uuid.UUID { return r.orderID }
+func (r changeStatusRequest) GetStatus() string     { return r.status }
+func (r changeStatusRequest) GetActorID() uuid.UUID { return r.actorID }
+func (r changeStatusRequest) GetReason() string     { return "" }
+
+type transferStockRequest struct {
+	actorID, productID, fromID, toID uuid.UUID
+	quantity                         uint64
+}
+
+func (r transferStockRequest) GetActorID() uuid.UUID         { return r.actorID }
+func (r transferStockRequest) GetProductID() uuid.UUID       { return r.productID }
+func (r transferStockRequest) GetFromWarehouseID() uuid.UUID { return r.fromID }
+func (r transferStockRequest) GetToWarehouseID() uuid.UUID   { return r.toID }
+func (r transferStockRequest) GetQuantity() uint64           { return r.quantity }
+
+type incomeRequest struct {
+	actorID, productID, warehouseID uuid.UUID
+	quantity                        uint64
+	price                           int64
+	reason, documentRef             string
+}
+
+func (r incomeRequest) GetActorID() uuid.UUID     { return r.actorID }
+func (r incomeRequest) GetProductID() uuid.UUID   { return r.productID }
+func (r incomeRequest) GetWarehouseID() uuid.UUID { return r.warehouseID }
+func (r incomeRequest) GetQuantity() uint64       { return r.quantity }
+func (r incomeRequest) GetPurchasePrice() int64   { return r.price }
+func (r incomeRequest) GetReason() string         { return r.reason }
+func (r incomeRequest) GetDocumentRef() string    { return r.documentRef }
+
+type writeOffRequest struct {
+	actorID, productID, warehouseID uuid.UUID
+	quantity                        uint64
+	reason, documentRef             string
+}
+
+func (r writeOffRequest) GetActorID() uuid.UUID     { return r.actorID }
+func (r writeOffRequest) GetProductID() uuid.UUID   { return r.productID }
+func (r writeOffRequest) GetWarehouseID() uuid.UUID { return r.warehouseID }
+func (r writeOffRequest) GetQuantity() uint64       { return r.quantity }
+func (r writeOffRequest) GetReason() string         { return r.reason }
+func (r writeOffRequest) GetDocumentRef() string    { return r.documentRef }
+
+type receiveTransferRequest struct {
+	actorID, transferID uuid.UUID
+}
+
+func (r receiveTransferRequest) GetActorID() uuid.UUID    { return r.actorID }
+func (r receiveTransferRequest) GetTransferID() uuid.UUID { return r.transferID }
+
+type grantRoleRequest struct {
+	actorID, userID uuid.UUID
+	role            string
+}
+
+func (r grantRoleRequest) GetActorID() uuid.UUID { return r.actorID }
+func (r grantRoleRequest) GetUserID() uuid.UUID  { return r.userID }
+func (r grantRoleRequest) GetRole() string       { return r.role }
+
+type productRequest struct {
+	actorID, productID uuid.UUID
+	title, description string
+	tags               []string
+	price              int64
+}
+
+func (r productRequest) GetActorID() uuid.UUID   { return r.actorID }
+func (r productRequest) GetProductID() uuid.UUID { return r.productID }
+func (r productRequest) GetTitle() string        { return r.title }
+func (r productRequest) GetDescription() string  { return r.description }
+func (r productRequest) GetTags() []string       { return r.tags }
+func (r productRequest) GetPrice() int64         { return r.price }
+
+type orderHistoryRequest struct {
+	actorID      uuid.UUID
+	userID       uuid.UUID
+	statuses     []string
+	withProducts bool
+	perPage      int
+	cursor       *string
+}
+
+func (r orderHistoryRequest) GetActorID() uuid.UUID      { return r.actorID }
+func (r orderHistoryRequest) GetUserID() uuid.UUID       { return r.userID }
+func (r orderHistoryRequest) GetStatuses() []string      { return r.statuses }
+func (r orderHistoryRequest) GetCreatedFrom() *time.Time { return nil }
+func (r orderHistoryRequest) GetCreatedTo() *time.Time   { return nil }
+func (r orderHistoryRequest) GetSort() string            { return "" }
+func (r orderHistoryRequest) GetWithProducts() bool      { return r.withProducts }
+func (r orderHistoryRequest) GetPage() int               { return 0 }
+func (r orderHistoryRequest) GetPerPage() int            { return r.perPage }
+func (r orderHistoryRequest) GetCursor() *string         { return r.cursor }
+
+// addUser сохраняет в хранилище пользователя с ролью role в обход регистрации,
+// так же как первый администратор назначается напрямую в БД.
+func addUser(ctx context.Context, storage *memory.Storage, role vObject.Role) entities.User {
+	user := entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(uuid.New()), Role: role}
+	storage.AddUser(ctx, user)
+
+	return user
+}
+
+func TestContainer_InMemory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage := memory.NewStorage()
+
+	c, err := ioc.NewContainer(ioc.NewMemoryImplementations(storage))
+	require.NoError(t, err)
+
+	// регистрация пользователя
+	user, err := c.UseCases.UserRegistration.Run(ctx, registrationRequest{})
+	require.NoError(t, err)
+
+	_, err = c.UseCases.UserRegistration.Run(ctx, registrationRequest{})
+	require.ErrorIs(t, err, entities.ErrUserAlreadyExists)
+
+	// добавление товара в заказ
+	product := entities.Product{
+		ID:    vObject.NewProductIDFromUUIDUnsafe(uuid.New()),
+		Title: vObject.NewProductTitleUnsafe("product"),
+		Price: vObject.NewPriceUnsafe(1000),
+	}
+	storage.AddProduct(ctx, product)
+	storage.AddStock(ctx, entities.Stock{
+		ProductID:         product.ID,
+		WarehouseID:       vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New()),
+		AvailableQuantity: vObject.NewQuantityUnsafe(5),
+	})
+
+	reserved := func() vObject.Quantity {
+		t.Helper()
+
+		productStocks, err := c.Queries.GetStocks.Handle(ctx, getStocks.NewQueryByProductIDUnsafe(product.ID))
+		require.NoError(t, err)
+		require.Len(t, productStocks, 1)
+
+		return productStocks[0].ReservedQuantity
+	}
+
+	failedOrderID, orderID := uuid.New(), uuid.New()
+	req := addProductRequest{userID: user.ID.UUID(), productID: product.ID.UUID(), quantity: 6}
+
+	c.UseCases.AddProductToOrder.SetUUIDGen(&fixedUUID{first: failedOrderID})
+	_, err = c.UseCases.AddProductToOrder.Run(ctx, req)
+	require.ErrorIs(t, err, entities.ErrNotEnoughProductIntStocks)
+
+	c.UseCases.AddProductToOrder.SetUUIDGen(&fixedUUID{first: orderID})
+	req.quantity = 3
+	created, err := c.UseCases.AddProductToOrder.Run(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, orderID, created.ID.UUID())
+
+	// недостаток товара откатывает транзакцию целиком: заказ создаётся только вторым вызовом
+	order, err := c.Queries.GetOrder.Handle(ctx, mustOrderQuery(t, orderID))
+	require.NoError(t, err)
+	require.Len(t, order.Products, 1)
+	assert.Equal(t, vObject.NewQuantityUnsafe(3), order.Products[0].Quantity)
+	require.Len(t, order.Products[0].Allocations, 1)
+	assert.Equal(t, vObject.NewQuantityUnsafe(3), order.Products[0].Allocations.Total())
+
+	_, err = c.Queries.GetOrder.Handle(ctx, mustOrderQuery(t, failedOrderID))
+	require.ErrorIs(t, err, entities.ErrOrderRecNotFound)
+
+	// резерв на складе следует за количеством товара в заказе
+	assert.Equal(t, vObject.NewQuantityUnsafe(3), reserved())
+
+	req.orderID, req.quantity = orderID, 1
+	_, err = c.UseCases.AddProductToOrder.Run(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, vObject.NewQuantityUnsafe(1), reserved())
+
+	req.quantity = 5
+	_, err = c.UseCases.AddProductToOrder.Run(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, vObject.NewQuantityUnsafe(5), reserved())
+
+	req.quantity = 6
+	_, err = c.UseCases.AddProductToOrder.Run(ctx, req)
+	require.ErrorIs(t, err, entities.ErrNotEnoughProductIntStocks)
+	assert.Equal(t, vObject.NewQuantityUnsafe(5), reserved())
+
+	// чужой заказ покупатель изменить не может: для него заказа нет
+	stranger := addUser(ctx, storage, vObject.RoleCustomer)
+	_, err = c.UseCases.AddProductToOrder.Run(ctx, addProductRequest{orderID: orderID, userID: stranger.ID.UUID(), productID: product.ID.UUID(), quantity: 1})
+	require.ErrorIs(t, err, entities.ErrOrderRecNotFound)
+	assert.Equal(t, vObject.NewQuantityUnsafe(5), reserved())
+
+	// указанный, но несуществующий заказ не создаётся заново
+	_, err = c.UseCases.AddProductToOrder.Run(ctx, addProductRequest{orderID: uuid.New(), userID: user.ID.UUID(), productID: product.ID.UUID(), quantity: 1})
+	require.ErrorIs(t, err, entities.ErrOrderRecNotFound)
+	assert.Equal(t, vObject.NewQuantityUnsafe(5), reserved())
+
+	// жизненный цикл заказа
+	_, err = c.UseCases.ChangeOrderStatus.Run(ctx, changeStatusRequest{orderID: orderID, status: "shipped"})
+	require.ErrorIs(t, err, entities.ErrOrderStatusTransition)
+
+	// оплату подтверждает оператор, покупатель может только отменить свой заказ
+	_, err = c.UseCases.ChangeOrderStatus.Run(ctx, changeStatusRequest{orderID: orderID, actorID: user.ID.UUID(), status: "paid"})
+	require.ErrorIs(t, err, entities.ErrPermissionDenied)
+
+	operator := addUser(ctx, storage, vObject.RoleOperator)
+	order, err = c.UseCases.ChangeOrderStatus.Run(ctx, changeStatusRequest{orderID: orderID, actorID: operator.ID.UUID(), status: "paid"})
+	require.NoError(t, err)
+	assert.Equal(t, vObject.OrderStatusPaid, order.Status)
+
+	_, err = c.UseCases.ChangeOrderStatus.Run(ctx, changeStatusRequest{orderID: orderID, status: "canceled"})
+	require.NoError(t, err)
+
+	order, err = c.Queries.GetOrder.Handle(ctx, mustOrderQuery(t, orderID))
+	require.NoError(t, err)
+	assert.Equal(t, vObject.OrderStatusCanceled, order.Status)
+
+	historyQuery, err := getStatusHistory.NewQuery(orderID)
+	require.NoError(t, err)
+
+	history, err := c.Queries.GetOrderStatusHistory.Handle(ctx, *historyQuery)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Empty(t, history[0].From)
+	assert.Equal(t, vObject.OrderStatusCreated, history[0].To)
+	assert.Equal(t, &user.ID, history[0].ActorID)
+	assert.Equal(t, vObject.OrderStatusCreated, history[1].From)
+	assert.Equal(t, vObject.OrderStatusPaid, history[1].To)
+	assert.Equal(t, &operator.ID, history[1].ActorID)
+	assert.Equal(t, vObject.OrderStatusCanceled, history[2].To)
+	assert.Nil(t, history[2].ActorID)
+}
+
+func TestContainer_InMemoryStockTransfer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage := memory.NewStorage()
+
+	c, err := ioc.NewContainer(ioc.NewMemoryImplementations(storage))
+	require.NoError(t, err)
+
+	productID := vObject.NewProductIDFromUUIDUnsafe(uuid.New())
+	fromID := vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New())
+	toID := vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New())
+
+	storage.AddStock(ctx, entities.Stock{
+		ProductID:         productID,
+		WarehouseID:       fromID,
+		AvailableQuantity: vObject.NewQuantityUnsafe(5),
+		ReservedQuantity:  vObject.NewQuantityUnsafe(2),
+	})
+
+	stockAt := func(warehouseID vObject.WarehouseID) entities.Stock {
+		t.Helper()
+
+		productStocks, err := c.Queries.GetStocks.Handle(ctx, getStocks.NewQueryByProductIDUnsafe(productID))
+		require.NoError(t, err)
+
+		stock := productStocks.GetByWarehouseIDUnsafe(warehouseID)
+		require.NotNil(t, stock)
+
+		return *stock
+	}
+
+	operator := addUser(ctx, storage, vObject.RoleOperator)
+	req := transferStockRequest{actorID: operator.ID.UUID(), productID: productID.UUID(), fromID: fromID.UUID(), toID: toID.UUID(), quantity: 4}
+
+	// зарезервированный товар переместить нельзя
+	_, err = c.UseCases.TransferStock.Run(ctx, req)
+	require.ErrorIs(t, err, entities.ErrNotEnoughProductIntStocks)
+
+	req.quantity = 3
+	transfer, err := c.UseCases.TransferStock.Run(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, vObject.StockTransferStatusInTransit, transfer.Status)
+
+	assert.Equal(t, vObject.NewQuantityUnsafe(2), stockAt(fromID).AvailableQuantity)
+	assert.Equal(t, vObject.QuantityZero, stockAt(toID).AvailableQuantity)
+	assert.Equal(t, vObject.NewQuantityUnsafe(3), stockAt(toID).InTransitQuantity)
+
+	received, err := c.UseCases.ReceiveTransfer.Run(ctx, receiveTransferRequest{actorID: operator.ID.UUID(), transferID: transfer.ID.UUID()})
+	require.NoError(t, err)
+	assert.Equal(t, vObject.StockTransferStatusReceived, received.Status)
+	assert.NotNil(t, received.ReceivedAt)
+
+	assert.Equal(t, vObject.NewQuantityUnsafe(3), stockAt(toID).AvailableQuantity)
+	assert.Equal(t, vObject.QuantityZero, stockAt(toID).InTransitQuantity)
+
+	_, err = c.UseCases.ReceiveTransfer.Run(ctx, receiveTransferRequest{actorID: operator.ID.UUID(), transferID: transfer.ID.UUID()})
+	require.ErrorIs(t, err, entities.ErrStockTransferNotInTransit)
+
+	_, err = c.UseCases.ReceiveTransfer.Run(ctx, receiveTransferRequest{actorID: operator.ID.UUID(), transferID: uuid.New()})
+	require.ErrorIs(t, err, entities.ErrStockTransferRecNotFound)
+}
+
+func TestContainer_InMemoryStockIncomeWriteOff(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage := memory.NewStorage()
+
+	c, err := ioc.NewContainer(ioc.NewMemoryImplementations(storage))
+	require.NoError(t, err)
+
+	productID := vObject.NewProductIDFromUUIDUnsafe(uuid.New())
+	warehouseID := vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New())
+
+	stock := func() entities.Stock {
+		t.Helper()
+
+		productStocks, err := c.Queries.GetStocks.Handle(ctx, getStocks.NewQueryByProductIDUnsafe(productID))
+		require.NoError(t, err)
+		require.Len(t, productStocks, 1)
+
+		return productStocks[0]
+	}
+
+	operator := addUser(ctx, storage, vObject.RoleOperator)
+	income := incomeRequest{
+		actorID:     operator.ID.UUID(),
+		productID:   productID.UUID(),
+		warehouseID: warehouseID.UUID(),
+		quantity:    10,
+		price:       700,
+		reason:      "purchase",
+		documentRef: "INV-1",
+	}
+
+	// остатками управляют только операторы склада
+	customerIncome := income
+	customerIncome.actorID = addUser(ctx, storage, vObject.RoleCustomer).ID.UUID()
+	_, err = c.UseCases.IncomeStock.Run(ctx, customerIncome)
+	require.ErrorIs(t, err, entities.ErrPermissionDenied)
+
+	movement, err := c.UseCases.IncomeStock.Run(ctx, income)
+	require.NoError(t, err)
+	assert.Equal(t, vObject.OperationTypeIncome, movement.OperationType)
+	assert.Equal(t, vObject.NewPriceUnsafe(700), movement.Price)
+
+	_, err = c.UseCases.IncomeStock.Run(ctx, income)
+	require.NoError(t, err)
+	assert.Equal(t, vObject.NewQuantityUnsafe(20), stock().AvailableQuantity)
+
+	// резерв не списывается
+	reserved := stock()
+	reserved.ReservedQuantity = vObject.NewQuantityUnsafe(15)
+	storage.AddStock(ctx, reserved)
+
+	writeOff := writeOffRequest{
+		actorID:     operator.ID.UUID(),
+		productID:   productID.UUID(),
+		warehouseID: warehouseID.UUID(),
+		quantity:    6,
+		reason:      "damage",
+		documentRef: "ACT-1",
+	}
+
+	_, err = c.UseCases.WriteOffStock.Run(ctx, writeOff)
+	require.ErrorIs(t, err, entities.ErrWriteOffBelowReserved)
+	assert.Equal(t, vObject.NewQuantityUnsafe(20), stock().AvailableQuantity)
+
+	writeOff.quantity = 5
+	movement, err = c.UseCases.WriteOffStock.Run(ctx, writeOff)
+	require.NoError(t, err)
+	assert.Equal(t, vObject.MovementReasonDamage, *movement.Reason)
+	assert.Equal(t, vObject.NewQuantityUnsafe(15), stock().AvailableQuantity)
+}
+
+func TestContainer_InMemoryRoles(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage := memory.NewStorage()
+
+	c, err := ioc.NewContainer(ioc.NewMemoryImplementations(storage))
+	require.NoError(t, err)
+
+	admin := addUser(ctx, storage, vObject.RoleAdmin)
+
+	user, err := c.UseCases.UserRegistration.Run(ctx, registrationRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, vObject.RoleCustomer, user.Role)
+
+	income := incomeRequest{
+		actorID:     user.ID.UUID(),
+		productID:   uuid.New(),
+		warehouseID: uuid.New(),
+		quantity:    1,
+		reason:      "purchase",
+		documentRef: "INV-1",
+	}
+
+	_, err = c.UseCases.IncomeStock.Run(ctx, income)
+	require.ErrorIs(t, err, entities.ErrPermissionDenied)
+
+	// назначать роли может только администратор
+	_, err = c.UseCases.GrantRole.Run(ctx, grantRoleRequest{actorID: user.ID.UUID(), userID: user.ID.UUID(), role: "admin"})
+	require.ErrorIs(t, err, entities.ErrPermissionDenied)
+
+	_, err = c.UseCases.GrantRole.Run(ctx, grantRoleRequest{actorID: admin.ID.UUID(), userID: user.ID.UUID(), role: "root"})
+	require.ErrorIs(t, err, vObject.ErrUnknownRole)
+
+	_, err = c.UseCases.GrantRole.Run(ctx, grantRoleRequest{actorID: admin.ID.UUID(), userID: admin.ID.UUID(), role: "customer"})
+	require.ErrorIs(t, err, entities.ErrOwnRoleChange)
+
+	_, err = c.UseCases.GrantRole.Run(ctx, grantRoleRequest{actorID: admin.ID.UUID(), userID: uuid.New(), role: "operator"})
+	require.ErrorIs(t, err, entities.ErrUserRecNotFound)
+
+	granted, err := c.UseCases.GrantRole.Run(ctx, grantRoleRequest{actorID: admin.ID.UUID(), userID: user.ID.UUID(), role: "operator"})
+	require.NoError(t, err)
+	assert.Equal(t, vObject.RoleOperator, granted.Role)
+
+	_, err = c.UseCases.IncomeStock.Run(ctx, income)
+	require.NoError(t, err)
+}
+
+func TestContainer_InMemoryProducts(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage := memory.NewStorage()
+
+	c, err := ioc.NewContainer(ioc.NewMemoryImplementations(storage))
+	require.NoError(t, err)
+
+	operator := addUser(ctx, storage, vObject.RoleOperator)
+	customer := addUser(ctx, storage, vObject.RoleCustomer)
+
+	req := productRequest{actorID: customer.ID.UUID(), title: "Молоко", tags: []string{"Молочное"}, price: 8900}
+	_, err = c.UseCases.AddProduct.Run(ctx, req)
+	require.ErrorIs(t, err, entities.ErrPermissionDenied)
+
+	req.actorID, req.title = operator.ID.UUID(), " "
+	_, err = c.UseCases.AddProduct.Run(ctx, req)
+	require.ErrorIs(t, err, vObject.ErrEmptyProductTitle)
+
+	req.title = "Молоко"
+	product, err := c.UseCases.AddProduct.Run(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, vObject.Tags{"молочное"}, product.Tags)
+
+	priceAt := func(at time.Time) (vObject.Price, error) {
+		query, err := getPrice.NewQuery(product.ID.UUID(), at)
+		require.NoError(t, err)
+
+		price, err := c.Queries.GetProductPrice.Handle(ctx, *query)
+		if err != nil {
+			return 0, err
+		}
+
+		return price.Price, nil
+	}
+
+	req.productID, req.price = product.ID.UUID(), 9500
+	edited, err := c.UseCases.EditProduct.Run(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, vObject.NewPriceUnsafe(9500), edited.Price)
+
+	// история цен начинается с создания товара, новая цена действует с момента изменения
+	_, err = priceAt(product.CreatedAt.Add(-time.Second))
+	require.ErrorIs(t, err, entities.ErrProductPriceRecNotFound)
+
+	price, err := priceAt(time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, vObject.NewPriceUnsafe(9500), price)
+
+	_, err = c.UseCases.IncomeStock.Run(ctx, incomeRequest{
+		actorID:     operator.ID.UUID(),
+		productID:   product.ID.UUID(),
+		warehouseID: uuid.New(),
+		quantity:    5,
+		reason:      "purchase",
+		documentRef: "INV-1",
+	})
+	require.NoError(t, err)
+
+	order, err := c.UseCases.AddProductToOrder.Run(ctx, addProductRequest{
+		userID:    customer.ID.UUID(),
+		productID: product.ID.UUID(),
+		quantity:  1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, vObject.NewPriceUnsafe(9500), order.TotalPrice)
+
+	// по умолчанию позиция заказа сохраняет цену первого добавления товара
+	req.price = 9900
+	_, err = c.UseCases.EditProduct.Run(ctx, req)
+	require.NoError(t, err)
+
+	order, err = c.UseCases.AddProductToOrder.Run(ctx, addProductRequest{
+		orderID:   order.ID.UUID(),
+		userID:    customer.ID.UUID(),
+		productID: product.ID.UUID(),
+		quantity:  2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, vObject.NewPriceUnsafe(19000), order.TotalPrice)
+
+	list := func(f listProducts.Filter) (entities.Products, *vObject.Meta) {
+		query, err := listProducts.NewQuery(f)
+		require.NoError(t, err)
+
+		products, meta, err := c.Queries.ListProducts.Handle(ctx, *query)
+		require.NoError(t, err)
+
+		return products, meta
+	}
+
+	products, meta := list(listProducts.Filter{Tags: []string{"Молочное"}, InStockOnly: true, Search: "молоко"})
+	require.Len(t, products, 1)
+	assert.Equal(t, vObject.NewPriceUnsafe(9900), products[0].Price)
+	assert.Equal(t, vObject.MetaTotal(1), meta.Total)
+
+	archive := productRequest{actorID: customer.ID.UUID(), productID: product.ID.UUID()}
+	require.ErrorIs(t, c.UseCases.ArchiveProduct.Run(ctx, archive), entities.ErrPermissionDenied)
+
+	archive.actorID = operator.ID.UUID()
+	require.NoError(t, c.UseCases.ArchiveProduct.Run(ctx, archive))
+	require.ErrorIs(t, c.UseCases.ArchiveProduct.Run(ctx, archive), entities.ErrProductRecNotFound)
+
+	products, meta = list(listProducts.Filter{})
+	assert.Empty(t, products)
+	assert.Equal(t, vObject.MetaTotal(0), meta.Total)
+
+	// архивный товар не меняется и не добавляется в заказы, оформленный заказ остаётся как был
+	_, err = c.UseCases.EditProduct.Run(ctx, req)
+	require.ErrorIs(t, err, entities.ErrProductRecNotFound)
+
+	_, err = c.UseCases.AddProductToOrder.Run(ctx, addProductRequest{
+		userID:    customer.ID.UUID(),
+		productID: product.ID.UUID(),
+		quantity:  1,
+	})
+	require.ErrorIs(t, err, entities.ErrProductRecNotFound)
+
+	query, err := getOrder.NewQuery(order.ID.UUID())
+	require.NoError(t, err)
+
+	got, err := c.Queries.GetOrder.Handle(ctx, *query)
+	require.NoError(t, err)
+	assert.Equal(t, order.TotalPrice, got.TotalPrice)
+	require.Len(t, got.Products, 1)
+}
+
+func TestContainer_InMemoryOrderHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage := memory.NewStorage()
+
+	c, err := ioc.NewContainer(ioc.NewMemoryImplementations(storage))
+	require.NoError(t, err)
+
+	customer := addUser(ctx, storage, vObject.RoleCustomer)
+	other := addUser(ctx, storage, vObject.RoleCustomer)
+	operator := addUser(ctx, storage, vObject.RoleOperator)
+
+	product := entities.Product{
+		ID:    vObject.NewProductIDFromUUIDUnsafe(uuid.New()),
+		Title: vObject.NewProductTitleUnsafe("product"),
+		Price: vObject.NewPriceUnsafe(1000),
+	}
+	storage.AddProduct(ctx, product)
+	storage.AddStock(ctx, entities.Stock{
+		ProductID:         product.ID,
+		WarehouseID:       vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New()),
+		AvailableQuantity: vObject.NewQuantityUnsafe(10),
+	})
+
+	var orderIDs []vObject.OrderID
+
+	for _, user := range []entities.User{customer, customer, other} {
+		order, err := c.UseCases.AddProductToOrder.Run(ctx, addProductRequest{userID: user.ID.UUID(), productID: product.ID.UUID(), quantity: 1})
+		require.NoError(t, err)
+
+		orderIDs = append(orderIDs, order.ID)
+	}
+
+	_, err = c.UseCases.ChangeOrderStatus.Run(ctx, changeStatusRequest{orderID: orderIDs[0].UUID(), status: "canceled", actorID: customer.ID.UUID()})
+	require.NoError(t, err)
+
+	// покупатель видит только свои заказы
+	orders, meta, err := c.UseCases.OrderHistory.Run(ctx, orderHistoryRequest{actorID: customer.ID.UUID(), userID: customer.ID.UUID()})
+	require.NoError(t, err)
+	assert.Len(t, orders, 2)
+	assert.Equal(t, vObject.MetaTotal(2), meta.Total)
+	assert.Empty(t, orders[0].Products)
+
+	orders, _, err = c.UseCases.OrderHistory.Run(ctx, orderHistoryRequest{
+		actorID:      customer.ID.UUID(),
+		userID:       customer.ID.UUID(),
+		statuses:     []string{"created"},
+		withProducts: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Equal(t, orderIDs[1], orders[0].ID)
+	require.Len(t, orders[0].Products, 1)
+	require.NotNil(t, orders[0].Products[0].Product)
+	assert.Equal(t, product.Title, orders[0].Products[0].Product.Title)
+
+	_, _, err = c.UseCases.OrderHistory.Run(ctx, orderHistoryRequest{actorID: customer.ID.UUID(), userID: other.ID.UUID()})
+	require.ErrorIs(t, err, entities.ErrPermissionDenied)
+
+	orders, _, err = c.UseCases.OrderHistory.Run(ctx, orderHistoryRequest{actorID: operator.ID.UUID(), userID: other.ID.UUID()})
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Equal(t, orderIDs[2], orders[0].ID)
+
+	// листание курсором вперёд и обратно
+	page := func(cursor string) (entities.Orders, *vObject.Meta) {
+		t.Helper()
+
+		orders, meta, err := c.UseCases.OrderHistory.Run(ctx, orderHistoryRequest{
+			actorID: customer.ID.UUID(),
+			userID:  customer.ID.UUID(),
+			perPage: 1,
+			cursor:  &cursor,
+		})
+		require.NoError(t, err)
+		require.Len(t, orders, 1)
+
+		return orders, meta
+	}
+
+	first, meta := page("")
+	assert.Empty(t, meta.PrevCursor)
+	require.NotEmpty(t, meta.NextCursor)
+	assert.Equal(t, vObject.MetaTotal(0), meta.Total)
+
+	second, meta := page(meta.NextCursor.String())
+	assert.NotEqual(t, first[0].ID, second[0].ID)
+	assert.Empty(t, meta.NextCursor)
+	require.NotEmpty(t, meta.PrevCursor)
+
+	back, meta := page(meta.PrevCursor.String())
+	assert.Equal(t, first[0].ID, back[0].ID)
+	assert.Empty(t, meta.PrevCursor)
+	assert.NotEmpty(t, meta.NextCursor)
+}
+
+func TestContainer_InMemoryAuth(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	c, err := ioc.NewContainer(ioc.NewMemoryImplementations(memory.NewStorage()))
+	require.NoError(t, err)
+
+	user, err := c.UseCases.UserRegistration.Run(ctx, registrationRequest{})
+	require.NoError(t, err)
+
+	_, err = c.UseCases.LoginUser.Run(ctx, loginRequest{password: "wrong_password"})
+	require.ErrorIs(t, err, entities.ErrInvalidCredentials)
+
+	pair, err := c.UseCases.LoginUser.Run(ctx, loginRequest{password: registrationRequest{}.GetPassword()})
+	require.NoError(t, err)
+
+	claims, err := c.UseCases.Authenticate.Run(ctx, tokenRequest(pair.AccessToken))
+	require.NoError(t, err)
+	assert.Equal(t, user.ID.UUID(), claims.UserID)
+
+	// токен обновления одноразовый: повторное использование отзывает сессию
+	refreshed, err := c.UseCases.RefreshSession.Run(ctx, tokenRequest(pair.RefreshToken))
+	require.NoError(t, err)
+
+	_, err = c.UseCases.RefreshSession.Run(ctx, tokenRequest(pair.RefreshToken))
+	require.ErrorIs(t, err, entities.ErrRefreshTokenReused)
+
+	_, err = c.UseCases.Authenticate.Run(ctx, tokenRequest(refreshed.AccessToken))
+	require.ErrorIs(t, err, entities.ErrSessionInactive)
+
+	// выход отзывает сессию
+	pair, err = c.UseCases.LoginUser.Run(ctx, loginRequest{password: registrationRequest{}.GetPassword()})
+	require.NoError(t, err)
+
+	claims, err = c.UseCases.Authenticate.Run(ctx, tokenRequest(pair.AccessToken))
+	require.NoError(t, err)
+
+	require.NoError(t, c.UseCases.RevokeSession.Run(ctx, revokeRequest{sessionID: claims.SessionID, userID: claims.UserID}))
+
+	_, err = c.UseCases.Authenticate.Run(ctx, tokenRequest(pair.AccessToken))
+	require.ErrorIs(t, err, entities.ErrSessionInactive)
+
+	// после серии неудачных попыток вход блокируется даже с верным паролем
+	for range vObject.DefaultRules().MaxFailedLogins {
+		_, err = c.UseCases.LoginUser.Run(ctx, loginRequest{password: "wrong_password"})
+		require.ErrorIs(t, err, entities.ErrInvalidCredentials)
+	}
+
+	_, err = c.UseCases.LoginUser.Run(ctx, loginRequest{password: registrationRequest{}.GetPassword()})
+	require.ErrorIs(t, err, entities.ErrUserLocked)
+}
+
+func TestContainer_InMemoryEmailVerificationAndPasswordReset(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mailer := mail.NewMemory()
+
+	c, err := ioc.NewContainer(
+		ioc.NewMemoryImplementations(memory.NewStorage()),
+		ioc.WithMailer(mailer),
+		ioc.WithEmailVerificationURL("https://shop.local/verify?token="),
+		ioc.WithPasswordResetURL("https://shop.local/reset?token="),
+	)
+	require.NoError(t, err)
+
+	// секрет токена — всё, что в письме идёт за адресом страницы до конца строки
+	secretFrom := func(linkURL string) string {
+		t.Helper()
+
+		msg, ok := mailer.Last(registrationRequest{}.GetEmail())
+		require.True(t, ok)
+
+		_, rest, found := strings.Cut(msg.Body, linkURL)
+		require.True(t, found)
+
+		secret, _, _ := strings.Cut(rest, "\n")
+
+		return secret
+	}
+
+	user, err := c.UseCases.UserRegistration.Run(ctx, registrationRequest{})
+	require.NoError(t, err)
+	assert.False(t, user.IsEmailVerified())
+
+	// неизвестный адрес не раскрывается
+	require.NoError(t, c.UseCases.RequestEmailVerification.Run(ctx, emailRequest("unknown@test.ru")))
+	assert.Empty(t, mailer.Messages())
+
+	// подтверждение email
+	require.NoError(t, c.UseCases.RequestEmailVerification.Run(ctx, emailRequest(registrationRequest{}.GetEmail())))
+	verifySecret := secretFrom("https://shop.local/verify?token=")
+
+	require.ErrorIs(t, c.UseCases.VerifyEmail.Run(ctx, redeemRequest{token: "wrong"}), entities.ErrUserTokenInvalid)
+	require.NoError(t, c.UseCases.VerifyEmail.Run(ctx, redeemRequest{token: verifySecret}))
+	require.ErrorIs(t, c.UseCases.VerifyEmail.Run(ctx, redeemRequest{token: verifySecret}), entities.ErrUserTokenInvalid)
+
+	// токен подтверждения не подходит для сброса пароля
+	require.ErrorIs(t,
+		c.UseCases.ResetPassword.Run(ctx, redeemRequest{token: verifySecret, password: "new_secret_password"}),
+		entities.ErrUserTokenInvalid,
+	)
+
+	// сессия, открытая до сброса пароля
+	pair, err := c.UseCases.LoginUser.Run(ctx, loginRequest{password: registrationRequest{}.GetPassword()})
+	require.NoError(t, err)
+
+	// сброс пароля: из двух запрошенных токенов действует любой, пока пароль не сброшен
+	require.NoError(t, c.UseCases.RequestPasswordReset.Run(ctx, emailRequest(registrationRequest{}.GetEmail())))
+	staleSecret := secretFrom("https://shop.local/reset?token=")
+
+	require.NoError(t, c.UseCases.RequestPasswordReset.Run(ctx, emailRequest(registrationRequest{}.GetEmail())))
+	resetSecret := secretFrom("https://shop.local/reset?token=")
+	require.NotEqual(t, staleSecret, resetSecret)
+
+	// слишком короткий пароль не погашает токен
+	require.ErrorIs(t,
+		c.UseCases.ResetPassword.Run(ctx, redeemRequest{token: resetSecret, password: "short"}),
+		vObject.ErrPasswordLen,
+	)
+	require.NoError(t, c.UseCases.ResetPassword.Run(ctx, redeemRequest{token: resetSecret, password: "new_secret_password"}))
+	require.ErrorIs(t,
+		c.UseCases.ResetPassword.Run(ctx, redeemRequest{token: resetSecret, password: "new_secret_password"}),
+		entities.ErrUserTokenInvalid,
+	)
+
+	// сброс пароля погашает остальные токены сброса и отзывает открытые сессии
+	require.ErrorIs(t,
+		c.UseCases.ResetPassword.Run(ctx, redeemRequest{token: staleSecret, password: "other_secret_password"}),
+		entities.ErrUserTokenInvalid,
+	)
+
+	_, err = c.UseCases.Authenticate.Run(ctx, tokenRequest(pair.AccessToken))
+	require.ErrorIs(t, err, entities.ErrSessionInactive)
+
+	_, err = c.UseCases.RefreshSession.Run(ctx, tokenRequest(pair.RefreshToken))
+	require.ErrorIs(t, err, entities.ErrSessionInactive)
+
+	_, err = c.UseCases.LoginUser.Run(ctx, loginRequest{password: registrationRequest{}.GetPassword()})
+	require.ErrorIs(t, err, entities.ErrInvalidCredentials)
+
+	_, err = c.UseCases.LoginUser.Run(ctx, loginRequest{password: "new_secret_password"})
+	require.NoError(t, err)
+}
+
+func mustOrderQuery(t *testing.T, orderID uuid.UUID) getOrder.Query {
+	t.Helper()
+
+	query, err := getOrder.NewQueryForUpdate(orderID)
+	require.NoError(t, err)
+
+	return *query
+}
+
+// fixedUUID отдаёт заданный идентификатор первым (для заказа), остальные генерирует случайно.
+type fixedUUID struct {
+	first uuid.UUID
+	used  bool
+}
+
+func (f *fixedUUID) UUID() uuid.UUID {
+	// остальные идентификаторы упорядочены по времени, как у генератора по умолчанию
+	if f.used {
+		return uuid.Must(uuid.NewV7())
+	}
+
+	f.used = true
+
+	return f.first
+}
+
+// тест изменяет глобальный TracerProvider, поэтому не параллельный
+func TestContainer_InMemoryTracing(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctx := context.Background()
+
+	c, err := ioc.NewContainer(ioc.NewMemoryImplementations(memory.NewStorage()))
+	require.NoError(t, err)
+
+	_, err = c.UseCases.UserRegistration.Run(ctx, registrationRequest{})
+	require.NoError(t, err)
+
+	_, err = c.UseCases.UserRegistration.Run(ctx, registrationRequest{})
+	require.ErrorIs(t, err, entities.ErrUserAlreadyExists)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 5)
+
+	names := make([]string, 0, len(spans))
+	for _, span := range spans {
+		names = append(names, span.Name())
+	}
+
+	assert.Equal(t, []string{
+		"query.getUserByEmail", "command.createUser", "usecase.userRegistration",
+		"query.getUserByEmail", "usecase.userRegistration",
+	}, names)
+
+	// спаны команд и запросов — дочерние спаны юзкейса
+	root := spans[2]
+	for _, span := range spans[:2] {
+		assert.Equal(t, root.SpanContext().TraceID(), span.SpanContext().TraceID())
+		assert.Equal(t, root.SpanContext().SpanID(), span.Parent().SpanID())
+	}
+
+	assert.Equal(t, codes.Unset, root.Status().Code)
+	assert.Equal(t, codes.Error, spans[4].Status().Code)
+}
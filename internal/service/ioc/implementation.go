@@ -6,27 +6,77 @@ import (
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/application"
 	upsertOrder "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order/upsert"
 	upsertOrderProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order_product/upsert"
+	createOrderStatusHistory "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order_status_history/create"
+	createProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product/create"
+	updateProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product/update"
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	createProductPrice "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_price/create"
+	revokeUserSessions "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/revoke_by_user"
+	upsertSession "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/upsert"
+	createStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
+	upsertStockTransfer "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock_transfer/upsert"
 	createUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/create"
+	updateUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/update"
+	invalidateUserTokens "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/invalidate"
+	upsertUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/upsert"
 	getOrderByID "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_order"
+	getStatusHistory "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_status_history"
 	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	listOrders "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/list_orders"
+	getMovements "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_movements"
+	getPrice "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_price"
 	getProduct "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_product"
+	listProducts "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/list_products"
+	getSession "github.com/smgladkovskiy/warehouse-task/internal/service/queries/session/get_session"
+	getTransfer "github.com/smgladkovskiy/warehouse-task/internal/service/queries/stock_transfer/get_transfer"
 	getUserByEmail "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_by_email"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	getUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user_token/get_user_token"
 	orderProducts "github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/order_product"
+	orderStatusHistory "github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/order_status_history"
 	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/orders"
+	productMovements "github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/product_movements"
+	productPrices "github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/product_prices"
 	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/products"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/sessions"
+	stockTransfers "github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/stock_transfers"
 	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/stocks"
+	userTokens "github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/user_tokens"
 	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/users"
 )
 
 type Implementationable interface {
 	OrderGetter() getOrderByID.OrderGetter
+	OrderStatusHistoryGetter() getStatusHistory.OrderStatusHistoryGetter
 	StocksGetter() getStocks.StocksGetter
+	OrdersLister() listOrders.OrdersLister
 	ProductGetter() getProduct.ProductGetter
+	ProductMovementsGetter() getMovements.ProductMovementsGetter
+	ProductPriceGetter() getPrice.ProductPriceGetter
+	ProductsLister() listProducts.ProductsLister
+	StockTransferGetter() getTransfer.StockTransferGetter
 	UserGetter() getUserByEmail.UserGetter
+	UserByIDGetter() getUser.UserByIDGetter
+	UserTokenGetter() getUserToken.UserTokenGetter
+	SessionGetter() getSession.SessionGetter
 
 	OrderUpserter() upsertOrder.OrderUpserter
 	OrderProductUpserter() upsertOrderProduct.OrderProductUpserter
+	OrderStatusHistoryCreator() createOrderStatusHistory.OrderStatusHistoryCreator
+	ProductCreator() createProduct.ProductCreator
+	ProductUpdater() updateProduct.ProductUpdater
+	ProductMovementsCreator() createProductMovements.ProductMovementsCreator
+	ProductPriceCreator() createProductPrice.ProductPriceCreator
+	StockCreator() createStock.StockCreator
+	StockUpdater() updateStock.StockUpdater
+	StockTransferUpserter() upsertStockTransfer.StockTransferUpserter
 	UserCreator() createUser.UserCreator
+	UserUpdater() updateUser.UserUpdater
+	SessionUpserter() upsertSession.SessionUpserter
+	UserSessionsRevoker() revokeUserSessions.UserSessionsRevoker
+	UserTokenUpserter() upsertUserToken.UserTokenUpserter
+	UserTokensInvalidator() invalidateUserTokens.UserTokensInvalidator
 	TransactionManager() trm.Manager
 }
 
@@ -35,19 +85,32 @@ type Implementations struct {
 	orderRepo        *orders.Repository
 	stockRepo        *stocks.Repository
 	productRepo      *products.Repository
+	priceRepo        *productPrices.Repository
 	userRepo         *users.Repository
+	sessionRepo      *sessions.Repository
+	userTokenRepo    *userTokens.Repository
 	orderProductRepo *orderProducts.Repository
+	orderHistoryRepo *orderStatusHistory.Repository
+	movementRepo     *productMovements.Repository
+	transferRepo     *stockTransfers.Repository
 }
 
 var _ Implementationable = (*Implementations)(nil)
 
 func NewImplementations(app *application.App) *Implementations {
 	return &Implementations{
-		orderRepo:   orders.NewRepository(app.DB, app.TrxGetter),
-		stockRepo:   stocks.NewRepository(app.DB, app.TrxGetter),
-		productRepo: products.NewRepository(app.DB, app.TrxGetter),
-		userRepo:    users.NewRepository(app.DB, app.TrxGetter),
-		txManager:   app.TxManager,
+		orderRepo:        orders.NewRepository(app.DB, app.TrxGetter),
+		stockRepo:        stocks.NewRepository(app.DB, app.TrxGetter),
+		productRepo:      products.NewRepository(app.DB, app.TrxGetter),
+		priceRepo:        productPrices.NewRepository(app.DB, app.TrxGetter),
+		userRepo:         users.NewRepository(app.DB, app.TrxGetter),
+		sessionRepo:      sessions.NewRepository(app.DB, app.TrxGetter),
+		userTokenRepo:    userTokens.NewRepository(app.DB, app.TrxGetter),
+		orderProductRepo: orderProducts.NewRepository(app.DB, app.TrxGetter),
+		orderHistoryRepo: orderStatusHistory.NewRepository(app.DB, app.TrxGetter),
+		movementRepo:     productMovements.NewRepository(app.DB, app.TrxGetter),
+		transferRepo:     stockTransfers.NewRepository(app.DB, app.TrxGetter),
+		txManager:        app.TxManager,
 	}
 }
 
@@ -55,18 +118,54 @@ func (i *Implementations) OrderGetter() getOrderByID.OrderGetter {
 	return i.orderRepo
 }
 
+func (i *Implementations) OrderStatusHistoryGetter() getStatusHistory.OrderStatusHistoryGetter {
+	return i.orderHistoryRepo
+}
+
 func (i *Implementations) StocksGetter() getStocks.StocksGetter {
 	return i.stockRepo
 }
 
+func (i *Implementations) OrdersLister() listOrders.OrdersLister {
+	return i.orderRepo
+}
+
 func (i *Implementations) ProductGetter() getProduct.ProductGetter {
 	return i.productRepo
 }
 
+func (i *Implementations) ProductMovementsGetter() getMovements.ProductMovementsGetter {
+	return i.movementRepo
+}
+
+func (i *Implementations) ProductPriceGetter() getPrice.ProductPriceGetter {
+	return i.priceRepo
+}
+
+func (i *Implementations) ProductsLister() listProducts.ProductsLister {
+	return i.productRepo
+}
+
+func (i *Implementations) StockTransferGetter() getTransfer.StockTransferGetter {
+	return i.transferRepo
+}
+
 func (i *Implementations) UserGetter() getUserByEmail.UserGetter {
 	return i.userRepo
 }
 
+func (i *Implementations) UserByIDGetter() getUser.UserByIDGetter {
+	return i.userRepo
+}
+
+func (i *Implementations) UserTokenGetter() getUserToken.UserTokenGetter {
+	return i.userTokenRepo
+}
+
+func (i *Implementations) SessionGetter() getSession.SessionGetter {
+	return i.sessionRepo
+}
+
 func (i *Implementations) OrderUpserter() upsertOrder.OrderUpserter {
 	return i.orderRepo
 }
@@ -75,10 +174,62 @@ func (i *Implementations) OrderProductUpserter() upsertOrderProduct.OrderProduct
 	return i.orderProductRepo
 }
 
+func (i *Implementations) OrderStatusHistoryCreator() createOrderStatusHistory.OrderStatusHistoryCreator {
+	return i.orderHistoryRepo
+}
+
+func (i *Implementations) ProductCreator() createProduct.ProductCreator {
+	return i.productRepo
+}
+
+func (i *Implementations) ProductUpdater() updateProduct.ProductUpdater {
+	return i.productRepo
+}
+
+func (i *Implementations) ProductMovementsCreator() createProductMovements.ProductMovementsCreator {
+	return i.movementRepo
+}
+
+func (i *Implementations) ProductPriceCreator() createProductPrice.ProductPriceCreator {
+	return i.priceRepo
+}
+
+func (i *Implementations) StockCreator() createStock.StockCreator {
+	return i.stockRepo
+}
+
+func (i *Implementations) StockUpdater() updateStock.StockUpdater {
+	return i.stockRepo
+}
+
+func (i *Implementations) StockTransferUpserter() upsertStockTransfer.StockTransferUpserter {
+	return i.transferRepo
+}
+
 func (i *Implementations) UserCreator() createUser.UserCreator {
 	return i.userRepo
 }
 
+func (i *Implementations) UserUpdater() updateUser.UserUpdater {
+	return i.userRepo
+}
+
+func (i *Implementations) SessionUpserter() upsertSession.SessionUpserter {
+	return i.sessionRepo
+}
+
+func (i *Implementations) UserSessionsRevoker() revokeUserSessions.UserSessionsRevoker {
+	return i.sessionRepo
+}
+
+func (i *Implementations) UserTokenUpserter() upsertUserToken.UserTokenUpserter {
+	return i.userTokenRepo
+}
+
+func (i *Implementations) UserTokensInvalidator() invalidateUserTokens.UserTokensInvalidator {
+	return i.userTokenRepo
+}
+
 func (i *Implementations) TransactionManager() trm.Manager {
 	return i.txManager
 }
@@ -0,0 +1,191 @@
+package ioc
+
+import (
+	"github.com/avito-tech/go-transaction-manager/trm"
+
+	upsertOrder "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order/upsert"
+	upsertOrderProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order_product/upsert"
+	createOrderStatusHistory "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order_status_history/create"
+	createProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product/create"
+	updateProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product/update"
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	createProductPrice "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_price/create"
+	revokeUserSessions "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/revoke_by_user"
+	upsertSession "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/upsert"
+	createStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
+	upsertStockTransfer "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock_transfer/upsert"
+	createUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/create"
+	updateUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/update"
+	invalidateUserTokens "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/invalidate"
+	upsertUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/upsert"
+	getOrderByID "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_order"
+	getStatusHistory "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_status_history"
+	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	listOrders "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/list_orders"
+	getMovements "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_movements"
+	getPrice "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_price"
+	getProduct "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_product"
+	listProducts "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/list_products"
+	getSession "github.com/smgladkovskiy/warehouse-task/internal/service/queries/session/get_session"
+	getTransfer "github.com/smgladkovskiy/warehouse-task/internal/service/queries/stock_transfer/get_transfer"
+	getUserByEmail "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_by_email"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	getUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user_token/get_user_token"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/memory"
+)
+
+// MemoryImplementations собирает in-memory репозитории для тестов и локального запуска без postgres.
+type MemoryImplementations struct {
+	txManager        *memory.Manager
+	orderRepo        *memory.OrderRepository
+	stockRepo        *memory.StockRepository
+	productRepo      *memory.ProductRepository
+	priceRepo        *memory.ProductPriceRepository
+	userRepo         *memory.UserRepository
+	sessionRepo      *memory.SessionRepository
+	userTokenRepo    *memory.UserTokenRepository
+	orderProductRepo *memory.OrderProductRepository
+	orderHistoryRepo *memory.OrderStatusHistoryRepository
+	movementRepo     *memory.ProductMovementRepository
+	transferRepo     *memory.StockTransferRepository
+}
+
+var _ Implementationable = (*MemoryImplementations)(nil)
+
+func NewMemoryImplementations(storage *memory.Storage) *MemoryImplementations {
+	return &MemoryImplementations{
+		orderRepo:        memory.NewOrderRepository(storage),
+		stockRepo:        memory.NewStockRepository(storage),
+		productRepo:      memory.NewProductRepository(storage),
+		priceRepo:        memory.NewProductPriceRepository(storage),
+		userRepo:         memory.NewUserRepository(storage),
+		sessionRepo:      memory.NewSessionRepository(storage),
+		userTokenRepo:    memory.NewUserTokenRepository(storage),
+		orderProductRepo: memory.NewOrderProductRepository(storage),
+		orderHistoryRepo: memory.NewOrderStatusHistoryRepository(storage),
+		movementRepo:     memory.NewProductMovementRepository(storage),
+		transferRepo:     memory.NewStockTransferRepository(storage),
+		txManager:        memory.NewManager(storage),
+	}
+}
+
+func (i *MemoryImplementations) OrderGetter() getOrderByID.OrderGetter {
+	return i.orderRepo
+}
+
+func (i *MemoryImplementations) OrderStatusHistoryGetter() getStatusHistory.OrderStatusHistoryGetter {
+	return i.orderHistoryRepo
+}
+
+func (i *MemoryImplementations) StocksGetter() getStocks.StocksGetter {
+	return i.stockRepo
+}
+
+func (i *MemoryImplementations) OrdersLister() listOrders.OrdersLister {
+	return i.orderRepo
+}
+
+func (i *MemoryImplementations) ProductGetter() getProduct.ProductGetter {
+	return i.productRepo
+}
+
+func (i *MemoryImplementations) ProductMovementsGetter() getMovements.ProductMovementsGetter {
+	return i.movementRepo
+}
+
+func (i *MemoryImplementations) ProductPriceGetter() getPrice.ProductPriceGetter {
+	return i.priceRepo
+}
+
+func (i *MemoryImplementations) ProductsLister() listProducts.ProductsLister {
+	return i.productRepo
+}
+
+func (i *MemoryImplementations) StockTransferGetter() getTransfer.StockTransferGetter {
+	return i.transferRepo
+}
+
+func (i *MemoryImplementations) UserGetter() getUserByEmail.UserGetter {
+	return i.userRepo
+}
+
+func (i *MemoryImplementations) UserByIDGetter() getUser.UserByIDGetter {
+	return i.userRepo
+}
+
+func (i *MemoryImplementations) UserTokenGetter() getUserToken.UserTokenGetter {
+	return i.userTokenRepo
+}
+
+func (i *MemoryImplementations) SessionGetter() getSession.SessionGetter {
+	return i.sessionRepo
+}
+
+func (i *MemoryImplementations) OrderUpserter() upsertOrder.OrderUpserter {
+	return i.orderRepo
+}
+
+func (i *MemoryImplementations) OrderProductUpserter() upsertOrderProduct.OrderProductUpserter {
+	return i.orderProductRepo
+}
+
+func (i *MemoryImplementations) OrderStatusHistoryCreator() createOrderStatusHistory.OrderStatusHistoryCreator {
+	return i.orderHistoryRepo
+}
+
+func (i *MemoryImplementations) ProductCreator() createProduct.ProductCreator {
+	return i.productRepo
+}
+
+func (i *MemoryImplementations) ProductUpdater() updateProduct.ProductUpdater {
+	return i.productRepo
+}
+
+func (i *MemoryImplementations) ProductMovementsCreator() createProductMovements.ProductMovementsCreator {
+	return i.movementRepo
+}
+
+func (i *MemoryImplementations) ProductPriceCreator() createProductPrice.ProductPriceCreator {
+	return i.priceRepo
+}
+
+func (i *MemoryImplementations) StockCreator() createStock.StockCreator {
+	return i.stockRepo
+}
+
+func (i *MemoryImplementations) StockUpdater() updateStock.StockUpdater {
+	return i.stockRepo
+}
+
+func (i *MemoryImplementations) StockTransferUpserter() upsertStockTransfer.StockTransferUpserter {
+	return i.transferRepo
+}
+
+func (i *MemoryImplementations) UserCreator() createUser.UserCreator {
+	return i.userRepo
+}
+
+func (i *MemoryImplementations) UserUpdater() updateUser.UserUpdater {
+	return i.userRepo
+}
+
+func (i *MemoryImplementations) SessionUpserter() upsertSession.SessionUpserter {
+	return i.sessionRepo
+}
+
+func (i *MemoryImplementations) UserSessionsRevoker() revokeUserSessions.UserSessionsRevoker {
+	return i.sessionRepo
+}
+
+func (i *MemoryImplementations) UserTokenUpserter() upsertUserToken.UserTokenUpserter {
+	return i.userTokenRepo
+}
+
+func (i *MemoryImplementations) UserTokensInvalidator() invalidateUserTokens.UserTokensInvalidator {
+	return i.userTokenRepo
+}
+
+func (i *MemoryImplementations) TransactionManager() trm.Manager {
+	return i.txManager
+}
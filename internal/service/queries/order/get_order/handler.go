@@ -3,6 +3,7 @@ package getorder
 import (
 	"context"
 
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
 	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
 	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
 )
@@ -25,5 +26,10 @@ func NewQueryHandler(repo OrderGetter) *QueryHandler {
 }
 
 func (h *QueryHandler) Handle(ctx context.Context, q Query) (*entities.Order, error) {
-	return h.repo.GetOrder(ctx, queryOptions.NewOrderQueryOptions(q.qos...))
+	ctx, span := tracing.Start(ctx, "query.getOrder")
+	defer span.End()
+
+	order, err := h.repo.GetOrder(ctx, queryOptions.NewOrderQueryOptions(q.qos...))
+
+	return order, tracing.Error(span, err)
 }
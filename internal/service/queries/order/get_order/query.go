@@ -11,6 +11,34 @@ type Query struct {
 	qos []queryOptions.QueryOption[*queryOptions.OrderQueryOptions]
 }
 
+func NewQuery(orderUUID uuid.UUID) (*Query, error) {
+	orderID, err := vObject.NewOrderIDFromUUID(orderUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Query{
+		qos: []queryOptions.QueryOption[*queryOptions.OrderQueryOptions]{
+			queryOptions.WithOrderID(orderID),
+		},
+	}, nil
+}
+
+// NewQueryFromSync читает заказ из синхронной реплики: только что созданный или изменённый заказ виден сразу.
+func NewQueryFromSync(orderUUID uuid.UUID) (*Query, error) {
+	orderID, err := vObject.NewOrderIDFromUUID(orderUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Query{
+		qos: []queryOptions.QueryOption[*queryOptions.OrderQueryOptions]{
+			queryOptions.WithOrderID(orderID),
+			queryOptions.WithFromSync[*queryOptions.OrderQueryOptions](),
+		},
+	}, nil
+}
+
 func NewQueryForUpdate(orderUUID uuid.UUID) (*Query, error) {
 	orderID, err := vObject.NewOrderIDFromUUID(orderUUID)
 	if err != nil {
@@ -0,0 +1,36 @@
+package getstatushistory
+
+import (
+	"context"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+)
+
+//go:generate mockgen -source=handler.go -destination=order_status_history_getter_mock.go -package=getstatushistory -mock_names OrderStatusHistoryGetter=GetOrderStatusHistoryMock
+type OrderStatusHistoryGetter interface {
+	GetOrderStatusHistory(ctx context.Context, qos queryOptions.OrderQueryOptionable) (entities.OrderStatusHistories, error)
+}
+
+type QueryHandler struct {
+	repo OrderStatusHistoryGetter
+}
+
+func NewQueryHandler(repo OrderStatusHistoryGetter) *QueryHandler {
+	if repo == nil {
+		panic("OrderStatusHistoryGetter repo is nil")
+	}
+
+	return &QueryHandler{repo: repo}
+}
+
+// Handle возвращает хронологию смены статусов заказа.
+func (h *QueryHandler) Handle(ctx context.Context, q Query) (entities.OrderStatusHistories, error) {
+	ctx, span := tracing.Start(ctx, "query.getOrderStatusHistory")
+	defer span.End()
+
+	history, err := h.repo.GetOrderStatusHistory(ctx, queryOptions.NewOrderQueryOptions(q.qos...))
+
+	return history, tracing.Error(span, err)
+}
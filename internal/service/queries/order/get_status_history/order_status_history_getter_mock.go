@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handler.go -destination=order_status_history_getter_mock.go -package=getstatushistory -mock_names OrderStatusHistoryGetter=GetOrderStatusHistoryMock
+//
+
+// Package getstatushistory is a generated GoMock package.
+package getstatushistory
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryoptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// GetOrderStatusHistoryMock is a mock of OrderStatusHistoryGetter interface.
+type GetOrderStatusHistoryMock struct {
+	ctrl     *gomock.Controller
+	recorder *GetOrderStatusHistoryMockMockRecorder
+}
+
+// GetOrderStatusHistoryMockMockRecorder is the mock recorder for GetOrderStatusHistoryMock.
+type GetOrderStatusHistoryMockMockRecorder struct {
+	mock *GetOrderStatusHistoryMock
+}
+
+// NewGetOrderStatusHistoryMock creates a new mock instance.
+func NewGetOrderStatusHistoryMock(ctrl *gomock.Controller) *GetOrderStatusHistoryMock {
+	mock := &GetOrderStatusHistoryMock{ctrl: ctrl}
+	mock.recorder = &GetOrderStatusHistoryMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *GetOrderStatusHistoryMock) EXPECT() *GetOrderStatusHistoryMockMockRecorder {
+	return m.recorder
+}
+
+// GetOrderStatusHistory mocks base method.
+func (m *GetOrderStatusHistoryMock) GetOrderStatusHistory(ctx context.Context, qos queryoptions.OrderQueryOptionable) (entities.OrderStatusHistories, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderStatusHistory", ctx, qos)
+	ret0, _ := ret[0].(entities.OrderStatusHistories)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderStatusHistory indicates an expected call of GetOrderStatusHistory.
+func (mr *GetOrderStatusHistoryMockMockRecorder) GetOrderStatusHistory(ctx, qos any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderStatusHistory", reflect.TypeOf((*GetOrderStatusHistoryMock)(nil).GetOrderStatusHistory), ctx, qos)
+}
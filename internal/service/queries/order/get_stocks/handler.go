@@ -3,6 +3,7 @@ package getstocks
 import (
 	"context"
 
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
 	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
 	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
 )
@@ -25,5 +26,10 @@ func NewQueryHandler(repo StocksGetter) *QueryHandler {
 }
 
 func (h *QueryHandler) Handle(ctx context.Context, q Query) (entities.Stocks, error) {
-	return h.repo.GetStocks(ctx, queryOptions.NewStockQueryOptions(q.qos...))
+	ctx, span := tracing.Start(ctx, "query.getStocks")
+	defer span.End()
+
+	stocks, err := h.repo.GetStocks(ctx, queryOptions.NewStockQueryOptions(q.qos...))
+
+	return stocks, tracing.Error(span, err)
 }
@@ -14,3 +14,13 @@ func NewQueryByProductIDUnsafe(productID vObject.ProductID) Query {
 		qos: []queryOptions.QueryOption[*queryOptions.StockQueryOptions]{queryOptions.WithStockProductID(productID)},
 	}
 }
+
+// NewQueryByProductIDForUpdateUnsafe блокирует остатки товара до конца транзакции.
+func NewQueryByProductIDForUpdateUnsafe(productID vObject.ProductID) Query {
+	return Query{
+		qos: []queryOptions.QueryOption[*queryOptions.StockQueryOptions]{
+			queryOptions.WithStockProductID(productID),
+			queryOptions.WithForUpdate[*queryOptions.StockQueryOptions](),
+		},
+	}
+}
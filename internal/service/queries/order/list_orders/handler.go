@@ -0,0 +1,45 @@
+package listorders
+
+import (
+	"context"
+	"errors"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+var ErrInvalidCreatedRange = errors.New("created from must be before created to")
+
+//go:generate mockgen -source=handler.go -destination=orders_lister_mock.go -package=listorders -mock_names OrdersLister=ListOrdersMock
+type OrdersLister interface {
+	// ListOrders страница заказов по фильтру, общее число подходящих заказов записывается в qos.WithMetaTotal.
+	ListOrders(ctx context.Context, qos queryOptions.OrderQueryOptionable) (entities.Orders, error)
+}
+
+type QueryHandler struct {
+	repo OrdersLister
+}
+
+func NewQueryHandler(repo OrdersLister) *QueryHandler {
+	if repo == nil {
+		panic("OrdersLister repo is nil")
+	}
+
+	return &QueryHandler{repo: repo}
+}
+
+func (h *QueryHandler) Handle(ctx context.Context, q Query) (entities.Orders, *vObject.Meta, error) {
+	ctx, span := tracing.Start(ctx, "query.listOrders")
+	defer span.End()
+
+	qos := queryOptions.NewOrderQueryOptions(q.qos...)
+
+	orders, err := h.repo.ListOrders(ctx, qos)
+	if err != nil {
+		return nil, nil, tracing.Error(span, err)
+	}
+
+	return orders, qos.GetMeta(), nil
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handler.go -destination=orders_lister_mock.go -package=listorders -mock_names OrdersLister=ListOrdersMock
+//
+
+// Package listorders is a generated GoMock package.
+package listorders
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryoptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// ListOrdersMock is a mock of OrdersLister interface.
+type ListOrdersMock struct {
+	ctrl     *gomock.Controller
+	recorder *ListOrdersMockMockRecorder
+}
+
+// ListOrdersMockMockRecorder is the mock recorder for ListOrdersMock.
+type ListOrdersMockMockRecorder struct {
+	mock *ListOrdersMock
+}
+
+// NewListOrdersMock creates a new mock instance.
+func NewListOrdersMock(ctrl *gomock.Controller) *ListOrdersMock {
+	mock := &ListOrdersMock{ctrl: ctrl}
+	mock.recorder = &ListOrdersMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *ListOrdersMock) EXPECT() *ListOrdersMockMockRecorder {
+	return m.recorder
+}
+
+// ListOrders mocks base method.
+func (m *ListOrdersMock) ListOrders(ctx context.Context, qos queryoptions.OrderQueryOptionable) (entities.Orders, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrders", ctx, qos)
+	ret0, _ := ret[0].(entities.Orders)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrders indicates an expected call of ListOrders.
+func (mr *ListOrdersMockMockRecorder) ListOrders(ctx, qos any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*ListOrdersMock)(nil).ListOrders), ctx, qos)
+}
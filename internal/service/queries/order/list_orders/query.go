@@ -0,0 +1,122 @@
+package listorders
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+// Filter параметры истории заказов пользователя. Нулевые значения полей не ограничивают выборку.
+type Filter struct {
+	UserID   uuid.UUID
+	Statuses []string
+	// CreatedFrom и CreatedTo период создания заказа: начало включительно, конец не включая.
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// Sort newest, oldest, total_asc или total_desc, по умолчанию newest.
+	Sort string
+	// WithProducts загрузить позиции заказов вместе с товарами.
+	WithProducts bool
+	Page         int
+	PerPage      int
+	// Cursor курсор из Meta предыдущего ответа, пустая строка — первая страница, nil — навигация по Page.
+	// Курсором листают только в порядке newest или oldest, без номера страницы.
+	Cursor *string
+}
+
+type Query struct {
+	qos []queryOptions.QueryOption[*queryOptions.OrderQueryOptions]
+}
+
+// NewQuery страница заказов пользователя, удалённые заказы в список не попадают.
+func NewQuery(f Filter) (*Query, error) {
+	userID, err := vObject.NewUserIDFromUUID(f.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("[listOrders.NewQuery - vObject.NewUserIDFromUUID error]: %w", err)
+	}
+
+	statuses := make([]vObject.OrderStatus, 0, len(f.Statuses))
+
+	for _, s := range f.Statuses {
+		status, err := vObject.NewOrderStatus(s)
+		if err != nil {
+			return nil, fmt.Errorf("[listOrders.NewQuery - vObject.NewOrderStatus error]: %w", err)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	sort, err := vObject.NewOrderSort(f.Sort)
+	if err != nil {
+		return nil, fmt.Errorf("[listOrders.NewQuery - vObject.NewOrderSort error]: %w", err)
+	}
+
+	if f.CreatedFrom != nil && f.CreatedTo != nil && !f.CreatedFrom.Before(*f.CreatedTo) {
+		return nil, fmt.Errorf("[listOrders.NewQuery error]: %w", ErrInvalidCreatedRange)
+	}
+
+	if err = vObject.ValidateMetaPaging(f.Page, f.PerPage); err != nil {
+		return nil, fmt.Errorf("[listOrders.NewQuery - vObject.ValidateMetaPaging error]: %w", err)
+	}
+
+	qos := []queryOptions.QueryOption[*queryOptions.OrderQueryOptions]{
+		queryOptions.WithOrderUserID(userID),
+		queryOptions.WithOrderSort(sort),
+	}
+
+	if len(statuses) > 0 {
+		qos = append(qos, queryOptions.WithOrderStatuses(statuses...))
+	}
+
+	if f.CreatedFrom != nil {
+		qos = append(qos, queryOptions.WithOrderCreatedFrom(*f.CreatedFrom))
+	}
+
+	if f.CreatedTo != nil {
+		qos = append(qos, queryOptions.WithOrderCreatedTo(*f.CreatedTo))
+	}
+
+	if f.WithProducts {
+		qos = append(qos, queryOptions.WithOrderProducts())
+	}
+
+	if f.Page > 0 {
+		qos = append(qos, queryOptions.WithMetaPage[*queryOptions.OrderQueryOptions](f.Page))
+	}
+
+	if f.PerPage > 0 {
+		qos = append(qos, queryOptions.WithMetaPerPage[*queryOptions.OrderQueryOptions](f.PerPage))
+	}
+
+	if f.Cursor != nil {
+		cursor, err := newCursor(*f.Cursor, f.Page, sort)
+		if err != nil {
+			return nil, err
+		}
+
+		qos = append(qos, queryOptions.WithMetaCursor[*queryOptions.OrderQueryOptions](cursor))
+	}
+
+	return &Query{qos: qos}, nil
+}
+
+func newCursor(token string, page int, sort vObject.OrderSort) (vObject.Cursor, error) {
+	if page > 0 {
+		return vObject.Cursor{}, fmt.Errorf("[listOrders.NewQuery error]: %w", vObject.ErrCursorWithPage)
+	}
+
+	if sort != vObject.OrderSortNewest && sort != vObject.OrderSortOldest {
+		return vObject.Cursor{}, fmt.Errorf("[listOrders.NewQuery error]: %w", vObject.ErrCursorSort)
+	}
+
+	cursor, err := vObject.NewCursor(token)
+	if err != nil {
+		return vObject.Cursor{}, fmt.Errorf("[listOrders.NewQuery - vObject.NewCursor error]: %w", err)
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,35 @@
+package getmovements
+
+import (
+	"context"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+)
+
+//go:generate mockgen -source=handler.go -destination=product_movements_getter_mock.go -package=getmovements -mock_names ProductMovementsGetter=GetProductMovementsMock
+type ProductMovementsGetter interface {
+	GetProductMovements(ctx context.Context, qos queryOptions.ProductMovementQueryOptionable) (entities.ProductMovements, error)
+}
+
+type QueryHandler struct {
+	repo ProductMovementsGetter
+}
+
+func NewQueryHandler(repo ProductMovementsGetter) *QueryHandler {
+	if repo == nil {
+		panic("ProductMovementsGetter repo is nil")
+	}
+
+	return &QueryHandler{repo: repo}
+}
+
+func (h *QueryHandler) Handle(ctx context.Context, q Query) (entities.ProductMovements, error) {
+	ctx, span := tracing.Start(ctx, "query.getProductMovements")
+	defer span.End()
+
+	movements, err := h.repo.GetProductMovements(ctx, queryOptions.NewProductMovementQueryOptions(q.qos...))
+
+	return movements, tracing.Error(span, err)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handler.go -destination=product_movements_getter_mock.go -package=getmovements -mock_names ProductMovementsGetter=GetProductMovementsMock
+//
+
+// Package getmovements is a generated GoMock package.
+package getmovements
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryoptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// GetProductMovementsMock is a mock of ProductMovementsGetter interface.
+type GetProductMovementsMock struct {
+	ctrl     *gomock.Controller
+	recorder *GetProductMovementsMockMockRecorder
+}
+
+// GetProductMovementsMockMockRecorder is the mock recorder for GetProductMovementsMock.
+type GetProductMovementsMockMockRecorder struct {
+	mock *GetProductMovementsMock
+}
+
+// NewGetProductMovementsMock creates a new mock instance.
+func NewGetProductMovementsMock(ctrl *gomock.Controller) *GetProductMovementsMock {
+	mock := &GetProductMovementsMock{ctrl: ctrl}
+	mock.recorder = &GetProductMovementsMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *GetProductMovementsMock) EXPECT() *GetProductMovementsMockMockRecorder {
+	return m.recorder
+}
+
+// GetProductMovements mocks base method.
+func (m *GetProductMovementsMock) GetProductMovements(ctx context.Context, qos queryoptions.ProductMovementQueryOptionable) (entities.ProductMovements, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductMovements", ctx, qos)
+	ret0, _ := ret[0].(entities.ProductMovements)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductMovements indicates an expected call of GetProductMovements.
+func (mr *GetProductMovementsMockMockRecorder) GetProductMovements(ctx, qos any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductMovements", reflect.TypeOf((*GetProductMovementsMock)(nil).GetProductMovements), ctx, qos)
+}
@@ -0,0 +1,20 @@
+package getmovements
+
+import (
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+type Query struct {
+	qos []queryOptions.QueryOption[*queryOptions.ProductMovementQueryOptions]
+}
+
+// NewQueryByOrderProductUnsafe движения товара, выполненные для заказа.
+func NewQueryByOrderProductUnsafe(orderID vObject.OrderID, productID vObject.ProductID) Query {
+	return Query{
+		qos: []queryOptions.QueryOption[*queryOptions.ProductMovementQueryOptions]{
+			queryOptions.WithMovementProductID(productID),
+			queryOptions.WithMovementOrderID(orderID),
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package getprice
+
+import (
+	"context"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+)
+
+//go:generate mockgen -source=handler.go -destination=product_price_getter_mock.go -package=getprice -mock_names ProductPriceGetter=GetProductPriceMock
+type ProductPriceGetter interface {
+	GetProductPrice(ctx context.Context, qos queryOptions.ProductPriceQueryOptionable) (*entities.ProductPrice, error)
+}
+
+type QueryHandler struct {
+	repo ProductPriceGetter
+}
+
+func NewQueryHandler(repo ProductPriceGetter) *QueryHandler {
+	if repo == nil {
+		panic("ProductPriceGetter repo is nil")
+	}
+
+	return &QueryHandler{repo: repo}
+}
+
+// Handle возвращает цену товара, действовавшую в указанный момент, либо entities.ErrProductPriceRecNotFound.
+func (h *QueryHandler) Handle(ctx context.Context, q Query) (*entities.ProductPrice, error) {
+	ctx, span := tracing.Start(ctx, "query.getProductPrice")
+	defer span.End()
+
+	price, err := h.repo.GetProductPrice(ctx, queryOptions.NewProductPriceQueryOptions(q.qos...))
+
+	return price, tracing.Error(span, err)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handler.go -destination=product_price_getter_mock.go -package=getprice -mock_names ProductPriceGetter=GetProductPriceMock
+//
+
+// Package getprice is a generated GoMock package.
+package getprice
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryoptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// GetProductPriceMock is a mock of ProductPriceGetter interface.
+type GetProductPriceMock struct {
+	ctrl     *gomock.Controller
+	recorder *GetProductPriceMockMockRecorder
+}
+
+// GetProductPriceMockMockRecorder is the mock recorder for GetProductPriceMock.
+type GetProductPriceMockMockRecorder struct {
+	mock *GetProductPriceMock
+}
+
+// NewGetProductPriceMock creates a new mock instance.
+func NewGetProductPriceMock(ctrl *gomock.Controller) *GetProductPriceMock {
+	mock := &GetProductPriceMock{ctrl: ctrl}
+	mock.recorder = &GetProductPriceMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *GetProductPriceMock) EXPECT() *GetProductPriceMockMockRecorder {
+	return m.recorder
+}
+
+// GetProductPrice mocks base method.
+func (m *GetProductPriceMock) GetProductPrice(ctx context.Context, qos queryoptions.ProductPriceQueryOptionable) (*entities.ProductPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductPrice", ctx, qos)
+	ret0, _ := ret[0].(*entities.ProductPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductPrice indicates an expected call of GetProductPrice.
+func (mr *GetProductPriceMockMockRecorder) GetProductPrice(ctx, qos any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductPrice", reflect.TypeOf((*GetProductPriceMock)(nil).GetProductPrice), ctx, qos)
+}
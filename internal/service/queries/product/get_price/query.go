@@ -0,0 +1,30 @@
+package getprice
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+type Query struct {
+	qos []queryOptions.QueryOption[*queryOptions.ProductPriceQueryOptions]
+}
+
+// NewQuery цена товара, действовавшая в момент at.
+func NewQuery(productUUID uuid.UUID, at time.Time) (*Query, error) {
+	productID, err := vObject.NewProductIDFromUUID(productUUID)
+	if err != nil {
+		return nil, fmt.Errorf("[getPrice.NewQuery - vObject.NewProductIDFromUUID error]: %w", err)
+	}
+
+	return &Query{
+		qos: []queryOptions.QueryOption[*queryOptions.ProductPriceQueryOptions]{
+			queryOptions.WithPriceProductID(productID),
+			queryOptions.WithPriceAt(at),
+		},
+	}, nil
+}
@@ -3,6 +3,7 @@ package getproduct
 import (
 	"context"
 
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
 	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
 	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
 )
@@ -25,5 +26,10 @@ func NewQueryHandler(repo ProductGetter) *QueryHandler {
 }
 
 func (h *QueryHandler) Handle(ctx context.Context, q Query) (*entities.Product, error) {
-	return h.repo.GetProduct(ctx, queryOptions.NewProductQueryOptions(q.qos...))
+	ctx, span := tracing.Start(ctx, "query.getProduct")
+	defer span.End()
+
+	product, err := h.repo.GetProduct(ctx, queryOptions.NewProductQueryOptions(q.qos...))
+
+	return product, tracing.Error(span, err)
 }
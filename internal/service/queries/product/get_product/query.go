@@ -23,3 +23,13 @@ func NewQueryByID(productUUID uuid.UUID) (*Query, error) {
 		qos: []queryOptions.QueryOption[*queryOptions.ProductQueryOptions]{queryOptions.WithProductID(productID)},
 	}, nil
 }
+
+// NewQueryForUpdate блокирует запись товара до конца транзакции.
+func NewQueryForUpdate(productID vObject.ProductID) Query {
+	return Query{
+		qos: []queryOptions.QueryOption[*queryOptions.ProductQueryOptions]{
+			queryOptions.WithProductID(productID),
+			queryOptions.WithForUpdate[*queryOptions.ProductQueryOptions](),
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package listproducts
+
+import (
+	"context"
+	"errors"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+var (
+	ErrRelevanceWithoutSearch = errors.New("relevance sort requires search text")
+	ErrInvalidPriceRange      = errors.New("min price is greater than max price")
+)
+
+//go:generate mockgen -source=handler.go -destination=products_lister_mock.go -package=listproducts -mock_names ProductsLister=ListProductsMock
+type ProductsLister interface {
+	// ListProducts страница товаров по фильтру, общее число подходящих товаров записывается в qos.WithMetaTotal.
+	ListProducts(ctx context.Context, qos queryOptions.ProductQueryOptionable) (entities.Products, error)
+}
+
+type QueryHandler struct {
+	repo ProductsLister
+}
+
+func NewQueryHandler(repo ProductsLister) *QueryHandler {
+	if repo == nil {
+		panic("ProductsLister repo is nil")
+	}
+
+	return &QueryHandler{repo: repo}
+}
+
+func (h *QueryHandler) Handle(ctx context.Context, q Query) (entities.Products, *vObject.Meta, error) {
+	ctx, span := tracing.Start(ctx, "query.listProducts")
+	defer span.End()
+
+	qos := queryOptions.NewProductQueryOptions(q.qos...)
+
+	products, err := h.repo.ListProducts(ctx, qos)
+	if err != nil {
+		return nil, nil, tracing.Error(span, err)
+	}
+
+	return products, qos.GetMeta(), nil
+}
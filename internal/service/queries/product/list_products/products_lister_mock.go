@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handler.go -destination=products_lister_mock.go -package=listproducts -mock_names ProductsLister=ListProductsMock
+//
+
+// Package listproducts is a generated GoMock package.
+package listproducts
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryoptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// ListProductsMock is a mock of ProductsLister interface.
+type ListProductsMock struct {
+	ctrl     *gomock.Controller
+	recorder *ListProductsMockMockRecorder
+}
+
+// ListProductsMockMockRecorder is the mock recorder for ListProductsMock.
+type ListProductsMockMockRecorder struct {
+	mock *ListProductsMock
+}
+
+// NewListProductsMock creates a new mock instance.
+func NewListProductsMock(ctrl *gomock.Controller) *ListProductsMock {
+	mock := &ListProductsMock{ctrl: ctrl}
+	mock.recorder = &ListProductsMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *ListProductsMock) EXPECT() *ListProductsMockMockRecorder {
+	return m.recorder
+}
+
+// ListProducts mocks base method.
+func (m *ListProductsMock) ListProducts(ctx context.Context, qos queryoptions.ProductQueryOptionable) (entities.Products, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProducts", ctx, qos)
+	ret0, _ := ret[0].(entities.Products)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProducts indicates an expected call of ListProducts.
+func (mr *ListProductsMockMockRecorder) ListProducts(ctx, qos any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*ListProductsMock)(nil).ListProducts), ctx, qos)
+}
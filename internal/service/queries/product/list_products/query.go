@@ -0,0 +1,132 @@
+package listproducts
+
+import (
+	"fmt"
+	"strings"
+
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+// Filter параметры списка товаров каталога. Нулевые значения полей не ограничивают выборку.
+type Filter struct {
+	Tags []string
+	// TagsMatch any или all, по умолчанию any.
+	TagsMatch string
+	// Title подстрока названия без учёта регистра.
+	Title string
+	// Search полнотекстовый поиск по названию и описанию.
+	Search string
+	// MinPrice и MaxPrice границы цены в копейках включительно.
+	MinPrice *int64
+	MaxPrice *int64
+	// InStockOnly только товары, доступные на складах.
+	InStockOnly bool
+	// Sort newest, title, price_asc, price_desc или relevance, по умолчанию newest.
+	Sort    string
+	Page    int
+	PerPage int
+	// Cursor курсор из Meta предыдущего ответа, пустая строка — первая страница, nil — навигация по Page.
+	// Курсором листают только в порядке создания, без номера страницы.
+	Cursor *string
+}
+
+type Query struct {
+	qos []queryOptions.QueryOption[*queryOptions.ProductQueryOptions]
+}
+
+// NewQuery страница списка товаров каталога, снятые с продажи товары в список не попадают.
+func NewQuery(f Filter) (*Query, error) {
+	tags, err := vObject.NewTags(f.Tags)
+	if err != nil {
+		return nil, fmt.Errorf("[listProducts.NewQuery - vObject.NewTags error]: %w", err)
+	}
+
+	tagsMatch, err := vObject.NewTagsMatch(f.TagsMatch)
+	if err != nil {
+		return nil, fmt.Errorf("[listProducts.NewQuery - vObject.NewTagsMatch error]: %w", err)
+	}
+
+	sort, err := vObject.NewProductSort(f.Sort)
+	if err != nil {
+		return nil, fmt.Errorf("[listProducts.NewQuery - vObject.NewProductSort error]: %w", err)
+	}
+
+	search := strings.TrimSpace(f.Search)
+	if sort == vObject.ProductSortRelevance && search == "" {
+		return nil, fmt.Errorf("[listProducts.NewQuery error]: %w", ErrRelevanceWithoutSearch)
+	}
+
+	if err = vObject.ValidateMetaPaging(f.Page, f.PerPage); err != nil {
+		return nil, fmt.Errorf("[listProducts.NewQuery - vObject.ValidateMetaPaging error]: %w", err)
+	}
+
+	qos := []queryOptions.QueryOption[*queryOptions.ProductQueryOptions]{
+		queryOptions.WithProductTags(tags, tagsMatch),
+		queryOptions.WithProductTitle(strings.TrimSpace(f.Title)),
+		queryOptions.WithProductSearch(search),
+		queryOptions.WithProductSort(sort),
+	}
+
+	if f.MinPrice != nil {
+		price, err := vObject.NewPrice(*f.MinPrice)
+		if err != nil {
+			return nil, fmt.Errorf("[listProducts.NewQuery - vObject.NewPrice error]: %w", err)
+		}
+
+		qos = append(qos, queryOptions.WithProductMinPrice(price))
+	}
+
+	if f.MaxPrice != nil {
+		price, err := vObject.NewPrice(*f.MaxPrice)
+		if err != nil {
+			return nil, fmt.Errorf("[listProducts.NewQuery - vObject.NewPrice error]: %w", err)
+		}
+
+		qos = append(qos, queryOptions.WithProductMaxPrice(price))
+	}
+
+	if f.MinPrice != nil && f.MaxPrice != nil && *f.MinPrice > *f.MaxPrice {
+		return nil, fmt.Errorf("[listProducts.NewQuery error]: %w", ErrInvalidPriceRange)
+	}
+
+	if f.InStockOnly {
+		qos = append(qos, queryOptions.WithProductInStockOnly())
+	}
+
+	if f.Page > 0 {
+		qos = append(qos, queryOptions.WithMetaPage[*queryOptions.ProductQueryOptions](f.Page))
+	}
+
+	if f.PerPage > 0 {
+		qos = append(qos, queryOptions.WithMetaPerPage[*queryOptions.ProductQueryOptions](f.PerPage))
+	}
+
+	if f.Cursor != nil {
+		cursor, err := newCursor(*f.Cursor, f.Page, sort)
+		if err != nil {
+			return nil, err
+		}
+
+		qos = append(qos, queryOptions.WithMetaCursor[*queryOptions.ProductQueryOptions](cursor))
+	}
+
+	return &Query{qos: qos}, nil
+}
+
+func newCursor(token string, page int, sort vObject.ProductSort) (vObject.Cursor, error) {
+	if page > 0 {
+		return vObject.Cursor{}, fmt.Errorf("[listProducts.NewQuery error]: %w", vObject.ErrCursorWithPage)
+	}
+
+	if sort != vObject.ProductSortNewest {
+		return vObject.Cursor{}, fmt.Errorf("[listProducts.NewQuery error]: %w", vObject.ErrCursorSort)
+	}
+
+	cursor, err := vObject.NewCursor(token)
+	if err != nil {
+		return vObject.Cursor{}, fmt.Errorf("[listProducts.NewQuery - vObject.NewCursor error]: %w", err)
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,35 @@
+package getsession
+
+import (
+	"context"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+)
+
+//go:generate mockgen -source=handler.go -destination=session_getter_mock.go -package=getsession -mock_names SessionGetter=GetSessionMock
+type SessionGetter interface {
+	GetSession(ctx context.Context, qos queryOptions.SessionQueryOptionable) (*entities.Session, error)
+}
+
+type QueryHandler struct {
+	repo SessionGetter
+}
+
+func NewQueryHandler(repo SessionGetter) *QueryHandler {
+	if repo == nil {
+		panic("SessionGetter repo is nil")
+	}
+
+	return &QueryHandler{repo: repo}
+}
+
+func (h *QueryHandler) Handle(ctx context.Context, q Query) (*entities.Session, error) {
+	ctx, span := tracing.Start(ctx, "query.getSession")
+	defer span.End()
+
+	session, err := h.repo.GetSession(ctx, queryOptions.NewSessionQueryOptions(q.qos...))
+
+	return session, tracing.Error(span, err)
+}
@@ -0,0 +1,41 @@
+package getsession
+
+import (
+	"github.com/google/uuid"
+
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+type Query struct {
+	qos []queryOptions.QueryOption[*queryOptions.SessionQueryOptions]
+}
+
+// NewQuery читает сессию из синхронной реплики: отзыв сессии и новая сессия видны сразу после фиксации.
+func NewQuery(sessionUUID uuid.UUID) (*Query, error) {
+	sessionID, err := vObject.NewSessionIDFromUUID(sessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Query{
+		qos: []queryOptions.QueryOption[*queryOptions.SessionQueryOptions]{
+			queryOptions.WithSessionID(sessionID),
+			queryOptions.WithFromSync[*queryOptions.SessionQueryOptions](),
+		},
+	}, nil
+}
+
+func NewQueryForUpdate(sessionUUID uuid.UUID) (*Query, error) {
+	sessionID, err := vObject.NewSessionIDFromUUID(sessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Query{
+		qos: []queryOptions.QueryOption[*queryOptions.SessionQueryOptions]{
+			queryOptions.WithSessionID(sessionID),
+			queryOptions.WithForUpdate[*queryOptions.SessionQueryOptions](),
+		},
+	}, nil
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handler.go -destination=session_getter_mock.go -package=getsession -mock_names SessionGetter=GetSessionMock
+//
+
+// Package getsession is a generated GoMock package.
+package getsession
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryoptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// GetSessionMock is a mock of SessionGetter interface.
+type GetSessionMock struct {
+	ctrl     *gomock.Controller
+	recorder *GetSessionMockMockRecorder
+}
+
+// GetSessionMockMockRecorder is the mock recorder for GetSessionMock.
+type GetSessionMockMockRecorder struct {
+	mock *GetSessionMock
+}
+
+// NewGetSessionMock creates a new mock instance.
+func NewGetSessionMock(ctrl *gomock.Controller) *GetSessionMock {
+	mock := &GetSessionMock{ctrl: ctrl}
+	mock.recorder = &GetSessionMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *GetSessionMock) EXPECT() *GetSessionMockMockRecorder {
+	return m.recorder
+}
+
+// GetSession mocks base method.
+func (m *GetSessionMock) GetSession(ctx context.Context, qos queryoptions.SessionQueryOptionable) (*entities.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", ctx, qos)
+	ret0, _ := ret[0].(*entities.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *GetSessionMockMockRecorder) GetSession(ctx, qos any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*GetSessionMock)(nil).GetSession), ctx, qos)
+}
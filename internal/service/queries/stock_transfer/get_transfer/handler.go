@@ -0,0 +1,35 @@
+package gettransfer
+
+import (
+	"context"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+)
+
+//go:generate mockgen -source=handler.go -destination=stock_transfer_getter_mock.go -package=gettransfer -mock_names StockTransferGetter=GetStockTransferMock
+type StockTransferGetter interface {
+	GetStockTransfer(ctx context.Context, qos queryOptions.StockTransferQueryOptionable) (*entities.StockTransfer, error)
+}
+
+type QueryHandler struct {
+	repo StockTransferGetter
+}
+
+func NewQueryHandler(repo StockTransferGetter) *QueryHandler {
+	if repo == nil {
+		panic("StockTransferGetter repo is nil")
+	}
+
+	return &QueryHandler{repo: repo}
+}
+
+func (h *QueryHandler) Handle(ctx context.Context, q Query) (*entities.StockTransfer, error) {
+	ctx, span := tracing.Start(ctx, "query.getStockTransfer")
+	defer span.End()
+
+	transfer, err := h.repo.GetStockTransfer(ctx, queryOptions.NewStockTransferQueryOptions(q.qos...))
+
+	return transfer, tracing.Error(span, err)
+}
@@ -0,0 +1,26 @@
+package gettransfer
+
+import (
+	"github.com/google/uuid"
+
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+type Query struct {
+	qos []queryOptions.QueryOption[*queryOptions.StockTransferQueryOptions]
+}
+
+func NewQueryForUpdate(transferUUID uuid.UUID) (*Query, error) {
+	transferID, err := vObject.NewStockTransferIDFromUUID(transferUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Query{
+		qos: []queryOptions.QueryOption[*queryOptions.StockTransferQueryOptions]{
+			queryOptions.WithStockTransferID(transferID),
+			queryOptions.WithForUpdate[*queryOptions.StockTransferQueryOptions](),
+		},
+	}, nil
+}
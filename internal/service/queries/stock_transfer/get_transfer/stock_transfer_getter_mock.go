@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handler.go -destination=stock_transfer_getter_mock.go -package=gettransfer -mock_names StockTransferGetter=GetStockTransferMock
+//
+
+// Package gettransfer is a generated GoMock package.
+package gettransfer
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryoptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// GetStockTransferMock is a mock of StockTransferGetter interface.
+type GetStockTransferMock struct {
+	ctrl     *gomock.Controller
+	recorder *GetStockTransferMockMockRecorder
+}
+
+// GetStockTransferMockMockRecorder is the mock recorder for GetStockTransferMock.
+type GetStockTransferMockMockRecorder struct {
+	mock *GetStockTransferMock
+}
+
+// NewGetStockTransferMock creates a new mock instance.
+func NewGetStockTransferMock(ctrl *gomock.Controller) *GetStockTransferMock {
+	mock := &GetStockTransferMock{ctrl: ctrl}
+	mock.recorder = &GetStockTransferMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *GetStockTransferMock) EXPECT() *GetStockTransferMockMockRecorder {
+	return m.recorder
+}
+
+// GetStockTransfer mocks base method.
+func (m *GetStockTransferMock) GetStockTransfer(ctx context.Context, qos queryoptions.StockTransferQueryOptionable) (*entities.StockTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockTransfer", ctx, qos)
+	ret0, _ := ret[0].(*entities.StockTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockTransfer indicates an expected call of GetStockTransfer.
+func (mr *GetStockTransferMockMockRecorder) GetStockTransfer(ctx, qos any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockTransfer", reflect.TypeOf((*GetStockTransferMock)(nil).GetStockTransfer), ctx, qos)
+}
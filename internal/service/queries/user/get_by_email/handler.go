@@ -3,13 +3,14 @@ package getuserbyemail
 import (
 	"context"
 
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
 	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
-	vObjects "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
 )
 
 //go:generate mockgen -source=handler.go -destination=user_getter_mock.go -package=getuserbyemail -mock_names UserGetter=GetUserMock
 type UserGetter interface {
-	GetByEmail(ctx context.Context, email vObjects.Email) (*entities.User, error)
+	GetByEmail(ctx context.Context, qos queryOptions.UserQueryOptionable) (*entities.User, error)
 }
 
 type QueryHandler struct {
@@ -25,5 +26,10 @@ func NewQueryHandler(repo UserGetter) *QueryHandler {
 }
 
 func (h *QueryHandler) Handle(ctx context.Context, q Query) (*entities.User, error) {
-	return h.repo.GetByEmail(ctx, q.email)
+	ctx, span := tracing.Start(ctx, "query.getUserByEmail")
+	defer span.End()
+
+	user, err := h.repo.GetByEmail(ctx, queryOptions.NewUserQueryOptions(q.qos...))
+
+	return user, tracing.Error(span, err)
 }
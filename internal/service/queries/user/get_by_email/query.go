@@ -3,23 +3,35 @@ package getuserbyemail
 import (
 	"fmt"
 
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
 	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
 )
 
 type Query struct {
-	email vObject.Email
+	qos []queryOptions.QueryOption[*queryOptions.UserQueryOptions]
 }
 
 func NewQuery(email string) (*Query, error) {
-	var (
-		q   Query
-		err error
-	)
-
-	q.email, err = vObject.NewEmail(email)
+	userEmail, err := vObject.NewEmail(email)
 	if err != nil {
 		return nil, fmt.Errorf("[get_user_by_email.NewQuery] %w", err)
 	}
 
-	return &q, nil
+	return &Query{
+		qos: []queryOptions.QueryOption[*queryOptions.UserQueryOptions]{
+			queryOptions.WithUserEmail(userEmail),
+		},
+	}, nil
+}
+
+// NewQueryForUpdate блокирует запись пользователя до конца транзакции.
+func NewQueryForUpdate(email string) (*Query, error) {
+	q, err := NewQuery(email)
+	if err != nil {
+		return nil, err
+	}
+
+	q.qos = append(q.qos, queryOptions.WithForUpdate[*queryOptions.UserQueryOptions]())
+
+	return q, nil
 }
@@ -14,7 +14,7 @@ import (
 	reflect "reflect"
 
 	entities "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
-	valueobjects "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	queryoptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -42,16 +42,16 @@ func (m *GetUserMock) EXPECT() *GetUserMockMockRecorder {
 }
 
 // GetByEmail mocks base method.
-func (m *GetUserMock) GetByEmail(ctx context.Context, email valueobjects.Email) (*entities.User, error) {
+func (m *GetUserMock) GetByEmail(ctx context.Context, qos queryoptions.UserQueryOptionable) (*entities.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByEmail", ctx, email)
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, qos)
 	ret0, _ := ret[0].(*entities.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByEmail indicates an expected call of GetByEmail.
-func (mr *GetUserMockMockRecorder) GetByEmail(ctx, email any) *gomock.Call {
+func (mr *GetUserMockMockRecorder) GetByEmail(ctx, qos any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*GetUserMock)(nil).GetByEmail), ctx, email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*GetUserMock)(nil).GetByEmail), ctx, qos)
 }
@@ -0,0 +1,35 @@
+package getuser
+
+import (
+	"context"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+)
+
+//go:generate mockgen -source=handler.go -destination=user_by_id_getter_mock.go -package=getuser -mock_names UserByIDGetter=GetUserByIDMock
+type UserByIDGetter interface {
+	GetByID(ctx context.Context, qos queryOptions.UserQueryOptionable) (*entities.User, error)
+}
+
+type QueryHandler struct {
+	repo UserByIDGetter
+}
+
+func NewQueryHandler(repo UserByIDGetter) *QueryHandler {
+	if repo == nil {
+		panic("UserByIDGetter repo is nil")
+	}
+
+	return &QueryHandler{repo: repo}
+}
+
+func (h *QueryHandler) Handle(ctx context.Context, q Query) (*entities.User, error) {
+	ctx, span := tracing.Start(ctx, "query.getUser")
+	defer span.End()
+
+	user, err := h.repo.GetByID(ctx, queryOptions.NewUserQueryOptions(q.qos...))
+
+	return user, tracing.Error(span, err)
+}
@@ -0,0 +1,37 @@
+package getuser
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+type Query struct {
+	qos []queryOptions.QueryOption[*queryOptions.UserQueryOptions]
+}
+
+func NewQuery(userID uuid.UUID) (*Query, error) {
+	id, err := vObject.NewUserIDFromUUID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("[get_user.NewQuery] %w", err)
+	}
+
+	return &Query{
+		qos: []queryOptions.QueryOption[*queryOptions.UserQueryOptions]{
+			queryOptions.WithUserID(id),
+		},
+	}, nil
+}
+
+// NewQueryForUpdate блокирует запись пользователя до конца транзакции.
+func NewQueryForUpdate(userID vObject.UserID) Query {
+	return Query{
+		qos: []queryOptions.QueryOption[*queryOptions.UserQueryOptions]{
+			queryOptions.WithUserID(userID),
+			queryOptions.WithForUpdate[*queryOptions.UserQueryOptions](),
+		},
+	}
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handler.go -destination=user_by_id_getter_mock.go -package=getuser -mock_names UserByIDGetter=GetUserByIDMock
+//
+
+// Package getuser is a generated GoMock package.
+package getuser
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryoptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// GetUserByIDMock is a mock of UserByIDGetter interface.
+type GetUserByIDMock struct {
+	ctrl     *gomock.Controller
+	recorder *GetUserByIDMockMockRecorder
+}
+
+// GetUserByIDMockMockRecorder is the mock recorder for GetUserByIDMock.
+type GetUserByIDMockMockRecorder struct {
+	mock *GetUserByIDMock
+}
+
+// NewGetUserByIDMock creates a new mock instance.
+func NewGetUserByIDMock(ctrl *gomock.Controller) *GetUserByIDMock {
+	mock := &GetUserByIDMock{ctrl: ctrl}
+	mock.recorder = &GetUserByIDMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *GetUserByIDMock) EXPECT() *GetUserByIDMockMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *GetUserByIDMock) GetByID(ctx context.Context, qos queryoptions.UserQueryOptionable) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, qos)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *GetUserByIDMockMockRecorder) GetByID(ctx, qos any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*GetUserByIDMock)(nil).GetByID), ctx, qos)
+}
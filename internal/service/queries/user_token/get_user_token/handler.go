@@ -0,0 +1,35 @@
+package getusertoken
+
+import (
+	"context"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+)
+
+//go:generate mockgen -source=handler.go -destination=user_token_getter_mock.go -package=getusertoken -mock_names UserTokenGetter=GetUserTokenMock
+type UserTokenGetter interface {
+	GetUserToken(ctx context.Context, qos queryOptions.UserTokenQueryOptionable) (*entities.UserToken, error)
+}
+
+type QueryHandler struct {
+	repo UserTokenGetter
+}
+
+func NewQueryHandler(repo UserTokenGetter) *QueryHandler {
+	if repo == nil {
+		panic("UserTokenGetter repo is nil")
+	}
+
+	return &QueryHandler{repo: repo}
+}
+
+func (h *QueryHandler) Handle(ctx context.Context, q Query) (*entities.UserToken, error) {
+	ctx, span := tracing.Start(ctx, "query.getUserToken")
+	defer span.End()
+
+	token, err := h.repo.GetUserToken(ctx, queryOptions.NewUserTokenQueryOptions(q.qos...))
+
+	return token, tracing.Error(span, err)
+}
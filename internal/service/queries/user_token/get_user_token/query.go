@@ -0,0 +1,29 @@
+package getusertoken
+
+import (
+	"errors"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+)
+
+var ErrEmptyToken = errors.New("token is empty")
+
+type Query struct {
+	qos []queryOptions.QueryOption[*queryOptions.UserTokenQueryOptions]
+}
+
+// NewQueryForUpdate ищет токен по его секрету и блокирует запись до конца транзакции,
+// чтобы токен нельзя было погасить дважды параллельными запросами.
+func NewQueryForUpdate(secret string, purpose entities.UserTokenPurpose) (*Query, error) {
+	if secret == "" {
+		return nil, ErrEmptyToken
+	}
+
+	return &Query{
+		qos: []queryOptions.QueryOption[*queryOptions.UserTokenQueryOptions]{
+			queryOptions.WithUserToken(entities.HashUserTokenSecret(secret), purpose),
+			queryOptions.WithForUpdate[*queryOptions.UserTokenQueryOptions](),
+		},
+	}, nil
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handler.go -destination=user_token_getter_mock.go -package=getusertoken -mock_names UserTokenGetter=GetUserTokenMock
+//
+
+// Package getusertoken is a generated GoMock package.
+package getusertoken
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryoptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// GetUserTokenMock is a mock of UserTokenGetter interface.
+type GetUserTokenMock struct {
+	ctrl     *gomock.Controller
+	recorder *GetUserTokenMockMockRecorder
+}
+
+// GetUserTokenMockMockRecorder is the mock recorder for GetUserTokenMock.
+type GetUserTokenMockMockRecorder struct {
+	mock *GetUserTokenMock
+}
+
+// NewGetUserTokenMock creates a new mock instance.
+func NewGetUserTokenMock(ctrl *gomock.Controller) *GetUserTokenMock {
+	mock := &GetUserTokenMock{ctrl: ctrl}
+	mock.recorder = &GetUserTokenMockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *GetUserTokenMock) EXPECT() *GetUserTokenMockMockRecorder {
+	return m.recorder
+}
+
+// GetUserToken mocks base method.
+func (m *GetUserTokenMock) GetUserToken(ctx context.Context, qos queryoptions.UserTokenQueryOptionable) (*entities.UserToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserToken", ctx, qos)
+	ret0, _ := ret[0].(*entities.UserToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserToken indicates an expected call of GetUserToken.
+func (mr *GetUserTokenMockMockRecorder) GetUserToken(ctx, qos any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserToken", reflect.TypeOf((*GetUserTokenMock)(nil).GetUserToken), ctx, qos)
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/avito-tech/go-transaction-manager/trm"
+)
+
+// Manager is a trm.Manager over the Storage. On error all changes made inside
+// the transaction are rolled back to the snapshot taken at its beginning.
+type Manager struct {
+	storage *Storage
+}
+
+var _ trm.Manager = (*Manager)(nil)
+
+func NewManager(storage *Storage) *Manager {
+	if storage == nil {
+		panic("storage is nil")
+	}
+
+	return &Manager{storage: storage}
+}
+
+// Do processes a transaction inside a closure. Nested calls join the outer
+// transaction.
+func (m *Manager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	s := m.storage
+
+	if s.inTx(ctx) {
+		return fn(ctx)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := s.data.snapshot()
+
+	if err := fn(context.WithValue(ctx, txKey{}, s)); err != nil {
+		if !trm.IsSkippable(err) {
+			s.data = snapshot
+		}
+
+		return trm.UnSkippable(err)
+	}
+
+	return nil
+}
+
+// DoWithSettings processes a transaction inside a closure. Settings are ignored.
+func (m *Manager) DoWithSettings(ctx context.Context, _ trm.Settings, fn func(ctx context.Context) error) error {
+	return m.Do(ctx, fn)
+}
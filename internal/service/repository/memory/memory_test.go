@@ -0,0 +1,618 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/memory"
+)
+
+func newUser(email string) *entities.User {
+	return &entities.User{
+		ID:    vObject.NewUserIDFromUUIDUnsafe(uuid.New()),
+		Email: vObject.NewEmailUnsafe(email),
+	}
+}
+
+func byEmail(email vObject.Email) *queryOptions.UserQueryOptions {
+	return queryOptions.NewUserQueryOptions(queryOptions.WithUserEmail(email))
+}
+
+func TestUserRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := memory.NewUserRepository(memory.NewStorage())
+
+	_, err := repo.GetByEmail(ctx, byEmail(vObject.NewEmailUnsafe("test@test.ru")))
+	require.ErrorIs(t, err, entities.ErrUserRecNotFound)
+
+	user := newUser("test@test.ru")
+	require.NoError(t, repo.CreateUser(ctx, user))
+	require.ErrorIs(t, repo.CreateUser(ctx, newUser("test@test.ru")), entities.ErrUserAlreadyExists)
+
+	got, err := repo.GetByEmail(ctx, byEmail(user.Email))
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, got.ID)
+
+	got.FailedLoginAttempts = 3
+	require.NoError(t, repo.UpdateUser(ctx, got))
+	require.ErrorIs(t, repo.UpdateUser(ctx, newUser("other@test.ru")), entities.ErrUserRecNotFound)
+
+	got, err = repo.GetByEmail(ctx, byEmail(user.Email))
+	require.NoError(t, err)
+	assert.Equal(t, 3, got.FailedLoginAttempts)
+
+	got, err = repo.GetByID(ctx, queryOptions.NewUserQueryOptions(queryOptions.WithUserID(user.ID)))
+	require.NoError(t, err)
+	assert.Equal(t, user.Email, got.Email)
+
+	_, err = repo.GetByID(ctx, queryOptions.NewUserQueryOptions())
+	require.ErrorIs(t, err, entities.ErrUserRecNotFound)
+}
+
+func TestUserTokenRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := memory.NewUserTokenRepository(memory.NewStorage())
+	token, secret, err := entities.NewUserToken(vObject.NewUserIDFromUUIDUnsafe(uuid.New()), entities.UserTokenPasswordReset, time.Hour)
+	require.NoError(t, err)
+
+	qos := queryOptions.NewUserTokenQueryOptions(
+		queryOptions.WithUserToken(entities.HashUserTokenSecret(secret), entities.UserTokenPasswordReset),
+	)
+
+	_, err = repo.GetUserToken(ctx, qos)
+	require.ErrorIs(t, err, entities.ErrUserTokenRecNotFound)
+
+	require.NoError(t, repo.UpsertUserToken(ctx, &token))
+	require.NoError(t, token.Redeem())
+	require.NoError(t, repo.UpsertUserToken(ctx, &token))
+
+	got, err := repo.GetUserToken(ctx, qos)
+	require.NoError(t, err)
+	assert.Equal(t, token.UsedAt, got.UsedAt)
+
+	_, err = repo.GetUserToken(ctx, queryOptions.NewUserTokenQueryOptions(
+		queryOptions.WithUserToken(token.Hash, entities.UserTokenEmailVerification),
+	))
+	require.ErrorIs(t, err, entities.ErrUserTokenRecNotFound, "purpose must match")
+}
+
+func TestUserTokenRepository_InvalidateUserTokens(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := memory.NewUserTokenRepository(memory.NewStorage())
+	userID := vObject.NewUserIDFromUUIDUnsafe(uuid.New())
+
+	newToken := func(userID vObject.UserID, purpose entities.UserTokenPurpose) entities.UserToken {
+		token, _, err := entities.NewUserToken(userID, purpose, time.Hour)
+		require.NoError(t, err)
+		require.NoError(t, repo.UpsertUserToken(ctx, &token))
+
+		return token
+	}
+	get := func(token entities.UserToken) *entities.UserToken {
+		got, err := repo.GetUserToken(ctx, queryOptions.NewUserTokenQueryOptions(
+			queryOptions.WithUserToken(token.Hash, token.Purpose),
+		))
+		require.NoError(t, err)
+
+		return got
+	}
+
+	redeemed := newToken(userID, entities.UserTokenPasswordReset)
+	other := newToken(userID, entities.UserTokenPasswordReset)
+	verification := newToken(userID, entities.UserTokenEmailVerification)
+	foreign := newToken(vObject.NewUserIDFromUUIDUnsafe(uuid.New()), entities.UserTokenPasswordReset)
+
+	require.NoError(t, redeemed.Redeem())
+	require.NoError(t, repo.UpsertUserToken(ctx, &redeemed))
+	require.NoError(t, repo.InvalidateUserTokens(ctx, &redeemed))
+
+	assert.Equal(t, redeemed.UsedAt, get(other).UsedAt)
+	assert.Nil(t, get(verification).UsedAt, "other purpose must stay valid")
+	assert.Nil(t, get(foreign).UsedAt, "other user's token must stay valid")
+}
+
+func TestSessionRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := memory.NewSessionRepository(memory.NewStorage())
+	session := entities.NewSession(vObject.NewUserIDFromUUIDUnsafe(uuid.New()), time.Hour)
+	qos := queryOptions.NewSessionQueryOptions(queryOptions.WithSessionID(session.ID))
+
+	_, err := repo.GetSession(ctx, qos)
+	require.ErrorIs(t, err, entities.ErrSessionRecNotFound)
+
+	require.NoError(t, repo.UpsertSession(ctx, &session))
+
+	session.Revoke()
+	require.NoError(t, repo.UpsertSession(ctx, &session))
+
+	got, err := repo.GetSession(ctx, qos)
+	require.NoError(t, err)
+	assert.Equal(t, session.RevokedAt, got.RevokedAt)
+}
+
+func TestSessionRepository_RevokeUserSessions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := memory.NewSessionRepository(memory.NewStorage())
+	userID := vObject.NewUserIDFromUUIDUnsafe(uuid.New())
+	tn := time.Now().UTC()
+
+	revoked := entities.NewSession(userID, time.Hour)
+	revoked.Revoke()
+	active := entities.NewSession(userID, time.Hour)
+	foreign := entities.NewSession(vObject.NewUserIDFromUUIDUnsafe(uuid.New()), time.Hour)
+
+	for _, s := range []*entities.Session{&revoked, &active, &foreign} {
+		require.NoError(t, repo.UpsertSession(ctx, s))
+	}
+
+	require.NoError(t, repo.RevokeUserSessions(ctx, userID, tn))
+
+	get := func(id vObject.SessionID) *entities.Session {
+		got, err := repo.GetSession(ctx, queryOptions.NewSessionQueryOptions(queryOptions.WithSessionID(id)))
+		require.NoError(t, err)
+
+		return got
+	}
+
+	require.NotNil(t, get(active.ID).RevokedAt)
+	assert.Equal(t, tn, *get(active.ID).RevokedAt)
+	assert.Equal(t, revoked.RevokedAt, get(revoked.ID).RevokedAt, "already revoked session must not change")
+	assert.Nil(t, get(foreign.ID).RevokedAt)
+}
+
+func TestProductMovementRepository_Order(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := memory.NewProductMovementRepository(memory.NewStorage())
+	productID := vObject.NewProductIDFromUUIDUnsafe(uuid.New())
+	tn := time.Now().UTC()
+
+	// движения одного юзкейса с одним временем возвращаются в порядке создания
+	movements := make(entities.ProductMovements, 0, 20)
+	for i := range 20 {
+		operation := vObject.OperationTypeReserve
+		if i%2 == 1 {
+			operation = vObject.OperationTypeUnreserve
+		}
+
+		movements = append(movements, entities.ProductMovement{
+			ID:            vObject.NewProductMovementIDFromUUIDUnsafe(uuid.Must(uuid.NewV7())),
+			ProductID:     productID,
+			OperationType: operation,
+			Quantity:      vObject.NewQuantityUnsafe(1),
+			CreatedAt:     tn,
+		})
+	}
+
+	require.NoError(t, repo.CreateProductMovements(ctx, movements))
+
+	got, err := repo.GetProductMovements(ctx, queryOptions.NewProductMovementQueryOptions(
+		queryOptions.WithMovementProductID(productID),
+	))
+	require.NoError(t, err)
+	assert.Equal(t, movements, got)
+}
+
+func TestProductRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := memory.NewProductRepository(memory.NewStorage())
+
+	product, err := entities.NewProduct("product", "", []string{"a"}, 100)
+	require.NoError(t, err)
+
+	qos := queryOptions.NewProductQueryOptions(queryOptions.WithProductID(product.ID))
+
+	_, err = repo.GetProduct(ctx, qos)
+	require.ErrorIs(t, err, entities.ErrProductRecNotFound)
+
+	require.NoError(t, repo.CreateProduct(ctx, product))
+	require.Error(t, repo.CreateProduct(ctx, product))
+
+	require.NoError(t, product.Update("renamed", "", []string{"b"}, 200))
+	require.NoError(t, repo.UpdateProduct(ctx, product))
+
+	got, err := repo.GetProduct(ctx, qos)
+	require.NoError(t, err)
+	assert.Equal(t, product.Title, got.Title)
+	assert.Equal(t, product.Tags, got.Tags)
+
+	// архивный товар не находится
+	require.True(t, product.Archive())
+	require.NoError(t, repo.UpdateProduct(ctx, product))
+
+	_, err = repo.GetProduct(ctx, qos)
+	require.ErrorIs(t, err, entities.ErrProductRecNotFound)
+
+	missing := entities.Product{ID: vObject.NewProductIDFromUUIDUnsafe(uuid.New())}
+	require.ErrorIs(t, repo.UpdateProduct(ctx, &missing), entities.ErrProductRecNotFound)
+}
+
+func TestProductRepository_ListProducts(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage := memory.NewStorage()
+	repo := memory.NewProductRepository(storage)
+
+	tn := time.Now().UTC()
+	newProduct := func(title, description string, price int, tags vObject.Tags, age time.Duration) entities.Product {
+		p := entities.Product{
+			ID:          vObject.NewProductIDFromUUIDUnsafe(uuid.New()),
+			Title:       vObject.NewProductTitleUnsafe(title),
+			Description: vObject.NewProductDescriptionUnsafe(description),
+			Tags:        tags,
+			Price:       vObject.NewPriceUnsafe(price),
+			CreatedAt:   tn.Add(-age),
+		}
+		storage.AddProduct(ctx, p)
+
+		return p
+	}
+
+	newProduct("Молоко", "свежее коровье", 9000, vObject.Tags{"молочное", "напитки"}, time.Hour)
+	kefir := newProduct("Кефир", "свежее молоко после брожения", 8000, vObject.Tags{"молочное"}, 2*time.Hour)
+	juice := newProduct("Сок", "яблочный", 12000, vObject.Tags{"напитки"}, 3*time.Hour)
+	archived := newProduct("Молоко старое", "", 100, vObject.Tags{"молочное"}, 0)
+	archived.DeletedAt = &tn
+	storage.AddProduct(ctx, archived)
+
+	storage.AddStock(ctx, entities.Stock{
+		ProductID:         kefir.ID,
+		WarehouseID:       vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New()),
+		AvailableQuantity: vObject.NewQuantityUnsafe(5),
+		ReservedQuantity:  vObject.NewQuantityUnsafe(1),
+	})
+	storage.AddStock(ctx, entities.Stock{
+		ProductID:         juice.ID,
+		WarehouseID:       vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New()),
+		AvailableQuantity: vObject.NewQuantityUnsafe(2),
+		ReservedQuantity:  vObject.NewQuantityUnsafe(2),
+	})
+
+	titles := func(products entities.Products) []string {
+		result := make([]string, 0, len(products))
+		for _, p := range products {
+			result = append(result, string(p.Title))
+		}
+
+		return result
+	}
+
+	testCases := []struct {
+		name string
+		opts []queryOptions.QueryOption[*queryOptions.ProductQueryOptions]
+		want []string
+	}{
+		{name: "newest first", want: []string{"Молоко", "Кефир", "Сок"}},
+		{
+			name: "any tag",
+			opts: []queryOptions.QueryOption[*queryOptions.ProductQueryOptions]{
+				queryOptions.WithProductTags(vObject.Tags{"напитки", "нет"}, vObject.TagsMatchAny),
+			},
+			want: []string{"Молоко", "Сок"},
+		},
+		{
+			name: "all tags",
+			opts: []queryOptions.QueryOption[*queryOptions.ProductQueryOptions]{
+				queryOptions.WithProductTags(vObject.Tags{"напитки", "молочное"}, vObject.TagsMatchAll),
+			},
+			want: []string{"Молоко"},
+		},
+		{
+			name: "title substring",
+			opts: []queryOptions.QueryOption[*queryOptions.ProductQueryOptions]{queryOptions.WithProductTitle("олок")},
+			want: []string{"Молоко"},
+		},
+		{
+			name: "search by relevance",
+			opts: []queryOptions.QueryOption[*queryOptions.ProductQueryOptions]{
+				queryOptions.WithProductSearch("свежее молоко"),
+				queryOptions.WithProductSort(vObject.ProductSortRelevance),
+			},
+			want: []string{"Молоко", "Кефир"},
+		},
+		{
+			name: "price range",
+			opts: []queryOptions.QueryOption[*queryOptions.ProductQueryOptions]{
+				queryOptions.WithProductMinPrice(vObject.NewPriceUnsafe(8500)),
+				queryOptions.WithProductMaxPrice(vObject.NewPriceUnsafe(12000)),
+				queryOptions.WithProductSort(vObject.ProductSortPriceDesc),
+			},
+			want: []string{"Сок", "Молоко"},
+		},
+		{
+			name: "in stock only",
+			opts: []queryOptions.QueryOption[*queryOptions.ProductQueryOptions]{queryOptions.WithProductInStockOnly()},
+			want: []string{"Кефир"},
+		},
+		{
+			name: "second page by title",
+			opts: []queryOptions.QueryOption[*queryOptions.ProductQueryOptions]{
+				queryOptions.WithProductSort(vObject.ProductSortTitle),
+				queryOptions.WithMetaPage[*queryOptions.ProductQueryOptions](2),
+				queryOptions.WithMetaPerPage[*queryOptions.ProductQueryOptions](2),
+			},
+			want: []string{"Сок"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			list, err := repo.ListProducts(ctx, queryOptions.NewProductQueryOptions(tc.opts...))
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, titles(list))
+		})
+	}
+
+	qos := queryOptions.NewProductQueryOptions(queryOptions.WithMetaPerPage[*queryOptions.ProductQueryOptions](2))
+
+	_, err := repo.ListProducts(ctx, qos)
+	require.NoError(t, err)
+	assert.Equal(t, vObject.Meta{Total: 3, PerPage: 2, Page: 1, LastPage: 2}, *qos.GetMeta())
+
+	// по курсору товары листаются по id без подсчёта общего числа
+	byCursor := func(cursor vObject.Cursor) (entities.Products, *vObject.Meta) {
+		qos := queryOptions.NewProductQueryOptions(
+			queryOptions.WithMetaCursor[*queryOptions.ProductQueryOptions](cursor),
+			queryOptions.WithMetaPerPage[*queryOptions.ProductQueryOptions](2),
+		)
+
+		list, err := repo.ListProducts(ctx, qos)
+		require.NoError(t, err)
+
+		return list, qos.GetMeta()
+	}
+
+	first, meta := byCursor(vObject.Cursor{Direction: vObject.CursorNext})
+	require.Len(t, first, 2)
+	assert.Equal(t, vObject.Meta{PerPage: 2, NextCursor: vObject.NewNextCursor(first[1].ID.UUID()).Token()}, *meta)
+
+	second, meta := byCursor(vObject.NewNextCursor(first[1].ID.UUID()))
+	require.Len(t, second, 1)
+	assert.Equal(t, vObject.Meta{PerPage: 2, PrevCursor: vObject.NewPrevCursor(second[0].ID.UUID()).Token()}, *meta)
+	assert.ElementsMatch(t, []string{"Молоко", "Кефир", "Сок"}, titles(append(first, second...)))
+
+	back, _ := byCursor(vObject.NewPrevCursor(second[0].ID.UUID()))
+	assert.Equal(t, first, back)
+}
+
+func TestOrderRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage := memory.NewStorage()
+	orders := memory.NewOrderRepository(storage)
+	orderProducts := memory.NewOrderProductRepository(storage)
+
+	product := entities.Product{
+		ID:    vObject.NewProductIDFromUUIDUnsafe(uuid.New()),
+		Title: vObject.NewProductTitleUnsafe("product"),
+		Tags:  vObject.Tags{"a", "b"},
+	}
+	storage.AddProduct(ctx, product)
+
+	orderID := vObject.NewOrderIDFromUUIDUnsafe(uuid.New())
+	qos := queryOptions.NewOrderQueryOptions(queryOptions.WithOrderID(orderID))
+
+	_, err := orders.GetOrder(ctx, qos)
+	require.ErrorIs(t, err, entities.ErrOrderRecNotFound)
+
+	order := &entities.Order{ID: orderID, TotalPrice: vObject.NewPriceUnsafe(100)}
+	require.NoError(t, orders.UpsertOrder(ctx, order))
+	require.NoError(t, orderProducts.UpsertOrderProduct(ctx, &entities.OrderProduct{
+		OrderID:   orderID,
+		ProductID: product.ID,
+		Quantity:  vObject.NewQuantityUnsafe(2),
+		CreatedAt: time.Now(),
+	}))
+
+	got, err := orders.GetOrder(ctx, qos)
+	require.NoError(t, err)
+	assert.Equal(t, order.TotalPrice, got.TotalPrice)
+	require.Len(t, got.Products, 1)
+	require.NotNil(t, got.Products[0].Product)
+	assert.Equal(t, product.Title, got.Products[0].Product.Title)
+	assert.Equal(t, vObject.NewQuantityUnsafe(2), got.Products[0].Quantity)
+}
+
+func TestOrderRepository_ListOrders(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage := memory.NewStorage()
+	orders := memory.NewOrderRepository(storage)
+	orderProducts := memory.NewOrderProductRepository(storage)
+
+	userID := vObject.NewUserIDFromUUIDUnsafe(uuid.New())
+	tn := time.Now().UTC()
+
+	newOrder := func(user vObject.UserID, status vObject.OrderStatus, total int, age time.Duration) entities.Order {
+		o := entities.Order{
+			ID:         vObject.NewOrderIDFromUUIDUnsafe(uuid.New()),
+			UserID:     user,
+			Status:     status,
+			TotalPrice: vObject.NewPriceUnsafe(total),
+			CreatedAt:  tn.Add(-age),
+		}
+		require.NoError(t, orders.UpsertOrder(ctx, &o))
+
+		return o
+	}
+
+	first := newOrder(userID, vObject.OrderStatusReceived, 500, 72*time.Hour)
+	second := newOrder(userID, vObject.OrderStatusPaid, 3000, 48*time.Hour)
+	third := newOrder(userID, vObject.OrderStatusCreated, 1000, time.Hour)
+	newOrder(vObject.NewUserIDFromUUIDUnsafe(uuid.New()), vObject.OrderStatusPaid, 100, time.Hour)
+
+	deleted := newOrder(userID, vObject.OrderStatusCanceled, 100, time.Hour)
+	deleted.DeletedAt = &tn
+	require.NoError(t, orders.UpsertOrder(ctx, &deleted))
+
+	product := entities.Product{ID: vObject.NewProductIDFromUUIDUnsafe(uuid.New()), Title: vObject.NewProductTitleUnsafe("product")}
+	storage.AddProduct(ctx, product)
+	require.NoError(t, orderProducts.UpsertOrderProduct(ctx, &entities.OrderProduct{
+		OrderID:   second.ID,
+		ProductID: product.ID,
+		Quantity:  vObject.NewQuantityUnsafe(1),
+		CreatedAt: tn,
+	}))
+
+	ids := func(list entities.Orders) []vObject.OrderID {
+		result := make([]vObject.OrderID, 0, len(list))
+		for _, o := range list {
+			result = append(result, o.ID)
+		}
+
+		return result
+	}
+
+	byUser := queryOptions.WithOrderUserID(userID)
+
+	list, err := orders.ListOrders(ctx, queryOptions.NewOrderQueryOptions(byUser))
+	require.NoError(t, err)
+	assert.Equal(t, []vObject.OrderID{third.ID, second.ID, first.ID}, ids(list))
+	assert.Nil(t, list[1].Products)
+
+	qos := queryOptions.NewOrderQueryOptions(
+		byUser,
+		queryOptions.WithOrderStatuses(vObject.OrderStatusPaid, vObject.OrderStatusReceived),
+		queryOptions.WithOrderCreatedFrom(tn.Add(-72*time.Hour)),
+		queryOptions.WithOrderCreatedTo(tn.Add(-time.Hour)),
+		queryOptions.WithOrderSort(vObject.OrderSortTotalDesc),
+		queryOptions.WithOrderProducts(),
+		queryOptions.WithMetaPerPage[*queryOptions.OrderQueryOptions](1),
+	)
+
+	list, err = orders.ListOrders(ctx, qos)
+	require.NoError(t, err)
+	assert.Equal(t, []vObject.OrderID{second.ID}, ids(list))
+	require.Len(t, list[0].Products, 1)
+	require.NotNil(t, list[0].Products[0].Product)
+	assert.Equal(t, vObject.Meta{Total: 2, PerPage: 1, Page: 1, LastPage: 2}, *qos.GetMeta())
+}
+
+func TestManager_Do(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	type testCase struct {
+		name      string
+		fnErr     error
+		wantSaved bool
+	}
+
+	testCases := []testCase{
+		{name: "commit", wantSaved: true},
+		{name: "rollback", fnErr: errTest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			storage := memory.NewStorage()
+			manager := memory.NewManager(storage)
+			repo := memory.NewUserRepository(storage)
+			user := newUser("test@test.ru")
+
+			err := manager.Do(ctx, func(ctx context.Context) error {
+				if err := repo.CreateUser(ctx, user); err != nil {
+					return err
+				}
+
+				// вложенная транзакция присоединяется к внешней
+				return manager.Do(ctx, func(ctx context.Context) error {
+					_, err := repo.GetByEmail(ctx, byEmail(user.Email))
+					require.NoError(t, err)
+
+					return tc.fnErr
+				})
+			})
+			require.ErrorIs(t, err, tc.fnErr)
+
+			_, err = repo.GetByEmail(ctx, byEmail(user.Email))
+			if tc.wantSaved {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, entities.ErrUserRecNotFound)
+			}
+		})
+	}
+}
+
+func TestManager_DoSerializes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage := memory.NewStorage()
+	manager := memory.NewManager(storage)
+	stocks := memory.NewStockRepository(storage)
+
+	productID := vObject.NewProductIDFromUUIDUnsafe(uuid.New())
+	storage.AddStock(ctx, entities.Stock{
+		ProductID:         productID,
+		WarehouseID:       vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New()),
+		AvailableQuantity: vObject.NewQuantityUnsafe(1),
+	})
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		reserved int
+	)
+
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_ = manager.Do(ctx, func(ctx context.Context) error {
+				s, err := stocks.GetStocks(ctx, queryOptions.NewStockQueryOptions(queryOptions.WithStockProductID(productID)))
+				if err != nil || s.GetAvailableQuantity().IsLessThan(1) {
+					return err
+				}
+
+				s[0].ReservedQuantity++
+				storage.AddStock(ctx, s[0])
+
+				mu.Lock()
+				reserved++
+				mu.Unlock()
+
+				return nil
+			})
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 1, reserved)
+}
@@ -0,0 +1,31 @@
+package memory
+
+import (
+	"context"
+
+	upsertOrderProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order_product/upsert"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+)
+
+type OrderProductRepository struct {
+	storage *Storage
+}
+
+var _ upsertOrderProduct.OrderProductUpserter = (*OrderProductRepository)(nil)
+
+func NewOrderProductRepository(storage *Storage) *OrderProductRepository {
+	if storage == nil {
+		panic("storage is nil")
+	}
+
+	return &OrderProductRepository{storage: storage}
+}
+
+func (r *OrderProductRepository) UpsertOrderProduct(ctx context.Context, orderProduct *entities.OrderProduct) error {
+	return r.storage.do(ctx, func(data *tables) error {
+		key := orderProductKey{orderID: orderProduct.OrderID.UUID(), productID: orderProduct.ProductID.UUID()}
+		data.orderProducts[key] = orderProductRow(*orderProduct)
+
+		return nil
+	})
+}
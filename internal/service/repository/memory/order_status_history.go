@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	createOrderStatusHistory "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order_status_history/create"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	getStatusHistory "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_status_history"
+)
+
+type OrderStatusHistoryRepository struct {
+	storage *Storage
+}
+
+var (
+	_ createOrderStatusHistory.OrderStatusHistoryCreator = (*OrderStatusHistoryRepository)(nil)
+	_ getStatusHistory.OrderStatusHistoryGetter          = (*OrderStatusHistoryRepository)(nil)
+)
+
+func NewOrderStatusHistoryRepository(storage *Storage) *OrderStatusHistoryRepository {
+	if storage == nil {
+		panic("storage is nil")
+	}
+
+	return &OrderStatusHistoryRepository{storage: storage}
+}
+
+func (r *OrderStatusHistoryRepository) CreateOrderStatusHistory(ctx context.Context, history *entities.OrderStatusHistory) error {
+	return r.storage.do(ctx, func(data *tables) error {
+		data.orderStatusHistory[history.ID.UUID()] = *history
+
+		return nil
+	})
+}
+
+func (r *OrderStatusHistoryRepository) GetOrderStatusHistory(ctx context.Context, qos queryOptions.OrderQueryOptionable) (entities.OrderStatusHistories, error) {
+	history := make(entities.OrderStatusHistories, 0)
+
+	err := r.storage.do(ctx, func(data *tables) error {
+		if qos.ForOrderID() == nil {
+			return nil
+		}
+
+		for _, h := range data.orderStatusHistory {
+			if h.OrderID == *qos.ForOrderID() {
+				history = append(history, h)
+			}
+		}
+
+		return nil
+	})
+
+	// идентификаторы упорядочены по времени создания, поэтому разрешают записи с одинаковым created_at
+	sort.SliceStable(history, func(i, j int) bool {
+		if history[i].CreatedAt.Equal(history[j].CreatedAt) {
+			return history[i].ID.String() < history[j].ID.String()
+		}
+
+		return history[i].CreatedAt.Before(history[j].CreatedAt)
+	})
+
+	return history, err
+}
@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/google/uuid"
+
+	upsertOrder "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order/upsert"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getOrder "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_order"
+	listOrders "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/list_orders"
+)
+
+type OrderRepository struct {
+	storage *Storage
+}
+
+var (
+	_ getOrder.OrderGetter      = (*OrderRepository)(nil)
+	_ upsertOrder.OrderUpserter = (*OrderRepository)(nil)
+	_ listOrders.OrdersLister   = (*OrderRepository)(nil)
+)
+
+func NewOrderRepository(storage *Storage) *OrderRepository {
+	if storage == nil {
+		panic("storage is nil")
+	}
+
+	return &OrderRepository{storage: storage}
+}
+
+func (r *OrderRepository) GetOrder(ctx context.Context, qos queryOptions.OrderQueryOptionable) (*entities.Order, error) {
+	var order *entities.Order
+
+	err := r.storage.do(ctx, func(data *tables) error {
+		if qos.ForOrderID() == nil {
+			return fmt.Errorf("[memory.GetOrder] %w", entities.ErrOrderRecNotFound)
+		}
+
+		o, ok := data.orders[qos.ForOrderID().UUID()]
+		if !ok || o.DeletedAt != nil {
+			return fmt.Errorf("[memory.GetOrder] %w", entities.ErrOrderRecNotFound)
+		}
+
+		o.Products = data.orderProductsOf(o)
+		order = &o
+
+		return nil
+	})
+
+	return order, err
+}
+
+func (r *OrderRepository) ListOrders(ctx context.Context, qos queryOptions.OrderQueryOptionable) (entities.Orders, error) {
+	var orders entities.Orders
+
+	err := r.storage.do(ctx, func(data *tables) error {
+		for _, o := range data.orders {
+			switch {
+			case o.DeletedAt != nil,
+				qos.ForUserID() != nil && o.UserID != *qos.ForUserID(),
+				len(qos.ForStatuses()) > 0 && !slices.Contains(qos.ForStatuses(), o.Status),
+				qos.ForCreatedFrom() != nil && o.CreatedAt.Before(*qos.ForCreatedFrom()),
+				qos.ForCreatedTo() != nil && !o.CreatedAt.Before(*qos.ForCreatedTo()):
+				continue
+			}
+
+			if qos.IsWithProducts() {
+				o.Products = data.orderProductsOf(o)
+			}
+
+			orders = append(orders, o)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortOrders(orders, qos.ForSort())
+
+	desc := qos.ForSort() != vObject.OrderSortOldest
+
+	return page(orders, qos, desc, func(o entities.Order) uuid.UUID { return o.ID.UUID() }), nil
+}
+
+func (r *OrderRepository) UpsertOrder(ctx context.Context, order *entities.Order) error {
+	return r.storage.do(ctx, func(data *tables) error {
+		data.orders[order.ID.UUID()] = orderRow(*order)
+
+		return nil
+	})
+}
+
+// orderProductsOf returns not deleted products of the order with the products loaded.
+func (t *tables) orderProductsOf(order entities.Order) entities.OrderProducts {
+	orderProducts := make(entities.OrderProducts, 0)
+
+	for key, op := range t.orderProducts {
+		if key.orderID != order.ID.UUID() || op.DeletedAt != nil {
+			continue
+		}
+
+		if p, ok := t.products[key.productID]; ok {
+			p = productRow(p)
+			op.Product = &p
+		}
+
+		orderProducts = append(orderProducts, op)
+	}
+
+	sort.Slice(orderProducts, func(i, j int) bool {
+		return orderProducts[i].CreatedAt.Before(orderProducts[j].CreatedAt)
+	})
+
+	return orderProducts
+}
+
+func sortOrders(orders entities.Orders, order vObject.OrderSort) {
+	sort.Slice(orders, func(i, j int) bool {
+		a, b := orders[i], orders[j]
+		ida, idb := a.ID.String(), b.ID.String()
+
+		switch order {
+		case vObject.OrderSortOldest:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+		case vObject.OrderSortTotalAsc:
+			if a.TotalPrice != b.TotalPrice {
+				return a.TotalPrice < b.TotalPrice
+			}
+		case vObject.OrderSortTotalDesc:
+			if a.TotalPrice != b.TotalPrice {
+				return a.TotalPrice > b.TotalPrice
+			}
+
+			return ida > idb
+		default:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+
+			return ida > idb
+		}
+
+		return ida < idb
+	})
+}
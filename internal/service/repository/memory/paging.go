@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/google/uuid"
+
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+)
+
+// page cuts the page out of the sorted items the same way as postgres does.
+// By page number it stores the total in qos and slices by offset. By cursor it
+// orders the items by id (descending when desc), skips the items up to the
+// cursor and stores the cursors of the neighbour pages in qos.
+func page[T any](items []T, qos queryOptions.MetaQueryOptionable, desc bool, id func(T) uuid.UUID) []T {
+	if qos.ForCursor() == nil {
+		qos.WithMetaTotal(len(items))
+
+		offset := min(int(qos.ForOffset()), len(items))
+		limit := min(offset+int(qos.ForLimit()), len(items))
+
+		return append([]T{}, items[offset:limit]...)
+	}
+
+	from, ascending := qos.ForKeyset(desc)
+
+	compare := func(a, b uuid.UUID) int {
+		if ascending {
+			return bytes.Compare(a[:], b[:])
+		}
+
+		return bytes.Compare(b[:], a[:])
+	}
+
+	rows := make([]T, 0, len(items))
+
+	for _, item := range items {
+		if from == nil || compare(id(item), *from) > 0 {
+			rows = append(rows, item)
+		}
+	}
+
+	slices.SortFunc(rows, func(a, b T) int { return compare(id(a), id(b)) })
+
+	// the extra item tells whether there is a page further
+	rows = rows[:min(len(rows), int(qos.ForLimit())+1)]
+
+	return queryOptions.KeysetPage(qos, rows, id)
+}
@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	getMovements "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_movements"
+)
+
+type ProductMovementRepository struct {
+	storage *Storage
+}
+
+var (
+	_ createProductMovements.ProductMovementsCreator = (*ProductMovementRepository)(nil)
+	_ getMovements.ProductMovementsGetter            = (*ProductMovementRepository)(nil)
+)
+
+func NewProductMovementRepository(storage *Storage) *ProductMovementRepository {
+	if storage == nil {
+		panic("storage is nil")
+	}
+
+	return &ProductMovementRepository{storage: storage}
+}
+
+func (r *ProductMovementRepository) CreateProductMovements(ctx context.Context, movements entities.ProductMovements) error {
+	return r.storage.do(ctx, func(data *tables) error {
+		for _, movement := range movements {
+			data.productMovements[movement.ID.UUID()] = movement
+		}
+
+		return nil
+	})
+}
+
+func (r *ProductMovementRepository) GetProductMovements(ctx context.Context, qos queryOptions.ProductMovementQueryOptionable) (entities.ProductMovements, error) {
+	movements := make(entities.ProductMovements, 0)
+
+	err := r.storage.do(ctx, func(data *tables) error {
+		if qos.ForProductID() == nil {
+			return nil
+		}
+
+		for _, movement := range data.productMovements {
+			if movement.ProductID != *qos.ForProductID() {
+				continue
+			}
+
+			if orderID := qos.ForOrderID(); orderID != nil && (movement.OrderID == nil || *movement.OrderID != *orderID) {
+				continue
+			}
+
+			movements = append(movements, movement)
+		}
+
+		return nil
+	})
+
+	// движения одного юзкейса создаются с одним временем, порядок между ними задают идентификаторы UUIDv7
+	sort.SliceStable(movements, func(i, j int) bool {
+		if !movements[i].CreatedAt.Equal(movements[j].CreatedAt) {
+			return movements[i].CreatedAt.Before(movements[j].CreatedAt)
+		}
+
+		return movements[i].ID.UUID().String() < movements[j].ID.UUID().String()
+	})
+
+	return movements, err
+}
@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+
+	createProductPrice "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_price/create"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	getPrice "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_price"
+)
+
+type ProductPriceRepository struct {
+	storage *Storage
+}
+
+var (
+	_ createProductPrice.ProductPriceCreator = (*ProductPriceRepository)(nil)
+	_ getPrice.ProductPriceGetter            = (*ProductPriceRepository)(nil)
+)
+
+func NewProductPriceRepository(storage *Storage) *ProductPriceRepository {
+	if storage == nil {
+		panic("storage is nil")
+	}
+
+	return &ProductPriceRepository{storage: storage}
+}
+
+func (r *ProductPriceRepository) CreateProductPrice(ctx context.Context, price *entities.ProductPrice) error {
+	return r.storage.do(ctx, func(data *tables) error {
+		data.productPrices[price.ID.UUID()] = *price
+
+		return nil
+	})
+}
+
+func (r *ProductPriceRepository) GetProductPrice(ctx context.Context, qos queryOptions.ProductPriceQueryOptionable) (*entities.ProductPrice, error) {
+	var price *entities.ProductPrice
+
+	err := r.storage.do(ctx, func(data *tables) error {
+		var prices entities.ProductPrices
+
+		for _, p := range data.productPrices {
+			if p.ProductID == *qos.ForProductID() {
+				prices = append(prices, p)
+			}
+		}
+
+		found, ok := prices.At(qos.At())
+		if !ok {
+			return entities.ErrProductPriceRecNotFound
+		}
+
+		price = &found
+
+		return nil
+	})
+
+	return price, err
+}
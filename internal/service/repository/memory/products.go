@@ -0,0 +1,189 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	createProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product/create"
+	updateProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product/update"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getProduct "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_product"
+	listProducts "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/list_products"
+)
+
+type ProductRepository struct {
+	storage *Storage
+}
+
+var (
+	_ createProduct.ProductCreator = (*ProductRepository)(nil)
+	_ updateProduct.ProductUpdater = (*ProductRepository)(nil)
+	_ getProduct.ProductGetter     = (*ProductRepository)(nil)
+	_ listProducts.ProductsLister  = (*ProductRepository)(nil)
+)
+
+func NewProductRepository(storage *Storage) *ProductRepository {
+	if storage == nil {
+		panic("storage is nil")
+	}
+
+	return &ProductRepository{storage: storage}
+}
+
+func (r *ProductRepository) CreateProduct(ctx context.Context, product *entities.Product) error {
+	return r.storage.do(ctx, func(data *tables) error {
+		if _, ok := data.products[product.ID.UUID()]; ok {
+			return fmt.Errorf("[memory.CreateProduct] product %s already exists", product.ID)
+		}
+
+		data.products[product.ID.UUID()] = productRow(*product)
+
+		return nil
+	})
+}
+
+func (r *ProductRepository) UpdateProduct(ctx context.Context, product *entities.Product) error {
+	return r.storage.do(ctx, func(data *tables) error {
+		if _, ok := data.products[product.ID.UUID()]; !ok {
+			return fmt.Errorf("[memory.UpdateProduct] %w", entities.ErrProductRecNotFound)
+		}
+
+		data.products[product.ID.UUID()] = productRow(*product)
+
+		return nil
+	})
+}
+
+func (r *ProductRepository) GetProduct(ctx context.Context, qos queryOptions.ProductQueryOptionable) (*entities.Product, error) {
+	var product *entities.Product
+
+	err := r.storage.do(ctx, func(data *tables) error {
+		if qos.ForProductID() == nil {
+			return fmt.Errorf("[memory.GetProduct] %w", entities.ErrProductRecNotFound)
+		}
+
+		p, ok := data.products[qos.ForProductID().UUID()]
+		if !ok || p.DeletedAt != nil {
+			return fmt.Errorf("[memory.GetProduct] %w", entities.ErrProductRecNotFound)
+		}
+
+		p = productRow(p)
+		product = &p
+
+		return nil
+	})
+
+	return product, err
+}
+
+// ListProducts фильтрует товары так же, как postgres. Полнотекстовый поиск упрощён:
+// товар подходит, если каждое слово запроса входит в название или описание,
+// а релевантность — число слов, найденных в названии.
+func (r *ProductRepository) ListProducts(ctx context.Context, qos queryOptions.ProductQueryOptionable) (entities.Products, error) {
+	var products entities.Products
+
+	words := strings.Fields(strings.ToLower(qos.ForSearch()))
+
+	err := r.storage.do(ctx, func(data *tables) error {
+		available := make(map[vObject.ProductID]bool)
+		for _, stock := range data.stocks {
+			if stock.AvailableQuantity > stock.ReservedQuantity {
+				available[stock.ProductID] = true
+			}
+		}
+
+		tags, match := qos.ForTags()
+		title := strings.ToLower(qos.ForTitle())
+
+		for _, p := range data.products {
+			switch {
+			case p.DeletedAt != nil,
+				!match.Matches(p.Tags, tags),
+				!strings.Contains(strings.ToLower(string(p.Title)), title),
+				!containsWords(string(p.Title)+" "+string(p.Description), words),
+				qos.ForMinPrice() != nil && p.Price < *qos.ForMinPrice(),
+				qos.ForMaxPrice() != nil && p.Price > *qos.ForMaxPrice(),
+				qos.IsInStockOnly() && !available[p.ID]:
+				continue
+			}
+
+			products = append(products, productRow(p))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortProducts(products, qos.ForSort(), words)
+
+	return page(products, qos, true, func(p entities.Product) uuid.UUID { return p.ID.UUID() }), nil
+}
+
+func containsWords(text string, words []string) bool {
+	text = strings.ToLower(text)
+
+	for _, word := range words {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sortProducts(products entities.Products, order vObject.ProductSort, words []string) {
+	relevance := func(p entities.Product) int {
+		title := strings.ToLower(string(p.Title))
+		rank := 0
+
+		for _, word := range words {
+			if strings.Contains(title, word) {
+				rank++
+			}
+		}
+
+		return rank
+	}
+
+	sort.Slice(products, func(i, j int) bool {
+		a, b := products[i], products[j]
+		ida, idb := a.ID.String(), b.ID.String()
+
+		switch order {
+		case vObject.ProductSortTitle:
+			if a.Title != b.Title {
+				return a.Title < b.Title
+			}
+		case vObject.ProductSortPriceAsc:
+			if a.Price != b.Price {
+				return a.Price < b.Price
+			}
+		case vObject.ProductSortPriceDesc:
+			if a.Price != b.Price {
+				return a.Price > b.Price
+			}
+
+			return ida > idb
+		case vObject.ProductSortRelevance:
+			if ra, rb := relevance(a), relevance(b); ra != rb {
+				return ra > rb
+			}
+		default:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+
+			return ida > idb
+		}
+
+		return ida < idb
+	})
+}
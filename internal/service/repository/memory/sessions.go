@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	revokeUserSessions "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/revoke_by_user"
+	upsertSession "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/upsert"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getSession "github.com/smgladkovskiy/warehouse-task/internal/service/queries/session/get_session"
+)
+
+type SessionRepository struct {
+	storage *Storage
+}
+
+var (
+	_ getSession.SessionGetter               = (*SessionRepository)(nil)
+	_ upsertSession.SessionUpserter          = (*SessionRepository)(nil)
+	_ revokeUserSessions.UserSessionsRevoker = (*SessionRepository)(nil)
+)
+
+func NewSessionRepository(storage *Storage) *SessionRepository {
+	if storage == nil {
+		panic("storage is nil")
+	}
+
+	return &SessionRepository{storage: storage}
+}
+
+func (r *SessionRepository) GetSession(ctx context.Context, qos queryOptions.SessionQueryOptionable) (*entities.Session, error) {
+	var session *entities.Session
+
+	err := r.storage.do(ctx, func(data *tables) error {
+		if qos.ForSessionID() == nil {
+			return fmt.Errorf("[memory.GetSession] %w", entities.ErrSessionRecNotFound)
+		}
+
+		s, ok := data.sessions[qos.ForSessionID().UUID()]
+		if !ok {
+			return fmt.Errorf("[memory.GetSession] %w", entities.ErrSessionRecNotFound)
+		}
+
+		session = &s
+
+		return nil
+	})
+
+	return session, err
+}
+
+func (r *SessionRepository) UpsertSession(ctx context.Context, session *entities.Session) error {
+	return r.storage.do(ctx, func(data *tables) error {
+		data.sessions[session.ID.UUID()] = *session
+
+		return nil
+	})
+}
+
+func (r *SessionRepository) RevokeUserSessions(ctx context.Context, userID vObject.UserID, revokedAt time.Time) error {
+	return r.storage.do(ctx, func(data *tables) error {
+		for id, session := range data.sessions {
+			if session.UserID != userID || session.RevokedAt != nil {
+				continue
+			}
+
+			session.RevokedAt = &revokedAt
+			session.UpdatedAt = revokedAt
+			data.sessions[id] = session
+		}
+
+		return nil
+	})
+}
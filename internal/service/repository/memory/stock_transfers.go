@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	upsertStockTransfer "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock_transfer/upsert"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	getTransfer "github.com/smgladkovskiy/warehouse-task/internal/service/queries/stock_transfer/get_transfer"
+)
+
+type StockTransferRepository struct {
+	storage *Storage
+}
+
+var (
+	_ getTransfer.StockTransferGetter           = (*StockTransferRepository)(nil)
+	_ upsertStockTransfer.StockTransferUpserter = (*StockTransferRepository)(nil)
+)
+
+func NewStockTransferRepository(storage *Storage) *StockTransferRepository {
+	if storage == nil {
+		panic("storage is nil")
+	}
+
+	return &StockTransferRepository{storage: storage}
+}
+
+func (r *StockTransferRepository) GetStockTransfer(ctx context.Context, qos queryOptions.StockTransferQueryOptionable) (*entities.StockTransfer, error) {
+	var transfer *entities.StockTransfer
+
+	err := r.storage.do(ctx, func(data *tables) error {
+		if qos.ForStockTransferID() == nil {
+			return fmt.Errorf("[memory.GetStockTransfer] %w", entities.ErrStockTransferRecNotFound)
+		}
+
+		t, ok := data.stockTransfers[qos.ForStockTransferID().UUID()]
+		if !ok {
+			return fmt.Errorf("[memory.GetStockTransfer] %w", entities.ErrStockTransferRecNotFound)
+		}
+
+		transfer = &t
+
+		return nil
+	})
+
+	return transfer, err
+}
+
+func (r *StockTransferRepository) UpsertStockTransfer(ctx context.Context, transfer *entities.StockTransfer) error {
+	return r.storage.do(ctx, func(data *tables) error {
+		data.stockTransfers[transfer.ID.UUID()] = *transfer
+
+		return nil
+	})
+}
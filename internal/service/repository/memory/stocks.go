@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	createStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+)
+
+type StockRepository struct {
+	storage *Storage
+}
+
+var (
+	_ getStocks.StocksGetter   = (*StockRepository)(nil)
+	_ createStock.StockCreator = (*StockRepository)(nil)
+	_ updateStock.StockUpdater = (*StockRepository)(nil)
+)
+
+func NewStockRepository(storage *Storage) *StockRepository {
+	if storage == nil {
+		panic("storage is nil")
+	}
+
+	return &StockRepository{storage: storage}
+}
+
+func (r *StockRepository) GetStocks(ctx context.Context, qos queryOptions.StockQueryOptionable) (entities.Stocks, error) {
+	stocks := make(entities.Stocks, 0)
+
+	err := r.storage.do(ctx, func(data *tables) error {
+		if qos.ForProductID() == nil {
+			return nil
+		}
+
+		productID := qos.ForProductID().UUID()
+
+		for key, stock := range data.stocks {
+			if key.productID == productID {
+				stocks = append(stocks, stock)
+			}
+		}
+
+		return nil
+	})
+
+	sort.Slice(stocks, func(i, j int) bool {
+		a, b := stocks[i].WarehouseID.UUID(), stocks[j].WarehouseID.UUID()
+
+		return bytes.Compare(a[:], b[:]) < 0
+	})
+
+	return stocks, err
+}
+
+func (r *StockRepository) CreateStock(ctx context.Context, stock *entities.Stock) error {
+	return r.storage.do(ctx, func(data *tables) error {
+		key := stockKey{productID: stock.ProductID.UUID(), warehouseID: stock.WarehouseID.UUID()}
+
+		if _, ok := data.stocks[key]; !ok {
+			data.stocks[key] = *stock
+		}
+
+		return nil
+	})
+}
+
+func (r *StockRepository) UpdateStock(ctx context.Context, stock *entities.Stock) error {
+	return r.storage.do(ctx, func(data *tables) error {
+		key := stockKey{productID: stock.ProductID.UUID(), warehouseID: stock.WarehouseID.UUID()}
+
+		stored, ok := data.stocks[key]
+		if !ok {
+			return nil
+		}
+
+		stored.AvailableQuantity = stock.AvailableQuantity
+		stored.ReservedQuantity = stock.ReservedQuantity
+		stored.InTransitQuantity = stock.InTransitQuantity
+		data.stocks[key] = stored
+
+		return nil
+	})
+}
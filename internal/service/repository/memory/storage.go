@@ -0,0 +1,162 @@
+// Package memory contains thread-safe in-memory implementations of the
+// repositories and the transaction manager. It is used in tests and for local
+// runs without postgres.
+package memory
+
+import (
+	"context"
+	"maps"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+)
+
+// Storage keeps the entities in memory. All repositories created over the same
+// Storage share data and transactions.
+//
+// Transactions are serialized: a transaction holds the storage lock from
+// begin till commit or rollback, so it behaves like postgres SERIALIZABLE
+// isolation with FOR UPDATE locks on everything read.
+type Storage struct {
+	mu   sync.Mutex
+	data tables
+}
+
+type orderProductKey struct {
+	orderID   uuid.UUID
+	productID uuid.UUID
+}
+
+type stockKey struct {
+	productID   uuid.UUID
+	warehouseID uuid.UUID
+}
+
+type tables struct {
+	users              map[uuid.UUID]entities.User
+	orders             map[uuid.UUID]entities.Order
+	orderProducts      map[orderProductKey]entities.OrderProduct
+	orderStatusHistory map[uuid.UUID]entities.OrderStatusHistory
+	products           map[uuid.UUID]entities.Product
+	productPrices      map[uuid.UUID]entities.ProductPrice
+	productMovements   map[uuid.UUID]entities.ProductMovement
+	stocks             map[stockKey]entities.Stock
+	stockTransfers     map[uuid.UUID]entities.StockTransfer
+	sessions           map[uuid.UUID]entities.Session
+	userTokens         map[string]entities.UserToken
+}
+
+// NewStorage creates an empty storage.
+func NewStorage() *Storage {
+	return &Storage{
+		data: tables{
+			users:              make(map[uuid.UUID]entities.User),
+			orders:             make(map[uuid.UUID]entities.Order),
+			orderProducts:      make(map[orderProductKey]entities.OrderProduct),
+			orderStatusHistory: make(map[uuid.UUID]entities.OrderStatusHistory),
+			products:           make(map[uuid.UUID]entities.Product),
+			productPrices:      make(map[uuid.UUID]entities.ProductPrice),
+			productMovements:   make(map[uuid.UUID]entities.ProductMovement),
+			stocks:             make(map[stockKey]entities.Stock),
+			stockTransfers:     make(map[uuid.UUID]entities.StockTransfer),
+			sessions:           make(map[uuid.UUID]entities.Session),
+			userTokens:         make(map[string]entities.UserToken),
+		},
+	}
+}
+
+// snapshot copies the tables. Stored values are never mutated in place, so
+// copying the maps is enough.
+func (t tables) snapshot() tables {
+	return tables{
+		users:              maps.Clone(t.users),
+		orders:             maps.Clone(t.orders),
+		orderProducts:      maps.Clone(t.orderProducts),
+		orderStatusHistory: maps.Clone(t.orderStatusHistory),
+		products:           maps.Clone(t.products),
+		productPrices:      maps.Clone(t.productPrices),
+		productMovements:   maps.Clone(t.productMovements),
+		stocks:             maps.Clone(t.stocks),
+		stockTransfers:     maps.Clone(t.stockTransfers),
+		sessions:           maps.Clone(t.sessions),
+		userTokens:         maps.Clone(t.userTokens),
+	}
+}
+
+type txKey struct{}
+
+// inTx reports whether ctx belongs to a transaction of this storage.
+func (s *Storage) inTx(ctx context.Context) bool {
+	owner, _ := ctx.Value(txKey{}).(*Storage)
+
+	return owner == s
+}
+
+// do runs fn with the storage locked unless ctx already holds the lock.
+func (s *Storage) do(ctx context.Context, fn func(data *tables) error) error {
+	if !s.inTx(ctx) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+
+	return fn(&s.data)
+}
+
+// AddProduct puts the product into the storage.
+func (s *Storage) AddProduct(ctx context.Context, product entities.Product) {
+	_ = s.do(ctx, func(data *tables) error {
+		data.products[product.ID.UUID()] = productRow(product)
+
+		return nil
+	})
+}
+
+// AddUser puts the user into the storage.
+func (s *Storage) AddUser(ctx context.Context, user entities.User) {
+	_ = s.do(ctx, func(data *tables) error {
+		data.users[user.ID.UUID()] = userRow(user)
+
+		return nil
+	})
+}
+
+// AddStock puts the stock into the storage.
+func (s *Storage) AddStock(ctx context.Context, stock entities.Stock) {
+	_ = s.do(ctx, func(data *tables) error {
+		data.stocks[stockKey{productID: stock.ProductID.UUID(), warehouseID: stock.WarehouseID.UUID()}] = stock
+
+		return nil
+	})
+}
+
+func userRow(user entities.User) entities.User {
+	user.Orders = nil
+
+	return user
+}
+
+func orderRow(order entities.Order) entities.Order {
+	order.User = nil
+	order.Products = nil
+
+	return order
+}
+
+func orderProductRow(orderProduct entities.OrderProduct) entities.OrderProduct {
+	orderProduct.Order = nil
+	orderProduct.Product = nil
+	orderProduct.Allocations = append(orderProduct.Allocations[:0:0], orderProduct.Allocations...)
+
+	return orderProduct
+}
+
+func productRow(product entities.Product) entities.Product {
+	product.Tags = append(product.Tags[:0:0], product.Tags...)
+	product.Remains = nil
+	product.Movements = nil
+	product.Orders = nil
+
+	return product
+}
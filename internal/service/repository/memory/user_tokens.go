@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	invalidateUserTokens "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/invalidate"
+	upsertUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/upsert"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	getUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user_token/get_user_token"
+)
+
+type UserTokenRepository struct {
+	storage *Storage
+}
+
+var (
+	_ getUserToken.UserTokenGetter               = (*UserTokenRepository)(nil)
+	_ upsertUserToken.UserTokenUpserter          = (*UserTokenRepository)(nil)
+	_ invalidateUserTokens.UserTokensInvalidator = (*UserTokenRepository)(nil)
+)
+
+func NewUserTokenRepository(storage *Storage) *UserTokenRepository {
+	if storage == nil {
+		panic("storage is nil")
+	}
+
+	return &UserTokenRepository{storage: storage}
+}
+
+func (r *UserTokenRepository) GetUserToken(
+	ctx context.Context,
+	qos queryOptions.UserTokenQueryOptionable,
+) (*entities.UserToken, error) {
+	var token *entities.UserToken
+
+	err := r.storage.do(ctx, func(data *tables) error {
+		t, ok := data.userTokens[qos.ForUserTokenHash()]
+		if !ok || t.Purpose != qos.ForUserTokenPurpose() {
+			return fmt.Errorf("[memory.GetUserToken] %w", entities.ErrUserTokenRecNotFound)
+		}
+
+		token = &t
+
+		return nil
+	})
+
+	return token, err
+}
+
+func (r *UserTokenRepository) UpsertUserToken(ctx context.Context, token *entities.UserToken) error {
+	return r.storage.do(ctx, func(data *tables) error {
+		data.userTokens[token.Hash] = *token
+
+		return nil
+	})
+}
+
+func (r *UserTokenRepository) InvalidateUserTokens(ctx context.Context, token *entities.UserToken) error {
+	return r.storage.do(ctx, func(data *tables) error {
+		for hash, t := range data.userTokens {
+			if hash == token.Hash || t.UserID != token.UserID || t.Purpose != token.Purpose || t.UsedAt != nil {
+				continue
+			}
+
+			t.UsedAt = token.UsedAt
+			data.userTokens[hash] = t
+		}
+
+		return nil
+	})
+}
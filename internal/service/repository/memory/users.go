@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	createUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/create"
+	updateUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/update"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	getUserByEmail "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_by_email"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+)
+
+type UserRepository struct {
+	storage *Storage
+}
+
+var (
+	_ createUser.UserCreator    = (*UserRepository)(nil)
+	_ updateUser.UserUpdater    = (*UserRepository)(nil)
+	_ getUserByEmail.UserGetter = (*UserRepository)(nil)
+	_ getUser.UserByIDGetter    = (*UserRepository)(nil)
+)
+
+func NewUserRepository(storage *Storage) *UserRepository {
+	if storage == nil {
+		panic("storage is nil")
+	}
+
+	return &UserRepository{storage: storage}
+}
+
+func (r *UserRepository) CreateUser(ctx context.Context, user *entities.User) error {
+	return r.storage.do(ctx, func(data *tables) error {
+		for _, u := range data.users {
+			if u.Email == user.Email && u.DeletedAt == nil {
+				return fmt.Errorf("[memory.CreateUser] %w", entities.ErrUserAlreadyExists)
+			}
+		}
+
+		if _, ok := data.users[user.ID.UUID()]; ok {
+			return fmt.Errorf("[memory.CreateUser] %w", entities.ErrUserAlreadyExists)
+		}
+
+		data.users[user.ID.UUID()] = userRow(*user)
+
+		return nil
+	})
+}
+
+func (r *UserRepository) UpdateUser(ctx context.Context, user *entities.User) error {
+	return r.storage.do(ctx, func(data *tables) error {
+		if _, ok := data.users[user.ID.UUID()]; !ok {
+			return fmt.Errorf("[memory.UpdateUser] %w", entities.ErrUserRecNotFound)
+		}
+
+		data.users[user.ID.UUID()] = userRow(*user)
+
+		return nil
+	})
+}
+
+func (r *UserRepository) GetByEmail(ctx context.Context, qos queryOptions.UserQueryOptionable) (*entities.User, error) {
+	var user *entities.User
+
+	err := r.storage.do(ctx, func(data *tables) error {
+		for _, u := range data.users {
+			if u.Email == qos.ForUserEmail() && u.DeletedAt == nil {
+				user = &u
+
+				return nil
+			}
+		}
+
+		return fmt.Errorf("[memory.GetByEmail] %w", entities.ErrUserRecNotFound)
+	})
+
+	return user, err
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, qos queryOptions.UserQueryOptionable) (*entities.User, error) {
+	var user *entities.User
+
+	err := r.storage.do(ctx, func(data *tables) error {
+		if qos.ForUserID() == nil {
+			return fmt.Errorf("[memory.GetByID] %w", entities.ErrUserRecNotFound)
+		}
+
+		u, ok := data.users[qos.ForUserID().UUID()]
+		if !ok || u.DeletedAt != nil {
+			return fmt.Errorf("[memory.GetByID] %w", entities.ErrUserRecNotFound)
+		}
+
+		user = &u
+
+		return nil
+	})
+
+	return user, err
+}
@@ -0,0 +1,81 @@
+CREATE TABLE users (
+    id             uuid PRIMARY KEY,
+    email          text        NOT NULL,
+    first_name     text        NOT NULL,
+    last_name      text        NOT NULL,
+    birth_date     date        NOT NULL,
+    marital_status text        NOT NULL,
+    password_hash  text        NOT NULL,
+    created_at     timestamptz NOT NULL,
+    updated_at     timestamptz NOT NULL,
+    deleted_at     timestamptz
+);
+
+CREATE UNIQUE INDEX users_email_uidx ON users (email) WHERE deleted_at IS NULL;
+
+CREATE TABLE warehouses (
+    id         uuid PRIMARY KEY,
+    name       text        NOT NULL,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    deleted_at timestamptz
+);
+
+CREATE TABLE products (
+    id          uuid PRIMARY KEY,
+    title       text        NOT NULL,
+    description text        NOT NULL DEFAULT '',
+    tags        text[]      NOT NULL DEFAULT '{}',
+    price       bigint      NOT NULL CHECK (price >= 0),
+    created_at  timestamptz NOT NULL,
+    updated_at  timestamptz NOT NULL,
+    deleted_at  timestamptz
+);
+
+CREATE TABLE stocks (
+    product_id         uuid        NOT NULL REFERENCES products (id),
+    warehouse_id       uuid        NOT NULL REFERENCES warehouses (id),
+    available_quantity bigint      NOT NULL DEFAULT 0 CHECK (available_quantity >= 0),
+    reserved_quantity  bigint      NOT NULL DEFAULT 0 CHECK (reserved_quantity >= 0),
+    created_at         timestamptz NOT NULL,
+    PRIMARY KEY (product_id, warehouse_id)
+);
+
+CREATE INDEX stocks_warehouse_id_idx ON stocks (warehouse_id);
+
+CREATE TABLE product_movements (
+    id             uuid PRIMARY KEY,
+    product_id     uuid        NOT NULL REFERENCES products (id),
+    warehouse_id   uuid        NOT NULL REFERENCES warehouses (id),
+    operation_type text        NOT NULL CHECK (operation_type IN ('income', 'reserve', 'sale', 'transfer', 'write_off')),
+    quantity       bigint      NOT NULL,
+    price          bigint      NOT NULL DEFAULT 0,
+    created_at     timestamptz NOT NULL
+);
+
+CREATE INDEX product_movements_product_warehouse_idx ON product_movements (product_id, warehouse_id, created_at);
+
+CREATE TABLE orders (
+    id          uuid PRIMARY KEY,
+    user_id     uuid        NOT NULL REFERENCES users (id),
+    status      text        NOT NULL CHECK (status IN ('created', 'paid', 'ordered', 'shipped', 'received', 'returned', 'canceled')),
+    total_price bigint      NOT NULL DEFAULT 0,
+    created_at  timestamptz NOT NULL,
+    updated_at  timestamptz NOT NULL,
+    deleted_at  timestamptz
+);
+
+CREATE INDEX orders_user_id_created_at_idx ON orders (user_id, created_at);
+
+CREATE TABLE order_products (
+    order_id   uuid        NOT NULL REFERENCES orders (id),
+    product_id uuid        NOT NULL REFERENCES products (id),
+    quantity   bigint      NOT NULL CHECK (quantity >= 0),
+    price      bigint      NOT NULL CHECK (price >= 0),
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    deleted_at timestamptz,
+    PRIMARY KEY (order_id, product_id)
+);
+
+CREATE INDEX order_products_product_id_idx ON order_products (product_id);
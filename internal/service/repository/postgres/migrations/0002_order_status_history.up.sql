@@ -0,0 +1,11 @@
+CREATE TABLE order_status_history (
+    id          uuid PRIMARY KEY,
+    order_id    uuid        NOT NULL REFERENCES orders (id),
+    from_status text        NOT NULL,
+    to_status   text        NOT NULL,
+    actor_id    uuid REFERENCES users (id),
+    reason      text        NOT NULL DEFAULT '',
+    created_at  timestamptz NOT NULL
+);
+
+CREATE INDEX order_status_history_order_id_created_at_idx ON order_status_history (order_id, created_at);
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS product_movements_order_product_idx;
+
+DELETE FROM product_movements WHERE operation_type = 'unreserve';
+
+ALTER TABLE product_movements
+    DROP CONSTRAINT product_movements_operation_type_check,
+    ADD CONSTRAINT product_movements_operation_type_check
+        CHECK (operation_type IN ('income', 'reserve', 'sale', 'transfer', 'write_off'));
+
+ALTER TABLE product_movements
+    DROP COLUMN order_id;
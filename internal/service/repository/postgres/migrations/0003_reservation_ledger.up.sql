@@ -0,0 +1,9 @@
+ALTER TABLE product_movements
+    ADD COLUMN order_id uuid REFERENCES orders (id);
+
+ALTER TABLE product_movements
+    DROP CONSTRAINT product_movements_operation_type_check,
+    ADD CONSTRAINT product_movements_operation_type_check
+        CHECK (operation_type IN ('income', 'reserve', 'unreserve', 'sale', 'transfer', 'write_off'));
+
+CREATE INDEX product_movements_order_product_idx ON product_movements (order_id, product_id) WHERE order_id IS NOT NULL;
@@ -0,0 +1,18 @@
+ALTER TABLE order_products
+    ADD COLUMN allocations jsonb NOT NULL DEFAULT '[]';
+
+-- распределение по складам для уже существующих позиций восстанавливается из журнала резервов
+UPDATE order_products op
+SET allocations = COALESCE((
+    SELECT jsonb_agg(jsonb_build_object('warehouse_id', r.warehouse_id, 'quantity', r.quantity))
+    FROM (
+        SELECT pm.warehouse_id,
+               SUM(CASE pm.operation_type WHEN 'reserve' THEN pm.quantity ELSE -pm.quantity END) AS quantity
+        FROM product_movements pm
+        WHERE pm.order_id = op.order_id
+          AND pm.product_id = op.product_id
+          AND pm.operation_type IN ('reserve', 'unreserve')
+        GROUP BY pm.warehouse_id
+    ) r
+    WHERE r.quantity > 0
+), '[]');
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS product_movements_transfer_idx;
+
+ALTER TABLE product_movements
+    DROP COLUMN transfer_id;
+
+DROP TABLE stock_transfers;
+
+ALTER TABLE stocks
+    DROP COLUMN in_transit_quantity;
@@ -0,0 +1,21 @@
+ALTER TABLE stocks
+    ADD COLUMN in_transit_quantity bigint NOT NULL DEFAULT 0 CHECK (in_transit_quantity >= 0);
+
+CREATE TABLE stock_transfers (
+    id                uuid PRIMARY KEY,
+    product_id        uuid        NOT NULL REFERENCES products (id),
+    from_warehouse_id uuid        NOT NULL REFERENCES warehouses (id),
+    to_warehouse_id   uuid        NOT NULL REFERENCES warehouses (id),
+    quantity          bigint      NOT NULL CHECK (quantity > 0),
+    status            text        NOT NULL CHECK (status IN ('in_transit', 'received')),
+    dispatched_at     timestamptz NOT NULL,
+    received_at       timestamptz,
+    CHECK (from_warehouse_id <> to_warehouse_id)
+);
+
+CREATE INDEX stock_transfers_to_warehouse_status_idx ON stock_transfers (to_warehouse_id, status);
+
+ALTER TABLE product_movements
+    ADD COLUMN transfer_id uuid REFERENCES stock_transfers (id);
+
+CREATE INDEX product_movements_transfer_idx ON product_movements (transfer_id) WHERE transfer_id IS NOT NULL;
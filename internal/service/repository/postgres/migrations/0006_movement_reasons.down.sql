@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS product_movements_document_ref_idx;
+
+ALTER TABLE product_movements
+    DROP CONSTRAINT product_movements_reason_check,
+    DROP COLUMN document_ref,
+    DROP COLUMN reason;
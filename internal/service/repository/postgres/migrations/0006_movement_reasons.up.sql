@@ -0,0 +1,13 @@
+ALTER TABLE product_movements
+    ADD COLUMN reason       text,
+    ADD COLUMN document_ref text;
+
+-- причина обязательна для поступлений и списаний новых движений; ранее созданные строки не проверяются
+ALTER TABLE product_movements
+    ADD CONSTRAINT product_movements_reason_check CHECK (
+        (operation_type = 'income' AND reason IN ('purchase', 'customer_return', 'inventory_surplus'))
+            OR (operation_type = 'write_off' AND reason IN ('damage', 'expired', 'loss', 'inventory_shortage'))
+            OR (operation_type NOT IN ('income', 'write_off') AND reason IS NULL)
+        ) NOT VALID;
+
+CREATE INDEX product_movements_document_ref_idx ON product_movements (document_ref) WHERE document_ref IS NOT NULL;
@@ -0,0 +1,5 @@
+DROP TABLE user_sessions;
+
+ALTER TABLE users
+    DROP COLUMN locked_until,
+    DROP COLUMN failed_login_attempts;
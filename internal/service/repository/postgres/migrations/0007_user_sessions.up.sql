@@ -0,0 +1,15 @@
+ALTER TABLE users
+    ADD COLUMN failed_login_attempts integer NOT NULL DEFAULT 0 CHECK (failed_login_attempts >= 0),
+    ADD COLUMN locked_until          timestamptz;
+
+CREATE TABLE user_sessions (
+    id               uuid PRIMARY KEY,
+    user_id          uuid        NOT NULL REFERENCES users (id),
+    refresh_token_id uuid        NOT NULL,
+    expires_at       timestamptz NOT NULL,
+    revoked_at       timestamptz,
+    created_at       timestamptz NOT NULL,
+    updated_at       timestamptz NOT NULL
+);
+
+CREATE INDEX user_sessions_user_idx ON user_sessions (user_id) WHERE revoked_at IS NULL;
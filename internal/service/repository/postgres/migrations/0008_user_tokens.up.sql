@@ -0,0 +1,13 @@
+ALTER TABLE users
+    ADD COLUMN email_verified_at timestamptz;
+
+CREATE TABLE user_tokens (
+    token_hash text PRIMARY KEY,
+    user_id    uuid        NOT NULL REFERENCES users (id),
+    purpose    text        NOT NULL CHECK (purpose IN ('email_verification', 'password_reset')),
+    expires_at timestamptz NOT NULL,
+    used_at    timestamptz,
+    created_at timestamptz NOT NULL
+);
+
+CREATE INDEX user_tokens_user_idx ON user_tokens (user_id, purpose) WHERE used_at IS NULL;
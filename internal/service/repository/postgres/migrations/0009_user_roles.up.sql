@@ -0,0 +1,2 @@
+ALTER TABLE users
+    ADD COLUMN role text NOT NULL DEFAULT 'customer' CHECK (role IN ('customer', 'operator', 'admin'));
@@ -0,0 +1,14 @@
+CREATE TABLE product_prices (
+    id             uuid PRIMARY KEY,
+    product_id     uuid        NOT NULL REFERENCES products (id),
+    price          bigint      NOT NULL CHECK (price >= 0),
+    effective_from timestamptz NOT NULL,
+    actor_id       uuid REFERENCES users (id)
+);
+
+CREATE INDEX product_prices_product_id_effective_from_idx ON product_prices (product_id, effective_from);
+
+-- текущие цены каталога становятся первой записью истории и действуют с момента создания товара
+INSERT INTO product_prices (id, product_id, price, effective_from)
+SELECT gen_random_uuid(), id, price, created_at
+FROM products;
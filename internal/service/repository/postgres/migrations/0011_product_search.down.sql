@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS products_created_at_idx;
+DROP INDEX IF EXISTS products_price_idx;
+DROP INDEX IF EXISTS products_title_trgm_idx;
+DROP INDEX IF EXISTS products_tags_idx;
+DROP INDEX IF EXISTS products_search_vector_idx;
+
+ALTER TABLE products DROP COLUMN IF EXISTS search_vector;
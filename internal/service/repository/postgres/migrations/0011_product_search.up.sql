@@ -0,0 +1,14 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- поисковый вектор названия и описания, совпадения в названии весят больше
+ALTER TABLE products
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', title), 'A') ||
+        setweight(to_tsvector('russian', description), 'B')
+    ) STORED;
+
+CREATE INDEX products_search_vector_idx ON products USING GIN (search_vector) WHERE deleted_at IS NULL;
+CREATE INDEX products_tags_idx ON products USING GIN (tags) WHERE deleted_at IS NULL;
+CREATE INDEX products_title_trgm_idx ON products USING GIN (title gin_trgm_ops) WHERE deleted_at IS NULL;
+CREATE INDEX products_price_idx ON products (price) WHERE deleted_at IS NULL;
+CREATE INDEX products_created_at_idx ON products (created_at) WHERE deleted_at IS NULL;
@@ -0,0 +1,9 @@
+// Package migrations contains the postgres schema of the service.
+package migrations
+
+import "embed"
+
+// FS contains versioned migration scripts, see internal/pkg/migrate.
+//
+//go:embed *.sql
+var FS embed.FS
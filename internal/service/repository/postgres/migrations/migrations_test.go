@@ -0,0 +1,23 @@
+package migrations_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/migrate"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/migrations"
+)
+
+func TestFS(t *testing.T) {
+	t.Parallel()
+
+	ms, err := migrate.Load(migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, ms)
+
+	for _, m := range ms {
+		assert.NotEmpty(t, m.Down, "migration %d_%s must be reversible", m.Version, m.Name)
+	}
+}
@@ -0,0 +1,3 @@
+// Package models contains GORM row structures of the postgres repositories
+// and their mapping to the service entities and back.
+package models
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+// OrderRow is a row of the orders table.
+type OrderRow struct {
+	ID         uuid.UUID  `gorm:"column:id;primaryKey"`
+	UserID     uuid.UUID  `gorm:"column:user_id"`
+	Status     string     `gorm:"column:status"`
+	TotalPrice int64      `gorm:"column:total_price"`
+	CreatedAt  time.Time  `gorm:"column:created_at"`
+	UpdatedAt  time.Time  `gorm:"column:updated_at"`
+	DeletedAt  *time.Time `gorm:"column:deleted_at"`
+}
+
+func (OrderRow) TableName() string {
+	return "orders"
+}
+
+func NewOrderRow(order *entities.Order) OrderRow {
+	return OrderRow{
+		ID:         order.ID.UUID(),
+		UserID:     order.UserID.UUID(),
+		Status:     string(order.Status),
+		TotalPrice: int64(order.TotalPrice),
+		CreatedAt:  order.CreatedAt,
+		UpdatedAt:  order.UpdatedAt,
+		DeletedAt:  order.DeletedAt,
+	}
+}
+
+// ToEntity maps the order row with its products to the order entity.
+func (r OrderRow) ToEntity(products []OrderProductRow) *entities.Order {
+	order := entities.Order{
+		ID:         vObject.NewOrderIDFromUUIDUnsafe(r.ID),
+		UserID:     vObject.NewUserIDFromUUIDUnsafe(r.UserID),
+		Status:     vObject.OrderStatus(r.Status),
+		TotalPrice: vObject.NewPriceUnsafe(int(r.TotalPrice)),
+		CreatedAt:  r.CreatedAt,
+		UpdatedAt:  r.UpdatedAt,
+		DeletedAt:  r.DeletedAt,
+		Products:   make(entities.OrderProducts, 0, len(products)),
+	}
+
+	for _, product := range products {
+		order.Products = append(order.Products, product.ToEntity())
+	}
+
+	return &order
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+// OrderProductRow is a row of the order_products table.
+type OrderProductRow struct {
+	OrderID   uuid.UUID  `gorm:"column:order_id;primaryKey"`
+	ProductID uuid.UUID  `gorm:"column:product_id;primaryKey"`
+	Quantity  uint64     `gorm:"column:quantity"`
+	Price     int64      `gorm:"column:price"`
+	CreatedAt time.Time  `gorm:"column:created_at"`
+	UpdatedAt time.Time  `gorm:"column:updated_at"`
+	DeletedAt *time.Time `gorm:"column:deleted_at"`
+
+	Allocations []OrderProductAllocationRow `gorm:"column:allocations;serializer:json"`
+
+	Product *ProductRow `gorm:"foreignKey:ProductID;references:ID"`
+}
+
+// OrderProductAllocationRow is an element of the order_products.allocations jsonb array.
+type OrderProductAllocationRow struct {
+	WarehouseID uuid.UUID `json:"warehouse_id"`
+	Quantity    uint64    `json:"quantity"`
+}
+
+func (OrderProductRow) TableName() string {
+	return "order_products"
+}
+
+func NewOrderProductRow(orderProduct *entities.OrderProduct) OrderProductRow {
+	return OrderProductRow{
+		OrderID:   orderProduct.OrderID.UUID(),
+		ProductID: orderProduct.ProductID.UUID(),
+		Quantity:  orderProduct.Quantity.Uint64(),
+		Price:     int64(orderProduct.Price),
+		CreatedAt: orderProduct.CreatedAt,
+		UpdatedAt: orderProduct.UpdatedAt,
+		DeletedAt: orderProduct.DeletedAt,
+
+		Allocations: newOrderProductAllocationRows(orderProduct.Allocations),
+	}
+}
+
+func newOrderProductAllocationRows(allocations entities.OrderProductAllocations) []OrderProductAllocationRow {
+	rows := make([]OrderProductAllocationRow, 0, len(allocations))
+
+	for _, allocation := range allocations {
+		rows = append(rows, OrderProductAllocationRow{
+			WarehouseID: allocation.WarehouseID.UUID(),
+			Quantity:    allocation.Quantity.Uint64(),
+		})
+	}
+
+	return rows
+}
+
+func (r OrderProductRow) ToEntity() entities.OrderProduct {
+	op := entities.OrderProduct{
+		OrderID:   vObject.NewOrderIDFromUUIDUnsafe(r.OrderID),
+		ProductID: vObject.NewProductIDFromUUIDUnsafe(r.ProductID),
+		Quantity:  vObject.NewQuantityUnsafe(r.Quantity),
+		Price:     vObject.NewPriceUnsafe(int(r.Price)),
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+		DeletedAt: r.DeletedAt,
+	}
+
+	for _, allocation := range r.Allocations {
+		op.Allocations = append(op.Allocations, entities.OrderProductAllocation{
+			WarehouseID: vObject.NewWarehouseIDFromUUIDUnsafe(allocation.WarehouseID),
+			Quantity:    vObject.NewQuantityUnsafe(allocation.Quantity),
+		})
+	}
+
+	if r.Product != nil {
+		op.Product = r.Product.ToEntity()
+	}
+
+	return op
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+// OrderStatusHistoryRow is a row of the order_status_history table.
+type OrderStatusHistoryRow struct {
+	ID         uuid.UUID  `gorm:"column:id;primaryKey"`
+	OrderID    uuid.UUID  `gorm:"column:order_id"`
+	FromStatus string     `gorm:"column:from_status"`
+	ToStatus   string     `gorm:"column:to_status"`
+	ActorID    *uuid.UUID `gorm:"column:actor_id"`
+	Reason     string     `gorm:"column:reason"`
+	CreatedAt  time.Time  `gorm:"column:created_at"`
+}
+
+func (OrderStatusHistoryRow) TableName() string {
+	return "order_status_history"
+}
+
+func NewOrderStatusHistoryRow(history *entities.OrderStatusHistory) OrderStatusHistoryRow {
+	row := OrderStatusHistoryRow{
+		ID:         history.ID.UUID(),
+		OrderID:    history.OrderID.UUID(),
+		FromStatus: history.From.String(),
+		ToStatus:   history.To.String(),
+		Reason:     string(history.Reason),
+		CreatedAt:  history.CreatedAt,
+	}
+
+	if history.ActorID != nil {
+		actorID := history.ActorID.UUID()
+		row.ActorID = &actorID
+	}
+
+	return row
+}
+
+func (r OrderStatusHistoryRow) ToEntity() entities.OrderStatusHistory {
+	history := entities.OrderStatusHistory{
+		ID:        vObject.NewOrderStatusHistoryIDFromUUIDUnsafe(r.ID),
+		OrderID:   vObject.NewOrderIDFromUUIDUnsafe(r.OrderID),
+		From:      vObject.NewOrderStatusUnsafe(r.FromStatus),
+		To:        vObject.NewOrderStatusUnsafe(r.ToStatus),
+		Reason:    vObject.NewOrderStatusReasonUnsafe(r.Reason),
+		CreatedAt: r.CreatedAt,
+	}
+
+	if r.ActorID != nil {
+		actorID := vObject.NewUserIDFromUUIDUnsafe(*r.ActorID)
+		history.ActorID = &actorID
+	}
+
+	return history
+}
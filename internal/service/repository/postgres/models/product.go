@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+// ProductRow is a row of the products table.
+type ProductRow struct {
+	ID          uuid.UUID  `gorm:"column:id;primaryKey"`
+	Title       string     `gorm:"column:title"`
+	Description string     `gorm:"column:description"`
+	Tags        TagsColumn `gorm:"column:tags"`
+	Price       int64      `gorm:"column:price"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at"`
+	DeletedAt   *time.Time `gorm:"column:deleted_at"`
+}
+
+func (ProductRow) TableName() string {
+	return "products"
+}
+
+func NewProductRow(product *entities.Product) ProductRow {
+	tags := make(TagsColumn, 0, len(product.Tags))
+	for _, tag := range product.Tags {
+		tags = append(tags, string(tag))
+	}
+
+	return ProductRow{
+		ID:          product.ID.UUID(),
+		Title:       string(product.Title),
+		Description: string(product.Description),
+		Tags:        tags,
+		Price:       int64(product.Price),
+		CreatedAt:   product.CreatedAt,
+		UpdatedAt:   product.UpdatedAt,
+		DeletedAt:   product.DeletedAt,
+	}
+}
+
+func (r ProductRow) ToEntity() *entities.Product {
+	tags := make(vObject.Tags, 0, len(r.Tags))
+	for _, tag := range r.Tags {
+		tags = append(tags, vObject.Tag(tag))
+	}
+
+	return &entities.Product{
+		ID:          vObject.NewProductIDFromUUIDUnsafe(r.ID),
+		Title:       vObject.NewProductTitleUnsafe(r.Title),
+		Description: vObject.NewProductDescriptionUnsafe(r.Description),
+		Tags:        tags,
+		Price:       vObject.NewPriceUnsafe(int(r.Price)),
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+		DeletedAt:   r.DeletedAt,
+	}
+}
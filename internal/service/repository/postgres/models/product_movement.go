@@ -0,0 +1,97 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+// ProductMovementRow is a row of the product_movements table.
+type ProductMovementRow struct {
+	ID            uuid.UUID  `gorm:"column:id;primaryKey"`
+	ProductID     uuid.UUID  `gorm:"column:product_id"`
+	WarehouseID   uuid.UUID  `gorm:"column:warehouse_id"`
+	OrderID       *uuid.UUID `gorm:"column:order_id"`
+	TransferID    *uuid.UUID `gorm:"column:transfer_id"`
+	OperationType string     `gorm:"column:operation_type"`
+	Quantity      uint64     `gorm:"column:quantity"`
+	Price         int64      `gorm:"column:price"`
+	Reason        *string    `gorm:"column:reason"`
+	DocumentRef   *string    `gorm:"column:document_ref"`
+	CreatedAt     time.Time  `gorm:"column:created_at"`
+}
+
+func (ProductMovementRow) TableName() string {
+	return "product_movements"
+}
+
+func NewProductMovementRow(movement *entities.ProductMovement) ProductMovementRow {
+	row := ProductMovementRow{
+		ID:            movement.ID.UUID(),
+		ProductID:     movement.ProductID.UUID(),
+		WarehouseID:   movement.WarehouseID.UUID(),
+		OperationType: string(movement.OperationType),
+		Quantity:      movement.Quantity.Uint64(),
+		Price:         int64(movement.Price),
+		CreatedAt:     movement.CreatedAt,
+	}
+
+	if movement.OrderID != nil {
+		orderID := movement.OrderID.UUID()
+		row.OrderID = &orderID
+	}
+
+	if movement.TransferID != nil {
+		transferID := movement.TransferID.UUID()
+		row.TransferID = &transferID
+	}
+
+	if movement.Reason != nil {
+		reason := movement.Reason.String()
+		row.Reason = &reason
+	}
+
+	if movement.DocumentRef != nil {
+		documentRef := movement.DocumentRef.String()
+		row.DocumentRef = &documentRef
+	}
+
+	return row
+}
+
+func (r ProductMovementRow) ToEntity() entities.ProductMovement {
+	movement := entities.ProductMovement{
+		ID:            vObject.NewProductMovementIDFromUUIDUnsafe(r.ID),
+		ProductID:     vObject.NewProductIDFromUUIDUnsafe(r.ProductID),
+		WarehouseID:   vObject.NewWarehouseIDFromUUIDUnsafe(r.WarehouseID),
+		OperationType: vObject.OperationType(r.OperationType),
+		Quantity:      vObject.NewQuantityUnsafe(r.Quantity),
+		Price:         vObject.NewPriceUnsafe(int(r.Price)),
+		CreatedAt:     r.CreatedAt,
+	}
+
+	if r.OrderID != nil {
+		orderID := vObject.NewOrderIDFromUUIDUnsafe(*r.OrderID)
+		movement.OrderID = &orderID
+	}
+
+	if r.TransferID != nil {
+		transferID := vObject.NewStockTransferIDFromUUIDUnsafe(*r.TransferID)
+		movement.TransferID = &transferID
+	}
+
+	if r.Reason != nil {
+		reason := vObject.NewMovementReasonUnsafe(*r.Reason)
+		movement.Reason = &reason
+	}
+
+	if r.DocumentRef != nil {
+		documentRef := vObject.NewDocumentReferenceUnsafe(*r.DocumentRef)
+		movement.DocumentRef = &documentRef
+	}
+
+	return movement
+}
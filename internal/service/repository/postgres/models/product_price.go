@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+// ProductPriceRow is a row of the product_prices table.
+type ProductPriceRow struct {
+	ID            uuid.UUID  `gorm:"column:id;primaryKey"`
+	ProductID     uuid.UUID  `gorm:"column:product_id"`
+	Price         int64      `gorm:"column:price"`
+	EffectiveFrom time.Time  `gorm:"column:effective_from"`
+	ActorID       *uuid.UUID `gorm:"column:actor_id"`
+}
+
+func (ProductPriceRow) TableName() string {
+	return "product_prices"
+}
+
+func NewProductPriceRow(price *entities.ProductPrice) ProductPriceRow {
+	row := ProductPriceRow{
+		ID:            price.ID.UUID(),
+		ProductID:     price.ProductID.UUID(),
+		Price:         int64(price.Price),
+		EffectiveFrom: price.EffectiveFrom,
+	}
+
+	if price.ActorID != nil {
+		actorID := price.ActorID.UUID()
+		row.ActorID = &actorID
+	}
+
+	return row
+}
+
+func (r ProductPriceRow) ToEntity() *entities.ProductPrice {
+	price := entities.ProductPrice{
+		ID:            vObject.NewProductPriceIDFromUUIDUnsafe(r.ID),
+		ProductID:     vObject.NewProductIDFromUUIDUnsafe(r.ProductID),
+		Price:         vObject.NewPriceUnsafe(int(r.Price)),
+		EffectiveFrom: r.EffectiveFrom,
+	}
+
+	if r.ActorID != nil {
+		actorID := vObject.NewUserIDFromUUIDUnsafe(*r.ActorID)
+		price.ActorID = &actorID
+	}
+
+	return &price
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+// SessionRow is a row of the user_sessions table.
+type SessionRow struct {
+	ID             uuid.UUID  `gorm:"column:id;primaryKey"`
+	UserID         uuid.UUID  `gorm:"column:user_id"`
+	RefreshTokenID uuid.UUID  `gorm:"column:refresh_token_id"`
+	ExpiresAt      time.Time  `gorm:"column:expires_at"`
+	RevokedAt      *time.Time `gorm:"column:revoked_at"`
+	CreatedAt      time.Time  `gorm:"column:created_at"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at"`
+}
+
+func (SessionRow) TableName() string {
+	return "user_sessions"
+}
+
+func NewSessionRow(session *entities.Session) SessionRow {
+	return SessionRow{
+		ID:             session.ID.UUID(),
+		UserID:         session.UserID.UUID(),
+		RefreshTokenID: session.RefreshTokenID,
+		ExpiresAt:      session.ExpiresAt,
+		RevokedAt:      session.RevokedAt,
+		CreatedAt:      session.CreatedAt,
+		UpdatedAt:      session.UpdatedAt,
+	}
+}
+
+func (r SessionRow) ToEntity() *entities.Session {
+	return &entities.Session{
+		ID:             vObject.NewSessionIDFromUUIDUnsafe(r.ID),
+		UserID:         vObject.NewUserIDFromUUIDUnsafe(r.UserID),
+		RefreshTokenID: r.RefreshTokenID,
+		ExpiresAt:      r.ExpiresAt,
+		RevokedAt:      r.RevokedAt,
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+	}
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+// StockRow is a row of the stocks table.
+type StockRow struct {
+	ProductID         uuid.UUID `gorm:"column:product_id;primaryKey"`
+	WarehouseID       uuid.UUID `gorm:"column:warehouse_id;primaryKey"`
+	AvailableQuantity uint64    `gorm:"column:available_quantity"`
+	ReservedQuantity  uint64    `gorm:"column:reserved_quantity"`
+	InTransitQuantity uint64    `gorm:"column:in_transit_quantity"`
+	CreatedAt         time.Time `gorm:"column:created_at"`
+}
+
+func (StockRow) TableName() string {
+	return "stocks"
+}
+
+func NewStockRow(stock *entities.Stock) StockRow {
+	return StockRow{
+		ProductID:         stock.ProductID.UUID(),
+		WarehouseID:       stock.WarehouseID.UUID(),
+		AvailableQuantity: stock.AvailableQuantity.Uint64(),
+		ReservedQuantity:  stock.ReservedQuantity.Uint64(),
+		InTransitQuantity: stock.InTransitQuantity.Uint64(),
+		CreatedAt:         stock.CreatedAt,
+	}
+}
+
+func (r StockRow) ToEntity() entities.Stock {
+	return entities.Stock{
+		ProductID:         vObject.NewProductIDFromUUIDUnsafe(r.ProductID),
+		WarehouseID:       vObject.NewWarehouseIDFromUUIDUnsafe(r.WarehouseID),
+		AvailableQuantity: vObject.NewQuantityUnsafe(r.AvailableQuantity),
+		ReservedQuantity:  vObject.NewQuantityUnsafe(r.ReservedQuantity),
+		InTransitQuantity: vObject.NewQuantityUnsafe(r.InTransitQuantity),
+		CreatedAt:         r.CreatedAt,
+	}
+}
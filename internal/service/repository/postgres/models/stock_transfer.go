@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+// StockTransferRow is a row of the stock_transfers table.
+type StockTransferRow struct {
+	ID              uuid.UUID  `gorm:"column:id;primaryKey"`
+	ProductID       uuid.UUID  `gorm:"column:product_id"`
+	FromWarehouseID uuid.UUID  `gorm:"column:from_warehouse_id"`
+	ToWarehouseID   uuid.UUID  `gorm:"column:to_warehouse_id"`
+	Quantity        uint64     `gorm:"column:quantity"`
+	Status          string     `gorm:"column:status"`
+	DispatchedAt    time.Time  `gorm:"column:dispatched_at"`
+	ReceivedAt      *time.Time `gorm:"column:received_at"`
+}
+
+func (StockTransferRow) TableName() string {
+	return "stock_transfers"
+}
+
+func NewStockTransferRow(transfer *entities.StockTransfer) StockTransferRow {
+	return StockTransferRow{
+		ID:              transfer.ID.UUID(),
+		ProductID:       transfer.ProductID.UUID(),
+		FromWarehouseID: transfer.FromWarehouseID.UUID(),
+		ToWarehouseID:   transfer.ToWarehouseID.UUID(),
+		Quantity:        transfer.Quantity.Uint64(),
+		Status:          transfer.Status.String(),
+		DispatchedAt:    transfer.DispatchedAt,
+		ReceivedAt:      transfer.ReceivedAt,
+	}
+}
+
+func (r StockTransferRow) ToEntity() *entities.StockTransfer {
+	return &entities.StockTransfer{
+		ID:              vObject.NewStockTransferIDFromUUIDUnsafe(r.ID),
+		ProductID:       vObject.NewProductIDFromUUIDUnsafe(r.ProductID),
+		FromWarehouseID: vObject.NewWarehouseIDFromUUIDUnsafe(r.FromWarehouseID),
+		ToWarehouseID:   vObject.NewWarehouseIDFromUUIDUnsafe(r.ToWarehouseID),
+		Quantity:        vObject.NewQuantityUnsafe(r.Quantity),
+		Status:          vObject.NewStockTransferStatusUnsafe(r.Status),
+		DispatchedAt:    r.DispatchedAt,
+		ReceivedAt:      r.ReceivedAt,
+	}
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// TagsColumn maps the text[] column to the string slice.
+type TagsColumn []string
+
+//nolint:gochecknoglobals // pgtype.Map is safe for concurrent use after creation
+var pgTypes = pgtype.NewMap()
+
+func (t *TagsColumn) Scan(src any) error {
+	var tags []string
+
+	if err := pgTypes.SQLScanner(&tags).Scan(src); err != nil {
+		return fmt.Errorf("[TagsColumn.Scan] %w", err)
+	}
+
+	*t = tags
+
+	return nil
+}
+
+func (t TagsColumn) Value() (driver.Value, error) {
+	if t == nil {
+		t = TagsColumn{}
+	}
+
+	buf, err := pgTypes.Encode(pgtype.TextArrayOID, pgtype.TextFormatCode, []string(t), nil)
+	if err != nil {
+		return nil, fmt.Errorf("[TagsColumn.Value] %w", err)
+	}
+
+	return string(buf), nil
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+// UserRow is a row of the users table.
+type UserRow struct {
+	ID            uuid.UUID  `gorm:"column:id;primaryKey"`
+	Email         string     `gorm:"column:email"`
+	FirstName     string     `gorm:"column:first_name"`
+	LastName      string     `gorm:"column:last_name"`
+	BirthDate     time.Time  `gorm:"column:birth_date"`
+	MaritalStatus string     `gorm:"column:marital_status"`
+	PasswordHash  string     `gorm:"column:password_hash"`
+	Role          string     `gorm:"column:role"`
+	CreatedAt     time.Time  `gorm:"column:created_at"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at"`
+	DeletedAt     *time.Time `gorm:"column:deleted_at"`
+
+	FailedLoginAttempts int        `gorm:"column:failed_login_attempts"`
+	LockedUntil         *time.Time `gorm:"column:locked_until"`
+	EmailVerifiedAt     *time.Time `gorm:"column:email_verified_at"`
+}
+
+func (UserRow) TableName() string {
+	return "users"
+}
+
+func NewUserRow(user *entities.User) UserRow {
+	return UserRow{
+		ID:            user.ID.UUID(),
+		Email:         string(user.Email),
+		FirstName:     string(user.FirstName),
+		LastName:      string(user.LastName),
+		BirthDate:     user.BirthDate.Time(),
+		MaritalStatus: string(user.MaritalStatus),
+		PasswordHash:  string(user.PasswordHash),
+		Role:          string(user.Role),
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+		DeletedAt:     user.DeletedAt,
+
+		FailedLoginAttempts: user.FailedLoginAttempts,
+		LockedUntil:         user.LockedUntil,
+		EmailVerifiedAt:     user.EmailVerifiedAt,
+	}
+}
+
+func (r UserRow) ToEntity() *entities.User {
+	return &entities.User{
+		ID:            vObject.NewUserIDFromUUIDUnsafe(r.ID),
+		Email:         vObject.NewEmailUnsafe(r.Email),
+		FirstName:     vObject.NewFirstNameUnsafe(r.FirstName),
+		LastName:      vObject.NewLastNameUnsafe(r.LastName),
+		BirthDate:     vObject.NewAgeUnsafe(r.BirthDate),
+		MaritalStatus: vObject.NewMaritalStatusUnsafe(r.MaritalStatus),
+		PasswordHash:  vObject.NewPasswordHashUnsafe(r.PasswordHash),
+		Role:          vObject.NewRoleUnsafe(r.Role),
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+		DeletedAt:     r.DeletedAt,
+
+		FailedLoginAttempts: r.FailedLoginAttempts,
+		LockedUntil:         r.LockedUntil,
+		EmailVerifiedAt:     r.EmailVerifiedAt,
+	}
+}
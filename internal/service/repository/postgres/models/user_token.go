@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+)
+
+// UserTokenRow is a row of the user_tokens table.
+type UserTokenRow struct {
+	Hash      string     `gorm:"column:token_hash;primaryKey"`
+	UserID    uuid.UUID  `gorm:"column:user_id"`
+	Purpose   string     `gorm:"column:purpose"`
+	ExpiresAt time.Time  `gorm:"column:expires_at"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+	CreatedAt time.Time  `gorm:"column:created_at"`
+}
+
+func (UserTokenRow) TableName() string {
+	return "user_tokens"
+}
+
+func NewUserTokenRow(token *entities.UserToken) UserTokenRow {
+	return UserTokenRow{
+		Hash:      token.Hash,
+		UserID:    token.UserID.UUID(),
+		Purpose:   string(token.Purpose),
+		ExpiresAt: token.ExpiresAt,
+		UsedAt:    token.UsedAt,
+		CreatedAt: token.CreatedAt,
+	}
+}
+
+func (r UserTokenRow) ToEntity() *entities.UserToken {
+	return &entities.UserToken{
+		Hash:      r.Hash,
+		UserID:    vObject.NewUserIDFromUUIDUnsafe(r.UserID),
+		Purpose:   entities.UserTokenPurpose(r.Purpose),
+		ExpiresAt: r.ExpiresAt,
+		UsedAt:    r.UsedAt,
+		CreatedAt: r.CreatedAt,
+	}
+}
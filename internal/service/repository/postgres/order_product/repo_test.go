@@ -0,0 +1,73 @@
+package orderproduct_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	trmgorm "github.com/avito-tech/go-transaction-manager/gorm"
+	trmcontext "github.com/avito-tech/go-transaction-manager/trm/context"
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	orderProduct "github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/order_product"
+)
+
+func newRepository(t *testing.T) (*orderProduct.Repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       mockDB,
+		DriverName: "postgres",
+	}), &gorm.Config{SkipDefaultTransaction: true, Logger: logger.Discard})
+	require.NoError(t, err)
+
+	return orderProduct.NewRepository(&db.Instance{Gorm: gormDB}, trmgorm.NewCtxGetter(trmcontext.DefaultManager)), mock
+}
+
+func anyArgs(n int) []driver.Value {
+	args := make([]driver.Value, n)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+
+	return args
+}
+
+func TestRepository_UpsertOrderProduct(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepository(t)
+	orderProduct := entities.NewOrderProductUnsafe(
+		vObject.NewOrderIDFromUUIDUnsafe(baseUUID.New()),
+		vObject.NewProductIDFromUUIDUnsafe(baseUUID.New()),
+		entities.WithOrderProductPrice(vObject.NewPriceUnsafe(1000)),
+	)
+	orderProduct.ChangeQuantity(2)
+	orderProduct.Product = &entities.Product{}
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "order_products" ("order_id","product_id","quantity","price","created_at","updated_at","deleted_at","allocations") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) ON CONFLICT ("order_id","product_id") DO UPDATE SET "quantity"="excluded"."quantity","price"="excluded"."price","allocations"="excluded"."allocations","updated_at"="excluded"."updated_at","deleted_at"="excluded"."deleted_at"`)).
+		WithArgs(anyArgs(8)...).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.UpsertOrderProduct(context.Background(), &orderProduct))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "order_products"`)).
+		WithArgs(anyArgs(8)...).
+		WillReturnError(assert.AnError)
+
+	require.ErrorIs(t, repo.UpsertOrderProduct(context.Background(), &orderProduct), assert.AnError)
+}
@@ -2,11 +2,27 @@ package orderproduct
 
 import (
 	"context"
+	"fmt"
+
+	"gorm.io/gorm/clause"
 
 	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
 )
 
 func (r *Repository) UpsertOrderProduct(ctx context.Context, orderProduct *entities.OrderProduct) error {
-	//TODO implement me
-	panic("implement me")
+	row := models.NewOrderProductRow(orderProduct)
+
+	err := r.WriteDBTrx(ctx).WithContext(ctx).
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "order_id"}, {Name: "product_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"quantity", "price", "allocations", "updated_at", "deleted_at"}),
+		}).
+		Create(&row).Error
+	if err != nil {
+		return fmt.Errorf("[orderProduct.UpsertOrderProduct] %w", err)
+	}
+
+	return nil
 }
@@ -0,0 +1,19 @@
+package orderstatushistory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+)
+
+func (r *Repository) CreateOrderStatusHistory(ctx context.Context, history *entities.OrderStatusHistory) error {
+	row := models.NewOrderStatusHistoryRow(history)
+
+	if err := r.WriteDBTrx(ctx).WithContext(ctx).Create(&row).Error; err != nil {
+		return fmt.Errorf("[orderStatusHistory.CreateOrderStatusHistory] %w", err)
+	}
+
+	return nil
+}
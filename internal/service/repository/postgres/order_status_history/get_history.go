@@ -0,0 +1,30 @@
+package orderstatushistory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+)
+
+// GetOrderStatusHistory возвращает историю статусов заказа от старых записей к новым.
+func (r *Repository) GetOrderStatusHistory(ctx context.Context, qos queryOptions.OrderQueryOptionable) (entities.OrderStatusHistories, error) {
+	var rows []models.OrderStatusHistoryRow
+
+	err := r.GetQueryDB(ctx, qos).
+		Where("order_id = ?", qos.ForOrderID().UUID()).
+		Order("created_at, id").
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("[orderStatusHistory.GetOrderStatusHistory] %w", err)
+	}
+
+	history := make(entities.OrderStatusHistories, 0, len(rows))
+	for _, row := range rows {
+		history = append(history, row.ToEntity())
+	}
+
+	return history, nil
+}
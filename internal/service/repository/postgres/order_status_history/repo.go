@@ -0,0 +1,35 @@
+package orderstatushistory
+
+import (
+	trmgorm "github.com/avito-tech/go-transaction-manager/gorm"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	createOrderStatusHistory "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order_status_history/create"
+	getStatusHistory "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_status_history"
+)
+
+type Repository struct {
+	trx.WithTransactionDB
+}
+
+var (
+	_ createOrderStatusHistory.OrderStatusHistoryCreator = (*Repository)(nil)
+	_ getStatusHistory.OrderStatusHistoryGetter          = (*Repository)(nil)
+)
+
+func NewRepository(db *db.Instance, trx *trmgorm.CtxGetter) *Repository {
+	if db == nil {
+		panic("database instance is nil")
+	}
+
+	if trx == nil {
+		panic("transaction CtxGetter is nil")
+	}
+
+	r := Repository{}
+
+	r.SetTransactionDB(db, trx)
+
+	return &r
+}
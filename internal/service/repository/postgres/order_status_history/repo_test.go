@@ -0,0 +1,126 @@
+package orderstatushistory_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	trmgorm "github.com/avito-tech/go-transaction-manager/gorm"
+	trmcontext "github.com/avito-tech/go-transaction-manager/trm/context"
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	orderStatusHistory "github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/order_status_history"
+)
+
+func newRepository(t *testing.T) (*orderStatusHistory.Repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       mockDB,
+		DriverName: "postgres",
+	}), &gorm.Config{SkipDefaultTransaction: true, Logger: logger.Discard})
+	require.NoError(t, err)
+
+	return orderStatusHistory.NewRepository(&db.Instance{Gorm: gormDB}, trmgorm.NewCtxGetter(trmcontext.DefaultManager)), mock
+}
+
+func anyArgs(n int) []driver.Value {
+	args := make([]driver.Value, n)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+
+	return args
+}
+
+func TestRepository_CreateOrderStatusHistory(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepository(t)
+	actorID := vObject.NewUserIDFromUUIDUnsafe(baseUUID.New())
+	history := entities.NewOrderStatusHistoryUnsafe(
+		vObject.NewOrderIDFromUUIDUnsafe(baseUUID.New()),
+		vObject.OrderStatusCreated,
+		vObject.OrderStatusPaid,
+		&actorID,
+		vObject.NewOrderStatusReasonUnsafe("payment received"),
+	)
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "order_status_history" ("id","order_id","from_status","to_status","actor_id","reason","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7)`)).
+		WithArgs(history.ID.UUID(), history.OrderID.UUID(), "created", "paid", actorID.UUID(), "payment received", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.CreateOrderStatusHistory(context.Background(), &history))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "order_status_history"`)).
+		WithArgs(anyArgs(7)...).
+		WillReturnError(assert.AnError)
+
+	require.ErrorIs(t, repo.CreateOrderStatusHistory(context.Background(), &history), assert.AnError)
+}
+
+func TestRepository_GetOrderStatusHistory(t *testing.T) {
+	t.Parallel()
+
+	orderID := baseUUID.New()
+	actorID := baseUUID.New()
+	firstID, secondID := baseUUID.New(), baseUUID.New()
+	tn := time.Now().UTC().Truncate(time.Second)
+
+	repo, mock := newRepository(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "order_status_history" WHERE order_id = $1 ORDER BY created_at, id`)).
+		WithArgs(orderID.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "from_status", "to_status", "actor_id", "reason", "created_at"}).
+			AddRow(firstID.String(), orderID.String(), "created", "paid", actorID.String(), "", tn).
+			AddRow(secondID.String(), orderID.String(), "paid", "canceled", nil, "timeout", tn.Add(time.Minute)))
+
+	history, err := repo.GetOrderStatusHistory(context.Background(), queryOptions.NewOrderQueryOptions(
+		queryOptions.WithOrderID(vObject.NewOrderIDFromUUIDUnsafe(orderID)),
+	))
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	actor := vObject.NewUserIDFromUUIDUnsafe(actorID)
+	assert.Equal(t, entities.OrderStatusHistories{
+		{
+			ID:        vObject.NewOrderStatusHistoryIDFromUUIDUnsafe(firstID),
+			OrderID:   vObject.NewOrderIDFromUUIDUnsafe(orderID),
+			From:      vObject.OrderStatusCreated,
+			To:        vObject.OrderStatusPaid,
+			ActorID:   &actor,
+			CreatedAt: tn,
+		},
+		{
+			ID:        vObject.NewOrderStatusHistoryIDFromUUIDUnsafe(secondID),
+			OrderID:   vObject.NewOrderIDFromUUIDUnsafe(orderID),
+			From:      vObject.OrderStatusPaid,
+			To:        vObject.OrderStatusCanceled,
+			Reason:    vObject.NewOrderStatusReasonUnsafe("timeout"),
+			CreatedAt: tn.Add(time.Minute),
+		},
+	}, history)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "order_status_history"`)).WithArgs(anyArgs(1)...).WillReturnError(assert.AnError)
+
+	_, err = repo.GetOrderStatusHistory(context.Background(), queryOptions.NewOrderQueryOptions(
+		queryOptions.WithOrderID(vObject.NewOrderIDFromUUIDUnsafe(orderID)),
+	))
+	require.ErrorIs(t, err, assert.AnError)
+}
@@ -2,12 +2,40 @@ package orders
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
 	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
 	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
 )
 
 func (r *Repository) GetOrder(ctx context.Context, qos queryOptions.OrderQueryOptionable) (*entities.Order, error) {
-	//TODO implement me
-	panic("implement me")
+	var (
+		row      models.OrderRow
+		products []models.OrderProductRow
+	)
+
+	err := r.GetQueryDB(ctx, qos).
+		Where("id = ? AND deleted_at IS NULL", qos.ForOrderID().UUID()).
+		Take(&row).Error
+	if db.IsNotFoundError(err) {
+		return nil, fmt.Errorf("[orders.GetOrder] %w", entities.ErrOrderRecNotFound)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("[orders.GetOrder] %w", err)
+	}
+
+	// товары заказа читаем с теми же опциями, что и сам заказ (в той же транзакции и с той же блокировкой)
+	err = r.GetQueryDB(ctx, qos).
+		Preload("Product").
+		Where("order_id = ? AND deleted_at IS NULL", row.ID).
+		Order("created_at").
+		Find(&products).Error
+	if err != nil {
+		return nil, fmt.Errorf("[orders.GetOrder - order products] %w", err)
+	}
+
+	return row.ToEntity(products), nil
 }
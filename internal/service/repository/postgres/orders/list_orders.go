@@ -0,0 +1,93 @@
+package orders
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/paging"
+)
+
+//nolint:gochecknoglobals // таблица порядков сортировки
+var orderOrders = map[vObject.OrderSort]string{
+	vObject.OrderSortNewest:    "created_at DESC, id DESC",
+	vObject.OrderSortOldest:    "created_at, id",
+	vObject.OrderSortTotalAsc:  "total_price, id",
+	vObject.OrderSortTotalDesc: "total_price DESC, id DESC",
+}
+
+// ListOrders возвращает страницу заказов по фильтру и записывает в qos общее число подходящих заказов.
+// Позиции заказов с товарами загружаются только с опцией WithOrderProducts.
+func (r *Repository) ListOrders(ctx context.Context, qos queryOptions.OrderQueryOptionable) (entities.Orders, error) {
+	query := r.GetQueryDB(ctx, qos).Model(&models.OrderRow{}).Where("deleted_at IS NULL")
+
+	if userID := qos.ForUserID(); userID != nil {
+		query = query.Where("user_id = ?", userID.UUID())
+	}
+
+	if statuses := qos.ForStatuses(); len(statuses) > 0 {
+		values := make([]string, 0, len(statuses))
+		for _, status := range statuses {
+			values = append(values, string(status))
+		}
+
+		query = query.Where("status IN ?", values)
+	}
+
+	if from := qos.ForCreatedFrom(); from != nil {
+		query = query.Where("created_at >= ?", *from)
+	}
+
+	if to := qos.ForCreatedTo(); to != nil {
+		query = query.Where("created_at < ?", *to)
+	}
+
+	order, ok := orderOrders[qos.ForSort()]
+	if !ok {
+		order = orderOrders[vObject.OrderSortNewest]
+	}
+
+	// по курсору заказы листаются от новых к старым, а с сортировкой oldest — от старых к новым
+	desc := qos.ForSort() != vObject.OrderSortOldest
+
+	rows, err := paging.Find(query, qos, order, desc, func(row models.OrderRow) uuid.UUID { return row.ID })
+	if err != nil {
+		return nil, fmt.Errorf("[orders.ListOrders] %w", err)
+	}
+
+	products := make(map[uuid.UUID][]models.OrderProductRow, len(rows))
+
+	if qos.IsWithProducts() && len(rows) > 0 {
+		ids := make([]uuid.UUID, 0, len(rows))
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+
+		var productRows []models.OrderProductRow
+
+		err = r.GetQueryDB(ctx, qos).
+			Preload("Product").
+			Where("order_id IN ? AND deleted_at IS NULL", ids).
+			Order("created_at").
+			Find(&productRows).Error
+		if err != nil {
+			return nil, fmt.Errorf("[orders.ListOrders - order products] %w", err)
+		}
+
+		for _, p := range productRows {
+			products[p.OrderID] = append(products[p.OrderID], p)
+		}
+	}
+
+	orders := make(entities.Orders, 0, len(rows))
+	for _, row := range rows {
+		orders = append(orders, *row.ToEntity(products[row.ID]))
+	}
+
+	return orders, nil
+}
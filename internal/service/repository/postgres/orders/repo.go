@@ -9,6 +9,7 @@ import (
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
 	upsertOrder "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order/upsert"
 	getOrder "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_order"
+	listOrders "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/list_orders"
 )
 
 type Repository struct {
@@ -20,6 +21,7 @@ type Repository struct {
 var (
 	_ getOrder.OrderGetter      = (*Repository)(nil)
 	_ upsertOrder.OrderUpserter = (*Repository)(nil)
+	_ listOrders.OrdersLister   = (*Repository)(nil)
 )
 
 func NewRepository(db *db.Instance, trx *trmgorm.CtxGetter) *Repository {
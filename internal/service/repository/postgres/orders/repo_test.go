@@ -0,0 +1,248 @@
+package orders_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	trmgorm "github.com/avito-tech/go-transaction-manager/gorm"
+	trmcontext "github.com/avito-tech/go-transaction-manager/trm/context"
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/orders"
+)
+
+func newRepository(t *testing.T) (*orders.Repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       mockDB,
+		DriverName: "postgres",
+	}), &gorm.Config{SkipDefaultTransaction: true, Logger: logger.Discard})
+	require.NoError(t, err)
+
+	return orders.NewRepository(&db.Instance{Gorm: gormDB}, trmgorm.NewCtxGetter(trmcontext.DefaultManager)), mock
+}
+
+func anyArgs(n int) []driver.Value {
+	args := make([]driver.Value, n)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+
+	return args
+}
+
+func TestRepository_GetOrder(t *testing.T) {
+	t.Parallel()
+
+	orderID := baseUUID.New()
+	userID := baseUUID.New()
+	productID := baseUUID.New()
+	tn := time.Now().UTC().Truncate(time.Second)
+
+	t.Run("found for update", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepository(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "orders" WHERE id = $1 AND deleted_at IS NULL LIMIT $2 FOR UPDATE`)).
+			WithArgs(orderID.String(), 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status", "total_price", "created_at", "updated_at"}).
+				AddRow(orderID.String(), userID.String(), "created", 3000, tn, tn))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "order_products" WHERE order_id = $1 AND deleted_at IS NULL ORDER BY created_at FOR UPDATE`)).
+			WithArgs(anyArgs(1)...).
+			WillReturnRows(sqlmock.NewRows([]string{"order_id", "product_id", "quantity", "price", "created_at", "updated_at"}).
+				AddRow(orderID.String(), productID.String(), 3, 1000, tn, tn))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE "products"."id" = $1`)).
+			WithArgs(anyArgs(1)...).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "tags", "price", "created_at", "updated_at"}).
+				AddRow(productID.String(), "title", "description", "{a,b}", 1000, tn, tn))
+
+		order, err := repo.GetOrder(context.Background(), queryOptions.NewOrderQueryOptions(
+			queryOptions.WithOrderID(vObject.NewOrderIDFromUUIDUnsafe(orderID)),
+			queryOptions.WithForUpdate[*queryOptions.OrderQueryOptions](),
+		))
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+
+		assert.Equal(t, vObject.NewOrderIDFromUUIDUnsafe(orderID), order.ID)
+		assert.Equal(t, vObject.NewUserIDFromUUIDUnsafe(userID), order.UserID)
+		assert.Equal(t, vObject.OrderStatusCreated, order.Status)
+		assert.Equal(t, vObject.NewPriceUnsafe(3000), order.TotalPrice)
+		require.Len(t, order.Products, 1)
+		assert.Equal(t, vObject.NewQuantityUnsafe(3), order.Products[0].Quantity)
+		require.NotNil(t, order.Products[0].Product)
+		assert.Equal(t, vObject.Tags{"a", "b"}, order.Products[0].Product.Tags)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepository(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "orders"`)).
+			WithArgs(anyArgs(2)...).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		order, err := repo.GetOrder(context.Background(), queryOptions.NewOrderQueryOptions(
+			queryOptions.WithOrderID(vObject.NewOrderIDFromUUIDUnsafe(orderID)),
+		))
+		require.ErrorIs(t, err, entities.ErrOrderRecNotFound)
+		assert.Nil(t, order)
+	})
+}
+
+func TestRepository_ListOrders(t *testing.T) {
+	t.Parallel()
+
+	orderID := baseUUID.New()
+	otherOrderID := baseUUID.New()
+	userID := baseUUID.New()
+	productID := baseUUID.New()
+	tn := time.Now().UTC().Truncate(time.Second)
+	from := tn.Add(-24 * time.Hour)
+
+	t.Run("filters with products", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepository(t)
+
+		where := `WHERE deleted_at IS NULL AND user_id = $1 AND status IN ($2,$3) AND created_at >= $4 AND created_at < $5`
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "orders" `+where)).
+			WithArgs(userID.String(), "paid", "shipped", from, tn).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "orders" `+where+` ORDER BY total_price DESC, id DESC LIMIT $6 OFFSET $7`)).
+			WithArgs(userID.String(), "paid", "shipped", from, tn, 5, 5).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status", "total_price", "created_at", "updated_at"}).
+				AddRow(orderID.String(), userID.String(), "paid", 3000, tn, tn).
+				AddRow(otherOrderID.String(), userID.String(), "shipped", 1000, tn, tn))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "order_products" WHERE order_id IN ($1,$2) AND deleted_at IS NULL ORDER BY created_at`)).
+			WithArgs(orderID.String(), otherOrderID.String()).
+			WillReturnRows(sqlmock.NewRows([]string{"order_id", "product_id", "quantity", "price", "created_at", "updated_at"}).
+				AddRow(orderID.String(), productID.String(), 3, 1000, tn, tn))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE "products"."id" = $1`)).
+			WithArgs(anyArgs(1)...).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "tags", "price", "created_at", "updated_at"}).
+				AddRow(productID.String(), "title", "description", "{a}", 1000, tn, tn))
+
+		qos := queryOptions.NewOrderQueryOptions(
+			queryOptions.WithOrderUserID(vObject.NewUserIDFromUUIDUnsafe(userID)),
+			queryOptions.WithOrderStatuses(vObject.OrderStatusPaid, vObject.OrderStatusShipped),
+			queryOptions.WithOrderCreatedFrom(from),
+			queryOptions.WithOrderCreatedTo(tn),
+			queryOptions.WithOrderSort(vObject.OrderSortTotalDesc),
+			queryOptions.WithOrderProducts(),
+			queryOptions.WithMetaPage[*queryOptions.OrderQueryOptions](2),
+			queryOptions.WithMetaPerPage[*queryOptions.OrderQueryOptions](5),
+		)
+
+		orders, err := repo.ListOrders(context.Background(), qos)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+
+		require.Len(t, orders, 2)
+		assert.Equal(t, vObject.NewOrderIDFromUUIDUnsafe(orderID), orders[0].ID)
+		require.Len(t, orders[0].Products, 1)
+		require.NotNil(t, orders[0].Products[0].Product)
+		assert.Equal(t, vObject.NewProductTitleUnsafe("title"), orders[0].Products[0].Product.Title)
+		assert.Empty(t, orders[1].Products)
+		assert.Equal(t, vObject.Meta{Total: 7, PerPage: 5, Page: 2, LastPage: 2}, *qos.GetMeta())
+	})
+
+	t.Run("without products", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepository(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "orders" WHERE deleted_at IS NULL AND user_id = $1`)).
+			WithArgs(userID.String()).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "orders" WHERE deleted_at IS NULL AND user_id = $1 ORDER BY created_at DESC, id DESC LIMIT $2`)).
+			WithArgs(userID.String(), 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status", "total_price", "created_at", "updated_at"}).
+				AddRow(orderID.String(), userID.String(), "created", 3000, tn, tn))
+
+		orders, err := repo.ListOrders(context.Background(), queryOptions.NewOrderQueryOptions(
+			queryOptions.WithOrderUserID(vObject.NewUserIDFromUUIDUnsafe(userID)),
+		))
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+		require.Len(t, orders, 1)
+		assert.Empty(t, orders[0].Products)
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepository(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "orders" WHERE deleted_at IS NULL AND id > $1 ORDER BY id LIMIT $2`)).
+			WithArgs(orderID.String(), 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status", "total_price", "created_at", "updated_at"}).
+				AddRow(otherOrderID.String(), userID.String(), "created", 3000, tn, tn).
+				AddRow(productID.String(), userID.String(), "created", 1000, tn, tn))
+
+		qos := queryOptions.NewOrderQueryOptions(
+			queryOptions.WithOrderSort(vObject.OrderSortOldest),
+			queryOptions.WithMetaCursor[*queryOptions.OrderQueryOptions](vObject.NewNextCursor(orderID)),
+			queryOptions.WithMetaPerPage[*queryOptions.OrderQueryOptions](1),
+		)
+
+		orders, err := repo.ListOrders(context.Background(), qos)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+		require.Len(t, orders, 1)
+		assert.Equal(t, vObject.NewOrderIDFromUUIDUnsafe(otherOrderID), orders[0].ID)
+		assert.Equal(t, vObject.Meta{
+			PerPage:    1,
+			PrevCursor: vObject.NewPrevCursor(otherOrderID).Token(),
+			NextCursor: vObject.NewNextCursor(otherOrderID).Token(),
+		}, *qos.GetMeta())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepository(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "orders"`)).WillReturnError(assert.AnError)
+
+		_, err := repo.ListOrders(context.Background(), queryOptions.NewOrderQueryOptions())
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestRepository_UpsertOrder(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepository(t)
+	order := entities.NewOrderUnsafe(vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()))
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "orders" ("id","user_id","status","total_price","created_at","updated_at","deleted_at") VALUES ($1,$2,$3,$4,$5,$6,$7) ON CONFLICT ("id") DO UPDATE SET "status"="excluded"."status","total_price"="excluded"."total_price","updated_at"="excluded"."updated_at","deleted_at"="excluded"."deleted_at"`)).
+		WithArgs(anyArgs(7)...).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.UpsertOrder(context.Background(), &order))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "orders"`)).WithArgs(anyArgs(7)...).WillReturnError(assert.AnError)
+
+	require.ErrorIs(t, repo.UpsertOrder(context.Background(), &order), assert.AnError)
+}
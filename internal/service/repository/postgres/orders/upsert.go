@@ -2,11 +2,26 @@ package orders
 
 import (
 	"context"
+	"fmt"
+
+	"gorm.io/gorm/clause"
 
 	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
 )
 
 func (r *Repository) UpsertOrder(ctx context.Context, order *entities.Order) error {
-	//TODO implement me
-	panic("implement me")
+	row := models.NewOrderRow(order)
+
+	err := r.WriteDBTrx(ctx).WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"status", "total_price", "updated_at", "deleted_at"}),
+		}).
+		Create(&row).Error
+	if err != nil {
+		return fmt.Errorf("[orders.UpsertOrder] %w", err)
+	}
+
+	return nil
 }
@@ -0,0 +1,62 @@
+// Package paging выбирает страницы списков по номеру страницы или по курсору.
+package paging
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+)
+
+// Find выбирает страницу списка query.
+//
+// По номеру страницы подсчитывает все подходящие записи в qos.WithMetaTotal и выбирает страницу в порядке order
+// через LIMIT/OFFSET. По курсору выбирает страницу по условию на id без подсчёта и OFFSET, порядок — по id,
+// по убыванию при desc. Курсоры соседних страниц записываются в qos.
+func Find[T any](query *gorm.DB, qos queryOptions.MetaQueryOptionable, order any, desc bool, id func(T) uuid.UUID) ([]T, error) {
+	var rows []T
+
+	if qos.ForCursor() == nil {
+		// условия общие для подсчёта и выборки страницы
+		query = query.Session(&gorm.Session{})
+
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			return nil, err
+		}
+
+		qos.WithMetaTotal(int(total))
+
+		if total == 0 {
+			return []T{}, nil
+		}
+
+		err := query.
+			Order(order).
+			Limit(int(qos.ForLimit())).
+			Offset(int(qos.ForOffset())).
+			Find(&rows).Error
+
+		return rows, err
+	}
+
+	from, ascending := qos.ForKeyset(desc)
+
+	if from != nil && ascending {
+		query = query.Where("id > ?", *from)
+	} else if from != nil {
+		query = query.Where("id < ?", *from)
+	}
+
+	idOrder := "id DESC"
+	if ascending {
+		idOrder = "id"
+	}
+
+	// лишняя запись показывает, есть ли страница дальше
+	if err := query.Order(idOrder).Limit(int(qos.ForLimit()) + 1).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return queryOptions.KeysetPage(qos, rows, id), nil
+}
@@ -0,0 +1,22 @@
+package productmovements
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+)
+
+func (r *Repository) CreateProductMovements(ctx context.Context, movements entities.ProductMovements) error {
+	rows := make([]models.ProductMovementRow, 0, len(movements))
+	for i := range movements {
+		rows = append(rows, models.NewProductMovementRow(&movements[i]))
+	}
+
+	if err := r.WriteDBTrx(ctx).WithContext(ctx).Create(&rows).Error; err != nil {
+		return fmt.Errorf("[productMovements.CreateProductMovements] %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package productmovements
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+)
+
+// GetProductMovements возвращает движения товара в хронологическом порядке. Движения одной транзакции
+// имеют одно время создания, порядок между ними задают идентификаторы UUIDv7.
+func (r *Repository) GetProductMovements(ctx context.Context, qos queryOptions.ProductMovementQueryOptionable) (entities.ProductMovements, error) {
+	var rows []models.ProductMovementRow
+
+	query := r.GetQueryDB(ctx, qos).Where("product_id = ?", qos.ForProductID().UUID())
+
+	if orderID := qos.ForOrderID(); orderID != nil {
+		query = query.Where("order_id = ?", orderID.UUID())
+	}
+
+	if err := query.Order("created_at, id").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("[productMovements.GetProductMovements] %w", err)
+	}
+
+	movements := make(entities.ProductMovements, 0, len(rows))
+	for _, row := range rows {
+		movements = append(movements, row.ToEntity())
+	}
+
+	return movements, nil
+}
@@ -0,0 +1,35 @@
+package productmovements
+
+import (
+	trmgorm "github.com/avito-tech/go-transaction-manager/gorm"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	getMovements "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_movements"
+)
+
+type Repository struct {
+	trx.WithTransactionDB
+}
+
+var (
+	_ createProductMovements.ProductMovementsCreator = (*Repository)(nil)
+	_ getMovements.ProductMovementsGetter            = (*Repository)(nil)
+)
+
+func NewRepository(db *db.Instance, trx *trmgorm.CtxGetter) *Repository {
+	if db == nil {
+		panic("database instance is nil")
+	}
+
+	if trx == nil {
+		panic("transaction CtxGetter is nil")
+	}
+
+	r := Repository{}
+
+	r.SetTransactionDB(db, trx)
+
+	return &r
+}
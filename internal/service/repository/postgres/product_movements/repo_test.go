@@ -0,0 +1,135 @@
+package productmovements_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	trmgorm "github.com/avito-tech/go-transaction-manager/gorm"
+	trmcontext "github.com/avito-tech/go-transaction-manager/trm/context"
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	productMovements "github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/product_movements"
+)
+
+func newRepository(t *testing.T) (*productMovements.Repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       mockDB,
+		DriverName: "postgres",
+	}), &gorm.Config{SkipDefaultTransaction: true, Logger: logger.Discard})
+	require.NoError(t, err)
+
+	return productMovements.NewRepository(&db.Instance{Gorm: gormDB}, trmgorm.NewCtxGetter(trmcontext.DefaultManager)), mock
+}
+
+func anyArgs(n int) []driver.Value {
+	args := make([]driver.Value, n)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+
+	return args
+}
+
+func TestRepository_CreateProductMovements(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepository(t)
+	movement := entities.NewProductMovementUnsafe(
+		vObject.NewProductIDFromUUIDUnsafe(baseUUID.New()),
+		vObject.NewWarehouseIDFromUUIDUnsafe(baseUUID.New()),
+		vObject.OperationTypeReserve,
+		vObject.NewQuantityUnsafe(3),
+		vObject.NewPriceUnsafe(1000),
+		entities.WithProductMovementOrderID(vObject.NewOrderIDFromUUIDUnsafe(baseUUID.New())),
+	)
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "product_movements" ("id","product_id","warehouse_id","order_id","transfer_id","operation_type","quantity","price","reason","document_ref","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11),($12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22)`)).
+		WithArgs(anyArgs(22)...).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	require.NoError(t, repo.CreateProductMovements(context.Background(), entities.ProductMovements{movement, movement}))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "product_movements"`)).
+		WithArgs(anyArgs(11)...).
+		WillReturnError(assert.AnError)
+
+	require.ErrorIs(t, repo.CreateProductMovements(context.Background(), entities.ProductMovements{movement}), assert.AnError)
+}
+
+func TestRepository_GetProductMovements(t *testing.T) {
+	t.Parallel()
+
+	productID := baseUUID.New()
+	warehouseID := baseUUID.New()
+	orderID := baseUUID.New()
+	movementID := baseUUID.New()
+	incomeID := baseUUID.New()
+	tn := time.Now().UTC().Truncate(time.Second)
+
+	repo, mock := newRepository(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_movements" WHERE product_id = $1 AND order_id = $2 ORDER BY created_at, id`)).
+		WithArgs(productID.String(), orderID.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "warehouse_id", "order_id", "operation_type", "quantity", "price", "reason", "document_ref", "created_at"}).
+			AddRow(movementID.String(), productID.String(), warehouseID.String(), orderID.String(), "reserve", 3, 1000, nil, nil, tn).
+			AddRow(incomeID.String(), productID.String(), warehouseID.String(), nil, "income", 10, 700, "purchase", "INV-1", tn))
+
+	movements, err := repo.GetProductMovements(context.Background(), queryOptions.NewProductMovementQueryOptions(
+		queryOptions.WithMovementProductID(vObject.NewProductIDFromUUIDUnsafe(productID)),
+		queryOptions.WithMovementOrderID(vObject.NewOrderIDFromUUIDUnsafe(orderID)),
+	))
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	order := vObject.NewOrderIDFromUUIDUnsafe(orderID)
+	reason := vObject.MovementReasonPurchase
+	document := vObject.NewDocumentReferenceUnsafe("INV-1")
+	assert.Equal(t, entities.ProductMovements{{
+		ID:            vObject.NewProductMovementIDFromUUIDUnsafe(movementID),
+		ProductID:     vObject.NewProductIDFromUUIDUnsafe(productID),
+		WarehouseID:   vObject.NewWarehouseIDFromUUIDUnsafe(warehouseID),
+		OrderID:       &order,
+		OperationType: vObject.OperationTypeReserve,
+		Quantity:      vObject.NewQuantityUnsafe(3),
+		Price:         vObject.NewPriceUnsafe(1000),
+		CreatedAt:     tn,
+	}, {
+		ID:            vObject.NewProductMovementIDFromUUIDUnsafe(incomeID),
+		ProductID:     vObject.NewProductIDFromUUIDUnsafe(productID),
+		WarehouseID:   vObject.NewWarehouseIDFromUUIDUnsafe(warehouseID),
+		OperationType: vObject.OperationTypeIncome,
+		Quantity:      vObject.NewQuantityUnsafe(10),
+		Price:         vObject.NewPriceUnsafe(700),
+		Reason:        &reason,
+		DocumentRef:   &document,
+		CreatedAt:     tn,
+	}}, movements)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_movements" WHERE product_id = $1 ORDER BY created_at, id`)).
+		WithArgs(productID.String()).
+		WillReturnError(assert.AnError)
+
+	_, err = repo.GetProductMovements(context.Background(), queryOptions.NewProductMovementQueryOptions(
+		queryOptions.WithMovementProductID(vObject.NewProductIDFromUUIDUnsafe(productID)),
+	))
+	require.ErrorIs(t, err, assert.AnError)
+}
@@ -0,0 +1,19 @@
+package productprices
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+)
+
+func (r *Repository) CreateProductPrice(ctx context.Context, price *entities.ProductPrice) error {
+	row := models.NewProductPriceRow(price)
+
+	if err := r.WriteDBTrx(ctx).WithContext(ctx).Create(&row).Error; err != nil {
+		return fmt.Errorf("[productPrices.CreateProductPrice] %w", err)
+	}
+
+	return nil
+}
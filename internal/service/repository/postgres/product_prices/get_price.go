@@ -0,0 +1,30 @@
+package productprices
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+)
+
+// GetProductPrice возвращает последнюю запись истории цен товара, вступившую в силу не позже qos.At().
+func (r *Repository) GetProductPrice(ctx context.Context, qos queryOptions.ProductPriceQueryOptionable) (*entities.ProductPrice, error) {
+	var row models.ProductPriceRow
+
+	err := r.GetQueryDB(ctx, qos).
+		Where("product_id = ? AND effective_from <= ?", qos.ForProductID().UUID(), qos.At()).
+		Order("effective_from DESC, id DESC").
+		Take(&row).Error
+	if db.IsNotFoundError(err) {
+		return nil, fmt.Errorf("[productPrices.GetProductPrice] %w", entities.ErrProductPriceRecNotFound)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("[productPrices.GetProductPrice] %w", err)
+	}
+
+	return row.ToEntity(), nil
+}
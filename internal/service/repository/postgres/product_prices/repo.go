@@ -0,0 +1,35 @@
+package productprices
+
+import (
+	trmgorm "github.com/avito-tech/go-transaction-manager/gorm"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	createProductPrice "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_price/create"
+	getPrice "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_price"
+)
+
+type Repository struct {
+	trx.WithTransactionDB
+}
+
+var (
+	_ createProductPrice.ProductPriceCreator = (*Repository)(nil)
+	_ getPrice.ProductPriceGetter            = (*Repository)(nil)
+)
+
+func NewRepository(db *db.Instance, trx *trmgorm.CtxGetter) *Repository {
+	if db == nil {
+		panic("database instance is nil")
+	}
+
+	if trx == nil {
+		panic("transaction CtxGetter is nil")
+	}
+
+	r := Repository{}
+
+	r.SetTransactionDB(db, trx)
+
+	return &r
+}
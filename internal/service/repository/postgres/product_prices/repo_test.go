@@ -0,0 +1,114 @@
+package productprices_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	trmgorm "github.com/avito-tech/go-transaction-manager/gorm"
+	trmcontext "github.com/avito-tech/go-transaction-manager/trm/context"
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	productPrices "github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/product_prices"
+)
+
+func newRepository(t *testing.T) (*productPrices.Repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       mockDB,
+		DriverName: "postgres",
+	}), &gorm.Config{SkipDefaultTransaction: true, Logger: logger.Discard})
+	require.NoError(t, err)
+
+	return productPrices.NewRepository(&db.Instance{Gorm: gormDB}, trmgorm.NewCtxGetter(trmcontext.DefaultManager)), mock
+}
+
+func anyArgs(n int) []driver.Value {
+	args := make([]driver.Value, n)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+
+	return args
+}
+
+func TestRepository_CreateProductPrice(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepository(t)
+	actorID := vObject.NewUserIDFromUUIDUnsafe(baseUUID.New())
+	price := entities.NewProductPriceUnsafe(
+		vObject.NewProductIDFromUUIDUnsafe(baseUUID.New()),
+		vObject.NewPriceUnsafe(1500),
+		&actorID,
+	)
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "product_prices" ("id","product_id","price","effective_from","actor_id") VALUES ($1,$2,$3,$4,$5)`)).
+		WithArgs(price.ID.UUID(), price.ProductID.UUID(), int64(1500), sqlmock.AnyArg(), actorID.UUID()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.CreateProductPrice(context.Background(), &price))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "product_prices"`)).
+		WithArgs(anyArgs(5)...).
+		WillReturnError(assert.AnError)
+
+	require.ErrorIs(t, repo.CreateProductPrice(context.Background(), &price), assert.AnError)
+}
+
+func TestRepository_GetProductPrice(t *testing.T) {
+	t.Parallel()
+
+	productID := baseUUID.New()
+	priceID := baseUUID.New()
+	tn := time.Now().UTC().Truncate(time.Second)
+	qos := queryOptions.NewProductPriceQueryOptions(
+		queryOptions.WithPriceProductID(vObject.NewProductIDFromUUIDUnsafe(productID)),
+		queryOptions.WithPriceAt(tn),
+	)
+	query := regexp.QuoteMeta(`SELECT * FROM "product_prices" WHERE product_id = $1 AND effective_from <= $2 ORDER BY effective_from DESC, id DESC LIMIT $3`)
+
+	repo, mock := newRepository(t)
+
+	mock.ExpectQuery(query).
+		WithArgs(productID.String(), tn, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "price", "effective_from", "actor_id"}).
+			AddRow(priceID.String(), productID.String(), 1500, tn.Add(-time.Hour), nil))
+
+	price, err := repo.GetProductPrice(context.Background(), qos)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, &entities.ProductPrice{
+		ID:            vObject.NewProductPriceIDFromUUIDUnsafe(priceID),
+		ProductID:     vObject.NewProductIDFromUUIDUnsafe(productID),
+		Price:         vObject.NewPriceUnsafe(1500),
+		EffectiveFrom: tn.Add(-time.Hour),
+	}, price)
+
+	mock.ExpectQuery(query).WithArgs(anyArgs(3)...).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err = repo.GetProductPrice(context.Background(), qos)
+	require.ErrorIs(t, err, entities.ErrProductPriceRecNotFound)
+
+	mock.ExpectQuery(query).WithArgs(anyArgs(3)...).WillReturnError(assert.AnError)
+
+	_, err = repo.GetProductPrice(context.Background(), qos)
+	require.ErrorIs(t, err, assert.AnError)
+}
@@ -0,0 +1,19 @@
+package products
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+)
+
+func (r *Repository) CreateProduct(ctx context.Context, product *entities.Product) error {
+	row := models.NewProductRow(product)
+
+	if err := r.WriteDBTrx(ctx).WithContext(ctx).Create(&row).Error; err != nil {
+		return fmt.Errorf("[products.CreateProduct] %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package products
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+)
+
+func (r *Repository) GetProduct(ctx context.Context, qos queryOptions.ProductQueryOptionable) (*entities.Product, error) {
+	var row models.ProductRow
+
+	err := r.GetQueryDB(ctx, qos).
+		Where("id = ? AND deleted_at IS NULL", qos.ForProductID().UUID()).
+		Take(&row).Error
+	if db.IsNotFoundError(err) {
+		return nil, fmt.Errorf("[products.GetProduct] %w", entities.ErrProductRecNotFound)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("[products.GetProduct] %w", err)
+	}
+
+	return row.ToEntity(), nil
+}
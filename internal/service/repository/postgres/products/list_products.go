@@ -0,0 +1,96 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/paging"
+)
+
+// searchConfig конфигурация полнотекстового поиска, совпадает с products.search_vector.
+const searchConfig = "russian"
+
+//nolint:gochecknoglobals // таблица порядков сортировки
+var productOrders = map[vObject.ProductSort]string{
+	vObject.ProductSortNewest:    "created_at DESC, id DESC",
+	vObject.ProductSortTitle:     "title, id",
+	vObject.ProductSortPriceAsc:  "price, id",
+	vObject.ProductSortPriceDesc: "price DESC, id DESC",
+}
+
+// ListProducts возвращает страницу товаров каталога по фильтру и записывает в qos общее число подходящих товаров.
+func (r *Repository) ListProducts(ctx context.Context, qos queryOptions.ProductQueryOptionable) (entities.Products, error) {
+	query := r.GetQueryDB(ctx, qos).Model(&models.ProductRow{}).Where("deleted_at IS NULL")
+
+	if tags, match := qos.ForTags(); len(tags) > 0 {
+		column := make(models.TagsColumn, 0, len(tags))
+		for _, tag := range tags {
+			column = append(column, string(tag))
+		}
+
+		if match == vObject.TagsMatchAll {
+			query = query.Where("tags @> ?", column)
+		} else {
+			query = query.Where("tags && ?", column)
+		}
+	}
+
+	if title := qos.ForTitle(); title != "" {
+		query = query.Where("title ILIKE ?", "%"+escapeLike(title)+"%")
+	}
+
+	search := qos.ForSearch()
+	if search != "" {
+		query = query.Where("search_vector @@ websearch_to_tsquery('"+searchConfig+"', ?)", search)
+	}
+
+	if price := qos.ForMinPrice(); price != nil {
+		query = query.Where("price >= ?", int64(*price))
+	}
+
+	if price := qos.ForMaxPrice(); price != nil {
+		query = query.Where("price <= ?", int64(*price))
+	}
+
+	if qos.IsInStockOnly() {
+		query = query.Where("EXISTS (SELECT 1 FROM stocks WHERE stocks.product_id = products.id " +
+			"AND stocks.available_quantity > stocks.reserved_quantity)")
+	}
+
+	var order any = productOrders[vObject.ProductSortNewest]
+
+	if qos.ForSort() == vObject.ProductSortRelevance && search != "" {
+		order = clause.OrderBy{Expression: clause.Expr{
+			SQL:  "ts_rank(search_vector, websearch_to_tsquery('" + searchConfig + "', ?)) DESC, id",
+			Vars: []any{search},
+		}}
+	} else if o, ok := productOrders[qos.ForSort()]; ok {
+		order = o
+	}
+
+	// по курсору товары листаются от новых к старым
+	rows, err := paging.Find(query, qos, order, true, func(row models.ProductRow) uuid.UUID { return row.ID })
+	if err != nil {
+		return nil, fmt.Errorf("[products.ListProducts] %w", err)
+	}
+
+	products := make(entities.Products, 0, len(rows))
+	for _, row := range rows {
+		products = append(products, *row.ToEntity())
+	}
+
+	return products, nil
+}
+
+// escapeLike экранирует спецсимволы шаблона LIKE, чтобы подстрока искалась буквально.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
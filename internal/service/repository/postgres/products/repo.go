@@ -1,17 +1,16 @@
 package products
 
 import (
-	"context"
-
 	trmgorm "github.com/avito-tech/go-transaction-manager/gorm"
 
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
 	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
-	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
-	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	createProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product/create"
+	updateProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product/update"
 	getProduct "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_product"
+	listProducts "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/list_products"
 )
 
 type Repository struct {
@@ -20,7 +19,12 @@ type Repository struct {
 	trx.WithTransactionDB
 }
 
-var _ getProduct.ProductGetter = (*Repository)(nil)
+var (
+	_ createProduct.ProductCreator = (*Repository)(nil)
+	_ updateProduct.ProductUpdater = (*Repository)(nil)
+	_ getProduct.ProductGetter     = (*Repository)(nil)
+	_ listProducts.ProductsLister  = (*Repository)(nil)
+)
 
 func NewRepository(db *db.Instance, trx *trmgorm.CtxGetter) *Repository {
 	if db == nil {
@@ -37,8 +41,3 @@ func NewRepository(db *db.Instance, trx *trmgorm.CtxGetter) *Repository {
 
 	return &r
 }
-
-func (r *Repository) GetProduct(ctx context.Context, qos queryOptions.ProductQueryOptionable) (*entities.Product, error) {
-	//TODO implement me
-	panic("implement me")
-}
@@ -0,0 +1,299 @@
+package products_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	trmgorm "github.com/avito-tech/go-transaction-manager/gorm"
+	trmcontext "github.com/avito-tech/go-transaction-manager/trm/context"
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	products "github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/products"
+)
+
+func newRepository(t *testing.T) (*products.Repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       mockDB,
+		DriverName: "postgres",
+	}), &gorm.Config{SkipDefaultTransaction: true, Logger: logger.Discard})
+	require.NoError(t, err)
+
+	return products.NewRepository(&db.Instance{Gorm: gormDB}, trmgorm.NewCtxGetter(trmcontext.DefaultManager)), mock
+}
+
+func anyArgs(n int) []driver.Value {
+	args := make([]driver.Value, n)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+
+	return args
+}
+
+func TestRepository_GetProduct(t *testing.T) {
+	t.Parallel()
+
+	productID := baseUUID.New()
+	tn := time.Now().UTC().Truncate(time.Second)
+
+	t.Run("found", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepository(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id = $1 AND deleted_at IS NULL LIMIT $2`)).
+			WithArgs(productID.String(), 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "tags", "price", "created_at", "updated_at"}).
+				AddRow(productID.String(), "title", "description", "{}", 1000, tn, tn))
+
+		product, err := repo.GetProduct(context.Background(), queryOptions.NewProductQueryOptions(
+			queryOptions.WithProductID(vObject.NewProductIDFromUUIDUnsafe(productID)),
+		))
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+
+		assert.Equal(t, &entities.Product{
+			ID:          vObject.NewProductIDFromUUIDUnsafe(productID),
+			Title:       vObject.NewProductTitleUnsafe("title"),
+			Description: vObject.NewProductDescriptionUnsafe("description"),
+			Tags:        vObject.Tags{},
+			Price:       vObject.NewPriceUnsafe(1000),
+			CreatedAt:   tn,
+			UpdatedAt:   tn,
+		}, product)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepository(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products"`)).
+			WithArgs(anyArgs(2)...).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		product, err := repo.GetProduct(context.Background(), queryOptions.NewProductQueryOptions(
+			queryOptions.WithProductID(vObject.NewProductIDFromUUIDUnsafe(productID)),
+		))
+		require.ErrorIs(t, err, entities.ErrProductRecNotFound)
+		assert.Nil(t, product)
+	})
+}
+
+func TestRepository_GetProductForUpdate(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepository(t)
+	productID := baseUUID.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id = $1 AND deleted_at IS NULL LIMIT $2 FOR UPDATE`)).
+		WithArgs(productID.String(), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(productID.String(), "title"))
+
+	product, err := repo.GetProduct(context.Background(), queryOptions.NewProductQueryOptions(
+		queryOptions.WithProductID(vObject.NewProductIDFromUUIDUnsafe(productID)),
+		queryOptions.WithForUpdate[*queryOptions.ProductQueryOptions](),
+	))
+	require.NoError(t, err)
+	assert.Equal(t, productID, product.ID.UUID())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_CreateProduct(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepository(t)
+
+	tn := time.Now().UTC().Truncate(time.Second)
+	product := &entities.Product{
+		ID:          vObject.NewProductIDFromUUIDUnsafe(baseUUID.New()),
+		Title:       vObject.NewProductTitleUnsafe("title"),
+		Description: vObject.NewProductDescriptionUnsafe("description"),
+		Tags:        vObject.Tags{"tag", "other"},
+		Price:       vObject.NewPriceUnsafe(1000),
+		CreatedAt:   tn,
+		UpdatedAt:   tn,
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "products" ("id","title","description","tags","price","created_at","updated_at","deleted_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`)).
+		WithArgs(product.ID.UUID(), "title", "description", "{tag,other}", int64(1000), tn, tn, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.CreateProduct(context.Background(), product))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "products"`)).WithArgs(anyArgs(8)...).WillReturnError(assert.AnError)
+
+	require.ErrorIs(t, repo.CreateProduct(context.Background(), product), assert.AnError)
+}
+
+func TestRepository_UpdateProduct(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepository(t)
+
+	tn := time.Now().UTC().Truncate(time.Second)
+	product := &entities.Product{
+		ID:          vObject.NewProductIDFromUUIDUnsafe(baseUUID.New()),
+		Title:       vObject.NewProductTitleUnsafe("title"),
+		Description: vObject.NewProductDescriptionUnsafe("description"),
+		Tags:        vObject.Tags{"tag"},
+		Price:       vObject.NewPriceUnsafe(1000),
+		UpdatedAt:   tn,
+		DeletedAt:   &tn,
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "deleted_at"=$1,"description"=$2,"price"=$3,"tags"=$4,"title"=$5,"updated_at"=$6 WHERE id = $7`)).
+		WithArgs(tn, "description", int64(1000), "{tag}", "title", tn, product.ID.UUID()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.UpdateProduct(context.Background(), product))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products"`)).WithArgs(anyArgs(7)...).WillReturnError(assert.AnError)
+
+	require.ErrorIs(t, repo.UpdateProduct(context.Background(), product), assert.AnError)
+}
+
+func TestRepository_ListProducts(t *testing.T) {
+	t.Parallel()
+
+	productID := baseUUID.New()
+	newerID := baseUUID.New()
+	newestID := baseUUID.New()
+	tn := time.Now().UTC().Truncate(time.Second)
+
+	t.Run("filters and page", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepository(t)
+
+		where := `WHERE deleted_at IS NULL AND tags @> $1 AND title ILIKE $2 AND price >= $3 AND price <= $4 ` +
+			`AND (EXISTS (SELECT 1 FROM stocks WHERE stocks.product_id = products.id AND stocks.available_quantity > stocks.reserved_quantity))`
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "products" `+where)).
+			WithArgs("{молоко,напитки}", `%50\%%`, int64(100), int64(5000)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(21))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" `+where+` ORDER BY price DESC, id DESC LIMIT $5 OFFSET $6`)).
+			WithArgs("{молоко,напитки}", `%50\%%`, int64(100), int64(5000), 10, 20).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "tags", "price", "created_at", "updated_at"}).
+				AddRow(productID.String(), "Молоко 50%", "", "{молоко,напитки}", 1000, tn, tn))
+
+		qos := queryOptions.NewProductQueryOptions(
+			queryOptions.WithProductTags(vObject.Tags{"молоко", "напитки"}, vObject.TagsMatchAll),
+			queryOptions.WithProductTitle("50%"),
+			queryOptions.WithProductMinPrice(vObject.NewPriceUnsafe(100)),
+			queryOptions.WithProductMaxPrice(vObject.NewPriceUnsafe(5000)),
+			queryOptions.WithProductInStockOnly(),
+			queryOptions.WithProductSort(vObject.ProductSortPriceDesc),
+			queryOptions.WithMetaPage[*queryOptions.ProductQueryOptions](3),
+		)
+
+		list, err := repo.ListProducts(context.Background(), qos)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+
+		require.Len(t, list, 1)
+		assert.Equal(t, productID, list[0].ID.UUID())
+		assert.Equal(t, vObject.Tags{"молоко", "напитки"}, list[0].Tags)
+		assert.Equal(t, vObject.Meta{Total: 21, PerPage: 10, Page: 3, LastPage: 3}, *qos.GetMeta())
+	})
+
+	t.Run("search by relevance", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepository(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "products" WHERE deleted_at IS NULL AND tags && $1 AND search_vector @@ websearch_to_tsquery('russian', $2)`)).
+			WithArgs("{молоко}", "свежее молоко").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE deleted_at IS NULL AND tags && $1 AND search_vector @@ websearch_to_tsquery('russian', $2) ORDER BY ts_rank(search_vector, websearch_to_tsquery('russian', $3)) DESC, id LIMIT $4`)).
+			WithArgs("{молоко}", "свежее молоко", "свежее молоко", 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(productID.String(), "Молоко"))
+
+		qos := queryOptions.NewProductQueryOptions(
+			queryOptions.WithProductTags(vObject.Tags{"молоко"}, vObject.TagsMatchAny),
+			queryOptions.WithProductSearch("свежее молоко"),
+			queryOptions.WithProductSort(vObject.ProductSortRelevance),
+		)
+
+		list, err := repo.ListProducts(context.Background(), qos)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+		require.Len(t, list, 1)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepository(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "products" WHERE deleted_at IS NULL`)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		qos := queryOptions.NewProductQueryOptions()
+
+		list, err := repo.ListProducts(context.Background(), qos)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+		assert.Empty(t, list)
+		assert.Equal(t, vObject.Meta{Total: 0, PerPage: 10, Page: 1, LastPage: 0}, *qos.GetMeta())
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepository(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE deleted_at IS NULL AND id > $1 ORDER BY id LIMIT $2`)).
+			WithArgs(productID.String(), 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "tags", "price", "created_at", "updated_at"}).
+				AddRow(newerID.String(), "newer", "", "{}", 100, tn, tn).
+				AddRow(newestID.String(), "newest", "", "{}", 100, tn, tn))
+
+		qos := queryOptions.NewProductQueryOptions(
+			queryOptions.WithMetaCursor[*queryOptions.ProductQueryOptions](vObject.NewPrevCursor(productID)),
+			queryOptions.WithMetaPerPage[*queryOptions.ProductQueryOptions](1),
+		)
+
+		list, err := repo.ListProducts(context.Background(), qos)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+		require.Len(t, list, 1)
+		assert.Equal(t, vObject.NewProductIDFromUUIDUnsafe(newerID), list[0].ID)
+		assert.Equal(t, vObject.Meta{
+			PerPage:    1,
+			PrevCursor: vObject.NewPrevCursor(newerID).Token(),
+			NextCursor: vObject.NewNextCursor(newerID).Token(),
+		}, *qos.GetMeta())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepository(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "products"`)).WillReturnError(assert.AnError)
+
+		_, err := repo.ListProducts(context.Background(), queryOptions.NewProductQueryOptions())
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
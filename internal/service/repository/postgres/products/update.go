@@ -0,0 +1,31 @@
+package products
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+)
+
+// UpdateProduct сохраняет поля товара, которые меняет оператор, и отметку об архивировании.
+func (r *Repository) UpdateProduct(ctx context.Context, product *entities.Product) error {
+	row := models.NewProductRow(product)
+
+	err := r.WriteDBTrx(ctx).WithContext(ctx).
+		Model(&models.ProductRow{}).
+		Where("id = ?", row.ID).
+		Updates(map[string]any{
+			"title":       row.Title,
+			"description": row.Description,
+			"tags":        row.Tags,
+			"price":       row.Price,
+			"updated_at":  row.UpdatedAt,
+			"deleted_at":  row.DeletedAt,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("[products.UpdateProduct] %w", err)
+	}
+
+	return nil
+}
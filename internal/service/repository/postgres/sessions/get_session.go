@@ -0,0 +1,28 @@
+package sessions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+)
+
+func (r *Repository) GetSession(ctx context.Context, qos queryOptions.SessionQueryOptionable) (*entities.Session, error) {
+	var row models.SessionRow
+
+	err := r.GetQueryDB(ctx, qos).
+		Where("id = ?", qos.ForSessionID().UUID()).
+		Take(&row).Error
+	if db.IsNotFoundError(err) {
+		return nil, fmt.Errorf("[sessions.GetSession] %w", entities.ErrSessionRecNotFound)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("[sessions.GetSession] %w", err)
+	}
+
+	return row.ToEntity(), nil
+}
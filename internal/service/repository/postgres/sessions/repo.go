@@ -0,0 +1,36 @@
+package sessions
+
+import (
+	trmgorm "github.com/avito-tech/go-transaction-manager/gorm"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	revokeUserSessions "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/revoke_by_user"
+	upsertSession "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/upsert"
+	getSession "github.com/smgladkovskiy/warehouse-task/internal/service/queries/session/get_session"
+)
+
+type Repository struct {
+	trx.WithTransactionDB
+}
+
+var (
+	_ getSession.SessionGetter               = (*Repository)(nil)
+	_ upsertSession.SessionUpserter          = (*Repository)(nil)
+	_ revokeUserSessions.UserSessionsRevoker = (*Repository)(nil)
+)
+
+func NewRepository(db *db.Instance, trx *trmgorm.CtxGetter) *Repository {
+	if db == nil {
+		panic("database instance is nil")
+	}
+
+	if trx == nil {
+		panic("transaction CtxGetter is nil")
+	}
+
+	r := Repository{}
+	r.SetTransactionDB(db, trx)
+
+	return &r
+}
@@ -0,0 +1,141 @@
+package sessions_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	trmgorm "github.com/avito-tech/go-transaction-manager/gorm"
+	trmcontext "github.com/avito-tech/go-transaction-manager/trm/context"
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/sessions"
+)
+
+func newRepository(t *testing.T) (*sessions.Repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       mockDB,
+		DriverName: "postgres",
+	}), &gorm.Config{SkipDefaultTransaction: true, Logger: logger.Discard})
+	require.NoError(t, err)
+
+	return sessions.NewRepository(&db.Instance{Gorm: gormDB}, trmgorm.NewCtxGetter(trmcontext.DefaultManager)), mock
+}
+
+func anyArgs(n int) []driver.Value {
+	args := make([]driver.Value, n)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+
+	return args
+}
+
+func TestNewRepository(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() {
+		sessions.NewRepository(nil, trmgorm.NewCtxGetter(trmcontext.DefaultManager))
+	})
+	assert.Panics(t, func() {
+		sessions.NewRepository(&db.Instance{}, nil)
+	})
+}
+
+func TestRepository_UpsertSession(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepository(t)
+	session := entities.NewSession(vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), time.Hour)
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "user_sessions" ("id","user_id","refresh_token_id","expires_at","revoked_at","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7) ON CONFLICT ("id") DO UPDATE SET "refresh_token_id"="excluded"."refresh_token_id","expires_at"="excluded"."expires_at","revoked_at"="excluded"."revoked_at","updated_at"="excluded"."updated_at"`)).
+		WithArgs(anyArgs(7)...).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.UpsertSession(context.Background(), &session))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "user_sessions"`)).
+		WithArgs(anyArgs(7)...).
+		WillReturnError(assert.AnError)
+
+	require.ErrorIs(t, repo.UpsertSession(context.Background(), &session), assert.AnError)
+}
+
+func TestRepository_RevokeUserSessions(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepository(t)
+	userID := baseUUID.New()
+	tn := time.Now().UTC().Truncate(time.Second)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "user_sessions" SET "revoked_at"=$1,"updated_at"=$2 WHERE user_id = $3 AND revoked_at IS NULL`)).
+		WithArgs(tn, tn, userID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	require.NoError(t, repo.RevokeUserSessions(context.Background(), vObject.NewUserIDFromUUIDUnsafe(userID), tn))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "user_sessions"`)).
+		WithArgs(anyArgs(3)...).
+		WillReturnError(assert.AnError)
+
+	require.ErrorIs(t, repo.RevokeUserSessions(context.Background(), vObject.NewUserIDFromUUIDUnsafe(userID), tn), assert.AnError)
+}
+
+func TestRepository_GetSession(t *testing.T) {
+	t.Parallel()
+
+	id := baseUUID.New()
+	userID := baseUUID.New()
+	refreshID := baseUUID.New()
+	tn := time.Now().UTC().Truncate(time.Second)
+	qos := queryOptions.NewSessionQueryOptions(
+		queryOptions.WithSessionID(vObject.NewSessionIDFromUUIDUnsafe(id)),
+		queryOptions.WithForUpdate[*queryOptions.SessionQueryOptions](),
+	)
+
+	repo, mock := newRepository(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_sessions" WHERE id = $1 LIMIT $2 FOR UPDATE`)).
+		WithArgs(id.String(), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "refresh_token_id", "expires_at", "revoked_at", "created_at", "updated_at"}).
+			AddRow(id.String(), userID.String(), refreshID.String(), tn.Add(time.Hour), nil, tn, tn))
+
+	session, err := repo.GetSession(context.Background(), qos)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	assert.Equal(t, &entities.Session{
+		ID:             vObject.NewSessionIDFromUUIDUnsafe(id),
+		UserID:         vObject.NewUserIDFromUUIDUnsafe(userID),
+		RefreshTokenID: refreshID,
+		ExpiresAt:      tn.Add(time.Hour),
+		CreatedAt:      tn,
+		UpdatedAt:      tn,
+	}, session)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_sessions"`)).
+		WithArgs(anyArgs(2)...).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err = repo.GetSession(context.Background(), qos)
+	require.ErrorIs(t, err, entities.ErrSessionRecNotFound)
+}
@@ -0,0 +1,26 @@
+package sessions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+)
+
+// RevokeUserSessions отзывает все активные сессии пользователя, например после сброса пароля.
+func (r *Repository) RevokeUserSessions(ctx context.Context, userID vObject.UserID, revokedAt time.Time) error {
+	err := r.WriteDBTrx(ctx).WithContext(ctx).
+		Model(&models.SessionRow{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID.UUID()).
+		Updates(map[string]any{
+			"revoked_at": revokedAt,
+			"updated_at": revokedAt,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("[sessions.RevokeUserSessions] %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package sessions
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+)
+
+func (r *Repository) UpsertSession(ctx context.Context, session *entities.Session) error {
+	row := models.NewSessionRow(session)
+
+	err := r.WriteDBTrx(ctx).WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"refresh_token_id", "expires_at", "revoked_at", "updated_at"}),
+		}).
+		Create(&row).Error
+	if err != nil {
+		return fmt.Errorf("[sessions.UpsertSession] %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package stocktransfers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+)
+
+func (r *Repository) GetStockTransfer(ctx context.Context, qos queryOptions.StockTransferQueryOptionable) (*entities.StockTransfer, error) {
+	var row models.StockTransferRow
+
+	err := r.GetQueryDB(ctx, qos).
+		Where("id = ?", qos.ForStockTransferID().UUID()).
+		Take(&row).Error
+	if db.IsNotFoundError(err) {
+		return nil, fmt.Errorf("[stockTransfers.GetStockTransfer] %w", entities.ErrStockTransferRecNotFound)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("[stockTransfers.GetStockTransfer] %w", err)
+	}
+
+	return row.ToEntity(), nil
+}
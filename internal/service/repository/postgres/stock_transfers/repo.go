@@ -0,0 +1,35 @@
+package stocktransfers
+
+import (
+	trmgorm "github.com/avito-tech/go-transaction-manager/gorm"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	upsertstocktransfer "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock_transfer/upsert"
+	gettransfer "github.com/smgladkovskiy/warehouse-task/internal/service/queries/stock_transfer/get_transfer"
+)
+
+type Repository struct {
+	trx.WithTransactionDB
+}
+
+var (
+	_ gettransfer.StockTransferGetter           = (*Repository)(nil)
+	_ upsertstocktransfer.StockTransferUpserter = (*Repository)(nil)
+)
+
+func NewRepository(db *db.Instance, trx *trmgorm.CtxGetter) *Repository {
+	if db == nil {
+		panic("database instance is nil")
+	}
+
+	if trx == nil {
+		panic("transaction CtxGetter is nil")
+	}
+
+	r := Repository{}
+
+	r.SetTransactionDB(db, trx)
+
+	return &r
+}
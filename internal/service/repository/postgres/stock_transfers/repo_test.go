@@ -0,0 +1,117 @@
+package stocktransfers_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	trmgorm "github.com/avito-tech/go-transaction-manager/gorm"
+	trmcontext "github.com/avito-tech/go-transaction-manager/trm/context"
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	stockTransfers "github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/stock_transfers"
+)
+
+func newRepository(t *testing.T) (*stockTransfers.Repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       mockDB,
+		DriverName: "postgres",
+	}), &gorm.Config{SkipDefaultTransaction: true, Logger: logger.Discard})
+	require.NoError(t, err)
+
+	return stockTransfers.NewRepository(&db.Instance{Gorm: gormDB}, trmgorm.NewCtxGetter(trmcontext.DefaultManager)), mock
+}
+
+func anyArgs(n int) []driver.Value {
+	args := make([]driver.Value, n)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+
+	return args
+}
+
+func TestRepository_UpsertStockTransfer(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepository(t)
+	transfer, err := entities.NewStockTransfer(
+		vObject.NewProductIDFromUUIDUnsafe(baseUUID.New()),
+		vObject.NewWarehouseIDFromUUIDUnsafe(baseUUID.New()),
+		vObject.NewWarehouseIDFromUUIDUnsafe(baseUUID.New()),
+		vObject.NewQuantityUnsafe(3),
+	)
+	require.NoError(t, err)
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "stock_transfers" ("id","product_id","from_warehouse_id","to_warehouse_id","quantity","status","dispatched_at","received_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) ON CONFLICT ("id") DO UPDATE SET "status"="excluded"."status","received_at"="excluded"."received_at"`)).
+		WithArgs(anyArgs(8)...).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.UpsertStockTransfer(context.Background(), &transfer))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "stock_transfers"`)).
+		WithArgs(anyArgs(8)...).
+		WillReturnError(assert.AnError)
+
+	require.ErrorIs(t, repo.UpsertStockTransfer(context.Background(), &transfer), assert.AnError)
+}
+
+func TestRepository_GetStockTransfer(t *testing.T) {
+	t.Parallel()
+
+	id := baseUUID.New()
+	productID := baseUUID.New()
+	fromID := baseUUID.New()
+	toID := baseUUID.New()
+	tn := time.Now().UTC().Truncate(time.Second)
+	qos := queryOptions.NewStockTransferQueryOptions(
+		queryOptions.WithStockTransferID(vObject.NewStockTransferIDFromUUIDUnsafe(id)),
+		queryOptions.WithForUpdate[*queryOptions.StockTransferQueryOptions](),
+	)
+
+	repo, mock := newRepository(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_transfers" WHERE id = $1 LIMIT $2 FOR UPDATE`)).
+		WithArgs(id.String(), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "from_warehouse_id", "to_warehouse_id", "quantity", "status", "dispatched_at", "received_at"}).
+			AddRow(id.String(), productID.String(), fromID.String(), toID.String(), 3, "in_transit", tn, nil))
+
+	transfer, err := repo.GetStockTransfer(context.Background(), qos)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	assert.Equal(t, &entities.StockTransfer{
+		ID:              vObject.NewStockTransferIDFromUUIDUnsafe(id),
+		ProductID:       vObject.NewProductIDFromUUIDUnsafe(productID),
+		FromWarehouseID: vObject.NewWarehouseIDFromUUIDUnsafe(fromID),
+		ToWarehouseID:   vObject.NewWarehouseIDFromUUIDUnsafe(toID),
+		Quantity:        vObject.NewQuantityUnsafe(3),
+		Status:          vObject.StockTransferStatusInTransit,
+		DispatchedAt:    tn,
+	}, transfer)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_transfers"`)).
+		WithArgs(anyArgs(2)...).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err = repo.GetStockTransfer(context.Background(), qos)
+	require.ErrorIs(t, err, entities.ErrStockTransferRecNotFound)
+}
@@ -0,0 +1,27 @@
+package stocktransfers
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+)
+
+func (r *Repository) UpsertStockTransfer(ctx context.Context, transfer *entities.StockTransfer) error {
+	row := models.NewStockTransferRow(transfer)
+
+	err := r.WriteDBTrx(ctx).WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"status", "received_at"}),
+		}).
+		Create(&row).Error
+	if err != nil {
+		return fmt.Errorf("[stockTransfers.UpsertStockTransfer] %w", err)
+	}
+
+	return nil
+}
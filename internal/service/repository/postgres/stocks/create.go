@@ -0,0 +1,30 @@
+package stocks
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+)
+
+// CreateStock создаёт строку остатка товара на складе, если её ещё нет, и не трогает существующую.
+// Параллельная вставка той же строки ждёт фиксации первой транзакции, поэтому после CreateStock
+// остаток нужно получить заново с блокировкой (GetStocks с forUpdate) и только потом изменять.
+func (r *Repository) CreateStock(ctx context.Context, stock *entities.Stock) error {
+	row := models.NewStockRow(stock)
+
+	err := r.WriteDBTrx(ctx).WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "product_id"}, {Name: "warehouse_id"}},
+			DoNothing: true,
+		}).
+		Create(&row).Error
+	if err != nil {
+		return fmt.Errorf("[stocks.CreateStock] %w", err)
+	}
+
+	return nil
+}
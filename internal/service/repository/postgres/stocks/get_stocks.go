@@ -2,12 +2,30 @@ package stocks
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
 	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
 )
 
+// GetStocks возвращает остатки товара по всем складам.
+// Пагинация не применяется: для расчёта доступного количества нужны все склады.
 func (r *Repository) GetStocks(ctx context.Context, qos queryOptions.StockQueryOptionable) (entities.Stocks, error) {
-	//TODO implement me
-	panic("implement me")
+	var rows []models.StockRow
+
+	err := r.GetQueryDB(ctx, qos).
+		Where("product_id = ?", qos.ForProductID().UUID()).
+		Order("warehouse_id").
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("[stocks.GetStocks] %w", err)
+	}
+
+	stocks := make(entities.Stocks, 0, len(rows))
+	for _, row := range rows {
+		stocks = append(stocks, row.ToEntity())
+	}
+
+	return stocks, nil
 }
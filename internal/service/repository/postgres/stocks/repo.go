@@ -7,6 +7,8 @@ import (
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
 	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	createstock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/create"
+	updatestock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
 	getstocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
 )
 
@@ -16,7 +18,11 @@ type Repository struct {
 	trx.WithTransactionDB
 }
 
-var _ getstocks.StocksGetter = (*Repository)(nil)
+var (
+	_ getstocks.StocksGetter   = (*Repository)(nil)
+	_ createstock.StockCreator = (*Repository)(nil)
+	_ updatestock.StockUpdater = (*Repository)(nil)
+)
 
 func NewRepository(db *db.Instance, trx *trmgorm.CtxGetter) *Repository {
 	if db == nil {
@@ -0,0 +1,135 @@
+package stocks_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	trmgorm "github.com/avito-tech/go-transaction-manager/gorm"
+	trmcontext "github.com/avito-tech/go-transaction-manager/trm/context"
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	stocks "github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/stocks"
+)
+
+func newRepository(t *testing.T) (*stocks.Repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       mockDB,
+		DriverName: "postgres",
+	}), &gorm.Config{SkipDefaultTransaction: true, Logger: logger.Discard})
+	require.NoError(t, err)
+
+	return stocks.NewRepository(&db.Instance{Gorm: gormDB}, trmgorm.NewCtxGetter(trmcontext.DefaultManager)), mock
+}
+
+func anyArgs(n int) []driver.Value {
+	args := make([]driver.Value, n)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+
+	return args
+}
+
+func TestRepository_GetStocks(t *testing.T) {
+	t.Parallel()
+
+	productID := baseUUID.New()
+	warehouseID := baseUUID.New()
+	tn := time.Now().UTC().Truncate(time.Second)
+
+	repo, mock := newRepository(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stocks" WHERE product_id = $1 ORDER BY warehouse_id FOR UPDATE`)).
+		WithArgs(productID.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "warehouse_id", "available_quantity", "reserved_quantity", "in_transit_quantity", "created_at"}).
+			AddRow(productID.String(), warehouseID.String(), 10, 3, 2, tn))
+
+	stocks, err := repo.GetStocks(context.Background(), queryOptions.NewStockQueryOptions(
+		queryOptions.WithStockProductID(vObject.NewProductIDFromUUIDUnsafe(productID)),
+		queryOptions.WithForUpdate[*queryOptions.StockQueryOptions](),
+	))
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	assert.Equal(t, entities.Stocks{{
+		ProductID:         vObject.NewProductIDFromUUIDUnsafe(productID),
+		WarehouseID:       vObject.NewWarehouseIDFromUUIDUnsafe(warehouseID),
+		AvailableQuantity: vObject.NewQuantityUnsafe(10),
+		ReservedQuantity:  vObject.NewQuantityUnsafe(3),
+		InTransitQuantity: vObject.NewQuantityUnsafe(2),
+		CreatedAt:         tn,
+	}}, stocks)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stocks"`)).WithArgs(anyArgs(1)...).WillReturnError(assert.AnError)
+
+	_, err = repo.GetStocks(context.Background(), queryOptions.NewStockQueryOptions(
+		queryOptions.WithStockProductID(vObject.NewProductIDFromUUIDUnsafe(productID)),
+	))
+	require.ErrorIs(t, err, assert.AnError)
+}
+
+func TestRepository_UpdateStock(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepository(t)
+	stock := entities.Stock{
+		ProductID:         vObject.NewProductIDFromUUIDUnsafe(baseUUID.New()),
+		WarehouseID:       vObject.NewWarehouseIDFromUUIDUnsafe(baseUUID.New()),
+		AvailableQuantity: vObject.NewQuantityUnsafe(10),
+		ReservedQuantity:  vObject.NewQuantityUnsafe(4),
+		InTransitQuantity: vObject.NewQuantityUnsafe(5),
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "stocks" SET "available_quantity"=$1,"in_transit_quantity"=$2,"reserved_quantity"=$3 WHERE product_id = $4 AND warehouse_id = $5`)).
+		WithArgs(10, 5, 4, stock.ProductID.UUID(), stock.WarehouseID.UUID()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.UpdateStock(context.Background(), &stock))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "stocks"`)).WithArgs(anyArgs(5)...).WillReturnError(assert.AnError)
+
+	require.ErrorIs(t, repo.UpdateStock(context.Background(), &stock), assert.AnError)
+}
+
+func TestRepository_CreateStock(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepository(t)
+	stock := entities.NewStockUnsafe(
+		vObject.NewProductIDFromUUIDUnsafe(baseUUID.New()),
+		vObject.NewWarehouseIDFromUUIDUnsafe(baseUUID.New()),
+		vObject.QuantityZero,
+		vObject.QuantityZero,
+	)
+
+	// существующая строка остатка не перезаписывается
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "stocks" ("product_id","warehouse_id","available_quantity","reserved_quantity","in_transit_quantity","created_at") VALUES ($1,$2,$3,$4,$5,$6) ON CONFLICT ("product_id","warehouse_id") DO NOTHING`)).
+		WithArgs(stock.ProductID.UUID(), stock.WarehouseID.UUID(), 0, 0, 0, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	require.NoError(t, repo.CreateStock(context.Background(), &stock))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "stocks"`)).WithArgs(anyArgs(6)...).WillReturnError(assert.AnError)
+
+	require.ErrorIs(t, repo.CreateStock(context.Background(), &stock), assert.AnError)
+}
@@ -0,0 +1,30 @@
+package stocks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+)
+
+// UpdateStock сохраняет доступное, зарезервированное и находящееся в пути количество товара на складе.
+// Строка остатка должна существовать и быть заблокирована в текущей транзакции: новую строку сначала
+// создаёт CreateStock, затем остатки получаются заново через GetStocks с forUpdate.
+func (r *Repository) UpdateStock(ctx context.Context, stock *entities.Stock) error {
+	row := models.NewStockRow(stock)
+
+	err := r.WriteDBTrx(ctx).WithContext(ctx).
+		Model(&models.StockRow{}).
+		Where("product_id = ? AND warehouse_id = ?", row.ProductID, row.WarehouseID).
+		Updates(map[string]any{
+			"available_quantity":  row.AvailableQuantity,
+			"reserved_quantity":   row.ReservedQuantity,
+			"in_transit_quantity": row.InTransitQuantity,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("[stocks.UpdateStock] %w", err)
+	}
+
+	return nil
+}
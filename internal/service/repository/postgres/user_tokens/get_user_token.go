@@ -0,0 +1,28 @@
+package usertokens
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+)
+
+func (r *Repository) GetUserToken(ctx context.Context, qos queryOptions.UserTokenQueryOptionable) (*entities.UserToken, error) {
+	var row models.UserTokenRow
+
+	err := r.GetQueryDB(ctx, qos).
+		Where("token_hash = ? AND purpose = ?", qos.ForUserTokenHash(), string(qos.ForUserTokenPurpose())).
+		Take(&row).Error
+	if db.IsNotFoundError(err) {
+		return nil, fmt.Errorf("[userTokens.GetUserToken] %w", entities.ErrUserTokenRecNotFound)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("[userTokens.GetUserToken] %w", err)
+	}
+
+	return row.ToEntity(), nil
+}
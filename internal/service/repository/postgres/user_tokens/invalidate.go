@@ -0,0 +1,25 @@
+package usertokens
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+)
+
+// InvalidateUserTokens погашает остальные неиспользованные токены пользователя с тем же назначением,
+// что и token: после сброса пароля старые письма со ссылкой на сброс перестают действовать.
+func (r *Repository) InvalidateUserTokens(ctx context.Context, token *entities.UserToken) error {
+	row := models.NewUserTokenRow(token)
+
+	err := r.WriteDBTrx(ctx).WithContext(ctx).
+		Model(&models.UserTokenRow{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL AND token_hash <> ?", row.UserID, row.Purpose, row.Hash).
+		Update("used_at", row.UsedAt).Error
+	if err != nil {
+		return fmt.Errorf("[userTokens.InvalidateUserTokens] %w", err)
+	}
+
+	return nil
+}
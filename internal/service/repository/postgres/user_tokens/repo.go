@@ -0,0 +1,36 @@
+package usertokens
+
+import (
+	trmgorm "github.com/avito-tech/go-transaction-manager/gorm"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	invalidateUserTokens "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/invalidate"
+	upsertUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/upsert"
+	getUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user_token/get_user_token"
+)
+
+type Repository struct {
+	trx.WithTransactionDB
+}
+
+var (
+	_ getUserToken.UserTokenGetter               = (*Repository)(nil)
+	_ upsertUserToken.UserTokenUpserter          = (*Repository)(nil)
+	_ invalidateUserTokens.UserTokensInvalidator = (*Repository)(nil)
+)
+
+func NewRepository(db *db.Instance, trx *trmgorm.CtxGetter) *Repository {
+	if db == nil {
+		panic("database instance is nil")
+	}
+
+	if trx == nil {
+		panic("transaction CtxGetter is nil")
+	}
+
+	r := Repository{}
+	r.SetTransactionDB(db, trx)
+
+	return &r
+}
@@ -0,0 +1,140 @@
+package usertokens_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	trmgorm "github.com/avito-tech/go-transaction-manager/gorm"
+	trmcontext "github.com/avito-tech/go-transaction-manager/trm/context"
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	usertokens "github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/user_tokens"
+)
+
+func newRepository(t *testing.T) (*usertokens.Repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       mockDB,
+		DriverName: "postgres",
+	}), &gorm.Config{SkipDefaultTransaction: true, Logger: logger.Discard})
+	require.NoError(t, err)
+
+	return usertokens.NewRepository(&db.Instance{Gorm: gormDB}, trmgorm.NewCtxGetter(trmcontext.DefaultManager)), mock
+}
+
+func anyArgs(n int) []driver.Value {
+	args := make([]driver.Value, n)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+
+	return args
+}
+
+func TestNewRepository(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() {
+		usertokens.NewRepository(nil, trmgorm.NewCtxGetter(trmcontext.DefaultManager))
+	})
+	assert.Panics(t, func() {
+		usertokens.NewRepository(&db.Instance{}, nil)
+	})
+}
+
+func TestRepository_UpsertUserToken(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepository(t)
+	token, _, err := entities.NewUserToken(vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), entities.UserTokenPasswordReset, time.Hour)
+	require.NoError(t, err)
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "user_tokens" ("token_hash","user_id","purpose","expires_at","used_at","created_at") VALUES ($1,$2,$3,$4,$5,$6) ON CONFLICT ("token_hash") DO UPDATE SET "used_at"="excluded"."used_at"`)).
+		WithArgs(anyArgs(6)...).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.UpsertUserToken(context.Background(), &token))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "user_tokens"`)).
+		WithArgs(anyArgs(6)...).
+		WillReturnError(assert.AnError)
+
+	require.ErrorIs(t, repo.UpsertUserToken(context.Background(), &token), assert.AnError)
+}
+
+func TestRepository_InvalidateUserTokens(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepository(t)
+	token, _, err := entities.NewUserToken(vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), entities.UserTokenPasswordReset, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, token.Redeem())
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "user_tokens" SET "used_at"=$1 WHERE user_id = $2 AND purpose = $3 AND used_at IS NULL AND token_hash <> $4`)).
+		WithArgs(*token.UsedAt, token.UserID.UUID(), "password_reset", token.Hash).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.InvalidateUserTokens(context.Background(), &token))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "user_tokens"`)).
+		WithArgs(anyArgs(4)...).
+		WillReturnError(assert.AnError)
+
+	require.ErrorIs(t, repo.InvalidateUserTokens(context.Background(), &token), assert.AnError)
+}
+
+func TestRepository_GetUserToken(t *testing.T) {
+	t.Parallel()
+
+	userID := baseUUID.New()
+	tn := time.Now().UTC().Truncate(time.Second)
+	qos := queryOptions.NewUserTokenQueryOptions(
+		queryOptions.WithUserToken("token_hash", entities.UserTokenEmailVerification),
+		queryOptions.WithForUpdate[*queryOptions.UserTokenQueryOptions](),
+	)
+
+	repo, mock := newRepository(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_tokens" WHERE token_hash = $1 AND purpose = $2 LIMIT $3 FOR UPDATE`)).
+		WithArgs("token_hash", "email_verification", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"token_hash", "user_id", "purpose", "expires_at", "used_at", "created_at"}).
+			AddRow("token_hash", userID.String(), "email_verification", tn.Add(time.Hour), nil, tn))
+
+	token, err := repo.GetUserToken(context.Background(), qos)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	assert.Equal(t, &entities.UserToken{
+		Hash:      "token_hash",
+		UserID:    vObject.NewUserIDFromUUIDUnsafe(userID),
+		Purpose:   entities.UserTokenEmailVerification,
+		ExpiresAt: tn.Add(time.Hour),
+		CreatedAt: tn,
+	}, token)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_tokens"`)).
+		WithArgs(anyArgs(3)...).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err = repo.GetUserToken(context.Background(), qos)
+	require.ErrorIs(t, err, entities.ErrUserTokenRecNotFound)
+}
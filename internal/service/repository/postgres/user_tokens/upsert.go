@@ -0,0 +1,28 @@
+package usertokens
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+)
+
+// UpsertUserToken сохраняет выпущенный токен либо отметку о его использовании.
+func (r *Repository) UpsertUserToken(ctx context.Context, token *entities.UserToken) error {
+	row := models.NewUserTokenRow(token)
+
+	err := r.WriteDBTrx(ctx).WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "token_hash"}},
+			DoUpdates: clause.AssignmentColumns([]string{"used_at"}),
+		}).
+		Create(&row).Error
+	if err != nil {
+		return fmt.Errorf("[userTokens.UpsertUserToken] %w", err)
+	}
+
+	return nil
+}
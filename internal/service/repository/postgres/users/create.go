@@ -2,11 +2,23 @@ package users
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
 	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
 )
 
 func (r *Repository) CreateUser(ctx context.Context, user *entities.User) error {
-	//TODO implement me
-	panic("implement me")
+	row := models.NewUserRow(user)
+
+	if err := r.WriteDBTrx(ctx).WithContext(ctx).Create(&row).Error; err != nil {
+		if db.IsDuplicateKeyError(err) {
+			return fmt.Errorf("[users.CreateUser] %w", entities.ErrUserAlreadyExists)
+		}
+
+		return fmt.Errorf("[users.CreateUser] %w", err)
+	}
+
+	return nil
 }
@@ -2,12 +2,27 @@ package users
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
 	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
-	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
 )
 
-func (r *Repository) GetByEmail(ctx context.Context, email vObject.Email) (*entities.User, error) {
-	//TODO implement me
-	panic("implement me")
+func (r *Repository) GetByEmail(ctx context.Context, qos queryOptions.UserQueryOptionable) (*entities.User, error) {
+	var row models.UserRow
+
+	err := r.GetQueryDB(ctx, qos).
+		Where("email = ? AND deleted_at IS NULL", string(qos.ForUserEmail())).
+		Take(&row).Error
+	if db.IsNotFoundError(err) {
+		return nil, fmt.Errorf("[users.GetByEmail] %w", entities.ErrUserRecNotFound)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("[users.GetByEmail] %w", err)
+	}
+
+	return row.ToEntity(), nil
 }
@@ -0,0 +1,32 @@
+package users
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+)
+
+func (r *Repository) GetByID(ctx context.Context, qos queryOptions.UserQueryOptionable) (*entities.User, error) {
+	if qos.ForUserID() == nil {
+		return nil, fmt.Errorf("[users.GetByID] %w", entities.ErrUserRecNotFound)
+	}
+
+	var row models.UserRow
+
+	err := r.GetQueryDB(ctx, qos).
+		Where("id = ? AND deleted_at IS NULL", qos.ForUserID().UUID()).
+		Take(&row).Error
+	if db.IsNotFoundError(err) {
+		return nil, fmt.Errorf("[users.GetByID] %w", entities.ErrUserRecNotFound)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("[users.GetByID] %w", err)
+	}
+
+	return row.ToEntity(), nil
+}
@@ -8,7 +8,9 @@ import (
 	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
 	createUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/create"
+	updateUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/update"
 	getUserByEmail "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_by_email"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
 )
 
 type Repository struct {
@@ -19,7 +21,9 @@ type Repository struct {
 
 var (
 	_ createUser.UserCreator    = (*Repository)(nil)
+	_ updateUser.UserUpdater    = (*Repository)(nil)
 	_ getUserByEmail.UserGetter = (*Repository)(nil)
+	_ getUser.UserByIDGetter    = (*Repository)(nil)
 )
 
 func NewRepository(db *db.Instance, trx *trmgorm.CtxGetter) *Repository {
@@ -0,0 +1,234 @@
+package users_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	trmgorm "github.com/avito-tech/go-transaction-manager/gorm"
+	trmcontext "github.com/avito-tech/go-transaction-manager/trm/context"
+	baseUUID "github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/db"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/users"
+)
+
+func newRepository(t *testing.T) (*users.Repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       mockDB,
+		DriverName: "postgres",
+	}), &gorm.Config{SkipDefaultTransaction: true, Logger: logger.Discard})
+	require.NoError(t, err)
+
+	return users.NewRepository(&db.Instance{Gorm: gormDB}, trmgorm.NewCtxGetter(trmcontext.DefaultManager)), mock
+}
+
+func anyArgs(n int) []driver.Value {
+	args := make([]driver.Value, n)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+
+	return args
+}
+
+func TestNewRepository(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() {
+		users.NewRepository(nil, trmgorm.NewCtxGetter(trmcontext.DefaultManager))
+	})
+	assert.Panics(t, func() {
+		users.NewRepository(&db.Instance{}, nil)
+	})
+}
+
+func TestRepository_CreateUser(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name   string
+		dbErr  error
+		expErr error
+	}
+
+	tcs := []testCase{
+		{name: "happy path"},
+		{name: "duplicate email", dbErr: &pgconn.PgError{Code: "23505"}, expErr: entities.ErrUserAlreadyExists},
+		{name: "db error", dbErr: assert.AnError, expErr: assert.AnError},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo, mock := newRepository(t)
+			user := &entities.User{
+				ID:    vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()),
+				Email: vObject.NewEmailUnsafe("some@email.com"),
+			}
+
+			exp := mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "users"`)).WithArgs(anyArgs(14)...)
+			if tc.dbErr != nil {
+				exp.WillReturnError(tc.dbErr)
+			} else {
+				exp.WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			err := repo.CreateUser(context.Background(), user)
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRepository_GetByEmail(t *testing.T) {
+	t.Parallel()
+
+	id := baseUUID.New()
+	tn := time.Now().UTC().Truncate(time.Second)
+	birthDate := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("found", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepository(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE email = $1 AND deleted_at IS NULL LIMIT $2`)).
+			WithArgs("some@email.com", 1).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "email", "first_name", "last_name", "birth_date", "marital_status", "password_hash", "role", "created_at", "updated_at",
+			}).AddRow(id.String(), "some@email.com", "first", "last", birthDate, "married", "hash", "customer", tn, tn))
+
+		user, err := repo.GetByEmail(context.Background(), queryOptions.NewUserQueryOptions(
+			queryOptions.WithUserEmail(vObject.NewEmailUnsafe("some@email.com")),
+		))
+		require.NoError(t, err)
+
+		assert.Equal(t, &entities.User{
+			ID:            vObject.NewUserIDFromUUIDUnsafe(id),
+			Email:         vObject.NewEmailUnsafe("some@email.com"),
+			FirstName:     vObject.NewFirstNameUnsafe("first"),
+			LastName:      vObject.NewLastNameUnsafe("last"),
+			BirthDate:     vObject.NewAgeUnsafe(birthDate),
+			MaritalStatus: vObject.MaritalStatusMarried,
+			PasswordHash:  vObject.NewPasswordHashUnsafe("hash"),
+			Role:          vObject.RoleCustomer,
+			CreatedAt:     tn,
+			UpdatedAt:     tn,
+		}, user)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepository(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users"`)).WithArgs(anyArgs(2)...).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		user, err := repo.GetByEmail(context.Background(), queryOptions.NewUserQueryOptions(
+			queryOptions.WithUserEmail(vObject.NewEmailUnsafe("some@email.com")),
+		))
+		require.ErrorIs(t, err, entities.ErrUserRecNotFound)
+		assert.Nil(t, user)
+	})
+}
+
+func TestRepository_GetByEmailForUpdate(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepository(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE email = $1 AND deleted_at IS NULL LIMIT $2 FOR UPDATE`)).
+		WithArgs("some@email.com", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "failed_login_attempts"}).AddRow(baseUUID.New().String(), "some@email.com", 2))
+
+	user, err := repo.GetByEmail(context.Background(), queryOptions.NewUserQueryOptions(
+		queryOptions.WithUserEmail(vObject.NewEmailUnsafe("some@email.com")),
+		queryOptions.WithForUpdate[*queryOptions.UserQueryOptions](),
+	))
+	require.NoError(t, err)
+	assert.Equal(t, 2, user.FailedLoginAttempts)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_GetByID(t *testing.T) {
+	t.Parallel()
+
+	id := baseUUID.New()
+	qos := queryOptions.NewUserQueryOptions(
+		queryOptions.WithUserID(vObject.NewUserIDFromUUIDUnsafe(id)),
+		queryOptions.WithForUpdate[*queryOptions.UserQueryOptions](),
+	)
+
+	repo, mock := newRepository(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1 AND deleted_at IS NULL LIMIT $2 FOR UPDATE`)).
+		WithArgs(id.String(), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(id.String(), "some@email.com"))
+
+	user, err := repo.GetByID(context.Background(), qos)
+	require.NoError(t, err)
+	assert.Equal(t, id, user.ID.UUID())
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users"`)).WithArgs(anyArgs(2)...).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err = repo.GetByID(context.Background(), qos)
+	require.ErrorIs(t, err, entities.ErrUserRecNotFound)
+
+	_, err = repo.GetByID(context.Background(), queryOptions.NewUserQueryOptions())
+	require.ErrorIs(t, err, entities.ErrUserRecNotFound, "user id is required")
+}
+
+func TestRepository_UpdateUser(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepository(t)
+
+	tn := time.Now().UTC().Truncate(time.Second)
+	user := &entities.User{
+		ID:                  vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()),
+		FailedLoginAttempts: 1,
+		LockedUntil:         &tn,
+		PasswordHash:        "hashed_password",
+		EmailVerifiedAt:     &tn,
+		Role:                vObject.RoleOperator,
+		UpdatedAt:           tn,
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "email_verified_at"=$1,"failed_login_attempts"=$2,"locked_until"=$3,"password_hash"=$4,"role"=$5,"updated_at"=$6 WHERE id = $7`)).
+		WithArgs(tn, 1, tn, "hashed_password", "operator", tn, user.ID.UUID()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.UpdateUser(context.Background(), user))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users"`)).WithArgs(anyArgs(7)...).WillReturnError(assert.AnError)
+
+	require.ErrorIs(t, repo.UpdateUser(context.Background(), user), assert.AnError)
+}
@@ -0,0 +1,32 @@
+package users
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/postgres/models"
+)
+
+// UpdateUser сохраняет изменяемые после регистрации поля пользователя: состояние блокировки входа,
+// хеш пароля, подтверждение email и роль.
+func (r *Repository) UpdateUser(ctx context.Context, user *entities.User) error {
+	row := models.NewUserRow(user)
+
+	err := r.WriteDBTrx(ctx).WithContext(ctx).
+		Model(&models.UserRow{}).
+		Where("id = ?", row.ID).
+		Updates(map[string]any{
+			"email_verified_at":     row.EmailVerifiedAt,
+			"failed_login_attempts": row.FailedLoginAttempts,
+			"locked_until":          row.LockedUntil,
+			"password_hash":         row.PasswordHash,
+			"role":                  row.Role,
+			"updated_at":            row.UpdatedAt,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("[users.UpdateUser] %w", err)
+	}
+
+	return nil
+}
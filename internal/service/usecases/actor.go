@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+)
+
+// Actor получает пользователя actorID, от имени которого выполняется юзкейс. Анонимному вызову
+// и неизвестному пользователю отказывается в доступе: у них нет ни одного разрешения.
+func Actor(ctx context.Context, getUserQuery *getUser.QueryHandler, actorID uuid.UUID) (*entities.User, error) {
+	query, err := getUser.NewQuery(actorID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: anonymous actor", entities.ErrPermissionDenied)
+	}
+
+	actor, err := getUserQuery.Handle(ctx, *query)
+	if errors.Is(err, entities.ErrUserRecNotFound) {
+		return nil, fmt.Errorf("%w: unknown actor", entities.ErrPermissionDenied)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("[Actor - getUserQuery.Handle error]: %w", err)
+	}
+
+	return actor, nil
+}
+
+// Authorize получает пользователя actorID и проверяет, что его роль даёт разрешение permission.
+func Authorize(
+	ctx context.Context,
+	getUserQuery *getUser.QueryHandler,
+	actorID uuid.UUID,
+	permission vObject.Permission,
+) (*entities.User, error) {
+	actor, err := Actor(ctx, getUserQuery, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !actor.Can(permission) {
+		return nil, fmt.Errorf("%w: %s", entities.ErrPermissionDenied, permission)
+	}
+
+	return actor, nil
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryoptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+)
+
+func TestAuthorize(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name       string
+		actorID    baseUUID.UUID
+		permission vObject.Permission
+		exp        func(t *testing.T, getUserMock *getUser.GetUserByIDMock) (*entities.User, error)
+	}
+
+	id := baseUUID.New()
+	qos := queryoptions.NewUserQueryOptions(queryoptions.WithUserID(vObject.NewUserIDFromUUIDUnsafe(id)))
+	operator := &entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(id), Role: vObject.RoleOperator}
+	customer := &entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(id), Role: vObject.RoleCustomer}
+
+	tcs := []testCase{
+		{
+			name:       "permission granted",
+			actorID:    id,
+			permission: vObject.PermissionManageStock,
+			exp: func(t *testing.T, getUserMock *getUser.GetUserByIDMock) (*entities.User, error) {
+				t.Helper()
+
+				getUserMock.EXPECT().GetByID(gomock.Any(), qos).Return(operator, nil)
+
+				return operator, nil
+			},
+		},
+		{
+			name:       "permission denied",
+			actorID:    id,
+			permission: vObject.PermissionManageStock,
+			exp: func(t *testing.T, getUserMock *getUser.GetUserByIDMock) (*entities.User, error) {
+				t.Helper()
+
+				getUserMock.EXPECT().GetByID(gomock.Any(), qos).Return(customer, nil)
+
+				return nil, entities.ErrPermissionDenied
+			},
+		},
+		{
+			name:       "anonymous actor",
+			actorID:    baseUUID.Nil,
+			permission: vObject.PermissionManageOwnOrders,
+			exp: func(t *testing.T, _ *getUser.GetUserByIDMock) (*entities.User, error) {
+				t.Helper()
+
+				return nil, entities.ErrPermissionDenied
+			},
+		},
+		{
+			name:       "unknown actor",
+			actorID:    id,
+			permission: vObject.PermissionManageOwnOrders,
+			exp: func(t *testing.T, getUserMock *getUser.GetUserByIDMock) (*entities.User, error) {
+				t.Helper()
+
+				getUserMock.EXPECT().GetByID(gomock.Any(), qos).Return(nil, entities.ErrUserRecNotFound)
+
+				return nil, entities.ErrPermissionDenied
+			},
+		},
+		{
+			name:       "get user error",
+			actorID:    id,
+			permission: vObject.PermissionManageOwnOrders,
+			exp: func(t *testing.T, getUserMock *getUser.GetUserByIDMock) (*entities.User, error) {
+				t.Helper()
+
+				getUserMock.EXPECT().GetByID(gomock.Any(), qos).Return(nil, assert.AnError)
+
+				return nil, assert.AnError
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			getUserMock := getUser.NewGetUserByIDMock(gomock.NewController(t))
+			expUser, expErr := tc.exp(t, getUserMock)
+
+			user, err := Authorize(context.Background(), getUser.NewQueryHandler(getUserMock), tc.actorID, tc.permission)
+			require.ErrorIs(t, err, expErr)
+			assert.Equal(t, expUser, user)
+		})
+	}
+}
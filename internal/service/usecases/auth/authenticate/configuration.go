@@ -0,0 +1,33 @@
+package authenticate
+
+import (
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/auth"
+	getSession "github.com/smgladkovskiy/warehouse-task/internal/service/queries/session/get_session"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func WithTokenIssuer(issuer *auth.Issuer) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if issuer == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "issuer")
+		}
+
+		uc.issuer = issuer
+
+		return nil
+	}
+}
+
+func WithGetSessionQuery(handler *getSession.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getSession")
+		}
+
+		uc.getSessionQuery = handler
+
+		return nil
+	}
+}
@@ -0,0 +1,5 @@
+package authenticate
+
+type Requestable interface {
+	GetAccessToken() string
+}
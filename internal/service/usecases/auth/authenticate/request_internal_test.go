@@ -0,0 +1,11 @@
+package authenticate
+
+type testRequest struct {
+	accessToken string
+}
+
+var _ Requestable = (*testRequest)(nil)
+
+func (t testRequest) GetAccessToken() string {
+	return t.accessToken
+}
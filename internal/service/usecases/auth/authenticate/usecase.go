@@ -0,0 +1,92 @@
+package authenticate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/auth"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	getSession "github.com/smgladkovskiy/warehouse-task/internal/service/queries/session/get_session"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+type UseCase struct {
+	now.WithNowGenerator
+	checker.WithCheck
+	log.WithLogger
+
+	issuer *auth.Issuer
+
+	// Query handlers
+	getSessionQuery *getSession.QueryHandler
+}
+
+func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
+	uc := &UseCase{}
+
+	// Apply all Configurations passed in
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			return nil, checker.ErrInitError
+		}
+
+		err := cfg(uc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Check(*uc); err != nil {
+		return nil, err
+	}
+
+	return uc, nil
+}
+
+// Run проверяет токен доступа и то, что его сессия не отозвана и не истекла.
+// Возвращает утверждения токена для контекста запроса.
+func (uc *UseCase) Run(ctx context.Context, req Requestable) (*auth.Claims, error) {
+	ctx, span := tracing.Start(ctx, "usecase.authenticate")
+	defer span.End()
+
+	l := uc.Logger()
+
+	l.Debug(ctx, "START usecase")
+
+	claims, err := uc.issuer.Parse(req.GetAccessToken(), auth.TokenTypeAccess, uc.Now())
+	if err != nil {
+		l.Warn(ctx, "STOP usecase! issuer.Parse error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[authenticate - uc.issuer.Parse error]: %w", err))
+	}
+
+	query, err := getSession.NewQuery(claims.SessionID)
+	if err != nil {
+		l.Error(ctx, "STOP usecase! getSession.NewQuery error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[authenticate - getSession.NewQuery error]: %w", err))
+	}
+
+	session, err := uc.getSessionQuery.Handle(ctx, *query)
+	if err != nil {
+		l.Error(ctx, "STOP usecase! getSessionQuery.Handle error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[authenticate - uc.getSessionQuery.Handle error]: %w", err))
+	}
+
+	session.SetNowGen(uc.GetNowGen())
+
+	if session.UserID.UUID() != claims.UserID || !session.IsActive() {
+		l.Warn(ctx, "STOP usecase! session is inactive", log.String("sessionUUID", claims.SessionID.String()))
+
+		return nil, tracing.Error(span, fmt.Errorf("[authenticate - session.IsActive error]: %w", entities.ErrSessionInactive))
+	}
+
+	l.Debug(ctx, "END usecase")
+
+	return &claims, nil
+}
@@ -0,0 +1,162 @@
+package authenticate
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/auth"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getSession "github.com/smgladkovskiy/warehouse-task/internal/service/queries/session/get_session"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name        string
+		accessToken string
+		exp         func(t *testing.T, loggerMock *log.LogMock, getSessionMock *getSession.GetSessionMock) error
+	}
+
+	tn := time.Now().UTC().Truncate(time.Second)
+	userID := baseUUID.New()
+	sessionID := baseUUID.New()
+	revokedAt := tn.Add(-time.Second)
+
+	issuer, err := auth.NewIssuer([]byte(strings.Repeat("s", auth.MinSecretLength)))
+	require.NoError(t, err)
+
+	pair, err := issuer.Issue(userID, sessionID, baseUUID.New(), tn)
+	require.NoError(t, err)
+
+	newSession := func() *entities.Session {
+		return &entities.Session{
+			ID:        vObject.NewSessionIDFromUUIDUnsafe(sessionID),
+			UserID:    vObject.NewUserIDFromUUIDUnsafe(userID),
+			ExpiresAt: tn.Add(time.Hour),
+		}
+	}
+
+	getSessionQos := queryOptions.NewSessionQueryOptions(
+		queryOptions.WithSessionID(vObject.NewSessionIDFromUUIDUnsafe(sessionID)),
+		queryOptions.WithFromSync[*queryOptions.SessionQueryOptions](),
+	)
+
+	tcs := []testCase{
+		{
+			name:        "happy path",
+			accessToken: pair.AccessToken,
+			exp: func(t *testing.T, loggerMock *log.LogMock, getSessionMock *getSession.GetSessionMock) error {
+				t.Helper()
+
+				getSessionMock.EXPECT().GetSession(gomock.Any(), getSessionQos).Return(newSession(), nil)
+				loggerMock.EXPECT().Debug(gomock.Any(), "END usecase")
+
+				return nil
+			},
+		},
+		{
+			name:        "revoked session",
+			accessToken: pair.AccessToken,
+			exp: func(t *testing.T, loggerMock *log.LogMock, getSessionMock *getSession.GetSessionMock) error {
+				t.Helper()
+
+				session := newSession()
+				session.RevokedAt = &revokedAt
+
+				getSessionMock.EXPECT().GetSession(gomock.Any(), getSessionQos).Return(session, nil)
+				loggerMock.EXPECT().Warn(gomock.Any(), "STOP usecase! session is inactive", gomock.Any())
+
+				return entities.ErrSessionInactive
+			},
+		},
+		{
+			name:        "session of another user",
+			accessToken: pair.AccessToken,
+			exp: func(t *testing.T, loggerMock *log.LogMock, getSessionMock *getSession.GetSessionMock) error {
+				t.Helper()
+
+				session := newSession()
+				session.UserID = vObject.NewUserIDFromUUIDUnsafe(baseUUID.New())
+
+				getSessionMock.EXPECT().GetSession(gomock.Any(), getSessionQos).Return(session, nil)
+				loggerMock.EXPECT().Warn(gomock.Any(), "STOP usecase! session is inactive", gomock.Any())
+
+				return entities.ErrSessionInactive
+			},
+		},
+		{
+			name:        "get session error",
+			accessToken: pair.AccessToken,
+			exp: func(t *testing.T, loggerMock *log.LogMock, getSessionMock *getSession.GetSessionMock) error {
+				t.Helper()
+
+				getSessionMock.EXPECT().GetSession(gomock.Any(), getSessionQos).Return(nil, entities.ErrSessionRecNotFound)
+				loggerMock.EXPECT().Error(gomock.Any(), "STOP usecase! getSessionQuery.Handle error", gomock.Any())
+
+				return entities.ErrSessionRecNotFound
+			},
+		},
+		{
+			name:        "refresh token is not accepted",
+			accessToken: pair.RefreshToken,
+			exp: func(t *testing.T, loggerMock *log.LogMock, getSessionMock *getSession.GetSessionMock) error {
+				t.Helper()
+
+				loggerMock.EXPECT().Warn(gomock.Any(), "STOP usecase! issuer.Parse error", gomock.Any())
+
+				return auth.ErrInvalidToken
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			loggerMock := log.NewLogMock(ctrl)
+			nowFunc := now.NewMock(ctrl)
+			getSessionMock := getSession.NewGetSessionMock(ctrl)
+
+			nowFunc.EXPECT().Now().AnyTimes().Return(tn)
+
+			uc, err := NewUseCase(
+				usecase.WithLogger[*UseCase](loggerMock),
+				usecase.WithNowFunc[*UseCase](nowFunc),
+				WithTokenIssuer(issuer),
+				WithGetSessionQuery(getSession.NewQueryHandler(getSessionMock)),
+			)
+			require.NoError(t, err)
+
+			loggerMock.EXPECT().Debug(gomock.Any(), "START usecase")
+
+			expErr := tc.exp(t, loggerMock, getSessionMock)
+
+			claims, err := uc.Run(context.Background(), testRequest{accessToken: tc.accessToken})
+			require.ErrorIs(t, err, expErr)
+
+			if expErr != nil {
+				assert.Nil(t, claims)
+
+				return
+			}
+
+			require.NotNil(t, claims)
+			assert.Equal(t, userID, claims.UserID)
+			assert.Equal(t, sessionID, claims.SessionID)
+		})
+	}
+}
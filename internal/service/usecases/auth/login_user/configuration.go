@@ -0,0 +1,82 @@
+package loginuser
+
+import (
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/auth"
+	passcrypto "github.com/smgladkovskiy/warehouse-task/internal/pkg/pass_crypto"
+	upsertSession "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/upsert"
+	updateUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/update"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getUserByEmail "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_by_email"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func WithTokenIssuer(issuer *auth.Issuer) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if issuer == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "issuer")
+		}
+
+		uc.issuer = issuer
+
+		return nil
+	}
+}
+
+func WithGetUserByEmailQuery(handler *getUserByEmail.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getUserByEmail")
+		}
+
+		uc.getUserQuery = handler
+
+		return nil
+	}
+}
+
+func WithUpdateUserCommand(handler *updateUser.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "updateUser")
+		}
+
+		uc.updateUserCmd = handler
+
+		return nil
+	}
+}
+
+func WithUpsertSessionCommand(handler *upsertSession.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "upsertSession")
+		}
+
+		uc.upsertSessionCmd = handler
+
+		return nil
+	}
+}
+
+func WithPasswordHasher(hasher passcrypto.PasswordHashable) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if hasher == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "hasher")
+		}
+
+		uc.SetHasher(hasher)
+
+		return nil
+	}
+}
+
+// WithRules задаёт правила блокировки входа после неудачных попыток.
+func WithRules(rules vObject.Rules) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		uc.rules = rules
+
+		return nil
+	}
+}
@@ -0,0 +1,72 @@
+package loginuser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/auth"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	passCrypto "github.com/smgladkovskiy/warehouse-task/internal/pkg/pass_crypto"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	upsertSession "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/upsert"
+	updateUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/update"
+	getUserByEmail "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_by_email"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func TestConfiguration(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	loggerMock := log.NewLogMock(ctrl)
+	txManagerMock := trx.NewTransactionManagerMock(ctrl)
+	nowFunc := now.NewMock(ctrl)
+	hasherMock := passCrypto.NewPasswordHashMock(ctrl)
+	getUserByEmailMock := getUserByEmail.NewGetUserMock(ctrl)
+	updateUserMock := updateUser.NewUpdateUserMock(ctrl)
+	upsertSessionMock := upsertSession.NewUpsertSessionMock(ctrl)
+
+	issuer, err := auth.NewIssuer([]byte(strings.Repeat("s", auth.MinSecretLength)))
+	require.NoError(t, err)
+
+	cfgs := []usecase.Configuration[*UseCase]{
+		usecase.WithTransactionManager[*UseCase](txManagerMock),
+		usecase.WithLogger[*UseCase](loggerMock),
+		usecase.WithNowFunc[*UseCase](nowFunc),
+		WithTokenIssuer(issuer),
+		WithPasswordHasher(hasherMock),
+		WithGetUserByEmailQuery(getUserByEmail.NewQueryHandler(getUserByEmailMock)),
+		WithUpdateUserCommand(updateUser.NewCommandHandler(updateUserMock)),
+		WithUpsertSessionCommand(upsertSession.NewCommandHandler(upsertSessionMock)),
+	}
+
+	for _, f := range []usecase.Configuration[*UseCase]{
+		WithTokenIssuer(nil),
+		WithPasswordHasher(nil),
+		WithGetUserByEmailQuery(nil),
+		WithUpdateUserCommand(nil),
+		WithUpsertSessionCommand(nil),
+	} {
+		uc, err := NewUseCase(f)
+		require.ErrorIs(t, err, usecase.ErrEmptyStructParam)
+		assert.Empty(t, uc)
+	}
+
+	uc, err := NewUseCase(nil)
+	require.ErrorIs(t, err, checker.ErrInitError)
+	require.Empty(t, uc)
+
+	uc, err = NewUseCase(cfgs[:len(cfgs)-1]...)
+	require.ErrorIs(t, err, checker.ErrInitError)
+	require.Empty(t, uc)
+
+	uc, err = NewUseCase(cfgs...)
+	require.NoError(t, err)
+	assert.NotEmpty(t, uc)
+}
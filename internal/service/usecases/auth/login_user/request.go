@@ -0,0 +1,6 @@
+package loginuser
+
+type Requestable interface {
+	GetEmail() string
+	GetPassword() string
+}
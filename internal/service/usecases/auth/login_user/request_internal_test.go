@@ -0,0 +1,16 @@
+package loginuser
+
+type testRequest struct {
+	email    string
+	password string
+}
+
+var _ Requestable = (*testRequest)(nil)
+
+func (t testRequest) GetEmail() string {
+	return t.email
+}
+
+func (t testRequest) GetPassword() string {
+	return t.password
+}
@@ -0,0 +1,209 @@
+package loginuser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/auth"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	passcrypto "github.com/smgladkovskiy/warehouse-task/internal/pkg/pass_crypto"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	upsertSession "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/upsert"
+	updateUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/update"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getUserByEmail "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_by_email"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+// dummyPassword пароль фиктивного хеша, с которым сравнивается пароль при входе по неизвестному email.
+const dummyPassword = "warehouse dummy password"
+
+type UseCase struct {
+	uuid.WithUUIDGenerator
+	now.WithNowGenerator
+	checker.WithCheck
+	tx.WithTransactionManager
+	passcrypto.WithPasswordHasher
+	log.WithLogger
+
+	issuer *auth.Issuer
+
+	// dummyHash хеш dummyPassword настроенным алгоритмом, вычисляется при первом входе по неизвестному email
+	dummyHash func() (string, error)
+
+	// Query handlers
+	getUserQuery *getUserByEmail.QueryHandler
+
+	// Command handlers
+	updateUserCmd    *updateUser.CommandHandler
+	upsertSessionCmd *upsertSession.CommandHandler
+
+	// rules задают число неудачных попыток входа до блокировки и её длительность
+	rules vObject.Rules
+}
+
+func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
+	uc := &UseCase{rules: vObject.DefaultRules()}
+
+	// Apply all Configurations passed in
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			return nil, checker.ErrInitError
+		}
+
+		err := cfg(uc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Check(*uc); err != nil {
+		return nil, err
+	}
+
+	uc.dummyHash = sync.OnceValues(func() (string, error) {
+		return uc.GetHasher().HashAndSalt([]byte(dummyPassword))
+	})
+
+	return uc, nil
+}
+
+// Run проверяет email и пароль, открывает сессию и выдаёт пару токенов.
+// Неудачные попытки входа учитываются, после серии неудач вход временно блокируется.
+func (uc *UseCase) Run(ctx context.Context, req Requestable) (*auth.Pair, error) {
+	ctx, span := tracing.Start(ctx, "usecase.loginUser")
+	defer span.End()
+
+	l := uc.Logger().With(log.String("email", req.GetEmail()))
+
+	l.Debug(ctx, "START usecase")
+
+	query, err := getUserByEmail.NewQueryForUpdate(req.GetEmail())
+	if err != nil {
+		l.Error(ctx, "STOP usecase! getUserByEmail.NewQueryForUpdate error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[loginUser - getUserByEmail.NewQueryForUpdate error]: %w", err))
+	}
+
+	var (
+		session  *entities.Session
+		loginErr error
+	)
+
+	err = uc.TransactionDo(ctx, func(ctx context.Context) error {
+		session, loginErr = uc.login(ctx, *query, req.GetPassword())
+		// неудачная попытка входа сохранена, поэтому транзакция фиксируется
+		if errors.Is(loginErr, entities.ErrInvalidCredentials) {
+			return nil
+		}
+
+		return loginErr
+	})
+	if err == nil {
+		err = loginErr
+	}
+
+	if errors.Is(err, entities.ErrInvalidCredentials) || errors.Is(err, entities.ErrUserLocked) {
+		l.Warn(ctx, "STOP usecase! login rejected", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[loginUser - Run error]: %w", err))
+	}
+
+	if err != nil {
+		l.Error(ctx, "STOP usecase! transaction error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[loginUser - uc.TransactionDo error]: %w", err))
+	}
+
+	pair, err := uc.issuer.Issue(session.UserID.UUID(), session.ID.UUID(), session.RefreshTokenID, session.UpdatedAt)
+	if err != nil {
+		l.Error(ctx, "STOP usecase! issuer.Issue error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[loginUser - uc.issuer.Issue error]: %w", err))
+	}
+
+	l.Debug(ctx, "END usecase")
+
+	return &pair, nil
+}
+
+func (uc *UseCase) login(ctx context.Context, query getUserByEmail.Query, password string) (*entities.Session, error) {
+	// 1. Получаем пользователя с блокировкой записи, чтобы параллельные попытки не потеряли счётчик неудач
+	user, err := uc.getUserQuery.Handle(ctx, query)
+	if errors.Is(err, entities.ErrUserRecNotFound) {
+		// пароль всё равно проверяется, чтобы по времени ответа нельзя было узнать, зарегистрирован ли email
+		uc.compareDummyPassword(ctx, password)
+
+		return nil, entities.ErrInvalidCredentials
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("[loginUser - uc.getUserQuery.Handle error]: %w", err)
+	}
+
+	user.SetNowGen(uc.GetNowGen())
+	user.SetHasher(uc.GetHasher())
+
+	// 2. Проверяем блокировку и пароль
+	if user.IsLocked() {
+		return nil, entities.ErrUserLocked
+	}
+
+	if !user.CheckPassword(password) {
+		user.RegisterFailedLogin(uc.rules.MaxFailedLogins, uc.rules.LoginLockout)
+
+		if err = uc.updateUserCmd.Handle(ctx, updateUser.NewCommandUnsafe(user)); err != nil {
+			return nil, fmt.Errorf("[loginUser - uc.updateUserCmd.Handle error]: %w", err)
+		}
+
+		return nil, entities.ErrInvalidCredentials
+	}
+
+	// 3. Сбрасываем счётчик неудач и обновляем устаревший хеш пароля: пароль только что проверен,
+	// поэтому его можно перехешировать текущим алгоритмом. Ошибка перехеширования не мешает входу.
+	reset := user.ResetFailedLogins()
+
+	rehashed, err := user.RehashPassword(password)
+	if err != nil {
+		uc.Logger().Warn(ctx, "password rehash failed", log.Err(err))
+	}
+
+	if reset || rehashed {
+		if err = uc.updateUserCmd.Handle(ctx, updateUser.NewCommandUnsafe(user)); err != nil {
+			return nil, fmt.Errorf("[loginUser - uc.updateUserCmd.Handle error]: %w", err)
+		}
+	}
+
+	// 4. Открываем сессию
+	session := entities.NewSession(
+		user.ID,
+		uc.issuer.RefreshTokenTTL(),
+		entities.WithUUIDFunc[*entities.Session](uc.GetUUIDGen()),
+		entities.WithNowFunc[*entities.Session](uc.GetNowGen()),
+	)
+	if err = uc.upsertSessionCmd.Handle(ctx, upsertSession.NewCommandUnsafe(&session)); err != nil {
+		return nil, fmt.Errorf("[loginUser - uc.upsertSessionCmd.Handle error]: %w", err)
+	}
+
+	return &session, nil
+}
+
+// compareDummyPassword сравнивает пароль с фиктивным хешем: вход по неизвестному email занимает
+// столько же времени, сколько вход с неверным паролем.
+func (uc *UseCase) compareDummyPassword(ctx context.Context, password string) {
+	hash, err := uc.dummyHash()
+	if err != nil {
+		uc.Logger().Warn(ctx, "dummy password hash failed", log.Err(err))
+
+		return
+	}
+
+	uc.GetHasher().ComparePasswords(hash, []byte(password))
+}
@@ -0,0 +1,316 @@
+package loginuser
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/auth"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	passcrypto "github.com/smgladkovskiy/warehouse-task/internal/pkg/pass_crypto"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	upsertSession "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/upsert"
+	updateUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/update"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getUserByEmail "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_by_email"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+type mocks struct {
+	logger        *log.LogMock
+	hasher        *passcrypto.PasswordHashMock
+	getUser       *getUserByEmail.GetUserMock
+	updateUser    *updateUser.UpdateUserMock
+	upsertSession *upsertSession.UpsertSessionMock
+}
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		in   testRequest
+		exp  func(t *testing.T, in testRequest, m mocks) error
+	}
+
+	tn := time.Now().UTC().Truncate(time.Second)
+	userID := baseUUID.New()
+	sessionID := baseUUID.New()
+	lockedUntil := tn.Add(time.Minute)
+
+	issuer, err := auth.NewIssuer([]byte(strings.Repeat("s", auth.MinSecretLength)))
+	require.NoError(t, err)
+
+	newUser := func() *entities.User {
+		return &entities.User{
+			ID:           vObject.NewUserIDFromUUIDUnsafe(userID),
+			Email:        vObject.NewEmailUnsafe("some@email.com"),
+			PasswordHash: "hashed_password",
+		}
+	}
+
+	getUserQos := queryOptions.NewUserQueryOptions(
+		queryOptions.WithUserEmail(vObject.NewEmailUnsafe("some@email.com")),
+		queryOptions.WithForUpdate[*queryOptions.UserQueryOptions](),
+	)
+
+	tcs := []testCase{
+		{
+			name: "happy path",
+			in:   testRequest{email: "some@email.com", password: "12345678"},
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByEmail(gomock.Any(), getUserQos).Return(newUser(), nil)
+				m.hasher.EXPECT().ComparePasswords("hashed_password", []byte(in.GetPassword())).Return(true)
+				m.hasher.EXPECT().NeedsRehash("hashed_password").Return(false)
+				m.upsertSession.EXPECT().UpsertSession(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *entities.Session) error {
+					assert.Equal(t, sessionID, s.ID.UUID())
+					assert.Equal(t, userID, s.UserID.UUID())
+					assert.Equal(t, tn.Add(issuer.RefreshTokenTTL()), s.ExpiresAt)
+
+					return nil
+				})
+
+				return nil
+			},
+		},
+		{
+			name: "successful login resets failed attempts",
+			in:   testRequest{email: "some@email.com", password: "12345678"},
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				user := newUser()
+				user.FailedLoginAttempts = 2
+
+				m.getUser.EXPECT().GetByEmail(gomock.Any(), getUserQos).Return(user, nil)
+				m.hasher.EXPECT().ComparePasswords("hashed_password", []byte(in.GetPassword())).Return(true)
+				m.hasher.EXPECT().NeedsRehash("hashed_password").Return(false)
+				m.updateUser.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *entities.User) error {
+					assert.Zero(t, u.FailedLoginAttempts)
+
+					return nil
+				})
+				m.upsertSession.EXPECT().UpsertSession(gomock.Any(), gomock.Any()).Return(nil)
+
+				return nil
+			},
+		},
+		{
+			name: "legacy password hash is upgraded",
+			in:   testRequest{email: "some@email.com", password: "12345678"},
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByEmail(gomock.Any(), getUserQos).Return(newUser(), nil)
+				m.hasher.EXPECT().ComparePasswords("hashed_password", []byte(in.GetPassword())).Return(true)
+				m.hasher.EXPECT().NeedsRehash("hashed_password").Return(true)
+				m.hasher.EXPECT().HashAndSalt([]byte(in.GetPassword())).Return("rehashed_password", nil)
+				m.updateUser.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *entities.User) error {
+					assert.Equal(t, vObject.PasswordHash("rehashed_password"), u.PasswordHash)
+
+					return nil
+				})
+				m.upsertSession.EXPECT().UpsertSession(gomock.Any(), gomock.Any()).Return(nil)
+
+				return nil
+			},
+		},
+		{
+			name: "rehash error does not block login",
+			in:   testRequest{email: "some@email.com", password: "12345678"},
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByEmail(gomock.Any(), getUserQos).Return(newUser(), nil)
+				m.hasher.EXPECT().ComparePasswords("hashed_password", []byte(in.GetPassword())).Return(true)
+				m.hasher.EXPECT().NeedsRehash("hashed_password").Return(true)
+				m.hasher.EXPECT().HashAndSalt([]byte(in.GetPassword())).Return("", passcrypto.ErrPasswordHashing)
+				m.logger.EXPECT().Warn(gomock.Any(), "password rehash failed", gomock.Any())
+				m.upsertSession.EXPECT().UpsertSession(gomock.Any(), gomock.Any()).Return(nil)
+
+				return nil
+			},
+		},
+		{
+			name: "wrong password is counted",
+			in:   testRequest{email: "some@email.com", password: "wrong"},
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByEmail(gomock.Any(), getUserQos).Return(newUser(), nil)
+				m.hasher.EXPECT().ComparePasswords("hashed_password", []byte(in.GetPassword())).Return(false)
+				m.updateUser.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *entities.User) error {
+					assert.Equal(t, 1, u.FailedLoginAttempts)
+
+					return nil
+				})
+				m.logger.EXPECT().Warn(gomock.Any(), "STOP usecase! login rejected", gomock.Any())
+
+				return entities.ErrInvalidCredentials
+			},
+		},
+		{
+			name: "unknown email",
+			in:   testRequest{email: "some@email.com", password: "12345678"},
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByEmail(gomock.Any(), getUserQos).Return(nil, entities.ErrUserRecNotFound)
+				// пароль сравнивается с фиктивным хешем, как если бы пользователь был найден
+				m.hasher.EXPECT().HashAndSalt([]byte(dummyPassword)).Return("dummy_hash", nil)
+				m.hasher.EXPECT().ComparePasswords("dummy_hash", []byte(in.GetPassword())).Return(false)
+				m.logger.EXPECT().Warn(gomock.Any(), "STOP usecase! login rejected", gomock.Any())
+
+				return entities.ErrInvalidCredentials
+			},
+		},
+		{
+			name: "unknown email with dummy hash error",
+			in:   testRequest{email: "some@email.com", password: "12345678"},
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByEmail(gomock.Any(), getUserQos).Return(nil, entities.ErrUserRecNotFound)
+				m.hasher.EXPECT().HashAndSalt([]byte(dummyPassword)).Return("", passcrypto.ErrPasswordHashing)
+				m.logger.EXPECT().Warn(gomock.Any(), "dummy password hash failed", gomock.Any())
+				m.logger.EXPECT().Warn(gomock.Any(), "STOP usecase! login rejected", gomock.Any())
+
+				return entities.ErrInvalidCredentials
+			},
+		},
+		{
+			name: "user locked",
+			in:   testRequest{email: "some@email.com", password: "12345678"},
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				user := newUser()
+				user.LockedUntil = &lockedUntil
+
+				m.getUser.EXPECT().GetByEmail(gomock.Any(), getUserQos).Return(user, nil)
+				m.logger.EXPECT().Warn(gomock.Any(), "STOP usecase! login rejected", gomock.Any())
+
+				return entities.ErrUserLocked
+			},
+		},
+		{
+			name: "update user error",
+			in:   testRequest{email: "some@email.com", password: "wrong"},
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByEmail(gomock.Any(), getUserQos).Return(newUser(), nil)
+				m.hasher.EXPECT().ComparePasswords("hashed_password", []byte(in.GetPassword())).Return(false)
+				m.updateUser.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(assert.AnError)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+
+				return assert.AnError
+			},
+		},
+		{
+			name: "upsert session error",
+			in:   testRequest{email: "some@email.com", password: "12345678"},
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByEmail(gomock.Any(), getUserQos).Return(newUser(), nil)
+				m.hasher.EXPECT().ComparePasswords("hashed_password", []byte(in.GetPassword())).Return(true)
+				m.hasher.EXPECT().NeedsRehash("hashed_password").Return(false)
+				m.upsertSession.EXPECT().UpsertSession(gomock.Any(), gomock.Any()).Return(assert.AnError)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+
+				return assert.AnError
+			},
+		},
+		{
+			name: "get user query error",
+			in:   testRequest{email: "", password: "12345678"},
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! getUserByEmail.NewQueryForUpdate error", gomock.Any())
+
+				return vObject.ErrEmptyEmail
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := mocks{
+				logger:        log.NewLogMock(ctrl),
+				hasher:        passcrypto.NewPasswordHashMock(ctrl),
+				getUser:       getUserByEmail.NewGetUserMock(ctrl),
+				updateUser:    updateUser.NewUpdateUserMock(ctrl),
+				upsertSession: upsertSession.NewUpsertSessionMock(ctrl),
+			}
+			txManagerMock := trx.NewTransactionManagerMock(ctrl)
+			nowFunc := now.NewMock(ctrl)
+			uuidFunc := uuid.NewMock(ctrl)
+
+			nowFunc.EXPECT().Now().AnyTimes().Return(tn)
+			uuidFunc.EXPECT().UUID().AnyTimes().Return(sessionID)
+			txManagerMock.EXPECT().Do(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				},
+			)
+
+			uc, err := NewUseCase(
+				usecase.WithTransactionManager[*UseCase](txManagerMock),
+				usecase.WithLogger[*UseCase](m.logger),
+				usecase.WithNowFunc[*UseCase](nowFunc),
+				usecase.WithUUIDFunc[*UseCase](uuidFunc),
+				WithTokenIssuer(issuer),
+				WithPasswordHasher(m.hasher),
+				WithGetUserByEmailQuery(getUserByEmail.NewQueryHandler(m.getUser)),
+				WithUpdateUserCommand(updateUser.NewCommandHandler(m.updateUser)),
+				WithUpsertSessionCommand(upsertSession.NewCommandHandler(m.upsertSession)),
+			)
+			require.NoError(t, err)
+
+			m.logger.EXPECT().With(log.String("email", tc.in.GetEmail())).Return(m.logger)
+			m.logger.EXPECT().Debug(gomock.Any(), "START usecase")
+
+			expErr := tc.exp(t, tc.in, m)
+			if expErr == nil {
+				m.logger.EXPECT().Debug(gomock.Any(), "END usecase")
+			}
+
+			pair, err := uc.Run(context.Background(), tc.in)
+			require.ErrorIs(t, err, expErr)
+
+			if expErr != nil {
+				assert.Nil(t, pair)
+
+				return
+			}
+
+			require.NotNil(t, pair)
+
+			claims, err := issuer.Parse(pair.AccessToken, auth.TokenTypeAccess, tn)
+			require.NoError(t, err)
+			assert.Equal(t, userID, claims.UserID)
+			assert.Equal(t, sessionID, claims.SessionID)
+
+			claims, err = issuer.Parse(pair.RefreshToken, auth.TokenTypeRefresh, tn)
+			require.NoError(t, err)
+			assert.Equal(t, sessionID, claims.ID)
+		})
+	}
+}
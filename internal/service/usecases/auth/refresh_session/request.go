@@ -0,0 +1,5 @@
+package refreshsession
+
+type Requestable interface {
+	GetRefreshToken() string
+}
@@ -0,0 +1,11 @@
+package refreshsession
+
+type testRequest struct {
+	refreshToken string
+}
+
+var _ Requestable = (*testRequest)(nil)
+
+func (t testRequest) GetRefreshToken() string {
+	return t.refreshToken
+}
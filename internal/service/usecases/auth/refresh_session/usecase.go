@@ -0,0 +1,152 @@
+package refreshsession
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/auth"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	upsertSession "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/upsert"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	getSession "github.com/smgladkovskiy/warehouse-task/internal/service/queries/session/get_session"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+type UseCase struct {
+	uuid.WithUUIDGenerator
+	now.WithNowGenerator
+	checker.WithCheck
+	tx.WithTransactionManager
+	log.WithLogger
+
+	issuer *auth.Issuer
+
+	// Query handlers
+	getSessionQuery *getSession.QueryHandler
+
+	// Command handlers
+	upsertSessionCmd *upsertSession.CommandHandler
+}
+
+func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
+	uc := &UseCase{}
+
+	// Apply all Configurations passed in
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			return nil, checker.ErrInitError
+		}
+
+		err := cfg(uc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Check(*uc); err != nil {
+		return nil, err
+	}
+
+	return uc, nil
+}
+
+// Run обменивает токен обновления на новую пару токенов той же сессии.
+// Повторное предъявление уже обменянного токена отзывает сессию целиком.
+func (uc *UseCase) Run(ctx context.Context, req Requestable) (*auth.Pair, error) {
+	ctx, span := tracing.Start(ctx, "usecase.refreshSession")
+	defer span.End()
+
+	var l log.Logger = uc.Logger()
+
+	l.Debug(ctx, "START usecase")
+
+	claims, err := uc.issuer.Parse(req.GetRefreshToken(), auth.TokenTypeRefresh, uc.Now())
+	if err != nil {
+		l.Warn(ctx, "STOP usecase! issuer.Parse error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[refreshSession - uc.issuer.Parse error]: %w", err))
+	}
+
+	l = l.With(log.String("userUUID", claims.UserID.String()), log.String("sessionUUID", claims.SessionID.String()))
+
+	query, err := getSession.NewQueryForUpdate(claims.SessionID)
+	if err != nil {
+		l.Error(ctx, "STOP usecase! getSession.NewQueryForUpdate error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[refreshSession - getSession.NewQueryForUpdate error]: %w", err))
+	}
+
+	var (
+		session   *entities.Session
+		rotateErr error
+	)
+
+	err = uc.TransactionDo(ctx, func(ctx context.Context) error {
+		session, rotateErr = uc.rotate(ctx, *query, claims)
+		// отзыв сессии при повторном использовании токена должен сохраниться
+		if errors.Is(rotateErr, entities.ErrRefreshTokenReused) {
+			return nil
+		}
+
+		return rotateErr
+	})
+	if err == nil {
+		err = rotateErr
+	}
+
+	if errors.Is(err, entities.ErrRefreshTokenReused) ||
+		errors.Is(err, entities.ErrSessionInactive) ||
+		errors.Is(err, entities.ErrSessionRecNotFound) {
+		l.Warn(ctx, "STOP usecase! refresh rejected", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[refreshSession - Run error]: %w", err))
+	}
+
+	if err != nil {
+		l.Error(ctx, "STOP usecase! transaction error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[refreshSession - uc.TransactionDo error]: %w", err))
+	}
+
+	pair, err := uc.issuer.Issue(session.UserID.UUID(), session.ID.UUID(), session.RefreshTokenID, session.UpdatedAt)
+	if err != nil {
+		l.Error(ctx, "STOP usecase! issuer.Issue error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[refreshSession - uc.issuer.Issue error]: %w", err))
+	}
+
+	l.Debug(ctx, "END usecase")
+
+	return &pair, nil
+}
+
+func (uc *UseCase) rotate(ctx context.Context, query getSession.Query, claims auth.Claims) (*entities.Session, error) {
+	session, err := uc.getSessionQuery.Handle(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("[refreshSession - uc.getSessionQuery.Handle error]: %w", err)
+	}
+
+	if session.UserID.UUID() != claims.UserID {
+		return nil, entities.ErrSessionRecNotFound
+	}
+
+	session.SetNowGen(uc.GetNowGen())
+	session.SetUUIDGen(uc.GetUUIDGen())
+
+	rotateErr := session.Rotate(claims.ID, uc.issuer.RefreshTokenTTL())
+	if errors.Is(rotateErr, entities.ErrSessionInactive) {
+		return nil, rotateErr
+	}
+
+	if err = uc.upsertSessionCmd.Handle(ctx, upsertSession.NewCommandUnsafe(session)); err != nil {
+		return nil, fmt.Errorf("[refreshSession - uc.upsertSessionCmd.Handle error]: %w", err)
+	}
+
+	return session, rotateErr
+}
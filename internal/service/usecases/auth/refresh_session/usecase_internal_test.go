@@ -0,0 +1,233 @@
+package refreshsession
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/auth"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	upsertSession "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/upsert"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getSession "github.com/smgladkovskiy/warehouse-task/internal/service/queries/session/get_session"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name         string
+		refreshID    baseUUID.UUID
+		refreshToken string
+		exp          func(t *testing.T, getSessionMock *getSession.GetSessionMock, upsertSessionMock *upsertSession.UpsertSessionMock) error
+	}
+
+	tn := time.Now().UTC().Truncate(time.Second)
+	userID := baseUUID.New()
+	sessionID := baseUUID.New()
+	currentRefreshID := baseUUID.New()
+	nextRefreshID := baseUUID.New()
+	revokedAt := tn.Add(-time.Minute)
+
+	issuer, err := auth.NewIssuer([]byte(strings.Repeat("s", auth.MinSecretLength)))
+	require.NoError(t, err)
+
+	newSession := func() *entities.Session {
+		return &entities.Session{
+			ID:             vObject.NewSessionIDFromUUIDUnsafe(sessionID),
+			UserID:         vObject.NewUserIDFromUUIDUnsafe(userID),
+			RefreshTokenID: currentRefreshID,
+			ExpiresAt:      tn.Add(time.Hour),
+			CreatedAt:      tn.Add(-time.Hour),
+			UpdatedAt:      tn.Add(-time.Hour),
+		}
+	}
+
+	getSessionQos := queryOptions.NewSessionQueryOptions(
+		queryOptions.WithSessionID(vObject.NewSessionIDFromUUIDUnsafe(sessionID)),
+		queryOptions.WithForUpdate[*queryOptions.SessionQueryOptions](),
+	)
+
+	tcs := []testCase{
+		{
+			name:      "happy path",
+			refreshID: currentRefreshID,
+			exp: func(t *testing.T, getSessionMock *getSession.GetSessionMock, upsertSessionMock *upsertSession.UpsertSessionMock) error {
+				t.Helper()
+
+				getSessionMock.EXPECT().GetSession(gomock.Any(), getSessionQos).Return(newSession(), nil)
+				upsertSessionMock.EXPECT().UpsertSession(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *entities.Session) error {
+					assert.Equal(t, nextRefreshID, s.RefreshTokenID)
+					assert.Equal(t, tn.Add(issuer.RefreshTokenTTL()), s.ExpiresAt)
+
+					return nil
+				})
+
+				return nil
+			},
+		},
+		{
+			name:      "reused refresh token revokes session",
+			refreshID: baseUUID.New(),
+			exp: func(t *testing.T, getSessionMock *getSession.GetSessionMock, upsertSessionMock *upsertSession.UpsertSessionMock) error {
+				t.Helper()
+
+				getSessionMock.EXPECT().GetSession(gomock.Any(), getSessionQos).Return(newSession(), nil)
+				upsertSessionMock.EXPECT().UpsertSession(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *entities.Session) error {
+					assert.NotNil(t, s.RevokedAt)
+
+					return nil
+				})
+
+				return entities.ErrRefreshTokenReused
+			},
+		},
+		{
+			name:      "revoked session",
+			refreshID: currentRefreshID,
+			exp: func(t *testing.T, getSessionMock *getSession.GetSessionMock, upsertSessionMock *upsertSession.UpsertSessionMock) error {
+				t.Helper()
+
+				session := newSession()
+				session.RevokedAt = &revokedAt
+
+				getSessionMock.EXPECT().GetSession(gomock.Any(), getSessionQos).Return(session, nil)
+
+				return entities.ErrSessionInactive
+			},
+		},
+		{
+			name:      "session of another user",
+			refreshID: currentRefreshID,
+			exp: func(t *testing.T, getSessionMock *getSession.GetSessionMock, upsertSessionMock *upsertSession.UpsertSessionMock) error {
+				t.Helper()
+
+				session := newSession()
+				session.UserID = vObject.NewUserIDFromUUIDUnsafe(baseUUID.New())
+
+				getSessionMock.EXPECT().GetSession(gomock.Any(), getSessionQos).Return(session, nil)
+
+				return entities.ErrSessionRecNotFound
+			},
+		},
+		{
+			name:      "session not found",
+			refreshID: currentRefreshID,
+			exp: func(t *testing.T, getSessionMock *getSession.GetSessionMock, upsertSessionMock *upsertSession.UpsertSessionMock) error {
+				t.Helper()
+
+				getSessionMock.EXPECT().GetSession(gomock.Any(), getSessionQos).Return(nil, entities.ErrSessionRecNotFound)
+
+				return entities.ErrSessionRecNotFound
+			},
+		},
+		{
+			name:      "upsert session error",
+			refreshID: currentRefreshID,
+			exp: func(t *testing.T, getSessionMock *getSession.GetSessionMock, upsertSessionMock *upsertSession.UpsertSessionMock) error {
+				t.Helper()
+
+				getSessionMock.EXPECT().GetSession(gomock.Any(), getSessionQos).Return(newSession(), nil)
+				upsertSessionMock.EXPECT().UpsertSession(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+				return assert.AnError
+			},
+		},
+		{
+			name:         "invalid token",
+			refreshToken: "not a token",
+			exp: func(t *testing.T, getSessionMock *getSession.GetSessionMock, upsertSessionMock *upsertSession.UpsertSessionMock) error {
+				t.Helper()
+
+				return auth.ErrInvalidToken
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			loggerMock := log.NewLogMock(ctrl)
+			txManagerMock := trx.NewTransactionManagerMock(ctrl)
+			nowFunc := now.NewMock(ctrl)
+			uuidFunc := uuid.NewMock(ctrl)
+			getSessionMock := getSession.NewGetSessionMock(ctrl)
+			upsertSessionMock := upsertSession.NewUpsertSessionMock(ctrl)
+
+			nowFunc.EXPECT().Now().AnyTimes().Return(tn)
+			uuidFunc.EXPECT().UUID().AnyTimes().Return(nextRefreshID)
+			txManagerMock.EXPECT().Do(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				},
+			)
+
+			uc, err := NewUseCase(
+				usecase.WithTransactionManager[*UseCase](txManagerMock),
+				usecase.WithLogger[*UseCase](loggerMock),
+				usecase.WithNowFunc[*UseCase](nowFunc),
+				usecase.WithUUIDFunc[*UseCase](uuidFunc),
+				WithTokenIssuer(issuer),
+				WithGetSessionQuery(getSession.NewQueryHandler(getSessionMock)),
+				WithUpsertSessionCommand(upsertSession.NewCommandHandler(upsertSessionMock)),
+			)
+			require.NoError(t, err)
+
+			refreshToken := tc.refreshToken
+			if refreshToken == "" {
+				pair, err := issuer.Issue(userID, sessionID, tc.refreshID, tn.Add(-time.Minute))
+				require.NoError(t, err)
+
+				refreshToken = pair.RefreshToken
+
+				loggerMock.EXPECT().With(
+					log.String("userUUID", userID.String()),
+					log.String("sessionUUID", sessionID.String()),
+				).Return(loggerMock)
+			}
+
+			loggerMock.EXPECT().Debug(gomock.Any(), "START usecase")
+
+			expErr := tc.exp(t, getSessionMock, upsertSessionMock)
+
+			switch expErr {
+			case nil:
+				loggerMock.EXPECT().Debug(gomock.Any(), "END usecase")
+			case assert.AnError:
+				loggerMock.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+			default:
+				loggerMock.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any())
+			}
+
+			pair, err := uc.Run(context.Background(), testRequest{refreshToken: refreshToken})
+			require.ErrorIs(t, err, expErr)
+
+			if expErr != nil {
+				assert.Nil(t, pair)
+
+				return
+			}
+
+			require.NotNil(t, pair)
+
+			claims, err := issuer.Parse(pair.RefreshToken, auth.TokenTypeRefresh, tn)
+			require.NoError(t, err)
+			assert.Equal(t, nextRefreshID, claims.ID)
+			assert.Equal(t, sessionID, claims.SessionID)
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package revokesession
+
+import "github.com/google/uuid"
+
+type Requestable interface {
+	GetSessionID() uuid.UUID
+	GetUserID() uuid.UUID
+}
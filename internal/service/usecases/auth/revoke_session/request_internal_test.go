@@ -0,0 +1,18 @@
+package revokesession
+
+import "github.com/google/uuid"
+
+type testRequest struct {
+	sessionUUID uuid.UUID
+	userUUID    uuid.UUID
+}
+
+var _ Requestable = (*testRequest)(nil)
+
+func (t testRequest) GetSessionID() uuid.UUID {
+	return t.sessionUUID
+}
+
+func (t testRequest) GetUserID() uuid.UUID {
+	return t.userUUID
+}
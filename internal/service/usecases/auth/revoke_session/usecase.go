@@ -0,0 +1,100 @@
+package revokesession
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	upsertSession "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/upsert"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	getSession "github.com/smgladkovskiy/warehouse-task/internal/service/queries/session/get_session"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+type UseCase struct {
+	now.WithNowGenerator
+	checker.WithCheck
+	tx.WithTransactionManager
+	log.WithLogger
+
+	// Query handlers
+	getSessionQuery *getSession.QueryHandler
+
+	// Command handlers
+	upsertSessionCmd *upsertSession.CommandHandler
+}
+
+func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
+	uc := &UseCase{}
+
+	// Apply all Configurations passed in
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			return nil, checker.ErrInitError
+		}
+
+		err := cfg(uc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Check(*uc); err != nil {
+		return nil, err
+	}
+
+	return uc, nil
+}
+
+// Run отзывает сессию пользователя (выход). Выданные в сессии токены перестают приниматься.
+func (uc *UseCase) Run(ctx context.Context, req Requestable) error {
+	ctx, span := tracing.Start(ctx, "usecase.revokeSession")
+	defer span.End()
+
+	l := uc.Logger().With(
+		log.String("sessionUUID", req.GetSessionID().String()),
+		log.String("userUUID", req.GetUserID().String()),
+	)
+
+	l.Debug(ctx, "START usecase")
+
+	query, err := getSession.NewQueryForUpdate(req.GetSessionID())
+	if err != nil {
+		l.Error(ctx, "STOP usecase! getSession.NewQueryForUpdate error", log.Err(err))
+
+		return tracing.Error(span, fmt.Errorf("[revokeSession - getSession.NewQueryForUpdate error]: %w", err))
+	}
+
+	err = uc.TransactionDo(ctx, func(ctx context.Context) error {
+		session, err := uc.getSessionQuery.Handle(ctx, *query)
+		if err != nil {
+			return fmt.Errorf("[revokeSession - uc.getSessionQuery.Handle error]: %w", err)
+		}
+
+		if session.UserID.UUID() != req.GetUserID() {
+			return entities.ErrSessionRecNotFound
+		}
+
+		session.SetNowGen(uc.GetNowGen())
+		session.Revoke()
+
+		if err = uc.upsertSessionCmd.Handle(ctx, upsertSession.NewCommandUnsafe(session)); err != nil {
+			return fmt.Errorf("[revokeSession - uc.upsertSessionCmd.Handle error]: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		l.Error(ctx, "STOP usecase! transaction error", log.Err(err))
+
+		return tracing.Error(span, fmt.Errorf("[revokeSession - uc.TransactionDo error]: %w", err))
+	}
+
+	l.Debug(ctx, "END usecase")
+
+	return nil
+}
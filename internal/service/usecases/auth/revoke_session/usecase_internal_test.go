@@ -0,0 +1,156 @@
+package revokesession
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	upsertSession "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/upsert"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getSession "github.com/smgladkovskiy/warehouse-task/internal/service/queries/session/get_session"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		in   testRequest
+		exp  func(t *testing.T, getSessionMock *getSession.GetSessionMock, upsertSessionMock *upsertSession.UpsertSessionMock) error
+	}
+
+	tn := time.Now().UTC()
+	userID := baseUUID.New()
+	sessionID := baseUUID.New()
+
+	newSession := func() *entities.Session {
+		return &entities.Session{
+			ID:        vObject.NewSessionIDFromUUIDUnsafe(sessionID),
+			UserID:    vObject.NewUserIDFromUUIDUnsafe(userID),
+			ExpiresAt: tn.Add(time.Hour),
+		}
+	}
+
+	getSessionQos := queryOptions.NewSessionQueryOptions(
+		queryOptions.WithSessionID(vObject.NewSessionIDFromUUIDUnsafe(sessionID)),
+		queryOptions.WithForUpdate[*queryOptions.SessionQueryOptions](),
+	)
+
+	tcs := []testCase{
+		{
+			name: "happy path",
+			in:   testRequest{sessionUUID: sessionID, userUUID: userID},
+			exp: func(t *testing.T, getSessionMock *getSession.GetSessionMock, upsertSessionMock *upsertSession.UpsertSessionMock) error {
+				t.Helper()
+
+				getSessionMock.EXPECT().GetSession(gomock.Any(), getSessionQos).Return(newSession(), nil)
+				upsertSessionMock.EXPECT().UpsertSession(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *entities.Session) error {
+					require.NotNil(t, s.RevokedAt)
+					assert.Equal(t, tn, *s.RevokedAt)
+
+					return nil
+				})
+
+				return nil
+			},
+		},
+		{
+			name: "session of another user",
+			in:   testRequest{sessionUUID: sessionID, userUUID: baseUUID.New()},
+			exp: func(t *testing.T, getSessionMock *getSession.GetSessionMock, upsertSessionMock *upsertSession.UpsertSessionMock) error {
+				t.Helper()
+
+				getSessionMock.EXPECT().GetSession(gomock.Any(), getSessionQos).Return(newSession(), nil)
+
+				return entities.ErrSessionRecNotFound
+			},
+		},
+		{
+			name: "get session error",
+			in:   testRequest{sessionUUID: sessionID, userUUID: userID},
+			exp: func(t *testing.T, getSessionMock *getSession.GetSessionMock, upsertSessionMock *upsertSession.UpsertSessionMock) error {
+				t.Helper()
+
+				getSessionMock.EXPECT().GetSession(gomock.Any(), getSessionQos).Return(nil, entities.ErrSessionRecNotFound)
+
+				return entities.ErrSessionRecNotFound
+			},
+		},
+		{
+			name: "upsert session error",
+			in:   testRequest{sessionUUID: sessionID, userUUID: userID},
+			exp: func(t *testing.T, getSessionMock *getSession.GetSessionMock, upsertSessionMock *upsertSession.UpsertSessionMock) error {
+				t.Helper()
+
+				getSessionMock.EXPECT().GetSession(gomock.Any(), getSessionQos).Return(newSession(), nil)
+				upsertSessionMock.EXPECT().UpsertSession(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+				return assert.AnError
+			},
+		},
+		{
+			name: "empty session id",
+			in:   testRequest{sessionUUID: baseUUID.Nil, userUUID: userID},
+			exp: func(t *testing.T, getSessionMock *getSession.GetSessionMock, upsertSessionMock *upsertSession.UpsertSessionMock) error {
+				t.Helper()
+
+				return vObject.ErrEmptyID
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			loggerMock := log.NewLogMock(ctrl)
+			txManagerMock := trx.NewTransactionManagerMock(ctrl)
+			nowFunc := now.NewMock(ctrl)
+			getSessionMock := getSession.NewGetSessionMock(ctrl)
+			upsertSessionMock := upsertSession.NewUpsertSessionMock(ctrl)
+
+			nowFunc.EXPECT().Now().AnyTimes().Return(tn)
+			txManagerMock.EXPECT().Do(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				},
+			)
+
+			uc, err := NewUseCase(
+				usecase.WithTransactionManager[*UseCase](txManagerMock),
+				usecase.WithLogger[*UseCase](loggerMock),
+				usecase.WithNowFunc[*UseCase](nowFunc),
+				WithGetSessionQuery(getSession.NewQueryHandler(getSessionMock)),
+				WithUpsertSessionCommand(upsertSession.NewCommandHandler(upsertSessionMock)),
+			)
+			require.NoError(t, err)
+
+			loggerMock.EXPECT().With(
+				log.String("sessionUUID", tc.in.GetSessionID().String()),
+				log.String("userUUID", tc.in.GetUserID().String()),
+			).Return(loggerMock)
+			loggerMock.EXPECT().Debug(gomock.Any(), "START usecase")
+
+			expErr := tc.exp(t, getSessionMock, upsertSessionMock)
+			if expErr == nil {
+				loggerMock.EXPECT().Debug(gomock.Any(), "END usecase")
+			} else {
+				loggerMock.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+			}
+
+			require.ErrorIs(t, uc.Run(context.Background(), tc.in), expErr)
+		})
+	}
+}
@@ -5,9 +5,16 @@ import (
 
 	upsertOrder "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order/upsert"
 	upsertOrderProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order_product/upsert"
+	createOrderStatusHistory "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order_status_history/create"
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
 	getOrderByID "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_order"
 	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	getMovements "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_movements"
 	getProduct "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_product"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
 	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
 )
 
@@ -47,6 +54,30 @@ func WithGetStocksQuery(handler *getStocks.QueryHandler) usecase.Configuration[*
 	}
 }
 
+func WithGetProductMovementsQuery(handler *getMovements.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getProductMovements")
+		}
+
+		uc.getMovementsQuery = handler
+
+		return nil
+	}
+}
+
+func WithGetUserQuery(handler *getUser.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getUser")
+		}
+
+		uc.getUserQuery = handler
+
+		return nil
+	}
+}
+
 func WithUpsertOrderCommand(handler *upsertOrder.CommandHandler) usecase.Configuration[*UseCase] {
 	return func(uc *UseCase) error {
 		if handler == nil {
@@ -70,3 +101,65 @@ func WithUpsertOrderProductCommand(handler *upsertOrderProduct.CommandHandler) u
 		return nil
 	}
 }
+
+func WithUpdateStockCommand(handler *updateStock.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "updateStock")
+		}
+
+		uc.updateStockCmd = handler
+
+		return nil
+	}
+}
+
+func WithCreateProductMovementsCommand(handler *createProductMovements.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "createProductMovements")
+		}
+
+		uc.createMovementsCmd = handler
+
+		return nil
+	}
+}
+
+func WithCreateOrderStatusHistoryCommand(handler *createOrderStatusHistory.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "createOrderStatusHistory")
+		}
+
+		uc.createHistoryCmd = handler
+
+		return nil
+	}
+}
+
+// WithAllocationPolicy задаёт политику распределения товара по складам. По умолчанию SplitAllocationPolicy.
+func WithAllocationPolicy(policy entities.AllocationPolicy) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if policy == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "allocationPolicy")
+		}
+
+		uc.allocationPolicy = policy
+
+		return nil
+	}
+}
+
+// WithOrderPricePolicy задаёт политику цены позиций неоплаченного заказа. По умолчанию OrderPricePolicySnapshot.
+func WithOrderPricePolicy(policy vObject.OrderPricePolicy) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if !policy.IsValid() {
+			return fmt.Errorf("[addProductToOrder.WithOrderPricePolicy error]: %w: %q", vObject.ErrUnknownOrderPricePolicy, policy)
+		}
+
+		uc.pricePolicy = policy
+
+		return nil
+	}
+}
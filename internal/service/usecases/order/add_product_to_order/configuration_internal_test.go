@@ -13,9 +13,15 @@ import (
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
 	upsertOrder "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order/upsert"
 	upsertOrderProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order_product/upsert"
+	createOrderStatusHistory "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order_status_history/create"
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
 	getOrderByID "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_order"
 	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	getMovements "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_movements"
 	getProduct "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_product"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
 	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
 )
 
@@ -31,6 +37,11 @@ func TestConfiguration(t *testing.T) {
 	getStocksMock := getStocks.NewGetStocksMock(ctrl)
 	upsertOrderMock := upsertOrder.NewUpsertOrderMock(ctrl)
 	upsertOrderProductMock := upsertOrderProduct.NewUpsertOrderProductMock(ctrl)
+	getMovementsMock := getMovements.NewGetProductMovementsMock(ctrl)
+	updateStockMock := updateStock.NewUpdateStockMock(ctrl)
+	createMovementsMock := createProductMovements.NewCreateProductMovementsMock(ctrl)
+	historyMock := createOrderStatusHistory.NewCreateOrderStatusHistoryMock(ctrl)
+	getUserMock := getUser.NewGetUserByIDMock(ctrl)
 
 	cfgs := []usecase.Configuration[*UseCase]{
 		usecase.WithLogger[*UseCase](loggerMock),
@@ -41,6 +52,12 @@ func TestConfiguration(t *testing.T) {
 		WithGetStocksQuery(getStocks.NewQueryHandler(getStocksMock)),
 		WithUpsertOrderCommand(upsertOrder.NewCommandHandler(upsertOrderMock)),
 		WithUpsertOrderProductCommand(upsertOrderProduct.NewCommandHandler(upsertOrderProductMock)),
+		WithGetProductMovementsQuery(getMovements.NewQueryHandler(getMovementsMock)),
+		WithUpdateStockCommand(updateStock.NewCommandHandler(updateStockMock)),
+		WithCreateProductMovementsCommand(createProductMovements.NewCommandHandler(createMovementsMock)),
+		WithCreateOrderStatusHistoryCommand(createOrderStatusHistory.NewCommandHandler(historyMock)),
+		WithAllocationPolicy(entities.LeastFragmentationPolicy{}),
+		WithGetUserQuery(getUser.NewQueryHandler(getUserMock)),
 	}
 
 	f := WithGetOrderQuery(nil)
@@ -68,6 +85,36 @@ func TestConfiguration(t *testing.T) {
 	require.Error(t, err)
 	assert.Empty(t, uc)
 
+	f = WithGetProductMovementsQuery(nil)
+	uc, err = NewUseCase(f)
+	require.Error(t, err)
+	assert.Empty(t, uc)
+
+	f = WithUpdateStockCommand(nil)
+	uc, err = NewUseCase(f)
+	require.Error(t, err)
+	assert.Empty(t, uc)
+
+	f = WithCreateProductMovementsCommand(nil)
+	uc, err = NewUseCase(f)
+	require.Error(t, err)
+	assert.Empty(t, uc)
+
+	f = WithCreateOrderStatusHistoryCommand(nil)
+	uc, err = NewUseCase(f)
+	require.Error(t, err)
+	assert.Empty(t, uc)
+
+	f = WithAllocationPolicy(nil)
+	uc, err = NewUseCase(f)
+	require.Error(t, err)
+	assert.Empty(t, uc)
+
+	f = WithGetUserQuery(nil)
+	uc, err = NewUseCase(f)
+	require.Error(t, err)
+	assert.Empty(t, uc)
+
 	uc, err = NewUseCase(nil)
 	require.ErrorIs(t, err, checker.ErrInitError)
 	require.Empty(t, uc)
@@ -4,6 +4,7 @@ import "github.com/google/uuid"
 
 type Requestable interface {
 	GetOrderID() uuid.UUID
+	// GetUserID пользователь, изменяющий заказ. Новый заказ создаётся на него.
 	GetUserID() uuid.UUID
 	GetProductID() uuid.UUID
 	GetQuantity() uint64
@@ -2,7 +2,6 @@ package addproducttoorder
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	baseUUID "github.com/google/uuid"
@@ -10,14 +9,21 @@ import (
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
 	upsertOrder "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order/upsert"
 	upsertOrderProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order_product/upsert"
+	createOrderStatusHistory "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order_status_history/create"
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
 	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
 	getOrderByID "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_order"
 	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	getMovements "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_movements"
 	getProduct "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_product"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
 	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
 )
 
@@ -29,17 +35,30 @@ type UseCase struct {
 	log.WithLogger
 
 	// Query handlers
-	getOrderQuery   *getOrderByID.QueryHandler
-	getProductQuery *getProduct.QueryHandler
-	getStocksQuery  *getStocks.QueryHandler
+	getOrderQuery     *getOrderByID.QueryHandler
+	getProductQuery   *getProduct.QueryHandler
+	getStocksQuery    *getStocks.QueryHandler
+	getMovementsQuery *getMovements.QueryHandler
+	getUserQuery      *getUser.QueryHandler
 
 	// Command handlers
 	upsertOrderCmd        *upsertOrder.CommandHandler
 	upsertOrderProductCmd *upsertOrderProduct.CommandHandler
+	updateStockCmd        *updateStock.CommandHandler
+	createMovementsCmd    *createProductMovements.CommandHandler
+	createHistoryCmd      *createOrderStatusHistory.CommandHandler
+
+	// allocationPolicy решает, с каких складов резервируется товар
+	allocationPolicy entities.AllocationPolicy
+	// pricePolicy решает, обновляется ли цена позиции неоплаченного заказа при изменении количества
+	pricePolicy vObject.OrderPricePolicy
 }
 
 func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
-	uc := &UseCase{}
+	uc := &UseCase{
+		allocationPolicy: entities.SplitAllocationPolicy{},
+		pricePolicy:      vObject.OrderPricePolicySnapshot,
+	}
 
 	// Apply all Configurations passed in
 	for _, cfg := range cfgs {
@@ -60,7 +79,10 @@ func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
 	return uc, nil
 }
 
-func (uc *UseCase) Run(ctx context.Context, req Requestable) error {
+func (uc *UseCase) Run(ctx context.Context, req Requestable) (*entities.Order, error) {
+	ctx, span := tracing.Start(ctx, "usecase.addProductToOrder")
+	defer span.End()
+
 	l := uc.Logger().With(
 		log.String("orderUUID", req.GetOrderID().String()),
 		log.String("userUUID", req.GetUserID().String()),
@@ -70,86 +92,177 @@ func (uc *UseCase) Run(ctx context.Context, req Requestable) error {
 
 	l.Debug(ctx, "START usecase")
 
-	if err := uc.TransactionDo(ctx, uc.transaction(l, req)); err != nil {
+	actor, err := usecase.Authorize(ctx, uc.getUserQuery, req.GetUserID(), vObject.PermissionManageOwnOrders)
+	if err != nil {
+		l.Error(ctx, "STOP usecase! usecase.Authorize error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[addProductToOrder - usecase.Authorize error]: %w", err))
+	}
+
+	var order *entities.Order
+
+	err = uc.TransactionDo(ctx, func(ctx context.Context) (err error) {
+		order, err = uc.addProduct(ctx, l, actor, req)
+
+		return err
+	})
+	if err != nil {
 		l.Error(ctx, "STOP usecase! transaction error", log.Err(err))
 
-		return fmt.Errorf("[addProductToOrder - uc.TransactionDo error]: %w", err)
+		return nil, tracing.Error(span, fmt.Errorf("[addProductToOrder - uc.TransactionDo error]: %w", err))
 	}
 
 	l.Debug(ctx, "END usecase")
 
-	return nil
+	return order, nil
 }
 
-func (uc *UseCase) transaction(l log.Logger, req Requestable) func(ctx context.Context) error {
-	return func(ctx context.Context) error {
-		// 1. Получаем заказ по ID (если есть ID и запись в БД), либо создаём новый
-		order, err := uc.getOrder(ctx, req)
-		if err != nil {
-			return fmt.Errorf("[addProductToOrder - uc.getOrder error]: %w", err)
-		}
+func (uc *UseCase) addProduct(ctx context.Context, l log.Logger, actor *entities.User, req Requestable) (*entities.Order, error) {
+	// 1. Получаем заказ по ID с блокировкой до конца транзакции, без ID создаём новый
+	order, err := uc.getOrder(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("[addProductToOrder - uc.getOrder error]: %w", err)
+	}
 
-		l = l.With(log.String("orderID", order.ID.String()))
+	// пользователь изменяет только свои заказы, если роль не даёт доступа к заказам всех пользователей;
+	// чужой заказ, как и в orderDetails, не раскрывается
+	if !actor.CanManageOrder(order) {
+		return nil, fmt.Errorf("[addProductToOrder]: %w: order %s", entities.ErrOrderRecNotFound, order.ID)
+	}
 
-		// 2. Получаем товар по id
-		productQuery, err := getProduct.NewQueryByID(req.GetProductID())
-		if err != nil {
-			return fmt.Errorf("[addProductToOrder - getProduct.NewQueryByProductIDUnsafe error]: %w", err)
-		}
+	l = l.With(log.String("orderID", order.ID.String()))
 
-		product, err := uc.getProductQuery.Handle(ctx, *productQuery)
-		if err != nil {
-			return fmt.Errorf("[addProductToOrder - uc.getProductQuery.Handle error]: %w", err)
-		}
+	// 2. Получаем товар по id
+	productQuery, err := getProduct.NewQueryByID(req.GetProductID())
+	if err != nil {
+		return nil, fmt.Errorf("[addProductToOrder - getProduct.NewQueryByProductIDUnsafe error]: %w", err)
+	}
 
-		// 3. Получаем количество товара на складе
-		productStocks, err := uc.getStocksQuery.Handle(ctx, getStocks.NewQueryByProductIDUnsafe(product.ID))
-		if err != nil {
-			return fmt.Errorf("[addProductToOrder - uc.getStocksQuery.Handle error]: %w", err)
-		}
+	product, err := uc.getProductQuery.Handle(ctx, *productQuery)
+	if err != nil {
+		return nil, fmt.Errorf("[addProductToOrder - uc.getProductQuery.Handle error]: %w", err)
+	}
 
-		l = l.With(log.Uint64("productAvailableQuantity", productStocks.GetAvailableQuantity().Uint64()))
+	// 3. Получаем количество товара на складах с блокировкой остатков до конца транзакции
+	productStocks, err := uc.getStocksQuery.Handle(ctx, getStocks.NewQueryByProductIDForUpdateUnsafe(product.ID))
+	if err != nil {
+		return nil, fmt.Errorf("[addProductToOrder - uc.getStocksQuery.Handle error]: %w", err)
+	}
 
-		// 4. Изменяем количество товара в заказе с проверкой на доступность указанного количества товара на складе
-		if err = order.ChangeOrderProducts(productStocks, *product, req.GetQuantity()); err != nil {
-			return fmt.Errorf("[addProductToOrder - order.ChangeProductAmount error]: %w", err)
-		}
+	l = l.With(log.Uint64("productAvailableQuantity", productStocks.GetAvailableQuantity().Uint64()))
 
-		// 5. Сохраняем заказ
-		if err = uc.upsertOrderCmd.Handle(ctx, upsertOrder.NewCommandUnsafe(order)); err != nil {
-			return fmt.Errorf("[addProductToOrder - uc.upsertOrderCmd.Run error]: %w", err)
-		}
+	ordered := vObject.QuantityZero
+	if orderProduct := order.GetOrderProductByProductIDUnsafe(product.ID); orderProduct != nil {
+		ordered = orderProduct.Quantity
+	}
 
-		// 6. Сохраняем товар в заказе
-		orderProduct := order.GetOrderProductByProductIDUnsafe(product.ID)
-		if err = uc.upsertOrderProductCmd.Handle(ctx, upsertOrderProduct.NewCommandUnsafe(orderProduct)); err != nil {
-			return fmt.Errorf("[addProductToOrder - uc.upsertOrderProductCmd.Run error]: %w", err)
-		}
+	// 4. Изменяем количество товара в заказе с проверкой на доступность указанного количества товара на складе
+	orderProduct, err := order.ChangeOrderProducts(productStocks, *product, req.GetQuantity(), uc.pricePolicy)
+	if err != nil {
+		return nil, fmt.Errorf("[addProductToOrder - order.ChangeProductAmount error]: %w", err)
+	}
 
-		l = l.With(log.Uint64("orderProductQuantity", orderProduct.Quantity.Uint64()))
+	// удаляется товар, которого нет в заказе
+	if orderProduct == nil {
+		return order, nil
+	}
 
-		return nil
+	// 5. Сохраняем заказ
+	if err = uc.upsertOrderCmd.Handle(ctx, upsertOrder.NewCommandUnsafe(order)); err != nil {
+		return nil, fmt.Errorf("[addProductToOrder - uc.upsertOrderCmd.Run error]: %w", err)
 	}
+
+	// 6. Резервируем на складах изменение количества товара в заказе согласно политике распределения
+	movements, err := uc.reserve(ctx, order, product, productStocks, ordered, vObject.NewQuantityUnsafe(req.GetQuantity()))
+	if err != nil {
+		return nil, fmt.Errorf("[addProductToOrder - uc.reserve error]: %w", err)
+	}
+
+	// 7. Сохраняем товар в заказе вместе с распределением по складам
+	orderProduct.ApplyMovements(movements)
+	order.Products.Replace(*orderProduct)
+
+	if err = uc.upsertOrderProductCmd.Handle(ctx, upsertOrderProduct.NewCommandUnsafe(orderProduct)); err != nil {
+		return nil, fmt.Errorf("[addProductToOrder - uc.upsertOrderProductCmd.Run error]: %w", err)
+	}
+
+	l = l.With(log.Uint64("orderProductQuantity", orderProduct.Quantity.Uint64()))
+
+	return order, nil
 }
 
-func (uc *UseCase) getOrder(ctx context.Context, req Requestable) (*entities.Order, error) {
-	if req.GetOrderID() != baseUUID.Nil {
-		// подавляем ошибку, так как orderID может быть пустым
-		query, _ := getOrderByID.NewQueryForUpdate(req.GetOrderID())
-		order, err := uc.getOrderQuery.Handle(ctx, *query)
+// reserve резервирует на складах прирост количества товара в заказе либо снимает резерв при его уменьшении
+// и возвращает созданные движения. Остатки и движения сохраняются в текущей транзакции, остатки должны быть заблокированы.
+func (uc *UseCase) reserve(
+	ctx context.Context,
+	order *entities.Order,
+	product *entities.Product,
+	stocks entities.Stocks,
+	ordered, quantity vObject.Quantity,
+) (entities.ProductMovements, error) {
+	opts := []entities.Option[*entities.ProductMovement]{
+		entities.WithProductMovementOrderID(order.ID),
+		entities.WithUUIDFunc[*entities.ProductMovement](uc.GetUUIDGen()),
+		entities.WithNowFunc[*entities.ProductMovement](uc.GetNowGen()),
+	}
 
-		if errors.Is(err, entities.ErrOrderRecNotFound) {
-			return uc.createOrder(ctx, req)
+	var (
+		movements entities.ProductMovements
+		err       error
+	)
+
+	switch {
+	case quantity > ordered:
+		movements, err = stocks.Reserve(uc.allocationPolicy, quantity-ordered, product.Price, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("[addProductToOrder - stocks.Reserve error]: %w", err)
+		}
+	case quantity < ordered:
+		reservations, err := uc.getMovementsQuery.Handle(ctx, getMovements.NewQueryByOrderProductUnsafe(order.ID, product.ID))
+		if err != nil {
+			return nil, fmt.Errorf("[addProductToOrder - uc.getMovementsQuery.Handle error]: %w", err)
 		}
 
+		movements, err = stocks.Unreserve(reservations.ReservedByWarehouse(), ordered-quantity, product.Price, opts...)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("[addProductToOrder - stocks.Unreserve error]: %w", err)
 		}
+	default:
+		return nil, nil
+	}
 
-		return order, nil
+	for _, movement := range movements {
+		stock := stocks.GetByWarehouseIDUnsafe(movement.WarehouseID)
+		if err = uc.updateStockCmd.Handle(ctx, updateStock.NewCommandUnsafe(stock)); err != nil {
+			return nil, fmt.Errorf("[addProductToOrder - uc.updateStockCmd.Handle error]: %w", err)
+		}
+	}
+
+	if err = uc.createMovementsCmd.Handle(ctx, createProductMovements.NewCommandUnsafe(movements)); err != nil {
+		return nil, fmt.Errorf("[addProductToOrder - uc.createMovementsCmd.Handle error]: %w", err)
 	}
 
-	return uc.createOrder(ctx, req)
+	return movements, nil
+}
+
+// getOrder заказ из запроса, заблокированный до конца транзакции. Новый заказ создаётся, только если ID
+// не указан: указанный, но не найденный заказ — ошибка entities.ErrOrderRecNotFound.
+func (uc *UseCase) getOrder(ctx context.Context, req Requestable) (*entities.Order, error) {
+	if req.GetOrderID() == baseUUID.Nil {
+		return uc.createOrder(ctx, req)
+	}
+
+	query, err := getOrderByID.NewQueryForUpdate(req.GetOrderID())
+	if err != nil {
+		return nil, fmt.Errorf("[addProductToOrder - getOrderByID.NewQueryForUpdate error]: %w", err)
+	}
+
+	order, err := uc.getOrderQuery.Handle(ctx, *query)
+	if err != nil {
+		return nil, fmt.Errorf("[addProductToOrder - uc.getOrderQuery.Handle error]: %w", err)
+	}
+
+	return order, nil
 }
 
 func (uc *UseCase) createOrder(ctx context.Context, req Requestable) (*entities.Order, error) {
@@ -166,5 +279,20 @@ func (uc *UseCase) createOrder(ctx context.Context, req Requestable) (*entities.
 		return nil, fmt.Errorf("[addProductToOrder - uc.createOrderCmd.Run error]: %w", err)
 	}
 
-	return cmd.GetOrder(), nil
+	// история статусов начинается с создания заказа
+	order := cmd.GetOrder()
+	history := entities.NewOrderStatusHistoryUnsafe(
+		order.ID,
+		"",
+		order.Status,
+		&order.UserID,
+		"",
+		entities.WithUUIDFunc[*entities.OrderStatusHistory](uc.GetUUIDGen()),
+		entities.WithNowFunc[*entities.OrderStatusHistory](uc.GetNowGen()),
+	)
+	if err = uc.createHistoryCmd.Handle(ctx, createOrderStatusHistory.NewCommandUnsafe(&history)); err != nil {
+		return nil, fmt.Errorf("[addProductToOrder - uc.createHistoryCmd.Handle error]: %w", err)
+	}
+
+	return order, nil
 }
@@ -17,12 +17,17 @@ import (
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
 	upsertOrder "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order/upsert"
 	upsertOrderProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order_product/upsert"
+	createOrderStatusHistory "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order_status_history/create"
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
 	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
 	queryoptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
 	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
 	getOrderByID "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_order"
 	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	getMovements "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_movements"
 	getProduct "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_product"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
 	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
 )
 
@@ -39,6 +44,11 @@ func TestNewUseCase(t *testing.T) {
 	getStocksMock := getStocks.NewGetStocksMock(ctrl)
 	upsertOrderMock := upsertOrder.NewUpsertOrderMock(ctrl)
 	upsertOrderProductMock := upsertOrderProduct.NewUpsertOrderProductMock(ctrl)
+	getMovementsMock := getMovements.NewGetProductMovementsMock(ctrl)
+	updateStockMock := updateStock.NewUpdateStockMock(ctrl)
+	createMovementsMock := createProductMovements.NewCreateProductMovementsMock(ctrl)
+	historyMock := createOrderStatusHistory.NewCreateOrderStatusHistoryMock(ctrl)
+	getUserMock := getUser.NewGetUserByIDMock(ctrl)
 
 	cfgs := []usecase.Configuration[*UseCase]{
 		usecase.WithTransactionManager[*UseCase](txManagerMock),
@@ -50,11 +60,24 @@ func TestNewUseCase(t *testing.T) {
 		WithGetStocksQuery(getStocks.NewQueryHandler(getStocksMock)),
 		WithUpsertOrderCommand(upsertOrder.NewCommandHandler(upsertOrderMock)),
 		WithUpsertOrderProductCommand(upsertOrderProduct.NewCommandHandler(upsertOrderProductMock)),
+		WithGetProductMovementsQuery(getMovements.NewQueryHandler(getMovementsMock)),
+		WithUpdateStockCommand(updateStock.NewCommandHandler(updateStockMock)),
+		WithCreateProductMovementsCommand(createProductMovements.NewCommandHandler(createMovementsMock)),
+		WithCreateOrderStatusHistoryCommand(createOrderStatusHistory.NewCommandHandler(historyMock)),
+		WithGetUserQuery(getUser.NewQueryHandler(getUserMock)),
 	}
 
 	uc, err := NewUseCase(cfgs...)
 	require.NoError(t, err)
 	require.NotEmpty(t, uc)
+	assert.Equal(t, vObject.OrderPricePolicySnapshot, uc.pricePolicy)
+
+	uc, err = NewUseCase(append(cfgs, WithOrderPricePolicy(vObject.OrderPricePolicyReprice))...)
+	require.NoError(t, err)
+	assert.Equal(t, vObject.OrderPricePolicyReprice, uc.pricePolicy)
+
+	_, err = NewUseCase(append(cfgs, WithOrderPricePolicy("latest"))...)
+	require.ErrorIs(t, err, vObject.ErrUnknownOrderPricePolicy)
 
 	uc, err = NewUseCase(func(upc *UseCase) error {
 		return assert.AnError
@@ -80,10 +103,12 @@ func TestUseCase_Run(t *testing.T) {
 		exp  func(t *testing.T, in testRequest, loggerMock *log.LogMock, trxMng *trx.TransactionManagerMock) error
 	}
 
+	customer := entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), Role: vObject.RoleCustomer}
+
 	tcs := []testCase{
 		{
 			name: "happy path",
-			in:   testRequest{},
+			in:   testRequest{userUUID: customer.ID.UUID()},
 			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, trxMng *trx.TransactionManagerMock) error {
 				trxMng.EXPECT().Do(gomock.Any(), gomock.Any()).Return(nil)
 				loggerMock.EXPECT().Debug(gomock.Any(), "END usecase")
@@ -93,7 +118,7 @@ func TestUseCase_Run(t *testing.T) {
 		},
 		{
 			name: "transaction error",
-			in:   testRequest{},
+			in:   testRequest{userUUID: customer.ID.UUID()},
 			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, trxMng *trx.TransactionManagerMock) error {
 				trxMng.EXPECT().Do(gomock.Any(), gomock.Any()).Return(assert.AnError)
 
@@ -102,6 +127,24 @@ func TestUseCase_Run(t *testing.T) {
 				return assert.AnError
 			},
 		},
+		{
+			name: "anonymous actor",
+			in:   testRequest{},
+			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, trxMng *trx.TransactionManagerMock) error {
+				loggerMock.EXPECT().Error(gomock.Any(), "STOP usecase! usecase.Authorize error", gomock.Any())
+
+				return entities.ErrPermissionDenied
+			},
+		},
+		{
+			name: "unknown actor",
+			in:   testRequest{userUUID: baseUUID.New()},
+			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, trxMng *trx.TransactionManagerMock) error {
+				loggerMock.EXPECT().Error(gomock.Any(), "STOP usecase! usecase.Authorize error", gomock.Any())
+
+				return entities.ErrPermissionDenied
+			},
+		},
 	}
 
 	for _, tc := range tcs {
@@ -118,6 +161,14 @@ func TestUseCase_Run(t *testing.T) {
 			getStocksMock := getStocks.NewGetStocksMock(ctrl)
 			upsertOrderMock := upsertOrder.NewUpsertOrderMock(ctrl)
 			upsertOrderProductMock := upsertOrderProduct.NewUpsertOrderProductMock(ctrl)
+			getMovementsMock := getMovements.NewGetProductMovementsMock(ctrl)
+			updateStockMock := updateStock.NewUpdateStockMock(ctrl)
+			createMovementsMock := createProductMovements.NewCreateProductMovementsMock(ctrl)
+			historyMock := createOrderStatusHistory.NewCreateOrderStatusHistoryMock(ctrl)
+			getUserMock := getUser.NewGetUserByIDMock(ctrl)
+
+			getUserMock.EXPECT().GetByID(gomock.Any(), queryoptions.NewUserQueryOptions(queryoptions.WithUserID(customer.ID))).AnyTimes().Return(&customer, nil)
+			getUserMock.EXPECT().GetByID(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, entities.ErrUserRecNotFound)
 
 			cfgs := []usecase.Configuration[*UseCase]{
 				usecase.WithTransactionManager[*UseCase](txManagerMock),
@@ -129,6 +180,11 @@ func TestUseCase_Run(t *testing.T) {
 				WithGetStocksQuery(getStocks.NewQueryHandler(getStocksMock)),
 				WithUpsertOrderCommand(upsertOrder.NewCommandHandler(upsertOrderMock)),
 				WithUpsertOrderProductCommand(upsertOrderProduct.NewCommandHandler(upsertOrderProductMock)),
+				WithGetProductMovementsQuery(getMovements.NewQueryHandler(getMovementsMock)),
+				WithUpdateStockCommand(updateStock.NewCommandHandler(updateStockMock)),
+				WithCreateProductMovementsCommand(createProductMovements.NewCommandHandler(createMovementsMock)),
+				WithCreateOrderStatusHistoryCommand(createOrderStatusHistory.NewCommandHandler(historyMock)),
+				WithGetUserQuery(getUser.NewQueryHandler(getUserMock)),
 			}
 
 			loggerMock.EXPECT().With(
@@ -144,18 +200,19 @@ func TestUseCase_Run(t *testing.T) {
 
 			expErr := tc.exp(t, tc.in, loggerMock, txManagerMock)
 
-			assert.ErrorIs(t, uc.Run(context.Background(), tc.in), expErr)
+			_, err = uc.Run(context.Background(), tc.in)
+			assert.ErrorIs(t, err, expErr)
 		})
 	}
 }
 
-func TestUseCase_transaction(t *testing.T) {
+func TestUseCase_addProduct(t *testing.T) {
 	t.Parallel()
 
 	type testCase struct {
 		name string
 		in   testRequest
-		exp  func(t *testing.T, in testRequest, loggerMock *log.LogMock, getOrderMock *getOrderByID.GetOrderMock, getProductMock *getProduct.GetProductMock, getStocksMock *getStocks.GetStocksMock, upsertOrderMock *upsertOrder.UpsertOrderMock, upsertOrderProductMock *upsertOrderProduct.UpsertOrderProductMock) error
+		exp  func(t *testing.T, in testRequest, loggerMock *log.LogMock, getOrderMock *getOrderByID.GetOrderMock, getProductMock *getProduct.GetProductMock, getStocksMock *getStocks.GetStocksMock, upsertOrderMock *upsertOrder.UpsertOrderMock, upsertOrderProductMock *upsertOrderProduct.UpsertOrderProductMock, getMovementsMock *getMovements.GetProductMovementsMock, updateStockMock *updateStock.UpdateStockMock, createMovementsMock *createProductMovements.CreateProductMovementsMock) error
 	}
 
 	tn := time.Now()
@@ -177,7 +234,7 @@ func TestUseCase_transaction(t *testing.T) {
 				quantity:    6,
 				userUUID:    id,
 			},
-			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, getOrderMock *getOrderByID.GetOrderMock, getProductMock *getProduct.GetProductMock, getStocksMock *getStocks.GetStocksMock, upsertOrderMock *upsertOrder.UpsertOrderMock, upsertOrderProductMock *upsertOrderProduct.UpsertOrderProductMock) error {
+			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, getOrderMock *getOrderByID.GetOrderMock, getProductMock *getProduct.GetProductMock, getStocksMock *getStocks.GetStocksMock, upsertOrderMock *upsertOrder.UpsertOrderMock, upsertOrderProductMock *upsertOrderProduct.UpsertOrderProductMock, getMovementsMock *getMovements.GetProductMovementsMock, updateStockMock *updateStock.UpdateStockMock, createMovementsMock *createProductMovements.CreateProductMovementsMock) error {
 				t.Helper()
 
 				order := entities.NewOrderUnsafe(
@@ -210,22 +267,149 @@ func TestUseCase_transaction(t *testing.T) {
 				getOrderMock.EXPECT().GetOrder(gomock.Any(), queryoptions.NewOrderQueryOptions(queryoptions.WithOrderID(order.ID), queryoptions.WithForUpdate[*queryoptions.OrderQueryOptions]())).Return(&order, nil)
 				loggerMock.EXPECT().With(log.String("orderID", order.ID.String())).Return(loggerMock)
 				getProductMock.EXPECT().GetProduct(gomock.Any(), queryoptions.NewProductQueryOptions(queryoptions.WithProductID(product.ID))).Return(&product, nil)
-				getStocksMock.EXPECT().GetStocks(gomock.Any(), queryoptions.NewStockQueryOptions(queryoptions.WithStockProductID(product.ID))).Return(productStocks, nil)
+				getStocksMock.EXPECT().GetStocks(gomock.Any(), queryoptions.NewStockQueryOptions(queryoptions.WithStockProductID(product.ID), queryoptions.WithForUpdate[*queryoptions.StockQueryOptions]())).Return(productStocks, nil)
 				loggerMock.EXPECT().With(log.Uint64("productAvailableQuantity", productStocks.GetAvailableQuantity().Uint64())).Return(loggerMock)
 
 				changedOrder := order
-				require.NoError(t, changedOrder.ChangeOrderProducts(productStocks, product, in.GetQuantity()))
+				_, err := changedOrder.ChangeOrderProducts(productStocks, product, in.GetQuantity(), vObject.OrderPricePolicySnapshot)
+				require.NoError(t, err)
 
 				upsertOrderMock.EXPECT().UpsertOrder(gomock.Any(), &changedOrder).Return(nil)
 
+				// 6 единиц резервируются на первом складе: свободно 10 - 3 = 7
 				orderProduct := changedOrder.GetOrderProductByProductIDUnsafe(product.ID)
+				orderProduct.Allocations = entities.OrderProductAllocations{{WarehouseID: productStocks[0].WarehouseID, Quantity: 6}}
 
 				upsertOrderProductMock.EXPECT().UpsertOrderProduct(gomock.Any(), orderProduct).Return(nil)
 				loggerMock.EXPECT().With(log.Uint64("orderProductQuantity", orderProduct.Quantity.Uint64())).Return(loggerMock)
 
+				updateStockMock.EXPECT().UpdateStock(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, stock *entities.Stock) error {
+					assert.Equal(t, productStocks[0].WarehouseID, stock.WarehouseID)
+					assert.Equal(t, vObject.NewQuantityUnsafe(9), stock.ReservedQuantity)
+
+					return nil
+				})
+				createMovementsMock.EXPECT().CreateProductMovements(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, movements entities.ProductMovements) error {
+					require.Len(t, movements, 1)
+					assert.Equal(t, vObject.OperationTypeReserve, movements[0].OperationType)
+					assert.Equal(t, vObject.NewQuantityUnsafe(6), movements[0].Quantity)
+					assert.Equal(t, &order.ID, movements[0].OrderID)
+
+					return nil
+				})
+
+				return nil
+			},
+		},
+		{
+			name: "decrease quantity releases reserve",
+			in: testRequest{
+				orderUUID:   id,
+				productUUID: id,
+				quantity:    2,
+				userUUID:    id,
+			},
+			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, getOrderMock *getOrderByID.GetOrderMock, getProductMock *getProduct.GetProductMock, getStocksMock *getStocks.GetStocksMock, upsertOrderMock *upsertOrder.UpsertOrderMock, upsertOrderProductMock *upsertOrderProduct.UpsertOrderProductMock, getMovementsMock *getMovements.GetProductMovementsMock, updateStockMock *updateStock.UpdateStockMock, createMovementsMock *createProductMovements.CreateProductMovementsMock) error {
+				t.Helper()
+
+				order := entities.NewOrderUnsafe(
+					vObject.NewUserIDFromUUIDUnsafe(in.GetOrderID()),
+					entities.WithUUIDFunc[*entities.Order](uuidFunc),
+					entities.WithNowFunc[*entities.Order](nowFunc),
+				)
+				product := entities.NewProductUnsafe(
+					vObject.NewProductTitleUnsafe("product title"),
+					vObject.NewProductDescriptionUnsafe("product description"),
+					vObject.NewPriceUnsafe(10000),
+					entities.WithUUIDFunc[*entities.Product](uuidFunc),
+					entities.WithNowFunc[*entities.Product](nowFunc),
+				)
+				productStocks := entities.Stocks{
+					entities.NewStockUnsafe(
+						product.ID,
+						vObject.NewWarehouseIDFromUUIDUnsafe(baseUUID.New()),
+						vObject.NewQuantityUnsafe(6),  //reserve
+						vObject.NewQuantityUnsafe(10), //available
+					),
+				}
+				_, err := order.ChangeOrderProducts(entities.Stocks{{AvailableQuantity: 6}}, product, 6, vObject.OrderPricePolicySnapshot)
+				require.NoError(t, err)
+				order.Products[0].Allocations = entities.OrderProductAllocations{{WarehouseID: productStocks[0].WarehouseID, Quantity: 6}}
+
+				getOrderMock.EXPECT().GetOrder(gomock.Any(), gomock.Any()).Return(&order, nil)
+				loggerMock.EXPECT().With(gomock.Any()).AnyTimes().Return(loggerMock)
+				getProductMock.EXPECT().GetProduct(gomock.Any(), gomock.Any()).Return(&product, nil)
+				getStocksMock.EXPECT().GetStocks(gomock.Any(), gomock.Any()).Return(productStocks, nil)
+				upsertOrderMock.EXPECT().UpsertOrder(gomock.Any(), gomock.Any()).Return(nil)
+				upsertOrderProductMock.EXPECT().UpsertOrderProduct(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, orderProduct *entities.OrderProduct) error {
+					assert.Equal(t, entities.OrderProductAllocations{{WarehouseID: productStocks[0].WarehouseID, Quantity: 2}}, orderProduct.Allocations)
+
+					return nil
+				})
+				getMovementsMock.EXPECT().GetProductMovements(gomock.Any(), queryoptions.NewProductMovementQueryOptions(
+					queryoptions.WithMovementProductID(product.ID),
+					queryoptions.WithMovementOrderID(order.ID),
+				)).Return(entities.ProductMovements{
+					entities.NewProductMovementUnsafe(product.ID, productStocks[0].WarehouseID, vObject.OperationTypeReserve, 6, product.Price),
+				}, nil)
+				updateStockMock.EXPECT().UpdateStock(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, stock *entities.Stock) error {
+					assert.Equal(t, vObject.NewQuantityUnsafe(2), stock.ReservedQuantity)
+
+					return nil
+				})
+				createMovementsMock.EXPECT().CreateProductMovements(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, movements entities.ProductMovements) error {
+					require.Len(t, movements, 1)
+					assert.Equal(t, vObject.OperationTypeUnreserve, movements[0].OperationType)
+					assert.Equal(t, vObject.NewQuantityUnsafe(4), movements[0].Quantity)
+
+					return nil
+				})
+
 				return nil
 			},
 		},
+		{
+			name: "stock update error",
+			in: testRequest{
+				orderUUID:   id,
+				productUUID: id,
+				quantity:    6,
+				userUUID:    id,
+			},
+			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, getOrderMock *getOrderByID.GetOrderMock, getProductMock *getProduct.GetProductMock, getStocksMock *getStocks.GetStocksMock, upsertOrderMock *upsertOrder.UpsertOrderMock, upsertOrderProductMock *upsertOrderProduct.UpsertOrderProductMock, getMovementsMock *getMovements.GetProductMovementsMock, updateStockMock *updateStock.UpdateStockMock, createMovementsMock *createProductMovements.CreateProductMovementsMock) error {
+				t.Helper()
+
+				order := entities.NewOrderUnsafe(
+					vObject.NewUserIDFromUUIDUnsafe(in.GetOrderID()),
+					entities.WithUUIDFunc[*entities.Order](uuidFunc),
+					entities.WithNowFunc[*entities.Order](nowFunc),
+				)
+				product := entities.NewProductUnsafe(
+					vObject.NewProductTitleUnsafe("product title"),
+					vObject.NewProductDescriptionUnsafe("product description"),
+					vObject.NewPriceUnsafe(10000),
+					entities.WithUUIDFunc[*entities.Product](uuidFunc),
+					entities.WithNowFunc[*entities.Product](nowFunc),
+				)
+				productStocks := entities.Stocks{
+					entities.NewStockUnsafe(
+						product.ID,
+						vObject.NewWarehouseIDFromUUIDUnsafe(baseUUID.New()),
+						vObject.NewQuantityUnsafe(0),  //reserve
+						vObject.NewQuantityUnsafe(10), //available
+					),
+				}
+
+				getOrderMock.EXPECT().GetOrder(gomock.Any(), gomock.Any()).Return(&order, nil)
+				loggerMock.EXPECT().With(gomock.Any()).AnyTimes().Return(loggerMock)
+				getProductMock.EXPECT().GetProduct(gomock.Any(), gomock.Any()).Return(&product, nil)
+				getStocksMock.EXPECT().GetStocks(gomock.Any(), gomock.Any()).Return(productStocks, nil)
+				upsertOrderMock.EXPECT().UpsertOrder(gomock.Any(), gomock.Any()).Return(nil)
+				updateStockMock.EXPECT().UpdateStock(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+				return assert.AnError
+			},
+		},
 		{
 			name: "orderProduct upsert error",
 			in: testRequest{
@@ -234,7 +418,7 @@ func TestUseCase_transaction(t *testing.T) {
 				quantity:    6,
 				userUUID:    id,
 			},
-			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, getOrderMock *getOrderByID.GetOrderMock, getProductMock *getProduct.GetProductMock, getStocksMock *getStocks.GetStocksMock, upsertOrderMock *upsertOrder.UpsertOrderMock, upsertOrderProductMock *upsertOrderProduct.UpsertOrderProductMock) error {
+			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, getOrderMock *getOrderByID.GetOrderMock, getProductMock *getProduct.GetProductMock, getStocksMock *getStocks.GetStocksMock, upsertOrderMock *upsertOrder.UpsertOrderMock, upsertOrderProductMock *upsertOrderProduct.UpsertOrderProductMock, getMovementsMock *getMovements.GetProductMovementsMock, updateStockMock *updateStock.UpdateStockMock, createMovementsMock *createProductMovements.CreateProductMovementsMock) error {
 				t.Helper()
 
 				order := entities.NewOrderUnsafe(
@@ -267,15 +451,20 @@ func TestUseCase_transaction(t *testing.T) {
 				getOrderMock.EXPECT().GetOrder(gomock.Any(), queryoptions.NewOrderQueryOptions(queryoptions.WithOrderID(order.ID), queryoptions.WithForUpdate[*queryoptions.OrderQueryOptions]())).Return(&order, nil)
 				loggerMock.EXPECT().With(log.String("orderID", order.ID.String())).Return(loggerMock)
 				getProductMock.EXPECT().GetProduct(gomock.Any(), queryoptions.NewProductQueryOptions(queryoptions.WithProductID(product.ID))).Return(&product, nil)
-				getStocksMock.EXPECT().GetStocks(gomock.Any(), queryoptions.NewStockQueryOptions(queryoptions.WithStockProductID(product.ID))).Return(productStocks, nil)
+				getStocksMock.EXPECT().GetStocks(gomock.Any(), queryoptions.NewStockQueryOptions(queryoptions.WithStockProductID(product.ID), queryoptions.WithForUpdate[*queryoptions.StockQueryOptions]())).Return(productStocks, nil)
 				loggerMock.EXPECT().With(log.Uint64("productAvailableQuantity", productStocks.GetAvailableQuantity().Uint64())).Return(loggerMock)
 
 				changedOrder := order
-				require.NoError(t, changedOrder.ChangeOrderProducts(productStocks, product, in.GetQuantity()))
+				_, err := changedOrder.ChangeOrderProducts(productStocks, product, in.GetQuantity(), vObject.OrderPricePolicySnapshot)
+				require.NoError(t, err)
 
 				upsertOrderMock.EXPECT().UpsertOrder(gomock.Any(), &changedOrder).Return(nil)
 
+				updateStockMock.EXPECT().UpdateStock(gomock.Any(), gomock.Any()).Return(nil)
+				createMovementsMock.EXPECT().CreateProductMovements(gomock.Any(), gomock.Any()).Return(nil)
+
 				orderProduct := changedOrder.GetOrderProductByProductIDUnsafe(product.ID)
+				orderProduct.Allocations = entities.OrderProductAllocations{{WarehouseID: productStocks[0].WarehouseID, Quantity: 6}}
 
 				upsertOrderProductMock.EXPECT().UpsertOrderProduct(gomock.Any(), orderProduct).Return(assert.AnError)
 
@@ -290,7 +479,7 @@ func TestUseCase_transaction(t *testing.T) {
 				quantity:    6,
 				userUUID:    id,
 			},
-			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, getOrderMock *getOrderByID.GetOrderMock, getProductMock *getProduct.GetProductMock, getStocksMock *getStocks.GetStocksMock, upsertOrderMock *upsertOrder.UpsertOrderMock, upsertOrderProductMock *upsertOrderProduct.UpsertOrderProductMock) error {
+			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, getOrderMock *getOrderByID.GetOrderMock, getProductMock *getProduct.GetProductMock, getStocksMock *getStocks.GetStocksMock, upsertOrderMock *upsertOrder.UpsertOrderMock, upsertOrderProductMock *upsertOrderProduct.UpsertOrderProductMock, getMovementsMock *getMovements.GetProductMovementsMock, updateStockMock *updateStock.UpdateStockMock, createMovementsMock *createProductMovements.CreateProductMovementsMock) error {
 				t.Helper()
 
 				order := entities.NewOrderUnsafe(
@@ -323,11 +512,12 @@ func TestUseCase_transaction(t *testing.T) {
 				getOrderMock.EXPECT().GetOrder(gomock.Any(), queryoptions.NewOrderQueryOptions(queryoptions.WithOrderID(order.ID), queryoptions.WithForUpdate[*queryoptions.OrderQueryOptions]())).Return(&order, nil)
 				loggerMock.EXPECT().With(log.String("orderID", order.ID.String())).Return(loggerMock)
 				getProductMock.EXPECT().GetProduct(gomock.Any(), queryoptions.NewProductQueryOptions(queryoptions.WithProductID(product.ID))).Return(&product, nil)
-				getStocksMock.EXPECT().GetStocks(gomock.Any(), queryoptions.NewStockQueryOptions(queryoptions.WithStockProductID(product.ID))).Return(productStocks, nil)
+				getStocksMock.EXPECT().GetStocks(gomock.Any(), queryoptions.NewStockQueryOptions(queryoptions.WithStockProductID(product.ID), queryoptions.WithForUpdate[*queryoptions.StockQueryOptions]())).Return(productStocks, nil)
 				loggerMock.EXPECT().With(log.Uint64("productAvailableQuantity", productStocks.GetAvailableQuantity().Uint64())).Return(loggerMock)
 
 				changedOrder := order
-				require.NoError(t, changedOrder.ChangeOrderProducts(productStocks, product, in.GetQuantity()))
+				_, err := changedOrder.ChangeOrderProducts(productStocks, product, in.GetQuantity(), vObject.OrderPricePolicySnapshot)
+				require.NoError(t, err)
 
 				upsertOrderMock.EXPECT().UpsertOrder(gomock.Any(), &changedOrder).Return(assert.AnError)
 
@@ -342,7 +532,7 @@ func TestUseCase_transaction(t *testing.T) {
 				quantity:    111,
 				userUUID:    id,
 			},
-			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, getOrderMock *getOrderByID.GetOrderMock, getProductMock *getProduct.GetProductMock, getStocksMock *getStocks.GetStocksMock, upsertOrderMock *upsertOrder.UpsertOrderMock, upsertOrderProductMock *upsertOrderProduct.UpsertOrderProductMock) error {
+			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, getOrderMock *getOrderByID.GetOrderMock, getProductMock *getProduct.GetProductMock, getStocksMock *getStocks.GetStocksMock, upsertOrderMock *upsertOrder.UpsertOrderMock, upsertOrderProductMock *upsertOrderProduct.UpsertOrderProductMock, getMovementsMock *getMovements.GetProductMovementsMock, updateStockMock *updateStock.UpdateStockMock, createMovementsMock *createProductMovements.CreateProductMovementsMock) error {
 				t.Helper()
 
 				order := entities.NewOrderUnsafe(
@@ -375,7 +565,7 @@ func TestUseCase_transaction(t *testing.T) {
 				getOrderMock.EXPECT().GetOrder(gomock.Any(), queryoptions.NewOrderQueryOptions(queryoptions.WithOrderID(order.ID), queryoptions.WithForUpdate[*queryoptions.OrderQueryOptions]())).Return(&order, nil)
 				loggerMock.EXPECT().With(log.String("orderID", order.ID.String())).Return(loggerMock)
 				getProductMock.EXPECT().GetProduct(gomock.Any(), queryoptions.NewProductQueryOptions(queryoptions.WithProductID(product.ID))).Return(&product, nil)
-				getStocksMock.EXPECT().GetStocks(gomock.Any(), queryoptions.NewStockQueryOptions(queryoptions.WithStockProductID(product.ID))).Return(productStocks, nil)
+				getStocksMock.EXPECT().GetStocks(gomock.Any(), queryoptions.NewStockQueryOptions(queryoptions.WithStockProductID(product.ID), queryoptions.WithForUpdate[*queryoptions.StockQueryOptions]())).Return(productStocks, nil)
 				loggerMock.EXPECT().With(log.Uint64("productAvailableQuantity", productStocks.GetAvailableQuantity().Uint64())).Return(loggerMock)
 
 				return entities.ErrNotEnoughProductIntStocks
@@ -389,7 +579,7 @@ func TestUseCase_transaction(t *testing.T) {
 				quantity:    111,
 				userUUID:    id,
 			},
-			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, getOrderMock *getOrderByID.GetOrderMock, getProductMock *getProduct.GetProductMock, getStocksMock *getStocks.GetStocksMock, upsertOrderMock *upsertOrder.UpsertOrderMock, upsertOrderProductMock *upsertOrderProduct.UpsertOrderProductMock) error {
+			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, getOrderMock *getOrderByID.GetOrderMock, getProductMock *getProduct.GetProductMock, getStocksMock *getStocks.GetStocksMock, upsertOrderMock *upsertOrder.UpsertOrderMock, upsertOrderProductMock *upsertOrderProduct.UpsertOrderProductMock, getMovementsMock *getMovements.GetProductMovementsMock, updateStockMock *updateStock.UpdateStockMock, createMovementsMock *createProductMovements.CreateProductMovementsMock) error {
 				t.Helper()
 
 				order := entities.NewOrderUnsafe(
@@ -408,7 +598,7 @@ func TestUseCase_transaction(t *testing.T) {
 				getOrderMock.EXPECT().GetOrder(gomock.Any(), queryoptions.NewOrderQueryOptions(queryoptions.WithOrderID(order.ID), queryoptions.WithForUpdate[*queryoptions.OrderQueryOptions]())).Return(&order, nil)
 				loggerMock.EXPECT().With(log.String("orderID", order.ID.String())).Return(loggerMock)
 				getProductMock.EXPECT().GetProduct(gomock.Any(), queryoptions.NewProductQueryOptions(queryoptions.WithProductID(product.ID))).Return(&product, nil)
-				getStocksMock.EXPECT().GetStocks(gomock.Any(), queryoptions.NewStockQueryOptions(queryoptions.WithStockProductID(product.ID))).Return(nil, assert.AnError)
+				getStocksMock.EXPECT().GetStocks(gomock.Any(), queryoptions.NewStockQueryOptions(queryoptions.WithStockProductID(product.ID), queryoptions.WithForUpdate[*queryoptions.StockQueryOptions]())).Return(nil, assert.AnError)
 
 				return assert.AnError
 			},
@@ -421,7 +611,7 @@ func TestUseCase_transaction(t *testing.T) {
 				quantity:    111,
 				userUUID:    id,
 			},
-			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, getOrderMock *getOrderByID.GetOrderMock, getProductMock *getProduct.GetProductMock, getStocksMock *getStocks.GetStocksMock, upsertOrderMock *upsertOrder.UpsertOrderMock, upsertOrderProductMock *upsertOrderProduct.UpsertOrderProductMock) error {
+			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, getOrderMock *getOrderByID.GetOrderMock, getProductMock *getProduct.GetProductMock, getStocksMock *getStocks.GetStocksMock, upsertOrderMock *upsertOrder.UpsertOrderMock, upsertOrderProductMock *upsertOrderProduct.UpsertOrderProductMock, getMovementsMock *getMovements.GetProductMovementsMock, updateStockMock *updateStock.UpdateStockMock, createMovementsMock *createProductMovements.CreateProductMovementsMock) error {
 				t.Helper()
 
 				order := entities.NewOrderUnsafe(
@@ -452,7 +642,7 @@ func TestUseCase_transaction(t *testing.T) {
 				quantity:    111,
 				userUUID:    id,
 			},
-			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, getOrderMock *getOrderByID.GetOrderMock, getProductMock *getProduct.GetProductMock, getStocksMock *getStocks.GetStocksMock, upsertOrderMock *upsertOrder.UpsertOrderMock, upsertOrderProductMock *upsertOrderProduct.UpsertOrderProductMock) error {
+			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, getOrderMock *getOrderByID.GetOrderMock, getProductMock *getProduct.GetProductMock, getStocksMock *getStocks.GetStocksMock, upsertOrderMock *upsertOrder.UpsertOrderMock, upsertOrderProductMock *upsertOrderProduct.UpsertOrderProductMock, getMovementsMock *getMovements.GetProductMovementsMock, updateStockMock *updateStock.UpdateStockMock, createMovementsMock *createProductMovements.CreateProductMovementsMock) error {
 				t.Helper()
 
 				order := entities.NewOrderUnsafe(
@@ -475,7 +665,7 @@ func TestUseCase_transaction(t *testing.T) {
 				quantity:    111,
 				userUUID:    id,
 			},
-			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, getOrderMock *getOrderByID.GetOrderMock, getProductMock *getProduct.GetProductMock, getStocksMock *getStocks.GetStocksMock, upsertOrderMock *upsertOrder.UpsertOrderMock, upsertOrderProductMock *upsertOrderProduct.UpsertOrderProductMock) error {
+			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, getOrderMock *getOrderByID.GetOrderMock, getProductMock *getProduct.GetProductMock, getStocksMock *getStocks.GetStocksMock, upsertOrderMock *upsertOrder.UpsertOrderMock, upsertOrderProductMock *upsertOrderProduct.UpsertOrderProductMock, getMovementsMock *getMovements.GetProductMovementsMock, updateStockMock *updateStock.UpdateStockMock, createMovementsMock *createProductMovements.CreateProductMovementsMock) error {
 				t.Helper()
 
 				order := entities.NewOrderUnsafe(
@@ -489,6 +679,28 @@ func TestUseCase_transaction(t *testing.T) {
 				return assert.AnError
 			},
 		},
+		{
+			name: "customer cannot change foreign order",
+			in: testRequest{
+				orderUUID:   id,
+				productUUID: id,
+				quantity:    1,
+				userUUID:    baseUUID.New(),
+			},
+			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, getOrderMock *getOrderByID.GetOrderMock, getProductMock *getProduct.GetProductMock, getStocksMock *getStocks.GetStocksMock, upsertOrderMock *upsertOrder.UpsertOrderMock, upsertOrderProductMock *upsertOrderProduct.UpsertOrderProductMock, getMovementsMock *getMovements.GetProductMovementsMock, updateStockMock *updateStock.UpdateStockMock, createMovementsMock *createProductMovements.CreateProductMovementsMock) error {
+				t.Helper()
+
+				order := entities.NewOrderUnsafe(
+					vObject.NewUserIDFromUUIDUnsafe(in.GetOrderID()),
+					entities.WithUUIDFunc[*entities.Order](uuidFunc),
+					entities.WithNowFunc[*entities.Order](nowFunc),
+				)
+
+				getOrderMock.EXPECT().GetOrder(gomock.Any(), queryoptions.NewOrderQueryOptions(queryoptions.WithOrderID(order.ID), queryoptions.WithForUpdate[*queryoptions.OrderQueryOptions]())).Return(&order, nil)
+
+				return entities.ErrOrderRecNotFound
+			},
+		},
 	}
 
 	for _, tc := range tcs {
@@ -504,6 +716,10 @@ func TestUseCase_transaction(t *testing.T) {
 			getStocksMock := getStocks.NewGetStocksMock(ctrl)
 			upsertOrderMock := upsertOrder.NewUpsertOrderMock(ctrl)
 			upsertOrderProductMock := upsertOrderProduct.NewUpsertOrderProductMock(ctrl)
+			getMovementsMock := getMovements.NewGetProductMovementsMock(ctrl)
+			updateStockMock := updateStock.NewUpdateStockMock(ctrl)
+			createMovementsMock := createProductMovements.NewCreateProductMovementsMock(ctrl)
+			historyMock := createOrderStatusHistory.NewCreateOrderStatusHistoryMock(ctrl)
 
 			cfgs := []usecase.Configuration[*UseCase]{
 				usecase.WithTransactionManager[*UseCase](txManagerMock),
@@ -515,16 +731,24 @@ func TestUseCase_transaction(t *testing.T) {
 				WithGetStocksQuery(getStocks.NewQueryHandler(getStocksMock)),
 				WithUpsertOrderCommand(upsertOrder.NewCommandHandler(upsertOrderMock)),
 				WithUpsertOrderProductCommand(upsertOrderProduct.NewCommandHandler(upsertOrderProductMock)),
+				WithGetProductMovementsQuery(getMovements.NewQueryHandler(getMovementsMock)),
+				WithUpdateStockCommand(updateStock.NewCommandHandler(updateStockMock)),
+				WithCreateProductMovementsCommand(createProductMovements.NewCommandHandler(createMovementsMock)),
+				WithCreateOrderStatusHistoryCommand(createOrderStatusHistory.NewCommandHandler(historyMock)),
+				WithGetUserQuery(getUser.NewQueryHandler(getUser.NewGetUserByIDMock(ctrl))),
 			}
 
 			uc, err := NewUseCase(cfgs...)
 			require.NoError(t, err)
 
-			expErr := tc.exp(t, tc.in, loggerMock, getOrderMock, getProductMock, getStocksMock, upsertOrderMock, upsertOrderProductMock)
+			expErr := tc.exp(t, tc.in, loggerMock, getOrderMock, getProductMock, getStocksMock, upsertOrderMock, upsertOrderProductMock, getMovementsMock, updateStockMock, createMovementsMock)
 
-			errFunc := uc.transaction(loggerMock, tc.in)
+			// заказ изменяет покупатель из запроса
+			actor := &entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(tc.in.GetUserID()), Role: vObject.RoleCustomer}
+			order, err := uc.addProduct(context.Background(), loggerMock, actor, tc.in)
 
-			assert.ErrorIs(t, errFunc(context.Background()), expErr)
+			assert.ErrorIs(t, err, expErr)
+			assert.Equal(t, expErr == nil, order != nil)
 		})
 	}
 }
@@ -535,7 +759,7 @@ func TestUseCase_getOrder(t *testing.T) {
 	type testCase struct {
 		name string
 		in   testRequest
-		exp  func(t *testing.T, in testRequest, getOrderMock *getOrderByID.GetOrderMock, upsertOrderMock *upsertOrder.UpsertOrderMock) (*entities.Order, error)
+		exp  func(t *testing.T, in testRequest, getOrderMock *getOrderByID.GetOrderMock, upsertOrderMock *upsertOrder.UpsertOrderMock, historyMock *createOrderStatusHistory.CreateOrderStatusHistoryMock) (*entities.Order, error)
 	}
 
 	tn := time.Now()
@@ -554,7 +778,7 @@ func TestUseCase_getOrder(t *testing.T) {
 			in: testRequest{
 				orderUUID: id,
 			},
-			exp: func(t *testing.T, in testRequest, getOrderMock *getOrderByID.GetOrderMock, upsertOrderMock *upsertOrder.UpsertOrderMock) (*entities.Order, error) {
+			exp: func(t *testing.T, in testRequest, getOrderMock *getOrderByID.GetOrderMock, upsertOrderMock *upsertOrder.UpsertOrderMock, historyMock *createOrderStatusHistory.CreateOrderStatusHistoryMock) (*entities.Order, error) {
 				t.Helper()
 
 				order := entities.NewOrderUnsafe(
@@ -574,7 +798,7 @@ func TestUseCase_getOrder(t *testing.T) {
 				orderUUID: baseUUID.Nil,
 				userUUID:  baseUUID.New(),
 			},
-			exp: func(t *testing.T, in testRequest, getOrderMock *getOrderByID.GetOrderMock, upsertOrderMock *upsertOrder.UpsertOrderMock) (*entities.Order, error) {
+			exp: func(t *testing.T, in testRequest, getOrderMock *getOrderByID.GetOrderMock, upsertOrderMock *upsertOrder.UpsertOrderMock, historyMock *createOrderStatusHistory.CreateOrderStatusHistoryMock) (*entities.Order, error) {
 				t.Helper()
 
 				order := entities.NewOrderUnsafe(
@@ -584,6 +808,14 @@ func TestUseCase_getOrder(t *testing.T) {
 				)
 
 				upsertOrderMock.EXPECT().UpsertOrder(gomock.Any(), &order).Return(nil)
+				historyMock.EXPECT().CreateOrderStatusHistory(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, history *entities.OrderStatusHistory) error {
+					assert.Equal(t, order.ID, history.OrderID)
+					assert.Empty(t, history.From)
+					assert.Equal(t, vObject.OrderStatusCreated, history.To)
+					assert.Equal(t, &order.UserID, history.ActorID)
+
+					return nil
+				})
 
 				return &order, nil
 			},
@@ -594,7 +826,7 @@ func TestUseCase_getOrder(t *testing.T) {
 				orderUUID: id,
 				userUUID:  baseUUID.New(),
 			},
-			exp: func(t *testing.T, in testRequest, getOrderMock *getOrderByID.GetOrderMock, upsertOrderMock *upsertOrder.UpsertOrderMock) (*entities.Order, error) {
+			exp: func(t *testing.T, in testRequest, getOrderMock *getOrderByID.GetOrderMock, upsertOrderMock *upsertOrder.UpsertOrderMock, historyMock *createOrderStatusHistory.CreateOrderStatusHistoryMock) (*entities.Order, error) {
 				t.Helper()
 
 				order := entities.NewOrderUnsafe(
@@ -614,7 +846,7 @@ func TestUseCase_getOrder(t *testing.T) {
 				orderUUID: id,
 				userUUID:  baseUUID.New(),
 			},
-			exp: func(t *testing.T, in testRequest, getOrderMock *getOrderByID.GetOrderMock, upsertOrderMock *upsertOrder.UpsertOrderMock) (*entities.Order, error) {
+			exp: func(t *testing.T, in testRequest, getOrderMock *getOrderByID.GetOrderMock, upsertOrderMock *upsertOrder.UpsertOrderMock, historyMock *createOrderStatusHistory.CreateOrderStatusHistoryMock) (*entities.Order, error) {
 				t.Helper()
 
 				order := entities.NewOrderUnsafe(
@@ -623,10 +855,10 @@ func TestUseCase_getOrder(t *testing.T) {
 					entities.WithNowFunc[*entities.Order](nowFunc),
 				)
 
+				// указанный заказ не найден: новый заказ не создаётся
 				getOrderMock.EXPECT().GetOrder(gomock.Any(), queryoptions.NewOrderQueryOptions(queryoptions.WithOrderID(order.ID), queryoptions.WithForUpdate[*queryoptions.OrderQueryOptions]())).Return(nil, entities.ErrOrderRecNotFound)
-				upsertOrderMock.EXPECT().UpsertOrder(gomock.Any(), &order).Return(nil)
 
-				return &order, nil
+				return nil, entities.ErrOrderRecNotFound
 			},
 		},
 	}
@@ -644,6 +876,10 @@ func TestUseCase_getOrder(t *testing.T) {
 			getStocksMock := getStocks.NewGetStocksMock(ctrl)
 			upsertOrderMock := upsertOrder.NewUpsertOrderMock(ctrl)
 			upsertOrderProductMock := upsertOrderProduct.NewUpsertOrderProductMock(ctrl)
+			getMovementsMock := getMovements.NewGetProductMovementsMock(ctrl)
+			updateStockMock := updateStock.NewUpdateStockMock(ctrl)
+			createMovementsMock := createProductMovements.NewCreateProductMovementsMock(ctrl)
+			historyMock := createOrderStatusHistory.NewCreateOrderStatusHistoryMock(ctrl)
 
 			cfgs := []usecase.Configuration[*UseCase]{
 				usecase.WithTransactionManager[*UseCase](txManagerMock),
@@ -655,12 +891,17 @@ func TestUseCase_getOrder(t *testing.T) {
 				WithGetStocksQuery(getStocks.NewQueryHandler(getStocksMock)),
 				WithUpsertOrderCommand(upsertOrder.NewCommandHandler(upsertOrderMock)),
 				WithUpsertOrderProductCommand(upsertOrderProduct.NewCommandHandler(upsertOrderProductMock)),
+				WithGetProductMovementsQuery(getMovements.NewQueryHandler(getMovementsMock)),
+				WithUpdateStockCommand(updateStock.NewCommandHandler(updateStockMock)),
+				WithCreateProductMovementsCommand(createProductMovements.NewCommandHandler(createMovementsMock)),
+				WithCreateOrderStatusHistoryCommand(createOrderStatusHistory.NewCommandHandler(historyMock)),
+				WithGetUserQuery(getUser.NewQueryHandler(getUser.NewGetUserByIDMock(ctrl))),
 			}
 
 			uc, err := NewUseCase(cfgs...)
 			require.NoError(t, err)
 
-			expOut, expErr := tc.exp(t, tc.in, getOrderMock, upsertOrderMock)
+			expOut, expErr := tc.exp(t, tc.in, getOrderMock, upsertOrderMock, historyMock)
 
 			out, err := uc.getOrder(context.TODO(), tc.in)
 
@@ -676,7 +917,7 @@ func TestUseCase_createOrder(t *testing.T) {
 	type testCase struct {
 		name string
 		in   testRequest
-		exp  func(t *testing.T, in testRequest, getOrderMock *getOrderByID.GetOrderMock, upsertOrderMock *upsertOrder.UpsertOrderMock) (*entities.Order, error)
+		exp  func(t *testing.T, in testRequest, getOrderMock *getOrderByID.GetOrderMock, upsertOrderMock *upsertOrder.UpsertOrderMock, historyMock *createOrderStatusHistory.CreateOrderStatusHistoryMock) (*entities.Order, error)
 	}
 
 	tn := time.Now()
@@ -696,7 +937,7 @@ func TestUseCase_createOrder(t *testing.T) {
 				orderUUID: baseUUID.Nil,
 				userUUID:  baseUUID.New(),
 			},
-			exp: func(t *testing.T, in testRequest, getOrderMock *getOrderByID.GetOrderMock, upsertOrderMock *upsertOrder.UpsertOrderMock) (*entities.Order, error) {
+			exp: func(t *testing.T, in testRequest, getOrderMock *getOrderByID.GetOrderMock, upsertOrderMock *upsertOrder.UpsertOrderMock, historyMock *createOrderStatusHistory.CreateOrderStatusHistoryMock) (*entities.Order, error) {
 				t.Helper()
 
 				order := entities.NewOrderUnsafe(
@@ -706,6 +947,14 @@ func TestUseCase_createOrder(t *testing.T) {
 				)
 
 				upsertOrderMock.EXPECT().UpsertOrder(gomock.Any(), &order).Return(nil)
+				historyMock.EXPECT().CreateOrderStatusHistory(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, history *entities.OrderStatusHistory) error {
+					assert.Equal(t, order.ID, history.OrderID)
+					assert.Empty(t, history.From)
+					assert.Equal(t, vObject.OrderStatusCreated, history.To)
+					assert.Equal(t, &order.UserID, history.ActorID)
+
+					return nil
+				})
 
 				return &order, nil
 			},
@@ -716,7 +965,7 @@ func TestUseCase_createOrder(t *testing.T) {
 				orderUUID: baseUUID.Nil,
 				userUUID:  baseUUID.New(),
 			},
-			exp: func(t *testing.T, in testRequest, getOrderMock *getOrderByID.GetOrderMock, upsertOrderMock *upsertOrder.UpsertOrderMock) (*entities.Order, error) {
+			exp: func(t *testing.T, in testRequest, getOrderMock *getOrderByID.GetOrderMock, upsertOrderMock *upsertOrder.UpsertOrderMock, historyMock *createOrderStatusHistory.CreateOrderStatusHistoryMock) (*entities.Order, error) {
 				t.Helper()
 
 				order := entities.NewOrderUnsafe(
@@ -730,13 +979,28 @@ func TestUseCase_createOrder(t *testing.T) {
 				return nil, assert.AnError
 			},
 		},
+		{
+			name: "create status history error",
+			in: testRequest{
+				orderUUID: baseUUID.Nil,
+				userUUID:  baseUUID.New(),
+			},
+			exp: func(t *testing.T, in testRequest, getOrderMock *getOrderByID.GetOrderMock, upsertOrderMock *upsertOrder.UpsertOrderMock, historyMock *createOrderStatusHistory.CreateOrderStatusHistoryMock) (*entities.Order, error) {
+				t.Helper()
+
+				upsertOrderMock.EXPECT().UpsertOrder(gomock.Any(), gomock.Any()).Return(nil)
+				historyMock.EXPECT().CreateOrderStatusHistory(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+				return nil, assert.AnError
+			},
+		},
 		{
 			name: "upsert order command error",
 			in: testRequest{
 				orderUUID: baseUUID.Nil,
 				userUUID:  baseUUID.Nil,
 			},
-			exp: func(t *testing.T, in testRequest, getOrderMock *getOrderByID.GetOrderMock, upsertOrderMock *upsertOrder.UpsertOrderMock) (*entities.Order, error) {
+			exp: func(t *testing.T, in testRequest, getOrderMock *getOrderByID.GetOrderMock, upsertOrderMock *upsertOrder.UpsertOrderMock, historyMock *createOrderStatusHistory.CreateOrderStatusHistoryMock) (*entities.Order, error) {
 				t.Helper()
 
 				return nil, vObject.ErrEmptyID
@@ -757,6 +1021,10 @@ func TestUseCase_createOrder(t *testing.T) {
 			getStocksMock := getStocks.NewGetStocksMock(ctrl)
 			upsertOrderMock := upsertOrder.NewUpsertOrderMock(ctrl)
 			upsertOrderProductMock := upsertOrderProduct.NewUpsertOrderProductMock(ctrl)
+			getMovementsMock := getMovements.NewGetProductMovementsMock(ctrl)
+			updateStockMock := updateStock.NewUpdateStockMock(ctrl)
+			createMovementsMock := createProductMovements.NewCreateProductMovementsMock(ctrl)
+			historyMock := createOrderStatusHistory.NewCreateOrderStatusHistoryMock(ctrl)
 
 			cfgs := []usecase.Configuration[*UseCase]{
 				usecase.WithTransactionManager[*UseCase](txManagerMock),
@@ -768,9 +1036,14 @@ func TestUseCase_createOrder(t *testing.T) {
 				WithGetStocksQuery(getStocks.NewQueryHandler(getStocksMock)),
 				WithUpsertOrderCommand(upsertOrder.NewCommandHandler(upsertOrderMock)),
 				WithUpsertOrderProductCommand(upsertOrderProduct.NewCommandHandler(upsertOrderProductMock)),
+				WithGetProductMovementsQuery(getMovements.NewQueryHandler(getMovementsMock)),
+				WithUpdateStockCommand(updateStock.NewCommandHandler(updateStockMock)),
+				WithCreateProductMovementsCommand(createProductMovements.NewCommandHandler(createMovementsMock)),
+				WithCreateOrderStatusHistoryCommand(createOrderStatusHistory.NewCommandHandler(historyMock)),
+				WithGetUserQuery(getUser.NewQueryHandler(getUser.NewGetUserByIDMock(ctrl))),
 			}
 
-			expOut, expErr := tc.exp(t, tc.in, getOrderMock, upsertOrderMock)
+			expOut, expErr := tc.exp(t, tc.in, getOrderMock, upsertOrderMock, historyMock)
 
 			uc, err := NewUseCase(cfgs...)
 			require.NoError(t, err)
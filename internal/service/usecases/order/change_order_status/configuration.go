@@ -0,0 +1,111 @@
+package changeorderstatus
+
+import (
+	"fmt"
+
+	upsertOrder "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order/upsert"
+	createOrderStatusHistory "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order_status_history/create"
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
+	getOrderByID "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_order"
+	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	getMovements "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_movements"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func WithGetOrderQuery(handler *getOrderByID.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getOrderByID")
+		}
+
+		uc.getOrderQuery = handler
+
+		return nil
+	}
+}
+
+func WithGetUserQuery(handler *getUser.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getUser")
+		}
+
+		uc.getUserQuery = handler
+
+		return nil
+	}
+}
+
+func WithUpsertOrderCommand(handler *upsertOrder.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "upsertOrder")
+		}
+
+		uc.upsertOrderCmd = handler
+
+		return nil
+	}
+}
+
+func WithCreateOrderStatusHistoryCommand(handler *createOrderStatusHistory.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "createOrderStatusHistory")
+		}
+
+		uc.createOrderStatusHistoryCmd = handler
+
+		return nil
+	}
+}
+
+func WithGetStocksQuery(handler *getStocks.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getStocks")
+		}
+
+		uc.getStocksQuery = handler
+
+		return nil
+	}
+}
+
+func WithGetProductMovementsQuery(handler *getMovements.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getProductMovements")
+		}
+
+		uc.getMovementsQuery = handler
+
+		return nil
+	}
+}
+
+func WithUpdateStockCommand(handler *updateStock.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "updateStock")
+		}
+
+		uc.updateStockCmd = handler
+
+		return nil
+	}
+}
+
+func WithCreateProductMovementsCommand(handler *createProductMovements.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "createProductMovements")
+		}
+
+		uc.createMovementsCmd = handler
+
+		return nil
+	}
+}
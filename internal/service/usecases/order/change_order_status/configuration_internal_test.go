@@ -0,0 +1,102 @@
+package changeorderstatus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	upsertOrder "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order/upsert"
+	createOrderStatusHistory "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order_status_history/create"
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
+	getOrderByID "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_order"
+	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	getMovements "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_movements"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func TestConfiguration(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	loggerMock := log.NewLogMock(ctrl)
+	txManagerMock := trx.NewTransactionManagerMock(ctrl)
+	nowFunc := now.NewMock(ctrl)
+	getOrderMock := getOrderByID.NewGetOrderMock(ctrl)
+	upsertOrderMock := upsertOrder.NewUpsertOrderMock(ctrl)
+	createHistoryMock := createOrderStatusHistory.NewCreateOrderStatusHistoryMock(ctrl)
+	getUserMock := getUser.NewGetUserByIDMock(ctrl)
+	getStocksMock := getStocks.NewGetStocksMock(ctrl)
+	getMovementsMock := getMovements.NewGetProductMovementsMock(ctrl)
+	updateStockMock := updateStock.NewUpdateStockMock(ctrl)
+	createMovementsMock := createProductMovements.NewCreateProductMovementsMock(ctrl)
+
+	cfgs := []usecase.Configuration[*UseCase]{
+		usecase.WithTransactionManager[*UseCase](txManagerMock),
+		usecase.WithLogger[*UseCase](loggerMock),
+		usecase.WithNowFunc[*UseCase](nowFunc),
+		WithGetOrderQuery(getOrderByID.NewQueryHandler(getOrderMock)),
+		WithUpsertOrderCommand(upsertOrder.NewCommandHandler(upsertOrderMock)),
+		WithCreateOrderStatusHistoryCommand(createOrderStatusHistory.NewCommandHandler(createHistoryMock)),
+		WithGetUserQuery(getUser.NewQueryHandler(getUserMock)),
+		WithGetStocksQuery(getStocks.NewQueryHandler(getStocksMock)),
+		WithGetProductMovementsQuery(getMovements.NewQueryHandler(getMovementsMock)),
+		WithUpdateStockCommand(updateStock.NewCommandHandler(updateStockMock)),
+		WithCreateProductMovementsCommand(createProductMovements.NewCommandHandler(createMovementsMock)),
+	}
+
+	f := WithGetOrderQuery(nil)
+	uc, err := NewUseCase(f)
+	require.Error(t, err)
+	assert.Empty(t, uc)
+
+	f = WithUpsertOrderCommand(nil)
+	uc, err = NewUseCase(f)
+	require.Error(t, err)
+	assert.Empty(t, uc)
+
+	f = WithCreateOrderStatusHistoryCommand(nil)
+	uc, err = NewUseCase(f)
+	require.Error(t, err)
+	assert.Empty(t, uc)
+
+	f = WithGetUserQuery(nil)
+	uc, err = NewUseCase(f)
+	require.Error(t, err)
+	assert.Empty(t, uc)
+
+	f = WithGetStocksQuery(nil)
+	uc, err = NewUseCase(f)
+	require.Error(t, err)
+	assert.Empty(t, uc)
+
+	f = WithGetProductMovementsQuery(nil)
+	uc, err = NewUseCase(f)
+	require.Error(t, err)
+	assert.Empty(t, uc)
+
+	f = WithUpdateStockCommand(nil)
+	uc, err = NewUseCase(f)
+	require.Error(t, err)
+	assert.Empty(t, uc)
+
+	f = WithCreateProductMovementsCommand(nil)
+	uc, err = NewUseCase(f)
+	require.Error(t, err)
+	assert.Empty(t, uc)
+
+	uc, err = NewUseCase(nil)
+	require.ErrorIs(t, err, checker.ErrInitError)
+	require.Empty(t, uc)
+
+	uc, err = NewUseCase(cfgs...)
+	require.NoError(t, err)
+	assert.NotEmpty(t, uc)
+}
@@ -0,0 +1,11 @@
+package changeorderstatus
+
+import "github.com/google/uuid"
+
+type Requestable interface {
+	GetOrderID() uuid.UUID
+	GetStatus() string
+	// GetActorID пользователь, меняющий статус. uuid.Nil, если статус меняет система: её переходы не проверяются по ролям.
+	GetActorID() uuid.UUID
+	GetReason() string
+}
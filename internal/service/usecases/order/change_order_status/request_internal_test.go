@@ -0,0 +1,28 @@
+package changeorderstatus
+
+import "github.com/google/uuid"
+
+type testRequest struct {
+	orderUUID uuid.UUID
+	status    string
+	actorUUID uuid.UUID
+	reason    string
+}
+
+var _ Requestable = (*testRequest)(nil)
+
+func (t testRequest) GetOrderID() uuid.UUID {
+	return t.orderUUID
+}
+
+func (t testRequest) GetStatus() string {
+	return t.status
+}
+
+func (t testRequest) GetActorID() uuid.UUID {
+	return t.actorUUID
+}
+
+func (t testRequest) GetReason() string {
+	return t.reason
+}
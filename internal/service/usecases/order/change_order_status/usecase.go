@@ -0,0 +1,313 @@
+package changeorderstatus
+
+import (
+	"context"
+	"fmt"
+
+	baseUUID "github.com/google/uuid"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	upsertOrder "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order/upsert"
+	createOrderStatusHistory "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order_status_history/create"
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getOrderByID "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_order"
+	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	getMovements "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_movements"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+type UseCase struct {
+	uuid.WithUUIDGenerator
+	now.WithNowGenerator
+	checker.WithCheck
+	tx.WithTransactionManager
+	log.WithLogger
+
+	// Query handlers
+	getOrderQuery     *getOrderByID.QueryHandler
+	getUserQuery      *getUser.QueryHandler
+	getStocksQuery    *getStocks.QueryHandler
+	getMovementsQuery *getMovements.QueryHandler
+
+	// Command handlers
+	upsertOrderCmd              *upsertOrder.CommandHandler
+	createOrderStatusHistoryCmd *createOrderStatusHistory.CommandHandler
+	updateStockCmd              *updateStock.CommandHandler
+	createMovementsCmd          *createProductMovements.CommandHandler
+}
+
+func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
+	uc := &UseCase{}
+
+	// Apply all Configurations passed in
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			return nil, checker.ErrInitError
+		}
+
+		err := cfg(uc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Check(*uc); err != nil {
+		return nil, err
+	}
+
+	return uc, nil
+}
+
+func (uc *UseCase) Run(ctx context.Context, req Requestable) (*entities.Order, error) {
+	ctx, span := tracing.Start(ctx, "usecase.changeOrderStatus")
+	defer span.End()
+
+	l := uc.Logger().With(
+		log.String("orderUUID", req.GetOrderID().String()),
+		log.String("status", req.GetStatus()),
+		log.String("actorUUID", req.GetActorID().String()),
+	)
+
+	l.Debug(ctx, "START usecase")
+
+	status, err := vObject.NewOrderStatus(req.GetStatus())
+	if err != nil {
+		l.Error(ctx, "STOP usecase! vObject.NewOrderStatus error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[changeOrderStatus - vObject.NewOrderStatus error]: %w", err))
+	}
+
+	var order *entities.Order
+
+	err = uc.TransactionDo(ctx, func(ctx context.Context) error {
+		order, err = uc.changeStatus(ctx, req, status)
+
+		return err
+	})
+	if err != nil {
+		l.Error(ctx, "STOP usecase! transaction error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[changeOrderStatus - uc.TransactionDo error]: %w", err))
+	}
+
+	l.Debug(ctx, "END usecase")
+
+	return order, nil
+}
+
+func (uc *UseCase) changeStatus(ctx context.Context, req Requestable, status vObject.OrderStatus) (*entities.Order, error) {
+	// 1. Получаем заказ по ID с блокировкой записи
+	query, err := getOrderByID.NewQueryForUpdate(req.GetOrderID())
+	if err != nil {
+		return nil, fmt.Errorf("[changeOrderStatus - getOrderByID.NewQueryForUpdate error]: %w", err)
+	}
+
+	order, err := uc.getOrderQuery.Handle(ctx, *query)
+	if err != nil {
+		return nil, fmt.Errorf("[changeOrderStatus - uc.getOrderQuery.Handle error]: %w", err)
+	}
+
+	// 2. Проверяем, что пользователь может перевести заказ в новый статус
+	if err = uc.authorize(ctx, req.GetActorID(), order, status); err != nil {
+		return nil, fmt.Errorf("[changeOrderStatus - uc.authorize error]: %w", err)
+	}
+
+	// 3. Переводим заказ в новый статус согласно жизненному циклу заказа
+	order.SetNowGen(uc.GetNowGen())
+	from := order.Status
+
+	if err = order.TransitionTo(status); err != nil {
+		return nil, fmt.Errorf("[changeOrderStatus - order.TransitionTo error]: %w", err)
+	}
+
+	// 4. Сохраняем заказ
+	if err = uc.upsertOrderCmd.Handle(ctx, upsertOrder.NewCommandUnsafe(order)); err != nil {
+		return nil, fmt.Errorf("[changeOrderStatus - uc.upsertOrderCmd.Handle error]: %w", err)
+	}
+
+	// 5. Переносим товар заказа на складах: при отгрузке списываем остаток и резерв, при отмене до отгрузки
+	// снимаем резерв, при возврате или отмене после отгрузки принимаем товар обратно на склады
+	switch {
+	case from.HoldsReserve() && order.Status.IsShipped():
+		if err = uc.ship(ctx, order); err != nil {
+			return nil, fmt.Errorf("[changeOrderStatus - uc.ship error]: %w", err)
+		}
+	case from.HoldsReserve() && !order.Status.HoldsReserve():
+		if err = uc.releaseReserve(ctx, order); err != nil {
+			return nil, fmt.Errorf("[changeOrderStatus - uc.releaseReserve error]: %w", err)
+		}
+	case from.IsShipped() && !order.Status.IsShipped():
+		if err = uc.restock(ctx, order); err != nil {
+			return nil, fmt.Errorf("[changeOrderStatus - uc.restock error]: %w", err)
+		}
+	}
+
+	// 6. Сохраняем запись в истории статусов заказа
+	var actorID *vObject.UserID
+	if req.GetActorID() != baseUUID.Nil {
+		id := vObject.NewUserIDFromUUIDUnsafe(req.GetActorID())
+		actorID = &id
+	}
+
+	history := entities.NewOrderStatusHistoryUnsafe(
+		order.ID,
+		from,
+		order.Status,
+		actorID,
+		vObject.NewOrderStatusReasonUnsafe(req.GetReason()),
+		entities.WithUUIDFunc[*entities.OrderStatusHistory](uc.GetUUIDGen()),
+		entities.WithNowFunc[*entities.OrderStatusHistory](uc.GetNowGen()),
+	)
+	if err = uc.createOrderStatusHistoryCmd.Handle(ctx, createOrderStatusHistory.NewCommandUnsafe(&history)); err != nil {
+		return nil, fmt.Errorf("[changeOrderStatus - uc.createOrderStatusHistoryCmd.Handle error]: %w", err)
+	}
+
+	return order, nil
+}
+
+// ship отгружает товар каждой позиции заказа со складов её распределения: остаток и резерв складов
+// уменьшаются, в журнал сохраняется движение продажи по каждому складу. Остатки блокируются до конца транзакции.
+func (uc *UseCase) ship(ctx context.Context, order *entities.Order) error {
+	for _, orderProduct := range order.Products {
+		if len(orderProduct.Allocations) == 0 {
+			continue
+		}
+
+		productStocks, err := uc.getStocksQuery.Handle(ctx, getStocks.NewQueryByProductIDForUpdateUnsafe(orderProduct.ProductID))
+		if err != nil {
+			return fmt.Errorf("[changeOrderStatus - uc.getStocksQuery.Handle error]: %w", err)
+		}
+
+		movements, err := productStocks.Ship(orderProduct.Allocations, orderProduct.Price, uc.movementOptions(order)...)
+		if err != nil {
+			return fmt.Errorf("[changeOrderStatus - stocks.Ship error]: %w", err)
+		}
+
+		if err = uc.saveMovements(ctx, productStocks, movements); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restock принимает обратно на склады распределения каждой позиции товар отгруженного заказа,
+// который вернул покупатель или который отменён после отгрузки. Документом поступления служит заказ.
+func (uc *UseCase) restock(ctx context.Context, order *entities.Order) error {
+	document := vObject.NewDocumentReferenceUnsafe(order.ID.String())
+
+	for _, orderProduct := range order.Products {
+		if len(orderProduct.Allocations) == 0 {
+			continue
+		}
+
+		productStocks, err := uc.getStocksQuery.Handle(ctx, getStocks.NewQueryByProductIDForUpdateUnsafe(orderProduct.ProductID))
+		if err != nil {
+			return fmt.Errorf("[changeOrderStatus - uc.getStocksQuery.Handle error]: %w", err)
+		}
+
+		movements, err := productStocks.Restock(orderProduct.Allocations, orderProduct.Price, document, uc.movementOptions(order)...)
+		if err != nil {
+			return fmt.Errorf("[changeOrderStatus - stocks.Restock error]: %w", err)
+		}
+
+		if err = uc.saveMovements(ctx, productStocks, movements); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// releaseReserve снимает со складов резерв каждой позиции заказа по движениям резерва заказа.
+// Остатки блокируются до конца транзакции, движения снятия резерва сохраняются в журнал.
+func (uc *UseCase) releaseReserve(ctx context.Context, order *entities.Order) error {
+	opts := uc.movementOptions(order)
+
+	for _, orderProduct := range order.Products {
+		reservations, err := uc.getMovementsQuery.Handle(ctx, getMovements.NewQueryByOrderProductUnsafe(order.ID, orderProduct.ProductID))
+		if err != nil {
+			return fmt.Errorf("[changeOrderStatus - uc.getMovementsQuery.Handle error]: %w", err)
+		}
+
+		reserved := reservations.ReservedByWarehouse()
+
+		var quantity vObject.Quantity
+		for _, q := range reserved {
+			quantity += q
+		}
+
+		if quantity == vObject.QuantityZero {
+			continue
+		}
+
+		productStocks, err := uc.getStocksQuery.Handle(ctx, getStocks.NewQueryByProductIDForUpdateUnsafe(orderProduct.ProductID))
+		if err != nil {
+			return fmt.Errorf("[changeOrderStatus - uc.getStocksQuery.Handle error]: %w", err)
+		}
+
+		movements, err := productStocks.Unreserve(reserved, quantity, orderProduct.Price, opts...)
+		if err != nil {
+			return fmt.Errorf("[changeOrderStatus - stocks.Unreserve error]: %w", err)
+		}
+
+		if err = uc.saveMovements(ctx, productStocks, movements); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// movementOptions параметры движений по складам, создаваемых для заказа.
+func (uc *UseCase) movementOptions(order *entities.Order) []entities.Option[*entities.ProductMovement] {
+	return []entities.Option[*entities.ProductMovement]{
+		entities.WithProductMovementOrderID(order.ID),
+		entities.WithUUIDFunc[*entities.ProductMovement](uc.GetUUIDGen()),
+		entities.WithNowFunc[*entities.ProductMovement](uc.GetNowGen()),
+	}
+}
+
+// saveMovements сохраняет остатки складов, затронутых движениями, и сами движения.
+func (uc *UseCase) saveMovements(ctx context.Context, stocks entities.Stocks, movements entities.ProductMovements) error {
+	for _, movement := range movements {
+		stock := stocks.GetByWarehouseIDUnsafe(movement.WarehouseID)
+		if err := uc.updateStockCmd.Handle(ctx, updateStock.NewCommandUnsafe(stock)); err != nil {
+			return fmt.Errorf("[changeOrderStatus - uc.updateStockCmd.Handle error]: %w", err)
+		}
+	}
+
+	if err := uc.createMovementsCmd.Handle(ctx, createProductMovements.NewCommandUnsafe(movements)); err != nil {
+		return fmt.Errorf("[changeOrderStatus - uc.createMovementsCmd.Handle error]: %w", err)
+	}
+
+	return nil
+}
+
+// authorize проверяет право пользователя actorID на переход заказа в статус status. Переходы,
+// выполняемые системой (actorID == uuid.Nil), не ограничиваются.
+func (uc *UseCase) authorize(ctx context.Context, actorID baseUUID.UUID, order *entities.Order, status vObject.OrderStatus) error {
+	if actorID == baseUUID.Nil {
+		return nil
+	}
+
+	actor, err := usecase.Actor(ctx, uc.getUserQuery, actorID)
+	if err != nil {
+		return err
+	}
+
+	if !actor.CanChangeOrderStatus(order, status) {
+		return fmt.Errorf("%w: order status %s", entities.ErrPermissionDenied, status)
+	}
+
+	return nil
+}
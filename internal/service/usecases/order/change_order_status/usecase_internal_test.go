@@ -0,0 +1,551 @@
+package changeorderstatus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	upsertOrder "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order/upsert"
+	createOrderStatusHistory "github.com/smgladkovskiy/warehouse-task/internal/service/commands/order_status_history/create"
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryoptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getOrderByID "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_order"
+	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	getMovements "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_movements"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+type mocks struct {
+	getOrder        *getOrderByID.GetOrderMock
+	upsertOrder     *upsertOrder.UpsertOrderMock
+	createHistory   *createOrderStatusHistory.CreateOrderStatusHistoryMock
+	getStocks       *getStocks.GetStocksMock
+	getMovements    *getMovements.GetProductMovementsMock
+	updateStock     *updateStock.UpdateStockMock
+	createMovements *createProductMovements.CreateProductMovementsMock
+}
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		in   testRequest
+		exp  func(t *testing.T, in testRequest, m mocks) (*entities.Order, error)
+	}
+
+	created := time.Now().Add(-time.Hour)
+	tn := time.Now()
+	id := baseUUID.New()
+	historyID := baseUUID.New()
+	actorID := vObject.NewUserIDFromUUIDUnsafe(id)
+	operator := entities.User{ID: actorID, Role: vObject.RoleOperator}
+	customer := entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), Role: vObject.RoleCustomer}
+
+	newOrder := func(status vObject.OrderStatus) entities.Order {
+		return entities.Order{
+			ID:        vObject.NewOrderIDFromUUIDUnsafe(id),
+			UserID:    vObject.NewUserIDFromUUIDUnsafe(id),
+			Status:    status,
+			CreatedAt: created,
+			UpdatedAt: created,
+		}
+	}
+
+	productID := vObject.NewProductIDFromUUIDUnsafe(baseUUID.New())
+	firstWarehouse := vObject.NewWarehouseIDFromUUIDUnsafe(baseUUID.New())
+	secondWarehouse := vObject.NewWarehouseIDFromUUIDUnsafe(baseUUID.New())
+
+	// заказ с позицией из 5 единиц, зарезервированных на двух складах
+	newReservedOrder := func(status vObject.OrderStatus) entities.Order {
+		order := newOrder(status)
+		order.Products = entities.OrderProducts{{
+			OrderID:   order.ID,
+			ProductID: productID,
+			Quantity:  vObject.NewQuantityUnsafe(5),
+			Price:     vObject.NewPriceUnsafe(1000),
+		}}
+
+		return order
+	}
+	// заказ, позиция которого распределена на два склада: 3 единицы с первого и 2 со второго
+	newAllocatedOrder := func(status vObject.OrderStatus) entities.Order {
+		order := newReservedOrder(status)
+		order.Products[0].Allocations = entities.OrderProductAllocations{
+			{WarehouseID: firstWarehouse, Quantity: 3},
+			{WarehouseID: secondWarehouse, Quantity: 2},
+		}
+
+		return order
+	}
+	reservations := entities.ProductMovements{
+		{WarehouseID: firstWarehouse, OperationType: vObject.OperationTypeReserve, Quantity: 4},
+		{WarehouseID: secondWarehouse, OperationType: vObject.OperationTypeReserve, Quantity: 2},
+		{WarehouseID: firstWarehouse, OperationType: vObject.OperationTypeUnreserve, Quantity: 1},
+	}
+	productStocks := func() entities.Stocks {
+		return entities.Stocks{
+			{ProductID: productID, WarehouseID: firstWarehouse, AvailableQuantity: 10, ReservedQuantity: 7},
+			{ProductID: productID, WarehouseID: secondWarehouse, AvailableQuantity: 10, ReservedQuantity: 2},
+		}
+	}
+	movementsQos := queryoptions.NewProductMovementQueryOptions(
+		queryoptions.WithMovementProductID(productID),
+		queryoptions.WithMovementOrderID(vObject.NewOrderIDFromUUIDUnsafe(id)),
+	)
+	stocksQos := queryoptions.NewStockQueryOptions(
+		queryoptions.WithStockProductID(productID),
+		queryoptions.WithForUpdate[*queryoptions.StockQueryOptions](),
+	)
+
+	getOrderQos := queryoptions.NewOrderQueryOptions(
+		queryoptions.WithOrderID(vObject.NewOrderIDFromUUIDUnsafe(id)),
+		queryoptions.WithForUpdate[*queryoptions.OrderQueryOptions](),
+	)
+
+	tcs := []testCase{
+		{
+			name: "happy path",
+			in:   testRequest{orderUUID: id, status: "paid", actorUUID: id, reason: "payment received"},
+			exp: func(t *testing.T, in testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				order := newOrder(vObject.OrderStatusCreated)
+
+				m.getOrder.EXPECT().GetOrder(gomock.Any(), getOrderQos).Return(&order, nil)
+				m.upsertOrder.EXPECT().UpsertOrder(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, o *entities.Order) error {
+					assert.Equal(t, vObject.OrderStatusPaid, o.Status)
+					assert.Equal(t, tn, o.UpdatedAt)
+
+					return nil
+				})
+				m.createHistory.EXPECT().CreateOrderStatusHistory(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, h *entities.OrderStatusHistory) error {
+					assert.Equal(t, vObject.NewOrderStatusHistoryIDFromUUIDUnsafe(historyID), h.ID)
+					assert.Equal(t, order.ID, h.OrderID)
+					assert.Equal(t, vObject.OrderStatusCreated, h.From)
+					assert.Equal(t, vObject.OrderStatusPaid, h.To)
+					assert.Equal(t, &actorID, h.ActorID)
+					assert.Equal(t, vObject.NewOrderStatusReasonUnsafe("payment received"), h.Reason)
+					assert.Equal(t, tn, h.CreatedAt)
+
+					return nil
+				})
+
+				return &order, nil
+			},
+		},
+		{
+			name: "system change without actor",
+			in:   testRequest{orderUUID: id, status: "canceled"},
+			exp: func(t *testing.T, in testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				order := newOrder(vObject.OrderStatusCreated)
+
+				m.getOrder.EXPECT().GetOrder(gomock.Any(), getOrderQos).Return(&order, nil)
+				m.upsertOrder.EXPECT().UpsertOrder(gomock.Any(), gomock.Any()).Return(nil)
+				m.createHistory.EXPECT().CreateOrderStatusHistory(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, h *entities.OrderStatusHistory) error {
+					assert.Nil(t, h.ActorID)
+
+					return nil
+				})
+
+				return &order, nil
+			},
+		},
+		{
+			name: "customer cancels own order",
+			in:   testRequest{orderUUID: id, status: "canceled", actorUUID: customer.ID.UUID()},
+			exp: func(t *testing.T, in testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				order := newOrder(vObject.OrderStatusCreated)
+				order.UserID = customer.ID
+
+				m.getOrder.EXPECT().GetOrder(gomock.Any(), getOrderQos).Return(&order, nil)
+				m.upsertOrder.EXPECT().UpsertOrder(gomock.Any(), gomock.Any()).Return(nil)
+				m.createHistory.EXPECT().CreateOrderStatusHistory(gomock.Any(), gomock.Any()).Return(nil)
+
+				return &order, nil
+			},
+		},
+		{
+			name: "cancel releases reserve",
+			in:   testRequest{orderUUID: id, status: "canceled", actorUUID: id},
+			exp: func(t *testing.T, in testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				order := newReservedOrder(vObject.OrderStatusPaid)
+
+				m.getOrder.EXPECT().GetOrder(gomock.Any(), getOrderQos).Return(&order, nil)
+				m.upsertOrder.EXPECT().UpsertOrder(gomock.Any(), gomock.Any()).Return(nil)
+				m.getMovements.EXPECT().GetProductMovements(gomock.Any(), movementsQos).Return(reservations, nil)
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(productStocks(), nil)
+
+				released := make(map[vObject.WarehouseID]vObject.Quantity)
+				m.updateStock.EXPECT().UpdateStock(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(_ context.Context, stock *entities.Stock) error {
+					released[stock.WarehouseID] = stock.ReservedQuantity
+
+					return nil
+				})
+				m.createMovements.EXPECT().CreateProductMovements(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, movements entities.ProductMovements) error {
+					require.Len(t, movements, 2)
+
+					byWarehouse := make(map[vObject.WarehouseID]vObject.Quantity)
+					for _, movement := range movements {
+						assert.Equal(t, vObject.OperationTypeUnreserve, movement.OperationType)
+						assert.Equal(t, &order.ID, movement.OrderID)
+						assert.Equal(t, vObject.NewPriceUnsafe(1000), movement.Price)
+						byWarehouse[movement.WarehouseID] = movement.Quantity
+					}
+
+					assert.Equal(t, map[vObject.WarehouseID]vObject.Quantity{firstWarehouse: 3, secondWarehouse: 2}, byWarehouse)
+
+					return nil
+				})
+				m.createHistory.EXPECT().CreateOrderStatusHistory(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *entities.OrderStatusHistory) error {
+					// резерв заказа снят, чужой резерв на первом складе остался
+					assert.Equal(t, map[vObject.WarehouseID]vObject.Quantity{firstWarehouse: 4, secondWarehouse: 0}, released)
+
+					return nil
+				})
+
+				return &order, nil
+			},
+		},
+		{
+			name: "ship writes sale movements",
+			in:   testRequest{orderUUID: id, status: "shipped", actorUUID: id},
+			exp: func(t *testing.T, in testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				order := newAllocatedOrder(vObject.OrderStatusOrdered)
+
+				m.getOrder.EXPECT().GetOrder(gomock.Any(), getOrderQos).Return(&order, nil)
+				m.upsertOrder.EXPECT().UpsertOrder(gomock.Any(), gomock.Any()).Return(nil)
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(productStocks(), nil)
+
+				shipped := make(map[vObject.WarehouseID][2]vObject.Quantity)
+				m.updateStock.EXPECT().UpdateStock(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(_ context.Context, stock *entities.Stock) error {
+					shipped[stock.WarehouseID] = [2]vObject.Quantity{stock.AvailableQuantity, stock.ReservedQuantity}
+
+					return nil
+				})
+				m.createMovements.EXPECT().CreateProductMovements(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, movements entities.ProductMovements) error {
+					require.Len(t, movements, 2)
+
+					byWarehouse := make(map[vObject.WarehouseID]vObject.Quantity)
+					for _, movement := range movements {
+						assert.Equal(t, vObject.OperationTypeSale, movement.OperationType)
+						assert.Equal(t, &order.ID, movement.OrderID)
+						assert.Equal(t, vObject.NewPriceUnsafe(1000), movement.Price)
+						byWarehouse[movement.WarehouseID] = movement.Quantity
+					}
+
+					assert.Equal(t, map[vObject.WarehouseID]vObject.Quantity{firstWarehouse: 3, secondWarehouse: 2}, byWarehouse)
+
+					return nil
+				})
+				m.createHistory.EXPECT().CreateOrderStatusHistory(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *entities.OrderStatusHistory) error {
+					// остаток и резерв уменьшены на отгруженное количество, чужой резерв на первом складе остался
+					assert.Equal(t, map[vObject.WarehouseID][2]vObject.Quantity{
+						firstWarehouse:  {7, 4},
+						secondWarehouse: {8, 0},
+					}, shipped)
+
+					return nil
+				})
+
+				return &order, nil
+			},
+		},
+		{
+			name: "ship without enough reserve",
+			in:   testRequest{orderUUID: id, status: "shipped", actorUUID: id},
+			exp: func(t *testing.T, in testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				order := newAllocatedOrder(vObject.OrderStatusOrdered)
+				stocks := productStocks()
+				stocks[1].ReservedQuantity = 1
+
+				m.getOrder.EXPECT().GetOrder(gomock.Any(), getOrderQos).Return(&order, nil)
+				m.upsertOrder.EXPECT().UpsertOrder(gomock.Any(), gomock.Any()).Return(nil)
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(stocks, nil)
+
+				return nil, entities.ErrNotEnoughReservedProduct
+			},
+		},
+		{
+			name: "return restocks shipped goods",
+			in:   testRequest{orderUUID: id, status: "returned", actorUUID: id},
+			exp: func(t *testing.T, in testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				order := newAllocatedOrder(vObject.OrderStatusShipped)
+
+				m.getOrder.EXPECT().GetOrder(gomock.Any(), getOrderQos).Return(&order, nil)
+				m.upsertOrder.EXPECT().UpsertOrder(gomock.Any(), gomock.Any()).Return(nil)
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(productStocks(), nil)
+
+				restocked := make(map[vObject.WarehouseID][2]vObject.Quantity)
+				m.updateStock.EXPECT().UpdateStock(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(_ context.Context, stock *entities.Stock) error {
+					restocked[stock.WarehouseID] = [2]vObject.Quantity{stock.AvailableQuantity, stock.ReservedQuantity}
+
+					return nil
+				})
+				m.createMovements.EXPECT().CreateProductMovements(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, movements entities.ProductMovements) error {
+					require.Len(t, movements, 2)
+
+					byWarehouse := make(map[vObject.WarehouseID]vObject.Quantity)
+					for _, movement := range movements {
+						assert.Equal(t, vObject.OperationTypeIncome, movement.OperationType)
+						reason, document := vObject.MovementReasonCustomerReturn, vObject.NewDocumentReferenceUnsafe(order.ID.String())
+						assert.Equal(t, &reason, movement.Reason)
+						assert.Equal(t, &document, movement.DocumentRef)
+						assert.Equal(t, &order.ID, movement.OrderID)
+						byWarehouse[movement.WarehouseID] = movement.Quantity
+					}
+
+					assert.Equal(t, map[vObject.WarehouseID]vObject.Quantity{firstWarehouse: 3, secondWarehouse: 2}, byWarehouse)
+
+					return nil
+				})
+				m.createHistory.EXPECT().CreateOrderStatusHistory(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *entities.OrderStatusHistory) error {
+					// товар вернулся в остаток, резерв складов не изменился
+					assert.Equal(t, map[vObject.WarehouseID][2]vObject.Quantity{
+						firstWarehouse:  {13, 7},
+						secondWarehouse: {12, 2},
+					}, restocked)
+
+					return nil
+				})
+
+				return &order, nil
+			},
+		},
+		{
+			name: "paid order keeps reserve",
+			in:   testRequest{orderUUID: id, status: "paid", actorUUID: id},
+			exp: func(t *testing.T, in testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				order := newReservedOrder(vObject.OrderStatusCreated)
+
+				m.getOrder.EXPECT().GetOrder(gomock.Any(), getOrderQos).Return(&order, nil)
+				m.upsertOrder.EXPECT().UpsertOrder(gomock.Any(), gomock.Any()).Return(nil)
+				m.createHistory.EXPECT().CreateOrderStatusHistory(gomock.Any(), gomock.Any()).Return(nil)
+
+				return &order, nil
+			},
+		},
+		{
+			name: "release reserve stock update error",
+			in:   testRequest{orderUUID: id, status: "canceled", actorUUID: id},
+			exp: func(t *testing.T, in testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				order := newReservedOrder(vObject.OrderStatusCreated)
+
+				m.getOrder.EXPECT().GetOrder(gomock.Any(), getOrderQos).Return(&order, nil)
+				m.upsertOrder.EXPECT().UpsertOrder(gomock.Any(), gomock.Any()).Return(nil)
+				m.getMovements.EXPECT().GetProductMovements(gomock.Any(), movementsQos).Return(reservations, nil)
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(productStocks(), nil)
+				m.updateStock.EXPECT().UpdateStock(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+				return nil, assert.AnError
+			},
+		},
+		{
+			name: "customer cannot pay own order",
+			in:   testRequest{orderUUID: id, status: "paid", actorUUID: customer.ID.UUID()},
+			exp: func(t *testing.T, in testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				order := newOrder(vObject.OrderStatusCreated)
+				order.UserID = customer.ID
+
+				m.getOrder.EXPECT().GetOrder(gomock.Any(), getOrderQos).Return(&order, nil)
+
+				return nil, entities.ErrPermissionDenied
+			},
+		},
+		{
+			name: "customer cannot cancel foreign order",
+			in:   testRequest{orderUUID: id, status: "canceled", actorUUID: customer.ID.UUID()},
+			exp: func(t *testing.T, in testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				order := newOrder(vObject.OrderStatusCreated)
+
+				m.getOrder.EXPECT().GetOrder(gomock.Any(), getOrderQos).Return(&order, nil)
+
+				return nil, entities.ErrPermissionDenied
+			},
+		},
+		{
+			name: "unknown actor",
+			in:   testRequest{orderUUID: id, status: "canceled", actorUUID: baseUUID.New()},
+			exp: func(t *testing.T, in testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				order := newOrder(vObject.OrderStatusCreated)
+
+				m.getOrder.EXPECT().GetOrder(gomock.Any(), getOrderQos).Return(&order, nil)
+
+				return nil, entities.ErrPermissionDenied
+			},
+		},
+		{
+			name: "history create error",
+			in:   testRequest{orderUUID: id, status: "canceled"},
+			exp: func(t *testing.T, in testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				order := newOrder(vObject.OrderStatusPaid)
+
+				m.getOrder.EXPECT().GetOrder(gomock.Any(), getOrderQos).Return(&order, nil)
+				m.upsertOrder.EXPECT().UpsertOrder(gomock.Any(), gomock.Any()).Return(nil)
+				m.createHistory.EXPECT().CreateOrderStatusHistory(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+				return nil, assert.AnError
+			},
+		},
+		{
+			name: "order upsert error",
+			in:   testRequest{orderUUID: id, status: "canceled"},
+			exp: func(t *testing.T, in testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				order := newOrder(vObject.OrderStatusPaid)
+
+				m.getOrder.EXPECT().GetOrder(gomock.Any(), getOrderQos).Return(&order, nil)
+				m.upsertOrder.EXPECT().UpsertOrder(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+				return nil, assert.AnError
+			},
+		},
+		{
+			name: "transition is not allowed",
+			in:   testRequest{orderUUID: id, status: "shipped"},
+			exp: func(t *testing.T, in testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				order := newOrder(vObject.OrderStatusCreated)
+
+				m.getOrder.EXPECT().GetOrder(gomock.Any(), getOrderQos).Return(&order, nil)
+
+				return nil, entities.ErrOrderStatusTransition
+			},
+		},
+		{
+			name: "get order error",
+			in:   testRequest{orderUUID: id, status: "paid"},
+			exp: func(t *testing.T, in testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				m.getOrder.EXPECT().GetOrder(gomock.Any(), getOrderQos).Return(nil, entities.ErrOrderRecNotFound)
+
+				return nil, entities.ErrOrderRecNotFound
+			},
+		},
+		{
+			name: "empty order id",
+			in:   testRequest{orderUUID: baseUUID.Nil, status: "paid"},
+			exp: func(t *testing.T, in testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				return nil, vObject.ErrEmptyID
+			},
+		},
+		{
+			name: "unknown status",
+			in:   testRequest{orderUUID: id, status: "lost"},
+			exp: func(t *testing.T, in testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				return nil, vObject.ErrUnknownOrderStatus
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			loggerMock := log.NewLogMock(ctrl)
+			txManagerMock := trx.NewTransactionManagerMock(ctrl)
+			nowFunc := now.NewMock(ctrl)
+			m := mocks{
+				getOrder:        getOrderByID.NewGetOrderMock(ctrl),
+				upsertOrder:     upsertOrder.NewUpsertOrderMock(ctrl),
+				createHistory:   createOrderStatusHistory.NewCreateOrderStatusHistoryMock(ctrl),
+				getStocks:       getStocks.NewGetStocksMock(ctrl),
+				getMovements:    getMovements.NewGetProductMovementsMock(ctrl),
+				updateStock:     updateStock.NewUpdateStockMock(ctrl),
+				createMovements: createProductMovements.NewCreateProductMovementsMock(ctrl),
+			}
+			uuidFunc := uuid.NewMock(ctrl)
+			getUserMock := getUser.NewGetUserByIDMock(ctrl)
+
+			nowFunc.EXPECT().Now().AnyTimes().Return(tn)
+			getUserMock.EXPECT().GetByID(gomock.Any(), queryoptions.NewUserQueryOptions(queryoptions.WithUserID(operator.ID))).AnyTimes().Return(&operator, nil)
+			getUserMock.EXPECT().GetByID(gomock.Any(), queryoptions.NewUserQueryOptions(queryoptions.WithUserID(customer.ID))).AnyTimes().Return(&customer, nil)
+			getUserMock.EXPECT().GetByID(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, entities.ErrUserRecNotFound)
+			uuidFunc.EXPECT().UUID().AnyTimes().Return(historyID)
+			txManagerMock.EXPECT().Do(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				},
+			)
+
+			uc, err := NewUseCase(
+				usecase.WithTransactionManager[*UseCase](txManagerMock),
+				usecase.WithLogger[*UseCase](loggerMock),
+				usecase.WithNowFunc[*UseCase](nowFunc),
+				usecase.WithUUIDFunc[*UseCase](uuidFunc),
+				WithGetOrderQuery(getOrderByID.NewQueryHandler(m.getOrder)),
+				WithGetUserQuery(getUser.NewQueryHandler(getUserMock)),
+				WithUpsertOrderCommand(upsertOrder.NewCommandHandler(m.upsertOrder)),
+				WithCreateOrderStatusHistoryCommand(createOrderStatusHistory.NewCommandHandler(m.createHistory)),
+				WithGetStocksQuery(getStocks.NewQueryHandler(m.getStocks)),
+				WithGetProductMovementsQuery(getMovements.NewQueryHandler(m.getMovements)),
+				WithUpdateStockCommand(updateStock.NewCommandHandler(m.updateStock)),
+				WithCreateProductMovementsCommand(createProductMovements.NewCommandHandler(m.createMovements)),
+			)
+			require.NoError(t, err)
+
+			loggerMock.EXPECT().With(
+				log.String("orderUUID", tc.in.GetOrderID().String()),
+				log.String("status", tc.in.GetStatus()),
+				log.String("actorUUID", tc.in.GetActorID().String()),
+			).Return(loggerMock)
+			loggerMock.EXPECT().Debug(gomock.Any(), "START usecase")
+
+			expOrder, expErr := tc.exp(t, tc.in, m)
+			if expErr == nil {
+				loggerMock.EXPECT().Debug(gomock.Any(), "END usecase")
+			} else {
+				loggerMock.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+			}
+
+			order, err := uc.Run(context.Background(), tc.in)
+			require.ErrorIs(t, err, expErr)
+			assert.Equal(t, expOrder, order)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package orderdetails
+
+import (
+	"fmt"
+
+	getOrder "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_order"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func WithGetUserQuery(handler *getUser.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getUser")
+		}
+
+		uc.getUserQuery = handler
+
+		return nil
+	}
+}
+
+func WithGetOrderQuery(handler *getOrder.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getOrder")
+		}
+
+		uc.getOrderQuery = handler
+
+		return nil
+	}
+}
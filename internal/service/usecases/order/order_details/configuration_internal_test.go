@@ -0,0 +1,52 @@
+package orderdetails
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	getOrder "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_order"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func TestConfiguration(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	loggerMock := log.NewLogMock(ctrl)
+	getUserMock := getUser.NewGetUserByIDMock(ctrl)
+	getOrderMock := getOrder.NewGetOrderMock(ctrl)
+
+	cfgs := []usecase.Configuration[*UseCase]{
+		usecase.WithLogger[*UseCase](loggerMock),
+		WithGetUserQuery(getUser.NewQueryHandler(getUserMock)),
+		WithGetOrderQuery(getOrder.NewQueryHandler(getOrderMock)),
+	}
+
+	f := WithGetUserQuery(nil)
+	uc, err := NewUseCase(f)
+	require.ErrorIs(t, err, usecase.ErrEmptyStructParam)
+	assert.Empty(t, uc)
+
+	f = WithGetOrderQuery(nil)
+	uc, err = NewUseCase(f)
+	require.ErrorIs(t, err, usecase.ErrEmptyStructParam)
+	assert.Empty(t, uc)
+
+	uc, err = NewUseCase(nil)
+	require.ErrorIs(t, err, checker.ErrInitError)
+	require.Empty(t, uc)
+
+	uc, err = NewUseCase(cfgs[:len(cfgs)-1]...)
+	require.ErrorIs(t, err, checker.ErrInitError)
+	require.Empty(t, uc)
+
+	uc, err = NewUseCase(cfgs...)
+	require.NoError(t, err)
+	assert.NotEmpty(t, uc)
+}
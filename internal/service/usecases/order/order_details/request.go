@@ -0,0 +1,9 @@
+package orderdetails
+
+import "github.com/google/uuid"
+
+type Requestable interface {
+	// GetActorID пользователь, просматривающий заказ.
+	GetActorID() uuid.UUID
+	GetOrderID() uuid.UUID
+}
@@ -0,0 +1,18 @@
+package orderdetails
+
+import "github.com/google/uuid"
+
+type testRequest struct {
+	actorUUID uuid.UUID
+	orderUUID uuid.UUID
+}
+
+var _ Requestable = (*testRequest)(nil)
+
+func (t testRequest) GetActorID() uuid.UUID {
+	return t.actorUUID
+}
+
+func (t testRequest) GetOrderID() uuid.UUID {
+	return t.orderUUID
+}
@@ -0,0 +1,94 @@
+package orderdetails
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	getOrder "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_order"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+// UseCase возвращает заказ пользователю, которому разрешено его просматривать. Покупатель видит только
+// свои заказы; чужой заказ для него не существует, чтобы по ответу нельзя было подобрать идентификаторы заказов.
+type UseCase struct {
+	checker.WithCheck
+	log.WithLogger
+
+	// Query handlers
+	getUserQuery  *getUser.QueryHandler
+	getOrderQuery *getOrder.QueryHandler
+}
+
+func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
+	uc := &UseCase{}
+
+	// Apply all Configurations passed in
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			return nil, checker.ErrInitError
+		}
+
+		err := cfg(uc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Check(*uc); err != nil {
+		return nil, err
+	}
+
+	return uc, nil
+}
+
+// Run читает заказ из синхронной реплики, чтобы заказ, созданный предыдущим запросом, был уже виден.
+func (uc *UseCase) Run(ctx context.Context, req Requestable) (*entities.Order, error) {
+	ctx, span := tracing.Start(ctx, "usecase.orderDetails")
+	defer span.End()
+
+	l := uc.Logger().With(
+		log.String("actorUUID", req.GetActorID().String()),
+		log.String("orderUUID", req.GetOrderID().String()),
+	)
+
+	l.Debug(ctx, "START usecase")
+
+	query, err := getOrder.NewQueryFromSync(req.GetOrderID())
+	if err != nil {
+		l.Error(ctx, "STOP usecase! getOrder.NewQueryFromSync error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[orderDetails - getOrder.NewQueryFromSync error]: %w", err))
+	}
+
+	// 1. Получаем пользователя, просматривающего заказ
+	actor, err := usecase.Actor(ctx, uc.getUserQuery, req.GetActorID())
+	if err != nil {
+		l.Error(ctx, "STOP usecase! usecase.Actor error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[orderDetails - usecase.Actor error]: %w", err))
+	}
+
+	// 2. Получаем заказ
+	order, err := uc.getOrderQuery.Handle(ctx, *query)
+	if err != nil {
+		l.Error(ctx, "STOP usecase! uc.getOrderQuery.Handle error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[orderDetails - uc.getOrderQuery.Handle error]: %w", err))
+	}
+
+	// 3. Проверяем, что пользователь может смотреть заказ: чужой заказ не раскрывается
+	if !actor.CanViewOrdersOf(order.UserID) {
+		l.Warn(ctx, "STOP usecase! order of another user")
+
+		return nil, tracing.Error(span, fmt.Errorf("[orderDetails]: %w", entities.ErrOrderRecNotFound))
+	}
+
+	l.Debug(ctx, "END usecase")
+
+	return order, nil
+}
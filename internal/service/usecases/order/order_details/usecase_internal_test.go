@@ -0,0 +1,162 @@
+package orderdetails
+
+import (
+	"context"
+	"testing"
+
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getOrder "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_order"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+type mocks struct {
+	logger   *log.LogMock
+	getUser  *getUser.GetUserByIDMock
+	getOrder *getOrder.GetOrderMock
+}
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		in   testRequest
+		exp  func(t *testing.T, in testRequest, m mocks) (*entities.Order, error)
+	}
+
+	customer := &entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), Role: vObject.RoleCustomer}
+	other := &entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), Role: vObject.RoleCustomer}
+	operator := &entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), Role: vObject.RoleOperator}
+	order := &entities.Order{
+		ID:     vObject.NewOrderIDFromUUIDUnsafe(baseUUID.New()),
+		UserID: customer.ID,
+		Status: vObject.OrderStatusCreated,
+	}
+
+	actorQos := func(id vObject.UserID) *queryOptions.UserQueryOptions {
+		return queryOptions.NewUserQueryOptions(queryOptions.WithUserID(id))
+	}
+	orderQos := queryOptions.NewOrderQueryOptions(
+		queryOptions.WithOrderID(order.ID),
+		queryOptions.WithFromSync[*queryOptions.OrderQueryOptions](),
+	)
+
+	tcs := []testCase{
+		{
+			name: "customer own order",
+			in:   testRequest{actorUUID: customer.ID.UUID(), orderUUID: order.ID.UUID()},
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(customer.ID)).Return(customer, nil)
+				m.getOrder.EXPECT().GetOrder(gomock.Any(), orderQos).Return(order, nil)
+
+				return order, nil
+			},
+		},
+		{
+			name: "operator views customer order",
+			in:   testRequest{actorUUID: operator.ID.UUID(), orderUUID: order.ID.UUID()},
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(operator.ID)).Return(operator, nil)
+				m.getOrder.EXPECT().GetOrder(gomock.Any(), orderQos).Return(order, nil)
+
+				return order, nil
+			},
+		},
+		{
+			name: "order of another customer is not found",
+			in:   testRequest{actorUUID: other.ID.UUID(), orderUUID: order.ID.UUID()},
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(other.ID)).Return(other, nil)
+				m.getOrder.EXPECT().GetOrder(gomock.Any(), orderQos).Return(order, nil)
+				m.logger.EXPECT().Warn(gomock.Any(), "STOP usecase! order of another user")
+
+				return nil, entities.ErrOrderRecNotFound
+			},
+		},
+		{
+			name: "anonymous actor",
+			in:   testRequest{orderUUID: order.ID.UUID()},
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! usecase.Actor error", gomock.Any())
+
+				return nil, entities.ErrPermissionDenied
+			},
+		},
+		{
+			name: "empty order id",
+			in:   testRequest{actorUUID: customer.ID.UUID()},
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! getOrder.NewQueryFromSync error", gomock.Any())
+
+				return nil, vObject.ErrEmptyID
+			},
+		},
+		{
+			name: "order not found",
+			in:   testRequest{actorUUID: customer.ID.UUID(), orderUUID: order.ID.UUID()},
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Order, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(customer.ID)).Return(customer, nil)
+				m.getOrder.EXPECT().GetOrder(gomock.Any(), orderQos).Return(nil, entities.ErrOrderRecNotFound)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! uc.getOrderQuery.Handle error", gomock.Any())
+
+				return nil, entities.ErrOrderRecNotFound
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := mocks{
+				logger:   log.NewLogMock(ctrl),
+				getUser:  getUser.NewGetUserByIDMock(ctrl),
+				getOrder: getOrder.NewGetOrderMock(ctrl),
+			}
+
+			uc, err := NewUseCase(
+				usecase.WithLogger[*UseCase](m.logger),
+				WithGetUserQuery(getUser.NewQueryHandler(m.getUser)),
+				WithGetOrderQuery(getOrder.NewQueryHandler(m.getOrder)),
+			)
+			require.NoError(t, err)
+
+			m.logger.EXPECT().With(
+				log.String("actorUUID", tc.in.GetActorID().String()),
+				log.String("orderUUID", tc.in.GetOrderID().String()),
+			).Return(m.logger)
+			m.logger.EXPECT().Debug(gomock.Any(), "START usecase")
+
+			expOrder, expErr := tc.exp(t, tc.in, m)
+			if expErr == nil {
+				m.logger.EXPECT().Debug(gomock.Any(), "END usecase")
+			}
+
+			got, err := uc.Run(context.Background(), tc.in)
+			require.ErrorIs(t, err, expErr)
+			assert.Equal(t, expOrder, got)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package orderhistory
+
+import (
+	"fmt"
+
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	listOrders "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/list_orders"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func WithGetUserQuery(handler *getUser.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getUser")
+		}
+
+		uc.getUserQuery = handler
+
+		return nil
+	}
+}
+
+func WithListOrdersQuery(handler *listOrders.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "listOrders")
+		}
+
+		uc.listOrdersQuery = handler
+
+		return nil
+	}
+}
+
+// WithRules задаёт правила, из которых берётся размер страницы по умолчанию.
+func WithRules(rules vObject.Rules) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		uc.rules = rules
+
+		return nil
+	}
+}
@@ -0,0 +1,48 @@
+package orderhistory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	listOrders "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/list_orders"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func TestConfiguration(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	loggerMock := log.NewLogMock(ctrl)
+	getUserMock := getUser.NewGetUserByIDMock(ctrl)
+	listOrdersMock := listOrders.NewListOrdersMock(ctrl)
+
+	cfgs := []usecase.Configuration[*UseCase]{
+		usecase.WithLogger[*UseCase](loggerMock),
+		WithGetUserQuery(getUser.NewQueryHandler(getUserMock)),
+		WithListOrdersQuery(listOrders.NewQueryHandler(listOrdersMock)),
+	}
+
+	f := WithGetUserQuery(nil)
+	uc, err := NewUseCase(f)
+	require.ErrorIs(t, err, usecase.ErrEmptyStructParam)
+	assert.Empty(t, uc)
+
+	f = WithListOrdersQuery(nil)
+	uc, err = NewUseCase(f)
+	require.ErrorIs(t, err, usecase.ErrEmptyStructParam)
+	assert.Empty(t, uc)
+
+	uc, err = NewUseCase(nil)
+	require.ErrorIs(t, err, checker.ErrInitError)
+	require.Empty(t, uc)
+
+	uc, err = NewUseCase(cfgs...)
+	require.NoError(t, err)
+	assert.NotEmpty(t, uc)
+}
@@ -0,0 +1,27 @@
+package orderhistory
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Requestable параметры страницы истории заказов. Пустые фильтры не ограничивают выборку.
+type Requestable interface {
+	// GetActorID пользователь, просматривающий заказы.
+	GetActorID() uuid.UUID
+	// GetUserID владелец заказов.
+	GetUserID() uuid.UUID
+	GetStatuses() []string
+	// GetCreatedFrom и GetCreatedTo период создания заказа: начало включительно, конец не включая.
+	GetCreatedFrom() *time.Time
+	GetCreatedTo() *time.Time
+	// GetSort newest, oldest, total_asc или total_desc.
+	GetSort() string
+	// GetWithProducts загрузить позиции заказов вместе с товарами.
+	GetWithProducts() bool
+	GetPage() int
+	GetPerPage() int
+	// GetCursor курсор из Meta предыдущего ответа вместо номера страницы, nil — навигация по GetPage.
+	GetCursor() *string
+}
@@ -0,0 +1,62 @@
+package orderhistory
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type testRequest struct {
+	actorUUID    uuid.UUID
+	userUUID     uuid.UUID
+	statuses     []string
+	createdFrom  *time.Time
+	createdTo    *time.Time
+	sort         string
+	withProducts bool
+	page         int
+	perPage      int
+	cursor       *string
+}
+
+var _ Requestable = (*testRequest)(nil)
+
+func (t testRequest) GetActorID() uuid.UUID {
+	return t.actorUUID
+}
+
+func (t testRequest) GetUserID() uuid.UUID {
+	return t.userUUID
+}
+
+func (t testRequest) GetStatuses() []string {
+	return t.statuses
+}
+
+func (t testRequest) GetCreatedFrom() *time.Time {
+	return t.createdFrom
+}
+
+func (t testRequest) GetCreatedTo() *time.Time {
+	return t.createdTo
+}
+
+func (t testRequest) GetSort() string {
+	return t.sort
+}
+
+func (t testRequest) GetWithProducts() bool {
+	return t.withProducts
+}
+
+func (t testRequest) GetPage() int {
+	return t.page
+}
+
+func (t testRequest) GetPerPage() int {
+	return t.perPage
+}
+
+func (t testRequest) GetCursor() *string {
+	return t.cursor
+}
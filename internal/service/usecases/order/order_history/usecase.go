@@ -0,0 +1,116 @@
+package orderhistory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	listOrders "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/list_orders"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+// UseCase возвращает страницу истории заказов пользователя. Покупатель видит только свои заказы,
+// заказы других пользователей доступны с разрешением на заказы любых пользователей.
+type UseCase struct {
+	checker.WithCheck
+	log.WithLogger
+
+	// Query handlers
+	getUserQuery    *getUser.QueryHandler
+	listOrdersQuery *listOrders.QueryHandler
+
+	// rules задают размер страницы, если он не указан в запросе
+	rules vObject.Rules
+}
+
+func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
+	uc := &UseCase{rules: vObject.DefaultRules()}
+
+	// Apply all Configurations passed in
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			return nil, checker.ErrInitError
+		}
+
+		err := cfg(uc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Check(*uc); err != nil {
+		return nil, err
+	}
+
+	return uc, nil
+}
+
+func (uc *UseCase) Run(ctx context.Context, req Requestable) (entities.Orders, *vObject.Meta, error) {
+	ctx, span := tracing.Start(ctx, "usecase.orderHistory")
+	defer span.End()
+
+	l := uc.Logger().With(
+		log.String("actorUUID", req.GetActorID().String()),
+		log.String("userUUID", req.GetUserID().String()),
+		log.String("statuses", strings.Join(req.GetStatuses(), ",")),
+		log.String("sort", req.GetSort()),
+		log.Int("page", req.GetPage()),
+	)
+
+	l.Debug(ctx, "START usecase")
+
+	perPage := req.GetPerPage()
+	if perPage == 0 {
+		perPage = uc.rules.DefaultPerPage
+	}
+
+	// 1. Проверяем фильтры
+	query, err := listOrders.NewQuery(listOrders.Filter{
+		UserID:       req.GetUserID(),
+		Statuses:     req.GetStatuses(),
+		CreatedFrom:  req.GetCreatedFrom(),
+		CreatedTo:    req.GetCreatedTo(),
+		Sort:         req.GetSort(),
+		WithProducts: req.GetWithProducts(),
+		Page:         req.GetPage(),
+		PerPage:      perPage,
+		Cursor:       req.GetCursor(),
+	})
+	if err != nil {
+		l.Error(ctx, "STOP usecase! listOrders.NewQuery error", log.Err(err))
+
+		return nil, nil, tracing.Error(span, fmt.Errorf("[orderHistory - listOrders.NewQuery error]: %w", err))
+	}
+
+	// 2. Проверяем, что пользователь может смотреть заказы владельца
+	actor, err := usecase.Actor(ctx, uc.getUserQuery, req.GetActorID())
+	if err != nil {
+		l.Error(ctx, "STOP usecase! usecase.Actor error", log.Err(err))
+
+		return nil, nil, tracing.Error(span, fmt.Errorf("[orderHistory - usecase.Actor error]: %w", err))
+	}
+
+	if !actor.CanViewOrdersOf(vObject.NewUserIDFromUUIDUnsafe(req.GetUserID())) {
+		l.Error(ctx, "STOP usecase! permission denied")
+
+		return nil, nil, tracing.Error(span, fmt.Errorf("[orderHistory]: %w: orders of user %s", entities.ErrPermissionDenied, req.GetUserID()))
+	}
+
+	// 3. Получаем страницу заказов
+	orders, meta, err := uc.listOrdersQuery.Handle(ctx, *query)
+	if err != nil {
+		l.Error(ctx, "STOP usecase! uc.listOrdersQuery.Handle error", log.Err(err))
+
+		return nil, nil, tracing.Error(span, fmt.Errorf("[orderHistory - uc.listOrdersQuery.Handle error]: %w", err))
+	}
+
+	l.Debug(ctx, "END usecase")
+
+	return orders, meta, nil
+}
@@ -0,0 +1,261 @@
+package orderhistory
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	listOrders "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/list_orders"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+type mocks struct {
+	logger     *log.LogMock
+	getUser    *getUser.GetUserByIDMock
+	listOrders *listOrders.ListOrdersMock
+}
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		in   testRequest
+		exp  func(t *testing.T, in testRequest, m mocks) (entities.Orders, *vObject.Meta, error)
+	}
+
+	tn := time.Now().UTC().Truncate(time.Second)
+	from := tn.Add(-24 * time.Hour)
+	customer := &entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), Role: vObject.RoleCustomer}
+	operator := &entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), Role: vObject.RoleOperator}
+	orders := entities.Orders{{
+		ID:        vObject.NewOrderIDFromUUIDUnsafe(baseUUID.New()),
+		UserID:    customer.ID,
+		Status:    vObject.OrderStatusPaid,
+		CreatedAt: tn,
+	}}
+
+	firstPage := ""
+
+	actorQos := func(id vObject.UserID) *queryOptions.UserQueryOptions {
+		return queryOptions.NewUserQueryOptions(queryOptions.WithUserID(id))
+	}
+
+	tcs := []testCase{
+		{
+			name: "customer own orders",
+			in: testRequest{
+				actorUUID:    customer.ID.UUID(),
+				userUUID:     customer.ID.UUID(),
+				statuses:     []string{"paid", "shipped"},
+				createdFrom:  &from,
+				createdTo:    &tn,
+				sort:         "oldest",
+				withProducts: true,
+				page:         2,
+				perPage:      5,
+			},
+			exp: func(t *testing.T, _ testRequest, m mocks) (entities.Orders, *vObject.Meta, error) {
+				t.Helper()
+
+				qos := queryOptions.NewOrderQueryOptions(
+					queryOptions.WithOrderUserID(customer.ID),
+					queryOptions.WithOrderSort(vObject.OrderSortOldest),
+					queryOptions.WithOrderStatuses(vObject.OrderStatusPaid, vObject.OrderStatusShipped),
+					queryOptions.WithOrderCreatedFrom(from),
+					queryOptions.WithOrderCreatedTo(tn),
+					queryOptions.WithOrderProducts(),
+					queryOptions.WithMetaPage[*queryOptions.OrderQueryOptions](2),
+					queryOptions.WithMetaPerPage[*queryOptions.OrderQueryOptions](5),
+				)
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(customer.ID)).Return(customer, nil)
+				m.listOrders.EXPECT().ListOrders(gomock.Any(), qos).
+					DoAndReturn(func(_ context.Context, qos queryOptions.OrderQueryOptionable) (entities.Orders, error) {
+						qos.WithMetaTotal(6)
+
+						return orders, nil
+					})
+
+				return orders, &vObject.Meta{Total: 6, PerPage: 5, Page: 2, LastPage: 2}, nil
+			},
+		},
+		{
+			name: "first cursor page",
+			in:   testRequest{actorUUID: customer.ID.UUID(), userUUID: customer.ID.UUID(), cursor: &firstPage},
+			exp: func(t *testing.T, _ testRequest, m mocks) (entities.Orders, *vObject.Meta, error) {
+				t.Helper()
+
+				qos := queryOptions.NewOrderQueryOptions(
+					queryOptions.WithOrderUserID(customer.ID),
+					queryOptions.WithOrderSort(vObject.OrderSortNewest),
+					queryOptions.WithMetaCursor[*queryOptions.OrderQueryOptions](vObject.Cursor{Direction: vObject.CursorNext}),
+				)
+				next := vObject.NewNextCursor(orders[0].ID.UUID())
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(customer.ID)).Return(customer, nil)
+				m.listOrders.EXPECT().ListOrders(gomock.Any(), qos).
+					DoAndReturn(func(_ context.Context, qos queryOptions.OrderQueryOptionable) (entities.Orders, error) {
+						qos.WithMetaCursors(nil, &next)
+
+						return orders, nil
+					})
+
+				return orders, &vObject.Meta{PerPage: vObject.DefaultPerPage, NextCursor: next.Token()}, nil
+			},
+		},
+		{
+			name: "operator views customer orders",
+			in:   testRequest{actorUUID: operator.ID.UUID(), userUUID: customer.ID.UUID()},
+			exp: func(t *testing.T, _ testRequest, m mocks) (entities.Orders, *vObject.Meta, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(operator.ID)).Return(operator, nil)
+				m.listOrders.EXPECT().ListOrders(gomock.Any(), gomock.Any()).Return(entities.Orders{}, nil)
+
+				return entities.Orders{}, &vObject.Meta{Total: 0, PerPage: vObject.DefaultPerPage, Page: 1, LastPage: 0}, nil
+			},
+		},
+		{
+			name: "customer cannot view foreign orders",
+			in:   testRequest{actorUUID: customer.ID.UUID(), userUUID: operator.ID.UUID()},
+			exp: func(t *testing.T, _ testRequest, m mocks) (entities.Orders, *vObject.Meta, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(customer.ID)).Return(customer, nil)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! permission denied")
+
+				return nil, nil, entities.ErrPermissionDenied
+			},
+		},
+		{
+			name: "anonymous actor",
+			in:   testRequest{userUUID: customer.ID.UUID()},
+			exp: func(t *testing.T, _ testRequest, m mocks) (entities.Orders, *vObject.Meta, error) {
+				t.Helper()
+
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! usecase.Actor error", gomock.Any())
+
+				return nil, nil, entities.ErrPermissionDenied
+			},
+		},
+		{
+			name: "unknown status",
+			in:   testRequest{actorUUID: customer.ID.UUID(), userUUID: customer.ID.UUID(), statuses: []string{"lost"}},
+			exp: func(t *testing.T, _ testRequest, m mocks) (entities.Orders, *vObject.Meta, error) {
+				t.Helper()
+
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! listOrders.NewQuery error", gomock.Any())
+
+				return nil, nil, vObject.ErrUnknownOrderStatus
+			},
+		},
+		{
+			name: "invalid created range",
+			in:   testRequest{actorUUID: customer.ID.UUID(), userUUID: customer.ID.UUID(), createdFrom: &tn, createdTo: &from},
+			exp: func(t *testing.T, _ testRequest, m mocks) (entities.Orders, *vObject.Meta, error) {
+				t.Helper()
+
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! listOrders.NewQuery error", gomock.Any())
+
+				return nil, nil, listOrders.ErrInvalidCreatedRange
+			},
+		},
+		{
+			name: "page too large",
+			in:   testRequest{actorUUID: customer.ID.UUID(), userUUID: customer.ID.UUID(), perPage: vObject.MaxPerPage + 1},
+			exp: func(t *testing.T, _ testRequest, m mocks) (entities.Orders, *vObject.Meta, error) {
+				t.Helper()
+
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! listOrders.NewQuery error", gomock.Any())
+
+				return nil, nil, vObject.ErrInvalidMetaPerPage
+			},
+		},
+		{
+			name: "cursor with page",
+			in:   testRequest{actorUUID: customer.ID.UUID(), userUUID: customer.ID.UUID(), page: 2, cursor: &firstPage},
+			exp: func(t *testing.T, _ testRequest, m mocks) (entities.Orders, *vObject.Meta, error) {
+				t.Helper()
+
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! listOrders.NewQuery error", gomock.Any())
+
+				return nil, nil, vObject.ErrCursorWithPage
+			},
+		},
+		{
+			name: "cursor with total sort",
+			in:   testRequest{actorUUID: customer.ID.UUID(), userUUID: customer.ID.UUID(), sort: "total_asc", cursor: &firstPage},
+			exp: func(t *testing.T, _ testRequest, m mocks) (entities.Orders, *vObject.Meta, error) {
+				t.Helper()
+
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! listOrders.NewQuery error", gomock.Any())
+
+				return nil, nil, vObject.ErrCursorSort
+			},
+		},
+		{
+			name: "list orders error",
+			in:   testRequest{actorUUID: customer.ID.UUID(), userUUID: customer.ID.UUID()},
+			exp: func(t *testing.T, _ testRequest, m mocks) (entities.Orders, *vObject.Meta, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(customer.ID)).Return(customer, nil)
+				m.listOrders.EXPECT().ListOrders(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! uc.listOrdersQuery.Handle error", gomock.Any())
+
+				return nil, nil, assert.AnError
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := mocks{
+				logger:     log.NewLogMock(ctrl),
+				getUser:    getUser.NewGetUserByIDMock(ctrl),
+				listOrders: listOrders.NewListOrdersMock(ctrl),
+			}
+
+			uc, err := NewUseCase(
+				usecase.WithLogger[*UseCase](m.logger),
+				WithGetUserQuery(getUser.NewQueryHandler(m.getUser)),
+				WithListOrdersQuery(listOrders.NewQueryHandler(m.listOrders)),
+			)
+			require.NoError(t, err)
+
+			m.logger.EXPECT().With(
+				log.String("actorUUID", tc.in.GetActorID().String()),
+				log.String("userUUID", tc.in.GetUserID().String()),
+				log.String("statuses", strings.Join(tc.in.GetStatuses(), ",")),
+				log.String("sort", tc.in.GetSort()),
+				log.Int("page", tc.in.GetPage()),
+			).Return(m.logger)
+			m.logger.EXPECT().Debug(gomock.Any(), "START usecase")
+
+			expOrders, expMeta, expErr := tc.exp(t, tc.in, m)
+			if expErr == nil {
+				m.logger.EXPECT().Debug(gomock.Any(), "END usecase")
+			}
+
+			orders, meta, err := uc.Run(context.Background(), tc.in)
+			require.ErrorIs(t, err, expErr)
+			assert.Equal(t, expOrders, orders)
+			assert.Equal(t, expMeta, meta)
+		})
+	}
+}
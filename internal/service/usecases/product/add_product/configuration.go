@@ -0,0 +1,46 @@
+package addproduct
+
+import (
+	"fmt"
+
+	createProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product/create"
+	createProductPrice "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_price/create"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func WithGetUserQuery(handler *getUser.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getUser")
+		}
+
+		uc.getUserQuery = handler
+
+		return nil
+	}
+}
+
+func WithCreateProductCommand(handler *createProduct.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "createProduct")
+		}
+
+		uc.createProductCmd = handler
+
+		return nil
+	}
+}
+
+func WithCreateProductPriceCommand(handler *createProductPrice.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "createProductPrice")
+		}
+
+		uc.createProductPriceCmd = handler
+
+		return nil
+	}
+}
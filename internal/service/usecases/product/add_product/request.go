@@ -0,0 +1,13 @@
+package addproduct
+
+import "github.com/google/uuid"
+
+type Requestable interface {
+	// GetActorID пользователь, добавляющий товар в каталог.
+	GetActorID() uuid.UUID
+	GetTitle() string
+	GetDescription() string
+	GetTags() []string
+	// GetPrice цена товара в копейках.
+	GetPrice() int64
+}
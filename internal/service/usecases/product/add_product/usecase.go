@@ -0,0 +1,125 @@
+package addproduct
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	createProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product/create"
+	createProductPrice "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_price/create"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+// UseCase добавляет товар в каталог и открывает историю его цен. Доступен пользователям с разрешением на ведение каталога.
+type UseCase struct {
+	uuid.WithUUIDGenerator
+	now.WithNowGenerator
+	checker.WithCheck
+	tx.WithTransactionManager
+	log.WithLogger
+
+	// Query handlers
+	getUserQuery *getUser.QueryHandler
+
+	// Command handlers
+	createProductCmd      *createProduct.CommandHandler
+	createProductPriceCmd *createProductPrice.CommandHandler
+}
+
+func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
+	uc := &UseCase{}
+
+	// Apply all Configurations passed in
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			return nil, checker.ErrInitError
+		}
+
+		err := cfg(uc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Check(*uc); err != nil {
+		return nil, err
+	}
+
+	return uc, nil
+}
+
+func (uc *UseCase) Run(ctx context.Context, req Requestable) (*entities.Product, error) {
+	ctx, span := tracing.Start(ctx, "usecase.addProduct")
+	defer span.End()
+
+	l := uc.Logger().With(
+		log.String("actorUUID", req.GetActorID().String()),
+		log.String("title", req.GetTitle()),
+		log.String("tags", strings.Join(req.GetTags(), ",")),
+		log.Int64("price", req.GetPrice()),
+	)
+
+	l.Debug(ctx, "START usecase")
+
+	actor, err := usecase.Authorize(ctx, uc.getUserQuery, req.GetActorID(), vObject.PermissionManageProducts)
+	if err != nil {
+		l.Error(ctx, "STOP usecase! usecase.Authorize error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[addProduct - usecase.Authorize error]: %w", err))
+	}
+
+	cmd, err := createProduct.NewCommand(
+		req.GetTitle(),
+		req.GetDescription(),
+		req.GetTags(),
+		req.GetPrice(),
+		entities.WithUUIDFunc[*entities.Product](uc.GetUUIDGen()),
+		entities.WithNowFunc[*entities.Product](uc.GetNowGen()),
+	)
+	if err != nil {
+		l.Error(ctx, "STOP usecase! createProduct.NewCommand error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[addProduct - createProduct.NewCommand error]: %w", err))
+	}
+
+	err = uc.TransactionDo(ctx, func(ctx context.Context) error {
+		// 1. Сохраняем товар
+		if err = uc.createProductCmd.Handle(ctx, *cmd); err != nil {
+			return fmt.Errorf("[addProduct - uc.createProductCmd.Handle error]: %w", err)
+		}
+
+		// 2. Начальная цена товара открывает историю цен
+		product := cmd.GetProduct()
+		price := entities.NewProductPriceUnsafe(
+			product.ID,
+			product.Price,
+			&actor.ID,
+			entities.WithUUIDFunc[*entities.ProductPrice](uc.GetUUIDGen()),
+			entities.WithNowFunc[*entities.ProductPrice](uc.GetNowGen()),
+		)
+
+		if err = uc.createProductPriceCmd.Handle(ctx, createProductPrice.NewCommandUnsafe(&price)); err != nil {
+			return fmt.Errorf("[addProduct - uc.createProductPriceCmd.Handle error]: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		l.Error(ctx, "STOP usecase! transaction error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[addProduct - uc.TransactionDo error]: %w", err))
+	}
+
+	l.Debug(ctx, "END usecase")
+
+	return cmd.GetProduct(), nil
+}
@@ -0,0 +1,242 @@
+package addproduct
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	createProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product/create"
+	createProductPrice "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_price/create"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+type mocks struct {
+	logger        *log.LogMock
+	getUser       *getUser.GetUserByIDMock
+	createProduct *createProduct.CreateProductMock
+	createPrice   *createProductPrice.CreateProductPriceMock
+}
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		in   testRequest
+		exp  func(t *testing.T, in testRequest, m mocks) (*entities.Product, error)
+	}
+
+	tn := time.Now().UTC().Truncate(time.Second)
+	id := baseUUID.New()
+	operator := &entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), Role: vObject.RoleOperator}
+	customer := &entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), Role: vObject.RoleCustomer}
+
+	actorQos := func(id vObject.UserID) *queryOptions.UserQueryOptions {
+		return queryOptions.NewUserQueryOptions(queryOptions.WithUserID(id))
+	}
+	request := testRequest{
+		actorUUID:   operator.ID.UUID(),
+		title:       " Молоко ",
+		description: "Пастеризованное",
+		tags:        []string{"Молочное", "напитки"},
+		price:       8900,
+	}
+	product := &entities.Product{
+		ID:          vObject.NewProductIDFromUUIDUnsafe(id),
+		Title:       vObject.NewProductTitleUnsafe("Молоко"),
+		Description: vObject.NewProductDescriptionUnsafe("Пастеризованное"),
+		Tags:        vObject.Tags{"молочное", "напитки"},
+		Price:       vObject.NewPriceUnsafe(8900),
+		CreatedAt:   tn,
+		UpdatedAt:   tn,
+	}
+
+	tcs := []testCase{
+		{
+			name: "happy path",
+			in:   request,
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Product, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(operator.ID)).Return(operator, nil)
+				m.createProduct.EXPECT().CreateProduct(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, p *entities.Product) error {
+					assert.Equal(t, product.ID, p.ID)
+					assert.Equal(t, product.Title, p.Title)
+
+					return nil
+				})
+				m.createPrice.EXPECT().CreateProductPrice(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, p *entities.ProductPrice) error {
+					assert.Equal(t, product.ID, p.ProductID)
+					assert.Equal(t, product.Price, p.Price)
+					assert.Equal(t, tn, p.EffectiveFrom)
+					assert.Equal(t, &operator.ID, p.ActorID)
+
+					return nil
+				})
+
+				return product, nil
+			},
+		},
+		{
+			name: "create product error",
+			in:   request,
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Product, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(operator.ID)).Return(operator, nil)
+				m.createProduct.EXPECT().CreateProduct(gomock.Any(), gomock.Any()).Return(assert.AnError)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+
+				return nil, assert.AnError
+			},
+		},
+		{
+			name: "create product price error",
+			in:   request,
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Product, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(operator.ID)).Return(operator, nil)
+				m.createProduct.EXPECT().CreateProduct(gomock.Any(), gomock.Any()).Return(nil)
+				m.createPrice.EXPECT().CreateProductPrice(gomock.Any(), gomock.Any()).Return(assert.AnError)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+
+				return nil, assert.AnError
+			},
+		},
+		{
+			name: "empty title",
+			in:   testRequest{actorUUID: operator.ID.UUID(), title: " ", price: 8900},
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Product, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(operator.ID)).Return(operator, nil)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! createProduct.NewCommand error", gomock.Any())
+
+				return nil, vObject.ErrEmptyProductTitle
+			},
+		},
+		{
+			name: "too long description",
+			in:   testRequest{actorUUID: operator.ID.UUID(), title: "Молоко", description: strings.Repeat("я", 5000)},
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Product, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(operator.ID)).Return(operator, nil)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! createProduct.NewCommand error", gomock.Any())
+
+				return nil, vObject.ErrProductDescriptionTooLong
+			},
+		},
+		{
+			name: "negative price",
+			in:   testRequest{actorUUID: operator.ID.UUID(), title: "Молоко", price: -1},
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Product, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(operator.ID)).Return(operator, nil)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! createProduct.NewCommand error", gomock.Any())
+
+				return nil, vObject.ErrNegativePrice
+			},
+		},
+		{
+			name: "customer cannot manage products",
+			in:   testRequest{actorUUID: customer.ID.UUID(), title: "Молоко", price: 8900},
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Product, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(customer.ID)).Return(customer, nil)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! usecase.Authorize error", gomock.Any())
+
+				return nil, entities.ErrPermissionDenied
+			},
+		},
+		{
+			name: "anonymous actor",
+			in:   testRequest{title: "Молоко", price: 8900},
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Product, error) {
+				t.Helper()
+
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! usecase.Authorize error", gomock.Any())
+
+				return nil, entities.ErrPermissionDenied
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := mocks{
+				logger:        log.NewLogMock(ctrl),
+				getUser:       getUser.NewGetUserByIDMock(ctrl),
+				createProduct: createProduct.NewCreateProductMock(ctrl),
+				createPrice:   createProductPrice.NewCreateProductPriceMock(ctrl),
+			}
+			txManagerMock := trx.NewTransactionManagerMock(ctrl)
+			nowFunc := now.NewMock(ctrl)
+			uuidFunc := uuid.NewMock(ctrl)
+
+			nowFunc.EXPECT().Now().AnyTimes().Return(tn)
+			uuidFunc.EXPECT().UUID().AnyTimes().Return(id)
+			txManagerMock.EXPECT().Do(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				},
+			)
+
+			uc, err := NewUseCase(
+				usecase.WithTransactionManager[*UseCase](txManagerMock),
+				usecase.WithLogger[*UseCase](m.logger),
+				usecase.WithNowFunc[*UseCase](nowFunc),
+				usecase.WithUUIDFunc[*UseCase](uuidFunc),
+				WithGetUserQuery(getUser.NewQueryHandler(m.getUser)),
+				WithCreateProductCommand(createProduct.NewCommandHandler(m.createProduct)),
+				WithCreateProductPriceCommand(createProductPrice.NewCommandHandler(m.createPrice)),
+			)
+			require.NoError(t, err)
+
+			m.logger.EXPECT().With(
+				log.String("actorUUID", tc.in.GetActorID().String()),
+				log.String("title", tc.in.GetTitle()),
+				log.String("tags", strings.Join(tc.in.GetTags(), ",")),
+				log.Int64("price", tc.in.GetPrice()),
+			).Return(m.logger)
+			m.logger.EXPECT().Debug(gomock.Any(), "START usecase")
+
+			expProduct, expErr := tc.exp(t, tc.in, m)
+			if expErr == nil {
+				m.logger.EXPECT().Debug(gomock.Any(), "END usecase")
+			}
+
+			product, err := uc.Run(context.Background(), tc.in)
+			require.ErrorIs(t, err, expErr)
+
+			if expProduct != nil {
+				require.NotNil(t, product)
+				// генераторы, установленные use case, в сравнении не участвуют
+				product.WithNowGenerator = expProduct.WithNowGenerator
+				product.WithUUIDGenerator = expProduct.WithUUIDGenerator
+			}
+
+			assert.Equal(t, expProduct, product)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package archiveproduct
+
+import (
+	"fmt"
+
+	updateProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product/update"
+	getProduct "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_product"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func WithGetUserQuery(handler *getUser.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getUser")
+		}
+
+		uc.getUserQuery = handler
+
+		return nil
+	}
+}
+
+func WithGetProductQuery(handler *getProduct.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getProduct")
+		}
+
+		uc.getProductQuery = handler
+
+		return nil
+	}
+}
+
+func WithUpdateProductCommand(handler *updateProduct.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "updateProduct")
+		}
+
+		uc.updateProductCmd = handler
+
+		return nil
+	}
+}
@@ -0,0 +1,61 @@
+package archiveproduct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	updateProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product/update"
+	getProduct "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_product"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func TestConfiguration(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	loggerMock := log.NewLogMock(ctrl)
+	txManagerMock := trx.NewTransactionManagerMock(ctrl)
+	nowFunc := now.NewMock(ctrl)
+	getUserMock := getUser.NewGetUserByIDMock(ctrl)
+	getProductMock := getProduct.NewGetProductMock(ctrl)
+	updateProductMock := updateProduct.NewUpdateProductMock(ctrl)
+
+	cfgs := []usecase.Configuration[*UseCase]{
+		usecase.WithTransactionManager[*UseCase](txManagerMock),
+		usecase.WithLogger[*UseCase](loggerMock),
+		usecase.WithNowFunc[*UseCase](nowFunc),
+		WithGetUserQuery(getUser.NewQueryHandler(getUserMock)),
+		WithGetProductQuery(getProduct.NewQueryHandler(getProductMock)),
+		WithUpdateProductCommand(updateProduct.NewCommandHandler(updateProductMock)),
+	}
+
+	for _, f := range []usecase.Configuration[*UseCase]{
+		WithGetUserQuery(nil),
+		WithGetProductQuery(nil),
+		WithUpdateProductCommand(nil),
+	} {
+		uc, err := NewUseCase(f)
+		require.ErrorIs(t, err, usecase.ErrEmptyStructParam)
+		assert.Empty(t, uc)
+	}
+
+	uc, err := NewUseCase(nil)
+	require.ErrorIs(t, err, checker.ErrInitError)
+	require.Empty(t, uc)
+
+	uc, err = NewUseCase(cfgs[:len(cfgs)-1]...)
+	require.ErrorIs(t, err, checker.ErrInitError)
+	require.Empty(t, uc)
+
+	uc, err = NewUseCase(cfgs...)
+	require.NoError(t, err)
+	assert.NotEmpty(t, uc)
+}
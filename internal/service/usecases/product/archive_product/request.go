@@ -0,0 +1,9 @@
+package archiveproduct
+
+import "github.com/google/uuid"
+
+type Requestable interface {
+	// GetActorID пользователь, снимающий товар с продажи.
+	GetActorID() uuid.UUID
+	GetProductID() uuid.UUID
+}
@@ -0,0 +1,18 @@
+package archiveproduct
+
+import "github.com/google/uuid"
+
+type testRequest struct {
+	actorUUID   uuid.UUID
+	productUUID uuid.UUID
+}
+
+var _ Requestable = (*testRequest)(nil)
+
+func (t testRequest) GetActorID() uuid.UUID {
+	return t.actorUUID
+}
+
+func (t testRequest) GetProductID() uuid.UUID {
+	return t.productUUID
+}
@@ -0,0 +1,111 @@
+package archiveproduct
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	updateProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product/update"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getProduct "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_product"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+// UseCase снимает товар с продажи (мягкое удаление). Уже оформленные заказы, остатки и движения
+// товара сохраняются, но в новые заказы товар больше не добавляется.
+// Доступен пользователям с разрешением на ведение каталога.
+type UseCase struct {
+	now.WithNowGenerator
+	checker.WithCheck
+	tx.WithTransactionManager
+	log.WithLogger
+
+	// Query handlers
+	getUserQuery    *getUser.QueryHandler
+	getProductQuery *getProduct.QueryHandler
+
+	// Command handlers
+	updateProductCmd *updateProduct.CommandHandler
+}
+
+func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
+	uc := &UseCase{}
+
+	// Apply all Configurations passed in
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			return nil, checker.ErrInitError
+		}
+
+		err := cfg(uc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Check(*uc); err != nil {
+		return nil, err
+	}
+
+	return uc, nil
+}
+
+func (uc *UseCase) Run(ctx context.Context, req Requestable) error {
+	ctx, span := tracing.Start(ctx, "usecase.archiveProduct")
+	defer span.End()
+
+	l := uc.Logger().With(
+		log.String("actorUUID", req.GetActorID().String()),
+		log.String("productUUID", req.GetProductID().String()),
+	)
+
+	l.Debug(ctx, "START usecase")
+
+	productID, err := vObject.NewProductIDFromUUID(req.GetProductID())
+	if err != nil {
+		l.Error(ctx, "STOP usecase! vObject.NewProductIDFromUUID error", log.Err(err))
+
+		return tracing.Error(span, fmt.Errorf("[archiveProduct - vObject.NewProductIDFromUUID error]: %w", err))
+	}
+
+	if _, err = usecase.Authorize(ctx, uc.getUserQuery, req.GetActorID(), vObject.PermissionManageProducts); err != nil {
+		l.Error(ctx, "STOP usecase! usecase.Authorize error", log.Err(err))
+
+		return tracing.Error(span, fmt.Errorf("[archiveProduct - usecase.Authorize error]: %w", err))
+	}
+
+	err = uc.TransactionDo(ctx, func(ctx context.Context) error {
+		// 1. Получаем товар с блокировкой записи. Товар из архива не находится
+		product, err := uc.getProductQuery.Handle(ctx, getProduct.NewQueryForUpdate(productID))
+		if err != nil {
+			return fmt.Errorf("[archiveProduct - uc.getProductQuery.Handle error]: %w", err)
+		}
+
+		// 2. Отмечаем товар архивным и сохраняем его
+		product.SetNowGen(uc.GetNowGen())
+
+		if !product.Archive() {
+			return nil
+		}
+
+		if err = uc.updateProductCmd.Handle(ctx, updateProduct.NewCommandUnsafe(product)); err != nil {
+			return fmt.Errorf("[archiveProduct - uc.updateProductCmd.Handle error]: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		l.Error(ctx, "STOP usecase! transaction error", log.Err(err))
+
+		return tracing.Error(span, fmt.Errorf("[archiveProduct - uc.TransactionDo error]: %w", err))
+	}
+
+	l.Debug(ctx, "END usecase")
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package archiveproduct
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	updateProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product/update"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getProduct "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_product"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+type mocks struct {
+	logger        *log.LogMock
+	getUser       *getUser.GetUserByIDMock
+	getProduct    *getProduct.GetProductMock
+	updateProduct *updateProduct.UpdateProductMock
+}
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		in   testRequest
+		exp  func(t *testing.T, in testRequest, m mocks) error
+	}
+
+	tn := time.Now().UTC().Truncate(time.Second)
+	admin := &entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), Role: vObject.RoleAdmin}
+	customer := &entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), Role: vObject.RoleCustomer}
+	productID := vObject.NewProductIDFromUUIDUnsafe(baseUUID.New())
+
+	newProduct := func() *entities.Product {
+		return &entities.Product{ID: productID, Title: vObject.NewProductTitleUnsafe("Молоко")}
+	}
+
+	actorQos := func(id vObject.UserID) *queryOptions.UserQueryOptions {
+		return queryOptions.NewUserQueryOptions(queryOptions.WithUserID(id))
+	}
+	getProductQos := queryOptions.NewProductQueryOptions(
+		queryOptions.WithProductID(productID),
+		queryOptions.WithForUpdate[*queryOptions.ProductQueryOptions](),
+	)
+	request := testRequest{actorUUID: admin.ID.UUID(), productUUID: productID.UUID()}
+
+	tcs := []testCase{
+		{
+			name: "happy path",
+			in:   request,
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(admin.ID)).Return(admin, nil)
+				m.getProduct.EXPECT().GetProduct(gomock.Any(), getProductQos).Return(newProduct(), nil)
+				m.updateProduct.EXPECT().UpdateProduct(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, p *entities.Product) error {
+					require.NotNil(t, p.DeletedAt)
+					assert.Equal(t, tn, *p.DeletedAt)
+
+					return nil
+				})
+
+				return nil
+			},
+		},
+		{
+			name: "update product error",
+			in:   request,
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(admin.ID)).Return(admin, nil)
+				m.getProduct.EXPECT().GetProduct(gomock.Any(), getProductQos).Return(newProduct(), nil)
+				m.updateProduct.EXPECT().UpdateProduct(gomock.Any(), gomock.Any()).Return(assert.AnError)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+
+				return assert.AnError
+			},
+		},
+		{
+			name: "product not found or already archived",
+			in:   request,
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(admin.ID)).Return(admin, nil)
+				m.getProduct.EXPECT().GetProduct(gomock.Any(), getProductQos).Return(nil, entities.ErrProductRecNotFound)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+
+				return entities.ErrProductRecNotFound
+			},
+		},
+		{
+			name: "customer cannot manage products",
+			in:   testRequest{actorUUID: customer.ID.UUID(), productUUID: productID.UUID()},
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(customer.ID)).Return(customer, nil)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! usecase.Authorize error", gomock.Any())
+
+				return entities.ErrPermissionDenied
+			},
+		},
+		{
+			name: "empty product id",
+			in:   testRequest{actorUUID: admin.ID.UUID()},
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! vObject.NewProductIDFromUUID error", gomock.Any())
+
+				return vObject.ErrEmptyID
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := mocks{
+				logger:        log.NewLogMock(ctrl),
+				getUser:       getUser.NewGetUserByIDMock(ctrl),
+				getProduct:    getProduct.NewGetProductMock(ctrl),
+				updateProduct: updateProduct.NewUpdateProductMock(ctrl),
+			}
+			txManagerMock := trx.NewTransactionManagerMock(ctrl)
+			nowFunc := now.NewMock(ctrl)
+
+			nowFunc.EXPECT().Now().AnyTimes().Return(tn)
+			txManagerMock.EXPECT().Do(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				},
+			)
+
+			uc, err := NewUseCase(
+				usecase.WithTransactionManager[*UseCase](txManagerMock),
+				usecase.WithLogger[*UseCase](m.logger),
+				usecase.WithNowFunc[*UseCase](nowFunc),
+				WithGetUserQuery(getUser.NewQueryHandler(m.getUser)),
+				WithGetProductQuery(getProduct.NewQueryHandler(m.getProduct)),
+				WithUpdateProductCommand(updateProduct.NewCommandHandler(m.updateProduct)),
+			)
+			require.NoError(t, err)
+
+			m.logger.EXPECT().With(
+				log.String("actorUUID", tc.in.GetActorID().String()),
+				log.String("productUUID", tc.in.GetProductID().String()),
+			).Return(m.logger)
+			m.logger.EXPECT().Debug(gomock.Any(), "START usecase")
+
+			expErr := tc.exp(t, tc.in, m)
+			if expErr == nil {
+				m.logger.EXPECT().Debug(gomock.Any(), "END usecase")
+			}
+
+			require.ErrorIs(t, uc.Run(context.Background(), tc.in), expErr)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package editproduct
+
+import "github.com/google/uuid"
+
+// Requestable новые значения полей товара. Поля заменяются целиком: пустые теги удаляют все теги товара.
+type Requestable interface {
+	// GetActorID пользователь, изменяющий товар.
+	GetActorID() uuid.UUID
+	GetProductID() uuid.UUID
+	GetTitle() string
+	GetDescription() string
+	GetTags() []string
+	// GetPrice цена товара в копейках.
+	GetPrice() int64
+}
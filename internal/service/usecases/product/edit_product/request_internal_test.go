@@ -0,0 +1,38 @@
+package editproduct
+
+import "github.com/google/uuid"
+
+type testRequest struct {
+	actorUUID   uuid.UUID
+	productUUID uuid.UUID
+	title       string
+	description string
+	tags        []string
+	price       int64
+}
+
+var _ Requestable = (*testRequest)(nil)
+
+func (t testRequest) GetActorID() uuid.UUID {
+	return t.actorUUID
+}
+
+func (t testRequest) GetProductID() uuid.UUID {
+	return t.productUUID
+}
+
+func (t testRequest) GetTitle() string {
+	return t.title
+}
+
+func (t testRequest) GetDescription() string {
+	return t.description
+}
+
+func (t testRequest) GetTags() []string {
+	return t.tags
+}
+
+func (t testRequest) GetPrice() int64 {
+	return t.price
+}
@@ -0,0 +1,140 @@
+package editproduct
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	updateProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product/update"
+	createProductPrice "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_price/create"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getProduct "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_product"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+// UseCase изменяет название, описание, теги и цену товара, новая цена записывается в историю цен.
+// Доступен пользователям с разрешением на ведение каталога.
+type UseCase struct {
+	uuid.WithUUIDGenerator
+	now.WithNowGenerator
+	checker.WithCheck
+	tx.WithTransactionManager
+	log.WithLogger
+
+	// Query handlers
+	getUserQuery    *getUser.QueryHandler
+	getProductQuery *getProduct.QueryHandler
+
+	// Command handlers
+	updateProductCmd      *updateProduct.CommandHandler
+	createProductPriceCmd *createProductPrice.CommandHandler
+}
+
+func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
+	uc := &UseCase{}
+
+	// Apply all Configurations passed in
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			return nil, checker.ErrInitError
+		}
+
+		err := cfg(uc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Check(*uc); err != nil {
+		return nil, err
+	}
+
+	return uc, nil
+}
+
+func (uc *UseCase) Run(ctx context.Context, req Requestable) (*entities.Product, error) {
+	ctx, span := tracing.Start(ctx, "usecase.editProduct")
+	defer span.End()
+
+	l := uc.Logger().With(
+		log.String("actorUUID", req.GetActorID().String()),
+		log.String("productUUID", req.GetProductID().String()),
+		log.String("title", req.GetTitle()),
+		log.String("tags", strings.Join(req.GetTags(), ",")),
+		log.Int64("price", req.GetPrice()),
+	)
+
+	l.Debug(ctx, "START usecase")
+
+	productID, err := vObject.NewProductIDFromUUID(req.GetProductID())
+	if err != nil {
+		l.Error(ctx, "STOP usecase! vObject.NewProductIDFromUUID error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[editProduct - vObject.NewProductIDFromUUID error]: %w", err))
+	}
+
+	actor, err := usecase.Authorize(ctx, uc.getUserQuery, req.GetActorID(), vObject.PermissionManageProducts)
+	if err != nil {
+		l.Error(ctx, "STOP usecase! usecase.Authorize error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[editProduct - usecase.Authorize error]: %w", err))
+	}
+
+	var product *entities.Product
+
+	err = uc.TransactionDo(ctx, func(ctx context.Context) error {
+		// 1. Получаем товар с блокировкой записи
+		product, err = uc.getProductQuery.Handle(ctx, getProduct.NewQueryForUpdate(productID))
+		if err != nil {
+			return fmt.Errorf("[editProduct - uc.getProductQuery.Handle error]: %w", err)
+		}
+
+		// 2. Меняем поля товара и сохраняем его
+		product.SetNowGen(uc.GetNowGen())
+		price := product.Price
+
+		if err = product.Update(req.GetTitle(), req.GetDescription(), req.GetTags(), req.GetPrice()); err != nil {
+			return fmt.Errorf("[editProduct - product.Update error]: %w", err)
+		}
+
+		if err = uc.updateProductCmd.Handle(ctx, updateProduct.NewCommandUnsafe(product)); err != nil {
+			return fmt.Errorf("[editProduct - uc.updateProductCmd.Handle error]: %w", err)
+		}
+
+		// 3. Новая цена действует с момента изменения и записывается в историю цен
+		if product.Price == price {
+			return nil
+		}
+
+		productPrice := entities.NewProductPriceUnsafe(
+			product.ID,
+			product.Price,
+			&actor.ID,
+			entities.WithUUIDFunc[*entities.ProductPrice](uc.GetUUIDGen()),
+			entities.WithNowFunc[*entities.ProductPrice](uc.GetNowGen()),
+		)
+
+		if err = uc.createProductPriceCmd.Handle(ctx, createProductPrice.NewCommandUnsafe(&productPrice)); err != nil {
+			return fmt.Errorf("[editProduct - uc.createProductPriceCmd.Handle error]: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		l.Error(ctx, "STOP usecase! transaction error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[editProduct - uc.TransactionDo error]: %w", err))
+	}
+
+	l.Debug(ctx, "END usecase")
+
+	return product, nil
+}
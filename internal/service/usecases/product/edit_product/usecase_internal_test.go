@@ -0,0 +1,271 @@
+package editproduct
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	updateProduct "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product/update"
+	createProductPrice "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_price/create"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getProduct "github.com/smgladkovskiy/warehouse-task/internal/service/queries/product/get_product"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+type mocks struct {
+	logger        *log.LogMock
+	getUser       *getUser.GetUserByIDMock
+	getProduct    *getProduct.GetProductMock
+	updateProduct *updateProduct.UpdateProductMock
+	createPrice   *createProductPrice.CreateProductPriceMock
+}
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		in   testRequest
+		exp  func(t *testing.T, in testRequest, m mocks) (*entities.Product, error)
+	}
+
+	tn := time.Now().UTC().Truncate(time.Second)
+	created := tn.Add(-time.Hour)
+	operator := &entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), Role: vObject.RoleOperator}
+	customer := &entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), Role: vObject.RoleCustomer}
+	productID := vObject.NewProductIDFromUUIDUnsafe(baseUUID.New())
+
+	newProduct := func() *entities.Product {
+		return &entities.Product{
+			ID:        productID,
+			Title:     vObject.NewProductTitleUnsafe("Молоко"),
+			Tags:      vObject.Tags{"молочное"},
+			Price:     vObject.NewPriceUnsafe(8900),
+			CreatedAt: created,
+			UpdatedAt: created,
+		}
+	}
+
+	actorQos := func(id vObject.UserID) *queryOptions.UserQueryOptions {
+		return queryOptions.NewUserQueryOptions(queryOptions.WithUserID(id))
+	}
+	getProductQos := queryOptions.NewProductQueryOptions(
+		queryOptions.WithProductID(productID),
+		queryOptions.WithForUpdate[*queryOptions.ProductQueryOptions](),
+	)
+	request := testRequest{
+		actorUUID:   operator.ID.UUID(),
+		productUUID: productID.UUID(),
+		title:       "Кефир",
+		description: "1%",
+		price:       7500,
+	}
+
+	tcs := []testCase{
+		{
+			name: "happy path",
+			in:   request,
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Product, error) {
+				t.Helper()
+
+				exp := newProduct()
+				exp.Title = vObject.NewProductTitleUnsafe("Кефир")
+				exp.Description = vObject.NewProductDescriptionUnsafe("1%")
+				exp.Tags = vObject.Tags{}
+				exp.Price = vObject.NewPriceUnsafe(7500)
+				exp.UpdatedAt = tn
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(operator.ID)).Return(operator, nil)
+				m.getProduct.EXPECT().GetProduct(gomock.Any(), getProductQos).Return(newProduct(), nil)
+				m.updateProduct.EXPECT().UpdateProduct(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, p *entities.Product) error {
+					assert.Equal(t, exp.Title, p.Title)
+					assert.Equal(t, exp.Price, p.Price)
+					assert.Equal(t, tn, p.UpdatedAt)
+
+					return nil
+				})
+				m.createPrice.EXPECT().CreateProductPrice(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, p *entities.ProductPrice) error {
+					assert.Equal(t, productID, p.ProductID)
+					assert.Equal(t, exp.Price, p.Price)
+					assert.Equal(t, tn, p.EffectiveFrom)
+					assert.Equal(t, &operator.ID, p.ActorID)
+
+					return nil
+				})
+
+				return exp, nil
+			},
+		},
+		{
+			name: "price unchanged",
+			in:   testRequest{actorUUID: operator.ID.UUID(), productUUID: productID.UUID(), title: "Молоко 3,2%", tags: []string{"молочное"}, price: 8900},
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Product, error) {
+				t.Helper()
+
+				exp := newProduct()
+				exp.Title = vObject.NewProductTitleUnsafe("Молоко 3,2%")
+				exp.UpdatedAt = tn
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(operator.ID)).Return(operator, nil)
+				m.getProduct.EXPECT().GetProduct(gomock.Any(), getProductQos).Return(newProduct(), nil)
+				m.updateProduct.EXPECT().UpdateProduct(gomock.Any(), gomock.Any()).Return(nil)
+
+				return exp, nil
+			},
+		},
+		{
+			name: "create product price error",
+			in:   request,
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Product, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(operator.ID)).Return(operator, nil)
+				m.getProduct.EXPECT().GetProduct(gomock.Any(), getProductQos).Return(newProduct(), nil)
+				m.updateProduct.EXPECT().UpdateProduct(gomock.Any(), gomock.Any()).Return(nil)
+				m.createPrice.EXPECT().CreateProductPrice(gomock.Any(), gomock.Any()).Return(assert.AnError)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+
+				return nil, assert.AnError
+			},
+		},
+		{
+			name: "update product error",
+			in:   request,
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Product, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(operator.ID)).Return(operator, nil)
+				m.getProduct.EXPECT().GetProduct(gomock.Any(), getProductQos).Return(newProduct(), nil)
+				m.updateProduct.EXPECT().UpdateProduct(gomock.Any(), gomock.Any()).Return(assert.AnError)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+
+				return nil, assert.AnError
+			},
+		},
+		{
+			name: "invalid title",
+			in:   testRequest{actorUUID: operator.ID.UUID(), productUUID: productID.UUID(), price: 7500},
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Product, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(operator.ID)).Return(operator, nil)
+				m.getProduct.EXPECT().GetProduct(gomock.Any(), getProductQos).Return(newProduct(), nil)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+
+				return nil, vObject.ErrEmptyProductTitle
+			},
+		},
+		{
+			name: "product not found",
+			in:   request,
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Product, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(operator.ID)).Return(operator, nil)
+				m.getProduct.EXPECT().GetProduct(gomock.Any(), getProductQos).Return(nil, entities.ErrProductRecNotFound)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+
+				return nil, entities.ErrProductRecNotFound
+			},
+		},
+		{
+			name: "customer cannot manage products",
+			in:   testRequest{actorUUID: customer.ID.UUID(), productUUID: productID.UUID(), title: "Кефир"},
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Product, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(customer.ID)).Return(customer, nil)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! usecase.Authorize error", gomock.Any())
+
+				return nil, entities.ErrPermissionDenied
+			},
+		},
+		{
+			name: "empty product id",
+			in:   testRequest{actorUUID: operator.ID.UUID(), title: "Кефир"},
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.Product, error) {
+				t.Helper()
+
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! vObject.NewProductIDFromUUID error", gomock.Any())
+
+				return nil, vObject.ErrEmptyID
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := mocks{
+				logger:        log.NewLogMock(ctrl),
+				getUser:       getUser.NewGetUserByIDMock(ctrl),
+				getProduct:    getProduct.NewGetProductMock(ctrl),
+				updateProduct: updateProduct.NewUpdateProductMock(ctrl),
+				createPrice:   createProductPrice.NewCreateProductPriceMock(ctrl),
+			}
+			txManagerMock := trx.NewTransactionManagerMock(ctrl)
+			nowFunc := now.NewMock(ctrl)
+			uuidFunc := uuid.NewMock(ctrl)
+
+			nowFunc.EXPECT().Now().AnyTimes().Return(tn)
+			uuidFunc.EXPECT().UUID().AnyTimes().Return(baseUUID.New())
+			txManagerMock.EXPECT().Do(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				},
+			)
+
+			uc, err := NewUseCase(
+				usecase.WithTransactionManager[*UseCase](txManagerMock),
+				usecase.WithLogger[*UseCase](m.logger),
+				usecase.WithNowFunc[*UseCase](nowFunc),
+				usecase.WithUUIDFunc[*UseCase](uuidFunc),
+				WithGetUserQuery(getUser.NewQueryHandler(m.getUser)),
+				WithGetProductQuery(getProduct.NewQueryHandler(m.getProduct)),
+				WithUpdateProductCommand(updateProduct.NewCommandHandler(m.updateProduct)),
+				WithCreateProductPriceCommand(createProductPrice.NewCommandHandler(m.createPrice)),
+			)
+			require.NoError(t, err)
+
+			m.logger.EXPECT().With(
+				log.String("actorUUID", tc.in.GetActorID().String()),
+				log.String("productUUID", tc.in.GetProductID().String()),
+				log.String("title", tc.in.GetTitle()),
+				log.String("tags", strings.Join(tc.in.GetTags(), ",")),
+				log.Int64("price", tc.in.GetPrice()),
+			).Return(m.logger)
+			m.logger.EXPECT().Debug(gomock.Any(), "START usecase")
+
+			expProduct, expErr := tc.exp(t, tc.in, m)
+			if expErr == nil {
+				m.logger.EXPECT().Debug(gomock.Any(), "END usecase")
+			}
+
+			product, err := uc.Run(context.Background(), tc.in)
+			require.ErrorIs(t, err, expErr)
+
+			if expProduct != nil {
+				require.NotNil(t, product)
+				// генератор времени, установленный use case, в сравнении не участвует
+				product.WithNowGenerator = expProduct.WithNowGenerator
+			}
+
+			assert.Equal(t, expProduct, product)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package incomestock
+
+import (
+	"fmt"
+
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	createStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
+	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func WithGetStocksQuery(handler *getStocks.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getStocks")
+		}
+
+		uc.getStocksQuery = handler
+
+		return nil
+	}
+}
+
+func WithCreateStockCommand(handler *createStock.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "createStock")
+		}
+
+		uc.createStockCmd = handler
+
+		return nil
+	}
+}
+
+func WithUpdateStockCommand(handler *updateStock.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "updateStock")
+		}
+
+		uc.updateStockCmd = handler
+
+		return nil
+	}
+}
+
+func WithCreateProductMovementsCommand(handler *createProductMovements.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "createProductMovements")
+		}
+
+		uc.createMovementsCmd = handler
+
+		return nil
+	}
+}
+
+func WithGetUserQuery(handler *getUser.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getUser")
+		}
+
+		uc.getUserQuery = handler
+
+		return nil
+	}
+}
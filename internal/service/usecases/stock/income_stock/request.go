@@ -0,0 +1,13 @@
+package incomestock
+
+import "github.com/google/uuid"
+
+type Requestable interface {
+	GetActorID() uuid.UUID
+	GetProductID() uuid.UUID
+	GetWarehouseID() uuid.UUID
+	GetQuantity() uint64
+	GetPurchasePrice() int64 // закупочная цена единицы товара в копейках
+	GetReason() string
+	GetDocumentRef() string
+}
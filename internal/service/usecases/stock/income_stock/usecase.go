@@ -0,0 +1,210 @@
+package incomestock
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	createStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+// UseCase принимает товар на склад (поступление) по закупочной цене с указанием причины
+// и документа-основания.
+type UseCase struct {
+	uuid.WithUUIDGenerator
+	now.WithNowGenerator
+	checker.WithCheck
+	tx.WithTransactionManager
+	log.WithLogger
+
+	// Query handlers
+	getStocksQuery *getStocks.QueryHandler
+	getUserQuery   *getUser.QueryHandler
+
+	// Command handlers
+	createStockCmd     *createStock.CommandHandler
+	updateStockCmd     *updateStock.CommandHandler
+	createMovementsCmd *createProductMovements.CommandHandler
+}
+
+type income struct {
+	productID   vObject.ProductID
+	warehouseID vObject.WarehouseID
+	quantity    vObject.Quantity
+	price       vObject.Price
+	reason      vObject.MovementReason
+	document    vObject.DocumentReference
+}
+
+func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
+	uc := &UseCase{}
+
+	// Apply all Configurations passed in
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			return nil, checker.ErrInitError
+		}
+
+		err := cfg(uc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Check(*uc); err != nil {
+		return nil, err
+	}
+
+	return uc, nil
+}
+
+func (uc *UseCase) Run(ctx context.Context, req Requestable) (*entities.ProductMovement, error) {
+	ctx, span := tracing.Start(ctx, "usecase.incomeStock")
+	defer span.End()
+
+	l := uc.Logger().With(
+		log.String("actorUUID", req.GetActorID().String()),
+		log.String("productUUID", req.GetProductID().String()),
+		log.String("warehouseUUID", req.GetWarehouseID().String()),
+		log.Uint64("quantity", req.GetQuantity()),
+		log.String("reason", req.GetReason()),
+	)
+
+	l.Debug(ctx, "START usecase")
+
+	if _, err := usecase.Authorize(ctx, uc.getUserQuery, req.GetActorID(), vObject.PermissionManageStock); err != nil {
+		l.Error(ctx, "STOP usecase! usecase.Authorize error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[incomeStock - usecase.Authorize error]: %w", err))
+	}
+
+	in, err := newIncome(req)
+	if err != nil {
+		l.Error(ctx, "STOP usecase! newIncome error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[incomeStock - newIncome error]: %w", err))
+	}
+
+	var movement entities.ProductMovement
+
+	err = uc.TransactionDo(ctx, func(ctx context.Context) error {
+		movement, err = uc.income(ctx, in)
+
+		return err
+	})
+	if err != nil {
+		l.Error(ctx, "STOP usecase! transaction error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[incomeStock - uc.TransactionDo error]: %w", err))
+	}
+
+	l.Debug(ctx, "END usecase")
+
+	return &movement, nil
+}
+
+func newIncome(req Requestable) (income, error) {
+	productID, err := vObject.NewProductIDFromUUID(req.GetProductID())
+	if err != nil {
+		return income{}, err
+	}
+
+	warehouseID, err := vObject.NewWarehouseIDFromUUID(req.GetWarehouseID())
+	if err != nil {
+		return income{}, err
+	}
+
+	price, err := vObject.NewPrice(req.GetPurchasePrice())
+	if err != nil {
+		return income{}, err
+	}
+
+	reason, err := vObject.NewMovementReason(vObject.OperationTypeIncome, req.GetReason())
+	if err != nil {
+		return income{}, err
+	}
+
+	document, err := vObject.NewDocumentReference(req.GetDocumentRef())
+	if err != nil {
+		return income{}, err
+	}
+
+	return income{
+		productID:   productID,
+		warehouseID: warehouseID,
+		quantity:    vObject.NewQuantityUnsafe(req.GetQuantity()),
+		price:       price,
+		reason:      reason,
+		document:    document,
+	}, nil
+}
+
+func (uc *UseCase) income(ctx context.Context, in income) (entities.ProductMovement, error) {
+	// 1. Получаем остатки товара по складам с блокировкой до конца транзакции
+	stocks, err := uc.getStocksQuery.Handle(ctx, getStocks.NewQueryByProductIDForUpdateUnsafe(in.productID))
+	if err != nil {
+		return entities.ProductMovement{}, fmt.Errorf("[incomeStock - uc.getStocksQuery.Handle error]: %w", err)
+	}
+
+	// товар может поступить на склад впервые: создаём пустой остаток и блокируем остатки заново,
+	// иначе параллельное поступление на тот же склад перезапишет количество
+	if stocks.GetByWarehouseIDUnsafe(in.warehouseID) == nil {
+		newStock := entities.NewStockUnsafe(
+			in.productID,
+			in.warehouseID,
+			vObject.QuantityZero,
+			vObject.QuantityZero,
+			entities.WithNowFunc[*entities.Stock](uc.GetNowGen()),
+		)
+
+		if err = uc.createStockCmd.Handle(ctx, createStock.NewCommandUnsafe(&newStock)); err != nil {
+			return entities.ProductMovement{}, fmt.Errorf("[incomeStock - uc.createStockCmd.Handle error]: %w", err)
+		}
+
+		stocks, err = uc.getStocksQuery.Handle(ctx, getStocks.NewQueryByProductIDForUpdateUnsafe(in.productID))
+		if err != nil {
+			return entities.ProductMovement{}, fmt.Errorf("[incomeStock - uc.getStocksQuery.Handle error]: %w", err)
+		}
+	}
+
+	stock := stocks.GetByWarehouseIDUnsafe(in.warehouseID)
+	if stock == nil {
+		return entities.ProductMovement{}, fmt.Errorf("[incomeStock - stock error]: %w", entities.ErrNotEnoughProductIntStocks)
+	}
+
+	// 2. Увеличиваем остаток
+	movement, err := stock.Income(
+		in.quantity,
+		in.price,
+		in.reason,
+		in.document,
+		entities.WithUUIDFunc[*entities.ProductMovement](uc.GetUUIDGen()),
+		entities.WithNowFunc[*entities.ProductMovement](uc.GetNowGen()),
+	)
+	if err != nil {
+		return entities.ProductMovement{}, fmt.Errorf("[incomeStock - stock.Income error]: %w", err)
+	}
+
+	// 3. Сохраняем остаток и движение поступления
+	if err = uc.updateStockCmd.Handle(ctx, updateStock.NewCommandUnsafe(stock)); err != nil {
+		return entities.ProductMovement{}, fmt.Errorf("[incomeStock - uc.updateStockCmd.Handle error]: %w", err)
+	}
+
+	if err = uc.createMovementsCmd.Handle(ctx, createProductMovements.NewCommandUnsafe(entities.ProductMovements{movement})); err != nil {
+		return entities.ProductMovement{}, fmt.Errorf("[incomeStock - uc.createMovementsCmd.Handle error]: %w", err)
+	}
+
+	return movement, nil
+}
@@ -0,0 +1,332 @@
+package incomestock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	createStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryoptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+type mocks struct {
+	getUser         *getUser.GetUserByIDMock
+	getStocks       *getStocks.GetStocksMock
+	createStock     *createStock.CreateStockMock
+	updateStock     *updateStock.UpdateStockMock
+	createMovements *createProductMovements.CreateProductMovementsMock
+}
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		in   testRequest
+		exp  func(t *testing.T, m mocks) (*entities.ProductMovement, error)
+	}
+
+	tn := time.Now()
+	operator := entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), Role: vObject.RoleOperator}
+	customer := entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), Role: vObject.RoleCustomer}
+	actorQos := func(id vObject.UserID) *queryoptions.UserQueryOptions {
+		return queryoptions.NewUserQueryOptions(queryoptions.WithUserID(id))
+	}
+	movementID := baseUUID.New()
+	productID := vObject.NewProductIDFromUUIDUnsafe(baseUUID.New())
+	warehouseID := vObject.NewWarehouseIDFromUUIDUnsafe(baseUUID.New())
+	reason := vObject.MovementReasonPurchase
+	document := vObject.NewDocumentReferenceUnsafe("INV-42")
+
+	stocksQos := queryoptions.NewStockQueryOptions(
+		queryoptions.WithStockProductID(productID),
+		queryoptions.WithForUpdate[*queryoptions.StockQueryOptions](),
+	)
+	request := testRequest{
+		actorUUID:     operator.ID.UUID(),
+		productUUID:   productID.UUID(),
+		warehouseUUID: warehouseID.UUID(),
+		quantity:      10,
+		price:         700,
+		reason:        "purchase",
+		documentRef:   " INV-42 ",
+	}
+	movement := &entities.ProductMovement{
+		ID:            vObject.NewProductMovementIDFromUUIDUnsafe(movementID),
+		ProductID:     productID,
+		WarehouseID:   warehouseID,
+		OperationType: vObject.OperationTypeIncome,
+		Quantity:      vObject.NewQuantityUnsafe(10),
+		Price:         vObject.NewPriceUnsafe(700),
+		Reason:        &reason,
+		DocumentRef:   &document,
+		CreatedAt:     tn,
+	}
+
+	with := func(change func(r *testRequest)) testRequest {
+		r := request
+		change(&r)
+
+		return r
+	}
+
+	tcs := []testCase{
+		{
+			name: "happy path with existing stock",
+			in:   request,
+			exp: func(t *testing.T, m mocks) (*entities.ProductMovement, error) {
+				t.Helper()
+
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(entities.Stocks{
+					entities.NewStockUnsafe(productID, warehouseID, 2, 5),
+				}, nil)
+				m.updateStock.EXPECT().UpdateStock(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, stock *entities.Stock) error {
+					assert.Equal(t, vObject.NewQuantityUnsafe(15), stock.AvailableQuantity)
+					assert.Equal(t, vObject.NewQuantityUnsafe(2), stock.ReservedQuantity)
+
+					return nil
+				})
+				m.createMovements.EXPECT().CreateProductMovements(gomock.Any(), gomock.Any()).Return(nil)
+
+				return movement, nil
+			},
+		},
+		{
+			name: "happy path with new stock",
+			in:   request,
+			exp: func(t *testing.T, m mocks) (*entities.ProductMovement, error) {
+				t.Helper()
+
+				// остатка на складе нет: создаём пустой и блокируем остатки заново,
+				// к этому моменту параллельное поступление могло уже записать на склад товар
+				gomock.InOrder(
+					m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(entities.Stocks{}, nil),
+					m.createStock.EXPECT().CreateStock(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, stock *entities.Stock) error {
+						assert.Equal(t, productID, stock.ProductID)
+						assert.Equal(t, warehouseID, stock.WarehouseID)
+						assert.Equal(t, vObject.QuantityZero, stock.AvailableQuantity)
+						assert.Equal(t, tn, stock.CreatedAt)
+
+						return nil
+					}),
+					m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(entities.Stocks{
+						entities.NewStockUnsafe(productID, warehouseID, 0, 3),
+					}, nil),
+				)
+				m.updateStock.EXPECT().UpdateStock(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, stock *entities.Stock) error {
+					assert.Equal(t, warehouseID, stock.WarehouseID)
+					assert.Equal(t, vObject.NewQuantityUnsafe(13), stock.AvailableQuantity, "quantity of the concurrent income is kept")
+
+					return nil
+				})
+				m.createMovements.EXPECT().CreateProductMovements(gomock.Any(), gomock.Any()).Return(nil)
+
+				return movement, nil
+			},
+		},
+		{
+			name: "movements create error",
+			in:   request,
+			exp: func(t *testing.T, m mocks) (*entities.ProductMovement, error) {
+				t.Helper()
+
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(entities.Stocks{
+					entities.NewStockUnsafe(productID, warehouseID, 0, 0),
+				}, nil)
+				m.updateStock.EXPECT().UpdateStock(gomock.Any(), gomock.Any()).Return(nil)
+				m.createMovements.EXPECT().CreateProductMovements(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+				return nil, assert.AnError
+			},
+		},
+		{
+			name: "create stock error",
+			in:   request,
+			exp: func(t *testing.T, m mocks) (*entities.ProductMovement, error) {
+				t.Helper()
+
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(entities.Stocks{}, nil)
+				m.createStock.EXPECT().CreateStock(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+				return nil, assert.AnError
+			},
+		},
+		{
+			name: "relock stocks error",
+			in:   request,
+			exp: func(t *testing.T, m mocks) (*entities.ProductMovement, error) {
+				t.Helper()
+
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(entities.Stocks{}, nil)
+				m.createStock.EXPECT().CreateStock(gomock.Any(), gomock.Any()).Return(nil)
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(nil, assert.AnError)
+
+				return nil, assert.AnError
+			},
+		},
+		{
+			name: "get stocks error",
+			in:   request,
+			exp: func(t *testing.T, m mocks) (*entities.ProductMovement, error) {
+				t.Helper()
+
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(nil, assert.AnError)
+
+				return nil, assert.AnError
+			},
+		},
+		{
+			name: "zero quantity",
+			in:   with(func(r *testRequest) { r.quantity = 0 }),
+			exp: func(t *testing.T, m mocks) (*entities.ProductMovement, error) {
+				t.Helper()
+
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(entities.Stocks{
+					entities.NewStockUnsafe(productID, warehouseID, 0, 5),
+				}, nil)
+
+				return nil, entities.ErrEmptyStockQuantity
+			},
+		},
+		{
+			name: "write-off reason",
+			in:   with(func(r *testRequest) { r.reason = "damage" }),
+			exp: func(t *testing.T, _ mocks) (*entities.ProductMovement, error) {
+				t.Helper()
+
+				return nil, vObject.ErrUnknownMovementReason
+			},
+		},
+		{
+			name: "empty document",
+			in:   with(func(r *testRequest) { r.documentRef = " " }),
+			exp: func(t *testing.T, _ mocks) (*entities.ProductMovement, error) {
+				t.Helper()
+
+				return nil, vObject.ErrEmptyDocumentReference
+			},
+		},
+		{
+			name: "negative price",
+			in:   with(func(r *testRequest) { r.price = -1 }),
+			exp: func(t *testing.T, _ mocks) (*entities.ProductMovement, error) {
+				t.Helper()
+
+				return nil, vObject.ErrNegativePrice
+			},
+		},
+		{
+			name: "empty warehouse id",
+			in:   with(func(r *testRequest) { r.warehouseUUID = baseUUID.Nil }),
+			exp: func(t *testing.T, _ mocks) (*entities.ProductMovement, error) {
+				t.Helper()
+
+				return nil, vObject.ErrEmptyID
+			},
+		},
+		{
+			name: "customer cannot manage stock",
+			in:   with(func(r *testRequest) { r.actorUUID = customer.ID.UUID() }),
+			exp: func(t *testing.T, _ mocks) (*entities.ProductMovement, error) {
+				t.Helper()
+
+				return nil, entities.ErrPermissionDenied
+			},
+		},
+		{
+			name: "anonymous actor",
+			in:   with(func(r *testRequest) { r.actorUUID = baseUUID.Nil }),
+			exp: func(t *testing.T, _ mocks) (*entities.ProductMovement, error) {
+				t.Helper()
+
+				return nil, entities.ErrPermissionDenied
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			loggerMock := log.NewLogMock(ctrl)
+			txManagerMock := trx.NewTransactionManagerMock(ctrl)
+			nowFunc := now.NewMock(ctrl)
+			uuidFunc := uuid.NewMock(ctrl)
+			m := mocks{
+				getUser:         getUser.NewGetUserByIDMock(ctrl),
+				getStocks:       getStocks.NewGetStocksMock(ctrl),
+				createStock:     createStock.NewCreateStockMock(ctrl),
+				updateStock:     updateStock.NewUpdateStockMock(ctrl),
+				createMovements: createProductMovements.NewCreateProductMovementsMock(ctrl),
+			}
+
+			nowFunc.EXPECT().Now().AnyTimes().Return(tn)
+			m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(operator.ID)).AnyTimes().Return(&operator, nil)
+			m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(customer.ID)).AnyTimes().Return(&customer, nil)
+			uuidFunc.EXPECT().UUID().AnyTimes().Return(movementID)
+			txManagerMock.EXPECT().Do(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				},
+			)
+
+			uc, err := NewUseCase(
+				usecase.WithTransactionManager[*UseCase](txManagerMock),
+				usecase.WithLogger[*UseCase](loggerMock),
+				usecase.WithNowFunc[*UseCase](nowFunc),
+				usecase.WithUUIDFunc[*UseCase](uuidFunc),
+				WithGetUserQuery(getUser.NewQueryHandler(m.getUser)),
+				WithGetStocksQuery(getStocks.NewQueryHandler(m.getStocks)),
+				WithCreateStockCommand(createStock.NewCommandHandler(m.createStock)),
+				WithUpdateStockCommand(updateStock.NewCommandHandler(m.updateStock)),
+				WithCreateProductMovementsCommand(createProductMovements.NewCommandHandler(m.createMovements)),
+			)
+			require.NoError(t, err)
+
+			loggerMock.EXPECT().With(
+				log.String("actorUUID", tc.in.GetActorID().String()),
+				log.String("productUUID", tc.in.GetProductID().String()),
+				log.String("warehouseUUID", tc.in.GetWarehouseID().String()),
+				log.Uint64("quantity", tc.in.GetQuantity()),
+				log.String("reason", tc.in.GetReason()),
+			).Return(loggerMock)
+			loggerMock.EXPECT().Debug(gomock.Any(), "START usecase")
+
+			expMovement, expErr := tc.exp(t, m)
+			if expErr == nil {
+				loggerMock.EXPECT().Debug(gomock.Any(), "END usecase")
+			} else {
+				loggerMock.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+			}
+
+			result, err := uc.Run(context.Background(), tc.in)
+			require.ErrorIs(t, err, expErr)
+
+			if expMovement != nil {
+				require.NotNil(t, result)
+				// генераторы, установленные use case, в сравнении не участвуют
+				result.WithNowGenerator = expMovement.WithNowGenerator
+				result.WithUUIDGenerator = expMovement.WithUUIDGenerator
+			}
+
+			assert.Equal(t, expMovement, result)
+		})
+	}
+}
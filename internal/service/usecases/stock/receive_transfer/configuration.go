@@ -0,0 +1,85 @@
+package receivetransfer
+
+import (
+	"fmt"
+
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
+	upsertStockTransfer "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock_transfer/upsert"
+	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	getTransfer "github.com/smgladkovskiy/warehouse-task/internal/service/queries/stock_transfer/get_transfer"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func WithGetStockTransferQuery(handler *getTransfer.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getStockTransfer")
+		}
+
+		uc.getTransferQuery = handler
+
+		return nil
+	}
+}
+
+func WithGetStocksQuery(handler *getStocks.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getStocks")
+		}
+
+		uc.getStocksQuery = handler
+
+		return nil
+	}
+}
+
+func WithUpdateStockCommand(handler *updateStock.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "updateStock")
+		}
+
+		uc.updateStockCmd = handler
+
+		return nil
+	}
+}
+
+func WithUpsertStockTransferCommand(handler *upsertStockTransfer.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "upsertStockTransfer")
+		}
+
+		uc.upsertTransferCmd = handler
+
+		return nil
+	}
+}
+
+func WithCreateProductMovementsCommand(handler *createProductMovements.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "createProductMovements")
+		}
+
+		uc.createMovementsCmd = handler
+
+		return nil
+	}
+}
+
+func WithGetUserQuery(handler *getUser.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getUser")
+		}
+
+		uc.getUserQuery = handler
+
+		return nil
+	}
+}
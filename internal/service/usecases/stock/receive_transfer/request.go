@@ -0,0 +1,8 @@
+package receivetransfer
+
+import "github.com/google/uuid"
+
+type Requestable interface {
+	GetActorID() uuid.UUID
+	GetTransferID() uuid.UUID
+}
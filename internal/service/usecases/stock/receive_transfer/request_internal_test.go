@@ -0,0 +1,18 @@
+package receivetransfer
+
+import "github.com/google/uuid"
+
+type testRequest struct {
+	actorUUID    uuid.UUID
+	transferUUID uuid.UUID
+}
+
+var _ Requestable = (*testRequest)(nil)
+
+func (t testRequest) GetActorID() uuid.UUID {
+	return t.actorUUID
+}
+
+func (t testRequest) GetTransferID() uuid.UUID {
+	return t.transferUUID
+}
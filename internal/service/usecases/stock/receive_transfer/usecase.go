@@ -0,0 +1,147 @@
+package receivetransfer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
+	upsertStockTransfer "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock_transfer/upsert"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	getTransfer "github.com/smgladkovskiy/warehouse-task/internal/service/queries/stock_transfer/get_transfer"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+// UseCase принимает на складе-получателе товар, отгруженный перемещением (см. usecases/stock/transfer_stock).
+type UseCase struct {
+	uuid.WithUUIDGenerator
+	now.WithNowGenerator
+	checker.WithCheck
+	tx.WithTransactionManager
+	log.WithLogger
+
+	// Query handlers
+	getTransferQuery *getTransfer.QueryHandler
+	getStocksQuery   *getStocks.QueryHandler
+	getUserQuery     *getUser.QueryHandler
+
+	// Command handlers
+	updateStockCmd     *updateStock.CommandHandler
+	upsertTransferCmd  *upsertStockTransfer.CommandHandler
+	createMovementsCmd *createProductMovements.CommandHandler
+}
+
+func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
+	uc := &UseCase{}
+
+	// Apply all Configurations passed in
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			return nil, checker.ErrInitError
+		}
+
+		err := cfg(uc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Check(*uc); err != nil {
+		return nil, err
+	}
+
+	return uc, nil
+}
+
+func (uc *UseCase) Run(ctx context.Context, req Requestable) (*entities.StockTransfer, error) {
+	ctx, span := tracing.Start(ctx, "usecase.receiveTransfer")
+	defer span.End()
+
+	l := uc.Logger().With(
+		log.String("actorUUID", req.GetActorID().String()),
+		log.String("transferUUID", req.GetTransferID().String()),
+	)
+
+	l.Debug(ctx, "START usecase")
+
+	if _, err := usecase.Authorize(ctx, uc.getUserQuery, req.GetActorID(), vObject.PermissionManageStock); err != nil {
+		l.Error(ctx, "STOP usecase! usecase.Authorize error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[receiveTransfer - usecase.Authorize error]: %w", err))
+	}
+
+	query, err := getTransfer.NewQueryForUpdate(req.GetTransferID())
+	if err != nil {
+		l.Error(ctx, "STOP usecase! getTransfer.NewQueryForUpdate error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[receiveTransfer - getTransfer.NewQueryForUpdate error]: %w", err))
+	}
+
+	var transfer *entities.StockTransfer
+
+	err = uc.TransactionDo(ctx, func(ctx context.Context) error {
+		transfer, err = uc.receive(ctx, *query)
+
+		return err
+	})
+	if err != nil {
+		l.Error(ctx, "STOP usecase! transaction error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[receiveTransfer - uc.TransactionDo error]: %w", err))
+	}
+
+	l.Debug(ctx, "END usecase")
+
+	return transfer, nil
+}
+
+func (uc *UseCase) receive(ctx context.Context, query getTransfer.Query) (*entities.StockTransfer, error) {
+	// 1. Получаем перемещение с блокировкой записи
+	transfer, err := uc.getTransferQuery.Handle(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("[receiveTransfer - uc.getTransferQuery.Handle error]: %w", err)
+	}
+
+	// 2. Получаем остатки товара по складам с блокировкой до конца транзакции
+	stocks, err := uc.getStocksQuery.Handle(ctx, getStocks.NewQueryByProductIDForUpdateUnsafe(transfer.ProductID))
+	if err != nil {
+		return nil, fmt.Errorf("[receiveTransfer - uc.getStocksQuery.Handle error]: %w", err)
+	}
+
+	// 3. Принимаем товар: товар в пути переходит в доступный остаток склада-получателя
+	transfer.SetNowGen(uc.GetNowGen())
+	destination := stocks.GetByWarehouseIDUnsafe(transfer.ToWarehouseID)
+
+	movement, err := transfer.Receive(
+		destination,
+		entities.WithUUIDFunc[*entities.ProductMovement](uc.GetUUIDGen()),
+		entities.WithNowFunc[*entities.ProductMovement](uc.GetNowGen()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("[receiveTransfer - transfer.Receive error]: %w", err)
+	}
+
+	// 4. Сохраняем остаток, перемещение и движение по складу-получателю
+	if err = uc.updateStockCmd.Handle(ctx, updateStock.NewCommandUnsafe(destination)); err != nil {
+		return nil, fmt.Errorf("[receiveTransfer - uc.updateStockCmd.Handle error]: %w", err)
+	}
+
+	if err = uc.upsertTransferCmd.Handle(ctx, upsertStockTransfer.NewCommandUnsafe(transfer)); err != nil {
+		return nil, fmt.Errorf("[receiveTransfer - uc.upsertTransferCmd.Handle error]: %w", err)
+	}
+
+	if err = uc.createMovementsCmd.Handle(ctx, createProductMovements.NewCommandUnsafe(entities.ProductMovements{movement})); err != nil {
+		return nil, fmt.Errorf("[receiveTransfer - uc.createMovementsCmd.Handle error]: %w", err)
+	}
+
+	return transfer, nil
+}
@@ -0,0 +1,260 @@
+package receivetransfer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
+	upsertStockTransfer "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock_transfer/upsert"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryoptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	getTransfer "github.com/smgladkovskiy/warehouse-task/internal/service/queries/stock_transfer/get_transfer"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+type mocks struct {
+	getUser         *getUser.GetUserByIDMock
+	getTransfer     *getTransfer.GetStockTransferMock
+	getStocks       *getStocks.GetStocksMock
+	updateStock     *updateStock.UpdateStockMock
+	upsertTransfer  *upsertStockTransfer.UpsertStockTransferMock
+	createMovements *createProductMovements.CreateProductMovementsMock
+}
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		in   testRequest
+		exp  func(t *testing.T, m mocks) (*entities.StockTransfer, error)
+	}
+
+	tn := time.Now()
+	operator := entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), Role: vObject.RoleOperator}
+	customer := entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), Role: vObject.RoleCustomer}
+	actorQos := func(id vObject.UserID) *queryoptions.UserQueryOptions {
+		return queryoptions.NewUserQueryOptions(queryoptions.WithUserID(id))
+	}
+	dispatched := tn.Add(-time.Hour)
+	id := baseUUID.New()
+	productID := vObject.NewProductIDFromUUIDUnsafe(baseUUID.New())
+	fromID := vObject.NewWarehouseIDFromUUIDUnsafe(baseUUID.New())
+	toID := vObject.NewWarehouseIDFromUUIDUnsafe(baseUUID.New())
+
+	newTransfer := func(status vObject.StockTransferStatus) *entities.StockTransfer {
+		return &entities.StockTransfer{
+			ID:              vObject.NewStockTransferIDFromUUIDUnsafe(id),
+			ProductID:       productID,
+			FromWarehouseID: fromID,
+			ToWarehouseID:   toID,
+			Quantity:        vObject.NewQuantityUnsafe(4),
+			Status:          status,
+			DispatchedAt:    dispatched,
+		}
+	}
+
+	transferQos := queryoptions.NewStockTransferQueryOptions(
+		queryoptions.WithStockTransferID(vObject.NewStockTransferIDFromUUIDUnsafe(id)),
+		queryoptions.WithForUpdate[*queryoptions.StockTransferQueryOptions](),
+	)
+	stocksQos := queryoptions.NewStockQueryOptions(
+		queryoptions.WithStockProductID(productID),
+		queryoptions.WithForUpdate[*queryoptions.StockQueryOptions](),
+	)
+	destination := func() entities.Stocks {
+		stock := entities.NewStockUnsafe(productID, toID, 0, 1)
+		stock.InTransitQuantity = vObject.NewQuantityUnsafe(4)
+
+		return entities.Stocks{stock}
+	}
+
+	tcs := []testCase{
+		{
+			name: "happy path",
+			in:   testRequest{actorUUID: operator.ID.UUID(), transferUUID: id},
+			exp: func(t *testing.T, m mocks) (*entities.StockTransfer, error) {
+				t.Helper()
+
+				m.getTransfer.EXPECT().GetStockTransfer(gomock.Any(), transferQos).Return(newTransfer(vObject.StockTransferStatusInTransit), nil)
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(destination(), nil)
+				m.updateStock.EXPECT().UpdateStock(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, stock *entities.Stock) error {
+					assert.Equal(t, vObject.NewQuantityUnsafe(5), stock.AvailableQuantity)
+					assert.Equal(t, vObject.QuantityZero, stock.InTransitQuantity)
+
+					return nil
+				})
+				m.upsertTransfer.EXPECT().UpsertStockTransfer(gomock.Any(), gomock.Any()).Return(nil)
+				m.createMovements.EXPECT().CreateProductMovements(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, movements entities.ProductMovements) error {
+					require.Len(t, movements, 1)
+					assert.Equal(t, toID, movements[0].WarehouseID)
+					assert.Equal(t, vObject.OperationTypeTransfer, movements[0].OperationType)
+
+					return nil
+				})
+
+				received := newTransfer(vObject.StockTransferStatusReceived)
+				received.ReceivedAt = &tn
+
+				return received, nil
+			},
+		},
+		{
+			name: "transfer upsert error",
+			in:   testRequest{actorUUID: operator.ID.UUID(), transferUUID: id},
+			exp: func(t *testing.T, m mocks) (*entities.StockTransfer, error) {
+				t.Helper()
+
+				m.getTransfer.EXPECT().GetStockTransfer(gomock.Any(), transferQos).Return(newTransfer(vObject.StockTransferStatusInTransit), nil)
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(destination(), nil)
+				m.updateStock.EXPECT().UpdateStock(gomock.Any(), gomock.Any()).Return(nil)
+				m.upsertTransfer.EXPECT().UpsertStockTransfer(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+				return nil, assert.AnError
+			},
+		},
+		{
+			name: "already received",
+			in:   testRequest{actorUUID: operator.ID.UUID(), transferUUID: id},
+			exp: func(t *testing.T, m mocks) (*entities.StockTransfer, error) {
+				t.Helper()
+
+				m.getTransfer.EXPECT().GetStockTransfer(gomock.Any(), transferQos).Return(newTransfer(vObject.StockTransferStatusReceived), nil)
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(destination(), nil)
+
+				return nil, entities.ErrStockTransferNotInTransit
+			},
+		},
+		{
+			name: "destination stock is missing",
+			in:   testRequest{actorUUID: operator.ID.UUID(), transferUUID: id},
+			exp: func(t *testing.T, m mocks) (*entities.StockTransfer, error) {
+				t.Helper()
+
+				m.getTransfer.EXPECT().GetStockTransfer(gomock.Any(), transferQos).Return(newTransfer(vObject.StockTransferStatusInTransit), nil)
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(entities.Stocks{}, nil)
+
+				return nil, entities.ErrStockTransferStockMismatch
+			},
+		},
+		{
+			name: "transfer not found",
+			in:   testRequest{actorUUID: operator.ID.UUID(), transferUUID: id},
+			exp: func(t *testing.T, m mocks) (*entities.StockTransfer, error) {
+				t.Helper()
+
+				m.getTransfer.EXPECT().GetStockTransfer(gomock.Any(), transferQos).Return(nil, entities.ErrStockTransferRecNotFound)
+
+				return nil, entities.ErrStockTransferRecNotFound
+			},
+		},
+		{
+			name: "empty transfer id",
+			in:   testRequest{actorUUID: operator.ID.UUID(), transferUUID: baseUUID.Nil},
+			exp: func(t *testing.T, m mocks) (*entities.StockTransfer, error) {
+				t.Helper()
+
+				return nil, vObject.ErrEmptyID
+			},
+		},
+		{
+			name: "customer cannot manage stock",
+			in:   testRequest{actorUUID: customer.ID.UUID(), transferUUID: id},
+			exp: func(t *testing.T, _ mocks) (*entities.StockTransfer, error) {
+				t.Helper()
+
+				return nil, entities.ErrPermissionDenied
+			},
+		},
+		{
+			name: "anonymous actor",
+			in:   testRequest{actorUUID: baseUUID.Nil, transferUUID: id},
+			exp: func(t *testing.T, _ mocks) (*entities.StockTransfer, error) {
+				t.Helper()
+
+				return nil, entities.ErrPermissionDenied
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			loggerMock := log.NewLogMock(ctrl)
+			txManagerMock := trx.NewTransactionManagerMock(ctrl)
+			nowFunc := now.NewMock(ctrl)
+			uuidFunc := uuid.NewMock(ctrl)
+			m := mocks{
+				getUser:         getUser.NewGetUserByIDMock(ctrl),
+				getTransfer:     getTransfer.NewGetStockTransferMock(ctrl),
+				getStocks:       getStocks.NewGetStocksMock(ctrl),
+				updateStock:     updateStock.NewUpdateStockMock(ctrl),
+				upsertTransfer:  upsertStockTransfer.NewUpsertStockTransferMock(ctrl),
+				createMovements: createProductMovements.NewCreateProductMovementsMock(ctrl),
+			}
+
+			nowFunc.EXPECT().Now().AnyTimes().Return(tn)
+			m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(operator.ID)).AnyTimes().Return(&operator, nil)
+			m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(customer.ID)).AnyTimes().Return(&customer, nil)
+			uuidFunc.EXPECT().UUID().AnyTimes().Return(baseUUID.New())
+			txManagerMock.EXPECT().Do(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				},
+			)
+
+			uc, err := NewUseCase(
+				usecase.WithTransactionManager[*UseCase](txManagerMock),
+				usecase.WithLogger[*UseCase](loggerMock),
+				usecase.WithNowFunc[*UseCase](nowFunc),
+				usecase.WithUUIDFunc[*UseCase](uuidFunc),
+				WithGetUserQuery(getUser.NewQueryHandler(m.getUser)),
+				WithGetStockTransferQuery(getTransfer.NewQueryHandler(m.getTransfer)),
+				WithGetStocksQuery(getStocks.NewQueryHandler(m.getStocks)),
+				WithUpdateStockCommand(updateStock.NewCommandHandler(m.updateStock)),
+				WithUpsertStockTransferCommand(upsertStockTransfer.NewCommandHandler(m.upsertTransfer)),
+				WithCreateProductMovementsCommand(createProductMovements.NewCommandHandler(m.createMovements)),
+			)
+			require.NoError(t, err)
+
+			loggerMock.EXPECT().With(
+				log.String("actorUUID", tc.in.GetActorID().String()),
+				log.String("transferUUID", tc.in.GetTransferID().String()),
+			).Return(loggerMock)
+			loggerMock.EXPECT().Debug(gomock.Any(), "START usecase")
+
+			expTransfer, expErr := tc.exp(t, m)
+			if expErr == nil {
+				loggerMock.EXPECT().Debug(gomock.Any(), "END usecase")
+			} else {
+				loggerMock.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+			}
+
+			transfer, err := uc.Run(context.Background(), tc.in)
+			require.ErrorIs(t, err, expErr)
+
+			if expTransfer != nil {
+				// генератор времени, установленный use case, в сравнении не участвует
+				transfer.WithNowGenerator = expTransfer.WithNowGenerator
+			}
+
+			assert.Equal(t, expTransfer, transfer)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package transferstock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	createStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
+	upsertStockTransfer "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock_transfer/upsert"
+	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func TestConfiguration(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	loggerMock := log.NewLogMock(ctrl)
+	txManagerMock := trx.NewTransactionManagerMock(ctrl)
+	getStocksMock := getStocks.NewGetStocksMock(ctrl)
+	createStockMock := createStock.NewCreateStockMock(ctrl)
+	updateStockMock := updateStock.NewUpdateStockMock(ctrl)
+	upsertTransferMock := upsertStockTransfer.NewUpsertStockTransferMock(ctrl)
+	createMovementsMock := createProductMovements.NewCreateProductMovementsMock(ctrl)
+	getUserMock := getUser.NewGetUserByIDMock(ctrl)
+
+	cfgs := []usecase.Configuration[*UseCase]{
+		usecase.WithTransactionManager[*UseCase](txManagerMock),
+		usecase.WithLogger[*UseCase](loggerMock),
+		WithGetStocksQuery(getStocks.NewQueryHandler(getStocksMock)),
+		WithCreateStockCommand(createStock.NewCommandHandler(createStockMock)),
+		WithUpdateStockCommand(updateStock.NewCommandHandler(updateStockMock)),
+		WithUpsertStockTransferCommand(upsertStockTransfer.NewCommandHandler(upsertTransferMock)),
+		WithCreateProductMovementsCommand(createProductMovements.NewCommandHandler(createMovementsMock)),
+		WithGetUserQuery(getUser.NewQueryHandler(getUserMock)),
+	}
+
+	f := WithGetStocksQuery(nil)
+	uc, err := NewUseCase(f)
+	require.Error(t, err)
+	assert.Empty(t, uc)
+
+	f = WithCreateStockCommand(nil)
+	uc, err = NewUseCase(f)
+	require.Error(t, err)
+	assert.Empty(t, uc)
+
+	f = WithUpdateStockCommand(nil)
+	uc, err = NewUseCase(f)
+	require.Error(t, err)
+	assert.Empty(t, uc)
+
+	f = WithUpsertStockTransferCommand(nil)
+	uc, err = NewUseCase(f)
+	require.Error(t, err)
+	assert.Empty(t, uc)
+
+	f = WithCreateProductMovementsCommand(nil)
+	uc, err = NewUseCase(f)
+	require.Error(t, err)
+	assert.Empty(t, uc)
+
+	f = WithGetUserQuery(nil)
+	uc, err = NewUseCase(f)
+	require.Error(t, err)
+	assert.Empty(t, uc)
+
+	uc, err = NewUseCase(nil)
+	require.ErrorIs(t, err, checker.ErrInitError)
+	require.Empty(t, uc)
+
+	uc, err = NewUseCase(cfgs...)
+	require.NoError(t, err)
+	assert.NotEmpty(t, uc)
+}
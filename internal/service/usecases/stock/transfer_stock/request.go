@@ -0,0 +1,11 @@
+package transferstock
+
+import "github.com/google/uuid"
+
+type Requestable interface {
+	GetActorID() uuid.UUID
+	GetProductID() uuid.UUID
+	GetFromWarehouseID() uuid.UUID
+	GetToWarehouseID() uuid.UUID
+	GetQuantity() uint64
+}
@@ -0,0 +1,33 @@
+package transferstock
+
+import "github.com/google/uuid"
+
+type testRequest struct {
+	actorUUID         uuid.UUID
+	productUUID       uuid.UUID
+	fromWarehouseUUID uuid.UUID
+	toWarehouseUUID   uuid.UUID
+	quantity          uint64
+}
+
+var _ Requestable = (*testRequest)(nil)
+
+func (t testRequest) GetActorID() uuid.UUID {
+	return t.actorUUID
+}
+
+func (t testRequest) GetProductID() uuid.UUID {
+	return t.productUUID
+}
+
+func (t testRequest) GetFromWarehouseID() uuid.UUID {
+	return t.fromWarehouseUUID
+}
+
+func (t testRequest) GetToWarehouseID() uuid.UUID {
+	return t.toWarehouseUUID
+}
+
+func (t testRequest) GetQuantity() uint64 {
+	return t.quantity
+}
@@ -0,0 +1,200 @@
+package transferstock
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	createStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
+	upsertStockTransfer "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock_transfer/upsert"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+// UseCase отгружает товар со склада-источника на склад-получатель. Товар остаётся в пути
+// до приёмки (см. usecases/stock/receive_transfer).
+type UseCase struct {
+	uuid.WithUUIDGenerator
+	now.WithNowGenerator
+	checker.WithCheck
+	tx.WithTransactionManager
+	log.WithLogger
+
+	// Query handlers
+	getStocksQuery *getStocks.QueryHandler
+	getUserQuery   *getUser.QueryHandler
+
+	// Command handlers
+	createStockCmd     *createStock.CommandHandler
+	updateStockCmd     *updateStock.CommandHandler
+	upsertTransferCmd  *upsertStockTransfer.CommandHandler
+	createMovementsCmd *createProductMovements.CommandHandler
+}
+
+func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
+	uc := &UseCase{}
+
+	// Apply all Configurations passed in
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			return nil, checker.ErrInitError
+		}
+
+		err := cfg(uc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Check(*uc); err != nil {
+		return nil, err
+	}
+
+	return uc, nil
+}
+
+func (uc *UseCase) Run(ctx context.Context, req Requestable) (*entities.StockTransfer, error) {
+	ctx, span := tracing.Start(ctx, "usecase.transferStock")
+	defer span.End()
+
+	l := uc.Logger().With(
+		log.String("actorUUID", req.GetActorID().String()),
+		log.String("productUUID", req.GetProductID().String()),
+		log.String("fromWarehouseUUID", req.GetFromWarehouseID().String()),
+		log.String("toWarehouseUUID", req.GetToWarehouseID().String()),
+		log.Uint64("quantity", req.GetQuantity()),
+	)
+
+	l.Debug(ctx, "START usecase")
+
+	if _, err := usecase.Authorize(ctx, uc.getUserQuery, req.GetActorID(), vObject.PermissionManageStock); err != nil {
+		l.Error(ctx, "STOP usecase! usecase.Authorize error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[transferStock - usecase.Authorize error]: %w", err))
+	}
+
+	transfer, err := uc.newTransfer(req)
+	if err != nil {
+		l.Error(ctx, "STOP usecase! uc.newTransfer error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[transferStock - uc.newTransfer error]: %w", err))
+	}
+
+	err = uc.TransactionDo(ctx, func(ctx context.Context) error {
+		return uc.dispatch(ctx, &transfer)
+	})
+	if err != nil {
+		l.Error(ctx, "STOP usecase! transaction error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[transferStock - uc.TransactionDo error]: %w", err))
+	}
+
+	l.Debug(ctx, "END usecase")
+
+	return &transfer, nil
+}
+
+func (uc *UseCase) newTransfer(req Requestable) (entities.StockTransfer, error) {
+	productID, err := vObject.NewProductIDFromUUID(req.GetProductID())
+	if err != nil {
+		return entities.StockTransfer{}, err
+	}
+
+	fromWarehouseID, err := vObject.NewWarehouseIDFromUUID(req.GetFromWarehouseID())
+	if err != nil {
+		return entities.StockTransfer{}, err
+	}
+
+	toWarehouseID, err := vObject.NewWarehouseIDFromUUID(req.GetToWarehouseID())
+	if err != nil {
+		return entities.StockTransfer{}, err
+	}
+
+	return entities.NewStockTransfer(
+		productID,
+		fromWarehouseID,
+		toWarehouseID,
+		vObject.NewQuantityUnsafe(req.GetQuantity()),
+		entities.WithUUIDFunc[*entities.StockTransfer](uc.GetUUIDGen()),
+		entities.WithNowFunc[*entities.StockTransfer](uc.GetNowGen()),
+	)
+}
+
+func (uc *UseCase) dispatch(ctx context.Context, transfer *entities.StockTransfer) error {
+	// 1. Получаем остатки товара по складам с блокировкой до конца транзакции
+	stocks, err := uc.getStocksQuery.Handle(ctx, getStocks.NewQueryByProductIDForUpdateUnsafe(transfer.ProductID))
+	if err != nil {
+		return fmt.Errorf("[transferStock - uc.getStocksQuery.Handle error]: %w", err)
+	}
+
+	if stocks.GetByWarehouseIDUnsafe(transfer.FromWarehouseID) == nil {
+		return fmt.Errorf("[transferStock - source stock error]: %w", entities.ErrNotEnoughProductIntStocks)
+	}
+
+	// на складе-получателе товара может ещё не быть: создаём пустой остаток и блокируем остатки заново,
+	// иначе параллельная отгрузка на тот же склад перезапишет количество товара в пути
+	if stocks.GetByWarehouseIDUnsafe(transfer.ToWarehouseID) == nil {
+		stock := entities.NewStockUnsafe(
+			transfer.ProductID,
+			transfer.ToWarehouseID,
+			vObject.QuantityZero,
+			vObject.QuantityZero,
+			entities.WithNowFunc[*entities.Stock](uc.GetNowGen()),
+		)
+
+		if err = uc.createStockCmd.Handle(ctx, createStock.NewCommandUnsafe(&stock)); err != nil {
+			return fmt.Errorf("[transferStock - uc.createStockCmd.Handle error]: %w", err)
+		}
+
+		stocks, err = uc.getStocksQuery.Handle(ctx, getStocks.NewQueryByProductIDForUpdateUnsafe(transfer.ProductID))
+		if err != nil {
+			return fmt.Errorf("[transferStock - uc.getStocksQuery.Handle error]: %w", err)
+		}
+	}
+
+	source := stocks.GetByWarehouseIDUnsafe(transfer.FromWarehouseID)
+	destination := stocks.GetByWarehouseIDUnsafe(transfer.ToWarehouseID)
+
+	if source == nil || destination == nil {
+		return fmt.Errorf("[transferStock - stocks error]: %w", entities.ErrNotEnoughProductIntStocks)
+	}
+
+	// 2. Отгружаем товар: остаток источника уменьшается, на получателе товар числится в пути
+	movement, err := transfer.Dispatch(
+		source,
+		destination,
+		entities.WithUUIDFunc[*entities.ProductMovement](uc.GetUUIDGen()),
+		entities.WithNowFunc[*entities.ProductMovement](uc.GetNowGen()),
+	)
+	if err != nil {
+		return fmt.Errorf("[transferStock - transfer.Dispatch error]: %w", err)
+	}
+
+	// 3. Сохраняем остатки обоих складов
+	for _, stock := range []*entities.Stock{source, destination} {
+		if err = uc.updateStockCmd.Handle(ctx, updateStock.NewCommandUnsafe(stock)); err != nil {
+			return fmt.Errorf("[transferStock - uc.updateStockCmd.Handle error]: %w", err)
+		}
+	}
+
+	// 4. Сохраняем перемещение и движение по складу-источнику
+	if err = uc.upsertTransferCmd.Handle(ctx, upsertStockTransfer.NewCommandUnsafe(transfer)); err != nil {
+		return fmt.Errorf("[transferStock - uc.upsertTransferCmd.Handle error]: %w", err)
+	}
+
+	if err = uc.createMovementsCmd.Handle(ctx, createProductMovements.NewCommandUnsafe(entities.ProductMovements{movement})); err != nil {
+		return fmt.Errorf("[transferStock - uc.createMovementsCmd.Handle error]: %w", err)
+	}
+
+	return nil
+}
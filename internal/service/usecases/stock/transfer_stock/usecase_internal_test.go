@@ -0,0 +1,337 @@
+package transferstock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	createStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
+	upsertStockTransfer "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock_transfer/upsert"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryoptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+type mocks struct {
+	getStocks       *getStocks.GetStocksMock
+	createStock     *createStock.CreateStockMock
+	updateStock     *updateStock.UpdateStockMock
+	upsertTransfer  *upsertStockTransfer.UpsertStockTransferMock
+	createMovements *createProductMovements.CreateProductMovementsMock
+}
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		in   testRequest
+		exp  func(t *testing.T, in testRequest, m mocks) error
+	}
+
+	tn := time.Now()
+	operator := entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), Role: vObject.RoleOperator}
+	customer := entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), Role: vObject.RoleCustomer}
+	actorQos := func(id vObject.UserID) *queryoptions.UserQueryOptions {
+		return queryoptions.NewUserQueryOptions(queryoptions.WithUserID(id))
+	}
+	id := baseUUID.New()
+	productID := vObject.NewProductIDFromUUIDUnsafe(baseUUID.New())
+	fromID := vObject.NewWarehouseIDFromUUIDUnsafe(baseUUID.New())
+	toID := vObject.NewWarehouseIDFromUUIDUnsafe(baseUUID.New())
+
+	stocksQos := queryoptions.NewStockQueryOptions(
+		queryoptions.WithStockProductID(productID),
+		queryoptions.WithForUpdate[*queryoptions.StockQueryOptions](),
+	)
+	request := testRequest{
+		actorUUID:         operator.ID.UUID(),
+		productUUID:       productID.UUID(),
+		fromWarehouseUUID: fromID.UUID(),
+		toWarehouseUUID:   toID.UUID(),
+		quantity:          4,
+	}
+
+	tcs := []testCase{
+		{
+			name: "happy path with new destination stock",
+			in:   request,
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				// склада-получателя нет: создаём пустой остаток и блокируем остатки заново,
+				// к этому моменту параллельная отгрузка могла уже записать на получателя товар в пути
+				inTransit := entities.NewStockUnsafe(productID, toID, 0, 0)
+				inTransit.InTransitQuantity = vObject.NewQuantityUnsafe(2)
+
+				gomock.InOrder(
+					m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(entities.Stocks{
+						entities.NewStockUnsafe(productID, fromID, 1, 5),
+					}, nil),
+					m.createStock.EXPECT().CreateStock(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, stock *entities.Stock) error {
+						assert.Equal(t, productID, stock.ProductID)
+						assert.Equal(t, toID, stock.WarehouseID)
+						assert.Equal(t, vObject.QuantityZero, stock.AvailableQuantity)
+						assert.Equal(t, vObject.QuantityZero, stock.InTransitQuantity)
+						assert.Equal(t, tn, stock.CreatedAt)
+
+						return nil
+					}),
+					m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(entities.Stocks{
+						entities.NewStockUnsafe(productID, fromID, 1, 5),
+						inTransit,
+					}, nil),
+				)
+				gomock.InOrder(
+					m.updateStock.EXPECT().UpdateStock(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, stock *entities.Stock) error {
+						assert.Equal(t, fromID, stock.WarehouseID)
+						assert.Equal(t, vObject.NewQuantityUnsafe(1), stock.AvailableQuantity)
+						assert.Equal(t, vObject.NewQuantityUnsafe(1), stock.ReservedQuantity)
+
+						return nil
+					}),
+					m.updateStock.EXPECT().UpdateStock(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, stock *entities.Stock) error {
+						assert.Equal(t, toID, stock.WarehouseID)
+						assert.Equal(t, vObject.QuantityZero, stock.AvailableQuantity)
+						assert.Equal(t, vObject.NewQuantityUnsafe(6), stock.InTransitQuantity, "in transit quantity of the concurrent dispatch is kept")
+
+						return nil
+					}),
+				)
+				m.upsertTransfer.EXPECT().UpsertStockTransfer(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, transfer *entities.StockTransfer) error {
+					assert.Equal(t, vObject.NewStockTransferIDFromUUIDUnsafe(id), transfer.ID)
+					assert.Equal(t, vObject.StockTransferStatusInTransit, transfer.Status)
+					assert.Equal(t, tn, transfer.DispatchedAt)
+
+					return nil
+				})
+				m.createMovements.EXPECT().CreateProductMovements(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, movements entities.ProductMovements) error {
+					require.Len(t, movements, 1)
+					assert.Equal(t, fromID, movements[0].WarehouseID)
+					assert.Equal(t, vObject.OperationTypeTransfer, movements[0].OperationType)
+					assert.Equal(t, vObject.NewQuantityUnsafe(4), movements[0].Quantity)
+
+					return nil
+				})
+
+				return nil
+			},
+		},
+		{
+			name: "movement create error",
+			in:   request,
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(entities.Stocks{
+					entities.NewStockUnsafe(productID, fromID, 0, 5),
+					entities.NewStockUnsafe(productID, toID, 0, 5),
+				}, nil)
+				m.updateStock.EXPECT().UpdateStock(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+				m.upsertTransfer.EXPECT().UpsertStockTransfer(gomock.Any(), gomock.Any()).Return(nil)
+				m.createMovements.EXPECT().CreateProductMovements(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+				return assert.AnError
+			},
+		},
+		{
+			name: "stock upsert error",
+			in:   request,
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(entities.Stocks{
+					entities.NewStockUnsafe(productID, fromID, 0, 5),
+					entities.NewStockUnsafe(productID, toID, 0, 0),
+				}, nil)
+				m.updateStock.EXPECT().UpdateStock(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+				return assert.AnError
+			},
+		},
+		{
+			name: "create destination stock error",
+			in:   request,
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(entities.Stocks{
+					entities.NewStockUnsafe(productID, fromID, 0, 5),
+				}, nil)
+				m.createStock.EXPECT().CreateStock(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+				return assert.AnError
+			},
+		},
+		{
+			name: "relock stocks error",
+			in:   request,
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(entities.Stocks{
+					entities.NewStockUnsafe(productID, fromID, 0, 5),
+				}, nil)
+				m.createStock.EXPECT().CreateStock(gomock.Any(), gomock.Any()).Return(nil)
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(nil, assert.AnError)
+
+				return assert.AnError
+			},
+		},
+		{
+			name: "not enough free stock at source",
+			in:   request,
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(entities.Stocks{
+					entities.NewStockUnsafe(productID, fromID, 2, 5),
+					entities.NewStockUnsafe(productID, toID, 0, 0),
+				}, nil)
+
+				return entities.ErrNotEnoughProductIntStocks
+			},
+		},
+		{
+			name: "no stock at source",
+			in:   request,
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(entities.Stocks{}, nil)
+
+				return entities.ErrNotEnoughProductIntStocks
+			},
+		},
+		{
+			name: "get stocks error",
+			in:   request,
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.getStocks.EXPECT().GetStocks(gomock.Any(), stocksQos).Return(nil, assert.AnError)
+
+				return assert.AnError
+			},
+		},
+		{
+			name: "same warehouse",
+			in:   testRequest{actorUUID: operator.ID.UUID(), productUUID: productID.UUID(), fromWarehouseUUID: fromID.UUID(), toWarehouseUUID: fromID.UUID(), quantity: 1},
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				return entities.ErrStockTransferSameWarehouse
+			},
+		},
+		{
+			name: "empty warehouse id",
+			in:   testRequest{actorUUID: operator.ID.UUID(), productUUID: productID.UUID(), fromWarehouseUUID: fromID.UUID(), quantity: 1},
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				return vObject.ErrEmptyID
+			},
+		},
+		{
+			name: "customer cannot manage stock",
+			in:   testRequest{actorUUID: customer.ID.UUID(), productUUID: productID.UUID(), fromWarehouseUUID: fromID.UUID(), toWarehouseUUID: toID.UUID(), quantity: 1},
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				return entities.ErrPermissionDenied
+			},
+		},
+		{
+			name: "anonymous actor",
+			in:   testRequest{actorUUID: baseUUID.Nil, productUUID: productID.UUID(), fromWarehouseUUID: fromID.UUID(), toWarehouseUUID: toID.UUID(), quantity: 1},
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				return entities.ErrPermissionDenied
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			loggerMock := log.NewLogMock(ctrl)
+			txManagerMock := trx.NewTransactionManagerMock(ctrl)
+			nowFunc := now.NewMock(ctrl)
+			uuidFunc := uuid.NewMock(ctrl)
+			getUserMock := getUser.NewGetUserByIDMock(ctrl)
+			m := mocks{
+				getStocks:       getStocks.NewGetStocksMock(ctrl),
+				createStock:     createStock.NewCreateStockMock(ctrl),
+				updateStock:     updateStock.NewUpdateStockMock(ctrl),
+				upsertTransfer:  upsertStockTransfer.NewUpsertStockTransferMock(ctrl),
+				createMovements: createProductMovements.NewCreateProductMovementsMock(ctrl),
+			}
+
+			nowFunc.EXPECT().Now().AnyTimes().Return(tn)
+			getUserMock.EXPECT().GetByID(gomock.Any(), actorQos(operator.ID)).AnyTimes().Return(&operator, nil)
+			getUserMock.EXPECT().GetByID(gomock.Any(), actorQos(customer.ID)).AnyTimes().Return(&customer, nil)
+			uuidFunc.EXPECT().UUID().AnyTimes().Return(id)
+			txManagerMock.EXPECT().Do(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				},
+			)
+
+			uc, err := NewUseCase(
+				usecase.WithTransactionManager[*UseCase](txManagerMock),
+				usecase.WithLogger[*UseCase](loggerMock),
+				usecase.WithNowFunc[*UseCase](nowFunc),
+				usecase.WithUUIDFunc[*UseCase](uuidFunc),
+				WithGetUserQuery(getUser.NewQueryHandler(getUserMock)),
+				WithGetStocksQuery(getStocks.NewQueryHandler(m.getStocks)),
+				WithCreateStockCommand(createStock.NewCommandHandler(m.createStock)),
+				WithUpdateStockCommand(updateStock.NewCommandHandler(m.updateStock)),
+				WithUpsertStockTransferCommand(upsertStockTransfer.NewCommandHandler(m.upsertTransfer)),
+				WithCreateProductMovementsCommand(createProductMovements.NewCommandHandler(m.createMovements)),
+			)
+			require.NoError(t, err)
+
+			loggerMock.EXPECT().With(
+				log.String("actorUUID", tc.in.GetActorID().String()),
+				log.String("productUUID", tc.in.GetProductID().String()),
+				log.String("fromWarehouseUUID", tc.in.GetFromWarehouseID().String()),
+				log.String("toWarehouseUUID", tc.in.GetToWarehouseID().String()),
+				log.Uint64("quantity", tc.in.GetQuantity()),
+			).Return(loggerMock)
+			loggerMock.EXPECT().Debug(gomock.Any(), "START usecase")
+
+			expErr := tc.exp(t, tc.in, m)
+			if expErr == nil {
+				loggerMock.EXPECT().Debug(gomock.Any(), "END usecase")
+			} else {
+				loggerMock.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+			}
+
+			transfer, err := uc.Run(context.Background(), tc.in)
+			require.ErrorIs(t, err, expErr)
+
+			if expErr == nil {
+				assert.Equal(t, vObject.StockTransferStatusInTransit, transfer.Status)
+			} else {
+				assert.Nil(t, transfer)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package writeoffstock
+
+import "github.com/google/uuid"
+
+type Requestable interface {
+	GetActorID() uuid.UUID
+	GetProductID() uuid.UUID
+	GetWarehouseID() uuid.UUID
+	GetQuantity() uint64
+	GetReason() string
+	GetDocumentRef() string
+}
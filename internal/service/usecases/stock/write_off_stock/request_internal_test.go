@@ -0,0 +1,38 @@
+package writeoffstock
+
+import "github.com/google/uuid"
+
+type testRequest struct {
+	actorUUID     uuid.UUID
+	productUUID   uuid.UUID
+	warehouseUUID uuid.UUID
+	quantity      uint64
+	reason        string
+	documentRef   string
+}
+
+var _ Requestable = (*testRequest)(nil)
+
+func (t testRequest) GetActorID() uuid.UUID {
+	return t.actorUUID
+}
+
+func (t testRequest) GetProductID() uuid.UUID {
+	return t.productUUID
+}
+
+func (t testRequest) GetWarehouseID() uuid.UUID {
+	return t.warehouseUUID
+}
+
+func (t testRequest) GetQuantity() uint64 {
+	return t.quantity
+}
+
+func (t testRequest) GetReason() string {
+	return t.reason
+}
+
+func (t testRequest) GetDocumentRef() string {
+	return t.documentRef
+}
@@ -0,0 +1,179 @@
+package writeoffstock
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
+	createProductMovements "github.com/smgladkovskiy/warehouse-task/internal/service/commands/product_movement/create"
+	updateStock "github.com/smgladkovskiy/warehouse-task/internal/service/commands/stock/update"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+// UseCase списывает со склада испорченный, утерянный или просроченный товар с указанием причины
+// и документа-основания. Зарезервированный товар списать нельзя.
+type UseCase struct {
+	uuid.WithUUIDGenerator
+	now.WithNowGenerator
+	checker.WithCheck
+	tx.WithTransactionManager
+	log.WithLogger
+
+	// Query handlers
+	getStocksQuery *getStocks.QueryHandler
+	getUserQuery   *getUser.QueryHandler
+
+	// Command handlers
+	updateStockCmd     *updateStock.CommandHandler
+	createMovementsCmd *createProductMovements.CommandHandler
+}
+
+type writeOff struct {
+	productID   vObject.ProductID
+	warehouseID vObject.WarehouseID
+	quantity    vObject.Quantity
+	reason      vObject.MovementReason
+	document    vObject.DocumentReference
+}
+
+func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
+	uc := &UseCase{}
+
+	// Apply all Configurations passed in
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			return nil, checker.ErrInitError
+		}
+
+		err := cfg(uc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Check(*uc); err != nil {
+		return nil, err
+	}
+
+	return uc, nil
+}
+
+func (uc *UseCase) Run(ctx context.Context, req Requestable) (*entities.ProductMovement, error) {
+	ctx, span := tracing.Start(ctx, "usecase.writeOffStock")
+	defer span.End()
+
+	l := uc.Logger().With(
+		log.String("actorUUID", req.GetActorID().String()),
+		log.String("productUUID", req.GetProductID().String()),
+		log.String("warehouseUUID", req.GetWarehouseID().String()),
+		log.Uint64("quantity", req.GetQuantity()),
+		log.String("reason", req.GetReason()),
+	)
+
+	l.Debug(ctx, "START usecase")
+
+	if _, err := usecase.Authorize(ctx, uc.getUserQuery, req.GetActorID(), vObject.PermissionManageStock); err != nil {
+		l.Error(ctx, "STOP usecase! usecase.Authorize error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[writeOffStock - usecase.Authorize error]: %w", err))
+	}
+
+	in, err := newWriteOff(req)
+	if err != nil {
+		l.Error(ctx, "STOP usecase! newWriteOff error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[writeOffStock - newWriteOff error]: %w", err))
+	}
+
+	var movement entities.ProductMovement
+
+	err = uc.TransactionDo(ctx, func(ctx context.Context) error {
+		movement, err = uc.writeOff(ctx, in)
+
+		return err
+	})
+	if err != nil {
+		l.Error(ctx, "STOP usecase! transaction error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[writeOffStock - uc.TransactionDo error]: %w", err))
+	}
+
+	l.Debug(ctx, "END usecase")
+
+	return &movement, nil
+}
+
+func newWriteOff(req Requestable) (writeOff, error) {
+	productID, err := vObject.NewProductIDFromUUID(req.GetProductID())
+	if err != nil {
+		return writeOff{}, err
+	}
+
+	warehouseID, err := vObject.NewWarehouseIDFromUUID(req.GetWarehouseID())
+	if err != nil {
+		return writeOff{}, err
+	}
+
+	reason, err := vObject.NewMovementReason(vObject.OperationTypeWriteOff, req.GetReason())
+	if err != nil {
+		return writeOff{}, err
+	}
+
+	document, err := vObject.NewDocumentReference(req.GetDocumentRef())
+	if err != nil {
+		return writeOff{}, err
+	}
+
+	return writeOff{
+		productID:   productID,
+		warehouseID: warehouseID,
+		quantity:    vObject.NewQuantityUnsafe(req.GetQuantity()),
+		reason:      reason,
+		document:    document,
+	}, nil
+}
+
+func (uc *UseCase) writeOff(ctx context.Context, in writeOff) (entities.ProductMovement, error) {
+	// 1. Получаем остатки товара по складам с блокировкой до конца транзакции
+	stocks, err := uc.getStocksQuery.Handle(ctx, getStocks.NewQueryByProductIDForUpdateUnsafe(in.productID))
+	if err != nil {
+		return entities.ProductMovement{}, fmt.Errorf("[writeOffStock - uc.getStocksQuery.Handle error]: %w", err)
+	}
+
+	stock := stocks.GetByWarehouseIDUnsafe(in.warehouseID)
+	if stock == nil {
+		return entities.ProductMovement{}, fmt.Errorf("[writeOffStock - stock error]: %w", entities.ErrNotEnoughProductIntStocks)
+	}
+
+	// 2. Уменьшаем остаток, не затрагивая резерв
+	movement, err := stock.WriteOff(
+		in.quantity,
+		in.reason,
+		in.document,
+		entities.WithUUIDFunc[*entities.ProductMovement](uc.GetUUIDGen()),
+		entities.WithNowFunc[*entities.ProductMovement](uc.GetNowGen()),
+	)
+	if err != nil {
+		return entities.ProductMovement{}, fmt.Errorf("[writeOffStock - stock.WriteOff error]: %w", err)
+	}
+
+	// 3. Сохраняем остаток и движение списания
+	if err = uc.updateStockCmd.Handle(ctx, updateStock.NewCommandUnsafe(stock)); err != nil {
+		return entities.ProductMovement{}, fmt.Errorf("[writeOffStock - uc.updateStockCmd.Handle error]: %w", err)
+	}
+
+	if err = uc.createMovementsCmd.Handle(ctx, createProductMovements.NewCommandUnsafe(entities.ProductMovements{movement})); err != nil {
+		return entities.ProductMovement{}, fmt.Errorf("[writeOffStock - uc.createMovementsCmd.Handle error]: %w", err)
+	}
+
+	return movement, nil
+}
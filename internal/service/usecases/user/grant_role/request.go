@@ -0,0 +1,10 @@
+package grantrole
+
+import "github.com/google/uuid"
+
+type Requestable interface {
+	// GetActorID администратор, назначающий роль.
+	GetActorID() uuid.UUID
+	GetUserID() uuid.UUID
+	GetRole() string
+}
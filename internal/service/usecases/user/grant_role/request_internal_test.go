@@ -0,0 +1,23 @@
+package grantrole
+
+import "github.com/google/uuid"
+
+type testRequest struct {
+	actorUUID uuid.UUID
+	userUUID  uuid.UUID
+	role      string
+}
+
+var _ Requestable = (*testRequest)(nil)
+
+func (t testRequest) GetActorID() uuid.UUID {
+	return t.actorUUID
+}
+
+func (t testRequest) GetUserID() uuid.UUID {
+	return t.userUUID
+}
+
+func (t testRequest) GetRole() string {
+	return t.role
+}
@@ -0,0 +1,126 @@
+package grantrole
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	updateUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/update"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+// UseCase назначает пользователю роль. Доступен только пользователям с разрешением на назначение ролей.
+type UseCase struct {
+	now.WithNowGenerator
+	checker.WithCheck
+	tx.WithTransactionManager
+	log.WithLogger
+
+	// Query handlers
+	getUserQuery *getUser.QueryHandler
+
+	// Command handlers
+	updateUserCmd *updateUser.CommandHandler
+}
+
+func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
+	uc := &UseCase{}
+
+	// Apply all Configurations passed in
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			return nil, checker.ErrInitError
+		}
+
+		err := cfg(uc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Check(*uc); err != nil {
+		return nil, err
+	}
+
+	return uc, nil
+}
+
+func (uc *UseCase) Run(ctx context.Context, req Requestable) (*entities.User, error) {
+	ctx, span := tracing.Start(ctx, "usecase.grantRole")
+	defer span.End()
+
+	l := uc.Logger().With(
+		log.String("actorUUID", req.GetActorID().String()),
+		log.String("userUUID", req.GetUserID().String()),
+		log.String("role", req.GetRole()),
+	)
+
+	l.Debug(ctx, "START usecase")
+
+	role, err := vObject.NewRole(req.GetRole())
+	if err != nil {
+		l.Error(ctx, "STOP usecase! vObject.NewRole error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[grantRole - vObject.NewRole error]: %w", err))
+	}
+
+	userID, err := vObject.NewUserIDFromUUID(req.GetUserID())
+	if err != nil {
+		l.Error(ctx, "STOP usecase! vObject.NewUserIDFromUUID error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[grantRole - vObject.NewUserIDFromUUID error]: %w", err))
+	}
+
+	actor, err := usecase.Authorize(ctx, uc.getUserQuery, req.GetActorID(), vObject.PermissionGrantRoles)
+	if err != nil {
+		l.Error(ctx, "STOP usecase! usecase.Authorize error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[grantRole - usecase.Authorize error]: %w", err))
+	}
+
+	// иначе последний администратор может лишить себя права назначать роли
+	if actor.ID == userID {
+		l.Error(ctx, "STOP usecase! own role change", log.Err(entities.ErrOwnRoleChange))
+
+		return nil, tracing.Error(span, fmt.Errorf("[grantRole - own role change]: %w", entities.ErrOwnRoleChange))
+	}
+
+	var user *entities.User
+
+	err = uc.TransactionDo(ctx, func(ctx context.Context) error {
+		// 1. Получаем пользователя с блокировкой записи
+		user, err = uc.getUserQuery.Handle(ctx, getUser.NewQueryForUpdate(userID))
+		if err != nil {
+			return fmt.Errorf("[grantRole - uc.getUserQuery.Handle error]: %w", err)
+		}
+
+		// 2. Назначаем роль и сохраняем пользователя, если роль изменилась
+		user.SetNowGen(uc.GetNowGen())
+
+		if !user.GrantRole(role) {
+			return nil
+		}
+
+		if err = uc.updateUserCmd.Handle(ctx, updateUser.NewCommandUnsafe(user)); err != nil {
+			return fmt.Errorf("[grantRole - uc.updateUserCmd.Handle error]: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		l.Error(ctx, "STOP usecase! transaction error", log.Err(err))
+
+		return nil, tracing.Error(span, fmt.Errorf("[grantRole - uc.TransactionDo error]: %w", err))
+	}
+
+	l.Debug(ctx, "END usecase")
+
+	return user, nil
+}
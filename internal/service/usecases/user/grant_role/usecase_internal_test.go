@@ -0,0 +1,231 @@
+package grantrole
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	updateUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/update"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+type mocks struct {
+	logger     *log.LogMock
+	getUser    *getUser.GetUserByIDMock
+	updateUser *updateUser.UpdateUserMock
+}
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		in   testRequest
+		exp  func(t *testing.T, in testRequest, m mocks) (*entities.User, error)
+	}
+
+	tn := time.Now().UTC().Truncate(time.Second)
+	created := tn.Add(-time.Hour)
+	admin := &entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), Role: vObject.RoleAdmin}
+	operator := &entities.User{ID: vObject.NewUserIDFromUUIDUnsafe(baseUUID.New()), Role: vObject.RoleOperator}
+	userID := vObject.NewUserIDFromUUIDUnsafe(baseUUID.New())
+
+	newUser := func(role vObject.Role) *entities.User {
+		return &entities.User{ID: userID, Role: role, CreatedAt: created, UpdatedAt: created}
+	}
+
+	actorQos := func(id vObject.UserID) *queryOptions.UserQueryOptions {
+		return queryOptions.NewUserQueryOptions(queryOptions.WithUserID(id))
+	}
+	getUserQos := queryOptions.NewUserQueryOptions(
+		queryOptions.WithUserID(userID),
+		queryOptions.WithForUpdate[*queryOptions.UserQueryOptions](),
+	)
+	request := testRequest{actorUUID: admin.ID.UUID(), userUUID: userID.UUID(), role: "operator"}
+
+	tcs := []testCase{
+		{
+			name: "happy path",
+			in:   request,
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.User, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(admin.ID)).Return(admin, nil)
+				m.getUser.EXPECT().GetByID(gomock.Any(), getUserQos).Return(newUser(vObject.RoleCustomer), nil)
+				m.updateUser.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *entities.User) error {
+					assert.Equal(t, vObject.RoleOperator, u.Role)
+					assert.Equal(t, tn, u.UpdatedAt)
+
+					return nil
+				})
+
+				exp := newUser(vObject.RoleOperator)
+				exp.UpdatedAt = tn
+
+				return exp, nil
+			},
+		},
+		{
+			name: "role already granted",
+			in:   request,
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.User, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(admin.ID)).Return(admin, nil)
+				m.getUser.EXPECT().GetByID(gomock.Any(), getUserQos).Return(newUser(vObject.RoleOperator), nil)
+
+				return newUser(vObject.RoleOperator), nil
+			},
+		},
+		{
+			name: "update user error",
+			in:   request,
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.User, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(admin.ID)).Return(admin, nil)
+				m.getUser.EXPECT().GetByID(gomock.Any(), getUserQos).Return(newUser(vObject.RoleCustomer), nil)
+				m.updateUser.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(assert.AnError)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+
+				return nil, assert.AnError
+			},
+		},
+		{
+			name: "user not found",
+			in:   request,
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.User, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(admin.ID)).Return(admin, nil)
+				m.getUser.EXPECT().GetByID(gomock.Any(), getUserQos).Return(nil, entities.ErrUserRecNotFound)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+
+				return nil, entities.ErrUserRecNotFound
+			},
+		},
+		{
+			name: "own role change",
+			in:   testRequest{actorUUID: admin.ID.UUID(), userUUID: admin.ID.UUID(), role: "customer"},
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.User, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(admin.ID)).Return(admin, nil)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! own role change", gomock.Any())
+
+				return nil, entities.ErrOwnRoleChange
+			},
+		},
+		{
+			name: "operator cannot grant roles",
+			in:   testRequest{actorUUID: operator.ID.UUID(), userUUID: userID.UUID(), role: "operator"},
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.User, error) {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByID(gomock.Any(), actorQos(operator.ID)).Return(operator, nil)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! usecase.Authorize error", gomock.Any())
+
+				return nil, entities.ErrPermissionDenied
+			},
+		},
+		{
+			name: "anonymous actor",
+			in:   testRequest{userUUID: userID.UUID(), role: "operator"},
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.User, error) {
+				t.Helper()
+
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! usecase.Authorize error", gomock.Any())
+
+				return nil, entities.ErrPermissionDenied
+			},
+		},
+		{
+			name: "empty user id",
+			in:   testRequest{actorUUID: admin.ID.UUID(), role: "operator"},
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.User, error) {
+				t.Helper()
+
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! vObject.NewUserIDFromUUID error", gomock.Any())
+
+				return nil, vObject.ErrEmptyID
+			},
+		},
+		{
+			name: "unknown role",
+			in:   testRequest{actorUUID: admin.ID.UUID(), userUUID: userID.UUID(), role: "root"},
+			exp: func(t *testing.T, _ testRequest, m mocks) (*entities.User, error) {
+				t.Helper()
+
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! vObject.NewRole error", gomock.Any())
+
+				return nil, vObject.ErrUnknownRole
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := mocks{
+				logger:     log.NewLogMock(ctrl),
+				getUser:    getUser.NewGetUserByIDMock(ctrl),
+				updateUser: updateUser.NewUpdateUserMock(ctrl),
+			}
+			txManagerMock := trx.NewTransactionManagerMock(ctrl)
+			nowFunc := now.NewMock(ctrl)
+
+			nowFunc.EXPECT().Now().AnyTimes().Return(tn)
+			txManagerMock.EXPECT().Do(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				},
+			)
+
+			uc, err := NewUseCase(
+				usecase.WithTransactionManager[*UseCase](txManagerMock),
+				usecase.WithLogger[*UseCase](m.logger),
+				usecase.WithNowFunc[*UseCase](nowFunc),
+				WithGetUserQuery(getUser.NewQueryHandler(m.getUser)),
+				WithUpdateUserCommand(updateUser.NewCommandHandler(m.updateUser)),
+			)
+			require.NoError(t, err)
+
+			m.logger.EXPECT().With(
+				log.String("actorUUID", tc.in.GetActorID().String()),
+				log.String("userUUID", tc.in.GetUserID().String()),
+				log.String("role", tc.in.GetRole()),
+			).Return(m.logger)
+			m.logger.EXPECT().Debug(gomock.Any(), "START usecase")
+
+			expUser, expErr := tc.exp(t, tc.in, m)
+			if expErr == nil {
+				m.logger.EXPECT().Debug(gomock.Any(), "END usecase")
+			}
+
+			user, err := uc.Run(context.Background(), tc.in)
+			require.ErrorIs(t, err, expErr)
+
+			if expUser != nil {
+				require.NotNil(t, user)
+				// генератор времени, установленный use case, в сравнении не участвует
+				user.WithNowGenerator = expUser.WithNowGenerator
+			}
+
+			assert.Equal(t, expUser, user)
+		})
+	}
+}
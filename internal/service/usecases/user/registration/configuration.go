@@ -5,6 +5,7 @@ import (
 
 	passcrypto "github.com/smgladkovskiy/warehouse-task/internal/pkg/pass_crypto"
 	createUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/create"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
 	getUserByEmail "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_by_email"
 	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
 )
@@ -45,3 +46,12 @@ func WithPasswordHasher(hasher passcrypto.PasswordHashable) usecase.Configuratio
 	}
 
 }
+
+// WithRules задаёт правила регистрации. Без опции действуют vObject.DefaultRules.
+func WithRules(rules vObject.Rules) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		uc.rules = rules
+
+		return nil
+	}
+}
@@ -10,9 +10,11 @@ import (
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
 	passcrypto "github.com/smgladkovskiy/warehouse-task/internal/pkg/pass_crypto"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
 	createUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/create"
 	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
 	getUserByEmail "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_by_email"
 	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
 )
@@ -29,12 +31,15 @@ type UseCase struct {
 
 	// Command handlers
 	createUserCmd *createUser.CommandHandler
+
+	// rules задают минимальный возраст пользователя и длину пароля
+	rules vObject.Rules
 }
 
 //var _ handler.Authenticator = (*UseCase)(nil)
 
 func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
-	uc := &UseCase{}
+	uc := &UseCase{rules: vObject.DefaultRules()}
 
 	// Apply all Configurations passed in
 	for _, cfg := range cfgs {
@@ -56,6 +61,9 @@ func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
 }
 
 func (uc *UseCase) Run(ctx context.Context, req Requestable) (*entities.User, error) {
+	ctx, span := tracing.Start(ctx, "usecase.userRegistration")
+	defer span.End()
+
 	l := uc.Logger().With(
 		log.String("email", req.GetEmail()),
 		log.String("firstName", req.GetFirstName()),
@@ -72,20 +80,20 @@ func (uc *UseCase) Run(ctx context.Context, req Requestable) (*entities.User, er
 	if err != nil {
 		l.Error(ctx, "STOP usecase! getUserByEmail.NewQuery error", log.Err(err))
 
-		return nil, fmt.Errorf("[userRegistration - getUserByEmail.NewQuery error]: %w", err)
+		return nil, tracing.Error(span, fmt.Errorf("[userRegistration - getUserByEmail.NewQuery error]: %w", err))
 	}
 
 	existedUser, err := uc.getUserQuery.Handle(ctx, *query)
 	if err != nil && !errors.Is(err, entities.ErrUserRecNotFound) {
 		l.Error(ctx, "STOP usecase! getUserQuery.Handle error", log.Err(err))
 
-		return nil, fmt.Errorf("[userRegistration - getUserQuery.Handle error]: %w", err)
+		return nil, tracing.Error(span, fmt.Errorf("[userRegistration - getUserQuery.Handle error]: %w", err))
 	}
 
 	if existedUser != nil {
 		l.Error(ctx, "STOP usecase! user already exists", log.Err(entities.ErrUserAlreadyExists))
 
-		return nil, fmt.Errorf("[userRegistration - Run error]: %w", entities.ErrUserAlreadyExists)
+		return nil, tracing.Error(span, fmt.Errorf("[userRegistration - Run error]: %w", entities.ErrUserAlreadyExists))
 	}
 
 	// 2. сохранить (зарегистрировать) пользователя
@@ -95,21 +103,22 @@ func (uc *UseCase) Run(ctx context.Context, req Requestable) (*entities.User, er
 		req.GetLastName(),
 		req.GetMaritalStatus(),
 		req.GetBirthDate(),
+		uc.rules.MinAge,
 		entities.WithUserPasswordHasher(uc.GetHasher()),
-		entities.WithUserPassword(req.GetPassword()),
+		entities.WithUserPassword(req.GetPassword(), uc.rules.MinPasswordLength),
 		entities.WithUUIDFunc[*entities.User](uc.GetUUIDGen()),
 		entities.WithNowFunc[*entities.User](uc.GetNowGen()),
 	)
 	if err != nil {
 		l.Error(ctx, "STOP usecase! createUser.NewCommand error", log.Err(err))
 
-		return nil, fmt.Errorf("[userRegistration - createUser.NewCommand error]: %w", err)
+		return nil, tracing.Error(span, fmt.Errorf("[userRegistration - createUser.NewCommand error]: %w", err))
 	}
 
 	if err = uc.createUserCmd.Handle(ctx, *cmd); err != nil {
 		l.Error(ctx, "STOP usecase! createUserCmd.Handle error", log.Err(err))
 
-		return nil, fmt.Errorf("[userRegistration - createUserCmd.Handle error]: %w", err)
+		return nil, tracing.Error(span, fmt.Errorf("[userRegistration - createUserCmd.Handle error]: %w", err))
 	}
 
 	l.Debug(ctx, "END usecase")
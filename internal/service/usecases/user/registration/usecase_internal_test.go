@@ -18,11 +18,16 @@ import (
 	"github.com/smgladkovskiy/warehouse-task/internal/pkg/uuid"
 	createUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/create"
 	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
 	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
 	getUserByEmail "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_by_email"
 	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
 )
 
+func byEmail(email vObject.Email) *queryOptions.UserQueryOptions {
+	return queryOptions.NewUserQueryOptions(queryOptions.WithUserEmail(email))
+}
+
 func TestNewUseCase(t *testing.T) {
 	t.Parallel()
 
@@ -94,14 +99,15 @@ func TestUseCase_Run(t *testing.T) {
 					in.GetLastName(),
 					in.GetMaritalStatus(),
 					in.GetBirthDate(),
+					vObject.DefaultRules().MinAge,
 					entities.WithUserPasswordHasher(hasherMock),
-					entities.WithUserPassword(in.GetPassword()),
+					entities.WithUserPassword(in.GetPassword(), vObject.DefaultRules().MinPasswordLength),
 					entities.WithUUIDFunc[*entities.User](uuidFunc),
 					entities.WithNowFunc[*entities.User](nowFunc),
 				)
 				require.NoError(t, err)
 
-				getUserByEmailMock.EXPECT().GetByEmail(gomock.Any(), user.Email).Return(nil, entities.ErrUserRecNotFound)
+				getUserByEmailMock.EXPECT().GetByEmail(gomock.Any(), byEmail(user.Email)).Return(nil, entities.ErrUserRecNotFound)
 				createUserMock.EXPECT().CreateUser(gomock.Any(), user).Return(nil)
 				loggerMock.EXPECT().Debug(gomock.Any(), "END usecase")
 
@@ -129,14 +135,15 @@ func TestUseCase_Run(t *testing.T) {
 					in.GetLastName(),
 					in.GetMaritalStatus(),
 					in.GetBirthDate(),
+					vObject.DefaultRules().MinAge,
 					entities.WithUserPasswordHasher(hasherMock),
-					entities.WithUserPassword(in.GetPassword()),
+					entities.WithUserPassword(in.GetPassword(), vObject.DefaultRules().MinPasswordLength),
 					entities.WithUUIDFunc[*entities.User](uuidFunc),
 					entities.WithNowFunc[*entities.User](nowFunc),
 				)
 				require.NoError(t, err)
 
-				getUserByEmailMock.EXPECT().GetByEmail(gomock.Any(), user.Email).Return(nil, entities.ErrUserRecNotFound)
+				getUserByEmailMock.EXPECT().GetByEmail(gomock.Any(), byEmail(user.Email)).Return(nil, entities.ErrUserRecNotFound)
 				createUserMock.EXPECT().CreateUser(gomock.Any(), user).Return(assert.AnError)
 				loggerMock.EXPECT().Error(gomock.Any(), "STOP usecase! createUserCmd.Handle error", log.Err(assert.AnError))
 
@@ -158,7 +165,7 @@ func TestUseCase_Run(t *testing.T) {
 
 				hasherMock.EXPECT().HashAndSalt([]byte(in.GetPassword())).Return("hashed_password", nil).AnyTimes()
 
-				getUserByEmailMock.EXPECT().GetByEmail(gomock.Any(), vObject.NewEmailUnsafe(in.GetEmail())).Return(nil, entities.ErrUserRecNotFound)
+				getUserByEmailMock.EXPECT().GetByEmail(gomock.Any(), byEmail(vObject.NewEmailUnsafe(in.GetEmail()))).Return(nil, entities.ErrUserRecNotFound)
 				loggerMock.EXPECT().Error(gomock.Any(), "STOP usecase! createUser.NewCommand error", gomock.Any())
 
 				return nil, vObject.ErrPasswordLen
@@ -185,14 +192,15 @@ func TestUseCase_Run(t *testing.T) {
 					in.GetLastName(),
 					in.GetMaritalStatus(),
 					in.GetBirthDate(),
+					vObject.DefaultRules().MinAge,
 					entities.WithUserPasswordHasher(hasherMock),
-					entities.WithUserPassword(in.GetPassword()),
+					entities.WithUserPassword(in.GetPassword(), vObject.DefaultRules().MinPasswordLength),
 					entities.WithUUIDFunc[*entities.User](uuidFunc),
 					entities.WithNowFunc[*entities.User](nowFunc),
 				)
 				require.NoError(t, err)
 
-				getUserByEmailMock.EXPECT().GetByEmail(gomock.Any(), vObject.NewEmailUnsafe(in.GetEmail())).Return(user, nil)
+				getUserByEmailMock.EXPECT().GetByEmail(gomock.Any(), byEmail(vObject.NewEmailUnsafe(in.GetEmail()))).Return(user, nil)
 				loggerMock.EXPECT().Error(gomock.Any(), "STOP usecase! user already exists", gomock.Any())
 
 				return nil, entities.ErrUserAlreadyExists
@@ -211,7 +219,7 @@ func TestUseCase_Run(t *testing.T) {
 			exp: func(t *testing.T, in testRequest, loggerMock *log.LogMock, hasherMock *passcrypto.PasswordHashMock, getUserByEmailMock *getUserByEmail.GetUserMock, createUserMock *createUser.CreateUserMock) (*entities.User, error) {
 				t.Helper()
 
-				getUserByEmailMock.EXPECT().GetByEmail(gomock.Any(), vObject.NewEmailUnsafe(in.GetEmail())).Return(nil, assert.AnError)
+				getUserByEmailMock.EXPECT().GetByEmail(gomock.Any(), byEmail(vObject.NewEmailUnsafe(in.GetEmail()))).Return(nil, assert.AnError)
 				loggerMock.EXPECT().Error(gomock.Any(), "STOP usecase! getUserQuery.Handle error", gomock.Any())
 
 				return nil, assert.AnError
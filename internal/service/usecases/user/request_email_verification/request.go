@@ -0,0 +1,5 @@
+package requestemailverification
+
+type Requestable interface {
+	GetEmail() string
+}
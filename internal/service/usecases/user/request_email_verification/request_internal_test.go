@@ -0,0 +1,11 @@
+package requestemailverification
+
+type testRequest struct {
+	email string
+}
+
+var _ Requestable = (*testRequest)(nil)
+
+func (t testRequest) GetEmail() string {
+	return t.email
+}
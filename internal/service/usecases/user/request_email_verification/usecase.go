@@ -0,0 +1,144 @@
+package requestemailverification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/mail"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	upsertUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/upsert"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getUserByEmail "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_by_email"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+type UseCase struct {
+	now.WithNowGenerator
+	checker.WithCheck
+	log.WithLogger
+
+	mailer  mail.Mailer
+	linkURL string
+
+	// Query handlers
+	getUserQuery *getUserByEmail.QueryHandler
+
+	// Command handlers
+	upsertTokenCmd *upsertUserToken.CommandHandler
+
+	// rules задают срок действия токена подтверждения email
+	rules vObject.Rules
+}
+
+func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
+	uc := &UseCase{rules: vObject.DefaultRules()}
+
+	// Apply all Configurations passed in
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			return nil, checker.ErrInitError
+		}
+
+		err := cfg(uc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Check(*uc); err != nil {
+		return nil, err
+	}
+
+	return uc, nil
+}
+
+// Run выпускает токен подтверждения email и отправляет его письмом. Для неизвестного адреса и уже
+// подтверждённого email письмо не отправляется, но и ошибки нет: ответ не раскрывает, зарегистрирован ли адрес.
+func (uc *UseCase) Run(ctx context.Context, req Requestable) error {
+	ctx, span := tracing.Start(ctx, "usecase.requestEmailVerification")
+	defer span.End()
+
+	l := uc.Logger().With(log.String("email", req.GetEmail()))
+
+	l.Debug(ctx, "START usecase")
+
+	// 1. Ищем пользователя
+	query, err := getUserByEmail.NewQuery(req.GetEmail())
+	if err != nil {
+		l.Error(ctx, "STOP usecase! getUserByEmail.NewQuery error", log.Err(err))
+
+		return tracing.Error(span, fmt.Errorf("[requestEmailVerification - getUserByEmail.NewQuery error]: %w", err))
+	}
+
+	user, err := uc.getUserQuery.Handle(ctx, *query)
+	if errors.Is(err, entities.ErrUserRecNotFound) {
+		l.Debug(ctx, "STOP usecase! user not found")
+
+		return nil
+	}
+
+	if err != nil {
+		l.Error(ctx, "STOP usecase! getUserQuery.Handle error", log.Err(err))
+
+		return tracing.Error(span, fmt.Errorf("[requestEmailVerification - getUserQuery.Handle error]: %w", err))
+	}
+
+	if user.IsEmailVerified() {
+		l.Debug(ctx, "STOP usecase! email already verified")
+
+		return nil
+	}
+
+	// 2. Выпускаем токен
+	token, secret, err := entities.NewUserToken(
+		user.ID,
+		entities.UserTokenEmailVerification,
+		uc.rules.EmailVerificationTTL,
+		entities.WithNowFunc[*entities.UserToken](uc.GetNowGen()),
+	)
+	if err != nil {
+		l.Error(ctx, "STOP usecase! entities.NewUserToken error", log.Err(err))
+
+		return tracing.Error(span, fmt.Errorf("[requestEmailVerification - entities.NewUserToken error]: %w", err))
+	}
+
+	if err = uc.upsertTokenCmd.Handle(ctx, upsertUserToken.NewCommandUnsafe(&token)); err != nil {
+		l.Error(ctx, "STOP usecase! upsertTokenCmd.Handle error", log.Err(err))
+
+		return tracing.Error(span, fmt.Errorf("[requestEmailVerification - upsertTokenCmd.Handle error]: %w", err))
+	}
+
+	// 3. Отправляем письмо
+	if err = uc.mailer.Send(ctx, uc.message(user.Email, secret, token.ExpiresAt)); err != nil {
+		l.Error(ctx, "STOP usecase! mailer.Send error", log.Err(err))
+
+		return tracing.Error(span, fmt.Errorf("[requestEmailVerification - mailer.Send error]: %w", err))
+	}
+
+	l.Debug(ctx, "END usecase")
+
+	return nil
+}
+
+func (uc *UseCase) message(email vObject.Email, secret string, expiresAt time.Time) mail.Message {
+	action := "Код подтверждения: " + secret
+	if uc.linkURL != "" {
+		action = "Чтобы подтвердить адрес, перейдите по ссылке: " + uc.linkURL + secret
+	}
+
+	return mail.Message{
+		To:      string(email),
+		Subject: "Подтверждение адреса электронной почты",
+		Body: fmt.Sprintf(
+			"Здравствуйте!\n\n%s\n\nКод действует до %s.\nЕсли вы не регистрировались, просто проигнорируйте это письмо.\n",
+			action,
+			expiresAt.UTC().Format("02.01.2006 15:04 MST"),
+		),
+	}
+}
@@ -0,0 +1,66 @@
+package requestpasswordreset
+
+import (
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/mail"
+	upsertUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/upsert"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getUserByEmail "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_by_email"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func WithGetUserByEmailQuery(handler *getUserByEmail.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getUserByEmail")
+		}
+
+		uc.getUserQuery = handler
+
+		return nil
+	}
+}
+
+func WithUpsertUserTokenCommand(handler *upsertUserToken.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "upsertUserToken")
+		}
+
+		uc.upsertTokenCmd = handler
+
+		return nil
+	}
+}
+
+func WithMailer(mailer mail.Mailer) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if mailer == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "mailer")
+		}
+
+		uc.mailer = mailer
+
+		return nil
+	}
+}
+
+// WithLinkURL задаёт адрес страницы сброса пароля: секрет токена дописывается в конец адреса,
+// например https://shop.example.com/reset-password?token=. Без адреса письмо содержит только код.
+func WithLinkURL(linkURL string) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		uc.linkURL = linkURL
+
+		return nil
+	}
+}
+
+// WithRules задаёт правила, из которых берётся срок действия токена сброса.
+func WithRules(rules vObject.Rules) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		uc.rules = rules
+
+		return nil
+	}
+}
@@ -0,0 +1,59 @@
+package requestpasswordreset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/mail"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	upsertUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/upsert"
+	getUserByEmail "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_by_email"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func TestConfiguration(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	loggerMock := log.NewLogMock(ctrl)
+	nowFunc := now.NewMock(ctrl)
+	mailerMock := mail.NewMailerMock(ctrl)
+	getUserByEmailMock := getUserByEmail.NewGetUserMock(ctrl)
+	upsertUserTokenMock := upsertUserToken.NewUpsertUserTokenMock(ctrl)
+
+	cfgs := []usecase.Configuration[*UseCase]{
+		usecase.WithLogger[*UseCase](loggerMock),
+		usecase.WithNowFunc[*UseCase](nowFunc),
+		WithLinkURL(linkURL),
+		WithMailer(mailerMock),
+		WithGetUserByEmailQuery(getUserByEmail.NewQueryHandler(getUserByEmailMock)),
+		WithUpsertUserTokenCommand(upsertUserToken.NewCommandHandler(upsertUserTokenMock)),
+	}
+
+	for _, f := range []usecase.Configuration[*UseCase]{
+		WithMailer(nil),
+		WithGetUserByEmailQuery(nil),
+		WithUpsertUserTokenCommand(nil),
+	} {
+		uc, err := NewUseCase(f)
+		require.ErrorIs(t, err, usecase.ErrEmptyStructParam)
+		assert.Empty(t, uc)
+	}
+
+	uc, err := NewUseCase(nil)
+	require.ErrorIs(t, err, checker.ErrInitError)
+	require.Empty(t, uc)
+
+	uc, err = NewUseCase(cfgs[:len(cfgs)-1]...)
+	require.ErrorIs(t, err, checker.ErrInitError)
+	require.Empty(t, uc)
+
+	uc, err = NewUseCase(cfgs...)
+	require.NoError(t, err)
+	assert.NotEmpty(t, uc)
+}
@@ -0,0 +1,5 @@
+package requestpasswordreset
+
+type Requestable interface {
+	GetEmail() string
+}
@@ -0,0 +1,187 @@
+package requestpasswordreset
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/mail"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	upsertUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/upsert"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getUserByEmail "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_by_email"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+const linkURL = "https://shop.example.com/reset-password?token="
+
+var secretRe = regexp.MustCompile(regexp.QuoteMeta(linkURL) + `(\S+)`)
+
+type mocks struct {
+	logger      *log.LogMock
+	mailer      *mail.MailerMock
+	getUser     *getUserByEmail.GetUserMock
+	upsertToken *upsertUserToken.UpsertUserTokenMock
+}
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		in   testRequest
+		exp  func(t *testing.T, in testRequest, m mocks) error
+	}
+
+	tn := time.Now().UTC().Truncate(time.Second)
+	rules := vObject.DefaultRules()
+	rules.PasswordResetTTL = 30 * time.Minute
+	userID := vObject.NewUserIDFromUUIDUnsafe(baseUUID.New())
+
+	newUser := func() *entities.User {
+		return &entities.User{ID: userID, Email: vObject.NewEmailUnsafe("some@email.com")}
+	}
+
+	getUserQos := queryOptions.NewUserQueryOptions(queryOptions.WithUserEmail(vObject.NewEmailUnsafe("some@email.com")))
+
+	tcs := []testCase{
+		{
+			name: "happy path",
+			in:   testRequest{email: "some@email.com"},
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				var token *entities.UserToken
+
+				m.getUser.EXPECT().GetByEmail(gomock.Any(), getUserQos).Return(newUser(), nil)
+				m.upsertToken.EXPECT().UpsertUserToken(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, tok *entities.UserToken) error {
+					assert.Equal(t, userID, tok.UserID)
+					assert.Equal(t, entities.UserTokenPasswordReset, tok.Purpose)
+					assert.Equal(t, tn.Add(rules.PasswordResetTTL), tok.ExpiresAt)
+					assert.Nil(t, tok.UsedAt)
+
+					token = tok
+
+					return nil
+				})
+				m.mailer.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, msg mail.Message) error {
+					assert.Equal(t, "some@email.com", msg.To)
+
+					secret := secretRe.FindStringSubmatch(msg.Body)
+					require.Len(t, secret, 2, msg.Body)
+					assert.Equal(t, token.Hash, entities.HashUserTokenSecret(secret[1]))
+
+					return nil
+				})
+				m.logger.EXPECT().Debug(gomock.Any(), "END usecase")
+
+				return nil
+			},
+		},
+		{
+			name: "unknown email is not disclosed",
+			in:   testRequest{email: "some@email.com"},
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByEmail(gomock.Any(), getUserQos).Return(nil, entities.ErrUserRecNotFound)
+				m.logger.EXPECT().Debug(gomock.Any(), "STOP usecase! user not found")
+
+				return nil
+			},
+		},
+		{
+			name: "invalid email",
+			in:   testRequest{email: ""},
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! getUserByEmail.NewQuery error", gomock.Any())
+
+				return vObject.ErrEmptyEmail
+			},
+		},
+		{
+			name: "get user error",
+			in:   testRequest{email: "some@email.com"},
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByEmail(gomock.Any(), getUserQos).Return(nil, assert.AnError)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! getUserQuery.Handle error", gomock.Any())
+
+				return assert.AnError
+			},
+		},
+		{
+			name: "upsert token error",
+			in:   testRequest{email: "some@email.com"},
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByEmail(gomock.Any(), getUserQos).Return(newUser(), nil)
+				m.upsertToken.EXPECT().UpsertUserToken(gomock.Any(), gomock.Any()).Return(assert.AnError)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! upsertTokenCmd.Handle error", gomock.Any())
+
+				return assert.AnError
+			},
+		},
+		{
+			name: "send error",
+			in:   testRequest{email: "some@email.com"},
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				m.getUser.EXPECT().GetByEmail(gomock.Any(), getUserQos).Return(newUser(), nil)
+				m.upsertToken.EXPECT().UpsertUserToken(gomock.Any(), gomock.Any()).Return(nil)
+				m.mailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(mail.ErrSend)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! mailer.Send error", gomock.Any())
+
+				return mail.ErrSend
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := mocks{
+				logger:      log.NewLogMock(ctrl),
+				mailer:      mail.NewMailerMock(ctrl),
+				getUser:     getUserByEmail.NewGetUserMock(ctrl),
+				upsertToken: upsertUserToken.NewUpsertUserTokenMock(ctrl),
+			}
+			nowFunc := now.NewMock(ctrl)
+			nowFunc.EXPECT().Now().AnyTimes().Return(tn)
+
+			uc, err := NewUseCase(
+				usecase.WithLogger[*UseCase](m.logger),
+				usecase.WithNowFunc[*UseCase](nowFunc),
+				WithMailer(m.mailer),
+				WithLinkURL(linkURL),
+				WithRules(rules),
+				WithGetUserByEmailQuery(getUserByEmail.NewQueryHandler(m.getUser)),
+				WithUpsertUserTokenCommand(upsertUserToken.NewCommandHandler(m.upsertToken)),
+			)
+			require.NoError(t, err)
+
+			m.logger.EXPECT().With(log.String("email", tc.in.GetEmail())).Return(m.logger)
+			m.logger.EXPECT().Debug(gomock.Any(), "START usecase")
+
+			expErr := tc.exp(t, tc.in, m)
+
+			require.ErrorIs(t, uc.Run(context.Background(), tc.in), expErr)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package resetpassword
+
+import (
+	"fmt"
+
+	passcrypto "github.com/smgladkovskiy/warehouse-task/internal/pkg/pass_crypto"
+	revokeUserSessions "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/revoke_by_user"
+	updateUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/update"
+	invalidateUserTokens "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/invalidate"
+	upsertUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/upsert"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	getUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user_token/get_user_token"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func WithGetUserTokenQuery(handler *getUserToken.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getUserToken")
+		}
+
+		uc.getTokenQuery = handler
+
+		return nil
+	}
+}
+
+func WithGetUserQuery(handler *getUser.QueryHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "getUser")
+		}
+
+		uc.getUserQuery = handler
+
+		return nil
+	}
+}
+
+func WithUpdateUserCommand(handler *updateUser.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "updateUser")
+		}
+
+		uc.updateUserCmd = handler
+
+		return nil
+	}
+}
+
+func WithUpsertUserTokenCommand(handler *upsertUserToken.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "upsertUserToken")
+		}
+
+		uc.upsertTokenCmd = handler
+
+		return nil
+	}
+}
+
+func WithInvalidateUserTokensCommand(handler *invalidateUserTokens.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "invalidateUserTokens")
+		}
+
+		uc.invalidateTokensCmd = handler
+
+		return nil
+	}
+}
+
+func WithRevokeUserSessionsCommand(handler *revokeUserSessions.CommandHandler) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if handler == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "revokeUserSessions")
+		}
+
+		uc.revokeSessionsCmd = handler
+
+		return nil
+	}
+}
+
+func WithPasswordHasher(hasher passcrypto.PasswordHashable) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		if hasher == nil {
+			return fmt.Errorf("%w %s", usecase.ErrEmptyStructParam, "hasher")
+		}
+
+		uc.SetHasher(hasher)
+
+		return nil
+	}
+}
+
+// WithRules задаёт правила, из которых берётся минимальная длина пароля.
+func WithRules(rules vObject.Rules) usecase.Configuration[*UseCase] {
+	return func(uc *UseCase) error {
+		uc.rules = rules
+
+		return nil
+	}
+}
@@ -0,0 +1,77 @@
+package resetpassword
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	passcrypto "github.com/smgladkovskiy/warehouse-task/internal/pkg/pass_crypto"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	revokeUserSessions "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/revoke_by_user"
+	updateUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/update"
+	invalidateUserTokens "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/invalidate"
+	upsertUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/upsert"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	getUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user_token/get_user_token"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+func TestConfiguration(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	loggerMock := log.NewLogMock(ctrl)
+	txManagerMock := trx.NewTransactionManagerMock(ctrl)
+	nowFunc := now.NewMock(ctrl)
+	getUserTokenMock := getUserToken.NewGetUserTokenMock(ctrl)
+	getUserMock := getUser.NewGetUserByIDMock(ctrl)
+	updateUserMock := updateUser.NewUpdateUserMock(ctrl)
+	upsertUserTokenMock := upsertUserToken.NewUpsertUserTokenMock(ctrl)
+	invalidateUserTokensMock := invalidateUserTokens.NewInvalidateUserTokensMock(ctrl)
+	revokeUserSessionsMock := revokeUserSessions.NewRevokeUserSessionsMock(ctrl)
+	hasherMock := passcrypto.NewPasswordHashMock(ctrl)
+
+	cfgs := []usecase.Configuration[*UseCase]{
+		usecase.WithTransactionManager[*UseCase](txManagerMock),
+		usecase.WithLogger[*UseCase](loggerMock),
+		usecase.WithNowFunc[*UseCase](nowFunc),
+		WithPasswordHasher(hasherMock),
+		WithGetUserTokenQuery(getUserToken.NewQueryHandler(getUserTokenMock)),
+		WithGetUserQuery(getUser.NewQueryHandler(getUserMock)),
+		WithUpdateUserCommand(updateUser.NewCommandHandler(updateUserMock)),
+		WithUpsertUserTokenCommand(upsertUserToken.NewCommandHandler(upsertUserTokenMock)),
+		WithInvalidateUserTokensCommand(invalidateUserTokens.NewCommandHandler(invalidateUserTokensMock)),
+		WithRevokeUserSessionsCommand(revokeUserSessions.NewCommandHandler(revokeUserSessionsMock)),
+	}
+
+	for _, f := range []usecase.Configuration[*UseCase]{
+		WithPasswordHasher(nil),
+		WithGetUserTokenQuery(nil),
+		WithGetUserQuery(nil),
+		WithUpdateUserCommand(nil),
+		WithUpsertUserTokenCommand(nil),
+		WithInvalidateUserTokensCommand(nil),
+		WithRevokeUserSessionsCommand(nil),
+	} {
+		uc, err := NewUseCase(f)
+		require.ErrorIs(t, err, usecase.ErrEmptyStructParam)
+		assert.Empty(t, uc)
+	}
+
+	uc, err := NewUseCase(nil)
+	require.ErrorIs(t, err, checker.ErrInitError)
+	require.Empty(t, uc)
+
+	uc, err = NewUseCase(cfgs[:len(cfgs)-1]...)
+	require.ErrorIs(t, err, checker.ErrInitError)
+	require.Empty(t, uc)
+
+	uc, err = NewUseCase(cfgs...)
+	require.NoError(t, err)
+	assert.NotEmpty(t, uc)
+}
@@ -0,0 +1,6 @@
+package resetpassword
+
+type Requestable interface {
+	GetToken() string
+	GetPassword() string
+}
@@ -0,0 +1,16 @@
+package resetpassword
+
+type testRequest struct {
+	token    string
+	password string
+}
+
+var _ Requestable = (*testRequest)(nil)
+
+func (t testRequest) GetToken() string {
+	return t.token
+}
+
+func (t testRequest) GetPassword() string {
+	return t.password
+}
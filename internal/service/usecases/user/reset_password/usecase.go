@@ -0,0 +1,154 @@
+package resetpassword
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	passcrypto "github.com/smgladkovskiy/warehouse-task/internal/pkg/pass_crypto"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	revokeUserSessions "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/revoke_by_user"
+	updateUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/update"
+	invalidateUserTokens "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/invalidate"
+	upsertUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/upsert"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	getUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user_token/get_user_token"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+type UseCase struct {
+	now.WithNowGenerator
+	checker.WithCheck
+	tx.WithTransactionManager
+	passcrypto.WithPasswordHasher
+	log.WithLogger
+
+	// Query handlers
+	getTokenQuery *getUserToken.QueryHandler
+	getUserQuery  *getUser.QueryHandler
+
+	// Command handlers
+	updateUserCmd       *updateUser.CommandHandler
+	upsertTokenCmd      *upsertUserToken.CommandHandler
+	invalidateTokensCmd *invalidateUserTokens.CommandHandler
+	revokeSessionsCmd   *revokeUserSessions.CommandHandler
+
+	// rules задают минимальную длину нового пароля
+	rules vObject.Rules
+}
+
+func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
+	uc := &UseCase{rules: vObject.DefaultRules()}
+
+	// Apply all Configurations passed in
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			return nil, checker.ErrInitError
+		}
+
+		err := cfg(uc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Check(*uc); err != nil {
+		return nil, err
+	}
+
+	return uc, nil
+}
+
+// Run погашает токен сброса пароля и задаёт пользователю новый пароль. Блокировка входа снимается,
+// email считается подтверждённым: токен пришёл на него письмом. Если новый пароль не подходит
+// по правилам, токен не погашается и им можно воспользоваться снова. После смены пароля остальные токены
+// сброса пользователя погашаются, а все его сессии отзываются: украденные ранее токены перестают действовать.
+func (uc *UseCase) Run(ctx context.Context, req Requestable) error {
+	ctx, span := tracing.Start(ctx, "usecase.resetPassword")
+	defer span.End()
+
+	l := uc.Logger().With(log.String("password", strings.Repeat("*", len(req.GetPassword()))))
+
+	l.Debug(ctx, "START usecase")
+
+	query, err := getUserToken.NewQueryForUpdate(req.GetToken(), entities.UserTokenPasswordReset)
+	if err != nil {
+		l.Error(ctx, "STOP usecase! getUserToken.NewQueryForUpdate error", log.Err(err))
+
+		return tracing.Error(span, fmt.Errorf("[resetPassword - getUserToken.NewQueryForUpdate error]: %w", err))
+	}
+
+	err = uc.TransactionDo(ctx, func(ctx context.Context) error {
+		// 1. Погашаем токен
+		token, err := uc.getTokenQuery.Handle(ctx, *query)
+		if errors.Is(err, entities.ErrUserTokenRecNotFound) {
+			return entities.ErrUserTokenInvalid
+		}
+
+		if err != nil {
+			return fmt.Errorf("[resetPassword - uc.getTokenQuery.Handle error]: %w", err)
+		}
+
+		token.SetNowGen(uc.GetNowGen())
+
+		if err = token.Redeem(); err != nil {
+			return err
+		}
+
+		// 2. Задаём новый пароль
+		user, err := uc.getUserQuery.Handle(ctx, getUser.NewQueryForUpdate(token.UserID))
+		if err != nil {
+			return fmt.Errorf("[resetPassword - uc.getUserQuery.Handle error]: %w", err)
+		}
+
+		user.SetNowGen(uc.GetNowGen())
+		user.SetHasher(uc.GetHasher())
+
+		if err = user.ResetPassword(req.GetPassword(), uc.rules.MinPasswordLength); err != nil {
+			return fmt.Errorf("[resetPassword - user.ResetPassword error]: %w", err)
+		}
+
+		user.VerifyEmail()
+
+		if err = uc.updateUserCmd.Handle(ctx, updateUser.NewCommandUnsafe(user)); err != nil {
+			return fmt.Errorf("[resetPassword - uc.updateUserCmd.Handle error]: %w", err)
+		}
+
+		if err = uc.upsertTokenCmd.Handle(ctx, upsertUserToken.NewCommandUnsafe(token)); err != nil {
+			return fmt.Errorf("[resetPassword - uc.upsertTokenCmd.Handle error]: %w", err)
+		}
+
+		// 3. Погашаем остальные токены сброса и отзываем сессии
+		if err = uc.invalidateTokensCmd.Handle(ctx, invalidateUserTokens.NewCommandUnsafe(token)); err != nil {
+			return fmt.Errorf("[resetPassword - uc.invalidateTokensCmd.Handle error]: %w", err)
+		}
+
+		if err = uc.revokeSessionsCmd.Handle(ctx, revokeUserSessions.NewCommandUnsafe(user.ID, *token.UsedAt)); err != nil {
+			return fmt.Errorf("[resetPassword - uc.revokeSessionsCmd.Handle error]: %w", err)
+		}
+
+		return nil
+	})
+	if errors.Is(err, entities.ErrUserTokenInvalid) {
+		l.Warn(ctx, "STOP usecase! token rejected", log.Err(err))
+
+		return tracing.Error(span, fmt.Errorf("[resetPassword - uc.TransactionDo error]: %w", err))
+	}
+
+	if err != nil {
+		l.Error(ctx, "STOP usecase! transaction error", log.Err(err))
+
+		return tracing.Error(span, fmt.Errorf("[resetPassword - uc.TransactionDo error]: %w", err))
+	}
+
+	l.Debug(ctx, "END usecase")
+
+	return nil
+}
@@ -0,0 +1,302 @@
+package resetpassword
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	passcrypto "github.com/smgladkovskiy/warehouse-task/internal/pkg/pass_crypto"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	revokeUserSessions "github.com/smgladkovskiy/warehouse-task/internal/service/commands/session/revoke_by_user"
+	updateUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/update"
+	invalidateUserTokens "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/invalidate"
+	upsertUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/upsert"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	getUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user_token/get_user_token"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+type mocks struct {
+	logger      *log.LogMock
+	hasher      *passcrypto.PasswordHashMock
+	getToken    *getUserToken.GetUserTokenMock
+	getUser     *getUser.GetUserByIDMock
+	updateUser  *updateUser.UpdateUserMock
+	upsertToken *upsertUserToken.UpsertUserTokenMock
+	invalidate  *invalidateUserTokens.InvalidateUserTokensMock
+	revoke      *revokeUserSessions.RevokeUserSessionsMock
+}
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		in   testRequest
+		exp  func(t *testing.T, in testRequest, m mocks) error
+	}
+
+	tn := time.Now().UTC().Truncate(time.Second)
+	userID := vObject.NewUserIDFromUUIDUnsafe(baseUUID.New())
+	lockedUntil := tn.Add(time.Hour)
+
+	newToken := func() *entities.UserToken {
+		return &entities.UserToken{
+			Hash:      entities.HashUserTokenSecret("secret"),
+			UserID:    userID,
+			Purpose:   entities.UserTokenPasswordReset,
+			ExpiresAt: tn.Add(time.Hour),
+			CreatedAt: tn.Add(-time.Hour),
+		}
+	}
+	newUser := func() *entities.User {
+		return &entities.User{
+			ID:                  userID,
+			PasswordHash:        vObject.NewPasswordHashUnsafe("old_hash"),
+			FailedLoginAttempts: 5,
+			LockedUntil:         &lockedUntil,
+		}
+	}
+
+	getTokenQos := queryOptions.NewUserTokenQueryOptions(
+		queryOptions.WithUserToken(entities.HashUserTokenSecret("secret"), entities.UserTokenPasswordReset),
+		queryOptions.WithForUpdate[*queryOptions.UserTokenQueryOptions](),
+	)
+	getUserQos := queryOptions.NewUserQueryOptions(
+		queryOptions.WithUserID(userID),
+		queryOptions.WithForUpdate[*queryOptions.UserQueryOptions](),
+	)
+
+	tcs := []testCase{
+		{
+			name: "happy path",
+			in:   testRequest{token: "secret", password: "new_password"},
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.getToken.EXPECT().GetUserToken(gomock.Any(), getTokenQos).Return(newToken(), nil)
+				m.getUser.EXPECT().GetByID(gomock.Any(), getUserQos).Return(newUser(), nil)
+				m.hasher.EXPECT().HashAndSalt([]byte(in.GetPassword())).Return("new_hash", nil)
+				m.updateUser.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *entities.User) error {
+					assert.Equal(t, vObject.NewPasswordHashUnsafe("new_hash"), u.PasswordHash)
+					assert.Zero(t, u.FailedLoginAttempts)
+					assert.Nil(t, u.LockedUntil)
+					assert.True(t, u.IsEmailVerified())
+
+					return nil
+				})
+				m.upsertToken.EXPECT().UpsertUserToken(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, tok *entities.UserToken) error {
+					require.NotNil(t, tok.UsedAt)
+					assert.Equal(t, tn, *tok.UsedAt)
+
+					return nil
+				})
+				m.invalidate.EXPECT().InvalidateUserTokens(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, tok *entities.UserToken) error {
+					assert.Equal(t, entities.HashUserTokenSecret("secret"), tok.Hash)
+					assert.Equal(t, userID, tok.UserID)
+					assert.Equal(t, entities.UserTokenPasswordReset, tok.Purpose)
+					require.NotNil(t, tok.UsedAt)
+					assert.Equal(t, tn, *tok.UsedAt)
+
+					return nil
+				})
+				m.revoke.EXPECT().RevokeUserSessions(gomock.Any(), userID, tn).Return(nil)
+
+				return nil
+			},
+		},
+		{
+			name: "password too short",
+			in:   testRequest{token: "secret", password: "short"},
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				m.getToken.EXPECT().GetUserToken(gomock.Any(), getTokenQos).Return(newToken(), nil)
+				m.getUser.EXPECT().GetByID(gomock.Any(), getUserQos).Return(newUser(), nil)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+
+				return vObject.ErrPasswordLen
+			},
+		},
+		{
+			name: "hashing error",
+			in:   testRequest{token: "secret", password: "new_password"},
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.getToken.EXPECT().GetUserToken(gomock.Any(), getTokenQos).Return(newToken(), nil)
+				m.getUser.EXPECT().GetByID(gomock.Any(), getUserQos).Return(newUser(), nil)
+				m.hasher.EXPECT().HashAndSalt([]byte(in.GetPassword())).Return("", passcrypto.ErrPasswordHashing)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+
+				return passcrypto.ErrPasswordHashing
+			},
+		},
+		{
+			name: "unknown token",
+			in:   testRequest{token: "secret", password: "new_password"},
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				m.getToken.EXPECT().GetUserToken(gomock.Any(), getTokenQos).Return(nil, entities.ErrUserTokenRecNotFound)
+				m.logger.EXPECT().Warn(gomock.Any(), "STOP usecase! token rejected", gomock.Any())
+
+				return entities.ErrUserTokenInvalid
+			},
+		},
+		{
+			name: "expired token",
+			in:   testRequest{token: "secret", password: "new_password"},
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				token := newToken()
+				token.ExpiresAt = tn
+
+				m.getToken.EXPECT().GetUserToken(gomock.Any(), getTokenQos).Return(token, nil)
+				m.logger.EXPECT().Warn(gomock.Any(), "STOP usecase! token rejected", gomock.Any())
+
+				return entities.ErrUserTokenInvalid
+			},
+		},
+		{
+			name: "empty token",
+			in:   testRequest{token: "", password: "new_password"},
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! getUserToken.NewQueryForUpdate error", gomock.Any())
+
+				return getUserToken.ErrEmptyToken
+			},
+		},
+		{
+			name: "update user error",
+			in:   testRequest{token: "secret", password: "new_password"},
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.getToken.EXPECT().GetUserToken(gomock.Any(), getTokenQos).Return(newToken(), nil)
+				m.getUser.EXPECT().GetByID(gomock.Any(), getUserQos).Return(newUser(), nil)
+				m.hasher.EXPECT().HashAndSalt([]byte(in.GetPassword())).Return("new_hash", nil)
+				m.updateUser.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(assert.AnError)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+
+				return assert.AnError
+			},
+		},
+		{
+			name: "upsert token error",
+			in:   testRequest{token: "secret", password: "new_password"},
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.getToken.EXPECT().GetUserToken(gomock.Any(), getTokenQos).Return(newToken(), nil)
+				m.getUser.EXPECT().GetByID(gomock.Any(), getUserQos).Return(newUser(), nil)
+				m.hasher.EXPECT().HashAndSalt([]byte(in.GetPassword())).Return("new_hash", nil)
+				m.updateUser.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(nil)
+				m.upsertToken.EXPECT().UpsertUserToken(gomock.Any(), gomock.Any()).Return(assert.AnError)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+
+				return assert.AnError
+			},
+		},
+		{
+			name: "invalidate tokens error",
+			in:   testRequest{token: "secret", password: "new_password"},
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.getToken.EXPECT().GetUserToken(gomock.Any(), getTokenQos).Return(newToken(), nil)
+				m.getUser.EXPECT().GetByID(gomock.Any(), getUserQos).Return(newUser(), nil)
+				m.hasher.EXPECT().HashAndSalt([]byte(in.GetPassword())).Return("new_hash", nil)
+				m.updateUser.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(nil)
+				m.upsertToken.EXPECT().UpsertUserToken(gomock.Any(), gomock.Any()).Return(nil)
+				m.invalidate.EXPECT().InvalidateUserTokens(gomock.Any(), gomock.Any()).Return(assert.AnError)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+
+				return assert.AnError
+			},
+		},
+		{
+			name: "revoke sessions error",
+			in:   testRequest{token: "secret", password: "new_password"},
+			exp: func(t *testing.T, in testRequest, m mocks) error {
+				t.Helper()
+
+				m.getToken.EXPECT().GetUserToken(gomock.Any(), getTokenQos).Return(newToken(), nil)
+				m.getUser.EXPECT().GetByID(gomock.Any(), getUserQos).Return(newUser(), nil)
+				m.hasher.EXPECT().HashAndSalt([]byte(in.GetPassword())).Return("new_hash", nil)
+				m.updateUser.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(nil)
+				m.upsertToken.EXPECT().UpsertUserToken(gomock.Any(), gomock.Any()).Return(nil)
+				m.invalidate.EXPECT().InvalidateUserTokens(gomock.Any(), gomock.Any()).Return(nil)
+				m.revoke.EXPECT().RevokeUserSessions(gomock.Any(), userID, tn).Return(assert.AnError)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+
+				return assert.AnError
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := mocks{
+				logger:      log.NewLogMock(ctrl),
+				hasher:      passcrypto.NewPasswordHashMock(ctrl),
+				getToken:    getUserToken.NewGetUserTokenMock(ctrl),
+				getUser:     getUser.NewGetUserByIDMock(ctrl),
+				updateUser:  updateUser.NewUpdateUserMock(ctrl),
+				upsertToken: upsertUserToken.NewUpsertUserTokenMock(ctrl),
+				invalidate:  invalidateUserTokens.NewInvalidateUserTokensMock(ctrl),
+				revoke:      revokeUserSessions.NewRevokeUserSessionsMock(ctrl),
+			}
+			txManagerMock := trx.NewTransactionManagerMock(ctrl)
+			nowFunc := now.NewMock(ctrl)
+
+			nowFunc.EXPECT().Now().AnyTimes().Return(tn)
+			txManagerMock.EXPECT().Do(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				},
+			)
+
+			uc, err := NewUseCase(
+				usecase.WithTransactionManager[*UseCase](txManagerMock),
+				usecase.WithLogger[*UseCase](m.logger),
+				usecase.WithNowFunc[*UseCase](nowFunc),
+				WithPasswordHasher(m.hasher),
+				WithGetUserTokenQuery(getUserToken.NewQueryHandler(m.getToken)),
+				WithGetUserQuery(getUser.NewQueryHandler(m.getUser)),
+				WithUpdateUserCommand(updateUser.NewCommandHandler(m.updateUser)),
+				WithUpsertUserTokenCommand(upsertUserToken.NewCommandHandler(m.upsertToken)),
+				WithInvalidateUserTokensCommand(invalidateUserTokens.NewCommandHandler(m.invalidate)),
+				WithRevokeUserSessionsCommand(revokeUserSessions.NewCommandHandler(m.revoke)),
+			)
+			require.NoError(t, err)
+
+			m.logger.EXPECT().With(log.String("password", strings.Repeat("*", len(tc.in.GetPassword())))).Return(m.logger)
+			m.logger.EXPECT().Debug(gomock.Any(), "START usecase")
+
+			expErr := tc.exp(t, tc.in, m)
+			if expErr == nil {
+				m.logger.EXPECT().Debug(gomock.Any(), "END usecase")
+			}
+
+			require.ErrorIs(t, uc.Run(context.Background(), tc.in), expErr)
+		})
+	}
+}
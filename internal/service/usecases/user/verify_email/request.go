@@ -0,0 +1,5 @@
+package verifyemail
+
+type Requestable interface {
+	GetToken() string
+}
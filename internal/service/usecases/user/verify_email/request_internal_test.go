@@ -0,0 +1,11 @@
+package verifyemail
+
+type testRequest struct {
+	token string
+}
+
+var _ Requestable = (*testRequest)(nil)
+
+func (t testRequest) GetToken() string {
+	return t.token
+}
@@ -0,0 +1,126 @@
+package verifyemail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/checker"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	updateUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/update"
+	upsertUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/upsert"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	getUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user_token/get_user_token"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+type UseCase struct {
+	now.WithNowGenerator
+	checker.WithCheck
+	tx.WithTransactionManager
+	log.WithLogger
+
+	// Query handlers
+	getTokenQuery *getUserToken.QueryHandler
+	getUserQuery  *getUser.QueryHandler
+
+	// Command handlers
+	updateUserCmd  *updateUser.CommandHandler
+	upsertTokenCmd *upsertUserToken.CommandHandler
+}
+
+func NewUseCase(cfgs ...usecase.Configuration[*UseCase]) (*UseCase, error) {
+	uc := &UseCase{}
+
+	// Apply all Configurations passed in
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			return nil, checker.ErrInitError
+		}
+
+		err := cfg(uc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Check(*uc); err != nil {
+		return nil, err
+	}
+
+	return uc, nil
+}
+
+// Run погашает токен подтверждения email и отмечает email пользователя подтверждённым.
+func (uc *UseCase) Run(ctx context.Context, req Requestable) error {
+	ctx, span := tracing.Start(ctx, "usecase.verifyEmail")
+	defer span.End()
+
+	l := uc.Logger()
+
+	l.Debug(ctx, "START usecase")
+
+	query, err := getUserToken.NewQueryForUpdate(req.GetToken(), entities.UserTokenEmailVerification)
+	if err != nil {
+		l.Error(ctx, "STOP usecase! getUserToken.NewQueryForUpdate error", log.Err(err))
+
+		return tracing.Error(span, fmt.Errorf("[verifyEmail - getUserToken.NewQueryForUpdate error]: %w", err))
+	}
+
+	err = uc.TransactionDo(ctx, func(ctx context.Context) error {
+		// 1. Погашаем токен
+		token, err := uc.getTokenQuery.Handle(ctx, *query)
+		if errors.Is(err, entities.ErrUserTokenRecNotFound) {
+			return entities.ErrUserTokenInvalid
+		}
+
+		if err != nil {
+			return fmt.Errorf("[verifyEmail - uc.getTokenQuery.Handle error]: %w", err)
+		}
+
+		token.SetNowGen(uc.GetNowGen())
+
+		if err = token.Redeem(); err != nil {
+			return err
+		}
+
+		// 2. Подтверждаем email
+		user, err := uc.getUserQuery.Handle(ctx, getUser.NewQueryForUpdate(token.UserID))
+		if err != nil {
+			return fmt.Errorf("[verifyEmail - uc.getUserQuery.Handle error]: %w", err)
+		}
+
+		user.SetNowGen(uc.GetNowGen())
+
+		if user.VerifyEmail() {
+			if err = uc.updateUserCmd.Handle(ctx, updateUser.NewCommandUnsafe(user)); err != nil {
+				return fmt.Errorf("[verifyEmail - uc.updateUserCmd.Handle error]: %w", err)
+			}
+		}
+
+		if err = uc.upsertTokenCmd.Handle(ctx, upsertUserToken.NewCommandUnsafe(token)); err != nil {
+			return fmt.Errorf("[verifyEmail - uc.upsertTokenCmd.Handle error]: %w", err)
+		}
+
+		return nil
+	})
+	if errors.Is(err, entities.ErrUserTokenInvalid) {
+		l.Warn(ctx, "STOP usecase! token rejected", log.Err(err))
+
+		return tracing.Error(span, fmt.Errorf("[verifyEmail - uc.TransactionDo error]: %w", err))
+	}
+
+	if err != nil {
+		l.Error(ctx, "STOP usecase! transaction error", log.Err(err))
+
+		return tracing.Error(span, fmt.Errorf("[verifyEmail - uc.TransactionDo error]: %w", err))
+	}
+
+	l.Debug(ctx, "END usecase")
+
+	return nil
+}
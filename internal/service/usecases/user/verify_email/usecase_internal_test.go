@@ -0,0 +1,244 @@
+package verifyemail
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	baseUUID "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/now"
+	trx "github.com/smgladkovskiy/warehouse-task/internal/pkg/tx"
+	updateUser "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user/update"
+	upsertUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/commands/user_token/upsert"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryOptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getUser "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user/get_user"
+	getUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user_token/get_user_token"
+	usecase "github.com/smgladkovskiy/warehouse-task/internal/service/usecases"
+)
+
+type mocks struct {
+	logger      *log.LogMock
+	getToken    *getUserToken.GetUserTokenMock
+	getUser     *getUser.GetUserByIDMock
+	updateUser  *updateUser.UpdateUserMock
+	upsertToken *upsertUserToken.UpsertUserTokenMock
+}
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		in   testRequest
+		exp  func(t *testing.T, in testRequest, m mocks) error
+	}
+
+	tn := time.Now().UTC().Truncate(time.Second)
+	userID := vObject.NewUserIDFromUUIDUnsafe(baseUUID.New())
+	verifiedAt := tn.Add(-time.Hour)
+
+	newToken := func() *entities.UserToken {
+		return &entities.UserToken{
+			Hash:      entities.HashUserTokenSecret("secret"),
+			UserID:    userID,
+			Purpose:   entities.UserTokenEmailVerification,
+			ExpiresAt: tn.Add(time.Hour),
+			CreatedAt: tn.Add(-time.Hour),
+		}
+	}
+
+	getTokenQos := queryOptions.NewUserTokenQueryOptions(
+		queryOptions.WithUserToken(entities.HashUserTokenSecret("secret"), entities.UserTokenEmailVerification),
+		queryOptions.WithForUpdate[*queryOptions.UserTokenQueryOptions](),
+	)
+	getUserQos := queryOptions.NewUserQueryOptions(
+		queryOptions.WithUserID(userID),
+		queryOptions.WithForUpdate[*queryOptions.UserQueryOptions](),
+	)
+
+	tcs := []testCase{
+		{
+			name: "happy path",
+			in:   testRequest{token: "secret"},
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				m.getToken.EXPECT().GetUserToken(gomock.Any(), getTokenQos).Return(newToken(), nil)
+				m.getUser.EXPECT().GetByID(gomock.Any(), getUserQos).Return(&entities.User{ID: userID}, nil)
+				m.updateUser.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *entities.User) error {
+					require.NotNil(t, u.EmailVerifiedAt)
+					assert.Equal(t, tn, *u.EmailVerifiedAt)
+
+					return nil
+				})
+				m.upsertToken.EXPECT().UpsertUserToken(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, tok *entities.UserToken) error {
+					require.NotNil(t, tok.UsedAt)
+					assert.Equal(t, tn, *tok.UsedAt)
+
+					return nil
+				})
+
+				return nil
+			},
+		},
+		{
+			name: "email already verified",
+			in:   testRequest{token: "secret"},
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				m.getToken.EXPECT().GetUserToken(gomock.Any(), getTokenQos).Return(newToken(), nil)
+				m.getUser.EXPECT().GetByID(gomock.Any(), getUserQos).Return(&entities.User{ID: userID, EmailVerifiedAt: &verifiedAt}, nil)
+				m.upsertToken.EXPECT().UpsertUserToken(gomock.Any(), gomock.Any()).Return(nil)
+
+				return nil
+			},
+		},
+		{
+			name: "unknown token",
+			in:   testRequest{token: "secret"},
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				m.getToken.EXPECT().GetUserToken(gomock.Any(), getTokenQos).Return(nil, entities.ErrUserTokenRecNotFound)
+				m.logger.EXPECT().Warn(gomock.Any(), "STOP usecase! token rejected", gomock.Any())
+
+				return entities.ErrUserTokenInvalid
+			},
+		},
+		{
+			name: "used token",
+			in:   testRequest{token: "secret"},
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				token := newToken()
+				token.UsedAt = &verifiedAt
+
+				m.getToken.EXPECT().GetUserToken(gomock.Any(), getTokenQos).Return(token, nil)
+				m.logger.EXPECT().Warn(gomock.Any(), "STOP usecase! token rejected", gomock.Any())
+
+				return entities.ErrUserTokenInvalid
+			},
+		},
+		{
+			name: "expired token",
+			in:   testRequest{token: "secret"},
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				token := newToken()
+				token.ExpiresAt = tn
+
+				m.getToken.EXPECT().GetUserToken(gomock.Any(), getTokenQos).Return(token, nil)
+				m.logger.EXPECT().Warn(gomock.Any(), "STOP usecase! token rejected", gomock.Any())
+
+				return entities.ErrUserTokenInvalid
+			},
+		},
+		{
+			name: "empty token",
+			in:   testRequest{token: ""},
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! getUserToken.NewQueryForUpdate error", gomock.Any())
+
+				return getUserToken.ErrEmptyToken
+			},
+		},
+		{
+			name: "get user error",
+			in:   testRequest{token: "secret"},
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				m.getToken.EXPECT().GetUserToken(gomock.Any(), getTokenQos).Return(newToken(), nil)
+				m.getUser.EXPECT().GetByID(gomock.Any(), getUserQos).Return(nil, entities.ErrUserRecNotFound)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+
+				return entities.ErrUserRecNotFound
+			},
+		},
+		{
+			name: "update user error",
+			in:   testRequest{token: "secret"},
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				m.getToken.EXPECT().GetUserToken(gomock.Any(), getTokenQos).Return(newToken(), nil)
+				m.getUser.EXPECT().GetByID(gomock.Any(), getUserQos).Return(&entities.User{ID: userID}, nil)
+				m.updateUser.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(assert.AnError)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+
+				return assert.AnError
+			},
+		},
+		{
+			name: "upsert token error",
+			in:   testRequest{token: "secret"},
+			exp: func(t *testing.T, _ testRequest, m mocks) error {
+				t.Helper()
+
+				m.getToken.EXPECT().GetUserToken(gomock.Any(), getTokenQos).Return(newToken(), nil)
+				m.getUser.EXPECT().GetByID(gomock.Any(), getUserQos).Return(&entities.User{ID: userID}, nil)
+				m.updateUser.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(nil)
+				m.upsertToken.EXPECT().UpsertUserToken(gomock.Any(), gomock.Any()).Return(assert.AnError)
+				m.logger.EXPECT().Error(gomock.Any(), "STOP usecase! transaction error", gomock.Any())
+
+				return assert.AnError
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := mocks{
+				logger:      log.NewLogMock(ctrl),
+				getToken:    getUserToken.NewGetUserTokenMock(ctrl),
+				getUser:     getUser.NewGetUserByIDMock(ctrl),
+				updateUser:  updateUser.NewUpdateUserMock(ctrl),
+				upsertToken: upsertUserToken.NewUpsertUserTokenMock(ctrl),
+			}
+			txManagerMock := trx.NewTransactionManagerMock(ctrl)
+			nowFunc := now.NewMock(ctrl)
+
+			nowFunc.EXPECT().Now().AnyTimes().Return(tn)
+			txManagerMock.EXPECT().Do(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				},
+			)
+
+			uc, err := NewUseCase(
+				usecase.WithTransactionManager[*UseCase](txManagerMock),
+				usecase.WithLogger[*UseCase](m.logger),
+				usecase.WithNowFunc[*UseCase](nowFunc),
+				WithGetUserTokenQuery(getUserToken.NewQueryHandler(m.getToken)),
+				WithGetUserQuery(getUser.NewQueryHandler(m.getUser)),
+				WithUpdateUserCommand(updateUser.NewCommandHandler(m.updateUser)),
+				WithUpsertUserTokenCommand(upsertUserToken.NewCommandHandler(m.upsertToken)),
+			)
+			require.NoError(t, err)
+
+			m.logger.EXPECT().Debug(gomock.Any(), "START usecase")
+
+			expErr := tc.exp(t, tc.in, m)
+			if expErr == nil {
+				m.logger.EXPECT().Debug(gomock.Any(), "END usecase")
+			}
+
+			require.ErrorIs(t, uc.Run(context.Background(), tc.in), expErr)
+		})
+	}
+}
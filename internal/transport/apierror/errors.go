@@ -0,0 +1,86 @@
+// Package apierror общее для транспортов соответствие доменных ошибок ответам клиенту.
+package apierror
+
+import (
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/auth"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getUserToken "github.com/smgladkovskiy/warehouse-task/internal/service/queries/user_token/get_user_token"
+)
+
+// ErrUnauthenticated запрос к защищённому методу пришёл без токена доступа.
+var ErrUnauthenticated = errors.New("authentication required")
+
+// Error описание доменной ошибки для клиента: код ответа в каждом транспорте и постоянный текст. Текст
+// не зависит от того, чем ошибка обёрнута по пути наверх, поэтому подробности (id, имена юзкейсов) остаются в логах.
+type Error struct {
+	Err        error
+	HTTPStatus int
+	GRPCCode   codes.Code
+	Message    string
+}
+
+// publicErrors доменные ошибки, о которых можно сообщить клиенту. Ошибки проверяются по порядку через errors.Is,
+// остальные считаются внутренними.
+var publicErrors = []Error{
+	{Err: vObject.ErrEmptyID, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "id is empty"},
+	{Err: vObject.ErrParseID, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "invalid id"},
+	{Err: vObject.ErrEmptyEmail, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "email is empty"},
+	{Err: vObject.ErrEmptyFirstName, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "first name is empty"},
+	{Err: vObject.ErrEmptyLastName, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "last name is empty"},
+	{Err: vObject.ErrAgeIsTooLow, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "user is younger than the minimum age"},
+	{Err: vObject.ErrPasswordLen, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "password is too short"},
+	{Err: vObject.ErrEmptyMaritalStatus, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "marital status is empty"},
+	{Err: vObject.ErrUnknownMaritalStatus, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "unknown marital status"},
+	{Err: vObject.ErrEmptyRole, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "role is empty"},
+	{Err: vObject.ErrUnknownRole, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "unknown role"},
+	{Err: vObject.ErrEmptyOrderStatus, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "order status is empty"},
+	{Err: vObject.ErrUnknownOrderStatus, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "unknown order status"},
+	{Err: vObject.ErrNegativePrice, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "price must not be negative"},
+	{Err: vObject.ErrEmptyMovementReason, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "movement reason is empty"},
+	{Err: vObject.ErrUnknownMovementReason, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "unknown movement reason for operation type"},
+	{Err: vObject.ErrEmptyDocumentReference, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "document reference is empty"},
+	{Err: vObject.ErrDocumentReferenceTooLong, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "document reference is too long"},
+	{Err: entities.ErrEmptyStockQuantity, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "stock operation quantity must be positive"},
+	{Err: entities.ErrStockTransferSameWarehouse, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "stock transfer source and destination warehouses are the same"},
+	{Err: entities.ErrStockTransferEmptyQuantity, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "stock transfer quantity is empty"},
+	{Err: entities.ErrUserTokenInvalid, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "token is invalid, used or expired"},
+	{Err: getUserToken.ErrEmptyToken, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Message: "token is empty"},
+	{Err: ErrUnauthenticated, HTTPStatus: http.StatusUnauthorized, GRPCCode: codes.Unauthenticated, Message: "authentication required"},
+	{Err: auth.ErrInvalidToken, HTTPStatus: http.StatusUnauthorized, GRPCCode: codes.Unauthenticated, Message: "invalid token"},
+	{Err: entities.ErrInvalidCredentials, HTTPStatus: http.StatusUnauthorized, GRPCCode: codes.Unauthenticated, Message: "invalid email or password"},
+	{Err: entities.ErrSessionRecNotFound, HTTPStatus: http.StatusUnauthorized, GRPCCode: codes.Unauthenticated, Message: "session not found"},
+	{Err: entities.ErrSessionInactive, HTTPStatus: http.StatusUnauthorized, GRPCCode: codes.Unauthenticated, Message: "session is revoked or expired"},
+	{Err: entities.ErrRefreshTokenReused, HTTPStatus: http.StatusUnauthorized, GRPCCode: codes.Unauthenticated, Message: "refresh token has already been used"},
+	{Err: entities.ErrPermissionDenied, HTTPStatus: http.StatusForbidden, GRPCCode: codes.PermissionDenied, Message: "permission denied"},
+	{Err: entities.ErrOwnRoleChange, HTTPStatus: http.StatusForbidden, GRPCCode: codes.PermissionDenied, Message: "user cannot change own role"},
+	{Err: entities.ErrUserRecNotFound, HTTPStatus: http.StatusNotFound, GRPCCode: codes.NotFound, Message: "user not found"},
+	{Err: entities.ErrOrderRecNotFound, HTTPStatus: http.StatusNotFound, GRPCCode: codes.NotFound, Message: "order not found"},
+	{Err: entities.ErrProductRecNotFound, HTTPStatus: http.StatusNotFound, GRPCCode: codes.NotFound, Message: "product not found"},
+	{Err: entities.ErrStockTransferRecNotFound, HTTPStatus: http.StatusNotFound, GRPCCode: codes.NotFound, Message: "stock transfer not found"},
+	{Err: entities.ErrUserAlreadyExists, HTTPStatus: http.StatusConflict, GRPCCode: codes.AlreadyExists, Message: "user already exists"},
+	{Err: entities.ErrOrderStatusTransition, HTTPStatus: http.StatusConflict, GRPCCode: codes.FailedPrecondition, Message: "order status transition is not allowed"},
+	{Err: entities.ErrOrderNotEditable, HTTPStatus: http.StatusConflict, GRPCCode: codes.FailedPrecondition, Message: "order products can not be changed in this status"},
+	{Err: entities.ErrStockTransferNotInTransit, HTTPStatus: http.StatusConflict, GRPCCode: codes.FailedPrecondition, Message: "stock transfer is not in transit"},
+	{Err: entities.ErrNotEnoughProductIntStocks, HTTPStatus: http.StatusUnprocessableEntity, GRPCCode: codes.FailedPrecondition, Message: "not enough products in stocks"},
+	{Err: entities.ErrNotEnoughReservedProduct, HTTPStatus: http.StatusUnprocessableEntity, GRPCCode: codes.FailedPrecondition, Message: "not enough reserved products in stocks"},
+	{Err: entities.ErrWriteOffBelowReserved, HTTPStatus: http.StatusUnprocessableEntity, GRPCCode: codes.FailedPrecondition, Message: "write-off would drop available quantity below reserved"},
+	{Err: entities.ErrUserLocked, HTTPStatus: http.StatusTooManyRequests, GRPCCode: codes.ResourceExhausted, Message: "user is temporarily locked after failed login attempts"},
+}
+
+// Lookup описание ошибки err для клиента. Для несопоставленной ошибки возвращает false: она внутренняя,
+// и её текст клиенту не передаётся.
+func Lookup(err error) (Error, bool) {
+	for _, public := range publicErrors {
+		if errors.Is(err, public.Err) {
+			return public, true
+		}
+	}
+
+	return Error{}, false
+}
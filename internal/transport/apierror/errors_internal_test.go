@@ -0,0 +1,36 @@
+package apierror
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+// TestPublicErrors каждая ошибка, о которой REST сообщает клиенту, должна иметь и осмысленный код gRPC,
+// иначе gRPC-клиент получит Internal на ту же ошибку.
+func TestPublicErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, public := range publicErrors {
+		name := public.Err.Error()
+
+		assert.GreaterOrEqual(t, public.HTTPStatus, http.StatusBadRequest, name)
+		assert.Less(t, public.HTTPStatus, http.StatusInternalServerError, name)
+		assert.NotContains(t, []codes.Code{codes.OK, codes.Unknown, codes.Internal}, public.GRPCCode, name)
+		assert.NotEmpty(t, public.Message, name)
+
+		found, ok := Lookup(fmt.Errorf("[usecase error]: %w", public.Err))
+		assert.True(t, ok, name)
+		assert.Equal(t, public, found, name)
+	}
+}
+
+func TestLookup_Internal(t *testing.T) {
+	t.Parallel()
+
+	_, ok := Lookup(assert.AnError)
+	assert.False(t, ok)
+}
@@ -0,0 +1,149 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	googleGRPC "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	warehousev1 "github.com/smgladkovskiy/warehouse-task/api/warehouse/v1"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/auth"
+	revokeSession "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/auth/revoke_session"
+	"github.com/smgladkovskiy/warehouse-task/internal/transport/apierror"
+)
+
+// authorizationKey ключ метаданных с токеном доступа: "Bearer <access_token>".
+const authorizationKey = "authorization"
+
+// accessTokenRequest токен из метаданных authorization.
+type accessTokenRequest string
+
+func (r accessTokenRequest) GetAccessToken() string { return string(r) }
+
+type revokeRequest auth.Claims
+
+var _ revokeSession.Requestable = (*revokeRequest)(nil)
+
+func (r revokeRequest) GetSessionID() uuid.UUID { return r.SessionID }
+func (r revokeRequest) GetUserID() uuid.UUID    { return r.UserID }
+
+// actorID пользователь из токена доступа вызова.
+func actorID(ctx context.Context) (uuid.UUID, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return uuid.Nil, apierror.ErrUnauthenticated
+	}
+
+	return claims.UserID, nil
+}
+
+func newTokens(pair *auth.Pair) *warehousev1.Tokens {
+	return &warehousev1.Tokens{
+		AccessToken:      pair.AccessToken,
+		AccessExpiresAt:  timestamppb.New(pair.AccessExpiresAt),
+		RefreshToken:     pair.RefreshToken,
+		RefreshExpiresAt: timestamppb.New(pair.RefreshExpiresAt),
+	}
+}
+
+// UnaryAuthInterceptor проверяет токен доступа из метаданных authorization и сохраняет его данные
+// в контексте вызова. Вызов без токена передаётся дальше анонимным, обработчик сам решает, нужна ли аутентификация.
+func (s *Server) UnaryAuthInterceptor() googleGRPC.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *googleGRPC.UnaryServerInfo, handler googleGRPC.UnaryHandler) (any, error) {
+		values := metadata.ValueFromIncomingContext(ctx, authorizationKey)
+		if len(values) == 0 {
+			return handler(ctx, req)
+		}
+
+		token, ok := auth.BearerToken(values[0])
+		if !ok {
+			return nil, s.toStatus(ctx, info.FullMethod, auth.ErrInvalidToken)
+		}
+
+		claims, err := s.container.UseCases.Authenticate.Run(ctx, accessTokenRequest(token))
+		if err != nil {
+			return nil, s.toStatus(ctx, info.FullMethod, err)
+		}
+
+		ctx = auth.ContextWithClaims(ctx, *claims)
+
+		return handler(ctx, req)
+	}
+}
+
+func (s *Server) Login(ctx context.Context, req *warehousev1.LoginRequest) (*warehousev1.LoginResponse, error) {
+	pair, err := s.container.UseCases.LoginUser.Run(ctx, req)
+	if err != nil {
+		return nil, s.toStatus(ctx, "Login", err)
+	}
+
+	return &warehousev1.LoginResponse{Tokens: newTokens(pair)}, nil
+}
+
+func (s *Server) RefreshToken(
+	ctx context.Context,
+	req *warehousev1.RefreshTokenRequest,
+) (*warehousev1.RefreshTokenResponse, error) {
+	pair, err := s.container.UseCases.RefreshSession.Run(ctx, req)
+	if err != nil {
+		return nil, s.toStatus(ctx, "RefreshToken", err)
+	}
+
+	return &warehousev1.RefreshTokenResponse{Tokens: newTokens(pair)}, nil
+}
+
+func (s *Server) Logout(ctx context.Context, _ *warehousev1.LogoutRequest) (*warehousev1.LogoutResponse, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, s.toStatus(ctx, "Logout", apierror.ErrUnauthenticated)
+	}
+
+	if err := s.container.UseCases.RevokeSession.Run(ctx, revokeRequest(claims)); err != nil {
+		return nil, s.toStatus(ctx, "Logout", err)
+	}
+
+	return &warehousev1.LogoutResponse{}, nil
+}
+
+func (s *Server) RequestEmailVerification(
+	ctx context.Context,
+	req *warehousev1.RequestEmailVerificationRequest,
+) (*warehousev1.RequestEmailVerificationResponse, error) {
+	if err := s.container.UseCases.RequestEmailVerification.Run(ctx, req); err != nil {
+		return nil, s.toStatus(ctx, "RequestEmailVerification", err)
+	}
+
+	return &warehousev1.RequestEmailVerificationResponse{}, nil
+}
+
+func (s *Server) VerifyEmail(ctx context.Context, req *warehousev1.VerifyEmailRequest) (*warehousev1.VerifyEmailResponse, error) {
+	if err := s.container.UseCases.VerifyEmail.Run(ctx, req); err != nil {
+		return nil, s.toStatus(ctx, "VerifyEmail", err)
+	}
+
+	return &warehousev1.VerifyEmailResponse{}, nil
+}
+
+func (s *Server) RequestPasswordReset(
+	ctx context.Context,
+	req *warehousev1.RequestPasswordResetRequest,
+) (*warehousev1.RequestPasswordResetResponse, error) {
+	if err := s.container.UseCases.RequestPasswordReset.Run(ctx, req); err != nil {
+		return nil, s.toStatus(ctx, "RequestPasswordReset", err)
+	}
+
+	return &warehousev1.RequestPasswordResetResponse{}, nil
+}
+
+func (s *Server) ResetPassword(
+	ctx context.Context,
+	req *warehousev1.ResetPasswordRequest,
+) (*warehousev1.ResetPasswordResponse, error) {
+	if err := s.container.UseCases.ResetPassword.Run(ctx, req); err != nil {
+		return nil, s.toStatus(ctx, "ResetPassword", err)
+	}
+
+	return &warehousev1.ResetPasswordResponse{}, nil
+}
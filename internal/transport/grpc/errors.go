@@ -0,0 +1,42 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/transport/apierror"
+)
+
+var errInvalidArgument = errors.New("invalid argument")
+
+// errorCode код gRPC и текст для клиента. Ошибка разбора запроса показывается как есть: её текст
+// составлен транспортом из самого запроса. Несопоставленная ошибка — внутренняя, её текст клиенту не передаётся.
+func errorCode(err error) (codes.Code, string) {
+	if errors.Is(err, errInvalidArgument) {
+		return codes.InvalidArgument, err.Error()
+	}
+
+	if public, ok := apierror.Lookup(err); ok {
+		return public.GRPCCode, public.Message
+	}
+
+	return codes.Internal, "internal error"
+}
+
+// toStatus переводит ошибку в статус gRPC с постоянным текстом. Полная цепочка ошибки пишется в лог сервера:
+// внутренние ошибки — с уровнем error, ошибки клиента — с уровнем info.
+func (s *Server) toStatus(ctx context.Context, method string, err error) error {
+	code, message := errorCode(err)
+
+	if code == codes.Internal {
+		s.logger.Error(ctx, "request error", log.String("method", method), log.Err(err))
+	} else {
+		s.logger.Info(ctx, "request rejected", log.String("method", method), log.String("code", code.String()), log.Err(err))
+	}
+
+	return status.Error(code, message)
+}
@@ -0,0 +1,48 @@
+package grpc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	"github.com/smgladkovskiy/warehouse-task/internal/transport/apierror"
+)
+
+func TestErrorCode(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		err        error
+		expCode    codes.Code
+		expMessage string
+	}{
+		{err: fmt.Errorf("[usecase error]: %w", entities.ErrUserAlreadyExists), expCode: codes.AlreadyExists, expMessage: "user already exists"},
+		{err: fmt.Errorf("[usecase error]: %w", entities.ErrNotEnoughProductIntStocks), expCode: codes.FailedPrecondition, expMessage: "not enough products in stocks"},
+		{err: fmt.Errorf("[usecase error]: %w", entities.ErrOrderStatusTransition), expCode: codes.FailedPrecondition, expMessage: "order status transition is not allowed"},
+		{err: fmt.Errorf("[usecase error]: %w", entities.ErrWriteOffBelowReserved), expCode: codes.FailedPrecondition, expMessage: "write-off would drop available quantity below reserved"},
+		{err: fmt.Errorf("[usecase error]: %w", entities.ErrStockTransferNotInTransit), expCode: codes.FailedPrecondition, expMessage: "stock transfer is not in transit"},
+		{err: fmt.Errorf("[usecase error]: %w", entities.ErrStockTransferRecNotFound), expCode: codes.NotFound, expMessage: "stock transfer not found"},
+		{err: fmt.Errorf("[usecase error]: %w", vObject.ErrPasswordLen), expCode: codes.InvalidArgument, expMessage: "password is too short"},
+		{err: fmt.Errorf("[usecase error]: %w", vObject.ErrUnknownMovementReason), expCode: codes.InvalidArgument, expMessage: "unknown movement reason for operation type"},
+		{err: fmt.Errorf("%w: invalid order_id", errInvalidArgument), expCode: codes.InvalidArgument, expMessage: "invalid argument: invalid order_id"},
+		{err: fmt.Errorf("[addProductToOrder]: %w: order 42", entities.ErrOrderRecNotFound), expCode: codes.NotFound, expMessage: "order not found"},
+		{err: apierror.ErrUnauthenticated, expCode: codes.Unauthenticated, expMessage: "authentication required"},
+		{err: fmt.Errorf("[usecase error]: %w", entities.ErrRefreshTokenReused), expCode: codes.Unauthenticated, expMessage: "refresh token has already been used"},
+		{err: fmt.Errorf("[usecase error]: %w", entities.ErrUserLocked), expCode: codes.ResourceExhausted, expMessage: "user is temporarily locked after failed login attempts"},
+		{err: fmt.Errorf("[usecase error]: %w", entities.ErrUserTokenInvalid), expCode: codes.InvalidArgument, expMessage: "token is invalid, used or expired"},
+		{err: fmt.Errorf("[usecase error]: %w", entities.ErrPermissionDenied), expCode: codes.PermissionDenied, expMessage: "permission denied"},
+		{err: fmt.Errorf("[usecase error]: %w", entities.ErrOwnRoleChange), expCode: codes.PermissionDenied, expMessage: "user cannot change own role"},
+		{err: fmt.Errorf("[usecase error]: %w", vObject.ErrUnknownRole), expCode: codes.InvalidArgument, expMessage: "unknown role"},
+		{err: assert.AnError, expCode: codes.Internal, expMessage: "internal error"},
+	}
+
+	for _, tc := range tcs {
+		code, message := errorCode(tc.err)
+		assert.Equal(t, tc.expCode, code, tc.err.Error())
+		assert.Equal(t, tc.expMessage, message, tc.err.Error())
+	}
+}
@@ -0,0 +1,186 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	warehousev1 "github.com/smgladkovskiy/warehouse-task/api/warehouse/v1"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	addProductToOrder "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/order/add_product_to_order"
+	changeOrderStatus "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/order/change_order_status"
+	orderDetails "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/order/order_details"
+)
+
+type addProductRequest struct {
+	orderID   uuid.UUID
+	userID    uuid.UUID
+	productID uuid.UUID
+	quantity  uint64
+}
+
+var _ addProductToOrder.Requestable = (*addProductRequest)(nil)
+
+func (r addProductRequest) GetOrderID() uuid.UUID   { return r.orderID }
+func (r addProductRequest) GetUserID() uuid.UUID    { return r.userID }
+func (r addProductRequest) GetProductID() uuid.UUID { return r.productID }
+func (r addProductRequest) GetQuantity() uint64     { return r.quantity }
+
+func newAddProductRequest(ctx context.Context, req *warehousev1.AddProductToOrderRequest) (addProductRequest, error) {
+	var (
+		r   = addProductRequest{quantity: req.GetQuantity()}
+		err error
+	)
+
+	if r.userID, err = actorID(ctx); err != nil {
+		return r, err
+	}
+
+	if r.orderID, err = parseUUID(req.GetOrderId(), "order_id"); err != nil {
+		return r, err
+	}
+
+	if r.productID, err = parseUUID(req.GetProductId(), "product_id"); err != nil {
+		return r, err
+	}
+
+	return r, nil
+}
+
+type orderDetailsRequest struct {
+	actorID uuid.UUID
+	orderID uuid.UUID
+}
+
+var _ orderDetails.Requestable = (*orderDetailsRequest)(nil)
+
+func (r orderDetailsRequest) GetActorID() uuid.UUID { return r.actorID }
+func (r orderDetailsRequest) GetOrderID() uuid.UUID { return r.orderID }
+
+type changeStatusRequest struct {
+	orderID uuid.UUID
+	status  string
+	actorID uuid.UUID
+	reason  string
+}
+
+var _ changeOrderStatus.Requestable = (*changeStatusRequest)(nil)
+
+func (r changeStatusRequest) GetOrderID() uuid.UUID { return r.orderID }
+func (r changeStatusRequest) GetStatus() string     { return r.status }
+func (r changeStatusRequest) GetActorID() uuid.UUID { return r.actorID }
+func (r changeStatusRequest) GetReason() string     { return r.reason }
+
+// newChangeStatusRequest запрос от имени пользователя из токена. Без токена статус не меняется:
+// смена статуса от имени системы через API недоступна.
+func newChangeStatusRequest(ctx context.Context, req *warehousev1.ChangeOrderStatusRequest) (changeStatusRequest, error) {
+	var (
+		r   = changeStatusRequest{status: req.GetStatus(), reason: req.GetReason()}
+		err error
+	)
+
+	if r.actorID, err = actorID(ctx); err != nil {
+		return r, err
+	}
+
+	if r.orderID, err = parseUUID(req.GetOrderId(), "order_id"); err != nil {
+		return r, err
+	}
+
+	return r, nil
+}
+
+func newOrder(order *entities.Order) *warehousev1.Order {
+	resp := &warehousev1.Order{
+		Id:         order.ID.String(),
+		UserId:     order.UserID.String(),
+		Status:     order.Status.String(),
+		TotalPrice: int64(order.TotalPrice),
+		Products:   make([]*warehousev1.OrderProduct, 0, len(order.Products)),
+		CreatedAt:  timestamppb.New(order.CreatedAt),
+		UpdatedAt:  timestamppb.New(order.UpdatedAt),
+	}
+
+	for _, product := range order.Products {
+		if product.DeletedAt != nil {
+			continue
+		}
+
+		allocations := make([]*warehousev1.OrderProductAllocation, 0, len(product.Allocations))
+		for _, allocation := range product.Allocations {
+			allocations = append(allocations, &warehousev1.OrderProductAllocation{
+				WarehouseId: allocation.WarehouseID.String(),
+				Quantity:    allocation.Quantity.Uint64(),
+			})
+		}
+
+		resp.Products = append(resp.Products, &warehousev1.OrderProduct{
+			ProductId:   product.ProductID.String(),
+			Quantity:    product.Quantity.Uint64(),
+			Price:       int64(product.Price),
+			Allocations: allocations,
+		})
+	}
+
+	return resp
+}
+
+func (s *Server) AddProductToOrder(
+	ctx context.Context,
+	req *warehousev1.AddProductToOrderRequest,
+) (*warehousev1.AddProductToOrderResponse, error) {
+	r, err := newAddProductRequest(ctx, req)
+	if err != nil {
+		return nil, s.toStatus(ctx, "AddProductToOrder", err)
+	}
+
+	order, err := s.container.UseCases.AddProductToOrder.Run(ctx, r)
+	if err != nil {
+		return nil, s.toStatus(ctx, "AddProductToOrder", err)
+	}
+
+	return &warehousev1.AddProductToOrderResponse{Order: newOrder(order)}, nil
+}
+
+// GetOrder заказ пользователя из токена доступа. Чужой заказ покупателю не виден: NotFound.
+func (s *Server) GetOrder(ctx context.Context, req *warehousev1.GetOrderRequest) (*warehousev1.GetOrderResponse, error) {
+	userID, err := actorID(ctx)
+	if err != nil {
+		return nil, s.toStatus(ctx, "GetOrder", err)
+	}
+
+	orderID, err := parseUUID(req.GetOrderId(), "order_id")
+	if err != nil {
+		return nil, s.toStatus(ctx, "GetOrder", err)
+	}
+
+	order, err := s.findOrder(ctx, userID, orderID)
+	if err != nil {
+		return nil, s.toStatus(ctx, "GetOrder", err)
+	}
+
+	return &warehousev1.GetOrderResponse{Order: newOrder(order)}, nil
+}
+
+func (s *Server) ChangeOrderStatus(
+	ctx context.Context,
+	req *warehousev1.ChangeOrderStatusRequest,
+) (*warehousev1.ChangeOrderStatusResponse, error) {
+	r, err := newChangeStatusRequest(ctx, req)
+	if err != nil {
+		return nil, s.toStatus(ctx, "ChangeOrderStatus", err)
+	}
+
+	order, err := s.container.UseCases.ChangeOrderStatus.Run(ctx, r)
+	if err != nil {
+		return nil, s.toStatus(ctx, "ChangeOrderStatus", err)
+	}
+
+	return &warehousev1.ChangeOrderStatusResponse{Order: newOrder(order)}, nil
+}
+
+// findOrder заказ, который может просматривать пользователь actorID, см. orderDetails.UseCase.
+func (s *Server) findOrder(ctx context.Context, actorID, orderID uuid.UUID) (*entities.Order, error) {
+	return s.container.UseCases.OrderDetails.Run(ctx, orderDetailsRequest{actorID: actorID, orderID: orderID})
+}
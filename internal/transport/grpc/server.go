@@ -0,0 +1,57 @@
+// Package grpc implements the gRPC API of the service (see api/warehouse/v1).
+// Handlers map protobuf messages into use case Requestable DTOs and entities into messages.
+package grpc
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	googleGRPC "google.golang.org/grpc"
+
+	warehousev1 "github.com/smgladkovskiy/warehouse-task/api/warehouse/v1"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/ioc"
+)
+
+type Server struct {
+	warehousev1.UnimplementedWarehouseServiceServer
+
+	container *ioc.Container
+	logger    log.Logger
+}
+
+var _ warehousev1.WarehouseServiceServer = (*Server)(nil)
+
+func NewServer(container *ioc.Container, logger log.Logger) *Server {
+	if container == nil {
+		panic("nil container")
+	}
+
+	if logger == nil {
+		panic("nil logger")
+	}
+
+	return &Server{
+		container: container,
+		logger:    logger,
+	}
+}
+
+// Register регистрирует сервис на gRPC-сервере.
+func (s *Server) Register(registrar googleGRPC.ServiceRegistrar) {
+	warehousev1.RegisterWarehouseServiceServer(registrar, s)
+}
+
+// parseUUID разбирает идентификатор из поля name. Пустое значение допустимо и даёт uuid.Nil.
+func parseUUID(value, name string) (uuid.UUID, error) {
+	if value == "" {
+		return uuid.Nil, nil
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: invalid %s", errInvalidArgument, name)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,411 @@
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	googleGRPC "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	warehousev1 "github.com/smgladkovskiy/warehouse-task/api/warehouse/v1"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/mail"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryoptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/ioc"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/memory"
+	"github.com/smgladkovskiy/warehouse-task/internal/transport/grpc"
+)
+
+const bufSize = 1 << 20
+
+func newClient(t *testing.T, opts ...ioc.Option) (warehousev1.WarehouseServiceClient, *memory.Storage) {
+	t.Helper()
+
+	storage := memory.NewStorage()
+
+	c, err := ioc.NewContainer(ioc.NewMemoryImplementations(storage), opts...)
+	require.NoError(t, err)
+
+	listener := bufconn.Listen(bufSize)
+
+	service := grpc.NewServer(c, log.Named("test"))
+
+	server := googleGRPC.NewServer(googleGRPC.ChainUnaryInterceptor(service.UnaryAuthInterceptor()))
+	service.Register(server)
+
+	go func() { _ = server.Serve(listener) }()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := googleGRPC.NewClient("passthrough:///bufnet",
+		googleGRPC.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		googleGRPC.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return warehousev1.NewWarehouseServiceClient(conn), storage
+}
+
+func requireCode(t *testing.T, exp codes.Code, err error) {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	require.True(t, ok, "not a gRPC status: %v", err)
+	assert.Equal(t, exp, st.Code(), st.Message())
+}
+
+// signUp регистрирует пользователя с адресом email и входит под ним.
+// Возвращает его идентификатор и контекст с токеном доступа в метаданных.
+func signUp(t *testing.T, client warehousev1.WarehouseServiceClient, email string) (string, context.Context) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	registered, err := client.RegisterUser(ctx, &warehousev1.RegisterUserRequest{
+		Email:         email,
+		FirstName:     "Иван",
+		LastName:      "Иванов",
+		BirthDate:     "1990-05-17",
+		MaritalStatus: "single",
+		Password:      "secret_password",
+	})
+	require.NoError(t, err)
+
+	login, err := client.Login(ctx, &warehousev1.LoginRequest{Email: email, Password: "secret_password"})
+	require.NoError(t, err)
+
+	return registered.GetUser().GetId(), metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+login.GetTokens().GetAccessToken())
+}
+
+// setRole назначает пользователю роль в обход API, как это делается при первичной настройке.
+func setRole(t *testing.T, storage *memory.Storage, userID string, role vObject.Role) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	user, err := memory.NewUserRepository(storage).GetByID(ctx, queryoptions.NewUserQueryOptions(
+		queryoptions.WithUserID(vObject.NewUserIDFromUUIDUnsafe(uuid.MustParse(userID))),
+	))
+	require.NoError(t, err)
+
+	user.Role = role
+	storage.AddUser(ctx, *user)
+}
+
+func TestServer_RegisterUser(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, _ := newClient(t)
+
+	req := &warehousev1.RegisterUserRequest{
+		Email:         "test@test.ru",
+		FirstName:     "Иван",
+		LastName:      "Иванов",
+		BirthDate:     "1990-05-17",
+		MaritalStatus: "single",
+		Password:      "secret_password",
+	}
+
+	resp, err := client.RegisterUser(ctx, req)
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.GetUser().GetId())
+	assert.Equal(t, "Иван Иванов", resp.GetUser().GetFullName())
+	assert.Equal(t, "1990-05-17", resp.GetUser().GetBirthDate())
+	assert.Equal(t, "customer", resp.GetUser().GetRole())
+	assert.False(t, resp.GetUser().GetCreatedAt().AsTime().IsZero())
+
+	_, err = client.RegisterUser(ctx, req)
+	requireCode(t, codes.AlreadyExists, err)
+
+	req.Email, req.Password = "other@test.ru", "short"
+	_, err = client.RegisterUser(ctx, req)
+	requireCode(t, codes.InvalidArgument, err)
+
+	req.Password, req.BirthDate = "secret_password", "17.05.1990"
+	_, err = client.RegisterUser(ctx, req)
+	requireCode(t, codes.InvalidArgument, err)
+}
+
+func TestServer_Auth(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, _ := newClient(t)
+
+	_, err := client.RegisterUser(ctx, &warehousev1.RegisterUserRequest{
+		Email:         "test@test.ru",
+		FirstName:     "Иван",
+		LastName:      "Иванов",
+		BirthDate:     "1990-05-17",
+		MaritalStatus: "single",
+		Password:      "secret_password",
+	})
+	require.NoError(t, err)
+
+	_, err = client.Login(ctx, &warehousev1.LoginRequest{Email: "test@test.ru", Password: "wrong_password"})
+	requireCode(t, codes.Unauthenticated, err)
+
+	login, err := client.Login(ctx, &warehousev1.LoginRequest{Email: "test@test.ru", Password: "secret_password"})
+	require.NoError(t, err)
+	require.NotEmpty(t, login.GetTokens().GetAccessToken())
+
+	refreshed, err := client.RefreshToken(ctx, &warehousev1.RefreshTokenRequest{RefreshToken: login.GetTokens().GetRefreshToken()})
+	require.NoError(t, err)
+	assert.NotEqual(t, login.GetTokens().GetRefreshToken(), refreshed.GetTokens().GetRefreshToken())
+
+	withToken := func(token string) context.Context {
+		return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	}
+
+	_, err = client.Logout(ctx, &warehousev1.LogoutRequest{})
+	requireCode(t, codes.Unauthenticated, err)
+
+	_, err = client.GetOrder(withToken("broken"), &warehousev1.GetOrderRequest{OrderId: uuid.NewString()})
+	requireCode(t, codes.Unauthenticated, err)
+
+	_, err = client.GetOrder(withToken(refreshed.GetTokens().GetAccessToken()), &warehousev1.GetOrderRequest{OrderId: uuid.NewString()})
+	requireCode(t, codes.NotFound, err)
+
+	_, err = client.Logout(withToken(refreshed.GetTokens().GetAccessToken()), &warehousev1.LogoutRequest{})
+	require.NoError(t, err)
+
+	// после выхода токены сессии не принимаются
+	_, err = client.Logout(withToken(refreshed.GetTokens().GetAccessToken()), &warehousev1.LogoutRequest{})
+	requireCode(t, codes.Unauthenticated, err)
+
+	_, err = client.RefreshToken(ctx, &warehousev1.RefreshTokenRequest{RefreshToken: refreshed.GetTokens().GetRefreshToken()})
+	requireCode(t, codes.Unauthenticated, err)
+}
+
+func TestServer_EmailVerificationAndPasswordReset(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mailer := mail.NewMemory()
+	client, _ := newClient(t,
+		ioc.WithMailer(mailer),
+		ioc.WithEmailVerificationURL("https://shop.local/verify?token="),
+		ioc.WithPasswordResetURL("https://shop.local/reset?token="),
+	)
+
+	tokenFrom := func(linkURL string) string {
+		t.Helper()
+
+		msg, ok := mailer.Last("test@test.ru")
+		require.True(t, ok)
+
+		_, rest, found := strings.Cut(msg.Body, linkURL)
+		require.True(t, found)
+
+		token, _, _ := strings.Cut(rest, "\n")
+
+		return token
+	}
+
+	registered, err := client.RegisterUser(ctx, &warehousev1.RegisterUserRequest{
+		Email:         "test@test.ru",
+		FirstName:     "Иван",
+		LastName:      "Иванов",
+		BirthDate:     "1990-05-17",
+		MaritalStatus: "single",
+		Password:      "secret_password",
+	})
+	require.NoError(t, err)
+	assert.False(t, registered.GetUser().GetEmailVerified())
+
+	// письмо с подтверждением уходит сразу после регистрации
+	_, err = client.VerifyEmail(ctx, &warehousev1.VerifyEmailRequest{Token: "wrong"})
+	requireCode(t, codes.InvalidArgument, err)
+
+	_, err = client.VerifyEmail(ctx, &warehousev1.VerifyEmailRequest{Token: tokenFrom("https://shop.local/verify?token=")})
+	require.NoError(t, err)
+
+	_, err = client.RequestEmailVerification(ctx, &warehousev1.RequestEmailVerificationRequest{Email: "unknown@test.ru"})
+	require.NoError(t, err)
+
+	_, err = client.RequestPasswordReset(ctx, &warehousev1.RequestPasswordResetRequest{Email: "test@test.ru"})
+	require.NoError(t, err)
+
+	reset := &warehousev1.ResetPasswordRequest{Token: tokenFrom("https://shop.local/reset?token="), Password: "short"}
+	_, err = client.ResetPassword(ctx, reset)
+	requireCode(t, codes.InvalidArgument, err)
+
+	reset.Password = "new_secret_password"
+	_, err = client.ResetPassword(ctx, reset)
+	require.NoError(t, err)
+
+	_, err = client.ResetPassword(ctx, reset)
+	requireCode(t, codes.InvalidArgument, err)
+
+	_, err = client.ResetPassword(ctx, &warehousev1.ResetPasswordRequest{Password: "new_secret_password"})
+	requireCode(t, codes.InvalidArgument, err)
+
+	_, err = client.Login(ctx, &warehousev1.LoginRequest{Email: "test@test.ru", Password: "new_secret_password"})
+	require.NoError(t, err)
+}
+
+func TestServer_Orders(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, storage := newClient(t)
+
+	product := entities.Product{
+		ID:    vObject.NewProductIDFromUUIDUnsafe(uuid.New()),
+		Title: vObject.NewProductTitleUnsafe("product"),
+		Price: vObject.NewPriceUnsafe(1000),
+	}
+	warehouseID := vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New())
+
+	storage.AddProduct(ctx, product)
+	storage.AddStock(ctx, entities.Stock{
+		ProductID:         product.ID,
+		WarehouseID:       warehouseID,
+		AvailableQuantity: vObject.NewQuantityUnsafe(5),
+	})
+
+	userID, userCtx := signUp(t, client, "customer@test.ru")
+	_, strangerCtx := signUp(t, client, "stranger@test.ru")
+	operatorID, operatorCtx := signUp(t, client, "operator@test.ru")
+	setRole(t, storage, operatorID, vObject.RoleOperator)
+
+	req := &warehousev1.AddProductToOrderRequest{ProductId: product.ID.String(), Quantity: 6}
+	_, err := client.AddProductToOrder(userCtx, req)
+	requireCode(t, codes.FailedPrecondition, err)
+
+	req.ProductId = uuid.NewString()
+	_, err = client.AddProductToOrder(userCtx, req)
+	requireCode(t, codes.NotFound, err)
+
+	req.ProductId = "not-an-id"
+	_, err = client.AddProductToOrder(userCtx, req)
+	requireCode(t, codes.InvalidArgument, err)
+
+	// заказ оформляется на пользователя из токена, анонимно заказать нельзя
+	req.ProductId, req.Quantity = product.ID.String(), 2
+	_, err = client.AddProductToOrder(ctx, req)
+	requireCode(t, codes.Unauthenticated, err)
+
+	created, err := client.AddProductToOrder(userCtx, req)
+	require.NoError(t, err)
+
+	order := created.GetOrder()
+	assert.Equal(t, "created", order.GetStatus())
+	assert.Equal(t, userID, order.GetUserId())
+	require.Len(t, order.GetProducts(), 1)
+	assert.Equal(t, uint64(2), order.GetProducts()[0].GetQuantity())
+	assert.Equal(t, int64(1000), order.GetProducts()[0].GetPrice())
+
+	// чужой заказ для покупателя не существует
+	req.OrderId, req.Quantity = order.GetId(), 4
+	_, err = client.AddProductToOrder(strangerCtx, req)
+	requireCode(t, codes.NotFound, err)
+
+	updated, err := client.AddProductToOrder(userCtx, req)
+	require.NoError(t, err)
+	assert.Equal(t, order.GetId(), updated.GetOrder().GetId())
+	require.Len(t, updated.GetOrder().GetProducts(), 1)
+	assert.Equal(t, uint64(4), updated.GetOrder().GetProducts()[0].GetQuantity())
+
+	req.OrderId = uuid.NewString()
+	_, err = client.AddProductToOrder(userCtx, req)
+	requireCode(t, codes.NotFound, err)
+
+	got, err := client.GetOrder(userCtx, &warehousev1.GetOrderRequest{OrderId: order.GetId()})
+	require.NoError(t, err)
+	assert.Equal(t, updated.GetOrder().GetProducts()[0].GetQuantity(), got.GetOrder().GetProducts()[0].GetQuantity())
+
+	// заказ видят владелец и оператор; анонимному вызову нужен токен, другому покупателю заказ не виден
+	_, err = client.GetOrder(ctx, &warehousev1.GetOrderRequest{OrderId: order.GetId()})
+	requireCode(t, codes.Unauthenticated, err)
+
+	_, err = client.GetOrder(strangerCtx, &warehousev1.GetOrderRequest{OrderId: order.GetId()})
+	requireCode(t, codes.NotFound, err)
+
+	got, err = client.GetOrder(operatorCtx, &warehousev1.GetOrderRequest{OrderId: order.GetId()})
+	require.NoError(t, err)
+	assert.Equal(t, order.GetId(), got.GetOrder().GetId())
+
+	_, err = client.GetOrder(userCtx, &warehousev1.GetOrderRequest{OrderId: uuid.NewString()})
+	requireCode(t, codes.NotFound, err)
+
+	_, err = client.GetOrder(userCtx, &warehousev1.GetOrderRequest{})
+	requireCode(t, codes.InvalidArgument, err)
+
+	stocks, err := client.GetStocks(ctx, &warehousev1.GetStocksRequest{ProductId: product.ID.String()})
+	require.NoError(t, err)
+	require.Len(t, stocks.GetStocks(), 1)
+	assert.Equal(t, warehouseID.String(), stocks.GetStocks()[0].GetWarehouseId())
+	assert.Equal(t, uint64(5), stocks.GetStocks()[0].GetAvailableQuantity())
+	assert.Equal(t, uint64(4), stocks.GetStocks()[0].GetReservedQuantity())
+
+	_, err = client.GetStocks(ctx, &warehousev1.GetStocksRequest{})
+	requireCode(t, codes.InvalidArgument, err)
+
+	pay := &warehousev1.ChangeOrderStatusRequest{OrderId: order.GetId(), Status: "paid"}
+	_, err = client.ChangeOrderStatus(ctx, pay)
+	requireCode(t, codes.Unauthenticated, err)
+
+	// оплату подтверждает оператор, покупатель может только отменить свой заказ
+	_, err = client.ChangeOrderStatus(userCtx, pay)
+	requireCode(t, codes.PermissionDenied, err)
+
+	paid, err := client.ChangeOrderStatus(operatorCtx, pay)
+	require.NoError(t, err)
+	assert.Equal(t, "paid", paid.GetOrder().GetStatus())
+
+	_, err = client.ChangeOrderStatus(operatorCtx, &warehousev1.ChangeOrderStatusRequest{OrderId: order.GetId(), Status: "created"})
+	requireCode(t, codes.FailedPrecondition, err)
+
+	_, err = client.ChangeOrderStatus(operatorCtx, &warehousev1.ChangeOrderStatusRequest{OrderId: order.GetId(), Status: "lost"})
+	requireCode(t, codes.InvalidArgument, err)
+}
+
+func TestServer_GrantRole(t *testing.T) {
+	t.Parallel()
+
+	client, storage := newClient(t)
+
+	adminID, adminCtx := signUp(t, client, "admin@test.ru")
+	setRole(t, storage, adminID, vObject.RoleAdmin)
+
+	userID, userCtx := signUp(t, client, "user@test.ru")
+
+	req := &warehousev1.GrantRoleRequest{UserId: userID, Role: "operator"}
+	_, err := client.GrantRole(context.Background(), req)
+	requireCode(t, codes.Unauthenticated, err)
+
+	_, err = client.GrantRole(userCtx, req)
+	requireCode(t, codes.PermissionDenied, err)
+
+	_, err = client.GrantRole(adminCtx, &warehousev1.GrantRoleRequest{UserId: userID, Role: "owner"})
+	requireCode(t, codes.InvalidArgument, err)
+
+	_, err = client.GrantRole(adminCtx, &warehousev1.GrantRoleRequest{UserId: uuid.NewString(), Role: "operator"})
+	requireCode(t, codes.NotFound, err)
+
+	// администратор не может снять роль с самого себя
+	_, err = client.GrantRole(adminCtx, &warehousev1.GrantRoleRequest{UserId: adminID, Role: "customer"})
+	requireCode(t, codes.PermissionDenied, err)
+
+	resp, err := client.GrantRole(adminCtx, req)
+	require.NoError(t, err)
+	assert.Equal(t, userID, resp.GetUser().GetId())
+	assert.Equal(t, "operator", resp.GetUser().GetRole())
+}
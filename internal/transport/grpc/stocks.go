@@ -0,0 +1,44 @@
+package grpc
+
+import (
+	"context"
+
+	warehousev1 "github.com/smgladkovskiy/warehouse-task/api/warehouse/v1"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	getStocks "github.com/smgladkovskiy/warehouse-task/internal/service/queries/order/get_stocks"
+)
+
+func newStock(stock entities.Stock) *warehousev1.Stock {
+	return &warehousev1.Stock{
+		ProductId:         stock.ProductID.String(),
+		WarehouseId:       stock.WarehouseID.String(),
+		AvailableQuantity: stock.AvailableQuantity.Uint64(),
+		ReservedQuantity:  stock.ReservedQuantity.Uint64(),
+		InTransitQuantity: stock.InTransitQuantity.Uint64(),
+	}
+}
+
+func (s *Server) GetStocks(ctx context.Context, req *warehousev1.GetStocksRequest) (*warehousev1.GetStocksResponse, error) {
+	id, err := parseUUID(req.GetProductId(), "product_id")
+	if err != nil {
+		return nil, s.toStatus(ctx, "GetStocks", err)
+	}
+
+	productID, err := vObject.NewProductIDFromUUID(id)
+	if err != nil {
+		return nil, s.toStatus(ctx, "GetStocks", err)
+	}
+
+	stocks, err := s.container.Queries.GetStocks.Handle(ctx, getStocks.NewQueryByProductIDUnsafe(productID))
+	if err != nil {
+		return nil, s.toStatus(ctx, "GetStocks", err)
+	}
+
+	resp := &warehousev1.GetStocksResponse{Stocks: make([]*warehousev1.Stock, 0, len(stocks))}
+	for _, stock := range stocks {
+		resp.Stocks = append(resp.Stocks, newStock(stock))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,118 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	warehousev1 "github.com/smgladkovskiy/warehouse-task/api/warehouse/v1"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	grantRole "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/user/grant_role"
+	userRegistration "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/user/registration"
+)
+
+const dateLayout = time.DateOnly
+
+// registrationRequest сообщение с разобранной датой рождения. Остальные геттеры даёт само сообщение.
+type registrationRequest struct {
+	*warehousev1.RegisterUserRequest
+
+	birthDate time.Time
+}
+
+var _ userRegistration.Requestable = (*registrationRequest)(nil)
+
+func (r registrationRequest) GetBirthDate() time.Time { return r.birthDate }
+
+func newRegistrationRequest(req *warehousev1.RegisterUserRequest) (registrationRequest, error) {
+	birthDate, err := time.Parse(dateLayout, req.GetBirthDate())
+	if err != nil {
+		return registrationRequest{}, fmt.Errorf("%w: birth_date must be in %s format", errInvalidArgument, dateLayout)
+	}
+
+	return registrationRequest{RegisterUserRequest: req, birthDate: birthDate}, nil
+}
+
+type grantRoleRequest struct {
+	actorID uuid.UUID
+	userID  uuid.UUID
+	role    string
+}
+
+var _ grantRole.Requestable = (*grantRoleRequest)(nil)
+
+func (r grantRoleRequest) GetActorID() uuid.UUID { return r.actorID }
+func (r grantRoleRequest) GetUserID() uuid.UUID  { return r.userID }
+func (r grantRoleRequest) GetRole() string       { return r.role }
+
+func newGrantRoleRequest(ctx context.Context, req *warehousev1.GrantRoleRequest) (grantRoleRequest, error) {
+	var (
+		r   = grantRoleRequest{role: req.GetRole()}
+		err error
+	)
+
+	if r.actorID, err = actorID(ctx); err != nil {
+		return r, err
+	}
+
+	if r.userID, err = parseUUID(req.GetUserId(), "user_id"); err != nil {
+		return r, err
+	}
+
+	return r, nil
+}
+
+func newUser(user *entities.User) *warehousev1.User {
+	return &warehousev1.User{
+		Id:            user.ID.String(),
+		Email:         string(user.Email),
+		FirstName:     string(user.FirstName),
+		LastName:      string(user.LastName),
+		FullName:      user.FullName(),
+		BirthDate:     user.BirthDate.Time().Format(dateLayout),
+		MaritalStatus: string(user.MaritalStatus),
+		EmailVerified: user.IsEmailVerified(),
+		Role:          user.Role.String(),
+		CreatedAt:     timestamppb.New(user.CreatedAt),
+	}
+}
+
+func (s *Server) RegisterUser(
+	ctx context.Context,
+	req *warehousev1.RegisterUserRequest,
+) (*warehousev1.RegisterUserResponse, error) {
+	registration, err := newRegistrationRequest(req)
+	if err != nil {
+		return nil, s.toStatus(ctx, "RegisterUser", err)
+	}
+
+	user, err := s.container.UseCases.UserRegistration.Run(ctx, registration)
+	if err != nil {
+		return nil, s.toStatus(ctx, "RegisterUser", err)
+	}
+
+	// письмо с подтверждением не должно мешать регистрации: его можно запросить повторно
+	if err = s.container.UseCases.RequestEmailVerification.Run(ctx, req); err != nil {
+		s.logger.Warn(ctx, "email verification request error", log.String("method", "RegisterUser"), log.Err(err))
+	}
+
+	return &warehousev1.RegisterUserResponse{User: newUser(user)}, nil
+}
+
+func (s *Server) GrantRole(ctx context.Context, req *warehousev1.GrantRoleRequest) (*warehousev1.GrantRoleResponse, error) {
+	r, err := newGrantRoleRequest(ctx, req)
+	if err != nil {
+		return nil, s.toStatus(ctx, "GrantRole", err)
+	}
+
+	user, err := s.container.UseCases.GrantRole.Run(ctx, r)
+	if err != nil {
+		return nil, s.toStatus(ctx, "GrantRole", err)
+	}
+
+	return &warehousev1.GrantRoleResponse{User: newUser(user)}, nil
+}
@@ -0,0 +1,260 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/auth"
+	loginUser "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/auth/login_user"
+	refreshSession "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/auth/refresh_session"
+	revokeSession "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/auth/revoke_session"
+	requestEmailVerification "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/user/request_email_verification"
+	requestPasswordReset "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/user/request_password_reset"
+	resetPassword "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/user/reset_password"
+	verifyEmail "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/user/verify_email"
+	"github.com/smgladkovskiy/warehouse-task/internal/transport/apierror"
+)
+
+type loginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+var _ loginUser.Requestable = (*loginRequest)(nil)
+
+func (r loginRequest) GetEmail() string    { return r.Email }
+func (r loginRequest) GetPassword() string { return r.Password }
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+var _ refreshSession.Requestable = (*refreshRequest)(nil)
+
+func (r refreshRequest) GetRefreshToken() string { return r.RefreshToken }
+
+// accessTokenRequest токен из заголовка Authorization.
+type accessTokenRequest string
+
+func (r accessTokenRequest) GetAccessToken() string { return string(r) }
+
+type revokeRequest struct {
+	SessionID uuid.UUID
+	UserID    uuid.UUID
+}
+
+var _ revokeSession.Requestable = (*revokeRequest)(nil)
+
+func (r revokeRequest) GetSessionID() uuid.UUID { return r.SessionID }
+func (r revokeRequest) GetUserID() uuid.UUID    { return r.UserID }
+
+type emailRequest struct {
+	Email string `json:"email"`
+}
+
+var (
+	_ requestEmailVerification.Requestable = (*emailRequest)(nil)
+	_ requestPasswordReset.Requestable     = (*emailRequest)(nil)
+)
+
+func (r emailRequest) GetEmail() string { return r.Email }
+
+type confirmRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password,omitempty"`
+}
+
+var (
+	_ verifyEmail.Requestable   = (*confirmRequest)(nil)
+	_ resetPassword.Requestable = (*confirmRequest)(nil)
+)
+
+func (r confirmRequest) GetToken() string    { return r.Token }
+func (r confirmRequest) GetPassword() string { return r.Password }
+
+type tokenResponse struct {
+	TokenType        string    `json:"token_type"`
+	AccessToken      string    `json:"access_token"`
+	AccessExpiresAt  time.Time `json:"access_expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+func newTokenResponse(pair *auth.Pair) tokenResponse {
+	return tokenResponse{
+		TokenType:        "Bearer",
+		AccessToken:      pair.AccessToken,
+		AccessExpiresAt:  pair.AccessExpiresAt,
+		RefreshToken:     pair.RefreshToken,
+		RefreshExpiresAt: pair.RefreshExpiresAt,
+	}
+}
+
+// authenticate проверяет токен доступа из заголовка Authorization и сохраняет его данные в контексте запроса.
+// Запрос без заголовка передаётся дальше анонимным, обработчик сам решает, нужна ли аутентификация.
+func (h *Handler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		token, ok := auth.BearerToken(header)
+		if !ok {
+			h.writeError(w, r, auth.ErrInvalidToken)
+
+			return
+		}
+
+		claims, err := h.container.UseCases.Authenticate.Run(r.Context(), accessTokenRequest(token))
+		if err != nil {
+			h.writeError(w, r, err)
+
+			return
+		}
+
+		ctx := auth.ContextWithClaims(r.Context(), *claims)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (h *Handler) login(w http.ResponseWriter, r *http.Request) {
+	var req loginRequest
+	if err := decode(w, r, &req); err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	pair, err := h.container.UseCases.LoginUser.Run(r.Context(), req)
+	if err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, newTokenResponse(pair))
+}
+
+func (h *Handler) refreshToken(w http.ResponseWriter, r *http.Request) {
+	var req refreshRequest
+	if err := decode(w, r, &req); err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	pair, err := h.container.UseCases.RefreshSession.Run(r.Context(), req)
+	if err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, newTokenResponse(pair))
+}
+
+// actorID пользователь, выполняющий запрос, по данным токена доступа.
+func actorID(r *http.Request) (uuid.UUID, error) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		return uuid.Nil, apierror.ErrUnauthenticated
+	}
+
+	return claims.UserID, nil
+}
+
+func (h *Handler) logout(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		h.writeError(w, r, apierror.ErrUnauthenticated)
+
+		return
+	}
+
+	err := h.container.UseCases.RevokeSession.Run(r.Context(), revokeRequest{SessionID: claims.SessionID, UserID: claims.UserID})
+	if err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// requestEmailVerification отправляет письмо с токеном подтверждения email.
+// Ответ одинаков для зарегистрированных и неизвестных адресов.
+func (h *Handler) requestEmailVerification(w http.ResponseWriter, r *http.Request) {
+	var req emailRequest
+	if err := decode(w, r, &req); err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	if err := h.container.UseCases.RequestEmailVerification.Run(r.Context(), req); err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) verifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req confirmRequest
+	if err := decode(w, r, &req); err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	if err := h.container.UseCases.VerifyEmail.Run(r.Context(), req); err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// requestPasswordReset отправляет письмо с токеном сброса пароля.
+// Ответ одинаков для зарегистрированных и неизвестных адресов.
+func (h *Handler) requestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var req emailRequest
+	if err := decode(w, r, &req); err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	if err := h.container.UseCases.RequestPasswordReset.Run(r.Context(), req); err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) resetPassword(w http.ResponseWriter, r *http.Request) {
+	var req confirmRequest
+	if err := decode(w, r, &req); err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	if err := h.container.UseCases.ResetPassword.Run(r.Context(), req); err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
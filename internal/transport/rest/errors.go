@@ -0,0 +1,45 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/transport/apierror"
+)
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// errorStatus код ответа и текст для клиента. Ошибка разбора запроса показывается как есть: её текст
+// составлен транспортом из самого запроса. Несопоставленная ошибка — внутренняя, её текст клиенту не передаётся.
+func errorStatus(err error) (int, string) {
+	if errors.Is(err, errBadRequest) {
+		return http.StatusBadRequest, err.Error()
+	}
+
+	if public, ok := apierror.Lookup(err); ok {
+		return public.HTTPStatus, public.Message
+	}
+
+	return http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError)
+}
+
+// writeError отвечает кодом и постоянным текстом, соответствующими ошибке. Полная цепочка ошибки
+// пишется в лог сервера: внутренние ошибки — с уровнем error, ошибки клиента — с уровнем info.
+func (h *Handler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	status, message := errorStatus(err)
+
+	if status == http.StatusInternalServerError {
+		h.logger.Error(r.Context(), "request error", log.String("path", r.URL.Path), log.Err(err))
+	} else {
+		h.logger.Info(r.Context(), "request rejected", log.String("path", r.URL.Path), log.Int("status", status), log.Err(err))
+	}
+
+	if status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+
+	h.writeJSON(w, r, status, errorResponse{Error: message})
+}
@@ -0,0 +1,42 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	"github.com/smgladkovskiy/warehouse-task/internal/transport/apierror"
+)
+
+func TestErrorStatus(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		err        error
+		expStatus  int
+		expMessage string
+	}{
+		{err: fmt.Errorf("[usecase error]: %w", entities.ErrUserAlreadyExists), expStatus: http.StatusConflict, expMessage: "user already exists"},
+		{err: fmt.Errorf("[usecase error]: %w", entities.ErrNotEnoughProductIntStocks), expStatus: http.StatusUnprocessableEntity, expMessage: "not enough products in stocks"},
+		{err: fmt.Errorf("[usecase error]: %w", vObject.ErrPasswordLen), expStatus: http.StatusBadRequest, expMessage: "password is too short"},
+		{err: fmt.Errorf("%w: invalid json", errBadRequest), expStatus: http.StatusBadRequest, expMessage: "bad request: invalid json"},
+		{err: fmt.Errorf("[addProductToOrder]: %w: order 42", entities.ErrOrderRecNotFound), expStatus: http.StatusNotFound, expMessage: "order not found"},
+		{err: fmt.Errorf("[usecase error]: %w", entities.ErrInvalidCredentials), expStatus: http.StatusUnauthorized, expMessage: "invalid email or password"},
+		{err: apierror.ErrUnauthenticated, expStatus: http.StatusUnauthorized, expMessage: "authentication required"},
+		{err: fmt.Errorf("[usecase error]: %w", entities.ErrUserLocked), expStatus: http.StatusTooManyRequests, expMessage: "user is temporarily locked after failed login attempts"},
+		{err: fmt.Errorf("[usecase error]: %w", entities.ErrUserTokenInvalid), expStatus: http.StatusBadRequest, expMessage: "token is invalid, used or expired"},
+		{err: fmt.Errorf("[usecase error]: %w", entities.ErrPermissionDenied), expStatus: http.StatusForbidden, expMessage: "permission denied"},
+		{err: fmt.Errorf("[usecase error]: %w", vObject.ErrUnknownRole), expStatus: http.StatusBadRequest, expMessage: "unknown role"},
+		{err: assert.AnError, expStatus: http.StatusInternalServerError, expMessage: http.StatusText(http.StatusInternalServerError)},
+	}
+
+	for _, tc := range tcs {
+		status, message := errorStatus(tc.err)
+		assert.Equal(t, tc.expStatus, status, tc.err.Error())
+		assert.Equal(t, tc.expMessage, message, tc.err.Error())
+	}
+}
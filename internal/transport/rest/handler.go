@@ -0,0 +1,126 @@
+// Package rest implements the REST API of the service over net/http.
+// Handlers map requests into use case Requestable DTOs and entities into response DTOs.
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/tracing"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/ioc"
+)
+
+// maxBodySize ограничивает размер тела запроса.
+const maxBodySize = 1 << 20
+
+var errBadRequest = errors.New("bad request")
+
+type Handler struct {
+	container *ioc.Container
+	logger    log.Logger
+	mux       *http.ServeMux
+	next      http.Handler
+
+	middlewares []func(http.Handler) http.Handler
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// Option specifies configuration options of Handler.
+type Option func(*Handler)
+
+// WithMiddleware оборачивает обработчики маршрутов после аутентификации:
+// контекст запроса уже содержит данные токена (см. auth.ClaimsFromContext).
+// Middleware применяются в порядке передачи, первый оказывается внешним.
+func WithMiddleware(middleware func(http.Handler) http.Handler) Option {
+	return func(h *Handler) {
+		h.middlewares = append(h.middlewares, middleware)
+	}
+}
+
+func NewHandler(container *ioc.Container, logger log.Logger, opts ...Option) *Handler {
+	if container == nil {
+		panic("nil container")
+	}
+
+	if logger == nil {
+		panic("nil logger")
+	}
+
+	h := &Handler{
+		container: container,
+		logger:    logger,
+		mux:       http.NewServeMux(),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	h.mux.HandleFunc("POST /api/v1/auth/login", h.login)
+	h.mux.HandleFunc("POST /api/v1/auth/refresh", h.refreshToken)
+	h.mux.HandleFunc("POST /api/v1/auth/logout", h.logout)
+	h.mux.HandleFunc("POST /api/v1/auth/email-verification", h.requestEmailVerification)
+	h.mux.HandleFunc("POST /api/v1/auth/email-verification/confirm", h.verifyEmail)
+	h.mux.HandleFunc("POST /api/v1/auth/password-reset", h.requestPasswordReset)
+	h.mux.HandleFunc("POST /api/v1/auth/password-reset/confirm", h.resetPassword)
+	h.mux.HandleFunc("POST /api/v1/users", h.registerUser)
+	h.mux.HandleFunc("PUT /api/v1/users/{userID}/role", h.grantRole)
+	h.mux.HandleFunc("POST /api/v1/orders", h.createOrder)
+	h.mux.HandleFunc("GET /api/v1/orders/{orderID}", h.getOrder)
+	h.mux.HandleFunc("PUT /api/v1/orders/{orderID}/products/{productID}", h.setOrderProduct)
+
+	h.next = h.mux
+	for i := len(h.middlewares) - 1; i >= 0; i-- {
+		h.next = h.middlewares[i](h.next)
+	}
+
+	h.next = h.authenticate(h.next)
+
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// корневой спан запроса; имя — шаблон маршрута, чтобы не плодить имена по идентификаторам
+	_, pattern := h.mux.Handler(r)
+	if pattern == "" {
+		pattern = r.Method
+	}
+
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+	ctx, span := tracing.Start(ctx, pattern,
+		attribute.String("http.request.method", r.Method),
+		attribute.String("url.path", r.URL.Path),
+	)
+	defer span.End()
+
+	h.next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+func decode(w http.ResponseWriter, r *http.Request, dst any) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		return fmt.Errorf("%w: %s", errBadRequest, err.Error())
+	}
+
+	return nil
+}
+
+func (h *Handler) writeJSON(w http.ResponseWriter, r *http.Request, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		h.logger.Error(r.Context(), "response encoding error", log.Err(err))
+	}
+}
@@ -0,0 +1,421 @@
+package rest_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/auth"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/mail"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	queryoptions "github.com/smgladkovskiy/warehouse-task/internal/service/entities/query_options"
+	vObject "github.com/smgladkovskiy/warehouse-task/internal/service/entities/value_objects"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/ioc"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/repository/memory"
+	"github.com/smgladkovskiy/warehouse-task/internal/transport/rest"
+)
+
+type apiClient struct {
+	t      *testing.T
+	server *httptest.Server
+	mailer *mail.Memory
+}
+
+func (c apiClient) do(method, path string, body any, out any) int {
+	c.t.Helper()
+
+	return c.doAuth(method, path, "", body, out)
+}
+
+// doAuth выполняет запрос с токеном доступа token в заголовке Authorization.
+func (c apiClient) doAuth(method, path, token string, body any, out any) int {
+	c.t.Helper()
+
+	var reader bytes.Buffer
+	if s, ok := body.(string); ok {
+		reader.WriteString(s)
+	} else if body != nil {
+		require.NoError(c.t, json.NewEncoder(&reader).Encode(body))
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), method, c.server.URL+path, &reader)
+	require.NoError(c.t, err)
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.server.Client().Do(req)
+	require.NoError(c.t, err)
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		assert.Equal(c.t, "Bearer", resp.Header.Get("WWW-Authenticate"))
+	}
+
+	if resp.StatusCode == http.StatusNoContent {
+		return resp.StatusCode
+	}
+
+	assert.Equal(c.t, "application/json", resp.Header.Get("Content-Type"))
+
+	if out != nil {
+		require.NoError(c.t, json.NewDecoder(resp.Body).Decode(out))
+	}
+
+	return resp.StatusCode
+}
+
+func newAPIClient(t *testing.T, opts ...rest.Option) (apiClient, *memory.Storage) {
+	t.Helper()
+
+	storage := memory.NewStorage()
+
+	mailer := mail.NewMemory()
+
+	c, err := ioc.NewContainer(ioc.NewMemoryImplementations(storage), ioc.WithMailer(mailer))
+	require.NoError(t, err)
+
+	server := httptest.NewServer(rest.NewHandler(c, log.Named("test"), opts...))
+	t.Cleanup(server.Close)
+
+	return apiClient{t: t, server: server, mailer: mailer}, storage
+}
+
+func TestHandler_Users(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newAPIClient(t)
+
+	body := map[string]string{
+		"email":          "test@test.ru",
+		"first_name":     "Иван",
+		"last_name":      "Иванов",
+		"birth_date":     "1990-05-17",
+		"marital_status": "single",
+		"password":       "secret_password",
+	}
+
+	var user map[string]any
+	require.Equal(t, http.StatusCreated, client.do(http.MethodPost, "/api/v1/users", body, &user))
+	assert.NotEmpty(t, user["id"])
+	assert.Equal(t, "Иван Иванов", user["full_name"])
+	assert.Equal(t, "1990-05-17", user["birth_date"])
+	assert.Equal(t, "customer", user["role"])
+	assert.NotContains(t, user, "password")
+
+	var errResp map[string]string
+	assert.Equal(t, http.StatusConflict, client.do(http.MethodPost, "/api/v1/users", body, &errResp))
+	assert.Equal(t, "user already exists", errResp["error"])
+
+	body["email"], body["password"] = "other@test.ru", "short"
+	assert.Equal(t, http.StatusBadRequest, client.do(http.MethodPost, "/api/v1/users", body, &errResp))
+
+	body["password"], body["birth_date"] = "secret_password", "17.05.1990"
+	assert.Equal(t, http.StatusBadRequest, client.do(http.MethodPost, "/api/v1/users", body, &errResp))
+
+	assert.Equal(t, http.StatusBadRequest, client.do(http.MethodPost, "/api/v1/users", `{"unknown": 1}`, &errResp))
+}
+
+// signUp регистрирует пользователя с адресом email и входит под ним. Возвращает его идентификатор и токен доступа.
+func (c apiClient) signUp(email string) (string, string) {
+	c.t.Helper()
+
+	var user map[string]any
+	require.Equal(c.t, http.StatusCreated, c.do(http.MethodPost, "/api/v1/users", map[string]string{
+		"email":          email,
+		"first_name":     "Иван",
+		"last_name":      "Иванов",
+		"birth_date":     "1990-05-17",
+		"marital_status": "single",
+		"password":       "secret_password",
+	}, &user))
+
+	var tokens map[string]any
+	require.Equal(c.t, http.StatusOK, c.do(http.MethodPost, "/api/v1/auth/login",
+		map[string]string{"email": email, "password": "secret_password"}, &tokens))
+
+	return user["id"].(string), tokens["access_token"].(string)
+}
+
+// setRole назначает пользователю роль в обход API, как это делается при первичной настройке.
+func setRole(t *testing.T, storage *memory.Storage, userID string, role vObject.Role) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	user, err := memory.NewUserRepository(storage).GetByID(ctx, queryoptions.NewUserQueryOptions(
+		queryoptions.WithUserID(vObject.NewUserIDFromUUIDUnsafe(uuid.MustParse(userID))),
+	))
+	require.NoError(t, err)
+
+	user.Role = role
+	storage.AddUser(ctx, *user)
+}
+
+// lastCode код из последнего письма на адрес to: без адреса страницы письмо содержит только его.
+func (c apiClient) lastCode(to string) string {
+	c.t.Helper()
+
+	msg, ok := c.mailer.Last(to)
+	require.True(c.t, ok)
+
+	_, rest, found := strings.Cut(msg.Body, ": ")
+	require.True(c.t, found)
+
+	code, _, _ := strings.Cut(rest, "\n")
+
+	return code
+}
+
+func TestHandler_EmailVerificationAndPasswordReset(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newAPIClient(t)
+
+	var user map[string]any
+	require.Equal(t, http.StatusCreated, client.do(http.MethodPost, "/api/v1/users", map[string]string{
+		"email":          "test@test.ru",
+		"first_name":     "Иван",
+		"last_name":      "Иванов",
+		"birth_date":     "1990-05-17",
+		"marital_status": "single",
+		"password":       "secret_password",
+	}, &user))
+	assert.Equal(t, false, user["email_verified"])
+
+	// письмо с подтверждением уходит сразу после регистрации
+	verifyCode := client.lastCode("test@test.ru")
+
+	var errResp map[string]string
+	assert.Equal(t, http.StatusBadRequest, client.do(http.MethodPost, "/api/v1/auth/email-verification/confirm",
+		map[string]string{"token": ""}, &errResp))
+	assert.Equal(t, http.StatusBadRequest, client.do(http.MethodPost, "/api/v1/auth/email-verification/confirm",
+		map[string]string{"token": "wrong"}, &errResp))
+	assert.Equal(t, "token is invalid, used or expired", errResp["error"])
+	assert.Equal(t, http.StatusNoContent, client.do(http.MethodPost, "/api/v1/auth/email-verification/confirm",
+		map[string]string{"token": verifyCode}, nil))
+
+	// подтверждённому адресу письмо не отправляется, неизвестный адрес не раскрывается
+	sent := len(client.mailer.Messages())
+	assert.Equal(t, http.StatusNoContent, client.do(http.MethodPost, "/api/v1/auth/email-verification",
+		map[string]string{"email": "test@test.ru"}, nil))
+	assert.Equal(t, http.StatusNoContent, client.do(http.MethodPost, "/api/v1/auth/password-reset",
+		map[string]string{"email": "unknown@test.ru"}, nil))
+	assert.Len(t, client.mailer.Messages(), sent)
+
+	require.Equal(t, http.StatusNoContent, client.do(http.MethodPost, "/api/v1/auth/password-reset",
+		map[string]string{"email": "test@test.ru"}, nil))
+	resetCode := client.lastCode("test@test.ru")
+
+	reset := map[string]string{"token": resetCode, "password": "short"}
+	assert.Equal(t, http.StatusBadRequest, client.do(http.MethodPost, "/api/v1/auth/password-reset/confirm", reset, &errResp))
+
+	reset["password"] = "new_secret_password"
+	assert.Equal(t, http.StatusNoContent, client.do(http.MethodPost, "/api/v1/auth/password-reset/confirm", reset, nil))
+	assert.Equal(t, http.StatusBadRequest, client.do(http.MethodPost, "/api/v1/auth/password-reset/confirm", reset, &errResp))
+
+	credentials := map[string]string{"email": "test@test.ru", "password": "secret_password"}
+	assert.Equal(t, http.StatusUnauthorized, client.do(http.MethodPost, "/api/v1/auth/login", credentials, &errResp))
+
+	credentials["password"] = "new_secret_password"
+	assert.Equal(t, http.StatusOK, client.do(http.MethodPost, "/api/v1/auth/login", credentials, nil))
+}
+
+func TestHandler_Auth(t *testing.T) {
+	t.Parallel()
+
+	// middleware видят пользователя из токена
+	var users []string
+
+	client, _ := newAPIClient(t, rest.WithMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			users = append(users, auth.UserID(r.Context()))
+
+			next.ServeHTTP(w, r)
+		})
+	}))
+
+	require.Equal(t, http.StatusCreated, client.do(http.MethodPost, "/api/v1/users", map[string]string{
+		"email":          "test@test.ru",
+		"first_name":     "Иван",
+		"last_name":      "Иванов",
+		"birth_date":     "1990-05-17",
+		"marital_status": "single",
+		"password":       "secret_password",
+	}, nil))
+
+	credentials := map[string]string{"email": "test@test.ru", "password": "wrong_password"}
+
+	var errResp map[string]string
+	require.Equal(t, http.StatusUnauthorized, client.do(http.MethodPost, "/api/v1/auth/login", credentials, &errResp))
+	assert.Equal(t, "invalid email or password", errResp["error"])
+
+	credentials["password"] = "secret_password"
+
+	var tokens map[string]any
+	require.Equal(t, http.StatusOK, client.do(http.MethodPost, "/api/v1/auth/login", credentials, &tokens))
+	assert.Equal(t, "Bearer", tokens["token_type"])
+	require.NotEmpty(t, tokens["access_token"])
+	require.NotEmpty(t, tokens["refresh_token"])
+
+	// неверный токен отклоняется на любом маршруте, заказ без токена не отдаётся
+	assert.Equal(t, http.StatusUnauthorized, client.doAuth(http.MethodGet, "/api/v1/orders/"+uuid.NewString(), "broken", nil, &errResp))
+	assert.Equal(t, http.StatusUnauthorized, client.do(http.MethodGet, "/api/v1/orders/"+uuid.NewString(), nil, &errResp))
+
+	var refreshed map[string]any
+	refresh := map[string]any{"refresh_token": tokens["refresh_token"]}
+	require.Equal(t, http.StatusOK, client.do(http.MethodPost, "/api/v1/auth/refresh", refresh, &refreshed))
+	assert.NotEqual(t, tokens["refresh_token"], refreshed["refresh_token"])
+
+	accessToken := refreshed["access_token"].(string)
+	assert.Equal(t, http.StatusNotFound, client.doAuth(http.MethodGet, "/api/v1/orders/"+uuid.NewString(), accessToken, nil, &errResp))
+	require.NotEmpty(t, users)
+	assert.NotEmpty(t, users[len(users)-1])
+	assert.Empty(t, users[0])
+
+	assert.Equal(t, http.StatusUnauthorized, client.do(http.MethodPost, "/api/v1/auth/logout", nil, &errResp))
+	assert.Equal(t, http.StatusNoContent, client.doAuth(http.MethodPost, "/api/v1/auth/logout", accessToken, nil, nil))
+
+	// после выхода токены сессии не принимаются
+	assert.Equal(t, http.StatusUnauthorized, client.doAuth(http.MethodPost, "/api/v1/auth/logout", accessToken, nil, &errResp))
+	assert.Equal(t, "session is revoked or expired", errResp["error"])
+
+	refresh["refresh_token"] = refreshed["refresh_token"]
+	assert.Equal(t, http.StatusUnauthorized, client.do(http.MethodPost, "/api/v1/auth/refresh", refresh, &errResp))
+}
+
+func TestHandler_Orders(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, storage := newAPIClient(t)
+
+	product := entities.Product{
+		ID:    vObject.NewProductIDFromUUIDUnsafe(uuid.New()),
+		Title: vObject.NewProductTitleUnsafe("product"),
+		Price: vObject.NewPriceUnsafe(1000),
+	}
+	storage.AddProduct(ctx, product)
+	storage.AddStock(ctx, entities.Stock{
+		ProductID:         product.ID,
+		WarehouseID:       vObject.NewWarehouseIDFromUUIDUnsafe(uuid.New()),
+		AvailableQuantity: vObject.NewQuantityUnsafe(5),
+	})
+
+	_, token := client.signUp("customer@test.ru")
+	_, strangerToken := client.signUp("stranger@test.ru")
+
+	type order struct {
+		ID       string `json:"id"`
+		Status   string `json:"status"`
+		Products []struct {
+			ProductID   string `json:"product_id"`
+			Quantity    uint64 `json:"quantity"`
+			Price       int64  `json:"price"`
+			Allocations []struct {
+				Quantity uint64 `json:"quantity"`
+			} `json:"allocations"`
+		} `json:"products"`
+	}
+
+	var errResp map[string]string
+
+	body := map[string]any{"product_id": product.ID.UUID(), "quantity": 6}
+	assert.Equal(t, http.StatusUnprocessableEntity, client.doAuth(http.MethodPost, "/api/v1/orders", token, body, &errResp))
+
+	// заказ оформляется на пользователя из токена, анонимно заказать нельзя
+	body["product_id"], body["quantity"] = product.ID.UUID(), 2
+	assert.Equal(t, http.StatusUnauthorized, client.do(http.MethodPost, "/api/v1/orders", body, &errResp))
+
+	body["user_id"] = uuid.New()
+	assert.Equal(t, http.StatusBadRequest, client.doAuth(http.MethodPost, "/api/v1/orders", token, body, &errResp))
+	delete(body, "user_id")
+
+	body["product_id"] = uuid.New()
+	assert.Equal(t, http.StatusNotFound, client.doAuth(http.MethodPost, "/api/v1/orders", token, body, &errResp))
+
+	var created order
+	body["product_id"] = product.ID.UUID()
+	require.Equal(t, http.StatusCreated, client.doAuth(http.MethodPost, "/api/v1/orders", token, body, &created))
+	assert.Equal(t, "created", created.Status)
+	require.Len(t, created.Products, 1)
+	assert.Equal(t, uint64(2), created.Products[0].Quantity)
+	assert.Equal(t, int64(1000), created.Products[0].Price)
+
+	productPath := "/api/v1/orders/" + created.ID + "/products/" + product.ID.String()
+
+	// чужой заказ для покупателя не существует
+	assert.Equal(t, http.StatusNotFound, client.doAuth(http.MethodPut, productPath, strangerToken, map[string]any{"quantity": 4}, &errResp))
+	assert.Equal(t, "order not found", errResp["error"])
+
+	var updated order
+	require.Equal(t, http.StatusOK, client.doAuth(http.MethodPut, productPath, token, map[string]any{"quantity": 4}, &updated))
+	assert.Equal(t, created.ID, updated.ID)
+	require.Len(t, updated.Products, 1)
+	assert.Equal(t, uint64(4), updated.Products[0].Quantity)
+	require.Len(t, updated.Products[0].Allocations, 1)
+	assert.Equal(t, uint64(4), updated.Products[0].Allocations[0].Quantity)
+
+	var got order
+	require.Equal(t, http.StatusOK, client.doAuth(http.MethodGet, "/api/v1/orders/"+created.ID, token, nil, &got))
+	assert.Equal(t, updated, got)
+
+	// заказ видят владелец и оператор; анонимному запросу нужен токен, другому покупателю заказ не виден
+	assert.Equal(t, http.StatusUnauthorized, client.do(http.MethodGet, "/api/v1/orders/"+created.ID, nil, &errResp))
+	assert.Equal(t, http.StatusNotFound, client.doAuth(http.MethodGet, "/api/v1/orders/"+created.ID, strangerToken, nil, &errResp))
+	assert.Equal(t, "order not found", errResp["error"])
+
+	operatorID, operatorToken := client.signUp("operator@test.ru")
+	setRole(t, storage, operatorID, vObject.RoleOperator)
+
+	got = order{}
+	require.Equal(t, http.StatusOK, client.doAuth(http.MethodGet, "/api/v1/orders/"+created.ID, operatorToken, nil, &got))
+	assert.Equal(t, updated, got)
+
+	missingPath := "/api/v1/orders/" + uuid.NewString() + "/products/" + product.ID.String()
+	assert.Equal(t, http.StatusNotFound, client.doAuth(http.MethodPut, missingPath, token, map[string]any{"quantity": 1}, &errResp))
+	assert.Equal(t, http.StatusNotFound, client.doAuth(http.MethodGet, "/api/v1/orders/"+uuid.NewString(), token, nil, &errResp))
+	assert.Equal(t, http.StatusBadRequest, client.doAuth(http.MethodGet, "/api/v1/orders/not-an-id", token, nil, &errResp))
+}
+
+func TestHandler_Roles(t *testing.T) {
+	t.Parallel()
+
+	client, storage := newAPIClient(t)
+
+	adminID, adminToken := client.signUp("admin@test.ru")
+	setRole(t, storage, adminID, vObject.RoleAdmin)
+
+	userID, userToken := client.signUp("user@test.ru")
+	rolePath := "/api/v1/users/" + userID + "/role"
+
+	var errResp map[string]string
+	assert.Equal(t, http.StatusUnauthorized, client.do(http.MethodPut, rolePath, map[string]string{"role": "operator"}, &errResp))
+	assert.Equal(t, http.StatusForbidden, client.doAuth(http.MethodPut, rolePath, userToken, map[string]string{"role": "admin"}, &errResp))
+	assert.Equal(t, "permission denied", errResp["error"])
+	assert.Equal(t, http.StatusBadRequest, client.doAuth(http.MethodPut, rolePath, adminToken, map[string]string{"role": "owner"}, &errResp))
+	assert.Equal(t, "unknown role", errResp["error"])
+	assert.Equal(t, http.StatusNotFound, client.doAuth(http.MethodPut, "/api/v1/users/"+uuid.NewString()+"/role", adminToken,
+		map[string]string{"role": "operator"}, &errResp))
+
+	// администратор не может снять роль с самого себя
+	assert.Equal(t, http.StatusForbidden, client.doAuth(http.MethodPut, "/api/v1/users/"+adminID+"/role", adminToken,
+		map[string]string{"role": "customer"}, &errResp))
+	assert.Equal(t, "user cannot change own role", errResp["error"])
+
+	var user map[string]any
+	require.Equal(t, http.StatusOK, client.doAuth(http.MethodPut, rolePath, adminToken, map[string]string{"role": "operator"}, &user))
+	assert.Equal(t, userID, user["id"])
+	assert.Equal(t, "operator", user["role"])
+}
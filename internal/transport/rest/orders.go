@@ -0,0 +1,202 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	addProductToOrder "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/order/add_product_to_order"
+	orderDetails "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/order/order_details"
+)
+
+type addProductRequest struct {
+	OrderID   uuid.UUID `json:"-"`
+	UserID    uuid.UUID `json:"-"`
+	ProductID uuid.UUID `json:"product_id"`
+	Quantity  uint64    `json:"quantity"`
+}
+
+var _ addProductToOrder.Requestable = (*addProductRequest)(nil)
+
+func (r addProductRequest) GetOrderID() uuid.UUID   { return r.OrderID }
+func (r addProductRequest) GetUserID() uuid.UUID    { return r.UserID }
+func (r addProductRequest) GetProductID() uuid.UUID { return r.ProductID }
+func (r addProductRequest) GetQuantity() uint64     { return r.Quantity }
+
+type orderDetailsRequest struct {
+	actorID uuid.UUID
+	orderID uuid.UUID
+}
+
+var _ orderDetails.Requestable = (*orderDetailsRequest)(nil)
+
+func (r orderDetailsRequest) GetActorID() uuid.UUID { return r.actorID }
+func (r orderDetailsRequest) GetOrderID() uuid.UUID { return r.orderID }
+
+type orderProductAllocationResponse struct {
+	WarehouseID string `json:"warehouse_id"`
+	Quantity    uint64 `json:"quantity"`
+}
+
+type orderProductResponse struct {
+	ProductID   string                           `json:"product_id"`
+	Quantity    uint64                           `json:"quantity"`
+	Price       int64                            `json:"price"`
+	Allocations []orderProductAllocationResponse `json:"allocations"`
+}
+
+type orderResponse struct {
+	ID         string                 `json:"id"`
+	UserID     string                 `json:"user_id"`
+	Status     string                 `json:"status"`
+	TotalPrice int64                  `json:"total_price"`
+	Products   []orderProductResponse `json:"products"`
+	CreatedAt  time.Time              `json:"created_at"`
+	UpdatedAt  time.Time              `json:"updated_at"`
+}
+
+func newOrderResponse(order *entities.Order) orderResponse {
+	resp := orderResponse{
+		ID:         order.ID.String(),
+		UserID:     order.UserID.String(),
+		Status:     order.Status.String(),
+		TotalPrice: int64(order.TotalPrice),
+		Products:   make([]orderProductResponse, 0, len(order.Products)),
+		CreatedAt:  order.CreatedAt,
+		UpdatedAt:  order.UpdatedAt,
+	}
+
+	for _, product := range order.Products {
+		if product.DeletedAt != nil {
+			continue
+		}
+
+		allocations := make([]orderProductAllocationResponse, 0, len(product.Allocations))
+		for _, allocation := range product.Allocations {
+			allocations = append(allocations, orderProductAllocationResponse{
+				WarehouseID: allocation.WarehouseID.String(),
+				Quantity:    allocation.Quantity.Uint64(),
+			})
+		}
+
+		resp.Products = append(resp.Products, orderProductResponse{
+			ProductID:   product.ProductID.String(),
+			Quantity:    product.Quantity.Uint64(),
+			Price:       int64(product.Price),
+			Allocations: allocations,
+		})
+	}
+
+	return resp
+}
+
+// createOrder создаёт заказ с первым товаром на пользователя из токена доступа.
+func (h *Handler) createOrder(w http.ResponseWriter, r *http.Request) {
+	userID, err := actorID(r)
+	if err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	req := addProductRequest{UserID: userID}
+	if err = decode(w, r, &req); err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	order, err := h.container.UseCases.AddProductToOrder.Run(r.Context(), req)
+	if err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusCreated, newOrderResponse(order))
+}
+
+// setOrderProduct устанавливает количество товара в существующем заказе. Нулевое количество удаляет товар из заказа.
+func (h *Handler) setOrderProduct(w http.ResponseWriter, r *http.Request) {
+	userID, err := actorID(r)
+	if err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	orderID, err := pathUUID(r, "orderID")
+	if err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	productID, err := pathUUID(r, "productID")
+	if err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	var req addProductRequest
+	if err = decode(w, r, &req); err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	req.OrderID, req.UserID, req.ProductID = orderID, userID, productID
+
+	order, err := h.container.UseCases.AddProductToOrder.Run(r.Context(), req)
+	if err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, newOrderResponse(order))
+}
+
+// getOrder заказ пользователя из токена доступа. Чужой заказ покупателю не виден: ответ 404.
+func (h *Handler) getOrder(w http.ResponseWriter, r *http.Request) {
+	userID, err := actorID(r)
+	if err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	orderID, err := pathUUID(r, "orderID")
+	if err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	order, err := h.findOrder(r, userID, orderID)
+	if err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, newOrderResponse(order))
+}
+
+// findOrder заказ, который может просматривать пользователь actorID, см. orderDetails.UseCase.
+func (h *Handler) findOrder(r *http.Request, actorID, orderID uuid.UUID) (*entities.Order, error) {
+	return h.container.UseCases.OrderDetails.Run(r.Context(), orderDetailsRequest{actorID: actorID, orderID: orderID})
+}
+
+func pathUUID(r *http.Request, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(r.PathValue(name))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: invalid %s", errBadRequest, name)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,149 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smgladkovskiy/warehouse-task/internal/pkg/log"
+	"github.com/smgladkovskiy/warehouse-task/internal/service/entities"
+	grantRole "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/user/grant_role"
+	userRegistration "github.com/smgladkovskiy/warehouse-task/internal/service/usecases/user/registration"
+)
+
+const dateLayout = time.DateOnly
+
+// date дата в формате YYYY-MM-DD.
+type date time.Time
+
+func (d *date) UnmarshalJSON(data []byte) error {
+	t, err := time.Parse(dateLayout, strings.Trim(string(data), `"`))
+	if err != nil {
+		return fmt.Errorf("%w: date must be in %s format", errBadRequest, dateLayout)
+	}
+
+	*d = date(t)
+
+	return nil
+}
+
+func (d date) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + time.Time(d).Format(dateLayout) + `"`), nil
+}
+
+type registrationRequest struct {
+	Email         string `json:"email"`
+	FirstName     string `json:"first_name"`
+	LastName      string `json:"last_name"`
+	BirthDate     date   `json:"birth_date"`
+	MaritalStatus string `json:"marital_status"`
+	Password      string `json:"password"`
+}
+
+var _ userRegistration.Requestable = (*registrationRequest)(nil)
+
+func (r registrationRequest) GetEmail() string         { return r.Email }
+func (r registrationRequest) GetFirstName() string     { return r.FirstName }
+func (r registrationRequest) GetLastName() string      { return r.LastName }
+func (r registrationRequest) GetBirthDate() time.Time  { return time.Time(r.BirthDate) }
+func (r registrationRequest) GetMaritalStatus() string { return r.MaritalStatus }
+func (r registrationRequest) GetPassword() string      { return r.Password }
+
+type grantRoleRequest struct {
+	ActorID uuid.UUID `json:"-"`
+	UserID  uuid.UUID `json:"-"`
+	Role    string    `json:"role"`
+}
+
+var _ grantRole.Requestable = (*grantRoleRequest)(nil)
+
+func (r grantRoleRequest) GetActorID() uuid.UUID { return r.ActorID }
+func (r grantRoleRequest) GetUserID() uuid.UUID  { return r.UserID }
+func (r grantRoleRequest) GetRole() string       { return r.Role }
+
+type userResponse struct {
+	ID            string    `json:"id"`
+	Email         string    `json:"email"`
+	FirstName     string    `json:"first_name"`
+	LastName      string    `json:"last_name"`
+	FullName      string    `json:"full_name"`
+	BirthDate     date      `json:"birth_date"`
+	MaritalStatus string    `json:"marital_status"`
+	EmailVerified bool      `json:"email_verified"`
+	Role          string    `json:"role"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func newUserResponse(user *entities.User) userResponse {
+	return userResponse{
+		ID:            user.ID.String(),
+		Email:         string(user.Email),
+		FirstName:     string(user.FirstName),
+		LastName:      string(user.LastName),
+		FullName:      user.FullName(),
+		BirthDate:     date(user.BirthDate.Time()),
+		MaritalStatus: string(user.MaritalStatus),
+		EmailVerified: user.IsEmailVerified(),
+		Role:          user.Role.String(),
+		CreatedAt:     user.CreatedAt,
+	}
+}
+
+func (h *Handler) registerUser(w http.ResponseWriter, r *http.Request) {
+	var req registrationRequest
+	if err := decode(w, r, &req); err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	user, err := h.container.UseCases.UserRegistration.Run(r.Context(), req)
+	if err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	// письмо с подтверждением не должно мешать регистрации: его можно запросить повторно
+	if err = h.container.UseCases.RequestEmailVerification.Run(r.Context(), emailRequest{Email: req.Email}); err != nil {
+		h.logger.Warn(r.Context(), "email verification request error", log.Err(err))
+	}
+
+	h.writeJSON(w, r, http.StatusCreated, newUserResponse(user))
+}
+
+// grantRole назначает пользователю роль. Доступно администраторам.
+func (h *Handler) grantRole(w http.ResponseWriter, r *http.Request) {
+	adminID, err := actorID(r)
+	if err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	userID, err := pathUUID(r, "userID")
+	if err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	req := grantRoleRequest{ActorID: adminID, UserID: userID}
+	if err = decode(w, r, &req); err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	user, err := h.container.UseCases.GrantRole.Run(r.Context(), req)
+	if err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, newUserResponse(user))
+}